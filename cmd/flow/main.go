@@ -29,10 +29,15 @@ import (
 	"github.com/onflow/flow-cli/internal/cadence"
 	"github.com/onflow/flow-cli/internal/collections"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/completion"
 	"github.com/onflow/flow-cli/internal/config"
+	"github.com/onflow/flow-cli/internal/doctor"
 	"github.com/onflow/flow-cli/internal/emulator"
 	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/generate"
+	"github.com/onflow/flow-cli/internal/history"
 	"github.com/onflow/flow-cli/internal/keys"
+	"github.com/onflow/flow-cli/internal/ops"
 	"github.com/onflow/flow-cli/internal/project"
 	"github.com/onflow/flow-cli/internal/quick"
 	"github.com/onflow/flow-cli/internal/scripts"
@@ -46,6 +51,7 @@ import (
 	"github.com/onflow/flow-cli/internal/transactions"
 	"github.com/onflow/flow-cli/internal/util"
 	"github.com/onflow/flow-cli/internal/version"
+	"github.com/onflow/flow-cli/internal/wait"
 )
 
 func main() {
@@ -64,6 +70,11 @@ func main() {
 	tools.DevWallet.AddToParent(cmd)
 	tools.Flowser.AddToParent(cmd)
 	test.TestCommand.AddToParent(cmd)
+	history.Command.AddToParent(cmd)
+	doctor.Command.AddToParent(cmd)
+	wait.Command.AddToParent(cmd)
+	events.IndexCommand.AddToParent(cmd)
+	project.ServeCommand.AddToParent(cmd)
 
 	// super commands
 	super.SetupCommand.AddToParent(cmd)
@@ -84,7 +95,12 @@ func main() {
 	cmd.AddCommand(project.Cmd)
 	cmd.AddCommand(config.Cmd)
 	cmd.AddCommand(signatures.Cmd)
+	cmd.AddCommand(ops.Cmd)
 	cmd.AddCommand(snapshot.Cmd)
+	cmd.AddCommand(generate.Cmd)
+	cmd.AddCommand(tools.Cmd)
+
+	completion.AddInstallCommand(cmd)
 
 	command.InitFlags(cmd)
 	cmd.AddGroup(&cobra.Group{
@@ -112,8 +128,15 @@ func main() {
 		Title: "🔒 Flow Security",
 	})
 
+	command.Localize(cmd)
 	cmd.SetUsageTemplate(command.UsageTemplate)
 
+	args, err := command.ExpandAliases(cmd, os.Args[1:], settings.Aliases())
+	if err != nil {
+		util.Exit(1, err.Error())
+	}
+	cmd.SetArgs(args)
+
 	if err := cmd.Execute(); err != nil {
 		util.Exit(1, err.Error())
 	}
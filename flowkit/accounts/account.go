@@ -60,7 +60,7 @@ func ToConfig(accounts Accounts) config.Accounts {
 }
 
 func fromConfig(account config.Account) (*Account, error) {
-	key, err := keyFromConfig(account.Key)
+	key, err := KeyFromConfig(account.Key)
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +151,13 @@ func (a Accounts) ByAddress(address flow.Address) (*Account, error) {
 }
 
 // ByName get an account by name or returns and error if no account found
+//
+// Names with the env: prefix are not looked up in the accounts but created from the environment variables.
 func (a Accounts) ByName(name string) (*Account, error) {
+	if IsEnvAccount(name) {
+		return FromEnv(name)
+	}
+
 	for i := range a {
 		if a[i].Name == name {
 			return &a[i], nil
@@ -165,7 +171,7 @@ func (a Accounts) ByName(name string) (*Account, error) {
 func (a *Accounts) AddOrUpdate(account *Account) {
 	for i, acc := range *a {
 		if acc.Name == account.Name {
-			(*a)[i] = acc
+			(*a)[i] = *account
 			return
 		}
 	}
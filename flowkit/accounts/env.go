@@ -0,0 +1,112 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// EnvPrefix marks account names resolved from the environment variables instead of the configuration.
+//
+// The account env:DEPLOYER is read from the following environment variables:
+// FLOW_DEPLOYER_ADDRESS and FLOW_DEPLOYER_PRIVATE_KEY are required,
+// FLOW_DEPLOYER_SIG_ALGO, FLOW_DEPLOYER_HASH_ALGO and FLOW_DEPLOYER_KEY_INDEX are optional
+// and default to ECDSA_P256, SHA3_256 and 0.
+const EnvPrefix = "env:"
+
+// IsEnvAccount checks whether the account name refers to an account defined by the environment variables.
+func IsEnvAccount(name string) bool {
+	return strings.HasPrefix(name, EnvPrefix)
+}
+
+// envVariable returns the name of the environment variable holding the field of the account.
+func envVariable(name string, field string) string {
+	name = strings.ToUpper(strings.ReplaceAll(strings.TrimPrefix(name, EnvPrefix), "-", "_"))
+	return fmt.Sprintf("FLOW_%s_%s", name, field)
+}
+
+// FromEnv creates the account from the environment variables, the name must start with the env: prefix.
+func FromEnv(name string) (*Account, error) {
+	if !IsEnvAccount(name) || strings.TrimPrefix(name, EnvPrefix) == "" {
+		return nil, fmt.Errorf("invalid environment account %s, expected format env:NAME", name)
+	}
+
+	lookup := func(field string, required bool) (string, error) {
+		variable := envVariable(name, field)
+		value := strings.TrimSpace(os.Getenv(variable))
+		if value == "" && required {
+			return "", fmt.Errorf("environment variable %s is required for account %s", variable, name)
+		}
+		return value, nil
+	}
+
+	address, err := lookup("ADDRESS", true)
+	if err != nil {
+		return nil, err
+	}
+	if flow.HexToAddress(address) == flow.EmptyAddress {
+		return nil, fmt.Errorf("invalid address %s in %s", address, envVariable(name, "ADDRESS"))
+	}
+
+	sigAlgo := crypto.ECDSA_P256
+	if value, _ := lookup("SIG_ALGO", false); value != "" {
+		sigAlgo = crypto.StringToSignatureAlgorithm(value)
+		if sigAlgo == crypto.UnknownSignatureAlgorithm {
+			return nil, fmt.Errorf("invalid signature algorithm %s in %s", value, envVariable(name, "SIG_ALGO"))
+		}
+	}
+
+	hashAlgo := crypto.SHA3_256
+	if value, _ := lookup("HASH_ALGO", false); value != "" {
+		hashAlgo = crypto.StringToHashAlgorithm(value)
+		if hashAlgo == crypto.UnknownHashAlgorithm {
+			return nil, fmt.Errorf("invalid hash algorithm %s in %s", value, envVariable(name, "HASH_ALGO"))
+		}
+	}
+
+	index := 0
+	if value, _ := lookup("KEY_INDEX", false); value != "" {
+		index, err = strconv.Atoi(value)
+		if err != nil || index < 0 {
+			return nil, fmt.Errorf("invalid key index %s in %s", value, envVariable(name, "KEY_INDEX"))
+		}
+	}
+
+	key, err := lookup("PRIVATE_KEY", true)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := crypto.DecodePrivateKeyHex(sigAlgo, strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		// don't include the key value in the error
+		return nil, fmt.Errorf("invalid private key in %s: the key must be a hex encoded %s key", envVariable(name, "PRIVATE_KEY"), sigAlgo)
+	}
+
+	return &Account{
+		Name:    name,
+		Address: flow.HexToAddress(address),
+		Key:     NewHexKeyFromPrivateKey(index, hashAlgo, privateKey),
+	}, nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envPrivateKey = "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+
+func Test_EnvAccount(t *testing.T) {
+	t.Run("Resolve by name", func(t *testing.T) {
+		t.Setenv("FLOW_DEPLOYER_ADDRESS", "0xf8d6e0586b0a20c7")
+		t.Setenv("FLOW_DEPLOYER_PRIVATE_KEY", envPrivateKey)
+
+		account, err := Accounts{}.ByName("env:DEPLOYER")
+		require.NoError(t, err)
+
+		assert.Equal(t, "env:DEPLOYER", account.Name)
+		assert.Equal(t, flow.HexToAddress("f8d6e0586b0a20c7"), account.Address)
+		assert.Equal(t, 0, account.Key.Index())
+		assert.Equal(t, crypto.ECDSA_P256, account.Key.SigAlgo())
+		assert.Equal(t, crypto.SHA3_256, account.Key.HashAlgo())
+	})
+
+	t.Run("Optional variables", func(t *testing.T) {
+		t.Setenv("FLOW_CI_SIGNER_ADDRESS", "01cf0e2f2f715450")
+		t.Setenv("FLOW_CI_SIGNER_PRIVATE_KEY", "0x"+envPrivateKey)
+		t.Setenv("FLOW_CI_SIGNER_SIG_ALGO", "ECDSA_secp256k1")
+		t.Setenv("FLOW_CI_SIGNER_HASH_ALGO", "SHA2_256")
+		t.Setenv("FLOW_CI_SIGNER_KEY_INDEX", "2")
+
+		account, err := FromEnv("env:ci-signer")
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, account.Key.Index())
+		assert.Equal(t, crypto.ECDSA_secp256k1, account.Key.SigAlgo())
+		assert.Equal(t, crypto.SHA2_256, account.Key.HashAlgo())
+	})
+
+	t.Run("Fail missing variables", func(t *testing.T) {
+		_, err := FromEnv("env:MISSING")
+		assert.EqualError(t, err, "environment variable FLOW_MISSING_ADDRESS is required for account env:MISSING")
+
+		t.Setenv("FLOW_MISSING_ADDRESS", "f8d6e0586b0a20c7")
+		_, err = FromEnv("env:MISSING")
+		assert.EqualError(t, err, "environment variable FLOW_MISSING_PRIVATE_KEY is required for account env:MISSING")
+	})
+
+	t.Run("Fail invalid values", func(t *testing.T) {
+		t.Setenv("FLOW_INVALID_ADDRESS", "f8d6e0586b0a20c7")
+		t.Setenv("FLOW_INVALID_PRIVATE_KEY", "secret")
+
+		_, err := FromEnv("env:INVALID")
+		assert.EqualError(t, err, "invalid private key in FLOW_INVALID_PRIVATE_KEY: the key must be a hex encoded ECDSA_P256 key")
+		assert.NotContains(t, err.Error(), "secret")
+
+		t.Setenv("FLOW_INVALID_KEY_INDEX", "-1")
+		_, err = FromEnv("env:INVALID")
+		assert.EqualError(t, err, "invalid key index -1 in FLOW_INVALID_KEY_INDEX")
+
+		_, err = FromEnv("env:")
+		assert.EqualError(t, err, "invalid environment account env:, expected format env:NAME")
+	})
+}
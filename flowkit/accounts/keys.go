@@ -71,7 +71,8 @@ var _ Key = &KMSKey{}
 
 var _ Key = &BIP44Key{}
 
-func keyFromConfig(accountKeyConf config.AccountKey) (Key, error) {
+// KeyFromConfig creates the key implementation matching the key type of the account key configuration.
+func KeyFromConfig(accountKeyConf config.AccountKey) (Key, error) {
 	switch accountKeyConf.Type {
 	case config.KeyTypeHex:
 		return hexKeyFromConfig(accountKeyConf)
@@ -337,7 +338,7 @@ func (f *FileKey) PrivateKey() (*crypto.PrivateKey, error) {
 		if err != nil {
 			return nil, fmt.Errorf("could not load the key for the account from provided location %s: %w", f.location, err)
 		}
-		pkey, err := crypto.DecodePrivateKeyHex(f.sigAlgo, strings.TrimPrefix(string(key), "0x"))
+		pkey, err := crypto.DecodePrivateKeyHex(f.sigAlgo, strings.TrimPrefix(strings.TrimSpace(string(key)), "0x"))
 		if err != nil {
 			return nil, fmt.Errorf("could not decode the key from provided location %s: %w", f.location, err)
 		}
@@ -349,6 +350,7 @@ func (f *FileKey) PrivateKey() (*crypto.PrivateKey, error) {
 func (f *FileKey) ToConfig() config.AccountKey {
 	return config.AccountKey{
 		Type:     config.KeyTypeFile,
+		Index:    f.index,
 		SigAlgo:  f.sigAlgo,
 		HashAlgo: f.hashAlgo,
 		Location: f.location,
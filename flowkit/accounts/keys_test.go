@@ -47,7 +47,7 @@ func Test_KMS_Keys(t *testing.T) {
 func Test_File_key(t *testing.T) {
 	confKey := config.AccountKey{
 		Type:     config.KeyTypeFile,
-		Index:    0,
+		Index:    2,
 		SigAlgo:  config.DefaultSigAlgo,
 		HashAlgo: config.DefaultHashAlgo,
 		Location: "./test.pkey",
@@ -21,6 +21,7 @@ package arguments
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/onflow/cadence"
@@ -30,6 +31,7 @@ import (
 	"github.com/onflow/cadence/runtime/cmd"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -68,45 +70,155 @@ func ParseJSON(args string) ([]cadence.Value, error) {
 	return cadenceArgs, nil
 }
 
-// ParseWithoutType parses arguments passed as string slice based on the Cadence code.
+// compositeTypes are the JSON-Cadence types encoded with an ID and fields.
+var compositeTypes = map[string]bool{
+	"Struct":   true,
+	"Resource": true,
+	"Event":    true,
+	"Contract": true,
+	"Enum":     true,
+}
+
+// ValidateJSON checks the JSON array with Cadence arguments doesn't contain fields unknown to the JSON-Cadence format.
 //
-// Using the Cadence code required arguments are computed and then extracted from passed slice of arguments.
-// The fileName argument is optional and can be empty if not present.
-func ParseWithoutType(args []string, code []byte, fileName string) (scriptArgs []cadence.Value, err error) {
+// Unknown fields are ignored when parsing the arguments, which can hide mistakes like misspelled field names.
+func ValidateJSON(args string) error {
+	var values []json.RawMessage
+	err := json.Unmarshal([]byte(args), &values)
+	if err != nil {
+		return err
+	}
 
-	resultArgs := make([]cadence.Value, 0, len(args))
+	for i, value := range values {
+		err = validateJSONValue(value, fmt.Sprintf("argument %d", i))
+		if err != nil {
+			return err
+		}
+	}
 
-	codes := map[common.Location][]byte{}
-	location := common.StringLocation(fileName)
-	program, must := cmd.PrepareProgram(code, location, codes)
-	checker, _ := cmd.PrepareChecker(program, location, codes, nil, must)
+	return nil
+}
 
-	var parameterList []*ast.Parameter
+// validateJSONValue checks the fields of a JSON-Cadence encoded value and all the values it contains.
+//
+// Malformed values are not reported since they already fail parsing.
+func validateJSONValue(raw json.RawMessage, path string) error {
+	var value map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+	if err := checkJSONFields(value, path, "type", "value"); err != nil {
+		return err
+	}
 
-	functionDeclaration := sema.FunctionEntryPointDeclaration(program)
-	if functionDeclaration != nil {
-		if functionDeclaration.ParameterList != nil {
-			parameterList = functionDeclaration.ParameterList.Parameters
+	var valueType string
+	_ = json.Unmarshal(value["type"], &valueType)
+	content := value["value"]
+
+	switch {
+	case valueType == "Optional":
+		if content == nil || string(content) == "null" {
+			return nil
 		}
-	}
+		return validateJSONValue(content, path)
 
-	transactionDeclaration := program.TransactionDeclarations()
-	if len(transactionDeclaration) == 1 {
-		if transactionDeclaration[0].ParameterList != nil {
-			parameterList = transactionDeclaration[0].ParameterList.Parameters
+	case valueType == "Array":
+		var elements []json.RawMessage
+		_ = json.Unmarshal(content, &elements)
+		for i, element := range elements {
+			if err := validateJSONValue(element, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
 		}
+
+	case valueType == "Dictionary":
+		var entries []map[string]json.RawMessage
+		_ = json.Unmarshal(content, &entries)
+		for i, entry := range entries {
+			entryPath := fmt.Sprintf("%s[%d]", path, i)
+			if err := checkJSONFields(entry, entryPath, "key", "value"); err != nil {
+				return err
+			}
+			if err := validateJSONValue(entry["key"], entryPath+".key"); err != nil {
+				return err
+			}
+			if err := validateJSONValue(entry["value"], entryPath+".value"); err != nil {
+				return err
+			}
+		}
+
+	case compositeTypes[valueType]:
+		var composite map[string]json.RawMessage
+		_ = json.Unmarshal(content, &composite)
+		if err := checkJSONFields(composite, path, "id", "fields"); err != nil {
+			return err
+		}
+
+		var fields []map[string]json.RawMessage
+		_ = json.Unmarshal(composite["fields"], &fields)
+		for i, field := range fields {
+			fieldPath := fmt.Sprintf("%s.fields[%d]", path, i)
+			if err := checkJSONFields(field, fieldPath, "name", "value"); err != nil {
+				return err
+			}
+			if err := validateJSONValue(field["value"], fieldPath); err != nil {
+				return err
+			}
+		}
+
+	case valueType == "Path":
+		var pathValue map[string]json.RawMessage
+		_ = json.Unmarshal(content, &pathValue)
+		return checkJSONFields(pathValue, path, "domain", "identifier")
+
+	case valueType == "Capability":
+		var capability map[string]json.RawMessage
+		_ = json.Unmarshal(content, &capability)
+		return checkJSONFields(capability, path, "id", "path", "address", "borrowType")
 	}
 
-	contractDeclaration := program.SoleContractDeclaration()
-	if contractDeclaration != nil {
-		contractInitializer := contractDeclaration.Members.Initializers()
-		if len(contractInitializer) == 1 {
-			if contractInitializer[0].FunctionDeclaration.ParameterList != nil {
-				parameterList = contractInitializer[0].FunctionDeclaration.ParameterList.Parameters
+	return nil
+}
+
+// checkJSONFields returns an error for the first field of the object that is not one of the known fields.
+func checkJSONFields(object map[string]json.RawMessage, path string, known ...string) error {
+	fields := make([]string, 0, len(object))
+	for field := range object {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		isKnown := false
+		for _, k := range known {
+			if field == k {
+				isKnown = true
+				break
 			}
 		}
+		if !isKnown {
+			return fmt.Errorf("%s contains unknown field \"%s\"", path, field)
+		}
 	}
 
+	return nil
+}
+
+// ParseWithoutType parses arguments passed as string slice based on the Cadence code.
+//
+// Using the Cadence code required arguments are computed and then extracted from passed slice of arguments.
+// Arguments prefixed with the name of a registered parser, e.g. `Duration:2d`, are converted by the parser.
+// The fileName argument is optional and can be empty if not present.
+func ParseWithoutType(args []string, code []byte, fileName string) (scriptArgs []cadence.Value, err error) {
+
+	resultArgs := make([]cadence.Value, 0, len(args))
+
+	codes := map[common.Location][]byte{}
+	location := common.StringLocation(fileName)
+	program, must := cmd.PrepareProgram(code, location, codes)
+	checker, _ := cmd.PrepareChecker(program, location, codes, nil, must)
+
+	parameterList := entryPointParameters(program)
 	if parameterList == nil {
 		return resultArgs, nil
 	}
@@ -124,6 +236,16 @@ func ParseWithoutType(args []string, code []byte, fileName string) (scriptArgs [
 		astType := parameterList[index].TypeAnnotation.Type
 		semaType := checker.ConvertType(astType)
 
+		if parser, literal, ok := customParser(argumentString); ok {
+			value, err := parser(literal, semaType)
+			if err != nil {
+				return nil, fmt.Errorf("argument `%s` is not valid: %w", parameterList[index].Identifier, err)
+			}
+
+			resultArgs = append(resultArgs, value)
+			continue
+		}
+
 		if semaType == sema.StringType {
 			if !strings.HasPrefix(argumentString, "\"") {
 				argumentString = ast.QuoteString(argumentString)
@@ -147,3 +269,49 @@ func ParseWithoutType(args []string, code []byte, fileName string) (scriptArgs [
 	}
 	return resultArgs, nil
 }
+
+// ParameterNames returns the names of the parameters declared by the entry point of the Cadence code, in order.
+func ParameterNames(code []byte) ([]string, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	for _, param := range entryPointParameters(program) {
+		names = append(names, param.Identifier.Identifier)
+	}
+	return names, nil
+}
+
+// entryPointParameters returns the parameters declared by the program entry point, which is either
+// the script main function, the transaction declaration or the contract initializer.
+func entryPointParameters(program *ast.Program) []*ast.Parameter {
+	var parameterList []*ast.Parameter
+
+	functionDeclaration := sema.FunctionEntryPointDeclaration(program)
+	if functionDeclaration != nil {
+		if functionDeclaration.ParameterList != nil {
+			parameterList = functionDeclaration.ParameterList.Parameters
+		}
+	}
+
+	transactionDeclaration := program.TransactionDeclarations()
+	if len(transactionDeclaration) == 1 {
+		if transactionDeclaration[0].ParameterList != nil {
+			parameterList = transactionDeclaration[0].ParameterList.Parameters
+		}
+	}
+
+	contractDeclaration := program.SoleContractDeclaration()
+	if contractDeclaration != nil {
+		contractInitializer := contractDeclaration.Members.Initializers()
+		if len(contractInitializer) == 1 {
+			if contractInitializer[0].FunctionDeclaration.ParameterList != nil {
+				parameterList = contractInitializer[0].FunctionDeclaration.ParameterList.Parameters
+			}
+		}
+	}
+
+	return parameterList
+}
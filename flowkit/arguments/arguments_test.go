@@ -252,3 +252,37 @@ func Test_ParseJSON(t *testing.T) {
 	assert.Equal(t, `"Hello World"`, values[0].String())
 	assert.Equal(t, "String", values[0].Type().ID())
 }
+
+func Test_ValidateJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		err   string
+	}{{
+		input: `[{"type": "String", "value": "Hello World"}, {"type": "Optional", "value": null}]`,
+	}, {
+		input: `[{"type": "Dictionary", "value": [{"key": {"type": "String", "value": "a"}, "value": {"type": "Int", "value": "1"}}]}]`,
+	}, {
+		input: `[{"type": "String", "vaule": "Hello World"}]`,
+		err:   `argument 0 contains unknown field "vaule"`,
+	}, {
+		input: `[{"type": "Int", "value": "1"}, {"type": "Array", "value": [{"type": "Int", "value": "1", "comment": "one"}]}]`,
+		err:   `argument 1[0] contains unknown field "comment"`,
+	}, {
+		input: `[{"type": "Struct", "value": {"id": "A.01.Foo.Bar", "fields": [{"name": "a", "value": {"type": "Optional", "value": {"type": "Int", "value": "1", "x": 1}}}]}}]`,
+		err:   `argument 0.fields[0] contains unknown field "x"`,
+	}, {
+		input: `[{"type": "Path", "value": {"domain": "storage", "identifier": "foo", "kind": "private"}}]`,
+		err:   `argument 0 contains unknown field "kind"`,
+	}}
+
+	for _, test := range tests {
+		err := ValidateJSON(test.input)
+		if test.err == "" {
+			assert.NoError(t, err, test.input)
+		} else {
+			assert.EqualError(t, err, test.err, test.input)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arguments
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/sema"
+)
+
+// Parser converts the literal of a custom argument to a Cadence value of the parameter type.
+type Parser func(literal string, paramType sema.Type) (cadence.Value, error)
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = map[string]Parser{
+		"Duration": parseDuration,
+	}
+)
+
+// RegisterParser registers the parser for arguments prefixed with the name and a colon, e.g. `Duration:2d`.
+//
+// Registering a parser with an existing name replaces it. Arguments that should be passed
+// literally can be quoted to skip the custom parsers.
+func RegisterParser(name string, parser Parser) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	parsers[name] = parser
+}
+
+// customParser returns the parser registered for the argument prefix and the literal following it.
+func customParser(argument string) (Parser, string, bool) {
+	name, literal, found := strings.Cut(argument, ":")
+	if !found {
+		return nil, "", false
+	}
+
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+	parser, ok := parsers[name]
+	return parser, literal, ok
+}
+
+// parseDuration converts a duration like `2d`, `1d12h` or `90s` to seconds.
+func parseDuration(literal string, paramType sema.Type) (cadence.Value, error) {
+	var days int64
+	if d, rest, found := strings.Cut(literal, "d"); found {
+		parsed, err := strconv.ParseInt(d, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %s", literal)
+		}
+		days = parsed
+		literal = rest
+	}
+
+	duration := time.Duration(days) * 24 * time.Hour
+	if literal != "" {
+		parsed, err := time.ParseDuration(literal)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %s", literal)
+		}
+		duration += parsed
+	}
+	if duration < 0 {
+		return nil, fmt.Errorf("duration must not be negative")
+	}
+
+	switch paramType {
+	case sema.UFix64Type:
+		seconds := strconv.FormatFloat(duration.Seconds(), 'f', -1, 64)
+		if !strings.Contains(seconds, ".") {
+			seconds += ".0"
+		}
+		return cadence.NewUFix64(seconds)
+	case sema.UInt64Type:
+		if duration%time.Second != 0 {
+			return nil, fmt.Errorf("duration must be whole seconds for type UInt64")
+		}
+		return cadence.NewUInt64(uint64(duration / time.Second)), nil
+	}
+
+	return nil, fmt.Errorf("duration can't be used for type %s, expected UFix64 or UInt64", paramType.QualifiedString())
+}
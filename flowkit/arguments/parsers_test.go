@@ -0,0 +1,83 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arguments
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CustomParsers(t *testing.T) {
+	t.Parallel()
+
+	code := []byte(`
+		transaction(period: UFix64, timeout: UInt64, note: String) {
+			prepare(signer: AuthAccount) {}
+		}
+	`)
+
+	t.Run("Duration", func(t *testing.T) {
+		t.Parallel()
+
+		args, err := ParseWithoutType([]string{"Duration:1d12h", "Duration:90s", "\"Duration:2d\""}, code, "")
+		require.NoError(t, err)
+
+		period, _ := cadence.NewUFix64("129600.0")
+		assert.Equal(t, []cadence.Value{
+			period,
+			cadence.NewUInt64(90),
+			cadence.String("Duration:2d"),
+		}, args)
+	})
+
+	t.Run("Fail invalid duration", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseWithoutType([]string{"Duration:2x", "Duration:1s", "foo"}, code, "")
+		assert.EqualError(t, err, "argument `period` is not valid: invalid duration 2x")
+	})
+
+	t.Run("Fail fractional seconds", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseWithoutType([]string{"Duration:1s", "Duration:1.5s", "foo"}, code, "")
+		assert.EqualError(t, err, "argument `timeout` is not valid: duration must be whole seconds for type UInt64")
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		t.Parallel()
+
+		RegisterParser("Upper", func(literal string, paramType sema.Type) (cadence.Value, error) {
+			if paramType != sema.StringType {
+				return nil, fmt.Errorf("expected String")
+			}
+			return cadence.String(strings.ToUpper(literal)), nil
+		})
+
+		args, err := ParseWithoutType([]string{"1.0", "1", "Upper:hello"}, code, "")
+		require.NoError(t, err)
+		assert.Equal(t, cadence.String("HELLO"), args[2])
+	})
+}
@@ -0,0 +1,116 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arguments
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// RedactedValue is displayed instead of the value of a secret argument.
+const RedactedValue = "<redacted>"
+
+// Secrets contains positions of the arguments that are sensitive and should not be displayed.
+//
+// Secret arguments are still submitted to the network unchanged, they are only redacted from any output.
+type Secrets map[int]bool
+
+// ParseSecrets resolves secret argument references to the argument positions.
+//
+// Each reference can be a zero-based argument index or a name of the parameter declared in the Cadence code.
+func ParseSecrets(refs []string, code []byte) (Secrets, error) {
+	secrets := make(Secrets)
+	if len(refs) == 0 {
+		return secrets, nil
+	}
+
+	var names []string
+	for _, ref := range refs {
+		if index, err := strconv.Atoi(ref); err == nil {
+			if index < 0 {
+				return nil, fmt.Errorf("invalid secret argument index %d", index)
+			}
+			secrets[index] = true
+			continue
+		}
+
+		if names == nil {
+			var err error
+			names, err = ParameterNames(code)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse code for secret arguments: %w", err)
+			}
+		}
+
+		found := false
+		for i, name := range names {
+			if name == ref {
+				secrets[i] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("secret argument '%s' is not declared as a parameter", ref)
+		}
+	}
+
+	return secrets, nil
+}
+
+// AddParameters marks the arguments of the parameters declared in the Cadence code with the provided names as secret.
+//
+// Unlike ParseSecrets, names which aren't declared by the code are ignored, so the same names can be used for all the
+// transactions of a project, like the secret arguments defined in the configuration.
+func (s Secrets) AddParameters(names []string, code []byte) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	params, err := ParameterNames(code)
+	if err != nil {
+		return fmt.Errorf("failed to parse code for secret arguments: %w", err)
+	}
+
+	for i, param := range params {
+		for _, name := range names {
+			if param == name {
+				s[i] = true
+			}
+		}
+	}
+
+	return nil
+}
+
+// Redact returns the argument at the provided index or a redacted placeholder if the argument is secret.
+func (s Secrets) Redact(index int, argument string) string {
+	if s[index] {
+		return RedactedValue
+	}
+	return argument
+}
+
+// RedactAll returns all the arguments with the secret arguments replaced by a redacted placeholder.
+func (s Secrets) RedactAll(args []string) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = s.Redact(i, arg)
+	}
+	return redacted
+}
@@ -0,0 +1,89 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arguments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseSecrets(t *testing.T) {
+	t.Parallel()
+
+	code := []byte(`
+		transaction(name: String, password: String) {
+			prepare(signer: AuthAccount) {}
+		}
+	`)
+
+	t.Run("By name and index", func(t *testing.T) {
+		t.Parallel()
+
+		secrets, err := ParseSecrets([]string{"password", "0"}, code)
+		require.NoError(t, err)
+		assert.Equal(t, Secrets{0: true, 1: true}, secrets)
+	})
+
+	t.Run("Redact", func(t *testing.T) {
+		t.Parallel()
+
+		secrets, err := ParseSecrets([]string{"password"}, code)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", RedactedValue}, secrets.RedactAll([]string{"alice", "hunter2"}))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		secrets, err := ParseSecrets(nil, code)
+		require.NoError(t, err)
+		assert.Equal(t, "foo", secrets.Redact(0, "foo"))
+	})
+
+	t.Run("Fail unknown name", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseSecrets([]string{"token"}, code)
+		assert.EqualError(t, err, "secret argument 'token' is not declared as a parameter")
+	})
+
+	t.Run("Fail negative index", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseSecrets([]string{"-1"}, code)
+		assert.EqualError(t, err, "invalid secret argument index -1")
+	})
+}
+
+func Test_SecretsAddParameters(t *testing.T) {
+	t.Parallel()
+
+	code := []byte(`
+		transaction(name: String, password: String) {
+			prepare(signer: AuthAccount) {}
+		}
+	`)
+
+	secrets := make(Secrets)
+	err := secrets.AddParameters([]string{"password", "privateKey"}, code)
+	require.NoError(t, err)
+	assert.Equal(t, Secrets{1: true}, secrets)
+}
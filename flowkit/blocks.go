@@ -0,0 +1,229 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+// progressBlocks is the operation of the progress events reported while iterating a block range.
+const progressBlocks = "blocks"
+
+// defaultBatchSize is the number of blocks in a batch if the batch size is not provided.
+const defaultBatchSize = 250
+
+// BlockRange is a batch of consecutive block heights, both start and end are inclusive.
+type BlockRange struct {
+	// Index of the batch in the iterated range, starting at zero.
+	Index int
+	Start uint64
+	End   uint64
+}
+
+// BlockRangeOptions define how the block range is iterated.
+type BlockRangeOptions struct {
+	// BatchSize is the number of blocks in each batch, defaults to 250.
+	BatchSize uint64
+	// Concurrency is the number of batches processed at the same time, defaults to 1.
+	Concurrency int
+	// Retries is the number of times a failed batch is retried before the iteration fails.
+	Retries int
+	// RetryDelay is the delay before a failed batch is retried.
+	RetryDelay time.Duration
+	// OnProgress is called after each processed batch with the number of processed and total blocks.
+	OnProgress func(processed uint64, total uint64)
+}
+
+// BatchFunc processes a batch of blocks using the gateway of the iterator.
+type BatchFunc func(ctx context.Context, gw gateway.Gateway, batch BlockRange) error
+
+// BlockIterator iterates a range of block heights in batches.
+type BlockIterator struct {
+	gateway gateway.Gateway
+	start   uint64
+	end     uint64
+	options BlockRangeOptions
+}
+
+// BlockRangeIterator returns an iterator over the block heights from start to end inclusive,
+// the gateway is passed to the function processing each batch.
+func BlockRangeIterator(gw gateway.Gateway, start uint64, end uint64, options BlockRangeOptions) *BlockIterator {
+	if options.BatchSize == 0 {
+		options.BatchSize = defaultBatchSize
+	}
+	if options.Concurrency < 1 {
+		options.Concurrency = 1
+	}
+
+	return &BlockIterator{
+		gateway: gw,
+		start:   start,
+		end:     end,
+		options: options,
+	}
+}
+
+// Batches returns the batches the block range is split into.
+func (it *BlockIterator) Batches() ([]BlockRange, error) {
+	if it.end < it.start {
+		return nil, fmt.Errorf("cannot have end height (%d) of block range less that start height (%d)", it.end, it.start)
+	}
+
+	batches := make([]BlockRange, 0)
+	for start := it.start; ; start += it.options.BatchSize {
+		batchEnd := start + it.options.BatchSize - 1
+		if batchEnd > it.end || batchEnd < start { // also guards against overflow
+			batchEnd = it.end
+		}
+		batches = append(batches, BlockRange{Index: len(batches), Start: start, End: batchEnd})
+
+		if batchEnd == it.end {
+			return batches, nil
+		}
+	}
+}
+
+// ForEach calls the function for each batch of the block range.
+//
+// Batches are started in order, but with concurrency higher than one they are processed at the same time,
+// so the function must be safe for concurrent use and can use the batch index to order the results.
+// A failed batch is retried as configured, and if it still fails the remaining batches are cancelled
+// and the error is returned.
+func (it *BlockIterator) ForEach(ctx context.Context, fn BatchFunc) error {
+	batches, err := it.Batches()
+	if err != nil {
+		return err
+	}
+
+	total := batches[len(batches)-1].End - batches[0].Start + 1
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	output.ReportProgress(output.ProgressEvent{
+		Operation: progressBlocks,
+		Status:    output.ProgressStarted,
+		Total:     total,
+		Message:   fmt.Sprintf("scanning blocks %d to %d", it.start, it.end),
+	})
+
+	jobs := make(chan BlockRange)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	processed := uint64(0)
+
+	for i := 0; i < it.options.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range jobs {
+				if err := it.process(ctx, fn, batch); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+					continue
+				}
+
+				mu.Lock()
+				processed += batch.End - batch.Start + 1
+				if firstErr == nil {
+					output.ReportProgress(output.ProgressEvent{
+						Operation: progressBlocks,
+						Status:    output.ProgressUpdated,
+						Step:      fmt.Sprintf("%d-%d", batch.Start, batch.End),
+						Current:   processed,
+						Total:     total,
+					})
+					if it.options.OnProgress != nil {
+						it.options.OnProgress(processed, total)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, batch := range batches {
+		select {
+		case jobs <- batch:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		output.ReportProgress(output.ProgressEvent{
+			Operation: progressBlocks,
+			Status:    output.ProgressFailed,
+			Current:   processed,
+			Total:     total,
+			Message:   firstErr.Error(),
+		})
+		return firstErr
+	}
+
+	output.ReportProgress(output.ProgressEvent{
+		Operation: progressBlocks,
+		Status:    output.ProgressCompleted,
+		Current:   processed,
+		Total:     total,
+	})
+	return nil
+}
+
+// process calls the function for the batch, retrying it if it fails.
+func (it *BlockIterator) process(ctx context.Context, fn BatchFunc, batch BlockRange) error {
+	var err error
+	for attempt := 0; attempt <= it.options.Retries; attempt++ {
+		if attempt > 0 && it.options.RetryDelay > 0 {
+			timer := time.NewTimer(it.options.RetryDelay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err = fn(ctx, it.gateway, batch)
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
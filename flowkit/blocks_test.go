@@ -0,0 +1,136 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+func Test_BlockRangeIterator(t *testing.T) {
+	t.Run("Batches", func(t *testing.T) {
+		batches, err := BlockRangeIterator(nil, 10, 34, BlockRangeOptions{BatchSize: 10}).Batches()
+		require.NoError(t, err)
+		assert.Equal(t, []BlockRange{
+			{Index: 0, Start: 10, End: 19},
+			{Index: 1, Start: 20, End: 29},
+			{Index: 2, Start: 30, End: 34},
+		}, batches)
+
+		_, err = BlockRangeIterator(nil, 10, 5, BlockRangeOptions{}).Batches()
+		assert.EqualError(t, err, "cannot have end height (5) of block range less that start height (10)")
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		var mu sync.Mutex
+		visited := make(map[uint64]bool)
+		progress := make([]uint64, 0)
+
+		it := BlockRangeIterator(nil, 0, 99, BlockRangeOptions{
+			BatchSize:   7,
+			Concurrency: 4,
+			OnProgress: func(processed uint64, total uint64) {
+				assert.Equal(t, uint64(100), total)
+				progress = append(progress, processed)
+			},
+		})
+		err := it.ForEach(context.Background(), func(_ context.Context, _ gateway.Gateway, batch BlockRange) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for h := batch.Start; h <= batch.End; h++ {
+				visited[h] = true
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Len(t, visited, 100)
+		assert.Len(t, progress, 15)
+		assert.Equal(t, uint64(100), progress[len(progress)-1])
+	})
+
+	t.Run("Retries", func(t *testing.T) {
+		attempts := 0
+		it := BlockRangeIterator(nil, 0, 9, BlockRangeOptions{Retries: 2})
+		err := it.ForEach(context.Background(), func(_ context.Context, _ gateway.Gateway, _ BlockRange) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("unavailable")
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		var mu sync.Mutex
+		processed := 0
+		it := BlockRangeIterator(nil, 0, 999, BlockRangeOptions{BatchSize: 10, Retries: 1})
+		err := it.ForEach(context.Background(), func(_ context.Context, _ gateway.Gateway, batch BlockRange) error {
+			mu.Lock()
+			defer mu.Unlock()
+			processed++
+			if batch.Index == 2 {
+				return errors.New("failed batch")
+			}
+			return nil
+		})
+		assert.EqualError(t, err, "failed batch")
+		// the failed batch is retried once and the remaining batches are not processed
+		assert.Equal(t, 4, processed)
+	})
+
+	t.Run("Progress Events", func(t *testing.T) {
+		var b bytes.Buffer
+		output.SetProgressJSON(&b)
+		t.Cleanup(func() { output.SetProgressJSON(nil) })
+
+		it := BlockRangeIterator(nil, 10, 24, BlockRangeOptions{BatchSize: 10})
+		err := it.ForEach(context.Background(), func(_ context.Context, _ gateway.Gateway, _ BlockRange) error {
+			return nil
+		})
+		require.NoError(t, err)
+
+		events := make([]output.ProgressEvent, 0)
+		for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+			var event output.ProgressEvent
+			require.NoError(t, json.Unmarshal([]byte(line), &event))
+			event.Time = time.Time{}
+			events = append(events, event)
+		}
+		assert.Equal(t, []output.ProgressEvent{
+			{Operation: "blocks", Status: output.ProgressStarted, Total: 15, Message: "scanning blocks 10 to 24"},
+			{Operation: "blocks", Status: output.ProgressUpdated, Step: "10-19", Current: 10, Total: 15},
+			{Operation: "blocks", Status: output.ProgressUpdated, Step: "20-24", Current: 15, Total: 15},
+			{Operation: "blocks", Status: output.ProgressCompleted, Current: 15, Total: 15},
+		}, events)
+	})
+}
@@ -70,6 +70,16 @@ func (a *AccountKey) IsDefault() bool {
 		a.HashAlgo == DefaultHashAlgo
 }
 
+// HasSecretKey checks whether the private key of the account is stored in the configuration.
+//
+// Keys of the emulator service account are not considered secret, since they only sign for local emulators.
+func (a *Account) HasSecretKey() bool {
+	if a.Address == flow.ServiceAddress(flow.Emulator) {
+		return false
+	}
+	return a.Key.Type == KeyTypeHex || a.Key.Type == KeyTypeBip44
+}
+
 // ByName get account by name or error if not found.
 func (a *Accounts) ByName(name string) (*Account, error) {
 	for _, account := range *a {
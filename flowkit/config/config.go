@@ -22,6 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // Config contains all the configuration for CLI and implements getters and setters for properties.
@@ -32,12 +34,22 @@ import (
 // Networks defines all the Flow networks addresses
 // Accounts defines Flow accounts and their addresses, private key and more properties
 // Deployments describes which contracts should be deployed to which accounts
+// Hooks defines shell commands executed before or after the matching CLI commands
+// Environments defines the variables of networks used by the deployment conditions
+// SourceRoot defines the directory the contract sources are relative to
+// Profiles defines the networks, accounts and deployments overridden for each environment
+// SecretArguments defines the names of transaction parameters which are redacted from the output
 type Config struct {
-	Emulators   Emulators
-	Contracts   Contracts
-	Networks    Networks
-	Accounts    Accounts
-	Deployments Deployments
+	SourceRoot      string
+	Emulators       Emulators
+	Contracts       Contracts
+	Networks        Networks
+	Accounts        Accounts
+	Deployments     Deployments
+	Hooks           Hooks
+	Environments    Environments
+	Profiles        Profiles
+	SecretArguments []string
 }
 
 type KeyType string
@@ -51,7 +63,15 @@ const (
 
 // Validate the configuration values.
 func (c *Config) Validate() error {
+	if err := ValidateSourceRoot(c.SourceRoot); err != nil {
+		return err
+	}
+
 	for _, con := range c.Contracts {
+		if err := ValidateCondition(con.OnlyIf); err != nil {
+			return fmt.Errorf("contract %s: %w", con.Name, err)
+		}
+
 		for _, alias := range con.Aliases {
 			_, err := c.Networks.ByName(alias.Network)
 			if alias.Network != "" && err != nil {
@@ -75,6 +95,9 @@ func (c *Config) Validate() error {
 			if _, err := c.Contracts.ByName(con.Name); err != nil {
 				return fmt.Errorf("deployment contains nonexisting contract %s", con.Name)
 			}
+			if err := ValidateCondition(con.OnlyIf); err != nil {
+				return fmt.Errorf("deployment of contract %s: %w", con.Name, err)
+			}
 		}
 
 		if _, err := c.Accounts.ByName(d.Account); err != nil {
@@ -82,6 +105,49 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for _, e := range c.Environments {
+		if _, err := c.Networks.ByName(e.Network); err != nil {
+			return fmt.Errorf("environment contains nonexisting network %s", e.Network)
+		}
+	}
+
+	for _, h := range c.Hooks {
+		if err := h.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range c.Profiles {
+		if _, err := c.WithProfile(p.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SourceLocation returns the location of a contract source defined in the configuration, which is
+// relative to the source root if one is defined. Remote and absolute locations are returned unchanged.
+func (c *Config) SourceLocation(location string) string {
+	if c.SourceRoot == "" || location == "" || filepath.IsAbs(location) ||
+		strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "ipfs://") {
+		return location
+	}
+
+	return filepath.ToSlash(filepath.Join(c.SourceRoot, location))
+}
+
+// ValidateSourceRoot checks the source root is a relative directory which doesn't point outside the project.
+func ValidateSourceRoot(root string) error {
+	if root == "" {
+		return nil
+	}
+
+	clean := filepath.ToSlash(filepath.Clean(root))
+	if filepath.IsAbs(root) || clean == ".." || strings.HasPrefix(clean, "../") || strings.Contains(root, "://") {
+		return fmt.Errorf("source root %s must be a directory inside the project", root)
+	}
+
 	return nil
 }
 
@@ -97,6 +163,22 @@ var ErrOutdatedFormat = errors.New("you are using old configuration format")
 
 const DefaultPath = "flow.json"
 
+// DefaultTOMLPath is the project configuration path used if the configuration in the default path doesn't exist.
+const DefaultTOMLPath = "flow.toml"
+
+// LocalPaths are the project configuration paths in the order they are looked up.
+var LocalPaths = []string{DefaultPath, DefaultTOMLPath}
+
+// PrivatePath returns the path of the private configuration overlaying the project configuration
+// at the path, for example flow.private.json for flow.json.
+//
+// The private configuration is loaded on top of the project configuration if it exists, and holds the
+// accounts with private keys that should not be committed.
+func PrivatePath(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + ".private" + ext
+}
+
 func IsDefaultPath(paths []string) bool {
 	return len(paths) == 2 && paths[0] == GlobalPath() && paths[1] == DefaultPath
 }
@@ -111,6 +193,17 @@ func GlobalPath() string {
 	return fmt.Sprintf("%s/%s", dirname, DefaultPath)
 }
 
+// UserPath returns the path of the user configuration in the home directory, holding the networks
+// and accounts shared by all the projects of the user.
+func UserPath() string {
+	dirname, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dirname, ".flow", "config.json")
+}
+
 // DefaultPaths determines default paths for configuration.
 func DefaultPaths() []string {
 	return []string{
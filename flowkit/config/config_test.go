@@ -18,6 +18,7 @@
 package config_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/onflow/cadence"
@@ -340,3 +341,19 @@ func Test_DefaultPath(t *testing.T) {
 	def := config.DefaultPaths()
 	assert.True(t, config.IsDefaultPath(def))
 }
+
+func Test_SourceLocation(t *testing.T) {
+	cfg := &config.Config{SourceRoot: "cadence"}
+	assert.Equal(t, "cadence/contracts/Foo.cdc", cfg.SourceLocation("contracts/Foo.cdc"))
+	assert.Equal(t, "cadence/contracts/Foo.cdc", cfg.SourceLocation("./contracts/Foo.cdc"))
+	assert.Equal(t, "https://example.com/Foo.cdc", cfg.SourceLocation("https://example.com/Foo.cdc"))
+	assert.Equal(t, "/contracts/Foo.cdc", cfg.SourceLocation("/contracts/Foo.cdc"))
+
+	cfg.SourceRoot = ""
+	assert.Equal(t, "./contracts/Foo.cdc", cfg.SourceLocation("./contracts/Foo.cdc"))
+
+	for _, root := range []string{"..", "../cadence", "/cadence", "https://example.com"} {
+		cfg.SourceRoot = root
+		assert.EqualError(t, cfg.Validate(), fmt.Sprintf("source root %s must be a directory inside the project", root))
+	}
+}
@@ -27,9 +27,12 @@ import (
 
 // Contract defines the configuration for a Cadence contract.
 type Contract struct {
-	Name     string
-	Location string
-	Aliases  Aliases
+	Name       string
+	Location   string
+	Aliases    Aliases
+	OnlyIf     string // condition required to deploy the contract
+	MockSource string // source deployed instead of the location on the emulator
+	Version    string // release version stamped into the code when deployed
 }
 
 // Alias defines an existing pre-deployed contract address for specific network.
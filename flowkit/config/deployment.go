@@ -27,8 +27,22 @@ import (
 
 // ContractDeployment defines the deployment of the contract with possible args.
 type ContractDeployment struct {
-	Name string
-	Args []cadence.Value
+	Name       string
+	Args       []cadence.Value
+	Transforms ContractTransforms
+	OnlyIf     string // condition required to deploy the contract on the account
+}
+
+// ContractTransforms defines the transformations applied to the contract source before it is deployed.
+type ContractTransforms struct {
+	StripLogs  bool              // remove log() statements
+	StripDebug bool              // remove code between the #debug and #enddebug pragmas
+	Constants  map[string]string // build constants replacing the ${NAME} placeholders
+}
+
+// IsEmpty checks whether no transformation is defined.
+func (t ContractTransforms) IsEmpty() bool {
+	return !t.StripLogs && !t.StripDebug && len(t.Constants) == 0
 }
 
 // Deployment defines the configuration for a contract deployment.
@@ -32,6 +32,10 @@ type Emulator struct {
 	Name           string
 	Port           int
 	ServiceAccount string
+	// DBPath is the directory the embedded emulator persists the chain state in, the state is kept in memory when empty.
+	DBPath string
+	// AdminPort is the port of the emulator admin API used to manage the emulator state, the default port is used when zero.
+	AdminPort int
 }
 
 type Emulators []Emulator
@@ -0,0 +1,50 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"os"
+	"regexp"
+)
+
+// envReference matches the ${NAME} references to the environment variables in the configuration values.
+var envReference = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// ExpandEnv replaces the ${NAME} references in the value with the values of the environment variables.
+//
+// References to the variables which are not set are left unchanged, so they can be reported in strict mode.
+func ExpandEnv(value string) string {
+	return envReference.ReplaceAllStringFunc(value, func(reference string) string {
+		if env, ok := os.LookupEnv(envReference.FindStringSubmatch(reference)[1]); ok {
+			return env
+		}
+		return reference
+	})
+}
+
+// UnsetEnv returns the names of the environment variables referenced in the value which are not set.
+func UnsetEnv(value string) []string {
+	unset := make([]string, 0)
+	for _, match := range envReference.FindAllStringSubmatch(value, -1) {
+		if _, ok := os.LookupEnv(match[1]); !ok {
+			unset = append(unset, match[1])
+		}
+	}
+	return unset
+}
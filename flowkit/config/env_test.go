@@ -0,0 +1,37 @@
+/*
+* Flow CLI
+*
+* Copyright 2019-2020 Dapper Labs, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*   http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExpandEnv(t *testing.T) {
+	t.Setenv("FLOW_TEST_REGION", "eu")
+	t.Setenv("FLOW_TEST_PORT", "9000")
+
+	assert.Equal(t, "access-eu.example.org:9000", ExpandEnv("access-${FLOW_TEST_REGION}.example.org:${FLOW_TEST_PORT}"))
+	assert.Equal(t, "access-${FLOW_TEST_UNSET}.example.org:9000", ExpandEnv("access-${FLOW_TEST_UNSET}.example.org:${FLOW_TEST_PORT}"))
+	assert.Equal(t, "127.0.0.1:3569", ExpandEnv("127.0.0.1:3569"))
+
+	assert.Equal(t, []string{"FLOW_TEST_UNSET"}, UnsetEnv("access-${FLOW_TEST_UNSET}.example.org:${FLOW_TEST_PORT}"))
+	assert.Empty(t, UnsetEnv("access-${FLOW_TEST_REGION}.example.org"))
+}
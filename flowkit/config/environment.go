@@ -0,0 +1,113 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Environment defines the variables of a network used to evaluate the deployment conditions.
+type Environment struct {
+	Network   string
+	Variables map[string]bool
+}
+
+type Environments []Environment
+
+// ByNetwork get environment by network name or return nil if it doesn't exist.
+func (e *Environments) ByNetwork(network string) *Environment {
+	for i := range *e {
+		if (*e)[i].Network == network {
+			return &(*e)[i]
+		}
+	}
+
+	return nil
+}
+
+// AddOrUpdate add new or update if already present.
+func (e *Environments) AddOrUpdate(environment Environment) {
+	for i, existing := range *e {
+		if existing.Network == environment.Network {
+			(*e)[i] = environment
+			return
+		}
+	}
+
+	*e = append(*e, environment)
+}
+
+// Remove environment by network name.
+func (e *Environments) Remove(network string) error {
+	for i, environment := range *e {
+		if environment.Network == network {
+			*e = append((*e)[:i], (*e)[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("environment for network %s does not exist", network)
+}
+
+// Variables returns the variables of the network, overridden by the provided variables.
+func (e *Environments) Variables(network string, overrides map[string]bool) map[string]bool {
+	variables := make(map[string]bool)
+	if environment := e.ByNetwork(network); environment != nil {
+		for name, value := range environment.Variables {
+			variables[name] = value
+		}
+	}
+	for name, value := range overrides {
+		variables[name] = value
+	}
+
+	return variables
+}
+
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// ValidateCondition checks the condition is a variable name optionally negated with an exclamation mark.
+func ValidateCondition(condition string) error {
+	if condition == "" {
+		return nil
+	}
+
+	if !variableName.MatchString(strings.TrimPrefix(condition, "!")) {
+		return fmt.Errorf("invalid condition %s, conditions must be a variable name optionally prefixed with !", condition)
+	}
+
+	return nil
+}
+
+// ConditionMet checks whether the condition is met by the variables.
+//
+// Empty conditions are always met, undefined variables are false.
+func ConditionMet(condition string, variables map[string]bool) bool {
+	if condition == "" {
+		return true
+	}
+
+	if strings.HasPrefix(condition, "!") {
+		return !variables[strings.TrimPrefix(condition, "!")]
+	}
+
+	return variables[condition]
+}
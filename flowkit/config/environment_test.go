@@ -0,0 +1,60 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EnvironmentVariables(t *testing.T) {
+	environments := Environments{{
+		Network:   "emulator",
+		Variables: map[string]bool{"mocks": true, "feature_x": false},
+	}}
+
+	assert.Equal(t, map[string]bool{"mocks": true, "feature_x": true},
+		environments.Variables("emulator", map[string]bool{"feature_x": true}))
+	assert.Equal(t, map[string]bool{"feature_x": true},
+		environments.Variables("testnet", map[string]bool{"feature_x": true}))
+
+	environments.AddOrUpdate(Environment{Network: "emulator", Variables: map[string]bool{}})
+	assert.Len(t, environments, 1)
+	assert.Empty(t, environments.ByNetwork("emulator").Variables)
+
+	assert.NoError(t, environments.Remove("emulator"))
+	assert.EqualError(t, environments.Remove("emulator"), "environment for network emulator does not exist")
+}
+
+func Test_Conditions(t *testing.T) {
+	variables := map[string]bool{"mocks": true, "production": false}
+
+	assert.True(t, ConditionMet("", variables))
+	assert.True(t, ConditionMet("mocks", variables))
+	assert.False(t, ConditionMet("production", variables))
+	assert.False(t, ConditionMet("undefined", variables))
+	assert.True(t, ConditionMet("!production", variables))
+	assert.True(t, ConditionMet("!undefined", variables))
+
+	assert.NoError(t, ValidateCondition(""))
+	assert.NoError(t, ValidateCondition("!feature-x_2"))
+	assert.EqualError(t, ValidateCondition("a && b"), "invalid condition a && b, conditions must be a variable name optionally prefixed with !")
+	assert.Error(t, ValidateCondition("!!mocks"))
+}
@@ -0,0 +1,117 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	HookStagePre  = "pre"
+	HookStagePost = "post"
+)
+
+// Hook defines a shell command executed by the CLI before or after the matching command runs.
+//
+// The hook name is composed of the stage and the command, for example pre-deploy runs before
+// the deploy command and post-project-deploy runs after the project deploy command.
+type Hook struct {
+	Name    string
+	Command string
+}
+
+// Stage returns the stage of the hook, either pre or post.
+func (h Hook) Stage() string {
+	stage, _, _ := strings.Cut(h.Name, "-")
+	return stage
+}
+
+// Target returns the name of the command the hook runs around.
+func (h Hook) Target() string {
+	_, target, _ := strings.Cut(h.Name, "-")
+	return target
+}
+
+// Validate the hook name and command.
+func (h Hook) Validate() error {
+	stage, target, _ := strings.Cut(h.Name, "-")
+	if (stage != HookStagePre && stage != HookStagePost) || target == "" {
+		return fmt.Errorf("invalid hook name %s, hook names must be pre-<command> or post-<command>", h.Name)
+	}
+	if strings.TrimSpace(h.Command) == "" {
+		return fmt.Errorf("hook %s is missing the command", h.Name)
+	}
+
+	return nil
+}
+
+type Hooks []Hook
+
+// ByName get hook by name or return an error if it doesn't exist.
+func (h *Hooks) ByName(name string) (*Hook, error) {
+	for i := range *h {
+		if (*h)[i].Name == name {
+			return &(*h)[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("hook %s does not exist", name)
+}
+
+// AddOrUpdate add new or update if already present.
+func (h *Hooks) AddOrUpdate(hook Hook) {
+	for i, existingHook := range *h {
+		if existingHook.Name == hook.Name {
+			(*h)[i] = hook
+			return
+		}
+	}
+
+	*h = append(*h, hook)
+}
+
+// Remove hook by its name.
+func (h *Hooks) Remove(name string) error {
+	for i, hook := range *h {
+		if hook.Name == name {
+			*h = append((*h)[:i], (*h)[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("hook %s does not exist", name)
+}
+
+// ForCommand returns the hooks of the stage running around any of the command names.
+//
+// Commands can be matched by multiple names, for example by their name and their full path,
+// in which case the hooks are returned in the order of the names.
+func (h *Hooks) ForCommand(stage string, names ...string) Hooks {
+	hooks := make(Hooks, 0)
+	for _, name := range names {
+		for _, hook := range *h {
+			if hook.Stage() == stage && hook.Target() == name {
+				hooks = append(hooks, hook)
+			}
+		}
+	}
+
+	return hooks
+}
@@ -0,0 +1,70 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Hooks(t *testing.T) {
+	hooks := Hooks{
+		{Name: "pre-deploy", Command: "./scripts/gen.sh"},
+		{Name: "post-deploy", Command: "./scripts/clean.sh"},
+		{Name: "pre-project-deploy", Command: "./scripts/check.sh"},
+	}
+
+	t.Run("Stage and Target", func(t *testing.T) {
+		assert.Equal(t, HookStagePre, hooks[2].Stage())
+		assert.Equal(t, "project-deploy", hooks[2].Target())
+	})
+
+	t.Run("For Command", func(t *testing.T) {
+		pre := hooks.ForCommand(HookStagePre, "deploy", "project-deploy")
+		assert.Equal(t, Hooks{hooks[0], hooks[2]}, pre)
+
+		post := hooks.ForCommand(HookStagePost, "deploy", "project-deploy")
+		assert.Equal(t, Hooks{hooks[1]}, post)
+
+		assert.Len(t, hooks.ForCommand(HookStagePre, "run"), 0)
+	})
+
+	t.Run("Add Or Update", func(t *testing.T) {
+		h := Hooks{}
+		h.AddOrUpdate(Hook{Name: "pre-deploy", Command: "a"})
+		h.AddOrUpdate(Hook{Name: "pre-deploy", Command: "b"})
+
+		hook, err := h.ByName("pre-deploy")
+		require.NoError(t, err)
+		assert.Equal(t, "b", hook.Command)
+		assert.Len(t, h, 1)
+
+		require.NoError(t, h.Remove("pre-deploy"))
+		assert.EqualError(t, h.Remove("pre-deploy"), "hook pre-deploy does not exist")
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		assert.NoError(t, Hook{Name: "post-deploy", Command: "echo"}.Validate())
+		assert.EqualError(t, Hook{Name: "pre-", Command: "echo"}.Validate(), "invalid hook name pre-, hook names must be pre-<command> or post-<command>")
+		assert.EqualError(t, Hook{Name: "deploy", Command: "echo"}.Validate(), "invalid hook name deploy, hook names must be pre-<command> or post-<command>")
+		assert.EqualError(t, Hook{Name: "pre-deploy", Command: " "}.Validate(), "hook pre-deploy is missing the command")
+	})
+}
@@ -21,17 +21,25 @@ package json
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/onflow/flow-cli/flowkit/config"
 )
 
 // jsonConfig implements JSON format for persisting and parsing configuration.
 type jsonConfig struct {
-	Emulators   jsonEmulators   `json:"emulators,omitempty"`
-	Contracts   jsonContracts   `json:"contracts,omitempty"`
-	Networks    jsonNetworks    `json:"networks,omitempty"`
-	Accounts    jsonAccounts    `json:"accounts,omitempty"`
-	Deployments jsonDeployments `json:"deployments,omitempty"`
+	SourceRoot      string           `json:"sourceRoot,omitempty"`
+	Emulators       jsonEmulators    `json:"emulators,omitempty"`
+	Contracts       jsonContracts    `json:"contracts,omitempty"`
+	Networks        jsonNetworks     `json:"networks,omitempty"`
+	Accounts        jsonAccounts     `json:"accounts,omitempty"`
+	Deployments     jsonDeployments  `json:"deployments,omitempty"`
+	Hooks           jsonHooks        `json:"hooks,omitempty"`
+	Environments    jsonEnvironments `json:"environments,omitempty"`
+	Profiles        jsonProfiles     `json:"profiles,omitempty"`
+	SecretArguments []string         `json:"secretArguments,omitempty"`
 }
 
 func (j *jsonConfig) transformToConfig() (*config.Config, error) {
@@ -60,12 +68,32 @@ func (j *jsonConfig) transformToConfig() (*config.Config, error) {
 		return nil, err
 	}
 
+	hooks, err := j.Hooks.transformToConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	environments, err := j.Environments.transformToConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, err := j.Profiles.transformToConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	conf := &config.Config{
-		Emulators:   emulators,
-		Contracts:   contracts,
-		Networks:    networks,
-		Accounts:    accounts,
-		Deployments: deployments,
+		SourceRoot:      j.SourceRoot,
+		Emulators:       emulators,
+		Contracts:       contracts,
+		Networks:        networks,
+		Accounts:        accounts,
+		Deployments:     deployments,
+		Hooks:           hooks,
+		Environments:    environments,
+		Profiles:        profiles,
+		SecretArguments: j.SecretArguments,
 	}
 
 	return conf, nil
@@ -73,11 +101,16 @@ func (j *jsonConfig) transformToConfig() (*config.Config, error) {
 
 func transformConfigToJSON(config *config.Config) jsonConfig {
 	return jsonConfig{
-		Emulators:   transformEmulatorsToJSON(config.Emulators),
-		Contracts:   transformContractsToJSON(config.Contracts),
-		Networks:    transformNetworksToJSON(config.Networks),
-		Accounts:    transformAccountsToJSON(config.Accounts),
-		Deployments: transformDeploymentsToJSON(config.Deployments),
+		SourceRoot:      config.SourceRoot,
+		Emulators:       transformEmulatorsToJSON(config.Emulators),
+		Contracts:       transformContractsToJSON(config.Contracts),
+		Networks:        transformNetworksToJSON(config.Networks),
+		Accounts:        transformAccountsToJSON(config.Accounts),
+		Deployments:     transformDeploymentsToJSON(config.Deployments),
+		Hooks:           transformHooksToJSON(config.Hooks),
+		Environments:    transformEnvironmentsToJSON(config.Environments),
+		Profiles:        transformProfilesToJSON(config.Profiles),
+		SecretArguments: config.SecretArguments,
 	}
 }
 
@@ -101,6 +134,33 @@ func oldConfigFormat(raw []byte) bool {
 	return false
 }
 
+// UnknownFields returns the top level fields of the raw configuration which are not part of the
+// configuration format and are therefore ignored when parsing.
+func UnknownFields(raw []byte) ([]string, error) {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(raw, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{"$schema": true}
+	configType := reflect.TypeOf(jsonConfig{})
+	for i := 0; i < configType.NumField(); i++ {
+		name := strings.Split(configType.Field(i).Tag.Get("json"), ",")[0]
+		known[name] = true
+	}
+
+	unknown := make([]string, 0)
+	for field := range fields {
+		if !known[field] {
+			unknown = append(unknown, field)
+		}
+	}
+	sort.Strings(unknown)
+
+	return unknown, nil
+}
+
 // Parser for JSON configuration format.
 type Parser struct{}
 
@@ -130,6 +190,10 @@ func (p *Parser) Deserialize(raw []byte) (*config.Config, error) {
 	var jsonConf jsonConfig
 	err := json.Unmarshal(raw, &jsonConf)
 	if err != nil {
+		// report the values not matching the format instead of the first unmarshal error if possible
+		if schemaErrs := Validate(raw); len(schemaErrs) > 0 {
+			return nil, schemaErrs
+		}
 		return nil, fmt.Errorf("configuration syntax error: %w", err)
 	}
 
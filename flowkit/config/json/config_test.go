@@ -23,6 +23,7 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit/config"
 )
@@ -255,3 +256,56 @@ func Test_SerializeConfigToJsonEmulatorNotDefault(t *testing.T) {
 	assert.JSONEq(t, string(configJson), string(conf))
 
 }
+
+func Test_SourceRootJSONConfig(t *testing.T) {
+	b := []byte(`{
+		"sourceRoot": "cadence",
+		"contracts": {
+			"Foo": "contracts/Foo.cdc"
+		}
+	}`)
+
+	parser := NewParser()
+	conf, err := parser.Deserialize(b)
+	require.NoError(t, err)
+	assert.Equal(t, "cadence", conf.SourceRoot)
+
+	raw, err := parser.Serialize(conf)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"sourceRoot": "cadence"`)
+	assert.Empty(t, Validate(raw))
+}
+
+func Test_ProfilesJSONConfig(t *testing.T) {
+	b := []byte(`{
+		"contracts": { "Foo": "contracts/Foo.cdc" },
+		"networks": { "testnet": "access.devnet.nodes.onflow.org:9000" },
+		"accounts": {
+			"alice": { "address": "f8d6e0586b0a20c7", "key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47" }
+		},
+		"profiles": {
+			"staging": {
+				"networks": { "testnet": "staging.devnet.nodes.onflow.org:9000" },
+				"deployments": { "testnet": { "alice": ["Foo"] } }
+			}
+		}
+	}`)
+
+	parser := NewParser()
+	conf, err := parser.Deserialize(b)
+	require.NoError(t, err)
+	require.Len(t, conf.Profiles, 1)
+	staging := conf.Profiles[0]
+	assert.Equal(t, "staging", staging.Name)
+	assert.Equal(t, "staging.devnet.nodes.onflow.org:9000", staging.Networks[0].Host)
+	assert.Empty(t, staging.Accounts)
+	assert.Equal(t, "alice", staging.Deployments[0].Account)
+
+	raw, err := parser.Serialize(conf)
+	require.NoError(t, err)
+	assert.Empty(t, Validate(raw))
+
+	parsed, err := parser.Deserialize(raw)
+	require.NoError(t, err)
+	assert.Equal(t, conf.Profiles, parsed.Profiles)
+}
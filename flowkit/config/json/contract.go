@@ -44,8 +44,11 @@ func (j jsonContracts) transformToConfig() (config.Contracts, error) {
 			contracts = append(contracts, contract)
 		} else {
 			contract := config.Contract{
-				Name:     contractName,
-				Location: c.Advanced.Source,
+				Name:       contractName,
+				Location:   c.Advanced.Source,
+				OnlyIf:     c.Advanced.OnlyIf,
+				MockSource: c.Advanced.MockSource,
+				Version:    c.Advanced.Version,
 			}
 			for network, alias := range c.Advanced.Aliases {
 				address := flow.HexToAddress(alias)
@@ -68,7 +71,7 @@ func transformContractsToJSON(contracts config.Contracts) jsonContracts {
 
 	for _, c := range contracts {
 		// if simple case
-		if !c.IsAliased() {
+		if !c.IsAliased() && c.OnlyIf == "" && c.MockSource == "" && c.Version == "" {
 			jsonContracts[c.Name] = jsonContract{
 				Simple: c.Location,
 			}
@@ -81,8 +84,11 @@ func transformContractsToJSON(contracts config.Contracts) jsonContracts {
 
 			jsonContracts[c.Name] = jsonContract{
 				Advanced: jsonContractAdvanced{
-					Source:  c.Location,
-					Aliases: aliases,
+					Source:     c.Location,
+					Aliases:    aliases,
+					OnlyIf:     c.OnlyIf,
+					MockSource: c.MockSource,
+					Version:    c.Version,
 				},
 			}
 		}
@@ -93,8 +99,11 @@ func transformContractsToJSON(contracts config.Contracts) jsonContracts {
 
 // jsonContractAdvanced for json parsing advanced config.
 type jsonContractAdvanced struct {
-	Source  string            `json:"source"`
-	Aliases map[string]string `json:"aliases"`
+	Source     string            `json:"source"`
+	Aliases    map[string]string `json:"aliases,omitempty"`
+	OnlyIf     string            `json:"onlyIf,omitempty"`
+	MockSource string            `json:"mockSource,omitempty"`
+	Version    string            `json:"version,omitempty"`
 }
 
 // jsonContract structure for json parsing.
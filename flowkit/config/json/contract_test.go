@@ -184,3 +184,29 @@ func Test_TransformComplexContractToJSON(t *testing.T) {
 
 	assert.JSONEq(t, string(b), string(x))
 }
+
+func Test_ConfigContractsAdvancedFields(t *testing.T) {
+	b := []byte(`{
+		"Oracle": {
+			"source": "./cadence/contracts/Oracle.cdc",
+			"mockSource": "./cadence/mocks/Oracle.cdc",
+			"version": "2.1.0"
+		}
+	}`)
+
+	var jsonContracts jsonContracts
+	err := json.Unmarshal(b, &jsonContracts)
+	assert.NoError(t, err)
+
+	contracts, err := jsonContracts.transformToConfig()
+	assert.NoError(t, err)
+
+	oracle, err := contracts.ByName("Oracle")
+	assert.NoError(t, err)
+	assert.Equal(t, "./cadence/contracts/Oracle.cdc", oracle.Location)
+	assert.Equal(t, "./cadence/mocks/Oracle.cdc", oracle.MockSource)
+	assert.Equal(t, "2.1.0", oracle.Version)
+
+	x, _ := json.Marshal(transformContractsToJSON(contracts))
+	assert.JSONEq(t, string(b), string(x))
+}
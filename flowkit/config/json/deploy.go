@@ -70,13 +70,20 @@ func (j jsonDeployments) transformToConfig() (config.Deployments, error) {
 						args = append(args, cadenceArg)
 					}
 
-					contractDeploys = append(
-						contractDeploys,
-						config.ContractDeployment{
-							Name: contract.advanced.Name,
-							Args: args,
-						},
-					)
+					contractDeploy := config.ContractDeployment{
+						Name:   contract.advanced.Name,
+						Args:   args,
+						OnlyIf: contract.advanced.OnlyIf,
+					}
+					if contract.advanced.Transforms != nil {
+						contractDeploy.Transforms = config.ContractTransforms{
+							StripLogs:  contract.advanced.Transforms.StripLogs,
+							StripDebug: contract.advanced.Transforms.StripDebug,
+							Constants:  contract.advanced.Transforms.Constants,
+						}
+					}
+
+					contractDeploys = append(contractDeploys, contractDeploy)
 				}
 			}
 
@@ -96,7 +103,7 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 
 		deployments := make([]deployment, 0)
 		for _, c := range d.Contracts {
-			if len(c.Args) == 0 {
+			if len(c.Args) == 0 && c.Transforms.IsEmpty() && c.OnlyIf == "" {
 				deployments = append(deployments, deployment{
 					simple: c.Name,
 				})
@@ -117,11 +124,21 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 					}
 				}
 
+				advanced := contractDeployment{
+					Name:   c.Name,
+					Args:   args,
+					OnlyIf: c.OnlyIf,
+				}
+				if !c.Transforms.IsEmpty() {
+					advanced.Transforms = &contractTransforms{
+						StripLogs:  c.Transforms.StripLogs,
+						StripDebug: c.Transforms.StripDebug,
+						Constants:  c.Transforms.Constants,
+					}
+				}
+
 				deployments = append(deployments, deployment{
-					advanced: contractDeployment{
-						Name: c.Name,
-						Args: args,
-					},
+					advanced: advanced,
 				})
 			}
 		}
@@ -140,8 +157,16 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 }
 
 type contractDeployment struct {
-	Name string           `json:"name"`
-	Args []map[string]any `json:"args"`
+	Name       string              `json:"name"`
+	Args       []map[string]any    `json:"args"`
+	Transforms *contractTransforms `json:"transforms,omitempty"`
+	OnlyIf     string              `json:"onlyIf,omitempty"`
+}
+
+type contractTransforms struct {
+	StripLogs  bool              `json:"stripLogs,omitempty"`
+	StripDebug bool              `json:"stripDebug,omitempty"`
+	Constants  map[string]string `json:"constants,omitempty"`
 }
 
 type deployment struct {
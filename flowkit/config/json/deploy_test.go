@@ -151,3 +151,71 @@ func Test_DeploymentAdvanced(t *testing.T) {
 	assert.Equal(t, "KittyItemsMarket", alice.Contracts[1].Name)
 	assert.Len(t, alice.Contracts[1].Args, 0)
 }
+
+func Test_DeploymentTransforms(t *testing.T) {
+	b := []byte(`{
+		"testnet": {
+			"alice": [
+				{
+					"name": "Kibble",
+					"args": [],
+					"transforms": {
+						"stripLogs": true,
+						"stripDebug": true,
+						"constants": { "VERSION": "1.0.0" }
+					}
+				}
+			]
+		}
+	}`)
+
+	var jsonDeployments jsonDeployments
+	err := json.Unmarshal(b, &jsonDeployments)
+	assert.NoError(t, err)
+
+	deployments, err := jsonDeployments.transformToConfig()
+	assert.NoError(t, err)
+
+	alice := deployments.ByAccountAndNetwork("alice", "testnet")
+	assert.NotNil(t, alice)
+	assert.True(t, alice.Contracts[0].Transforms.StripLogs)
+	assert.True(t, alice.Contracts[0].Transforms.StripDebug)
+	assert.Equal(t, map[string]string{"VERSION": "1.0.0"}, alice.Contracts[0].Transforms.Constants)
+
+	j := transformDeploymentsToJSON(deployments)
+	x, _ := json.Marshal(j)
+
+	assert.Equal(t, cleanSpecialChars(b), cleanSpecialChars(x))
+}
+
+func Test_DeploymentConditions(t *testing.T) {
+	b := []byte(`{
+		"emulator": {
+			"alice": [
+				"Kibble",
+				{
+					"name": "KibbleMocks",
+					"args": [],
+					"onlyIf": "mocks"
+				}
+			]
+		}
+	}`)
+
+	var jsonDeployments jsonDeployments
+	err := json.Unmarshal(b, &jsonDeployments)
+	assert.NoError(t, err)
+
+	deployments, err := jsonDeployments.transformToConfig()
+	assert.NoError(t, err)
+
+	alice := deployments.ByAccountAndNetwork("alice", "emulator")
+	assert.NotNil(t, alice)
+	assert.Equal(t, "", alice.Contracts[0].OnlyIf)
+	assert.Equal(t, "mocks", alice.Contracts[1].OnlyIf)
+
+	j := transformDeploymentsToJSON(deployments)
+	x, _ := json.Marshal(j)
+
+	assert.Equal(t, cleanSpecialChars(b), cleanSpecialChars(x))
+}
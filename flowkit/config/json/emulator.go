@@ -34,11 +34,16 @@ func (j jsonEmulators) transformToConfig() (config.Emulators, error) {
 		if e.Port < 0 || e.Port > 65535 {
 			return nil, fmt.Errorf("invalid port value")
 		}
+		if e.AdminPort < 0 || e.AdminPort > 65535 {
+			return nil, fmt.Errorf("invalid admin port value")
+		}
 
 		emulator := config.Emulator{
 			Name:           name,
 			Port:           e.Port,
 			ServiceAccount: e.ServiceAccount,
+			DBPath:         e.DBPath,
+			AdminPort:      e.AdminPort,
 		}
 
 		emulators = append(emulators, emulator)
@@ -58,6 +63,8 @@ func transformEmulatorsToJSON(emulators config.Emulators) jsonEmulators {
 		jsonEmulators[e.Name] = jsonEmulator{
 			Port:           e.Port,
 			ServiceAccount: e.ServiceAccount,
+			DBPath:         e.DBPath,
+			AdminPort:      e.AdminPort,
 		}
 	}
 
@@ -67,4 +74,6 @@ func transformEmulatorsToJSON(emulators config.Emulators) jsonEmulators {
 type jsonEmulator struct {
 	Port           int    `json:"port"`
 	ServiceAccount string `json:"serviceAccount"`
+	DBPath         string `json:"dbPath,omitempty"`
+	AdminPort      int    `json:"adminPort,omitempty"`
 }
@@ -74,3 +74,51 @@ func Test_ConfigMultipleEmulators(t *testing.T) {
 	assert.Equal(t, emulators[1].Port, 3000)
 	assert.Equal(t, emulators[1].ServiceAccount, "custom-emulator-account")
 }
+
+func Test_ConfigEmulatorDBPath(t *testing.T) {
+	b := []byte(`{
+		 "default": {
+				"port": 9000,
+				"serviceAccount": "emulator-account",
+				"dbPath": "./flowdb"
+		 }
+	 }`)
+
+	var jsonEmulators jsonEmulators
+	err := json.Unmarshal(b, &jsonEmulators)
+	assert.NoError(t, err)
+
+	emulators, err := jsonEmulators.transformToConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "./flowdb", emulators[0].DBPath)
+
+	j := transformEmulatorsToJSON(emulators)
+	x, _ := json.Marshal(j)
+	assert.JSONEq(t, string(b), string(x))
+}
+
+func Test_ConfigEmulatorAdminPort(t *testing.T) {
+	b := []byte(`{
+		 "default": {
+				"port": 9000,
+				"serviceAccount": "emulator-account",
+				"adminPort": 9080
+		 }
+	 }`)
+
+	var jsonEmulators jsonEmulators
+	err := json.Unmarshal(b, &jsonEmulators)
+	assert.NoError(t, err)
+
+	emulators, err := jsonEmulators.transformToConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, 9080, emulators[0].AdminPort)
+
+	j := transformEmulatorsToJSON(emulators)
+	x, _ := json.Marshal(j)
+	assert.JSONEq(t, string(b), string(x))
+
+	jsonEmulators["default"] = jsonEmulator{Port: 9000, AdminPort: 70000}
+	_, err = jsonEmulators.transformToConfig()
+	assert.EqualError(t, err, "invalid admin port value")
+}
@@ -0,0 +1,58 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"sort"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// jsonEnvironments maps the network name to the variables used by the deployment conditions.
+type jsonEnvironments map[string]map[string]bool
+
+// transformToConfig transforms json structures to config structure.
+func (j jsonEnvironments) transformToConfig() (config.Environments, error) {
+	networks := make([]string, 0, len(j))
+	for network := range j {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	var environments config.Environments
+	for _, network := range networks {
+		environments = append(environments, config.Environment{
+			Network:   network,
+			Variables: j[network],
+		})
+	}
+
+	return environments, nil
+}
+
+// transformEnvironmentsToJSON transforms config structure to json structures for saving.
+func transformEnvironmentsToJSON(environments config.Environments) jsonEnvironments {
+	jsonEnvironments := jsonEnvironments{}
+
+	for _, e := range environments {
+		jsonEnvironments[e.Network] = e.Variables
+	}
+
+	return jsonEnvironments
+}
@@ -0,0 +1,50 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_ConfigEnvironments(t *testing.T) {
+	b := []byte(`{
+		"testnet": { "mocks": false },
+		"emulator": { "mocks": true, "feature_x": true }
+	}`)
+
+	var jsonEnvironments jsonEnvironments
+	err := json.Unmarshal(b, &jsonEnvironments)
+	require.NoError(t, err)
+
+	environments, err := jsonEnvironments.transformToConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, config.Environments{
+		{Network: "emulator", Variables: map[string]bool{"mocks": true, "feature_x": true}},
+		{Network: "testnet", Variables: map[string]bool{"mocks": false}},
+	}, environments)
+
+	assert.Equal(t, jsonEnvironments, transformEnvironmentsToJSON(environments))
+}
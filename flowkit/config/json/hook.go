@@ -0,0 +1,63 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"sort"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// jsonHooks maps the hook name, like pre-deploy, to the shell command it executes.
+type jsonHooks map[string]string
+
+// transformToConfig transforms json structures to config structure.
+func (j jsonHooks) transformToConfig() (config.Hooks, error) {
+	names := make([]string, 0, len(j))
+	for name := range j {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var hooks config.Hooks
+	for _, name := range names {
+		hook := config.Hook{
+			Name:    name,
+			Command: j[name],
+		}
+		if err := hook.Validate(); err != nil {
+			return nil, err
+		}
+
+		hooks = append(hooks, hook)
+	}
+
+	return hooks, nil
+}
+
+// transformHooksToJSON transforms config structure to json structures for saving.
+func transformHooksToJSON(hooks config.Hooks) jsonHooks {
+	jsonHooks := jsonHooks{}
+
+	for _, h := range hooks {
+		jsonHooks[h.Name] = h.Command
+	}
+
+	return jsonHooks
+}
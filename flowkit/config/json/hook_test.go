@@ -0,0 +1,61 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_ConfigHooks(t *testing.T) {
+	b := []byte(`{
+		"pre-deploy": "./scripts/gen.sh",
+		"post-project-deploy": "rm -rf .cache"
+	}`)
+
+	var jsonHooks jsonHooks
+	err := json.Unmarshal(b, &jsonHooks)
+	require.NoError(t, err)
+
+	hooks, err := jsonHooks.transformToConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, config.Hooks{
+		{Name: "post-project-deploy", Command: "rm -rf .cache"},
+		{Name: "pre-deploy", Command: "./scripts/gen.sh"},
+	}, hooks)
+
+	assert.Equal(t, jsonHooks, transformHooksToJSON(hooks))
+}
+
+func Test_ConfigHooksInvalid(t *testing.T) {
+	b := []byte(`{ "before-deploy": "./scripts/gen.sh" }`)
+
+	var jsonHooks jsonHooks
+	err := json.Unmarshal(b, &jsonHooks)
+	require.NoError(t, err)
+
+	_, err = jsonHooks.transformToConfig()
+	assert.EqualError(t, err, "invalid hook name before-deploy, hook names must be pre-<command> or post-<command>")
+}
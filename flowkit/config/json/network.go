@@ -37,21 +37,46 @@ func (j jsonNetworks) transformToConfig() (config.Networks, error) {
 	networks := make(config.Networks, 0)
 
 	for networkName, n := range j {
-		if n.Advanced.Key != "" && n.Advanced.Host != "" {
-			err := validateECDSAP256Pub(n.Advanced.Key)
-			if err != nil {
-				return nil, fmt.Errorf("invalid key %s for network with name %s", n.Advanced.Key, networkName)
+		if n.Advanced.Host != "" && (n.Advanced.Key != "" || n.Advanced.Secure || n.Advanced.MaxMessageSize != 0 || n.Advanced.RequestsPerSecond != 0 || hasFallbackKeys(n.Advanced.FallbackHosts)) {
+			if n.Advanced.Key != "" {
+				err := validateECDSAP256Pub(n.Advanced.Key)
+				if err != nil {
+					return nil, fmt.Errorf("invalid key %s for network with name %s", n.Advanced.Key, networkName)
+				}
+			}
+			for _, fallback := range n.Advanced.FallbackHosts {
+				if fallback.Key == "" {
+					continue
+				}
+				if err := validateECDSAP256Pub(fallback.Key); err != nil {
+					return nil, fmt.Errorf("invalid key %s of fallback host %s for network with name %s", fallback.Key, fallback.Host, networkName)
+				}
+			}
+			if n.Advanced.MaxMessageSize < 0 {
+				return nil, fmt.Errorf("invalid max message size %d for network with name %s", n.Advanced.MaxMessageSize, networkName)
+			}
+			if n.Advanced.RequestsPerSecond < 0 {
+				return nil, fmt.Errorf("invalid requests per second %v for network with name %s", n.Advanced.RequestsPerSecond, networkName)
 			}
 
+			host, fallbackHosts, hostsEnv := expandHosts(n.Advanced.Host, n.Advanced.FallbackHosts)
 			networks = append(networks, config.Network{
-				Name: networkName,
-				Host: n.Advanced.Host,
-				Key:  n.Advanced.Key,
+				Name:              networkName,
+				Host:              host,
+				FallbackHosts:     fallbackHosts,
+				HostsEnv:          hostsEnv,
+				Key:               n.Advanced.Key,
+				Secure:            n.Advanced.Secure,
+				MaxMessageSize:    n.Advanced.MaxMessageSize,
+				RequestsPerSecond: n.Advanced.RequestsPerSecond,
 			})
 		} else if n.Simple.Host != "" {
+			host, fallbackHosts, hostsEnv := expandHosts(n.Simple.Host, n.Simple.FallbackHosts)
 			networks = append(networks, config.Network{
-				Name: networkName,
-				Host: n.Simple.Host,
+				Name:          networkName,
+				Host:          host,
+				FallbackHosts: fallbackHosts,
+				HostsEnv:      hostsEnv,
 			})
 		} else {
 			return nil, fmt.Errorf("failed to transform networks configuration")
@@ -61,12 +86,69 @@ func (j jsonNetworks) transformToConfig() (config.Networks, error) {
 	return networks, nil
 }
 
+// expandHosts replaces the environment variable references in the hosts, if any host references a variable
+// the configured hosts are returned as well, so they are saved instead of the expanded hosts.
+func expandHosts(host string, fallbackHosts []jsonFallbackHost) (string, []config.FallbackHost, []string) {
+	fallbacks := make([]config.FallbackHost, 0, len(fallbackHosts))
+	configured := []string{host}
+	for _, fallback := range fallbackHosts {
+		fallbacks = append(fallbacks, config.FallbackHost{Host: fallback.Host, Key: fallback.Key})
+		configured = append(configured, fallback.Host)
+	}
+
+	expanded := make([]string, len(configured))
+	referenced := false
+	for i, h := range configured {
+		expanded[i] = config.ExpandEnv(h)
+		referenced = referenced || expanded[i] != h
+	}
+
+	if len(fallbacks) == 0 {
+		fallbacks = nil
+	}
+	if !referenced {
+		return host, fallbacks, nil
+	}
+	for i := range fallbacks {
+		fallbacks[i].Host = expanded[i+1]
+	}
+	return expanded[0], fallbacks, configured
+}
+
+// configuredHosts returns the hosts of the network as configured, before the environment variables were expanded.
+func configuredHosts(n config.Network) (string, []jsonFallbackHost) {
+	var fallbacks []jsonFallbackHost
+	for i, fallback := range n.FallbackHosts {
+		host := fallback.Host
+		if len(n.HostsEnv) == len(n.FallbackHosts)+1 {
+			host = n.HostsEnv[i+1]
+		}
+		fallbacks = append(fallbacks, jsonFallbackHost{Host: host, Key: fallback.Key})
+	}
+
+	if len(n.HostsEnv) == 0 {
+		return n.Host, fallbacks
+	}
+	return n.HostsEnv[0], fallbacks
+}
+
+// hasFallbackKeys checks if any fallback host has a key, so the network can only be saved in the advanced format.
+func hasFallbackKeys(fallbackHosts []jsonFallbackHost) bool {
+	for _, fallback := range fallbackHosts {
+		if fallback.Key != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // transformNetworksToJSON transforms config structure to json structures for saving.
 func transformNetworksToJSON(networks config.Networks) jsonNetworks {
 	jsonNetworks := jsonNetworks{}
 
 	for _, n := range networks {
-		if n.Key != "" {
+		_, fallbackHosts := configuredHosts(n)
+		if n.Key != "" || n.Secure || n.MaxMessageSize != 0 || n.RequestsPerSecond != 0 || hasFallbackKeys(fallbackHosts) {
 			jsonNetworks[n.Name] = transformAdvancedNetworkToJSON(n)
 		} else {
 			jsonNetworks[n.Name] = transformSimpleNetworkToJSON(n)
@@ -77,18 +159,25 @@ func transformNetworksToJSON(networks config.Networks) jsonNetworks {
 }
 
 func transformSimpleNetworkToJSON(n config.Network) jsonNetwork {
+	host, fallbackHosts := configuredHosts(n)
 	return jsonNetwork{
 		Simple: simpleNetwork{
-			Host: n.Host,
+			Host:          host,
+			FallbackHosts: fallbackHosts,
 		},
 	}
 }
 
 func transformAdvancedNetworkToJSON(n config.Network) jsonNetwork {
+	host, fallbackHosts := configuredHosts(n)
 	return jsonNetwork{
 		Advanced: advancedNetwork{
-			Host: n.Host,
-			Key:  n.Key,
+			Host:              host,
+			FallbackHosts:     fallbackHosts,
+			Key:               n.Key,
+			Secure:            n.Secure,
+			MaxMessageSize:    n.MaxMessageSize,
+			RequestsPerSecond: n.RequestsPerSecond,
 		},
 	}
 }
@@ -98,13 +187,72 @@ type jsonNetwork struct {
 	Advanced advancedNetwork
 }
 
+// simpleNetwork is either a single host or a list of hosts, where the first host
+// is used and the rest are fallback hosts.
 type simpleNetwork struct {
-	Host string `json:"host"`
+	Host          string
+	FallbackHosts []jsonFallbackHost
 }
 
 type advancedNetwork struct {
+	Host              string             `json:"host"`
+	FallbackHosts     []jsonFallbackHost `json:"fallbackHosts,omitempty"`
+	Key               string             `json:"key,omitempty"`
+	Secure            bool               `json:"secure,omitempty"`
+	MaxMessageSize    int                `json:"maxMessageSize,omitempty"`
+	RequestsPerSecond float64            `json:"requestsPerSecond,omitempty"`
+}
+
+// jsonFallbackHost is either a host or an object with the host and the key of the host.
+type jsonFallbackHost struct {
+	Host string
+	Key  string
+}
+
+type advancedFallbackHost struct {
 	Host string `json:"host"`
-	Key  string `json:"key"`
+	Key  string `json:"key,omitempty"`
+}
+
+func (j *jsonFallbackHost) UnmarshalJSON(b []byte) error {
+	var host string
+	if err := json.Unmarshal(b, &host); err == nil {
+		j.Host = host
+		return nil
+	}
+
+	var advanced advancedFallbackHost
+	err := json.Unmarshal(b, &advanced)
+	if err == nil {
+		j.Host = advanced.Host
+		j.Key = advanced.Key
+	}
+
+	return err
+}
+
+func (j jsonFallbackHost) MarshalJSON() ([]byte, error) {
+	if j.Key == "" {
+		return json.Marshal(j.Host)
+	}
+
+	return json.Marshal(advancedFallbackHost{Host: j.Host, Key: j.Key})
+}
+
+func (j jsonFallbackHost) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{
+				Type: "string",
+			},
+			{
+				Ref: "#/$defs/advancedFallbackHost",
+			},
+		},
+		Definitions: map[string]*jsonschema.Schema{
+			"advancedFallbackHost": jsonschema.Reflect(advancedFallbackHost{}),
+		},
+	}
 }
 
 func (j *jsonNetwork) UnmarshalJSON(b []byte) error {
@@ -115,19 +263,42 @@ func (j *jsonNetwork) UnmarshalJSON(b []byte) error {
 		return nil
 	}
 
+	var hosts []string
+	err = json.Unmarshal(b, &hosts)
+	if err == nil {
+		if len(hosts) > 0 {
+			j.Simple.Host = hosts[0]
+			for _, host := range hosts[1:] {
+				j.Simple.FallbackHosts = append(j.Simple.FallbackHosts, jsonFallbackHost{Host: host})
+			}
+		}
+		return nil
+	}
+
 	// ignore advanced schema from previous configuration format
 	var advanced advancedNetwork
 	err = json.Unmarshal(b, &advanced)
 	if err == nil {
 		j.Advanced.Host = advanced.Host
+		j.Advanced.FallbackHosts = advanced.FallbackHosts
 		j.Advanced.Key = advanced.Key
+		j.Advanced.Secure = advanced.Secure
+		j.Advanced.MaxMessageSize = advanced.MaxMessageSize
+		j.Advanced.RequestsPerSecond = advanced.RequestsPerSecond
 	}
 
 	return err
 }
 
 func (j jsonNetwork) MarshalJSON() ([]byte, error) {
-	if j.Simple != (simpleNetwork{}) {
+	if j.Simple.Host != "" {
+		if len(j.Simple.FallbackHosts) > 0 {
+			hosts := []string{j.Simple.Host}
+			for _, fallback := range j.Simple.FallbackHosts {
+				hosts = append(hosts, fallback.Host)
+			}
+			return json.Marshal(hosts)
+		}
 		return json.Marshal(j.Simple.Host)
 	}
 
@@ -161,9 +332,18 @@ func (j jsonNetwork) JSONSchema() *jsonschema.Schema {
 		},
 		Definitions: map[string]*jsonschema.Schema{
 			"simpleNetwork": {
-				Type: "string",
+				OneOf: []*jsonschema.Schema{
+					{
+						Type: "string",
+					},
+					{
+						Type:     "array",
+						Items:    &jsonschema.Schema{Type: "string"},
+						MinItems: 1,
+					},
+				},
 			},
 			"advancedNetwork": jsonschema.Reflect(advancedNetwork{}),
 		},
 	}
-}
\ No newline at end of file
+}
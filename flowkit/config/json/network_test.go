@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/flowkit/config"
 )
 
 func Test_ConfigNetworkSimple(t *testing.T) {
@@ -152,4 +154,161 @@ func Test_TransformConfigAdvanced(t *testing.T) {
 		_, err = jsonNetworks.transformToConfig()
 		assert.Error(t, err)
 	})
+	t.Run("should return secure advanced config without a key", func(t *testing.T) {
+		b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","secure":true}}`)
+		var jsonNetworks jsonNetworks
+		err := json.Unmarshal(b, &jsonNetworks)
+		assert.NoError(t, err)
+
+		conf, err := jsonNetworks.transformToConfig()
+		assert.NoError(t, err)
+
+		mainnet, err := conf.ByName("mainnet")
+		assert.NoError(t, err)
+		assert.Equal(t, "access.mainnet.nodes.onflow.org:9000", mainnet.Host)
+		assert.Equal(t, "", mainnet.Key)
+		assert.True(t, mainnet.Secure)
+
+		x, _ := json.Marshal(transformNetworksToJSON(conf))
+		assert.Equal(t, string(b), string(x))
+	})
+}
+
+func Test_TransformNetworkFallbackHosts(t *testing.T) {
+	t.Run("should parse list of hosts", func(t *testing.T) {
+		b := []byte(`{"testnet":["access.testnet.nodes.onflow.org:9000","access-001.devnet.nodes.onflow.org:9000"]}`)
+		var jsonNetworks jsonNetworks
+		err := json.Unmarshal(b, &jsonNetworks)
+		assert.NoError(t, err)
+
+		conf, err := jsonNetworks.transformToConfig()
+		assert.NoError(t, err)
+
+		testnet, err := conf.ByName("testnet")
+		assert.NoError(t, err)
+		assert.Equal(t, "access.testnet.nodes.onflow.org:9000", testnet.Host)
+		assert.Equal(t, []config.FallbackHost{{Host: "access-001.devnet.nodes.onflow.org:9000"}}, testnet.FallbackHosts)
+
+		x, _ := json.Marshal(transformNetworksToJSON(conf))
+		assert.Equal(t, string(b), string(x))
+	})
+	t.Run("should parse advanced fallback hosts", func(t *testing.T) {
+		b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","fallbackHosts":["access-001.mainnet.nodes.onflow.org:9000"],"secure":true}}`)
+		var jsonNetworks jsonNetworks
+		err := json.Unmarshal(b, &jsonNetworks)
+		assert.NoError(t, err)
+
+		conf, err := jsonNetworks.transformToConfig()
+		assert.NoError(t, err)
+
+		mainnet, err := conf.ByName("mainnet")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"access.mainnet.nodes.onflow.org:9000", "access-001.mainnet.nodes.onflow.org:9000"}, mainnet.Hosts())
+		assert.True(t, mainnet.Secure)
+
+		x, _ := json.Marshal(transformNetworksToJSON(conf))
+		assert.Equal(t, string(b), string(x))
+	})
+	t.Run("should parse fallback hosts with keys", func(t *testing.T) {
+		b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","fallbackHosts":[{"host":"access-001.mainnet.nodes.onflow.org:9000","key":"5000676131ad3e22d853a3f75a5b5d0db4236d08dd6612e2baad771014b5266a242bccecc3522ff7207ac357dbe4f225c709d9b273ac484fed5d13976a39bdcd"},"access-002.mainnet.nodes.onflow.org:9000"],"key":"5000676131ad3e22d853a3f75a5b5d0db4236d08dd6612e2baad771014b5266a242bccecc3522ff7207ac357dbe4f225c709d9b273ac484fed5d13976a39bdcd"}}`)
+		var jsonNetworks jsonNetworks
+		err := json.Unmarshal(b, &jsonNetworks)
+		assert.NoError(t, err)
+
+		conf, err := jsonNetworks.transformToConfig()
+		assert.NoError(t, err)
+
+		mainnet, err := conf.ByName("mainnet")
+		assert.NoError(t, err)
+		assert.Equal(t, []config.FallbackHost{
+			{Host: "access-001.mainnet.nodes.onflow.org:9000", Key: "5000676131ad3e22d853a3f75a5b5d0db4236d08dd6612e2baad771014b5266a242bccecc3522ff7207ac357dbe4f225c709d9b273ac484fed5d13976a39bdcd"},
+			{Host: "access-002.mainnet.nodes.onflow.org:9000"},
+		}, mainnet.FallbackHosts)
+
+		x, _ := json.Marshal(transformNetworksToJSON(conf))
+		assert.Equal(t, string(b), string(x))
+	})
+	t.Run("should return error for invalid fallback host key", func(t *testing.T) {
+		b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","fallbackHosts":[{"host":"access-001.mainnet.nodes.onflow.org:9000","key":"0x01"}]}}`)
+		var jsonNetworks jsonNetworks
+		err := json.Unmarshal(b, &jsonNetworks)
+		assert.NoError(t, err)
+
+		_, err = jsonNetworks.transformToConfig()
+		assert.EqualError(t, err, "invalid key 0x01 of fallback host access-001.mainnet.nodes.onflow.org:9000 for network with name mainnet")
+	})
+	t.Run("should return error for empty list of hosts", func(t *testing.T) {
+		b := []byte(`{"testnet":[]}`)
+		var jsonNetworks jsonNetworks
+		err := json.Unmarshal(b, &jsonNetworks)
+		assert.NoError(t, err)
+
+		_, err = jsonNetworks.transformToConfig()
+		assert.Error(t, err)
+	})
+}
+
+func Test_TransformNetworkMaxMessageSize(t *testing.T) {
+	t.Run("should parse max message size", func(t *testing.T) {
+		b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","maxMessageSize":52428800}}`)
+		var jsonNetworks jsonNetworks
+		err := json.Unmarshal(b, &jsonNetworks)
+		assert.NoError(t, err)
+
+		conf, err := jsonNetworks.transformToConfig()
+		assert.NoError(t, err)
+
+		mainnet, err := conf.ByName("mainnet")
+		assert.NoError(t, err)
+		assert.Equal(t, 52428800, mainnet.MaxMessageSize)
+
+		x, _ := json.Marshal(transformNetworksToJSON(conf))
+		assert.Equal(t, string(b), string(x))
+	})
+	t.Run("should return error for negative max message size", func(t *testing.T) {
+		b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","maxMessageSize":-1}}`)
+		var jsonNetworks jsonNetworks
+		err := json.Unmarshal(b, &jsonNetworks)
+		assert.NoError(t, err)
+
+		_, err = jsonNetworks.transformToConfig()
+		assert.EqualError(t, err, "invalid max message size -1 for network with name mainnet")
+	})
+}
+
+func Test_TransformNetworkRequestsPerSecond(t *testing.T) {
+	b := []byte(`{"testnet":{"host":"access.devnet.nodes.onflow.org:9000","requestsPerSecond":2.5}}`)
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	conf, err := jsonNetworks.transformToConfig()
+	assert.NoError(t, err)
+
+	testnet, err := conf.ByName("testnet")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, testnet.RequestsPerSecond)
+
+	x, _ := json.Marshal(transformNetworksToJSON(conf))
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_TransformNetworkEnvHosts(t *testing.T) {
+	t.Setenv("FLOW_TEST_ACCESS_HOST", "access.testnet.nodes.onflow.org")
+
+	b := []byte(`{"testnet":["${FLOW_TEST_ACCESS_HOST}:9000","access-${FLOW_TEST_UNSET}.devnet.nodes.onflow.org:9000"]}`)
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	assert.NoError(t, err)
+
+	conf, err := jsonNetworks.transformToConfig()
+	assert.NoError(t, err)
+
+	testnet, err := conf.ByName("testnet")
+	assert.NoError(t, err)
+	assert.Equal(t, "access.testnet.nodes.onflow.org:9000", testnet.Host)
+	assert.Equal(t, []config.FallbackHost{{Host: "access-${FLOW_TEST_UNSET}.devnet.nodes.onflow.org:9000"}}, testnet.FallbackHosts)
+
+	x, _ := json.Marshal(transformNetworksToJSON(conf))
+	assert.Equal(t, string(b), string(x))
 }
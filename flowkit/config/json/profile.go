@@ -0,0 +1,86 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"sort"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// jsonProfile overrides the networks, accounts and deployments, defined in the same format as the configuration.
+type jsonProfile struct {
+	Networks    jsonNetworks    `json:"networks,omitempty"`
+	Accounts    jsonAccounts    `json:"accounts,omitempty"`
+	Deployments jsonDeployments `json:"deployments,omitempty"`
+}
+
+// jsonProfiles maps the profile name, like staging, to the entries it overrides.
+type jsonProfiles map[string]jsonProfile
+
+// transformToConfig transforms json structures to config structure.
+func (j jsonProfiles) transformToConfig() (config.Profiles, error) {
+	names := make([]string, 0, len(j))
+	for name := range j {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var profiles config.Profiles
+	for _, name := range names {
+		networks, err := j[name].Networks.transformToConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		accounts, err := j[name].Accounts.transformToConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		deployments, err := j[name].Deployments.transformToConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		profiles = append(profiles, config.Profile{
+			Name:        name,
+			Networks:    networks,
+			Accounts:    accounts,
+			Deployments: deployments,
+		})
+	}
+
+	return profiles, nil
+}
+
+// transformProfilesToJSON transforms config structure to json structures for saving.
+func transformProfilesToJSON(profiles config.Profiles) jsonProfiles {
+	jsonProfiles := jsonProfiles{}
+
+	for _, p := range profiles {
+		jsonProfiles[p.Name] = jsonProfile{
+			Networks:    transformNetworksToJSON(p.Networks),
+			Accounts:    transformAccountsToJSON(p.Accounts),
+			Deployments: transformDeploymentsToJSON(p.Deployments),
+		}
+	}
+
+	return jsonProfiles
+}
@@ -0,0 +1,305 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/util"
+)
+
+// schemaNode is the subset of the configuration JSON schema used to validate the raw configuration.
+type schemaNode struct {
+	Ref                  string                 `json:"$ref"`
+	Definitions          map[string]*schemaNode `json:"$defs"`
+	OneOf                []*schemaNode          `json:"oneOf"`
+	Type                 string                 `json:"type"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	PatternProperties    map[string]*schemaNode `json:"patternProperties"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Required             []string               `json:"required"`
+	Items                *schemaNode            `json:"items"`
+	MinItems             int                    `json:"minItems"`
+}
+
+// schemaField is the top level field which is allowed in the configuration besides the configuration sections.
+const schemaField = "$schema"
+
+var simpleKey = regexp.MustCompile(`^[A-Za-z0-9_$-]+$`)
+
+// Validate checks the raw configuration against the configuration schema and returns all the values
+// that don't match it, with the path of each value and a suggestion for misspelled fields.
+func Validate(raw []byte) config.SchemaErrors {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return config.SchemaErrors{{Message: syntaxMessage(raw, err)}}
+	}
+
+	data, err := json.Marshal(GenerateSchema())
+	if err != nil {
+		return config.SchemaErrors{{Message: fmt.Sprintf("failed to generate the configuration schema: %s", err)}}
+	}
+	var root schemaNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		return config.SchemaErrors{{Message: fmt.Sprintf("failed to generate the configuration schema: %s", err)}}
+	}
+
+	v := &validator{definitions: root.Definitions}
+	return v.validate("", value, &root)
+}
+
+// syntaxMessage describes the JSON syntax error with the line and column it occurred at.
+func syntaxMessage(raw []byte, err error) string {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("invalid JSON: %s", err)
+	}
+
+	before := raw[:syntaxErr.Offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n') - 1
+	return fmt.Sprintf("invalid JSON at line %d, column %d: %s", line, column, err)
+}
+
+type validator struct {
+	definitions map[string]*schemaNode
+}
+
+// resolve follows the schema references to the schema definitions.
+func (v *validator) resolve(node *schemaNode) *schemaNode {
+	for node.Ref != "" {
+		definition, ok := v.definitions[strings.TrimPrefix(node.Ref, "#/$defs/")]
+		if !ok {
+			return &schemaNode{}
+		}
+		node = definition
+	}
+	return node
+}
+
+func (v *validator) validate(path string, value any, node *schemaNode) config.SchemaErrors {
+	node = v.resolve(node)
+
+	if len(node.OneOf) > 0 {
+		return v.validateOneOf(path, value, node.OneOf)
+	}
+
+	if node.Type != "" && !typeMatches(node.Type, value) {
+		return config.SchemaErrors{{
+			Path:    path,
+			Message: fmt.Sprintf("expected %s, found %s", node.Type, valueType(value)),
+		}}
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		return v.validateObject(path, value, node)
+	case []any:
+		return v.validateArray(path, value, node)
+	}
+	return nil
+}
+
+// validateOneOf reports the errors of the branch matching the value the closest, or the expected types if the
+// type of the value doesn't match any of the branches.
+func (v *validator) validateOneOf(path string, value any, branches []*schemaNode) config.SchemaErrors {
+	var closest config.SchemaErrors
+	matched := false
+	for _, branch := range branches {
+		if !v.matchesType(branch, value) {
+			continue
+		}
+
+		errs := v.validate(path, value, branch)
+		if len(errs) == 0 {
+			return nil
+		}
+		if !matched || len(errs) < len(closest) {
+			closest = errs
+			matched = true
+		}
+	}
+
+	if matched {
+		return closest
+	}
+	return config.SchemaErrors{{
+		Path:    path,
+		Message: fmt.Sprintf("expected %s, found %s", strings.Join(v.types(branches), " or "), valueType(value)),
+	}}
+}
+
+func (v *validator) validateObject(path string, value map[string]any, node *schemaNode) config.SchemaErrors {
+	errs := make(config.SchemaErrors, 0)
+
+	for _, field := range node.Required {
+		if _, ok := value[field]; !ok {
+			errs = append(errs, config.SchemaError{
+				Path:    path,
+				Message: fmt.Sprintf("missing required field \"%s\"", field),
+			})
+		}
+	}
+
+	known := make([]string, 0, len(node.Properties))
+	for field := range node.Properties {
+		known = append(known, field)
+	}
+	sort.Strings(known)
+
+	for _, field := range sortedKeys(value) {
+		fieldPath := childPath(path, field)
+
+		if property, ok := node.Properties[field]; ok {
+			errs = append(errs, v.validate(fieldPath, value[field], property)...)
+			continue
+		}
+		if pattern, ok := node.PatternProperties[".*"]; ok {
+			errs = append(errs, v.validate(fieldPath, value[field], pattern)...)
+			continue
+		}
+		if path == "" && field == schemaField {
+			continue
+		}
+		if string(node.AdditionalProperties) == "false" {
+			errs = append(errs, config.SchemaError{
+				Path:       fieldPath,
+				Message:    "unknown field",
+				Suggestion: util.ClosestMatch(field, known),
+			})
+		}
+	}
+
+	return errs
+}
+
+func (v *validator) validateArray(path string, value []any, node *schemaNode) config.SchemaErrors {
+	errs := make(config.SchemaErrors, 0)
+
+	if len(value) < node.MinItems {
+		errs = append(errs, config.SchemaError{
+			Path:    path,
+			Message: fmt.Sprintf("expected at least %d items, found %d", node.MinItems, len(value)),
+		})
+	}
+
+	if node.Items != nil {
+		for i, item := range value {
+			errs = append(errs, v.validate(fmt.Sprintf("%s[%d]", path, i), item, node.Items)...)
+		}
+	}
+
+	return errs
+}
+
+// matchesType checks whether the value has the type of the schema, without validating its content.
+func (v *validator) matchesType(node *schemaNode, value any) bool {
+	node = v.resolve(node)
+	if len(node.OneOf) > 0 {
+		for _, branch := range node.OneOf {
+			if v.matchesType(branch, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return node.Type == "" || typeMatches(node.Type, value)
+}
+
+// types returns the distinct types of the schemas in order.
+func (v *validator) types(nodes []*schemaNode) []string {
+	types := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, node := range nodes {
+		node = v.resolve(node)
+
+		nodeTypes := []string{node.Type}
+		if len(node.OneOf) > 0 {
+			nodeTypes = v.types(node.OneOf)
+		}
+		for _, t := range nodeTypes {
+			if t != "" && !seen[t] {
+				seen[t] = true
+				types = append(types, t)
+			}
+		}
+	}
+	return types
+}
+
+func typeMatches(schemaType string, value any) bool {
+	actual := valueType(value)
+	switch schemaType {
+	case "number":
+		return actual == "number" || actual == "integer"
+	default:
+		return actual == schemaType
+	}
+}
+
+// valueType returns the JSON schema type of the decoded value.
+func valueType(value any) string {
+	switch value := value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := value.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	default:
+		return "null"
+	}
+}
+
+// childPath returns the path of the field in the object at the path.
+func childPath(path string, field string) string {
+	if !simpleKey.MatchString(field) {
+		return fmt.Sprintf("%s[%q]", path, field)
+	}
+	if path == "" {
+		return field
+	}
+	return fmt.Sprintf("%s.%s", path, field)
+}
+
+func sortedKeys(value map[string]any) []string {
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,99 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		b := []byte(`{
+			"$schema": "https://developers.flow.com/schema.json",
+			"contracts": {
+				"Foo": "./foo.cdc",
+				"Bar": {
+					"source": "./bar.cdc",
+					"aliases": { "testnet": "9a0766d93b6608b7" }
+				}
+			},
+			"networks": {
+				"emulator": "127.0.0.1:3569",
+				"testnet": { "host": "access.devnet.nodes.onflow.org:9000", "key": "ba69f7d2e82b9edf25b103c195cd371cf0cc047ef8884a9bbe331e62982d46daeebf836f7445a2ac16741013b192959d8ad26998aff12f2adc67a99e1eb2988d" }
+			},
+			"accounts": {
+				"emulator-account": {
+					"address": "f8d6e0586b0a20c7",
+					"key": { "type": "hex", "index": 0, "privateKey": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47" }
+				}
+			},
+			"deployments": {
+				"emulator": { "emulator-account": ["Foo", { "name": "Bar", "args": [] }] }
+			}
+		}`)
+
+		assert.Empty(t, Validate(b))
+	})
+
+	t.Run("Misspelled section", func(t *testing.T) {
+		errs := Validate([]byte(`{ "contract": { "Foo": "./foo.cdc" } }`))
+		assert.Equal(t, config.SchemaErrors{{Path: "contract", Message: "unknown field", Suggestion: "contracts"}}, errs)
+	})
+
+	t.Run("Misspelled field", func(t *testing.T) {
+		errs := Validate([]byte(`{ "contracts": { "Foo": { "sorce": "./foo.cdc" } } }`))
+		assert.EqualError(t, errs, "invalid configuration:\n"+
+			"  - contracts.Foo: missing required field \"source\"\n"+
+			"  - contracts.Foo.sorce: unknown field, did you mean \"source\"?")
+	})
+
+	t.Run("Wrong type", func(t *testing.T) {
+		errs := Validate([]byte(`{ "networks": { "emulator": 3569 } }`))
+		assert.EqualError(t, errs, "invalid configuration:\n  - networks.emulator: expected string or array or object, found integer")
+	})
+
+	t.Run("Array item", func(t *testing.T) {
+		errs := Validate([]byte(`{ "deployments": { "emulator": { "emulator-account": ["Foo", { "name": "Bar", "args": [], "onlyif": "mocks" }] } } }`))
+		assert.EqualError(t, errs, "invalid configuration:\n  - deployments.emulator.emulator-account[1].onlyif: unknown field, did you mean \"onlyIf\"?")
+	})
+
+	t.Run("Quoted path", func(t *testing.T) {
+		errs := Validate([]byte(`{ "hooks": { "pre deploy": 1 } }`))
+		assert.EqualError(t, errs, "invalid configuration:\n  - hooks[\"pre deploy\"]: expected string, found integer")
+	})
+
+	t.Run("Syntax error", func(t *testing.T) {
+		errs := Validate([]byte("{\n  \"networks\": {,\n}"))
+		require.Len(t, errs, 1)
+		assert.Equal(t, "invalid JSON at line 2, column 16: invalid character ',' looking for beginning of object key string", errs[0].Message)
+	})
+}
+
+func Test_DeserializeSchemaErrors(t *testing.T) {
+	_, err := NewParser().Deserialize([]byte(`{ "emulators": { "default": { "port": "3569", "serviceAccount": "emulator-account" } } }`))
+
+	var schemaErrs config.SchemaErrors
+	require.ErrorAs(t, err, &schemaErrs)
+	assert.Equal(t, config.SchemaErrors{{Path: "emulators.default.port", Message: "expected integer, found string"}}, schemaErrs)
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // ErrDoesNotExist is error to be returned when config file does not exists.
@@ -68,22 +69,65 @@ type Loader struct {
 	readerWriter    ReaderWriter
 	configParsers   Parsers
 	LoadedLocations []string
+	// Conflicts are the values overridden by a later configuration when loading multiple configurations.
+	Conflicts []Conflict
+	// PrivateLocation is the location of the private configuration loaded on top of the project configuration.
+	PrivateLocation string
+	// UserLocation is the location of the user configuration loaded below the project configuration.
+	UserLocation string
+	overlay      *privateOverlay
+	userPath     string
+	user         *userEntries
+	profile      *profileOverlay
+	lockWait     time.Duration
+	// held are the paths locked with Lock.
+	held map[string]bool
 }
 
 // NewLoader returns a new loader.
 func NewLoader(readerWriter ReaderWriter) *Loader {
 	return &Loader{
 		readerWriter: readerWriter,
+		userPath:     UserPath(),
+		lockWait:     DefaultLockWait,
+		held:         make(map[string]bool),
 	}
 }
 
+// SetUserPath changes the path of the user configuration, an empty path disables the user configuration.
+func (l *Loader) SetUserPath(path string) {
+	l.userPath = path
+}
+
 // AddConfigParser adds a new configuration parser.
 func (l *Loader) AddConfigParser(format Parser) {
 	l.configParsers = append(l.configParsers, format)
 }
 
 // Save saves a configuration to a path with correct serializer.
+//
+// The configuration is locked while it is saved and replaced atomically, so concurrent invocations can't
+// corrupt it, saving fails with ErrLocked if another process holds the lock for longer than the lock wait.
+//
+// The networks and accounts only defined in the user configuration are not saved, and neither are the
+// entries overridden by the applied profile (see ApplyProfile), saving fails if they were changed.
+//
+// If the private configuration was loaded on top of the configuration at the path, the accounts
+// defined in the private configuration and the accounts added since are saved to the private
+// configuration, so their keys are never written to the project configuration.
 func (l *Loader) Save(conf *Config, path string) error {
+	conf, err := l.withoutProfile(conf)
+	if err != nil {
+		return err
+	}
+	conf = l.withoutUser(conf)
+	if l.overlay != nil && l.overlay.location == path {
+		return l.savePrivate(conf)
+	}
+	return l.save(conf, path)
+}
+
+func (l *Loader) save(conf *Config, path string) error {
 	configFormat := l.configParsers.FindForFormat(
 		filepath.Ext(path),
 	)
@@ -97,7 +141,7 @@ func (l *Loader) Save(conf *Config, path string) error {
 		return err
 	}
 
-	err = l.readerWriter.WriteFile(path, data, 0644)
+	err = l.writeLocked(path, data, l.fileMode(conf, path))
 	if err != nil {
 		return err
 	}
@@ -105,8 +149,33 @@ func (l *Loader) Save(conf *Config, path string) error {
 	return nil
 }
 
+// fileMode returns the permissions the configuration at the path is saved with.
+//
+// Configuration containing private keys is only accessible by the owner, other configuration keeps
+// the mode of the existing file.
+func (l *Loader) fileMode(conf *Config, path string) os.FileMode {
+	for _, account := range conf.Accounts {
+		if account.HasSecretKey() {
+			return 0600
+		}
+	}
+
+	if fs, ok := l.readerWriter.(lockingReaderWriter); ok {
+		if info, err := fs.Stat(path); err == nil {
+			return info.Mode().Perm()
+		}
+	}
+
+	return 0644
+}
+
 func (l *Loader) loadConfig(confPath string) (*Config, error) {
 	l.LoadedLocations = append(l.LoadedLocations, confPath)
+	return l.readConfig(confPath)
+}
+
+// readConfig reads and parses the configuration at the path without recording its location.
+func (l *Loader) readConfig(confPath string) (*Config, error) {
 	raw, err := l.loadFile(confPath)
 
 	if err != nil {
@@ -124,49 +193,88 @@ func (l *Loader) loadConfig(confPath string) (*Config, error) {
 
 // Load loads configuration from one or more file paths.
 //
-// If more than one path is specified, their contents are merged
-// together into on configuration object.
+// If more than one path is specified, their contents are merged together into one configuration
+// from left to right, so a value defined in a later file overrides the value of an earlier file:
+//   - contracts are merged by field, and their aliases by network
+//   - deployments are merged by the contracts deployed to the account on the network
+//   - environments are merged by variable
+//   - emulators, networks, accounts and hooks are replaced as a whole by name
+//   - secret arguments are combined
+//
+// The overridden values are reported in the Conflicts of the loader.
+//
+// If a single project configuration is loaded, the private configuration next to it (see PrivatePath)
+// is merged on top of it when it exists. The private configuration can only contain accounts, it can
+// override the keys of the project accounts and define the accounts only used locally.
+//
+// The networks and accounts of the user configuration (see UserPath) are added to the loaded
+// configuration if they are not defined by it.
 func (l *Loader) Load(paths []string) (*Config, error) {
+	l.LoadedLocations = nil
+	l.Conflicts = nil
+	l.PrivateLocation = ""
+	l.UserLocation = ""
+	l.overlay = nil
+	l.user = nil
+	l.profile = nil
+
+	conf, err := l.loadProject(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, err = l.loadUser(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.postprocess(conf)
+}
+
+// loadProject loads the project configuration from the paths.
+func (l *Loader) loadProject(paths []string) (*Config, error) {
 	// special case for default configs
 	// try to load local config and only if not found try to load global config
 	if IsDefaultPath(paths) {
-		conf, err := l.loadConfig(DefaultPath)
-		if err == nil { // if we could load it then process it
-			return l.postprocess(conf)
-		}
-		if !errors.Is(err, ErrDoesNotExist) {
-			return nil, err
+		for _, path := range LocalPaths {
+			conf, err := l.loadConfig(path)
+			if err == nil { // if we could load it then process it
+				return l.loadPrivate(conf, path)
+			}
+			if !errors.Is(err, ErrDoesNotExist) {
+				return nil, err
+			}
 		}
 
-		conf, err = l.loadConfig(GlobalPath())
+		conf, err := l.loadConfig(GlobalPath())
 		if err != nil {
 			return nil, ErrDoesNotExist
-		} else {
-			return l.postprocess(conf)
 		}
+		return conf, nil
 	}
 
-	var baseConf *Config
-	for _, confPath := range paths {
-		conf, err := l.loadConfig(confPath)
+	if len(paths) == 0 {
+		return nil, ErrDoesNotExist
+	}
+	if len(paths) == 1 {
+		conf, err := l.loadConfig(paths[0])
 		if err != nil {
 			return nil, err
 		}
-		// if first conf just assign as baseConf
-		if baseConf == nil {
-			baseConf = conf
-			continue
-		}
-
-		l.composeConfig(baseConf, conf)
+		return l.loadPrivate(conf, paths[0])
 	}
 
-	// if no config was loaded - neither local nor global return an error.
-	if baseConf == nil {
-		return nil, ErrDoesNotExist
+	m := newMerger()
+	for _, confPath := range paths {
+		conf, err := l.loadConfig(confPath)
+		if err != nil {
+			return nil, err
+		}
+		m.merge(conf, confPath)
 	}
+	l.Conflicts = m.conflicts
 
-	return l.postprocess(baseConf)
+	return m.base, nil
 }
 
 // preprocess does all manipulations to the raw configuration format happens here.
@@ -185,23 +293,6 @@ func (l *Loader) postprocess(baseConf *Config) (*Config, error) {
 	return baseConf, nil
 }
 
-// composeConfig merges multiple configuration files from right to left.
-func (l *Loader) composeConfig(baseConf *Config, conf *Config) {
-	// overwrite base config with the provided one
-	for _, account := range conf.Accounts {
-		baseConf.Accounts.AddOrUpdate(account.Name, account)
-	}
-	for _, network := range conf.Networks {
-		baseConf.Networks.AddOrUpdate(network)
-	}
-	for _, contract := range conf.Contracts {
-		baseConf.Contracts.AddOrUpdate(contract)
-	}
-	for _, deployment := range conf.Deployments {
-		baseConf.Deployments.AddOrUpdate(deployment)
-	}
-}
-
 // loadFile simple file loader.
 func (l *Loader) loadFile(path string) ([]byte, error) {
 	raw, err := l.readerWriter.ReadFile(path)
@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit/config/json"
+	"github.com/onflow/flow-cli/flowkit/config/toml"
 )
 
 var mockFS = afero.NewMemMapFs()
@@ -72,6 +73,30 @@ func Test_JSONSimple(t *testing.T) {
 	)
 }
 
+func Test_LoadSourceRoot(t *testing.T) {
+	base := []byte(`{
+		"sourceRoot": "cadence",
+		"contracts": { "Foo": "contracts/Foo.cdc" }
+	}`)
+	override := []byte(`{ "sourceRoot": "src" }`)
+	require.NoError(t, af.WriteFile("source-root.json", base, 0644))
+	require.NoError(t, af.WriteFile("source-root-override.json", override, 0644))
+
+	composer := config.NewLoader(af)
+	composer.AddConfigParser(json.NewParser())
+
+	conf, err := composer.Load([]string{"source-root.json"})
+	require.NoError(t, err)
+	assert.Equal(t, "cadence", conf.SourceRoot)
+	assert.Equal(t, "cadence/contracts/Foo.cdc", conf.SourceLocation(conf.Contracts[0].Location))
+
+	conf, err = composer.Load([]string{"source-root.json", "source-root-override.json"})
+	require.NoError(t, err)
+	assert.Equal(t, "src", conf.SourceRoot)
+	require.Len(t, composer.Conflicts, 1)
+	assert.Equal(t, "sourceRoot", composer.Conflicts[0].Path)
+}
+
 func Test_ErrorWhenMissingBothDefaultJsonFiles(t *testing.T) {
 	composer := config.NewLoader(afero.Afero{Fs: mockFS})
 	composer.AddConfigParser(json.NewParser())
@@ -185,7 +210,9 @@ func Test_ConfigurationWrongFormat(t *testing.T) {
 	composer.AddConfigParser(json.NewParser())
 
 	conf, err := composer.Load(config.DefaultPaths())
-	assert.EqualError(t, err, "configuration syntax error: json: cannot unmarshal string into Go struct field jsonConfig.deployments of type []json.deployment")
+	assert.EqualError(t, err, "invalid configuration:\n"+
+		"  - deployments.emulator-account.address: expected array, found string\n"+
+		"  - deployments.emulator-account.key: expected array, found string")
 	assert.Nil(t, conf)
 }
 
@@ -234,6 +261,33 @@ func Test_ComposeJSON(t *testing.T) {
 	assert.Equal(t, "0x3335dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7", adminAccount.Key.PrivateKey.String())
 }
 
+func Test_LoadTOML(t *testing.T) {
+	b := []byte(`
+[accounts.emulator-account]
+address = "f8d6e0586b0a20c7"
+key = "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+`)
+
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "flow.toml", b, 0644))
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+	composer.AddConfigParser(toml.NewParser())
+
+	conf, err := composer.Load(config.DefaultPaths())
+	require.NoError(t, err)
+
+	account, err := conf.Accounts.ByName("emulator-account")
+	require.NoError(t, err)
+	assert.Equal(t, "0x21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7", account.Key.PrivateKey.String())
+
+	require.NoError(t, composer.Save(conf, "flow.toml"))
+	saved, err := afero.ReadFile(mockFS, "flow.toml")
+	require.NoError(t, err)
+	assert.Contains(t, string(saved), "# Accounts used for signing the transactions")
+}
+
 func Test_ComposeCrossReference(t *testing.T) {
 	b := []byte(`{
 		"accounts": {
@@ -317,6 +371,80 @@ func Test_ComposeJSONOverwrite(t *testing.T) {
 	assert.Equal(t, "0x3335dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7", account.Key.PrivateKey.String())
 }
 
+func Test_ComposeMerge(t *testing.T) {
+	b := []byte(`{
+		"contracts": {
+			"Foo": {
+				"source": "./foo.cdc",
+				"aliases": { "testnet": "9a0766d93b6608b7", "mainnet": "f233dcee88fe0abe" }
+			},
+			"Bar": "./bar.cdc"
+		},
+		"networks": {
+			"emulator": "127.0.0.1:3569",
+			"testnet": "access.devnet.nodes.onflow.org:9000",
+			"mainnet": "access.mainnet.nodes.onflow.org:9000"
+		},
+		"accounts": {
+			"admin-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		},
+		"deployments": { "emulator": { "admin-account": ["Foo", "Bar"] } },
+		"environments": { "emulator": { "mocks": true, "feature": false } }
+	}`)
+
+	b2 := []byte(`{
+		"contracts": {
+			"Foo": {
+				"source": "./foo.cdc",
+				"aliases": { "testnet": "7e60df042a9c0868" }
+			}
+		},
+		"networks": { "testnet": "access.testnet.nodes.onflow.org:9000" },
+		"deployments": { "emulator": { "admin-account": [{ "name": "Bar", "args": [{ "type": "String", "value": "bar" }] }] } },
+		"environments": { "emulator": { "feature": true } }
+	}`)
+
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "flow.json", b, 0644))
+	require.NoError(t, afero.WriteFile(mockFS, "private.json", b2, 0644))
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+
+	conf, err := composer.Load([]string{"flow.json", "private.json"})
+	require.NoError(t, err)
+
+	foo, err := conf.Contracts.ByName("Foo")
+	require.NoError(t, err)
+	assert.Equal(t, "./foo.cdc", foo.Location)
+	assert.Equal(t, "7e60df042a9c0868", foo.Aliases.ByNetwork("testnet").Address.String())
+	assert.Equal(t, "f233dcee88fe0abe", foo.Aliases.ByNetwork("mainnet").Address.String())
+
+	testnet, err := conf.Networks.ByName("testnet")
+	require.NoError(t, err)
+	assert.Equal(t, "access.testnet.nodes.onflow.org:9000", testnet.Host)
+	assert.Len(t, conf.Networks, 3)
+
+	deployment := conf.Deployments.ByAccountAndNetwork("admin-account", "emulator")
+	require.NotNil(t, deployment)
+	require.Len(t, deployment.Contracts, 2)
+	assert.Equal(t, "Foo", deployment.Contracts[0].Name)
+	assert.Equal(t, "Bar", deployment.Contracts[1].Name)
+	assert.Len(t, deployment.Contracts[1].Args, 1)
+
+	assert.Equal(t, map[string]bool{"mocks": true, "feature": true}, conf.Environments.ByNetwork("emulator").Variables)
+
+	assert.ElementsMatch(t, []config.Conflict{
+		{Path: "contracts.Foo.aliases.testnet", Location: "flow.json", Override: "private.json"},
+		{Path: "networks.testnet", Location: "flow.json", Override: "private.json"},
+		{Path: "deployments.emulator.admin-account.Bar", Location: "flow.json", Override: "private.json"},
+		{Path: "environments.emulator.feature", Location: "flow.json", Override: "private.json"},
+	}, composer.Conflicts)
+}
+
 func Test_JSONEnv(t *testing.T) {
 	b := []byte(`{
 		"accounts": {
@@ -0,0 +1,214 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ErrLocked is returned when the configuration is locked by another process for longer than the lock wait.
+var ErrLocked = errors.New("configuration is locked by another process")
+
+// DefaultLockWait is how long saving waits for the configuration lock held by another process.
+const DefaultLockWait = 10 * time.Second
+
+// staleLockAge is the age of a lock after which it is considered left behind by a process that crashed.
+const staleLockAge = 2 * time.Minute
+
+// lockRetryInterval is how often the lock is retried while another process holds it.
+const lockRetryInterval = 50 * time.Millisecond
+
+// lockRefreshInterval is how often a lock held with Lock is refreshed, so it's not considered stale
+// by other processes while it's held for longer than the stale lock age.
+const lockRefreshInterval = staleLockAge / 4
+
+// lockingReaderWriter is implemented by the file systems supporting the configuration lock and atomic writes.
+//
+// The lock is a directory next to the configuration, since creating a directory fails if it already exists
+// on all platforms. Reader writers not implementing it, like custom in-memory loaders, write the configuration directly.
+type lockingReaderWriter interface {
+	Mkdir(name string, perm os.FileMode) error
+	Remove(name string) error
+	Rename(oldname, newname string) error
+	Stat(name string) (os.FileInfo, error)
+	Chtimes(name string, atime time.Time, mtime time.Time) error
+}
+
+// LockPath returns the path of the lock held while the configuration at the path is saved.
+func LockPath(path string) string {
+	return path + ".lock"
+}
+
+// SetLockWait changes how long saving waits for the lock held by another process, a zero wait fails immediately.
+func (l *Loader) SetLockWait(wait time.Duration) {
+	l.lockWait = wait
+}
+
+// Lock acquires the locks of the configurations at the paths until the returned unlock function is called,
+// so no other process saves them in the meantime. The configurations saved while holding the locks are
+// written without acquiring them again.
+//
+// Locking lets a configuration be loaded, changed and saved without another process saving it in between,
+// in which case the changes of one of the processes would be lost. The locks are refreshed while held,
+// so they are not considered stale by other processes.
+func (l *Loader) Lock(paths ...string) (func(), error) {
+	fs, ok := l.readerWriter.(lockingReaderWriter)
+	if !ok {
+		return func() {}, nil
+	}
+
+	// the paths are locked in the same order by all processes, so they can't wait for each other
+	sorted := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if !l.held[path] {
+			sorted = append(sorted, path)
+		}
+	}
+	sort.Strings(sorted)
+
+	unlocks := make([]func(), 0, len(sorted))
+	unlockAll := func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+	for _, path := range sorted {
+		if l.held[path] {
+			continue // listed more than once
+		}
+
+		path := path
+		unlock, err := l.lock(fs, path)
+		if err != nil {
+			unlockAll()
+			return nil, err
+		}
+		l.held[path] = true
+		unlocks = append(unlocks, func() {
+			delete(l.held, path)
+			unlock()
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(lockRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				for _, path := range sorted {
+					_ = fs.Chtimes(LockPath(path), now, now)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		unlockAll()
+	}, nil
+}
+
+// writeLocked writes the configuration data while holding its lock, the data is written to a temporary file
+// which then replaces the configuration, so other processes never read a partially written configuration.
+func (l *Loader) writeLocked(path string, data []byte, perm os.FileMode) error {
+	fs, ok := l.readerWriter.(lockingReaderWriter)
+	if !ok {
+		return l.readerWriter.WriteFile(path, data, perm)
+	}
+
+	if !l.held[path] {
+		unlock, err := l.lock(fs, path)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	tmp := path + ".tmp"
+	if err := l.readerWriter.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	if err := fs.Rename(tmp, path); err != nil {
+		_ = fs.Remove(tmp)
+		return fmt.Errorf("failed to replace configuration %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// lock acquires the lock of the configuration at the path, waiting for the lock wait if another process holds it.
+//
+// A lock older than the stale lock age is broken, since it was left behind by a process that didn't finish.
+func (l *Loader) lock(fs lockingReaderWriter, path string) (func(), error) {
+	lockPath := LockPath(path)
+	deadline := time.Now().Add(l.lockWait)
+
+	for {
+		err := fs.Mkdir(lockPath, 0700)
+		if err == nil {
+			return func() { _ = fs.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock configuration %s: %w", path, err)
+		}
+
+		if info, err := fs.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			breakStaleLock(fs, lockPath)
+			continue
+		}
+
+		if !time.Now().Before(deadline) {
+			return nil, fmt.Errorf(
+				"%w: %s is still locked after waiting %s, remove %s if no other flow command is running",
+				ErrLocked, path, l.lockWait, lockPath,
+			)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// breakStaleLock removes the stale lock by first renaming it to a unique path, since renaming is atomic only
+// one of the processes finding the stale lock moves it away, so another process can't remove a lock that
+// was acquired after the stale lock was broken.
+//
+// If the moved lock turns out not to be stale, because it was acquired by another process after the stale
+// lock was found, it's moved back.
+func breakStaleLock(fs lockingReaderWriter, lockPath string) {
+	stalePath := fmt.Sprintf("%s.stale-%d-%s", lockPath, os.Getpid(), strconv.FormatInt(time.Now().UnixNano(), 36))
+	if err := fs.Rename(lockPath, stalePath); err != nil {
+		return // already broken by another process
+	}
+
+	info, err := fs.Stat(stalePath)
+	if err == nil && time.Since(info.ModTime()) <= staleLockAge {
+		if err := fs.Rename(stalePath, lockPath); err == nil {
+			return
+		}
+	}
+	_ = fs.Remove(stalePath)
+}
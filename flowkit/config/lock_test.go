@@ -0,0 +1,236 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config_test
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/config/json"
+)
+
+// mapReaderWriter is a reader writer without support for locking.
+type mapReaderWriter map[string][]byte
+
+func (m mapReaderWriter) ReadFile(source string) ([]byte, error) {
+	data, ok := m[source]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return data, nil
+}
+
+func (m mapReaderWriter) WriteFile(filename string, data []byte, _ os.FileMode) error {
+	m[filename] = data
+	return nil
+}
+
+func Test_SaveLocked(t *testing.T) {
+	newLoader := func(rw config.ReaderWriter, wait time.Duration) *config.Loader {
+		loader := config.NewLoader(rw)
+		loader.AddConfigParser(json.NewParser())
+		loader.SetUserPath("")
+		loader.SetLockWait(wait)
+		return loader
+	}
+	conf := &config.Config{Networks: config.Networks{config.EmulatorNetwork}}
+
+	t.Run("Save atomically", func(t *testing.T) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		require.NoError(t, newLoader(fs, time.Second).Save(conf, "flow.json"))
+
+		loaded, err := newLoader(fs, time.Second).Load([]string{"flow.json"})
+		require.NoError(t, err)
+		assert.Len(t, loaded.Networks, 1)
+
+		for _, leftover := range []string{"flow.json.tmp", config.LockPath("flow.json")} {
+			exists, _ := fs.Exists(leftover)
+			assert.False(t, exists, leftover)
+		}
+	})
+
+	t.Run("Fail when locked", func(t *testing.T) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		require.NoError(t, fs.Mkdir(config.LockPath("flow.json"), 0700))
+
+		start := time.Now()
+		err := newLoader(fs, 100*time.Millisecond).Save(conf, "flow.json")
+		assert.ErrorIs(t, err, config.ErrLocked)
+		assert.EqualError(t, err, "configuration is locked by another process: flow.json is still locked after waiting 100ms, remove flow.json.lock if no other flow command is running")
+		assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+		exists, _ := fs.Exists("flow.json")
+		assert.False(t, exists)
+	})
+
+	t.Run("Wait for lock release", func(t *testing.T) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		require.NoError(t, fs.Mkdir(config.LockPath("flow.json"), 0700))
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			_ = fs.Remove(config.LockPath("flow.json"))
+		}()
+
+		require.NoError(t, newLoader(fs, 5*time.Second).Save(conf, "flow.json"))
+		exists, _ := fs.Exists("flow.json")
+		assert.True(t, exists)
+	})
+
+	t.Run("Remove stale lock", func(t *testing.T) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		lockPath := config.LockPath("flow.json")
+		require.NoError(t, fs.Mkdir(lockPath, 0700))
+		old := time.Now().Add(-time.Hour)
+		require.NoError(t, fs.Chtimes(lockPath, old, old))
+
+		require.NoError(t, newLoader(fs, 0).Save(conf, "flow.json"))
+	})
+
+	t.Run("Hold lock until unlocked", func(t *testing.T) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		loader := newLoader(fs, 0)
+
+		unlock, err := loader.Lock("flow.json", "flow.json")
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, newLoader(fs, 0).Save(conf, "flow.json"), config.ErrLocked)
+		require.NoError(t, loader.Save(conf, "flow.json"))
+
+		exists, _ := fs.Exists(config.LockPath("flow.json"))
+		assert.True(t, exists)
+
+		unlock()
+		require.NoError(t, newLoader(fs, 0).Save(conf, "flow.json"))
+	})
+
+	t.Run("Break stale lock once", func(t *testing.T) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		lockPath := config.LockPath("flow.json")
+		require.NoError(t, fs.Mkdir(lockPath, 0700))
+		old := time.Now().Add(-time.Hour)
+		require.NoError(t, fs.Chtimes(lockPath, old, old))
+
+		unlock, err := newLoader(fs, 0).Lock("flow.json")
+		require.NoError(t, err)
+		defer unlock()
+
+		// the lock acquired after breaking the stale lock is not broken by other processes
+		assert.ErrorIs(t, newLoader(fs, 0).Save(conf, "flow.json"), config.ErrLocked)
+
+		entries, err := fs.ReadDir(".")
+		require.NoError(t, err)
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		assert.Equal(t, []string{lockPath}, names)
+	})
+
+	t.Run("Concurrent saves", func(t *testing.T) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				conf := &config.Config{Networks: config.Networks{{
+					Name: fmt.Sprintf("network-%d", i),
+					Host: "127.0.0.1:3569",
+				}}}
+				errs <- newLoader(fs, 10*time.Second).Save(conf, "flow.json")
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		loaded, err := newLoader(fs, time.Second).Load([]string{"flow.json"})
+		require.NoError(t, err)
+		assert.Len(t, loaded.Networks, 1)
+	})
+
+	t.Run("Save without locking support", func(t *testing.T) {
+		rw := mapReaderWriter{}
+		require.NoError(t, newLoader(rw, 0).Save(conf, "flow.json"))
+		assert.Contains(t, string(rw["flow.json"]), "emulator")
+	})
+}
+
+func Test_SaveFileMode(t *testing.T) {
+	newLoader := func(rw config.ReaderWriter) *config.Loader {
+		loader := config.NewLoader(rw)
+		loader.AddConfigParser(json.NewParser())
+		loader.SetUserPath("")
+		return loader
+	}
+
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, make([]byte, crypto.MinSeedLength))
+	require.NoError(t, err)
+	withAccount := func(address flow.Address) *config.Config {
+		return &config.Config{Accounts: config.Accounts{{
+			Name:    "alice",
+			Address: address,
+			Key:     config.NewDefaultAccountKey(privateKey),
+		}}}
+	}
+
+	mode := func(fs afero.Afero) os.FileMode {
+		info, err := fs.Stat("flow.json")
+		require.NoError(t, err)
+		return info.Mode().Perm()
+	}
+
+	t.Run("Restrict configuration with secrets", func(t *testing.T) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		require.NoError(t, fs.WriteFile("flow.json", []byte("{}"), 0644))
+
+		require.NoError(t, newLoader(fs).Save(withAccount(flow.HexToAddress("0x01")), "flow.json"))
+		assert.Equal(t, os.FileMode(0600), mode(fs))
+	})
+
+	t.Run("Keep mode without secrets", func(t *testing.T) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		require.NoError(t, fs.WriteFile("flow.json", []byte("{}"), 0640))
+
+		require.NoError(t, newLoader(fs).Save(&config.Config{Networks: config.Networks{config.EmulatorNetwork}}, "flow.json"))
+		assert.Equal(t, os.FileMode(0640), mode(fs))
+	})
+
+	t.Run("Emulator service key is not secret", func(t *testing.T) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+
+		require.NoError(t, newLoader(fs).Save(withAccount(flow.ServiceAddress(flow.Emulator)), "flow.json"))
+		assert.Equal(t, os.FileMode(0644), mode(fs))
+	})
+}
@@ -0,0 +1,224 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"golang.org/x/exp/slices"
+)
+
+// Conflict is a configuration value defined in more than one configuration file, the value
+// of the later file overrides the value of the earlier file when the files are merged.
+//
+// The values are not part of the conflict, since they can contain private keys.
+type Conflict struct {
+	// Path of the overridden value, for example contracts.Foo.aliases.testnet.
+	Path string
+	// Location of the configuration file defining the overridden value.
+	Location string
+	// Override is the location of the configuration file overriding the value.
+	Override string
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s defined in %s is overridden by %s", c.Path, c.Location, c.Override)
+}
+
+// merger merges configurations from left to right, keeping the location each value was defined in
+// to report the values overridden by a later configuration.
+type merger struct {
+	base      *Config
+	origins   map[string]string
+	conflicts []Conflict
+}
+
+func newMerger() *merger {
+	return &merger{
+		base:    &Config{},
+		origins: make(map[string]string),
+	}
+}
+
+// set records the value at the path is defined in the location, and reports a conflict
+// if the value was already defined with a different value in another location.
+func (m *merger) set(path string, location string, previous any, value any, defined bool) {
+	if origin, ok := m.origins[path]; ok && defined && origin != location && !reflect.DeepEqual(previous, value) {
+		m.conflicts = append(m.conflicts, Conflict{Path: path, Location: origin, Override: location})
+	}
+	m.origins[path] = location
+}
+
+// merge the configuration loaded from the location into the base configuration.
+//
+// Contracts are merged by field and their aliases by network, deployments by the deployed contract
+// and environments by variable. The other entries are replaced as a whole, since their fields
+// depend on each other, like the account address and key.
+func (m *merger) merge(conf *Config, location string) {
+	if conf.SourceRoot != "" {
+		m.set("sourceRoot", location, m.base.SourceRoot, conf.SourceRoot, m.base.SourceRoot != "")
+		m.base.SourceRoot = conf.SourceRoot
+	}
+
+	for _, emulator := range conf.Emulators {
+		var existing *Emulator
+		for i, e := range m.base.Emulators {
+			if e.Name == emulator.Name {
+				existing = &m.base.Emulators[i]
+			}
+		}
+		m.set(fmt.Sprintf("emulators.%s", emulator.Name), location, existing, &emulator, existing != nil)
+		m.base.Emulators.AddOrUpdate(emulator.Name, emulator)
+	}
+
+	for _, contract := range conf.Contracts {
+		m.mergeContract(contract, location)
+	}
+
+	for _, network := range conf.Networks {
+		existing, err := m.base.Networks.ByName(network.Name)
+		m.set(fmt.Sprintf("networks.%s", network.Name), location, existing, &network, err == nil)
+		m.base.Networks.AddOrUpdate(network)
+	}
+
+	for _, account := range conf.Accounts {
+		existing, err := m.base.Accounts.ByName(account.Name)
+		m.set(fmt.Sprintf("accounts.%s", account.Name), location, existing, &account, err == nil)
+		m.base.Accounts.AddOrUpdate(account.Name, account)
+	}
+
+	for _, deployment := range conf.Deployments {
+		m.mergeDeployment(deployment, location)
+	}
+
+	for _, hook := range conf.Hooks {
+		existing, err := m.base.Hooks.ByName(hook.Name)
+		m.set(fmt.Sprintf("hooks.%s", hook.Name), location, existing, &hook, err == nil)
+		m.base.Hooks.AddOrUpdate(hook)
+	}
+
+	for _, environment := range conf.Environments {
+		m.mergeEnvironment(environment, location)
+	}
+
+	for _, profile := range conf.Profiles {
+		existing, err := m.base.Profiles.ByName(profile.Name)
+		m.set(fmt.Sprintf("profiles.%s", profile.Name), location, existing, &profile, err == nil)
+		m.base.Profiles.AddOrUpdate(profile)
+	}
+
+	for _, name := range conf.SecretArguments {
+		if !slices.Contains(m.base.SecretArguments, name) {
+			m.base.SecretArguments = append(m.base.SecretArguments, name)
+		}
+	}
+}
+
+func (m *merger) mergeContract(contract Contract, location string) {
+	path := fmt.Sprintf("contracts.%s", contract.Name)
+
+	merged := Contract{Name: contract.Name}
+	if existing, err := m.base.Contracts.ByName(contract.Name); err == nil {
+		merged = *existing
+		merged.Aliases = append(Aliases{}, existing.Aliases...)
+	}
+
+	fields := []struct {
+		name     string
+		existing *string
+		value    string
+	}{
+		{"source", &merged.Location, contract.Location},
+		{"onlyIf", &merged.OnlyIf, contract.OnlyIf},
+		{"mockSource", &merged.MockSource, contract.MockSource},
+		{"version", &merged.Version, contract.Version},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		m.set(fmt.Sprintf("%s.%s", path, field.name), location, *field.existing, field.value, *field.existing != "")
+		*field.existing = field.value
+	}
+
+	for _, alias := range contract.Aliases {
+		aliasPath := fmt.Sprintf("%s.aliases.%s", path, alias.Network)
+
+		replaced := false
+		for i, previous := range merged.Aliases {
+			if previous.Network == alias.Network {
+				m.set(aliasPath, location, previous.Address, alias.Address, true)
+				merged.Aliases[i].Address = alias.Address
+				replaced = true
+			}
+		}
+		if !replaced {
+			m.set(aliasPath, location, nil, alias.Address, false)
+			merged.Aliases.Add(alias.Network, alias.Address)
+		}
+	}
+
+	m.base.Contracts.AddOrUpdate(merged)
+}
+
+func (m *merger) mergeDeployment(deployment Deployment, location string) {
+	path := fmt.Sprintf("deployments.%s.%s", deployment.Network, deployment.Account)
+
+	merged := Deployment{Network: deployment.Network, Account: deployment.Account}
+	if existing := m.base.Deployments.ByAccountAndNetwork(deployment.Account, deployment.Network); existing != nil {
+		merged.Contracts = append(merged.Contracts, existing.Contracts...)
+	}
+
+	for _, contract := range deployment.Contracts {
+		contractPath := fmt.Sprintf("%s.%s", path, contract.Name)
+
+		replaced := false
+		for i, existing := range merged.Contracts {
+			if existing.Name == contract.Name {
+				m.set(contractPath, location, existing, contract, true)
+				merged.Contracts[i] = contract
+				replaced = true
+			}
+		}
+		if !replaced {
+			m.set(contractPath, location, nil, contract, false)
+			merged.Contracts = append(merged.Contracts, contract)
+		}
+	}
+
+	m.base.Deployments.AddOrUpdate(merged)
+}
+
+func (m *merger) mergeEnvironment(environment Environment, location string) {
+	merged := Environment{Network: environment.Network, Variables: make(map[string]bool)}
+	if existing := m.base.Environments.ByNetwork(environment.Network); existing != nil {
+		for name, value := range existing.Variables {
+			merged.Variables[name] = value
+		}
+	}
+
+	for name, value := range environment.Variables {
+		previous, defined := merged.Variables[name]
+		m.set(fmt.Sprintf("environments.%s.%s", environment.Network, name), location, previous, value, defined)
+		merged.Variables[name] = value
+	}
+
+	m.base.Environments.AddOrUpdate(merged)
+}
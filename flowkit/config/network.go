@@ -51,12 +51,45 @@ var (
 type Networks []Network
 
 // Network defines the configuration for a Flow network.
+//
+// Fallback hosts are used in order when the host is unreachable.
+// Max message size limits the size of the received gRPC messages in bytes, if zero the default limit is used.
+// Requests per second limits the rate of the calls to the access node, if zero the calls are not limited.
+//
+// The network contains slices, so it can't be compared with the == operator.
 type Network struct {
-	Name string
+	Name              string
+	Host              string
+	FallbackHosts     []FallbackHost
+	Key               string
+	Secure            bool
+	MaxMessageSize    int
+	RequestsPerSecond float64
+	// RequestID is sent with all the requests to the network and is not part of the configuration.
+	RequestID string
+	// HostsEnv are the configured hosts referencing the environment variables, saved instead of the expanded hosts.
+	HostsEnv []string
+}
+
+// FallbackHost is an access node host used when the network host is unreachable.
+//
+// The key of the host is used for the secure connection to the host, the network key is never used
+// for the fallback hosts since each access node has its own key.
+type FallbackHost struct {
 	Host string
 	Key  string
 }
 
+// Hosts returns the host followed by all the fallback hosts.
+func (n Network) Hosts() []string {
+	hosts := make([]string, 0, len(n.FallbackHosts)+1)
+	hosts = append(hosts, n.Host)
+	for _, fallback := range n.FallbackHosts {
+		hosts = append(hosts, fallback.Host)
+	}
+	return hosts
+}
+
 // ByName get network by name or return an error if not found.
 func (n *Networks) ByName(name string) (*Network, error) {
 	for _, network := range *n {
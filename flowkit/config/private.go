@@ -0,0 +1,109 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// privateOverlay is the private configuration loaded on top of a project configuration.
+type privateOverlay struct {
+	// location of the project configuration.
+	location string
+	// accounts defined in the project configuration when it was loaded or last saved.
+	accounts Accounts
+	// private are the names of the accounts defined in the private configuration.
+	private map[string]bool
+}
+
+// loadPrivate merges the private configuration on top of the project configuration loaded from
+// the location, the project configuration is returned unchanged if there is no private configuration.
+func (l *Loader) loadPrivate(conf *Config, location string) (*Config, error) {
+	privatePath := PrivatePath(location)
+	private, err := l.readConfig(privatePath)
+	if errors.Is(err, ErrDoesNotExist) {
+		return conf, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load private configuration %s: %w", privatePath, err)
+	}
+
+	if private.SourceRoot != "" || len(private.Emulators) > 0 || len(private.Contracts) > 0 || len(private.Networks) > 0 ||
+		len(private.Deployments) > 0 || len(private.Environments) > 0 || len(private.Hooks) > 0 ||
+		len(private.Profiles) > 0 {
+		return nil, fmt.Errorf("private configuration %s can only contain accounts", privatePath)
+	}
+
+	overlay := &privateOverlay{
+		location: location,
+		accounts: append(Accounts{}, conf.Accounts...),
+		private:  make(map[string]bool),
+	}
+	for _, account := range private.Accounts {
+		overlay.private[account.Name] = true
+	}
+
+	m := newMerger()
+	m.merge(conf, location)
+	m.merge(private, privatePath)
+
+	l.Conflicts = m.conflicts
+	l.PrivateLocation = privatePath
+	l.overlay = overlay
+
+	return m.base, nil
+}
+
+// savePrivate saves the configuration split between the project configuration and its private configuration.
+//
+// The accounts defined in the private configuration and the accounts not defined in the project configuration
+// are saved to the private configuration, while the project configuration keeps its own definition of the
+// accounts overridden by the private configuration.
+func (l *Loader) savePrivate(conf *Config) error {
+	projectConf := *conf
+	projectConf.Accounts = Accounts{}
+	privateConf := &Config{}
+	private := make(map[string]bool)
+
+	for _, account := range conf.Accounts {
+		original, err := l.overlay.accounts.ByName(account.Name)
+		if err == nil && !l.overlay.private[account.Name] {
+			projectConf.Accounts = append(projectConf.Accounts, account)
+			continue
+		}
+
+		privateConf.Accounts = append(privateConf.Accounts, account)
+		private[account.Name] = true
+		if err == nil {
+			projectConf.Accounts = append(projectConf.Accounts, *original)
+		}
+	}
+
+	if err := l.save(&projectConf, l.overlay.location); err != nil {
+		return err
+	}
+	if err := l.save(privateConf, PrivatePath(l.overlay.location)); err != nil {
+		return err
+	}
+
+	l.overlay.accounts = projectConf.Accounts
+	l.overlay.private = private
+	return nil
+}
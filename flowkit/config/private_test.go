@@ -0,0 +1,149 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/config/json"
+)
+
+const (
+	projectKey = "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+	privateKey = "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+)
+
+func Test_PrivatePath(t *testing.T) {
+	assert.Equal(t, "flow.private.json", config.PrivatePath("flow.json"))
+	assert.Equal(t, "project/flow.private.toml", config.PrivatePath("project/flow.toml"))
+}
+
+func Test_PrivateOverlay(t *testing.T) {
+	project := []byte(`{
+		"contracts": { "Foo": "./foo.cdc" },
+		"networks": { "emulator": "127.0.0.1:3569" },
+		"accounts": {
+			"emulator-account": { "address": "f8d6e0586b0a20c7", "key": "` + projectKey + `" },
+			"alice": { "address": "01cf0e2f2f715450", "key": "` + projectKey + `" }
+		},
+		"deployments": { "emulator": { "alice": ["Foo"] } }
+	}`)
+	private := []byte(`{
+		"accounts": {
+			"alice": { "address": "01cf0e2f2f715450", "key": "` + privateKey + `" },
+			"bob": { "address": "179b6b1cb6755e31", "key": "` + privateKey + `" }
+		}
+	}`)
+
+	newLoader := func(t *testing.T, files map[string][]byte) (*config.Loader, afero.Afero) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		for path, content := range files {
+			require.NoError(t, fs.WriteFile(path, content, 0644))
+		}
+		loader := config.NewLoader(fs)
+		loader.AddConfigParser(json.NewParser())
+		return loader, fs
+	}
+
+	t.Run("Load", func(t *testing.T) {
+		loader, _ := newLoader(t, map[string][]byte{"flow.json": project, "flow.private.json": private})
+
+		conf, err := loader.Load(config.DefaultPaths())
+		require.NoError(t, err)
+		assert.Equal(t, "flow.private.json", loader.PrivateLocation)
+		assert.Equal(t, []string{"flow.json"}, loader.LoadedLocations)
+
+		alice, err := conf.Accounts.ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, privateKey, alice.Key.PrivateKey.String()[2:])
+
+		_, err = conf.Accounts.ByName("bob")
+		require.NoError(t, err)
+		assert.Len(t, conf.Accounts, 3)
+
+		assert.Equal(t, []config.Conflict{
+			{Path: "accounts.alice", Location: "flow.json", Override: "flow.private.json"},
+		}, loader.Conflicts)
+	})
+
+	t.Run("Load without private", func(t *testing.T) {
+		loader, _ := newLoader(t, map[string][]byte{"flow.json": project})
+
+		conf, err := loader.Load([]string{"flow.json"})
+		require.NoError(t, err)
+		assert.Empty(t, loader.PrivateLocation)
+		assert.Len(t, conf.Accounts, 2)
+	})
+
+	t.Run("Save", func(t *testing.T) {
+		loader, fs := newLoader(t, map[string][]byte{"flow.json": project, "flow.private.json": private})
+
+		conf, err := loader.Load([]string{"flow.json"})
+		require.NoError(t, err)
+
+		carol := conf.Accounts[0]
+		carol.Name = "carol"
+		conf.Accounts.AddOrUpdate("carol", carol)
+		conf.Accounts.Remove("bob")
+		require.NoError(t, loader.Save(conf, "flow.json"))
+
+		projectConf, err := json.NewParser().Deserialize(readFile(t, fs, "flow.json"))
+		require.NoError(t, err)
+		assert.Len(t, projectConf.Accounts, 2)
+		alice, err := projectConf.Accounts.ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, projectKey, alice.Key.PrivateKey.String()[2:])
+
+		privateConf, err := json.NewParser().Deserialize(readFile(t, fs, "flow.private.json"))
+		require.NoError(t, err)
+		require.Len(t, privateConf.Accounts, 2)
+		alice, err = privateConf.Accounts.ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, privateKey, alice.Key.PrivateKey.String()[2:])
+		_, err = privateConf.Accounts.ByName("carol")
+		require.NoError(t, err)
+		assert.Empty(t, privateConf.Contracts)
+
+		// the saved configuration loads the same accounts
+		reloaded, err := loader.Load([]string{"flow.json"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, conf.Accounts, reloaded.Accounts)
+	})
+
+	t.Run("Fail not accounts", func(t *testing.T) {
+		loader, _ := newLoader(t, map[string][]byte{
+			"flow.json":         project,
+			"flow.private.json": []byte(`{ "networks": { "testnet": "access.devnet.nodes.onflow.org:9000" } }`),
+		})
+
+		_, err := loader.Load([]string{"flow.json"})
+		assert.EqualError(t, err, "private configuration flow.private.json can only contain accounts")
+	})
+}
+
+func readFile(t *testing.T, fs afero.Afero, path string) []byte {
+	raw, err := fs.ReadFile(path)
+	require.NoError(t, err)
+	return raw
+}
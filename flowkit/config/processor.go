@@ -25,11 +25,21 @@ import (
 // processorRun all pre-processors.
 func processorRun(raw []byte) []byte {
 	type config struct {
-		Accounts    map[string]map[string]any `json:"accounts,omitempty"`
-		Contracts   any                       `json:"contracts,omitempty"`
-		Networks    any                       `json:"networks,omitempty"`
-		Deployments any                       `json:"deployments,omitempty"`
-		Emulators   any                       `json:"emulators,omitempty"`
+		SourceRoot      string                    `json:"sourceRoot,omitempty"`
+		Accounts        map[string]map[string]any `json:"accounts,omitempty"`
+		Contracts       any                       `json:"contracts,omitempty"`
+		Networks        any                       `json:"networks,omitempty"`
+		Deployments     any                       `json:"deployments,omitempty"`
+		Emulators       any                       `json:"emulators,omitempty"`
+		Hooks           any                       `json:"hooks,omitempty"`
+		Environments    any                       `json:"environments,omitempty"`
+		Profiles        any                       `json:"profiles,omitempty"`
+		SecretArguments any                       `json:"secretArguments,omitempty"`
+	}
+
+	// configuration in other formats is left for its parser
+	if !json.Valid(raw) {
+		return raw
 	}
 
 	var conf config
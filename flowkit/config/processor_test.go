@@ -65,3 +65,19 @@ func Test_PrivateConfigFileAccounts(t *testing.T) {
 			}
 		}`, string(processorRun(b)))
 }
+
+func Test_ProcessorKeepsSections(t *testing.T) {
+	b := []byte(`{
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		},
+		"hooks": {
+			"pre-deploy": "./scripts/gen.sh"
+		},
+		"environments": {
+			"emulator": { "mocks": true }
+		}
+	}`)
+
+	assert.JSONEq(t, string(b), string(processorRun(b)))
+}
@@ -0,0 +1,229 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Profile overrides the networks, accounts and deployments of the configuration for an environment,
+// like staging or production, so a single configuration can be used for multiple environments.
+//
+// Networks and accounts replace the entries with the same name, and the deployments replace all
+// the deployments on the same network.
+type Profile struct {
+	Name        string
+	Networks    Networks
+	Accounts    Accounts
+	Deployments Deployments
+}
+
+type Profiles []Profile
+
+// ByName get profile by name or return an error if it doesn't exist.
+func (p *Profiles) ByName(name string) (*Profile, error) {
+	for i := range *p {
+		if (*p)[i].Name == name {
+			return &(*p)[i], nil
+		}
+	}
+
+	if len(*p) == 0 {
+		return nil, fmt.Errorf("profile %s does not exist, no profiles are defined in the configuration", name)
+	}
+	return nil, fmt.Errorf("profile %s does not exist, available profiles: %s", name, strings.Join(p.Names(), ", "))
+}
+
+// AddOrUpdate add new or update if already present.
+func (p *Profiles) AddOrUpdate(profile Profile) {
+	for i, existing := range *p {
+		if existing.Name == profile.Name {
+			(*p)[i] = profile
+			return
+		}
+	}
+
+	*p = append(*p, profile)
+}
+
+// Names returns the sorted names of the profiles.
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(*p))
+	for _, profile := range *p {
+		names = append(names, profile.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// deploymentNetworks returns the names of the networks the profile deploys to.
+func (p *Profile) deploymentNetworks() map[string]bool {
+	networks := make(map[string]bool)
+	for _, deployment := range p.Deployments {
+		networks[deployment.Network] = true
+	}
+	return networks
+}
+
+// WithProfile returns the configuration with the profile applied on top of it, the returned
+// configuration is validated and doesn't contain the profiles.
+func (c *Config) WithProfile(name string) (*Config, error) {
+	profile, err := c.Profiles.ByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := *c
+	conf.Profiles = nil
+	conf.Networks = append(Networks{}, c.Networks...)
+	conf.Accounts = append(Accounts{}, c.Accounts...)
+	conf.Deployments = Deployments{}
+
+	overridden := profile.deploymentNetworks()
+	for _, deployment := range c.Deployments {
+		if !overridden[deployment.Network] {
+			conf.Deployments = append(conf.Deployments, deployment)
+		}
+	}
+
+	for _, network := range profile.Networks {
+		conf.Networks.AddOrUpdate(network)
+	}
+	for _, account := range profile.Accounts {
+		conf.Accounts.AddOrUpdate(account.Name, account)
+	}
+	for _, deployment := range profile.Deployments {
+		conf.Deployments.AddOrUpdate(deployment)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", name, err)
+	}
+
+	return &conf, nil
+}
+
+// ApplyProfile returns the configuration with the profile applied on top of it.
+//
+// The entries defined by the profile are not saved, saving the configuration restores the networks,
+// accounts and deployments the profile overrides, so the profile only applies to the current invocation.
+// Saving fails if the entries overridden by the profile were changed.
+func (l *Loader) ApplyProfile(conf *Config, name string) (*Config, error) {
+	applied, err := conf.WithProfile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, _ := conf.Profiles.ByName(name)
+	l.profile = &profileOverlay{
+		profile: *profile,
+		base:    *conf,
+	}
+	return applied, nil
+}
+
+// profileOverlay is the profile applied on top of the loaded configuration.
+type profileOverlay struct {
+	profile Profile
+	// base is the configuration before the profile was applied.
+	base Config
+}
+
+// withoutProfile returns the configuration with the networks, accounts and deployments overridden by
+// the profile restored to their definition before the profile was applied, and the profiles added back.
+//
+// An error is returned if the entries overridden by the profile were changed or removed, since their
+// changes would otherwise be lost when the entries are restored.
+func (l *Loader) withoutProfile(conf *Config) (*Config, error) {
+	if l.profile == nil {
+		return conf, nil
+	}
+
+	profile := &l.profile.profile
+	base := &l.profile.base
+
+	for _, network := range profile.Networks {
+		changed, err := conf.Networks.ByName(network.Name)
+		if err != nil || !reflect.DeepEqual(*changed, network) {
+			return nil, l.profileChangeError("network", network.Name)
+		}
+	}
+	for _, account := range profile.Accounts {
+		changed, err := conf.Accounts.ByName(account.Name)
+		if err != nil || !reflect.DeepEqual(*changed, account) {
+			return nil, l.profileChangeError("account", account.Name)
+		}
+	}
+
+	overridden := profile.deploymentNetworks()
+	for network := range overridden {
+		if !reflect.DeepEqual(conf.Deployments.ByNetwork(network), profile.Deployments.ByNetwork(network)) {
+			return nil, l.profileChangeError("deployments on network", network)
+		}
+	}
+
+	project := *conf
+	project.Profiles = base.Profiles
+
+	project.Networks = Networks{}
+	for _, network := range conf.Networks {
+		if _, err := profile.Networks.ByName(network.Name); err == nil {
+			if original, err := base.Networks.ByName(network.Name); err == nil {
+				project.Networks = append(project.Networks, *original)
+			}
+			continue
+		}
+		project.Networks = append(project.Networks, network)
+	}
+
+	project.Accounts = Accounts{}
+	for _, account := range conf.Accounts {
+		if _, err := profile.Accounts.ByName(account.Name); err == nil {
+			if original, err := base.Accounts.ByName(account.Name); err == nil {
+				project.Accounts = append(project.Accounts, *original)
+			}
+			continue
+		}
+		project.Accounts = append(project.Accounts, account)
+	}
+
+	project.Deployments = Deployments{}
+	for _, deployment := range conf.Deployments {
+		if !overridden[deployment.Network] {
+			project.Deployments = append(project.Deployments, deployment)
+		}
+	}
+	for _, deployment := range base.Deployments {
+		if overridden[deployment.Network] {
+			project.Deployments = append(project.Deployments, deployment)
+		}
+	}
+
+	return &project, nil
+}
+
+func (l *Loader) profileChangeError(kind string, name string) error {
+	return fmt.Errorf(
+		"can't save the changes to the %s %s, it's overridden by the profile %s, edit the profile instead or save without applying the profile",
+		kind, name, l.profile.profile.Name,
+	)
+}
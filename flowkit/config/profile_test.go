@@ -0,0 +1,182 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/config/json"
+)
+
+func Test_Profiles(t *testing.T) {
+	project := []byte(`{
+		"contracts": { "Foo": "./foo.cdc", "Bar": "./bar.cdc" },
+		"networks": {
+			"emulator": "127.0.0.1:3569",
+			"testnet": "access.devnet.nodes.onflow.org:9000"
+		},
+		"accounts": {
+			"alice": { "address": "01cf0e2f2f715450", "key": "` + projectKey + `" },
+			"bob": { "address": "179b6b1cb6755e31", "key": "` + projectKey + `" }
+		},
+		"deployments": {
+			"emulator": { "alice": ["Foo"] },
+			"testnet": { "alice": ["Foo", "Bar"] }
+		},
+		"profiles": {
+			"staging": {
+				"networks": { "testnet": "staging.devnet.nodes.onflow.org:9000" },
+				"accounts": { "alice": { "address": "f8d6e0586b0a20c7", "key": "` + privateKey + `" } },
+				"deployments": { "testnet": { "bob": ["Bar"] } }
+			},
+			"prod": {}
+		}
+	}`)
+
+	newLoader := func(t *testing.T, content []byte) (*config.Loader, afero.Afero) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		require.NoError(t, fs.WriteFile("flow.json", content, 0644))
+		loader := config.NewLoader(fs)
+		loader.AddConfigParser(json.NewParser())
+		return loader, fs
+	}
+
+	t.Run("Apply", func(t *testing.T) {
+		loader, _ := newLoader(t, project)
+		conf, err := loader.Load([]string{"flow.json"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"prod", "staging"}, conf.Profiles.Names())
+
+		staging, err := loader.ApplyProfile(conf, "staging")
+		require.NoError(t, err)
+		assert.Nil(t, staging.Profiles)
+
+		testnet, err := staging.Networks.ByName("testnet")
+		require.NoError(t, err)
+		assert.Equal(t, "staging.devnet.nodes.onflow.org:9000", testnet.Host)
+		alice, err := staging.Accounts.ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, "f8d6e0586b0a20c7", alice.Address.String())
+		_, err = staging.Accounts.ByName("bob")
+		assert.NoError(t, err)
+
+		// the profile deployments replace the deployments on the same network
+		assert.Len(t, staging.Deployments.ByNetwork("emulator"), 1)
+		deployments := staging.Deployments.ByNetwork("testnet")
+		require.Len(t, deployments, 1)
+		assert.Equal(t, "bob", deployments[0].Account)
+
+		// the base configuration is not changed
+		testnet, err = conf.Networks.ByName("testnet")
+		require.NoError(t, err)
+		assert.Equal(t, "access.devnet.nodes.onflow.org:9000", testnet.Host)
+	})
+
+	t.Run("Apply empty", func(t *testing.T) {
+		loader, _ := newLoader(t, project)
+		conf, err := loader.Load([]string{"flow.json"})
+		require.NoError(t, err)
+
+		prod, err := loader.ApplyProfile(conf, "prod")
+		require.NoError(t, err)
+		assert.Equal(t, conf.Networks, prod.Networks)
+		assert.Equal(t, conf.Accounts, prod.Accounts)
+		assert.Equal(t, conf.Deployments, prod.Deployments)
+	})
+
+	t.Run("Save", func(t *testing.T) {
+		loader, fs := newLoader(t, project)
+		conf, err := loader.Load([]string{"flow.json"})
+		require.NoError(t, err)
+
+		staging, err := loader.ApplyProfile(conf, "staging")
+		require.NoError(t, err)
+		staging.Networks.AddOrUpdate(config.Network{Name: "previewnet", Host: "access.previewnet.nodes.onflow.org:9000"})
+		require.NoError(t, loader.Save(staging, "flow.json"))
+
+		saved, err := json.NewParser().Deserialize(readFile(t, fs, "flow.json"))
+		require.NoError(t, err)
+
+		// the entries overridden by the profile are restored and the new entries are kept
+		testnet, err := saved.Networks.ByName("testnet")
+		require.NoError(t, err)
+		assert.Equal(t, "access.devnet.nodes.onflow.org:9000", testnet.Host)
+		_, err = saved.Networks.ByName("previewnet")
+		assert.NoError(t, err)
+		alice, err := saved.Accounts.ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, "01cf0e2f2f715450", alice.Address.String())
+		assert.Equal(t, conf.Deployments.ByNetwork("testnet"), saved.Deployments.ByNetwork("testnet"))
+		assert.Equal(t, conf.Profiles, saved.Profiles)
+	})
+
+	t.Run("Fail saving profile changes", func(t *testing.T) {
+		loader, fs := newLoader(t, project)
+		conf, err := loader.Load([]string{"flow.json"})
+		require.NoError(t, err)
+
+		staging, err := loader.ApplyProfile(conf, "staging")
+		require.NoError(t, err)
+		staging.Networks.AddOrUpdate(config.Network{Name: "testnet", Host: "127.0.0.1:3570"})
+		err = loader.Save(staging, "flow.json")
+		assert.EqualError(t, err, "can't save the changes to the network testnet, it's overridden by the profile staging, edit the profile instead or save without applying the profile")
+
+		staging, err = loader.ApplyProfile(conf, "staging")
+		require.NoError(t, err)
+		staging.Accounts.Remove("alice")
+		err = loader.Save(staging, "flow.json")
+		assert.EqualError(t, err, "can't save the changes to the account alice, it's overridden by the profile staging, edit the profile instead or save without applying the profile")
+
+		staging, err = loader.ApplyProfile(conf, "staging")
+		require.NoError(t, err)
+		staging.Deployments.AddOrUpdate(config.Deployment{Network: "testnet", Account: "alice"})
+		err = loader.Save(staging, "flow.json")
+		assert.EqualError(t, err, "can't save the changes to the deployments on network testnet, it's overridden by the profile staging, edit the profile instead or save without applying the profile")
+
+		// the configuration is not changed
+		assert.Equal(t, string(project), string(readFile(t, fs, "flow.json")))
+	})
+
+	t.Run("Fail unknown", func(t *testing.T) {
+		loader, _ := newLoader(t, project)
+		conf, err := loader.Load([]string{"flow.json"})
+		require.NoError(t, err)
+
+		_, err = loader.ApplyProfile(conf, "dev")
+		assert.EqualError(t, err, "profile dev does not exist, available profiles: prod, staging")
+	})
+
+	t.Run("Fail invalid", func(t *testing.T) {
+		loader, _ := newLoader(t, []byte(`{
+			"contracts": { "Foo": "./foo.cdc" },
+			"networks": { "testnet": "access.devnet.nodes.onflow.org:9000" },
+			"profiles": {
+				"staging": { "deployments": { "testnet": { "alice": ["Foo"] } } }
+			}
+		}`))
+
+		_, err := loader.Load([]string{"flow.json"})
+		assert.EqualError(t, err, "profile staging: deployment contains nonexisting account alice")
+	})
+}
@@ -0,0 +1,184 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package toml
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	configJson "github.com/onflow/flow-cli/flowkit/config/json"
+)
+
+// sections of the configuration in the order they are saved, with the comment describing each of them.
+var sections = []struct {
+	name    string
+	comment string
+}{
+	{"sourceRoot", "Directory the contract sources are relative to."},
+	{"emulators", "Emulators started with 'flow emulator' using the service account."},
+	{"contracts", "Contracts of the project by name, with the source location and the aliases on each network."},
+	{"networks", "Networks the project interacts with by name and access node host."},
+	{"accounts", "Accounts used for signing the transactions, keep the private keys out of version control."},
+	{"deployments", "Contracts deployed to each account on the network with 'flow project deploy'."},
+	{"hooks", "Commands run before and after the CLI commands."},
+	{"environments", "Environments grouping the network, accounts and variables."},
+	{"profiles", "Profiles overriding the networks, accounts and deployments, selected with --profile."},
+	{"secretArguments", "Transaction parameters redacted from the output."},
+}
+
+// Parser for TOML configuration format.
+//
+// The TOML configuration has the same structure as the JSON configuration, so the parser converts
+// between the formats and relies on the JSON parser for the configuration values.
+type Parser struct {
+	json *configJson.Parser
+}
+
+// NewParser returns a TOML parser.
+func NewParser() *Parser {
+	return &Parser{
+		json: configJson.NewParser(),
+	}
+}
+
+// Serialize configuration to raw.
+//
+// Each section is preceded by a comment describing it, so the comments of the default configuration
+// are kept every time the configuration is saved.
+func (p *Parser) Serialize(conf *config.Config) ([]byte, error) {
+	raw, err := p.json.Serialize(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var values map[string]any
+	if err := decoder.Decode(&values); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	for _, section := range sections {
+		value, ok := values[section.name]
+		if !ok {
+			continue
+		}
+
+		data, err := toml.Marshal(map[string]any{section.name: tomlValue(value)})
+		if err != nil {
+			return nil, err
+		}
+
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		_, _ = fmt.Fprintf(&b, "# %s\n", section.comment)
+		b.Write(data)
+	}
+
+	return b.Bytes(), nil
+}
+
+// Deserialize configuration to config structure.
+func (p *Parser) Deserialize(raw []byte) (*config.Config, error) {
+	var values map[string]any
+	if err := toml.Unmarshal(raw, &values); err != nil {
+		return nil, fmt.Errorf("configuration syntax error: %w", err)
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.json.Deserialize(data)
+}
+
+// UnknownFields returns the top level fields of the raw configuration which are not part of the
+// configuration format and are therefore ignored when parsing.
+func UnknownFields(raw []byte) ([]string, error) {
+	var values map[string]any
+	if err := toml.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return configJson.UnknownFields(data)
+}
+
+// Validate checks the raw configuration against the configuration schema and returns all the values
+// that don't match it, with the path of each value and a suggestion for misspelled fields.
+func Validate(raw []byte) config.SchemaErrors {
+	var values map[string]any
+	if err := toml.Unmarshal(raw, &values); err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			row, column := decodeErr.Position()
+			return config.SchemaErrors{{Message: fmt.Sprintf("invalid TOML at line %d, column %d: %s", row, column, err)}}
+		}
+		return config.SchemaErrors{{Message: fmt.Sprintf("invalid TOML: %s", err)}}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return config.SchemaErrors{{Message: err.Error()}}
+	}
+
+	return configJson.Validate(data)
+}
+
+// SupportsFormat check if the file format is supported.
+func (p *Parser) SupportsFormat(extension string) bool {
+	return extension == ".toml"
+}
+
+// tomlValue converts the JSON numbers to integers where possible, so they are not saved as TOML floats.
+func tomlValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = tomlValue(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = tomlValue(item)
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return value
+	}
+}
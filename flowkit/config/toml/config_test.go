@@ -0,0 +1,150 @@
+/*
+* Flow CLI
+*
+* Copyright 2019 Dapper Labs, Inc.
+*
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*
+*   http://www.apache.org/licenses/LICENSE-2.0
+*
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+ */
+
+package toml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_SimpleTOMLConfig(t *testing.T) {
+	b := []byte(`
+[emulators.default]
+port = 3569
+serviceAccount = "emulator-account"
+
+[contracts.Hello]
+source = "./hello.cdc"
+aliases = { testnet = "9a0766d93b6608b7" }
+
+[networks]
+emulator = "127.0.0.1:3569"
+
+[accounts.emulator-account]
+address = "f8d6e0586b0a20c7"
+key = "11c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+
+[deployments.emulator]
+emulator-account = ["Hello", { name = "Hello", args = [{ type = "String", value = "Hi" }] }]
+`)
+
+	parser := NewParser()
+	conf, err := parser.Deserialize(b)
+	require.NoError(t, err)
+
+	assert.Len(t, conf.Accounts, 1)
+	assert.Equal(t, "emulator-account", conf.Accounts[0].Name)
+	assert.Equal(t, "0x11c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7", conf.Accounts[0].Key.PrivateKey.String())
+	assert.Equal(t, 3569, conf.Emulators[0].Port)
+
+	network, err := conf.Networks.ByName("emulator")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:3569", network.Host)
+
+	contract, err := conf.Contracts.ByName("Hello")
+	require.NoError(t, err)
+	assert.Equal(t, "./hello.cdc", contract.Location)
+	assert.Equal(t, "9a0766d93b6608b7", contract.Aliases.ByNetwork("testnet").Address.String())
+
+	require.Len(t, conf.Deployments, 1)
+	require.Len(t, conf.Deployments[0].Contracts, 2)
+	assert.Equal(t, "Hi", conf.Deployments[0].Contracts[1].Args[0].ToGoValue())
+
+	data, err := parser.Serialize(conf)
+	require.NoError(t, err)
+	parsed, err := parser.Deserialize(data)
+	require.NoError(t, err)
+	assert.Equal(t, conf.Deployments, parsed.Deployments)
+	assert.Equal(t, conf.Accounts, parsed.Accounts)
+}
+
+func Test_SerializeTOMLConfig(t *testing.T) {
+	parser := NewParser()
+	conf := config.Default()
+	conf.Emulators = config.Emulators{{Name: "default", Port: 8888, ServiceAccount: "emulator-account"}}
+
+	data, err := parser.Serialize(conf)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Networks the project interacts with by name and access node host.\n[networks]\n")
+	assert.Contains(t, string(data), "port = 8888\n")
+
+	parsed, err := parser.Deserialize(data)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, conf.Networks, parsed.Networks)
+	assert.Equal(t, conf.Emulators, parsed.Emulators)
+
+	conf.SourceRoot = "cadence"
+	data, err = parser.Serialize(conf)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Directory the contract sources are relative to.\nsourceRoot = 'cadence'\n")
+
+	parsed, err = parser.Deserialize(data)
+	require.NoError(t, err)
+	assert.Equal(t, "cadence", parsed.SourceRoot)
+	assert.ElementsMatch(t, conf.Networks, parsed.Networks)
+
+	conf.Profiles = config.Profiles{{
+		Name:     "staging",
+		Networks: config.Networks{{Name: "testnet", Host: "staging.devnet.nodes.onflow.org:9000"}},
+	}}
+	data, err = parser.Serialize(conf)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "[profiles.staging.networks]\n")
+
+	parsed, err = parser.Deserialize(data)
+	require.NoError(t, err)
+	require.Len(t, parsed.Profiles, 1)
+	assert.Equal(t, "staging", parsed.Profiles[0].Name)
+	assert.Equal(t, conf.Profiles[0].Networks, parsed.Profiles[0].Networks)
+	assert.Empty(t, parsed.Profiles[0].Deployments)
+}
+
+func Test_InvalidTOMLConfig(t *testing.T) {
+	_, err := NewParser().Deserialize([]byte(`[networks`))
+	assert.ErrorContains(t, err, "configuration syntax error")
+	assert.True(t, NewParser().SupportsFormat(".toml"))
+	assert.False(t, NewParser().SupportsFormat(".json"))
+}
+
+func Test_UnknownFields(t *testing.T) {
+	fields, err := UnknownFields([]byte("[networks]\nemulator = \"127.0.0.1:3569\"\n[contract]\nFoo = \"./foo.cdc\"\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"contract"}, fields)
+}
+
+func Test_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		assert.Empty(t, Validate([]byte("[networks]\nemulator = \"127.0.0.1:3569\"\n")))
+	})
+
+	t.Run("Wrong type", func(t *testing.T) {
+		errs := Validate([]byte("[emulators.default]\nport = \"3569\"\nserviceAccount = \"emulator-account\"\n"))
+		assert.EqualError(t, errs, "invalid configuration:\n  - emulators.default.port: expected integer, found string")
+	})
+
+	t.Run("Syntax error", func(t *testing.T) {
+		errs := Validate([]byte("[networks]\nemulator = \n"))
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Message, "invalid TOML at line 2")
+	})
+}
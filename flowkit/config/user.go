@@ -0,0 +1,96 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// userEntries are the entries of the user configuration added to the project configuration.
+type userEntries struct {
+	networks map[string]bool
+	accounts map[string]bool
+}
+
+// loadUser adds the networks and accounts of the user configuration not defined by the configuration.
+//
+// The user configuration can also define the defaults used by the CLI, which are ignored by the loader.
+func (l *Loader) loadUser(conf *Config) (*Config, error) {
+	if l.userPath == "" {
+		return conf, nil
+	}
+
+	user, err := l.readConfig(l.userPath)
+	if errors.Is(err, ErrDoesNotExist) {
+		return conf, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user configuration %s: %w", l.userPath, err)
+	}
+
+	if user.SourceRoot != "" || len(user.Emulators) > 0 || len(user.Contracts) > 0 || len(user.Deployments) > 0 ||
+		len(user.Environments) > 0 || len(user.Hooks) > 0 || len(user.Profiles) > 0 {
+		return nil, fmt.Errorf("user configuration %s can only contain networks, accounts and defaults", l.userPath)
+	}
+
+	entries := &userEntries{
+		networks: make(map[string]bool),
+		accounts: make(map[string]bool),
+	}
+	for _, network := range user.Networks {
+		if _, err := conf.Networks.ByName(network.Name); err != nil {
+			conf.Networks.AddOrUpdate(network)
+			entries.networks[network.Name] = true
+		}
+	}
+	for _, account := range user.Accounts {
+		if _, err := conf.Accounts.ByName(account.Name); err != nil {
+			conf.Accounts.AddOrUpdate(account.Name, account)
+			entries.accounts[account.Name] = true
+		}
+	}
+
+	l.UserLocation = l.userPath
+	l.user = entries
+	return conf, nil
+}
+
+// withoutUser returns the configuration without the networks and accounts added from the user configuration.
+func (l *Loader) withoutUser(conf *Config) *Config {
+	if l.user == nil {
+		return conf
+	}
+
+	project := *conf
+	project.Networks = Networks{}
+	for _, network := range conf.Networks {
+		if !l.user.networks[network.Name] {
+			project.Networks = append(project.Networks, network)
+		}
+	}
+	project.Accounts = Accounts{}
+	for _, account := range conf.Accounts {
+		if !l.user.accounts[account.Name] {
+			project.Accounts = append(project.Accounts, account)
+		}
+	}
+
+	return &project
+}
@@ -0,0 +1,103 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/config/json"
+)
+
+func Test_UserConfig(t *testing.T) {
+	project := []byte(`{
+		"networks": { "testnet": "access.devnet.nodes.onflow.org:9000" },
+		"accounts": { "alice": { "address": "01cf0e2f2f715450", "key": "` + projectKey + `" } }
+	}`)
+	user := []byte(`{
+		"defaults": { "network": "testnet", "signer": "bob" },
+		"networks": {
+			"testnet": "127.0.0.1:3570",
+			"previewnet": "access.previewnet.nodes.onflow.org:9000"
+		},
+		"accounts": {
+			"alice": { "address": "179b6b1cb6755e31", "key": "` + privateKey + `" },
+			"bob": { "address": "179b6b1cb6755e31", "key": "` + privateKey + `" }
+		}
+	}`)
+
+	newLoader := func(t *testing.T, files map[string][]byte) (*config.Loader, afero.Afero) {
+		fs := afero.Afero{Fs: afero.NewMemMapFs()}
+		for path, content := range files {
+			require.NoError(t, fs.WriteFile(path, content, 0644))
+		}
+		loader := config.NewLoader(fs)
+		loader.AddConfigParser(json.NewParser())
+		loader.SetUserPath("/home/.flow/config.json")
+		return loader, fs
+	}
+
+	t.Run("Load", func(t *testing.T) {
+		loader, _ := newLoader(t, map[string][]byte{"flow.json": project, "/home/.flow/config.json": user})
+
+		conf, err := loader.Load([]string{"flow.json"})
+		require.NoError(t, err)
+		assert.Equal(t, "/home/.flow/config.json", loader.UserLocation)
+
+		// the project configuration takes precedence
+		testnet, err := conf.Networks.ByName("testnet")
+		require.NoError(t, err)
+		assert.Equal(t, "access.devnet.nodes.onflow.org:9000", testnet.Host)
+		alice, err := conf.Accounts.ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, "01cf0e2f2f715450", alice.Address.String())
+
+		_, err = conf.Networks.ByName("previewnet")
+		assert.NoError(t, err)
+		_, err = conf.Accounts.ByName("bob")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Save", func(t *testing.T) {
+		loader, fs := newLoader(t, map[string][]byte{"flow.json": project, "/home/.flow/config.json": user})
+
+		conf, err := loader.Load([]string{"flow.json"})
+		require.NoError(t, err)
+		require.NoError(t, loader.Save(conf, "flow.json"))
+
+		saved, err := json.NewParser().Deserialize(readFile(t, fs, "flow.json"))
+		require.NoError(t, err)
+		assert.Len(t, saved.Networks, 1)
+		assert.Len(t, saved.Accounts, 1)
+	})
+
+	t.Run("Fail project entries", func(t *testing.T) {
+		loader, _ := newLoader(t, map[string][]byte{
+			"flow.json":               project,
+			"/home/.flow/config.json": []byte(`{ "contracts": { "Foo": "./foo.cdc" } }`),
+		})
+
+		_, err := loader.Load([]string{"flow.json"})
+		assert.EqualError(t, err, "user configuration /home/.flow/config.json can only contain networks, accounts and defaults")
+	})
+}
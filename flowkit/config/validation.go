@@ -0,0 +1,56 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaError is a value of the configuration that doesn't match the configuration format.
+type SchemaError struct {
+	// Path of the value in the configuration, for example networks.testnet.host.
+	Path string
+	// Message describing what was expected at the path.
+	Message string
+	// Suggestion is the closest known field name for an unknown field, empty if none is close enough.
+	Suggestion string
+}
+
+func (e SchemaError) String() string {
+	message := e.Message
+	if e.Path != "" {
+		message = fmt.Sprintf("%s: %s", e.Path, message)
+	}
+	if e.Suggestion != "" {
+		message = fmt.Sprintf("%s, did you mean \"%s\"?", message, e.Suggestion)
+	}
+	return message
+}
+
+// SchemaErrors are all the values of a configuration that don't match the configuration format.
+type SchemaErrors []SchemaError
+
+func (e SchemaErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.String()
+	}
+	return fmt.Sprintf("invalid configuration:\n  - %s", strings.Join(messages, "\n  - "))
+}
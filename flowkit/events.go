@@ -19,10 +19,18 @@
 package flowkit
 
 import (
+	"strings"
+
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 )
 
+const (
+	feesDeductedEvent    = "FlowFees.FeesDeducted"
+	tokensDepositedEvent = "FlowToken.TokensDeposited"
+	tokensWithdrawnEvent = "FlowToken.TokensWithdrawn"
+)
+
 type Event struct {
 	Type   string
 	Values map[string]cadence.Value
@@ -75,3 +83,58 @@ func (e *Events) GetCreatedAddresses() []*flow.Address {
 
 	return addresses
 }
+
+// GetFeesDeducted returns the transaction fee amount from the FlowFees.FeesDeducted event.
+//
+// If the event is not present, for example on networks with fees disabled, false is returned.
+func (e *Events) GetFeesDeducted() (cadence.UFix64, bool) {
+	for _, event := range *e {
+		if !strings.HasSuffix(event.Type, feesDeductedEvent) {
+			continue
+		}
+		if amount, ok := event.Values["amount"].(cadence.UFix64); ok {
+			return amount, true
+		}
+	}
+
+	return 0, false
+}
+
+// GetFlowBalanceDeltas returns the FLOW balance change of each account inferred from the
+// FlowToken deposit and withdraw events. Deposits and withdrawals from and to resources
+// not stored in an account (with nil address) are not included.
+func (e *Events) GetFlowBalanceDeltas() map[flow.Address]cadence.Fix64 {
+	deltas := make(map[flow.Address]cadence.Fix64)
+	for _, event := range *e {
+		amount, ok := event.Values["amount"].(cadence.UFix64)
+		if !ok {
+			continue
+		}
+
+		if strings.HasSuffix(event.Type, tokensDepositedEvent) {
+			if address := optionalAddress(event.Values["to"]); address != nil {
+				deltas[*address] += cadence.Fix64(amount)
+			}
+		} else if strings.HasSuffix(event.Type, tokensWithdrawnEvent) {
+			if address := optionalAddress(event.Values["from"]); address != nil {
+				deltas[*address] -= cadence.Fix64(amount)
+			}
+		}
+	}
+
+	return deltas
+}
+
+// optionalAddress converts an address or optional address value to a Flow address.
+func optionalAddress(value cadence.Value) *flow.Address {
+	if optional, ok := value.(cadence.Optional); ok {
+		value = optional.Value
+	}
+
+	if a, ok := value.(cadence.Address); ok {
+		address := flow.Address(a)
+		return &address
+	}
+
+	return nil
+}
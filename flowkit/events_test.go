@@ -54,3 +54,50 @@ func TestAddress(t *testing.T) {
 	address := flow.HexToAddress("cdfef0f4f0786e9")
 	assert.Equal(t, "0cdfef0f4f0786e9", address.String())
 }
+
+func Test_FeesAndBalanceDeltas(t *testing.T) {
+	payer := flow.HexToAddress("f8d6e0586b0a20c7")
+	feesAccount := flow.HexToAddress("e5a8b7f23e8b548f")
+	amountFields := func(addressField string) []cadence.Field {
+		return []cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: addressField, Type: &cadence.OptionalType{Type: cadence.AddressType{}}},
+		}
+	}
+
+	withdrawn := tests.NewEvent(0,
+		"A.0ae53cb6e3f42a79.FlowToken.TokensWithdrawn",
+		amountFields("from"),
+		[]cadence.Value{cadence.UFix64(100), cadence.NewOptional(cadence.NewAddress(payer))},
+	)
+	deposited := tests.NewEvent(1,
+		"A.0ae53cb6e3f42a79.FlowToken.TokensDeposited",
+		amountFields("to"),
+		[]cadence.Value{cadence.UFix64(100), cadence.NewOptional(cadence.NewAddress(feesAccount))},
+	)
+	fees := tests.NewEvent(2,
+		"A.e5a8b7f23e8b548f.FlowFees.FeesDeducted",
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "inclusionEffort", Type: cadence.UFix64Type{}},
+			{Identifier: "executionEffort", Type: cadence.UFix64Type{}},
+		},
+		[]cadence.Value{cadence.UFix64(100), cadence.UFix64(100000000), cadence.UFix64(0)},
+	)
+
+	tx := tests.NewTransactionResult([]flow.Event{*withdrawn, *deposited, *fees})
+	events := flowkit.EventsFromTransaction(tx)
+
+	fee, ok := events.GetFeesDeducted()
+	assert.True(t, ok)
+	assert.Equal(t, cadence.UFix64(100), fee)
+
+	deltas := events.GetFlowBalanceDeltas()
+	assert.Len(t, deltas, 2)
+	assert.Equal(t, cadence.Fix64(-100), deltas[payer])
+	assert.Equal(t, cadence.Fix64(100), deltas[feesAccount])
+
+	empty := flowkit.EventsFromTransaction(tests.NewTransactionResult(nil))
+	_, ok = empty.GetFeesDeducted()
+	assert.False(t, ok)
+}
@@ -23,9 +23,10 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
-	"sync"
+	"time"
 
 	goeth "github.com/ethereum/go-ethereum/accounts"
 	"github.com/lmars/go-slip10"
@@ -41,6 +42,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/gateway/stats"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/flowkit/project"
 	"github.com/onflow/flow-cli/flowkit/transactions"
@@ -51,22 +53,31 @@ type BlockQuery struct {
 	ID     *flow.Identifier
 	Height uint64
 	Latest bool
+	// Finalized used together with Latest specifies the latest finalized block instead of the latest sealed block.
+	Finalized bool
 }
 
 // LatestBlockQuery specifies the latest block.
 var LatestBlockQuery = BlockQuery{Latest: true}
 
+// FinalizedBlockQuery specifies the latest finalized block.
+var FinalizedBlockQuery = BlockQuery{Latest: true, Finalized: true}
+
 // NewBlockQuery creates block query based on the passed query value.
 //
 // Query string options:
-// - "latest"                : return the latest block
+// - "latest" or "sealed"    : return the latest sealed block
+// - "final"                 : return the latest finalized block
 // - height (e.g. 123456789) : return block at this height
 // - ID                      : return block with this ID
 // if none of the valid values are passed an error is returned.
 func NewBlockQuery(query string) (BlockQuery, error) {
-	if query == "latest" {
+	if query == "latest" || query == "sealed" {
 		return LatestBlockQuery, nil
 	}
+	if query == "final" {
+		return FinalizedBlockQuery, nil
+	}
 	if height, ce := strconv.ParseUint(query, 10, 64); ce == nil {
 		return BlockQuery{Height: height}, nil
 	}
@@ -74,7 +85,7 @@ func NewBlockQuery(query string) (BlockQuery, error) {
 		return BlockQuery{ID: &id}, nil
 	}
 
-	return BlockQuery{}, fmt.Errorf("invalid query: %s, valid are: \"latest\", block height or block ID", query)
+	return BlockQuery{}, fmt.Errorf("invalid query: %s, valid are: \"latest\", \"sealed\", \"final\", block height or block ID", query)
 }
 
 // ScriptQuery defines block ID or height at which we should execute the script.
@@ -102,14 +113,15 @@ func NewFlowkit(
 	gateway gateway.Gateway,
 	logger output.Logger,
 ) *Flowkit {
-	return &Flowkit{state, network, gateway, logger}
+	return &Flowkit{state, network, gateway, logger, newSequenceTracker()}
 }
 
 type Flowkit struct {
-	state   *State
-	network config.Network
-	gateway gateway.Gateway
-	logger  output.Logger
+	state     *State
+	network   config.Network
+	gateway   gateway.Gateway
+	logger    output.Logger
+	sequences *sequenceTracker
 }
 
 func (f *Flowkit) Network() config.Network {
@@ -136,8 +148,13 @@ func (f *Flowkit) Ping() error {
 }
 
 // GetAccount fetches account on the Flow network.
-func (f *Flowkit) GetAccount(_ context.Context, address flow.Address) (*flow.Account, error) {
-	return f.gateway.GetAccount(address)
+func (f *Flowkit) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return f.gateway.GetAccount(ctx, address)
+}
+
+// GetAccountAtBlockHeight fetches account on the Flow network as of the block height.
+func (f *Flowkit) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	return f.gateway.GetAccountAtBlockHeight(ctx, address, height)
 }
 
 // CreateAccount on the Flow network with the provided keys and using the signer for creation transaction.
@@ -145,9 +162,27 @@ func (f *Flowkit) GetAccount(_ context.Context, address flow.Address) (*flow.Acc
 //
 // Keys is a slice but only one can be passed as well. If the transaction fails or there are other issues an error is returned.
 func (f *Flowkit) CreateAccount(
-	_ context.Context,
+	ctx context.Context,
+	signer *accounts.Account,
+	keys []accounts.PublicKey,
+) (*flow.Account, flow.Identifier, error) {
+	return f.CreateAccountWithOptions(ctx, signer, keys, CreateAccountOptions{})
+}
+
+// CreateAccountOptions define the optional parameters of the account creation.
+type CreateAccountOptions struct {
+	// Setup code declaring the function setup(account: AuthAccount), which is called with the new account in the
+	// creation transaction. The imports are resolved for the network, if the code is empty no setup is run.
+	Setup Script
+}
+
+// CreateAccountWithOptions creates the account the same way as CreateAccount, and runs the provided setup
+// on the new account in the same transaction, so the account is created already set up.
+func (f *Flowkit) CreateAccountWithOptions(
+	ctx context.Context,
 	signer *accounts.Account,
 	keys []accounts.PublicKey,
+	options CreateAccountOptions,
 ) (*flow.Account, flow.Identifier, error) {
 	var accKeys []*flow.AccountKey
 	for _, k := range keys {
@@ -170,12 +205,12 @@ func (f *Flowkit) CreateAccount(
 		accKeys = append(accKeys, accKey)
 	}
 
-	tx, err := transactions.NewCreateAccount(signer, accKeys, nil)
+	tx, err := f.newCreateAccountTransaction(signer, accKeys, options.Setup)
 	if err != nil {
 		return nil, flow.EmptyID, err
 	}
 
-	tx, err = f.prepareTransaction(tx, signer)
+	tx, err = f.prepareTransaction(ctx, tx, signer)
 	if err != nil {
 		return nil, flow.EmptyID, err
 	}
@@ -184,7 +219,7 @@ func (f *Flowkit) CreateAccount(
 	f.logger.StartProgress("Creating account...")
 	defer f.logger.StopProgress()
 
-	sentTx, err := f.gateway.SendSignedTransaction(tx.FlowTransaction())
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
 	if err != nil {
 		return nil, flow.EmptyID, errors.Wrap(err, "account creation transaction failed")
 	}
@@ -192,7 +227,7 @@ func (f *Flowkit) CreateAccount(
 	f.logger.StartProgress("Waiting for transaction to be sealed...")
 	defer f.logger.StopProgress()
 
-	result, err := f.gateway.GetTransactionResult(sentTx.ID(), true)
+	result, err := f.gateway.GetTransactionResult(ctx, sentTx.ID(), true)
 	if err != nil {
 		return nil, flow.EmptyID, err
 	}
@@ -207,7 +242,7 @@ func (f *Flowkit) CreateAccount(
 		return nil, flow.EmptyID, fmt.Errorf("new account address couldn't be fetched")
 	}
 
-	account, err := f.gateway.GetAccount(*newAccountAddress[0]) // we know it's the only and first event
+	account, err := f.gateway.GetAccount(ctx, *newAccountAddress[0]) // we know it's the only and first event
 	if err != nil {
 		return nil, flow.EmptyID, err
 	}
@@ -215,18 +250,59 @@ func (f *Flowkit) CreateAccount(
 	return account, sentTx.ID(), nil
 }
 
+// newCreateAccountTransaction builds the account creation transaction, running the setup code if provided.
+func (f *Flowkit) newCreateAccountTransaction(
+	signer *accounts.Account,
+	keys []*flow.AccountKey,
+	setup Script,
+) (*transactions.Transaction, error) {
+	if len(setup.Code) == 0 {
+		return transactions.NewCreateAccount(signer, keys, nil)
+	}
+
+	state, err := f.State()
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := project.NewProgram(setup.Code, nil, setup.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	if program.HasImports() {
+		contracts, err := state.DeploymentContractsByNetwork(f.network)
+		if err != nil {
+			return nil, err
+		}
+
+		importReplacer := project.NewImportReplacer(
+			contracts,
+			state.AliasesForNetwork(f.network),
+		)
+
+		program, err = importReplacer.Replace(program)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving imports: %w", err)
+		}
+	}
+
+	return transactions.NewCreateAccountWithSetup(signer, keys, program.Code())
+}
+
 // prepareTransaction prepares transaction for sending with data from network
 func (f *Flowkit) prepareTransaction(
+	ctx context.Context,
 	tx *transactions.Transaction,
 	account *accounts.Account,
 ) (*transactions.Transaction, error) {
 
-	block, err := f.gateway.GetLatestBlock()
+	block, err := f.gateway.GetLatestBlock(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	proposer, err := f.gateway.GetAccount(account.Address)
+	proposer, err := f.gateway.GetAccount(ctx, account.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -310,7 +386,7 @@ func (f *Flowkit) AddContract(
 	f.logger.StartProgress(fmt.Sprintf("Checking contract '%s' on account '%s'...", name, account.Address))
 
 	// check if contract exists on account
-	flowAccount, err := f.gateway.GetAccount(account.Address)
+	flowAccount, err := f.gateway.GetAccount(ctx, account.Address)
 	if err != nil {
 		return flow.EmptyID, false, err
 	}
@@ -333,13 +409,13 @@ func (f *Flowkit) AddContract(
 		}
 	}
 
-	tx, err = f.prepareTransaction(tx, account)
+	tx, err = f.prepareTransaction(ctx, tx, account)
 	if err != nil {
 		return flow.EmptyID, false, err
 	}
 
 	// send transaction with contract
-	sentTx, err := f.gateway.SendSignedTransaction(tx.FlowTransaction())
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
 	if err != nil {
 		return tx.FlowTransaction().ID(), false, fmt.Errorf("failed to send transaction to deploy a contract: %w", err)
 	}
@@ -351,7 +427,7 @@ func (f *Flowkit) AddContract(
 	}
 
 	// we wait for transaction to be sealed
-	trx, err := f.gateway.GetTransactionResult(sentTx.ID(), true)
+	trx, err := f.gateway.GetTransactionResult(ctx, sentTx.ID(), true)
 	if err != nil {
 		return tx.FlowTransaction().ID(), false, err
 	}
@@ -381,12 +457,12 @@ func (f *Flowkit) AddContract(
 //
 // If removal is successful transaction ID is returned.
 func (f *Flowkit) RemoveContract(
-	_ context.Context,
+	ctx context.Context,
 	account *accounts.Account,
 	contractName string,
 ) (flow.Identifier, error) {
 	// check if contracts exists on the account
-	flowAcc, err := f.gateway.GetAccount(account.Address)
+	flowAcc, err := f.gateway.GetAccount(ctx, account.Address)
 	if err != nil {
 		return flow.EmptyID, err
 	}
@@ -405,7 +481,7 @@ func (f *Flowkit) RemoveContract(
 		return flow.EmptyID, err
 	}
 
-	tx, err = f.prepareTransaction(tx, account)
+	tx, err = f.prepareTransaction(ctx, tx, account)
 	if err != nil {
 		return flow.EmptyID, err
 	}
@@ -415,12 +491,12 @@ func (f *Flowkit) RemoveContract(
 	)
 	defer f.logger.StopProgress()
 
-	sentTx, err := f.gateway.SendSignedTransaction(tx.FlowTransaction())
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
 	if err != nil {
 		return flow.EmptyID, err
 	}
 
-	txr, err := f.gateway.GetTransactionResult(sentTx.ID(), true)
+	txr, err := f.gateway.GetTransactionResult(ctx, sentTx.ID(), true)
 	if err != nil {
 		return flow.EmptyID, err
 	}
@@ -434,31 +510,98 @@ func (f *Flowkit) RemoveContract(
 }
 
 // GetBlock by the query from Flow blockchain. Query can define a block by ID, block by height or require the latest block.
-func (f *Flowkit) GetBlock(_ context.Context, query BlockQuery) (*flow.Block, error) {
+func (f *Flowkit) GetBlock(ctx context.Context, query BlockQuery) (*flow.Block, error) {
 	var err error
 	var block *flow.Block
-	if query.Latest {
-		block, err = f.gateway.GetLatestBlock()
+	if query.Latest && query.Finalized {
+		block, err = f.gateway.GetLatestFinalizedBlock(ctx)
+	} else if query.Latest {
+		block, err = f.gateway.GetLatestBlock(ctx)
 	} else if query.ID != nil {
-		block, err = f.gateway.GetBlockByID(*query.ID)
+		block, err = f.gateway.GetBlockByID(ctx, *query.ID)
 	} else {
-		block, err = f.gateway.GetBlockByHeight(query.Height)
+		block, err = f.gateway.GetBlockByHeight(ctx, query.Height)
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("error fetching block: %s", err.Error())
+		return nil, fmt.Errorf("error fetching block: %w", err)
 	}
 
 	if block == nil {
-		return nil, fmt.Errorf("block not found")
+		return nil, fmt.Errorf("block %w", gateway.ErrNotFound)
 	}
 
 	return block, err
 }
 
+// blockPollInterval is how often the latest sealed block is checked when following blocks.
+var blockPollInterval = time.Second
+
+// SubscribeBlocks streams the sealed blocks starting at the provided height, or at the latest sealed block
+// if the height is zero.
+//
+// The latest sealed block is polled from the gateway and all the blocks up to it are sent on the returned
+// channel in order, until the context is done. If fetching a block fails the error is sent on the error channel.
+func (f *Flowkit) SubscribeBlocks(ctx context.Context, startHeight uint64) (<-chan *flow.Block, <-chan error, error) {
+	latest, err := f.gateway.GetLatestBlock(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching block: %w", err)
+	}
+
+	next := startHeight
+	if next == 0 {
+		next = latest.Height
+	}
+
+	blocks := make(chan *flow.Block)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(blocks)
+		defer close(errs)
+
+		for {
+			for ; next <= latest.Height; next++ {
+				block := latest
+				if next != latest.Height {
+					block, err = f.gateway.GetBlockByHeight(ctx, next)
+					if err != nil {
+						if ctx.Err() == nil {
+							errs <- fmt.Errorf("error fetching block %d: %w", next, err)
+						}
+						return
+					}
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case blocks <- block:
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(blockPollInterval):
+			}
+
+			latest, err = f.gateway.GetLatestBlock(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					errs <- fmt.Errorf("error fetching block: %w", err)
+				}
+				return
+			}
+		}
+	}()
+
+	return blocks, errs, nil
+}
+
 // GetCollection by the ID from Flow network.
-func (f *Flowkit) GetCollection(_ context.Context, ID flow.Identifier) (*flow.Collection, error) {
-	return f.gateway.GetCollection(ID)
+func (f *Flowkit) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	return f.gateway.GetCollection(ctx, ID)
 }
 
 // GetEvents from Flow network by their event name in the specified height interval defined by start and end inclusive.
@@ -468,7 +611,7 @@ func (f *Flowkit) GetCollection(_ context.Context, ID flow.Identifier) (*flow.Co
 // Providing worker value will produce faster response as the interval will be scanned concurrently. This parameter is optional,
 // if not provided only a single worker will be used.
 func (f *Flowkit) GetEvents(
-	_ context.Context,
+	ctx context.Context,
 	names []string,
 	startHeight uint64,
 	endHeight uint64,
@@ -485,60 +628,61 @@ func (f *Flowkit) GetEvents(
 		}
 	}
 
-	queries := makeEventQueries(names, startHeight, endHeight, worker.BlocksPerWorker)
-
-	jobChan := make(chan grpc.EventRangeQuery, worker.Count)
-	results := make(chan eventWorkerResult)
-
-	var wg sync.WaitGroup
-
-	for i := 0; i < worker.Count; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			f.eventWorker(jobChan, results)
-		}()
+	iterator := BlockRangeIterator(f.gateway, startHeight, endHeight, BlockRangeOptions{
+		BatchSize:   worker.BlocksPerWorker,
+		Concurrency: worker.Count,
+	})
+	batches, err := iterator.Batches()
+	if err != nil {
+		return nil, err
 	}
 
-	// wait on the workers to finish and close the result channel
-	// to signal downstream that all work is done
-	go func() {
-		defer close(results)
-		wg.Wait()
-	}()
-
-	go func() {
-		defer close(jobChan)
-		for _, query := range queries {
-			jobChan <- query
-		}
-	}()
+	results := make([][]flow.BlockEvents, len(batches))
+	err = iterator.ForEach(ctx, func(_ context.Context, _ gateway.Gateway, batch BlockRange) error {
+		blockEvents, err := f.getEventsInRange(ctx, names, batch)
+		results[batch.Index] = blockEvents
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	var resultEvents []flow.BlockEvents
-	for eventResult := range results {
-		if eventResult.err != nil {
-			return nil, eventResult.err
-		}
-
-		resultEvents = append(resultEvents, eventResult.events...)
+	for _, blockEvents := range results {
+		resultEvents = append(resultEvents, blockEvents...)
 	}
 
 	return resultEvents, nil
 }
 
-func (f *Flowkit) eventWorker(jobChan <-chan grpc.EventRangeQuery, results chan<- eventWorkerResult) {
-	for q := range jobChan {
-		blockEvents, err := f.gateway.GetEvents(q.Type, q.StartHeight, q.EndHeight)
+// getEventsInRange fetches the events of all the types in the block range.
+func (f *Flowkit) getEventsInRange(ctx context.Context, names []string, batch BlockRange) ([]flow.BlockEvents, error) {
+	var blockEvents []flow.BlockEvents
+	for _, q := range makeEventQueries(names, batch.Start, batch.End, batch.End-batch.Start+1) {
+		events, err := f.gateway.GetEvents(ctx, q.Type, q.StartHeight, q.EndHeight)
 		if err != nil {
-			results <- eventWorkerResult{nil, err}
+			return nil, err
 		}
-		results <- eventWorkerResult{blockEvents, nil}
+		blockEvents = append(blockEvents, events...)
 	}
+	return blockEvents, nil
 }
 
-type eventWorkerResult struct {
-	events []flow.BlockEvents
-	err    error
+// SubscribeEvents streams the events of the provided types for each sealed block, starting at the provided height
+// or at the latest sealed block if the height is zero.
+//
+// The events are sent on the returned channel until the context is done, blocks without matching events are sent
+// as well so the progress can be followed. If the subscription fails the error is sent on the error channel.
+func (f *Flowkit) SubscribeEvents(
+	ctx context.Context,
+	names []string,
+	startHeight uint64,
+) (<-chan flow.BlockEvents, <-chan error, error) {
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("at least one event type must be provided")
+	}
+
+	return f.gateway.SubscribeEvents(ctx, names, startHeight)
 }
 
 func makeEventQueries(
@@ -688,16 +832,130 @@ func (f *Flowkit) derivePrivateKeyFromSeed(
 // the imports in the contract source, so it corresponds to the account name the contract was deployed to.
 // If contracts already exist use UpdateExistingContract(bool) to define whether a contract should be updated or not.
 func (f *Flowkit) DeployProject(ctx context.Context, update UpdateContract) ([]*project.Contract, error) {
+	return f.DeployProjectWithOptions(ctx, DeployOptions{Update: update})
+}
+
+// DeployStatus of a contract during the project deployment.
+type DeployStatus string
+
+const (
+	DeployStatusDeploying DeployStatus = "deploying"
+	DeployStatusDeployed  DeployStatus = "deployed"
+	DeployStatusUpdated   DeployStatus = "updated"
+	DeployStatusSkipped   DeployStatus = "skipped"
+	DeployStatusFailed    DeployStatus = "failed"
+)
+
+// ContractProgress reports the deployment status of a single contract.
+//
+// Index is the position of the contract in the deployment order and Total the number of deployed contracts.
+type ContractProgress struct {
+	Contract      *project.Contract
+	Status        DeployStatus
+	TransactionID flow.Identifier
+	Error         error
+	Index         int
+	Total         int
+}
+
+// progressDeploy is the operation of the progress events reported while deploying the project.
+const progressDeploy = "deploy"
+
+// reportDeployProgress reports the contract deployment progress as a progress event,
+// the contracts processed so far are the current progress.
+func reportDeployProgress(p ContractProgress) {
+	current := p.Index
+	if p.Status != DeployStatusDeploying {
+		current++
+	}
+
+	message := string(p.Status)
+	if p.Error != nil {
+		message = fmt.Sprintf("%s: %s", p.Status, p.Error)
+	}
+
+	output.ReportProgress(output.ProgressEvent{
+		Operation: progressDeploy,
+		Status:    output.ProgressUpdated,
+		Step:      p.Contract.Name,
+		Current:   uint64(current),
+		Total:     uint64(p.Total),
+		Message:   message,
+	})
+}
+
+// DeployOptions define how the project is deployed.
+type DeployOptions struct {
+	// Network name to deploy to, if empty the network the services were created for is used.
+	Network string
+	// Update defines whether existing contracts are updated, if nil existing contracts are not updated.
+	Update UpdateContract
+	// OnProgress is called before and after each contract is deployed.
+	OnProgress func(ContractProgress)
+	// Contracts names to deploy, if empty all the contracts on the network are deployed.
+	Contracts []string
+	// NewGateway creates the gateway to the Network, if nil a gRPC gateway is created.
+	//
+	// Programs wrapping their gateways, for example with retries or rate limits, should provide the function
+	// creating their gateways, so the Network is accessed the same way as the services network.
+	NewGateway func(config.Network) (gateway.Gateway, error)
+}
+
+// DeployProjectWithOptions deploys the project contracts the same way as DeployProject and reports progress
+// of each contract deployment to the provided OnProgress callback.
+//
+// If a network different from the services network is provided a new gateway is created for it with the
+// NewGateway function, and closed once the project is deployed.
+func (f *Flowkit) DeployProjectWithOptions(ctx context.Context, options DeployOptions) ([]*project.Contract, error) {
 	state, err := f.State()
 	if err != nil {
 		return nil, err
 	}
 
+	if options.Network != "" && options.Network != f.network.Name {
+		network, err := state.Networks().ByName(options.Network)
+		if err != nil {
+			return nil, err
+		}
+
+		newGateway := options.NewGateway
+		if newGateway == nil {
+			newGateway = func(network config.Network) (gateway.Gateway, error) {
+				return gateway.NewGrpcGateway(network)
+			}
+		}
+
+		gw, err := newGateway(*network)
+		if err != nil {
+			return nil, err
+		}
+		if closer, ok := gw.(io.Closer); ok {
+			defer closer.Close()
+		}
+
+		options.Network = ""
+		return NewFlowkit(state, *network, gw, f.logger).DeployProjectWithOptions(ctx, options)
+	}
+
+	if options.Update == nil {
+		options.Update = UpdateExistingContract(false)
+	}
+	progress := func(p ContractProgress) {
+		reportDeployProgress(p)
+		if options.OnProgress != nil {
+			options.OnProgress(p)
+		}
+	}
+
 	contracts, err := state.DeploymentContractsByNetwork(f.network)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := f.verifyAliases(ctx, state); err != nil {
+		return nil, err
+	}
+
 	deployment, err := project.NewDeployment(contracts, state.AliasesForNetwork(f.network))
 	if err != nil {
 		return nil, err
@@ -708,55 +966,127 @@ func (f *Flowkit) DeployProject(ctx context.Context, update UpdateContract) ([]*
 		return nil, err
 	}
 
+	sorted, err = filterContracts(sorted, options.Contracts)
+	if err != nil {
+		return nil, err
+	}
+
 	f.logger.Info(fmt.Sprintf(
 		"\nDeploying %d contracts for accounts: %s\n",
 		len(sorted),
 		state.AccountsForNetwork(f.network).String(),
 	))
 	defer f.logger.StopProgress()
+	output.ReportProgress(output.ProgressEvent{
+		Operation: progressDeploy,
+		Status:    output.ProgressStarted,
+		Total:     uint64(len(sorted)),
+		Message:   f.network.Name,
+	})
 
 	deployErr := &ProjectDeploymentError{}
-	for _, contract := range sorted {
+	for i, contract := range sorted {
 		targetAccount, err := state.Accounts().ByName(contract.AccountName)
 		if err != nil {
 			return nil, fmt.Errorf("target account for deploying contract not found in configuration")
 		}
 
+		progress(ContractProgress{Contract: contract, Status: DeployStatusDeploying, Index: i, Total: len(sorted)})
+
 		txID, updated, err := f.AddContract(
 			ctx,
 			targetAccount,
 			Script{Code: contract.Code(), Args: contract.Args, Location: contract.Location()},
-			update,
+			options.Update,
 		)
 		if err != nil && errors.Is(err, errUpdateNoDiff) {
 			f.logger.Info(fmt.Sprintf(
-				"%s -> 0x%s [skipping, no changes found]",
+				"%s -> 0x%s [skipping, no changes found]%s",
 				output.Italic(contract.Name),
 				contract.AccountAddress.String(),
+				mockedLabel(contract),
 			))
+			progress(ContractProgress{Contract: contract, Status: DeployStatusSkipped, Index: i, Total: len(sorted)})
 			continue
 		} else if err != nil {
 			deployErr.add(contract, err, fmt.Sprintf("failed to deploy contract %s", contract.Name))
+			progress(ContractProgress{Contract: contract, Status: DeployStatusFailed, Error: err, Index: i, Total: len(sorted)})
 			continue
 		}
 
 		f.logger.Info(fmt.Sprintf(
-			"%s -> 0x%s (%s) %s",
+			"%s -> 0x%s (%s) %s%s",
 			output.Green(contract.Name),
 			contract.AccountAddress,
 			txID.String(),
 			map[bool]string{true: "[updated]", false: ""}[updated],
+			mockedLabel(contract),
 		))
+
+		status := DeployStatusDeployed
+		if updated {
+			status = DeployStatusUpdated
+		}
+		progress(ContractProgress{Contract: contract, Status: status, TransactionID: txID, Index: i, Total: len(sorted)})
 	}
 
 	if len(deployErr.contracts) > 0 {
+		output.ReportProgress(output.ProgressEvent{
+			Operation: progressDeploy,
+			Status:    output.ProgressFailed,
+			Current:   uint64(len(sorted)),
+			Total:     uint64(len(sorted)),
+			Message:   deployErr.Error(),
+		})
 		return nil, deployErr
 	}
 
+	output.ReportProgress(output.ProgressEvent{
+		Operation: progressDeploy,
+		Status:    output.ProgressCompleted,
+		Current:   uint64(len(sorted)),
+		Total:     uint64(len(sorted)),
+	})
 	f.logger.Info(fmt.Sprintf("\n%s All contracts deployed successfully", output.SuccessEmoji()))
 	return sorted, nil
 }
 
+// filterContracts keeps only the contracts with provided names preserving the deployment order.
+func filterContracts(contracts []*project.Contract, names []string) ([]*project.Contract, error) {
+	if len(names) == 0 {
+		return contracts, nil
+	}
+
+	found := make(map[string]bool, len(names))
+	for _, name := range names {
+		found[name] = false
+	}
+
+	filtered := make([]*project.Contract, 0, len(names))
+	for _, contract := range contracts {
+		if _, ok := found[contract.Name]; ok {
+			found[contract.Name] = true
+			filtered = append(filtered, contract)
+		}
+	}
+
+	for _, name := range names {
+		if !found[name] {
+			return nil, fmt.Errorf("contract %s is not part of the deployment", name)
+		}
+	}
+
+	return filtered, nil
+}
+
+// mockedLabel marks the contracts deployed from their mock source.
+func mockedLabel(contract *project.Contract) string {
+	if !contract.Mocked {
+		return ""
+	}
+	return fmt.Sprintf(" %s", output.Magenta("[mocked]"))
+}
+
 type ProjectDeploymentError struct {
 	contracts map[string]error
 }
@@ -791,7 +1121,7 @@ type Script struct {
 
 // ExecuteScript on the Flow network and return the Cadence value as a result. The script is executed at the
 // block provided as part of the ScriptQuery value.
-func (f *Flowkit) ExecuteScript(_ context.Context, script Script, query ScriptQuery) (cadence.Value, error) {
+func (f *Flowkit) ExecuteScript(ctx context.Context, script Script, query ScriptQuery) (cadence.Value, error) {
 	state, err := f.State()
 	if err != nil {
 		return nil, err
@@ -816,7 +1146,7 @@ func (f *Flowkit) ExecuteScript(_ context.Context, script Script, query ScriptQu
 		if state == nil {
 			return nil, config.ErrDoesNotExist
 		}
-		if f.network == config.EmptyNetwork {
+		if f.network.Name == "" {
 			return nil, fmt.Errorf("missing network, specify which network to use to resolve imports in script code")
 		}
 		if script.Location == "" {
@@ -830,24 +1160,24 @@ func (f *Flowkit) ExecuteScript(_ context.Context, script Script, query ScriptQu
 	}
 
 	if query.Latest {
-		return f.gateway.ExecuteScript(program.Code(), script.Args)
+		return f.gateway.ExecuteScript(ctx, program.Code(), script.Args)
 	} else if query.ID != flow.EmptyID {
-		return f.gateway.ExecuteScriptAtID(program.Code(), script.Args, query.ID)
+		return f.gateway.ExecuteScriptAtID(ctx, program.Code(), script.Args, query.ID)
 	} else {
-		return f.gateway.ExecuteScriptAtHeight(program.Code(), script.Args, query.Height)
+		return f.gateway.ExecuteScriptAtHeight(ctx, program.Code(), script.Args, query.Height)
 	}
 }
 
 // GetTransactionByID from the Flow network including the transaction result. Using the waitSeal we can wait for the transaction to be sealed.
 func (f *Flowkit) GetTransactionByID(
-	_ context.Context,
+	ctx context.Context,
 	ID flow.Identifier,
 	waitSeal bool,
 ) (*flow.Transaction, *flow.TransactionResult, error) {
 	f.logger.StartProgress("Fetching Transaction...")
 	defer f.logger.StopProgress()
 
-	tx, err := f.gateway.GetTransaction(ID)
+	tx, err := f.gateway.GetTransaction(ctx, ID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -856,31 +1186,49 @@ func (f *Flowkit) GetTransactionByID(
 		f.logger.StartProgress("Waiting for transaction to be sealed...")
 	}
 
-	result, err := f.gateway.GetTransactionResult(ID, waitSeal)
+	result, err := f.gateway.GetTransactionResult(ctx, ID, waitSeal)
 	return tx, result, err
 }
 
 func (f *Flowkit) GetTransactionsByBlockID(
-	_ context.Context,
+	ctx context.Context,
 	blockID flow.Identifier,
 ) ([]*flow.Transaction, []*flow.TransactionResult, error) {
-	tx, err := f.gateway.GetTransactionsByBlockID(blockID)
+	tx, err := f.gateway.GetTransactionsByBlockID(ctx, blockID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	txRes, err := f.gateway.GetTransactionResultsByBlockID(blockID)
+	txRes, err := f.gateway.GetTransactionResultsByBlockID(ctx, blockID)
 	if err != nil {
 		return nil, nil, err
 	}
 	return tx, txRes, nil
 }
 
+// GetSystemTransaction from the Flow network including the transaction result, the system transaction
+// is executed in the system chunk of each block and handles protocol logic such as fee deduction.
+func (f *Flowkit) GetSystemTransaction(
+	ctx context.Context,
+	blockID flow.Identifier,
+) (*flow.Transaction, *flow.TransactionResult, error) {
+	tx, err := f.gateway.GetSystemTransaction(ctx, blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := f.gateway.GetSystemTransactionResult(ctx, blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, result, nil
+}
+
 // BuildTransaction builds a new transaction type for later signing and submitting to the network.
 //
 // AddressesRoles type defines the address for each role (payer, proposer, authorizers) and the script defines the transaction content.
 func (f *Flowkit) BuildTransaction(
-	_ context.Context,
+	ctx context.Context,
 	addresses transactions.AddressesRoles,
 	proposerKeyIndex int,
 	script Script,
@@ -891,12 +1239,12 @@ func (f *Flowkit) BuildTransaction(
 		return nil, err
 	}
 
-	latestBlock, err := f.gateway.GetLatestBlock()
+	latestBlock, err := f.gateway.GetLatestBlock(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest sealed block: %w", err)
 	}
 
-	proposerAccount, err := f.gateway.GetAccount(addresses.Proposer)
+	proposerAccount, err := f.gateway.GetAccount(ctx, addresses.Proposer)
 	if err != nil {
 		return nil, err
 	}
@@ -912,7 +1260,7 @@ func (f *Flowkit) BuildTransaction(
 	}
 
 	if program.HasImports() {
-		if f.network == config.EmptyNetwork {
+		if f.network.Name == "" {
 			return nil, fmt.Errorf("missing network, specify which network to use to resolve imports in transaction code")
 		}
 		if script.Location == "" { // when used as lib with code we don't support imports
@@ -938,6 +1286,13 @@ func (f *Flowkit) BuildTransaction(
 	if err := tx.SetProposer(proposerAccount, proposerKeyIndex); err != nil {
 		return nil, err
 	}
+	// the fetched account doesn't reflect transactions sent by this process that are not yet sealed
+	proposalKey := tx.FlowTransaction().ProposalKey
+	tx.FlowTransaction().SetProposalKey(
+		proposalKey.Address,
+		proposalKey.KeyIndex,
+		f.sequences.sequenceNumber(proposalKey.Address, proposalKey.KeyIndex, proposalKey.SequenceNumber),
+	)
 
 	if err := tx.SetScriptWithArgs(program.Code(), script.Args); err != nil {
 		return nil, err
@@ -976,30 +1331,55 @@ func (f *Flowkit) SignTransactionPayload(
 //
 // You can build the transaction using the BuildTransaction method and then sign it using the SignTranscation method.
 func (f *Flowkit) SendSignedTransaction(
-	_ context.Context,
+	ctx context.Context,
 	tx *transactions.Transaction,
 ) (*flow.Transaction, *flow.TransactionResult, error) {
-	sentTx, err := f.gateway.SendSignedTransaction(tx.FlowTransaction())
+	proposalKey := tx.FlowTransaction().ProposalKey
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
 	if err != nil {
+		f.sequences.recover(proposalKey, err)
 		return nil, nil, err
 	}
+	f.sequences.sent(proposalKey)
 
-	res, err := f.gateway.GetTransactionResult(sentTx.ID(), true)
+	res, err := f.gateway.GetTransactionResult(ctx, sentTx.ID(), true)
 	if err != nil {
 		return nil, nil, err
 	}
+	if res != nil {
+		f.sequences.recover(proposalKey, res.Error)
+	}
 
 	return sentTx, res, nil
 }
 
 // SendTransaction will build and send a transaction to the Flow network, using the accounts provided for each role and
 // contain the script. Transaction as well as transaction result will be returned in case the transaction is successfully submitted.
+//
+// The sequence numbers of the proposal keys used are tracked, so transactions can be sent in a quick succession. If the
+// transaction still fails with a sequence number mismatch it is sent once more with the current sequence number.
 func (f *Flowkit) SendTransaction(
 	ctx context.Context,
 	accounts transactions.AccountRoles,
 	script Script,
 	gasLimit uint64,
 ) (*flow.Transaction, *flow.TransactionResult, error) {
+	sentTx, res, mismatch, err := f.sendTransaction(ctx, accounts, script, gasLimit)
+	if mismatch {
+		f.logger.Info("Proposal key sequence number is outdated, sending the transaction again")
+		sentTx, res, _, err = f.sendTransaction(ctx, accounts, script, gasLimit)
+	}
+
+	return sentTx, res, err
+}
+
+// sendTransaction builds, signs and sends the transaction, and reports whether it failed with a sequence number mismatch.
+func (f *Flowkit) sendTransaction(
+	ctx context.Context,
+	accounts transactions.AccountRoles,
+	script Script,
+	gasLimit uint64,
+) (*flow.Transaction, *flow.TransactionResult, bool, error) {
 	tx, err := f.BuildTransaction(
 		ctx,
 		accounts.AddressRoles(),
@@ -1008,34 +1388,85 @@ func (f *Flowkit) SendTransaction(
 		gasLimit,
 	)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, false, err
 	}
 
 	for _, signer := range accounts.Signers() {
 		err = tx.SetSigner(signer)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, false, err
 		}
 
 		tx, err = tx.Sign()
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, false, err
 		}
 	}
 
 	f.logger.Info(fmt.Sprintf("Transaction ID: %s", tx.FlowTransaction().ID()))
 	f.logger.StartProgress("Sending transaction...")
 
-	sentTx, err := f.gateway.SendSignedTransaction(tx.FlowTransaction())
+	proposalKey := tx.FlowTransaction().ProposalKey
+	sentTx, err := f.gateway.SendSignedTransaction(ctx, tx.FlowTransaction())
 	if err != nil {
-		return nil, nil, err
+		f.logger.StopProgress()
+		return nil, nil, f.sequences.recover(proposalKey, err), err
 	}
+	f.sequences.sent(proposalKey)
 
 	f.logger.StopProgress()
 	f.logger.StartProgress("Waiting for transaction to be sealed...")
 	defer f.logger.StopProgress()
 
-	res, err := f.gateway.GetTransactionResult(sentTx.ID(), true)
+	res, err := f.gateway.GetTransactionResult(ctx, sentTx.ID(), true)
+	if err != nil || res == nil {
+		return sentTx, res, false, err
+	}
 
-	return sentTx, res, err
+	return sentTx, res, f.sequences.recover(proposalKey, res.Error), nil
+}
+
+// CreateSnapshot of the current emulator state with the provided name.
+func (f *Flowkit) CreateSnapshot(ctx context.Context, name string) error {
+	admin, err := f.emulatorAdmin()
+	if err != nil {
+		return err
+	}
+	return admin.CreateSnapshot(ctx, name)
+}
+
+// LoadSnapshot restores the emulator state to the snapshot with the provided name.
+func (f *Flowkit) LoadSnapshot(ctx context.Context, name string) error {
+	admin, err := f.emulatorAdmin()
+	if err != nil {
+		return err
+	}
+	return admin.LoadSnapshot(ctx, name)
+}
+
+// Rollback the emulator state to the provided block height.
+func (f *Flowkit) Rollback(ctx context.Context, height uint64) error {
+	admin, err := f.emulatorAdmin()
+	if err != nil {
+		return err
+	}
+	return admin.Rollback(ctx, height)
+}
+
+// GetEmulatorStats returns the current usage of the emulator.
+func (f *Flowkit) GetEmulatorStats(ctx context.Context) (*stats.Emulator, error) {
+	admin, err := f.emulatorAdmin()
+	if err != nil {
+		return nil, err
+	}
+	return admin.GetEmulatorStats(ctx)
+}
+
+// emulatorAdmin returns the emulator admin of the gateway, or an error if the gateway is not connected to an emulator.
+func (f *Flowkit) emulatorAdmin() (gateway.EmulatorAdmin, error) {
+	admin, ok := gateway.AsEmulatorAdmin(f.gateway)
+	if !ok {
+		return nil, fmt.Errorf("managing the emulator state is only supported by gateways connected to the emulator")
+	}
+	return admin, nil
 }
@@ -23,8 +23,12 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/transactions"
@@ -110,7 +114,7 @@ func TestAccounts(t *testing.T) {
 		_, flowkit, gw := setup()
 		account, err := flowkit.GetAccount(ctx, serviceAddress)
 
-		gw.Mock.AssertCalled(t, "GetAccount", serviceAddress)
+		gw.Mock.AssertCalled(t, "GetAccount", ctx, serviceAddress)
 		assert.NoError(t, err)
 		assert.Equal(t, serviceAddress, account.Address)
 	})
@@ -120,7 +124,7 @@ func TestAccounts(t *testing.T) {
 		newAddress := flow.HexToAddress("192440c99cb17282")
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, serviceAddress, tx.Authorizers[0])
 			assert.Equal(t, serviceAddress, tx.Payer)
 
@@ -129,7 +133,7 @@ func TestAccounts(t *testing.T) {
 
 		compareAddress := serviceAddress
 		gw.GetAccount.Run(func(args mock.Arguments) {
-			address := args.Get(0).(flow.Address)
+			address := args.Get(1).(flow.Address)
 			assert.Equal(t, address, compareAddress)
 			compareAddress = newAddress
 			gw.GetAccount.Return(
@@ -152,8 +156,8 @@ func TestAccounts(t *testing.T) {
 			}},
 		)
 
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, serviceAddress)
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, newAddress)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, ctx, serviceAddress)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, ctx, newAddress)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 2)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 1)
 		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 1)
@@ -166,7 +170,7 @@ func TestAccounts(t *testing.T) {
 	t.Run("Contract Add for Account", func(t *testing.T) {
 		_, flowkit, gw := setup()
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, serviceAddress)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.add"))
 
@@ -180,7 +184,7 @@ func TestAccounts(t *testing.T) {
 			UpdateExistingContract(false),
 		)
 
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, serviceAddress)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, ctx, serviceAddress)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 2)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 1)
 		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 1)
@@ -191,7 +195,7 @@ func TestAccounts(t *testing.T) {
 	t.Run("Contract Remove for Account", func(t *testing.T) {
 		_, flowkit, gw := setup()
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, serviceAddress)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.remove"))
 
@@ -199,7 +203,7 @@ func TestAccounts(t *testing.T) {
 		})
 
 		gw.GetAccount.Run(func(args mock.Arguments) {
-			addr := args.Get(0).(flow.Address)
+			addr := args.Get(1).(flow.Address)
 			assert.Equal(t, addr.String(), serviceAcc.Address.String())
 			racc := tests.NewAccountWithAddress(addr.String())
 			racc.Contracts = map[string][]byte{
@@ -215,7 +219,7 @@ func TestAccounts(t *testing.T) {
 			tests.ContractHelloString.Name,
 		)
 
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, serviceAddress)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, ctx, serviceAddress)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 2)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 1)
 		gw.Mock.AssertNumberOfCalls(t, mocks.SendSignedTransactionFunc, 1)
@@ -648,6 +652,24 @@ func TestAccountsGet_Integration(t *testing.T) {
 		assert.Nil(t, acc)
 		assert.Equal(t, err.Error(), "could not find account with address 0000000000000001")
 	})
+
+	t.Run("Get Account At Block Height", func(t *testing.T) {
+		t.Parallel()
+		state, flowkit := setupIntegration()
+		setupAccounts(state, flowkit)
+
+		a, _ := state.Accounts().ByName("Alice")
+		latest, err := flowkit.GetBlock(ctx, LatestBlockQuery)
+		require.NoError(t, err)
+
+		acc, err := flowkit.GetAccountAtBlockHeight(ctx, a.Address, latest.Height)
+		assert.NoError(t, err)
+		assert.Equal(t, a.Address, acc.Address)
+
+		// the account didn't exist yet at genesis
+		_, err = flowkit.GetAccountAtBlockHeight(ctx, a.Address, 0)
+		assert.Error(t, err)
+	})
 }
 
 func TestBlocks(t *testing.T) {
@@ -658,7 +680,7 @@ func TestBlocks(t *testing.T) {
 
 		_, err := flowkit.GetBlock(ctx, BlockQuery{Latest: true})
 
-		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc)
+		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc, ctx)
 		gw.Mock.AssertNotCalled(t, mocks.GetBlockByHeightFunc)
 		gw.Mock.AssertNotCalled(t, mocks.GetBlockByIDFunc)
 		assert.NoError(t, err)
@@ -675,7 +697,7 @@ func TestBlocks(t *testing.T) {
 
 		_, err := flowkit.GetBlock(ctx, BlockQuery{Height: 10})
 
-		gw.Mock.AssertCalled(t, mocks.GetBlockByHeightFunc, uint64(10))
+		gw.Mock.AssertCalled(t, mocks.GetBlockByHeightFunc, ctx, uint64(10))
 		gw.Mock.AssertNotCalled(t, mocks.GetLatestBlockFunc)
 		gw.Mock.AssertNotCalled(t, mocks.GetBlockByIDFunc)
 		assert.NoError(t, err)
@@ -689,13 +711,57 @@ func TestBlocks(t *testing.T) {
 		_, err := flowkit.GetBlock(ctx, BlockQuery{ID: &ID})
 
 		assert.NoError(t, err)
-		gw.Mock.AssertCalled(t, mocks.GetBlockByIDFunc, ID)
+		gw.Mock.AssertCalled(t, mocks.GetBlockByIDFunc, ctx, ID)
 		gw.Mock.AssertNotCalled(t, mocks.GetBlockByHeightFunc)
 		gw.Mock.AssertNotCalled(t, mocks.GetLatestBlockFunc)
 	})
 
 }
 
+func TestBlocksSubscribe(t *testing.T) {
+	t.Run("Subscribe Blocks Since Height", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, gw := setup()
+
+		latest := tests.NewBlock()
+		latest.Height = 3
+		gw.GetLatestBlock.Return(latest, nil)
+		gw.GetBlockByHeight.Run(func(args mock.Arguments) {
+			block := tests.NewBlock()
+			block.Height = args.Get(1).(uint64)
+			gw.GetBlockByHeight.Return(block, nil)
+		})
+
+		subCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		blocks, _, err := flowkit.SubscribeBlocks(subCtx, 1)
+		require.NoError(t, err)
+
+		heights := make([]uint64, 0)
+		for block := range blocks {
+			heights = append(heights, block.Height)
+			if len(heights) == 3 {
+				cancel()
+			}
+		}
+
+		assert.Equal(t, []uint64{1, 2, 3}, heights)
+		gw.Mock.AssertNotCalled(t, mocks.GetBlockByHeightFunc, mock.Anything, uint64(3))
+	})
+
+	t.Run("Subscribe Blocks Fail", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, gw := setup()
+		gw.GetLatestBlock.Return(nil, errors.New("unavailable"))
+
+		_, _, err := flowkit.SubscribeBlocks(ctx, 0)
+		assert.EqualError(t, err, "error fetching block: unavailable")
+	})
+}
+
 func TestBlocksGet_Integration(t *testing.T) {
 	t.Run("Get Block", func(t *testing.T) {
 		t.Parallel()
@@ -709,6 +775,137 @@ func TestBlocksGet_Integration(t *testing.T) {
 	})
 }
 
+func TestPersistentStore_Integration(t *testing.T) {
+	state, _ := setupIntegration()
+	acc, _ := state.EmulatorServiceAccount()
+	pk, _ := acc.Key.PrivateKey()
+	key := &gateway.EmulatorKey{
+		PublicKey: (*pk).PublicKey(),
+		SigAlgo:   acc.Key.SigAlgo(),
+		HashAlgo:  acc.Key.HashAlgo(),
+	}
+	path := filepath.Join(t.TempDir(), "emulator")
+
+	open := func() (*gateway.EmulatorGateway, Flowkit) {
+		store, err := gateway.WithPersistentStore(path)
+		require.NoError(t, err)
+		gw := gateway.NewEmulatorGatewayWithOpts(key, store)
+		return gw, Flowkit{
+			state:   state,
+			network: config.EmulatorNetwork,
+			gateway: gw,
+			logger:  output.NewStdoutLogger(output.NoneLog),
+		}
+	}
+
+	gw, flowkit := open()
+	created, _, err := flowkit.CreateAccount(ctx, acc, []accounts.PublicKey{{
+		Public:   tests.PubKeys()[0],
+		Weight:   flow.AccountKeyWeightThreshold,
+		SigAlgo:  crypto.ECDSA_P256,
+		HashAlgo: crypto.SHA3_256,
+	}})
+	require.NoError(t, err)
+	block, err := flowkit.GetBlock(ctx, LatestBlockQuery)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	t.Run("Resume chain state", func(t *testing.T) {
+		gw, flowkit := open()
+		defer gw.Close()
+
+		latest, err := flowkit.GetBlock(ctx, LatestBlockQuery)
+		require.NoError(t, err)
+		assert.Equal(t, block.Height, latest.Height)
+
+		account, err := flowkit.GetAccount(ctx, created.Address)
+		require.NoError(t, err)
+		assert.Equal(t, created.Address, account.Address)
+	})
+
+	t.Run("Reset chain state", func(t *testing.T) {
+		require.NoError(t, gateway.ResetPersistentStore(path))
+		_, err := os.Stat(path)
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+
+		gw, flowkit := open()
+		defer gw.Close()
+
+		latest, err := flowkit.GetBlock(ctx, LatestBlockQuery)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), latest.Height)
+	})
+
+	t.Run("Reset keeps other files", func(t *testing.T) {
+		other := filepath.Join(t.TempDir(), "flowdb")
+		require.NoError(t, os.MkdirAll(other, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(other, "emulator.sqlite"), []byte("standalone"), 0644))
+
+		err := gateway.ResetPersistentStore(other)
+		assert.EqualError(t, err, fmt.Sprintf("emulator store at %s was not created by the CLI and will not be removed", other))
+		data, err := os.ReadFile(filepath.Join(other, "emulator.sqlite"))
+		require.NoError(t, err)
+		assert.Equal(t, "standalone", string(data))
+
+		file := filepath.Join(t.TempDir(), "notes.txt")
+		require.NoError(t, os.WriteFile(file, nil, 0644))
+		assert.EqualError(t, gateway.ResetPersistentStore(file), fmt.Sprintf("emulator store path %s is not a directory", file))
+
+		_, err = gateway.WithPersistentStore(file)
+		assert.EqualError(t, err, fmt.Sprintf("emulator store path %s is not a directory", file))
+	})
+}
+
+func TestEmulatorServe_Integration(t *testing.T) {
+	state, _ := setupIntegration()
+	acc, _ := state.EmulatorServiceAccount()
+	pk, _ := acc.Key.PrivateKey()
+	gw := gateway.NewEmulatorGatewayWithOpts(&gateway.EmulatorKey{
+		PublicKey: (*pk).PublicKey(),
+		SigAlgo:   acc.Key.SigAlgo(),
+		HashAlgo:  acc.Key.HashAlgo(),
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error)
+	go func() {
+		served <- gw.Serve(ctx, port, 0, 0)
+	}()
+
+	client, err := gateway.NewGrpcGateway(config.Network{Name: "served", Host: fmt.Sprintf("127.0.0.1:%d", port)})
+	require.NoError(t, err)
+	var external *flow.Block
+	require.Eventually(t, func() bool {
+		external, err = client.GetLatestBlock(ctx)
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+	inProcess, err := gw.GetLatestBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, inProcess.ID, external.ID)
+
+	cancel()
+	assert.NoError(t, <-served)
+	assert.EqualError(t, gw.Serve(context.Background(), 0, 0, 0), "at least one of the gRPC, REST or admin ports must be provided")
+
+	t.Run("Close listeners when a port is in use", func(t *testing.T) {
+		used, err := net.Listen("tcp", ":0")
+		require.NoError(t, err)
+		defer used.Close()
+
+		err = gw.Serve(context.Background(), port, 0, used.Addr().(*net.TCPAddr).Port)
+		assert.ErrorContains(t, err, "failed to listen on admin port")
+
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		require.NoError(t, err, "gRPC port should be released")
+		require.NoError(t, listener.Close())
+	})
+}
+
 func TestCollections(t *testing.T) {
 	t.Run("Get Collection", func(t *testing.T) {
 		_, flowkit, gw := setup()
@@ -717,7 +914,7 @@ func TestCollections(t *testing.T) {
 		_, err := flowkit.GetCollection(ctx, ID)
 
 		assert.NoError(t, err)
-		gw.Mock.AssertCalled(t, "GetCollection", ID)
+		gw.Mock.AssertCalled(t, "GetCollection", ctx, ID)
 	})
 }
 
@@ -729,7 +926,7 @@ func TestEvents(t *testing.T) {
 		_, err := flowkit.GetEvents(ctx, []string{"flow.CreateAccount"}, 0, 0, nil)
 
 		assert.NoError(t, err)
-		gw.Mock.AssertCalled(t, mocks.GetEventsFunc, "flow.CreateAccount", uint64(0), uint64(0))
+		gw.Mock.AssertCalled(t, mocks.GetEventsFunc, ctx, "flow.CreateAccount", uint64(0), uint64(0))
 	})
 
 	t.Run("Should have larger endHeight then startHeight", func(t *testing.T) {
@@ -765,6 +962,121 @@ func TestEvents(t *testing.T) {
 		assert.EqualError(t, err, "failed getting event")
 	})
 
+	t.Run("Subscribe Events", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, gw := setup()
+
+		blockEvents := make(chan flow.BlockEvents)
+		gw.Mock.
+			On("SubscribeEvents", mock.Anything, []string{"flow.AccountCreated"}, uint64(10)).
+			Return((<-chan flow.BlockEvents)(blockEvents), (<-chan error)(make(chan error)), nil)
+
+		events, _, err := flowkit.SubscribeEvents(ctx, []string{"flow.AccountCreated"}, 10)
+		assert.NoError(t, err)
+		assert.NotNil(t, events)
+	})
+
+	t.Run("Subscribe Events without types", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, _ := setup()
+		_, _, err := flowkit.SubscribeEvents(ctx, nil, 0)
+		assert.EqualError(t, err, "at least one event type must be provided")
+	})
+
+	t.Run("Subscribe Decoded Events", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, gw := setup()
+
+		latest := tests.NewBlock()
+		latest.Height = 3
+		gw.GetLatestBlock.Return(latest, nil)
+		gw.GetEvents.Run(func(args mock.Arguments) {
+			blockEvents := make([]flow.BlockEvents, 0)
+			for height := args.Get(2).(uint64); height <= args.Get(3).(uint64); height++ {
+				event := tests.NewEvent(0, "flow.AccountCreated", []cadence.Field{{Identifier: "address", Type: cadence.AddressType{}}}, []cadence.Value{cadence.NewAddress([8]byte{7: byte(height)})})
+				blockEvents = append(blockEvents, flow.BlockEvents{Height: height, Events: []flow.Event{*event}})
+			}
+			gw.GetEvents.Return(blockEvents, nil)
+		})
+		gw.Mock.
+			On("SubscribeEvents", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil, errors.New("not supported"))
+
+		subCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		events, _ := flowkit.SubscribeDecodedEvents(subCtx, []string{"flow.AccountCreated"}, 1)
+
+		heights := make([]uint64, 0)
+		for event := range events {
+			heights = append(heights, event.BlockHeight)
+			assert.Equal(t, flow.HexToAddress(fmt.Sprintf("%x", event.BlockHeight)), *event.GetAddress())
+			if len(heights) == 3 {
+				cancel()
+			}
+		}
+
+		assert.Equal(t, []uint64{1, 2, 3}, heights)
+	})
+
+	t.Run("Subscribe Decoded Events Reconnect", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, gw := setup()
+
+		latest := tests.NewBlock()
+		latest.Height = 1
+		gw.GetLatestBlock.Return(latest, nil)
+
+		stream := func(height uint64, err error) (<-chan flow.BlockEvents, <-chan error) {
+			blockEvents := make(chan flow.BlockEvents, 1)
+			errs := make(chan error, 1)
+			event := tests.NewEvent(0, "flow.AccountCreated", nil, nil)
+			blockEvents <- flow.BlockEvents{Height: height, Events: []flow.Event{*event}}
+			close(blockEvents)
+			errs <- err
+			close(errs)
+			return blockEvents, errs
+		}
+		first, firstErrs := stream(2, errors.New("connection reset"))
+		second, secondErrs := stream(3, nil)
+		gw.Mock.
+			On("SubscribeEvents", mock.Anything, mock.Anything, uint64(2)).
+			Return(first, firstErrs, nil)
+		gw.Mock.
+			On("SubscribeEvents", mock.Anything, mock.Anything, uint64(3)).
+			Return(second, secondErrs, nil)
+
+		subCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		events, _ := flowkit.SubscribeDecodedEvents(subCtx, []string{"flow.AccountCreated"}, 2)
+
+		heights := make([]uint64, 0)
+		for event := range events {
+			heights = append(heights, event.BlockHeight)
+			if len(heights) == 2 {
+				cancel()
+			}
+		}
+
+		assert.Equal(t, []uint64{2, 3}, heights)
+	})
+
+	t.Run("Subscribe Decoded Events without types", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, _ := setup()
+		events, errs := flowkit.SubscribeDecodedEvents(ctx, nil, 0)
+
+		assert.EqualError(t, <-errs, "at least one event type must be provided")
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
 }
 
 func TestEvents_Integration(t *testing.T) {
@@ -988,7 +1300,7 @@ func TestProject(t *testing.T) {
 		state.Deployments().AddOrUpdate(d)
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, acct2.Address)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.add"))
 
@@ -1002,6 +1314,70 @@ func TestProject(t *testing.T) {
 		assert.Equal(t, contracts[0].AccountAddress, acct2.Address)
 	})
 
+	t.Run("Deploy Project With Progress", func(t *testing.T) {
+		t.Parallel()
+
+		state, flowkit, gw := setup()
+
+		c := config.Contract{
+			Name:     "Hello",
+			Location: tests.ContractHelloString.Filename,
+		}
+		state.Contracts().AddOrUpdate(c)
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+		acct2 := Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   acct2.Name,
+			Contracts: []config.ContractDeployment{{Name: c.Name}},
+		})
+
+		gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+
+		progress := make([]ContractProgress, 0)
+		contracts, err := flowkit.DeployProjectWithOptions(ctx, DeployOptions{
+			Network: config.EmulatorNetwork.Name,
+			OnProgress: func(p ContractProgress) {
+				progress = append(progress, p)
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, contracts, 1)
+		require.Len(t, progress, 2)
+		assert.Equal(t, DeployStatusDeploying, progress[0].Status)
+		assert.Equal(t, DeployStatusDeployed, progress[1].Status)
+		assert.Equal(t, "Hello", progress[1].Contract.Name)
+		assert.Equal(t, 1, progress[1].Total)
+
+		_, err = flowkit.DeployProjectWithOptions(ctx, DeployOptions{Network: "foo"})
+		assert.EqualError(t, err, "network named foo does not exist in configuration")
+	})
+
+	t.Run("Deploy Project With Network Gateway", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, _ := setup()
+		gw := &closingGateway{Mock: &gateway.Mock{}}
+		var network config.Network
+
+		contracts, err := flowkit.DeployProjectWithOptions(ctx, DeployOptions{
+			Network: config.TestnetNetwork.Name,
+			NewGateway: func(n config.Network) (gateway.Gateway, error) {
+				network = n
+				return gw, nil
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, contracts)
+		assert.Equal(t, config.TestnetNetwork.Name, network.Name)
+		assert.True(t, gw.closed)
+	})
+
 	t.Run("Deploy Project Using LocationAliases", func(t *testing.T) {
 		t.Parallel()
 
@@ -1052,7 +1428,7 @@ func TestProject(t *testing.T) {
 		} // don't change formatting of the above code since it compares the strings with included formatting
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, a.Address)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.add"))
 
@@ -1074,8 +1450,8 @@ func TestProject(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Equal(t, len(contracts), 2)
-		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc)
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, a.Address)
+		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc, ctx)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, ctx, a.Address)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 2)
 	})
 
@@ -1129,7 +1505,7 @@ func TestProject(t *testing.T) {
 		} // don't change formatting of the above code since it compares the strings with included formatting
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, a.Address)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.add"))
 
@@ -1151,8 +1527,8 @@ func TestProject(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Equal(t, len(contracts), 2)
-		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc)
-		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, a.Address)
+		gw.Mock.AssertCalled(t, mocks.GetLatestBlockFunc, ctx)
+		gw.Mock.AssertCalled(t, mocks.GetAccountFunc, ctx, a.Address)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 2)
 	})
 
@@ -1185,7 +1561,7 @@ func TestProject(t *testing.T) {
 		state.Deployments().AddOrUpdate(d)
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			assert.Equal(t, tx.Payer, acct2.Address)
 			assert.True(t, strings.Contains(string(tx.Script), "signer.contracts.add"))
 
@@ -1328,13 +1704,54 @@ func TestProject_Integration(t *testing.T) {
 
 }
 
+func TestLockfileAliases(t *testing.T) {
+	state, flowkit, gw := setup()
+
+	state.Contracts().AddOrUpdate(config.Contract{
+		Name:     "FungibleToken",
+		Location: "./ft.cdc",
+		Aliases: config.Aliases{{
+			Network: config.EmulatorNetwork.Name,
+			Address: flow.HexToAddress("0xee82856bf20e2aa6"),
+		}},
+	})
+
+	deployed := func(code string) {
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			account := tests.NewAccountWithAddress(args.Get(1).(flow.Address).String())
+			account.Contracts = map[string][]byte{"FungibleToken": []byte(code)}
+			gw.GetAccount.Return(account, nil)
+		})
+	}
+
+	deployed("pub contract FungibleToken {}")
+	require.NoError(t, flowkit.verifyAliases(ctx, state))
+
+	lock, err := LoadLockfile(state.ReaderWriter(), state.LockfilePath())
+	require.NoError(t, err)
+	assert.Equal(t, "0xee82856bf20e2aa6", lock.Aliases["emulator"]["FungibleToken"].Address)
+	assert.Equal(t, sourceHash([]byte("pub contract FungibleToken {}")), lock.Aliases["emulator"]["FungibleToken"].Hash)
+
+	deployed("pub contract FungibleToken { pub fun foo() {} }")
+	state.SetFrozenLockfile(true)
+	err = flowkit.verifyAliases(ctx, state)
+	assert.ErrorIs(t, err, ErrFrozenLockfile)
+	assert.EqualError(t, err, "lockfile is frozen: aliased contract FungibleToken on network emulator changed since it was locked")
+
+	state.SetFrozenLockfile(false)
+	require.NoError(t, flowkit.verifyAliases(ctx, state))
+	lock, err = LoadLockfile(state.ReaderWriter(), state.LockfilePath())
+	require.NoError(t, err)
+	assert.Equal(t, sourceHash([]byte("pub contract FungibleToken { pub fun foo() {} }")), lock.Aliases["emulator"]["FungibleToken"].Hash)
+}
+
 func TestScripts(t *testing.T) {
 	t.Run("Execute Script", func(t *testing.T) {
 		_, flowkit, gw := setup()
 
 		gw.ExecuteScript.Run(func(args mock.Arguments) {
-			assert.Len(t, string(args.Get(0).([]byte)), 78)
-			assert.Equal(t, "\"Foo\"", args.Get(1).([]cadence.Value)[0].String())
+			assert.Len(t, string(args.Get(1).([]byte)), 78)
+			assert.Equal(t, "\"Foo\"", args.Get(2).([]cadence.Value)[0].String())
 			gw.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
 		})
 
@@ -1460,6 +1877,42 @@ func TestScripts_Integration(t *testing.T) {
 
 const gasLimit = 1000
 
+// closingGateway records whether the gateway was closed.
+type closingGateway struct {
+	*gateway.Mock
+	closed bool
+}
+
+func (g *closingGateway) Close() error {
+	g.closed = true
+	return nil
+}
+
+func TestSnapshots(t *testing.T) {
+	t.Run("Emulator", func(t *testing.T) {
+		state, _, _ := setup()
+		gw := &gateway.Mock{
+			CreateSnapshotFunc: func(ctx context.Context, name string) error { return nil },
+			LoadSnapshotFunc:   func(ctx context.Context, name string) error { return nil },
+		}
+		flowkit := NewFlowkit(state, config.EmulatorNetwork, gateway.NewRetryGateway(gw, gateway.DefaultRetryConfig), nil)
+
+		assert.NoError(t, flowkit.CreateSnapshot(ctx, "test"))
+		assert.NoError(t, flowkit.LoadSnapshot(ctx, "test"))
+		assert.Equal(t, []gateway.MockCall{
+			{Method: "CreateSnapshot", Args: []any{"test"}},
+			{Method: "LoadSnapshot", Args: []any{"test"}},
+		}, gw.Calls())
+	})
+
+	t.Run("Fail Not Emulator", func(t *testing.T) {
+		_, flowkit, _ := setup()
+
+		err := flowkit.CreateSnapshot(ctx, "test")
+		assert.EqualError(t, err, "managing the emulator state is only supported by gateways connected to the emulator")
+	})
+}
+
 func TestTransactions(t *testing.T) {
 	state, _, _ := setup()
 	serviceAcc, _ := state.EmulatorServiceAccount()
@@ -1474,7 +1927,7 @@ func TestTransactions(t *testing.T) {
 
 		assert.NoError(t, err)
 		gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 1)
-		gw.Mock.AssertCalled(t, mocks.GetTransactionFunc, txs.ID())
+		gw.Mock.AssertCalled(t, mocks.GetTransactionFunc, ctx, txs.ID())
 	})
 
 	t.Run("Send Transaction args", func(t *testing.T) {
@@ -1483,7 +1936,7 @@ func TestTransactions(t *testing.T) {
 
 		var txID flow.Identifier
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
-			tx := args.Get(0).(*flow.Transaction)
+			tx := args.Get(1).(*flow.Transaction)
 			arg, err := tx.Argument(0)
 			assert.NoError(t, err)
 			assert.Equal(t, "\"Bar\"", arg.String())
@@ -1496,7 +1949,7 @@ func TestTransactions(t *testing.T) {
 		})
 
 		gw.GetTransactionResult.Run(func(args mock.Arguments) {
-			assert.Equal(t, txID, args.Get(0).(flow.Identifier))
+			assert.Equal(t, txID, args.Get(1).(flow.Identifier))
 			gw.GetTransactionResult.Return(tests.NewTransactionResult(nil), nil)
 		})
 
@@ -2072,6 +2525,36 @@ func TestTransactions_Integration(t *testing.T) {
 		assert.Nil(t, txr.Error)
 		assert.Equal(t, txr.Status, flow.TransactionStatusSealed)
 	})
+
+	t.Run("Get Transactions By Block ID", func(t *testing.T) {
+		t.Parallel()
+		state, flowkit := setupIntegration()
+		setupAccounts(state, flowkit)
+
+		a, _ := state.Accounts().ByName("Alice")
+
+		tx, _, err := flowkit.SendTransaction(
+			ctx,
+			transactions.SingleAccountRole(*a),
+			Script{
+				Code:     tests.TransactionSimple.Source,
+				Location: tests.TransactionSimple.Filename,
+			},
+			flow.DefaultTransactionGasLimit,
+		)
+		require.NoError(t, err)
+
+		block, err := flowkit.GetBlock(ctx, LatestBlockQuery)
+		require.NoError(t, err)
+
+		txs, results, err := flowkit.GetTransactionsByBlockID(ctx, block.ID)
+		require.NoError(t, err)
+		require.Len(t, txs, 1)
+		require.Len(t, results, 1)
+		assert.Equal(t, tx.ID(), txs[0].ID())
+		assert.Equal(t, tx.ID(), results[0].TransactionID)
+		assert.Equal(t, flow.TransactionStatusSealed, results[0].Status)
+	})
 }
 
 func Test_BlockQuery(t *testing.T) {
@@ -2088,7 +2571,33 @@ func Test_BlockQuery(t *testing.T) {
 	assert.Equal(t, id, *q.ID)
 	assert.NoError(t, err)
 
+	q, err = NewBlockQuery("sealed")
+	assert.Equal(t, LatestBlockQuery, q)
+	assert.NoError(t, err)
+
+	q, err = NewBlockQuery("final")
+	assert.True(t, q.Latest)
+	assert.True(t, q.Finalized)
+	assert.NoError(t, err)
+
 	_, err = NewBlockQuery("invalid")
-	assert.EqualError(t, err, "invalid query: invalid, valid are: \"latest\", block height or block ID")
+	assert.EqualError(t, err, "invalid query: invalid, valid are: \"latest\", \"sealed\", \"final\", block height or block ID")
+
+}
+
+func Test_FilterContracts(t *testing.T) {
+	contracts := []*project.Contract{{Name: "A"}, {Name: "B"}, {Name: "C"}}
+
+	filtered, err := filterContracts(contracts, nil)
+	require.NoError(t, err)
+	assert.Len(t, filtered, 3)
+
+	filtered, err = filterContracts(contracts, []string{"C", "A"})
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "A", filtered[0].Name)
+	assert.Equal(t, "C", filtered[1].Name)
 
+	_, err = filterContracts(contracts, []string{"Foo"})
+	assert.EqualError(t, err, "contract Foo is not part of the deployment")
 }
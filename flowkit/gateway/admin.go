@@ -0,0 +1,210 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway/stats"
+)
+
+// DefaultEmulatorAdminPort is the port the emulator serves the admin API on by default.
+const DefaultEmulatorAdminPort = 8080
+
+// EmulatorAdmin manages the state of an emulator.
+//
+// It's only implemented by the gateways connected to an emulator, so callers detect the support with
+// a type assertion, or with AsEmulatorAdmin if the gateway may be wrapped by other gateways.
+type EmulatorAdmin interface {
+	CreateSnapshot(context.Context, string) error
+	LoadSnapshot(context.Context, string) error
+	Rollback(context.Context, uint64) error
+	GetEmulatorStats(context.Context) (*stats.Emulator, error)
+}
+
+var (
+	_ EmulatorAdmin = &EmulatorGateway{}
+	_ EmulatorAdmin = &GrpcEmulatorGateway{}
+)
+
+// AsEmulatorAdmin returns the emulator admin of the gateway, unwrapping the gateways wrapping another gateway
+// until one implementing EmulatorAdmin is found. False is returned if the gateway is not connected to an emulator.
+//
+// The cache gateway is not unwrapped, since the cached responses would not match the emulator state after
+// a snapshot is loaded or the emulator is rolled back.
+func AsEmulatorAdmin(gw Gateway) (EmulatorAdmin, bool) {
+	for {
+		if admin, ok := gw.(EmulatorAdmin); ok {
+			return admin, true
+		}
+
+		wrapper, ok := gw.(interface{ Unwrap() Gateway })
+		if !ok {
+			return nil, false
+		}
+		gw = wrapper.Unwrap()
+	}
+}
+
+// GrpcEmulatorGateway is a gRPC gateway connected to an emulator, managing the emulator state with
+// the emulator admin API on the same host as the access API.
+type GrpcEmulatorGateway struct {
+	*GrpcGateway
+	adminPort int
+}
+
+// NewGrpcEmulatorGateway returns a new gRPC gateway connected to the emulator on the network host,
+// with the admin API on the admin port.
+func NewGrpcEmulatorGateway(network config.Network, adminPort int) (*GrpcEmulatorGateway, error) {
+	gw, err := NewGrpcGateway(network)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GrpcEmulatorGateway{
+		GrpcGateway: gw,
+		adminPort:   adminPort,
+	}, nil
+}
+
+// CreateSnapshot creates a named snapshot of the emulator state using the emulator admin API.
+func (g *GrpcEmulatorGateway) CreateSnapshot(ctx context.Context, name string) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		g.adminEndpoint("snapshots"),
+		strings.NewReader(url.Values{"name": {name}}.Encode()),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := g.doAdminRequest(req)
+	return snapshotResponseError("create", name, resp, err)
+}
+
+// LoadSnapshot restores the emulator state to the named snapshot using the emulator admin API.
+func (g *GrpcEmulatorGateway) LoadSnapshot(ctx context.Context, name string) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		fmt.Sprintf("%s/%s", g.adminEndpoint("snapshots"), url.PathEscape(name)),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := g.doAdminRequest(req)
+	return snapshotResponseError("load", name, resp, err)
+}
+
+// Rollback rewinds the emulator state to the block height using the emulator admin API.
+func (g *GrpcEmulatorGateway) Rollback(ctx context.Context, height uint64) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		g.adminEndpoint("rollback"),
+		strings.NewReader(url.Values{"height": {strconv.FormatUint(height, 10)}}.Encode()),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := g.doAdminRequest(req)
+	if err != nil {
+		return fmt.Errorf("failed to rollback to block height %d, make sure the emulator admin API is served on port %d: %w", height, g.adminPort, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to rollback to block height %d: status code %d", height, resp.StatusCode)
+	}
+	return nil
+}
+
+// GetEmulatorStats returns the usage of the emulator using the emulator admin API.
+//
+// The stats are only served by the in-process emulator of the dev command.
+func (g *GrpcEmulatorGateway) GetEmulatorStats(ctx context.Context) (*stats.Emulator, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.adminEndpoint("stats"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.doAdminRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get emulator stats, make sure the emulator admin API is served on port %d: %w", g.adminPort, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("emulator stats are only supported by the in-process emulator started with 'flow dev'")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get emulator stats: status code %d", resp.StatusCode)
+	}
+
+	var usage stats.Emulator
+	if err := json.NewDecoder(resp.Body).Decode(&usage); err != nil {
+		return nil, fmt.Errorf("failed to decode emulator stats: %w", err)
+	}
+	return &usage, nil
+}
+
+// doAdminRequest sends the request to the emulator admin API with the request ID header if set.
+func (g *GrpcEmulatorGateway) doAdminRequest(req *http.Request) (*http.Response, error) {
+	if g.requestID != "" {
+		req.Header.Set(RequestIDHeader, g.requestID)
+	}
+	return http.DefaultClient.Do(req)
+}
+
+// adminEndpoint returns the emulator admin API endpoint on the same host as the access API.
+func (g *GrpcEmulatorGateway) adminEndpoint(path string) string {
+	host, _, err := net.SplitHostPort(g.host)
+	if err != nil {
+		host = g.host
+	}
+
+	return fmt.Sprintf("http://%s/emulator/%s", net.JoinHostPort(host, strconv.Itoa(g.adminPort)), path)
+}
+
+func snapshotResponseError(action string, name string, resp *http.Response, err error) error {
+	if err != nil {
+		return fmt.Errorf("failed to %s snapshot %s, make sure the emulator admin API is served: %w", action, name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to %s snapshot %s: status code %d", action, name, resp.StatusCode)
+	}
+
+	return nil
+}
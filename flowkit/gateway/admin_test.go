@@ -0,0 +1,79 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AsEmulatorAdmin(t *testing.T) {
+	emulator := &Mock{}
+
+	t.Run("Emulator", func(t *testing.T) {
+		admin, ok := AsEmulatorAdmin(emulator)
+		assert.True(t, ok)
+		assert.Equal(t, emulator, admin)
+	})
+
+	t.Run("Wrapped Emulator", func(t *testing.T) {
+		gw := NewTimeoutGateway(NewRetryGateway(emulator, DefaultRetryConfig), time.Second)
+
+		admin, ok := AsEmulatorAdmin(gw)
+		assert.True(t, ok)
+		assert.Equal(t, emulator, admin)
+	})
+
+	t.Run("Cached Emulator", func(t *testing.T) {
+		_, ok := AsEmulatorAdmin(NewCacheGateway(emulator, NewMemoryCache(), time.Minute))
+		assert.False(t, ok)
+	})
+
+	t.Run("Not Emulator", func(t *testing.T) {
+		_, ok := AsEmulatorAdmin(NewRetryGateway(&GrpcGateway{}, DefaultRetryConfig))
+		assert.False(t, ok)
+	})
+}
+
+func Test_GrpcEmulatorGateway(t *testing.T) {
+	var path, height string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		height = r.FormValue("height")
+	}))
+	defer server.Close()
+
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	adminPort, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	gw := &GrpcEmulatorGateway{GrpcGateway: &GrpcGateway{host: "127.0.0.1:3569"}, adminPort: adminPort}
+	require.NoError(t, gw.Rollback(context.Background(), 42))
+	assert.Equal(t, "/emulator/rollback", path)
+	assert.Equal(t, "42", height)
+}
@@ -0,0 +1,240 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+)
+
+var _ Gateway = &AllowlistGateway{}
+
+// AllowlistGateway is a gateway decorator that only sends the transactions and executes the scripts
+// with the code hash in the allowlist, other calls are passed to the decorated gateway unchanged.
+//
+// The code is checked as it's sent to the network, so the hashes must be computed from the code with
+// the imports already resolved for the network.
+type AllowlistGateway struct {
+	gateway Gateway
+	allowed map[string]bool
+	// chainID limits the allowlist to the network with the chain ID, the allowlist applies to any network if empty.
+	chainID flow.ChainID
+
+	chainOnce sync.Once
+	enforced  bool
+	chainErr  error
+}
+
+// NewAllowlistGateway returns a new gateway only allowing the code with the hashes to be sent with the provided gateway.
+func NewAllowlistGateway(gateway Gateway, hashes []string) *AllowlistGateway {
+	allowed := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		allowed[hash] = true
+	}
+
+	return &AllowlistGateway{
+		gateway: gateway,
+		allowed: allowed,
+	}
+}
+
+// NewChainAllowlistGateway returns a new gateway only allowing the code with the hashes to be sent with the provided
+// gateway if it's connected to the network with the chain ID, other networks are not limited.
+//
+// The chain ID reported by the host is fetched once when code is first sent rather than when the gateway is created,
+// so creating the gateway doesn't require the network to be reachable. If the chain ID can't be fetched the code
+// is not sent, so the allowlist can't be bypassed when the host fails to report it.
+func NewChainAllowlistGateway(gateway Gateway, hashes []string, chainID flow.ChainID) *AllowlistGateway {
+	g := NewAllowlistGateway(gateway, hashes)
+	g.chainID = chainID
+	return g
+}
+
+// CodeHash returns the hex encoded SHA-256 hash of the code used in the allowlist.
+func CodeHash(code []byte) string {
+	hash := sha256.Sum256(code)
+	return hex.EncodeToString(hash[:])
+}
+
+// check returns an error if the allowlist applies to the network and the code hash is not in the allowlist.
+func (g *AllowlistGateway) check(ctx context.Context, code []byte) error {
+	enforced, err := g.enforce(ctx)
+	if err != nil || !enforced {
+		return err
+	}
+
+	hash := CodeHash(code)
+	if g.allowed[hash] {
+		return nil
+	}
+
+	return &Error{
+		Type:    ErrNotAllowed,
+		Message: fmt.Sprintf("code with hash %s is not in the allowlist", hash),
+	}
+}
+
+// enforce returns whether the allowlist applies to the network of the gateway.
+func (g *AllowlistGateway) enforce(ctx context.Context) (bool, error) {
+	if g.chainID == "" {
+		return true, nil
+	}
+
+	g.chainOnce.Do(func() {
+		chainID, err := g.gateway.GetNetworkParameters(ctx)
+		if err != nil {
+			g.chainErr = fmt.Errorf("failed to get the network chain ID: %w", err)
+			return
+		}
+		g.enforced = chainID == g.chainID
+	})
+	return g.enforced, g.chainErr
+}
+
+func (g *AllowlistGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return g.gateway.GetAccount(ctx, address)
+}
+
+func (g *AllowlistGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	return g.gateway.GetAccountAtBlockHeight(ctx, address, height)
+}
+
+func (g *AllowlistGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	if err := g.check(ctx, tx.Script); err != nil {
+		return nil, err
+	}
+	return g.gateway.SendSignedTransaction(ctx, tx)
+}
+
+func (g *AllowlistGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	return g.gateway.GetTransaction(ctx, ID)
+}
+
+func (g *AllowlistGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return g.gateway.GetTransactionResultsByBlockID(ctx, blockID)
+}
+
+func (g *AllowlistGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	return g.gateway.GetTransactionResult(ctx, ID, waitSeal)
+}
+
+func (g *AllowlistGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return g.gateway.GetTransactionsByBlockID(ctx, blockID)
+}
+
+func (g *AllowlistGateway) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.Transaction, error) {
+	return g.gateway.GetSystemTransaction(ctx, blockID)
+}
+
+func (g *AllowlistGateway) GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier) (*flow.TransactionResult, error) {
+	return g.gateway.GetSystemTransactionResult(ctx, blockID)
+}
+
+func (g *AllowlistGateway) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	return g.gateway.GetExecutionResultForBlockID(ctx, blockID)
+}
+
+func (g *AllowlistGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	if err := g.check(ctx, script); err != nil {
+		return nil, err
+	}
+	return g.gateway.ExecuteScript(ctx, script, arguments)
+}
+
+func (g *AllowlistGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	if err := g.check(ctx, script); err != nil {
+		return nil, err
+	}
+	return g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height)
+}
+
+func (g *AllowlistGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	if err := g.check(ctx, script); err != nil {
+		return nil, err
+	}
+	return g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID)
+}
+
+func (g *AllowlistGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	return g.gateway.GetLatestBlock(ctx)
+}
+
+func (g *AllowlistGateway) GetLatestFinalizedBlock(ctx context.Context) (*flow.Block, error) {
+	return g.gateway.GetLatestFinalizedBlock(ctx)
+}
+
+func (g *AllowlistGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	return g.gateway.GetBlockByHeight(ctx, height)
+}
+
+func (g *AllowlistGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	return g.gateway.GetBlockByID(ctx, ID)
+}
+
+func (g *AllowlistGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	return g.gateway.GetEvents(ctx, eventType, startHeight, endHeight)
+}
+
+func (g *AllowlistGateway) SubscribeEvents(ctx context.Context, eventTypes []string, startHeight uint64) (<-chan flow.BlockEvents, <-chan error, error) {
+	return g.gateway.SubscribeEvents(ctx, eventTypes, startHeight)
+}
+
+func (g *AllowlistGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	return g.gateway.GetCollection(ctx, ID)
+}
+
+func (g *AllowlistGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	return g.gateway.GetLatestProtocolStateSnapshot(ctx)
+}
+
+func (g *AllowlistGateway) GetNetworkParameters(ctx context.Context) (flow.ChainID, error) {
+	return g.gateway.GetNetworkParameters(ctx)
+}
+
+func (g *AllowlistGateway) GetNodeVersion(ctx context.Context) (string, error) {
+	return g.gateway.GetNodeVersion(ctx)
+}
+
+func (g *AllowlistGateway) Ping() error {
+	return g.gateway.Ping()
+}
+
+func (g *AllowlistGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
+
+// Unwrap returns the wrapped gateway, so the emulator admin of the gateway can be found with AsEmulatorAdmin.
+func (g *AllowlistGateway) Unwrap() Gateway {
+	return g.gateway
+}
+
+// Close the decorated gateway if it holds any resources.
+func (g *AllowlistGateway) Close() error {
+	if closer, ok := g.gateway.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func Test_AllowlistGateway(t *testing.T) {
+	ctx := context.Background()
+	allowed := []byte("pub fun main(): Int { return 1 }")
+	other := []byte("pub fun main(): Int { return 2 }")
+
+	m := &mocks.Gateway{}
+	m.On("ExecuteScript", mock.Anything, allowed, mock.Anything).Return(cadence.NewInt(1), nil)
+	m.On("GetLatestBlock", mock.Anything).Return(tests.NewBlock(), nil)
+
+	g := NewAllowlistGateway(m, []string{CodeHash(allowed)})
+
+	t.Run("Allow Script", func(t *testing.T) {
+		value, err := g.ExecuteScript(ctx, allowed, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(1), value)
+	})
+
+	t.Run("Reject Script", func(t *testing.T) {
+		_, err := g.ExecuteScriptAtHeight(ctx, other, nil, 10)
+		assert.ErrorIs(t, err, ErrNotAllowed)
+		m.AssertNotCalled(t, "ExecuteScriptAtHeight", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reject Transaction", func(t *testing.T) {
+		_, err := g.SendSignedTransaction(ctx, flow.NewTransaction().SetScript(other))
+		assert.ErrorIs(t, err, ErrNotAllowed)
+		m.AssertNotCalled(t, "SendSignedTransaction", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Pass Reads", func(t *testing.T) {
+		_, err := g.GetLatestBlock(ctx)
+		assert.NoError(t, err)
+	})
+}
+
+func Test_ChainAllowlistGateway(t *testing.T) {
+	ctx := context.Background()
+	script := []byte("pub fun main(): Int { return 1 }")
+
+	t.Run("Allow Other Chain", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		m.On("GetNetworkParameters", mock.Anything).Return(flow.Testnet, nil).Once()
+		m.On("ExecuteScript", mock.Anything, script, mock.Anything).Return(cadence.NewInt(1), nil)
+
+		g := NewChainAllowlistGateway(m, nil, flow.Mainnet)
+		m.AssertNotCalled(t, "GetNetworkParameters", mock.Anything)
+
+		for i := 0; i < 2; i++ {
+			_, err := g.ExecuteScript(ctx, script, nil)
+			assert.NoError(t, err)
+		}
+		m.AssertNumberOfCalls(t, "GetNetworkParameters", 1)
+	})
+
+	t.Run("Reject Chain", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		m.On("GetNetworkParameters", mock.Anything).Return(flow.Mainnet, nil).Once()
+
+		g := NewChainAllowlistGateway(m, nil, flow.Mainnet)
+		_, err := g.ExecuteScript(ctx, script, nil)
+		assert.ErrorIs(t, err, ErrNotAllowed)
+	})
+
+	t.Run("Fail Unknown Chain", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		m.On("GetNetworkParameters", mock.Anything).Return(flow.ChainID(""), fmt.Errorf("unreachable"))
+
+		g := NewChainAllowlistGateway(m, nil, flow.Mainnet)
+		_, err := g.SendSignedTransaction(ctx, flow.NewTransaction().SetScript(script))
+		assert.EqualError(t, err, "failed to get the network chain ID: unreachable")
+		m.AssertNotCalled(t, "SendSignedTransaction", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,715 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// DefaultCacheTTL is how long the responses depending on the latest block are cached.
+const DefaultCacheTTL = 10 * time.Second
+
+// latestGenerationKey stores the generation of the responses depending on the latest block,
+// the generation is increased when a transaction is sent so those responses are no longer used.
+const latestGenerationKey = "latest-generation"
+
+var _ Gateway = &CacheGateway{}
+
+// CacheGateway is a gateway decorator caching the responses of read-only calls.
+//
+// Immutable responses, like blocks by ID or sealed transaction results, are cached without expiry,
+// while responses depending on the latest block, like accounts or script results, are cached for the TTL.
+// Sending a transaction invalidates the responses depending on the latest block. Caching is best effort,
+// failing to store a response doesn't fail the call.
+type CacheGateway struct {
+	gateway Gateway
+	cache   Cache
+	ttl     time.Duration
+}
+
+// NewCacheGateway returns a new gateway caching the calls of the provided gateway.
+//
+// Calls to an emulator are not cached, since its state can be changed around the cache by loading
+// a snapshot or rolling back, which would leave the cached responses stale.
+func NewCacheGateway(gateway Gateway, cache Cache, ttl time.Duration) *CacheGateway {
+	if _, ok := AsEmulatorAdmin(gateway); ok {
+		cache = noCache{}
+	}
+
+	return &CacheGateway{
+		gateway: gateway,
+		cache:   cache,
+		ttl:     ttl,
+	}
+}
+
+// codec encodes and decodes the cached values.
+type codec[T any] struct {
+	encode func(T) ([]byte, error)
+	decode func([]byte) (T, error)
+}
+
+func jsonCodec[T any]() codec[T] {
+	return codec[T]{
+		encode: func(value T) ([]byte, error) {
+			return json.Marshal(value)
+		},
+		decode: func(raw []byte) (T, error) {
+			var value T
+			err := json.Unmarshal(raw, &value)
+			return value, err
+		},
+	}
+}
+
+// cached returns the value stored by the key or fetches it and stores it if it's cacheable.
+func cached[T any](
+	g *CacheGateway,
+	key string,
+	ttl time.Duration,
+	c codec[T],
+	fetch func() (T, error),
+	cacheable func(T) bool,
+) (T, error) {
+	if raw, ok := g.cache.Get(key); ok {
+		if value, err := c.decode(raw); err == nil {
+			return value, nil
+		}
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+
+	if cacheable == nil || cacheable(value) {
+		if raw, err := c.encode(value); err == nil {
+			_ = g.cache.Set(key, raw, ttl)
+		}
+	}
+
+	return value, nil
+}
+
+// cacheKey returns the key of the call made with the parameters.
+func cacheKey(method string, params ...[]byte) string {
+	hash := sha256.New()
+	for _, param := range params {
+		_ = binary.Write(hash, binary.BigEndian, uint64(len(param)))
+		hash.Write(param)
+	}
+
+	return fmt.Sprintf("%s/%s", method, hex.EncodeToString(hash.Sum(nil)))
+}
+
+// latestKey returns the key of the call depending on the latest block.
+func (g *CacheGateway) latestKey(method string, params ...[]byte) string {
+	return fmt.Sprintf("latest/%d/%s", g.latestGeneration(), cacheKey(method, params...))
+}
+
+func (g *CacheGateway) latestGeneration() uint64 {
+	raw, ok := g.cache.Get(latestGenerationKey)
+	if !ok {
+		return 0
+	}
+
+	generation, _ := strconv.ParseUint(string(raw), 10, 64)
+	return generation
+}
+
+// invalidateLatest makes the cached responses depending on the latest block unused.
+func (g *CacheGateway) invalidateLatest() {
+	generation := strconv.FormatUint(g.latestGeneration()+1, 10)
+	_ = g.cache.Set(latestGenerationKey, []byte(generation), 0)
+}
+
+func heightParam(height uint64) []byte {
+	return []byte(strconv.FormatUint(height, 10))
+}
+
+func scriptParams(script []byte, arguments []cadence.Value) ([][]byte, error) {
+	params := [][]byte{script}
+	for _, arg := range arguments {
+		encoded, err := jsoncdc.Encode(arg)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, encoded)
+	}
+
+	return params, nil
+}
+
+func (g *CacheGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return cached(
+		g,
+		g.latestKey("GetAccount", address.Bytes()),
+		g.ttl,
+		accountCodec,
+		func() (*flow.Account, error) { return g.gateway.GetAccount(ctx, address) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	return cached(
+		g,
+		cacheKey("GetAccountAtBlockHeight", address.Bytes(), heightParam(height)),
+		0,
+		accountCodec,
+		func() (*flow.Account, error) { return g.gateway.GetAccountAtBlockHeight(ctx, address, height) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	g.invalidateLatest()
+	return g.gateway.SendSignedTransaction(ctx, tx)
+}
+
+func (g *CacheGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	return cached(
+		g,
+		cacheKey("GetTransaction", ID.Bytes()),
+		0,
+		jsonCodec[*flow.Transaction](),
+		func() (*flow.Transaction, error) { return g.gateway.GetTransaction(ctx, ID) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return cached(
+		g,
+		cacheKey("GetTransactionResultsByBlockID", blockID.Bytes()),
+		0,
+		transactionResultsCodec,
+		func() ([]*flow.TransactionResult, error) {
+			return g.gateway.GetTransactionResultsByBlockID(ctx, blockID)
+		},
+		func(results []*flow.TransactionResult) bool {
+			for _, result := range results {
+				if !sealed(result) {
+					return false
+				}
+			}
+			return true
+		},
+	)
+}
+
+// GetTransactionResult caches only the sealed results, which also satisfy the calls waiting for the seal.
+func (g *CacheGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	return cached(
+		g,
+		cacheKey("GetTransactionResult", ID.Bytes()),
+		0,
+		transactionResultCodec,
+		func() (*flow.TransactionResult, error) { return g.gateway.GetTransactionResult(ctx, ID, waitSeal) },
+		sealed,
+	)
+}
+
+func (g *CacheGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return cached(
+		g,
+		cacheKey("GetTransactionsByBlockID", blockID.Bytes()),
+		0,
+		jsonCodec[[]*flow.Transaction](),
+		func() ([]*flow.Transaction, error) { return g.gateway.GetTransactionsByBlockID(ctx, blockID) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.Transaction, error) {
+	return cached(
+		g,
+		cacheKey("GetSystemTransaction", blockID.Bytes()),
+		0,
+		jsonCodec[*flow.Transaction](),
+		func() (*flow.Transaction, error) { return g.gateway.GetSystemTransaction(ctx, blockID) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier) (*flow.TransactionResult, error) {
+	return cached(
+		g,
+		cacheKey("GetSystemTransactionResult", blockID.Bytes()),
+		0,
+		transactionResultCodec,
+		func() (*flow.TransactionResult, error) { return g.gateway.GetSystemTransactionResult(ctx, blockID) },
+		sealed,
+	)
+}
+
+func (g *CacheGateway) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	return cached(
+		g,
+		cacheKey("GetExecutionResultForBlockID", blockID.Bytes()),
+		0,
+		jsonCodec[*flow.ExecutionResult](),
+		func() (*flow.ExecutionResult, error) { return g.gateway.GetExecutionResultForBlockID(ctx, blockID) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	params, err := scriptParams(script, arguments)
+	if err != nil {
+		return g.gateway.ExecuteScript(ctx, script, arguments)
+	}
+
+	return cached(
+		g,
+		g.latestKey("ExecuteScript", params...),
+		g.ttl,
+		valueCodec,
+		func() (cadence.Value, error) { return g.gateway.ExecuteScript(ctx, script, arguments) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	params, err := scriptParams(script, arguments)
+	if err != nil {
+		return g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height)
+	}
+
+	return cached(
+		g,
+		cacheKey("ExecuteScriptAtHeight", append(params, heightParam(height))...),
+		0,
+		valueCodec,
+		func() (cadence.Value, error) { return g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	params, err := scriptParams(script, arguments)
+	if err != nil {
+		return g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID)
+	}
+
+	return cached(
+		g,
+		cacheKey("ExecuteScriptAtID", append(params, ID.Bytes())...),
+		0,
+		valueCodec,
+		func() (cadence.Value, error) { return g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	return cached(
+		g,
+		g.latestKey("GetLatestBlock"),
+		g.ttl,
+		jsonCodec[*flow.Block](),
+		func() (*flow.Block, error) { return g.gateway.GetLatestBlock(ctx) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) GetLatestFinalizedBlock(ctx context.Context) (*flow.Block, error) {
+	return cached(
+		g,
+		g.latestKey("GetLatestFinalizedBlock"),
+		g.ttl,
+		jsonCodec[*flow.Block](),
+		func() (*flow.Block, error) { return g.gateway.GetLatestFinalizedBlock(ctx) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	return cached(
+		g,
+		cacheKey("GetBlockByHeight", heightParam(height)),
+		0,
+		jsonCodec[*flow.Block](),
+		func() (*flow.Block, error) { return g.gateway.GetBlockByHeight(ctx, height) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	return cached(
+		g,
+		cacheKey("GetBlockByID", ID.Bytes()),
+		0,
+		jsonCodec[*flow.Block](),
+		func() (*flow.Block, error) { return g.gateway.GetBlockByID(ctx, ID) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	return cached(
+		g,
+		g.latestKey("GetEvents", []byte(eventType), heightParam(startHeight), heightParam(endHeight)),
+		g.ttl,
+		blockEventsCodec,
+		func() ([]flow.BlockEvents, error) { return g.gateway.GetEvents(ctx, eventType, startHeight, endHeight) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) SubscribeEvents(ctx context.Context, eventTypes []string, startHeight uint64) (<-chan flow.BlockEvents, <-chan error, error) {
+	return g.gateway.SubscribeEvents(ctx, eventTypes, startHeight)
+}
+
+func (g *CacheGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	return cached(
+		g,
+		cacheKey("GetCollection", ID.Bytes()),
+		0,
+		jsonCodec[*flow.Collection](),
+		func() (*flow.Collection, error) { return g.gateway.GetCollection(ctx, ID) },
+		nil,
+	)
+}
+
+func (g *CacheGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	return g.gateway.GetLatestProtocolStateSnapshot(ctx)
+}
+
+func (g *CacheGateway) GetNetworkParameters(ctx context.Context) (flow.ChainID, error) {
+	return g.gateway.GetNetworkParameters(ctx)
+}
+
+func (g *CacheGateway) GetNodeVersion(ctx context.Context) (string, error) {
+	return g.gateway.GetNodeVersion(ctx)
+}
+
+func (g *CacheGateway) Ping() error {
+	return g.gateway.Ping()
+}
+
+func (g *CacheGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
+
+// Close the decorated gateway if it holds any resources.
+func (g *CacheGateway) Close() error {
+	if closer, ok := g.gateway.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func sealed(result *flow.TransactionResult) bool {
+	return result != nil && result.Status == flow.TransactionStatusSealed
+}
+
+var valueCodec = codec[cadence.Value]{
+	encode: func(value cadence.Value) ([]byte, error) {
+		return jsoncdc.Encode(value)
+	},
+	decode: func(raw []byte) (cadence.Value, error) {
+		return jsoncdc.Decode(nil, raw)
+	},
+}
+
+// cachedAccountKey is the account key with the public key encoded, so it can be decoded for the signature algorithm.
+type cachedAccountKey struct {
+	Index          int
+	PublicKey      []byte
+	SigAlgo        crypto.SignatureAlgorithm
+	HashAlgo       crypto.HashAlgorithm
+	Weight         int
+	SequenceNumber uint64
+	Revoked        bool
+}
+
+type cachedAccount struct {
+	Address   flow.Address
+	Balance   uint64
+	Code      []byte
+	Keys      []cachedAccountKey
+	Contracts map[string][]byte
+}
+
+var accountCodec = codec[*flow.Account]{
+	encode: func(account *flow.Account) ([]byte, error) {
+		if account == nil {
+			return nil, fmt.Errorf("account not found")
+		}
+
+		c := cachedAccount{
+			Address:   account.Address,
+			Balance:   account.Balance,
+			Code:      account.Code,
+			Contracts: account.Contracts,
+		}
+		for _, key := range account.Keys {
+			c.Keys = append(c.Keys, cachedAccountKey{
+				Index:          key.Index,
+				PublicKey:      key.PublicKey.Encode(),
+				SigAlgo:        key.SigAlgo,
+				HashAlgo:       key.HashAlgo,
+				Weight:         key.Weight,
+				SequenceNumber: key.SequenceNumber,
+				Revoked:        key.Revoked,
+			})
+		}
+		return json.Marshal(c)
+	},
+	decode: func(raw []byte) (*flow.Account, error) {
+		var c cachedAccount
+		if err := json.Unmarshal(raw, &c); err != nil {
+			return nil, err
+		}
+
+		account := &flow.Account{
+			Address:   c.Address,
+			Balance:   c.Balance,
+			Code:      c.Code,
+			Contracts: c.Contracts,
+		}
+		for _, key := range c.Keys {
+			publicKey, err := crypto.DecodePublicKey(key.SigAlgo, key.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+			account.Keys = append(account.Keys, &flow.AccountKey{
+				Index:          key.Index,
+				PublicKey:      publicKey,
+				SigAlgo:        key.SigAlgo,
+				HashAlgo:       key.HashAlgo,
+				Weight:         key.Weight,
+				SequenceNumber: key.SequenceNumber,
+				Revoked:        key.Revoked,
+			})
+		}
+		return account, nil
+	},
+}
+
+// cachedEvent is the event with the value encoded as JSON-Cadence.
+type cachedEvent struct {
+	Type             string
+	TransactionID    flow.Identifier
+	TransactionIndex int
+	EventIndex       int
+	Payload          []byte
+}
+
+func encodeEvents(events []flow.Event) ([]cachedEvent, error) {
+	encoded := make([]cachedEvent, 0, len(events))
+	for _, event := range events {
+		payload, err := jsoncdc.Encode(event.Value)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, cachedEvent{
+			Type:             event.Type,
+			TransactionID:    event.TransactionID,
+			TransactionIndex: event.TransactionIndex,
+			EventIndex:       event.EventIndex,
+			Payload:          payload,
+		})
+	}
+	return encoded, nil
+}
+
+func decodeEvents(encoded []cachedEvent) ([]flow.Event, error) {
+	events := make([]flow.Event, 0, len(encoded))
+	for _, e := range encoded {
+		value, err := jsoncdc.Decode(nil, e.Payload)
+		if err != nil {
+			return nil, err
+		}
+		eventValue, ok := value.(cadence.Event)
+		if !ok {
+			return nil, fmt.Errorf("expected event value, got %s", value.Type().ID())
+		}
+		events = append(events, flow.Event{
+			Type:             e.Type,
+			TransactionID:    e.TransactionID,
+			TransactionIndex: e.TransactionIndex,
+			EventIndex:       e.EventIndex,
+			Value:            eventValue,
+			Payload:          e.Payload,
+		})
+	}
+	return events, nil
+}
+
+// cachedTransactionResult is the transaction result with the error and events encoded.
+type cachedTransactionResult struct {
+	Status        flow.TransactionStatus
+	Error         string
+	Events        []cachedEvent
+	BlockID       flow.Identifier
+	BlockHeight   uint64
+	TransactionID flow.Identifier
+}
+
+func encodeTransactionResult(result *flow.TransactionResult) (cachedTransactionResult, error) {
+	if result == nil {
+		return cachedTransactionResult{}, fmt.Errorf("transaction result not found")
+	}
+
+	events, err := encodeEvents(result.Events)
+	if err != nil {
+		return cachedTransactionResult{}, err
+	}
+
+	c := cachedTransactionResult{
+		Status:        result.Status,
+		Events:        events,
+		BlockID:       result.BlockID,
+		BlockHeight:   result.BlockHeight,
+		TransactionID: result.TransactionID,
+	}
+	if result.Error != nil {
+		c.Error = result.Error.Error()
+	}
+	return c, nil
+}
+
+func decodeTransactionResult(c cachedTransactionResult) (*flow.TransactionResult, error) {
+	events, err := decodeEvents(c.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &flow.TransactionResult{
+		Status:        c.Status,
+		Events:        events,
+		BlockID:       c.BlockID,
+		BlockHeight:   c.BlockHeight,
+		TransactionID: c.TransactionID,
+	}
+	if c.Error != "" {
+		result.Error = errors.New(c.Error)
+	}
+	return result, nil
+}
+
+var transactionResultCodec = codec[*flow.TransactionResult]{
+	encode: func(result *flow.TransactionResult) ([]byte, error) {
+		c, err := encodeTransactionResult(result)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(c)
+	},
+	decode: func(raw []byte) (*flow.TransactionResult, error) {
+		var c cachedTransactionResult
+		if err := json.Unmarshal(raw, &c); err != nil {
+			return nil, err
+		}
+		return decodeTransactionResult(c)
+	},
+}
+
+var transactionResultsCodec = codec[[]*flow.TransactionResult]{
+	encode: func(results []*flow.TransactionResult) ([]byte, error) {
+		encoded := make([]cachedTransactionResult, 0, len(results))
+		for _, result := range results {
+			c, err := encodeTransactionResult(result)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, c)
+		}
+		return json.Marshal(encoded)
+	},
+	decode: func(raw []byte) ([]*flow.TransactionResult, error) {
+		var encoded []cachedTransactionResult
+		if err := json.Unmarshal(raw, &encoded); err != nil {
+			return nil, err
+		}
+
+		results := make([]*flow.TransactionResult, 0, len(encoded))
+		for _, c := range encoded {
+			result, err := decodeTransactionResult(c)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+		return results, nil
+	},
+}
+
+type cachedBlockEvents struct {
+	BlockID        flow.Identifier
+	Height         uint64
+	BlockTimestamp time.Time
+	Events         []cachedEvent
+}
+
+var blockEventsCodec = codec[[]flow.BlockEvents]{
+	encode: func(blockEvents []flow.BlockEvents) ([]byte, error) {
+		encoded := make([]cachedBlockEvents, 0, len(blockEvents))
+		for _, b := range blockEvents {
+			events, err := encodeEvents(b.Events)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, cachedBlockEvents{
+				BlockID:        b.BlockID,
+				Height:         b.Height,
+				BlockTimestamp: b.BlockTimestamp,
+				Events:         events,
+			})
+		}
+		return json.Marshal(encoded)
+	},
+	decode: func(raw []byte) ([]flow.BlockEvents, error) {
+		var encoded []cachedBlockEvents
+		if err := json.Unmarshal(raw, &encoded); err != nil {
+			return nil, err
+		}
+
+		blockEvents := make([]flow.BlockEvents, 0, len(encoded))
+		for _, b := range encoded {
+			events, err := decodeEvents(b.Events)
+			if err != nil {
+				return nil, err
+			}
+			blockEvents = append(blockEvents, flow.BlockEvents{
+				BlockID:        b.BlockID,
+				Height:         b.Height,
+				BlockTimestamp: b.BlockTimestamp,
+				Events:         events,
+			})
+		}
+		return blockEvents, nil
+	},
+}
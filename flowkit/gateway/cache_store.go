@@ -0,0 +1,190 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cache stores the encoded responses of the caching gateway by key.
+type Cache interface {
+	// Get the value stored by the key, expired values are not returned.
+	Get(key string) ([]byte, bool)
+	// Set the value by the key, a zero ttl stores the value without expiry.
+	Set(key string, value []byte, ttl time.Duration) error
+	// Clear all the stored values.
+	Clear() error
+}
+
+type cacheEntry struct {
+	Value   []byte    `json:"value"`
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+func newCacheEntry(value []byte, ttl time.Duration) cacheEntry {
+	entry := cacheEntry{Value: value}
+	if ttl > 0 {
+		entry.Expires = time.Now().Add(ttl)
+	}
+	return entry
+}
+
+func (e cacheEntry) expired() bool {
+	return !e.Expires.IsZero() && time.Now().After(e.Expires)
+}
+
+var _ Cache = &MemoryCache{}
+
+// MemoryCache is a cache keeping the values in memory for the lifetime of the process.
+type MemoryCache struct {
+	entries map[string]cacheEntry
+	mu      sync.RWMutex
+}
+
+// NewMemoryCache returns a new empty in-memory cache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]cacheEntry)}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	if !ok || entry.expired() {
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = newCacheEntry(value, ttl)
+	return nil
+}
+
+func (c *MemoryCache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]cacheEntry)
+	return nil
+}
+
+var _ Cache = &FileCache{}
+
+// FileCache is a cache keeping the values on disk, so they are reused between runs.
+//
+// Each value is stored in a separate file in the cache directory named by the hash of the key.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache returns a new cache storing the values in the directory, the directory is created if missing.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory %s: %w", dir, err)
+	}
+
+	return &FileCache{dir: dir}, nil
+}
+
+func (c *FileCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:]))
+}
+
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	raw, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.expired() {
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+func (c *FileCache) Set(key string, value []byte, ttl time.Duration) error {
+	raw, err := json.Marshal(newCacheEntry(value, ttl))
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so concurrent runs never read a partially written value
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+func (c *FileCache) Clear() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	var clearErr error
+	for _, entry := range entries {
+		if err := os.Remove(filepath.Join(c.dir, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			clearErr = err
+		}
+	}
+	return clearErr
+}
+
+// noCache is a cache not storing any value, used to disable caching.
+type noCache struct{}
+
+var _ Cache = noCache{}
+
+func (noCache) Get(string) ([]byte, bool) {
+	return nil, false
+}
+
+func (noCache) Set(string, []byte, time.Duration) error {
+	return nil
+}
+
+func (noCache) Clear() error {
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func Test_CacheGateway(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Cache Immutable Calls", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		block := tests.NewBlock()
+		m.On("GetBlockByID", mock.Anything, block.ID).Return(block, nil).Once()
+
+		g := NewCacheGateway(m, NewMemoryCache(), time.Minute)
+		for i := 0; i < 3; i++ {
+			cachedBlock, err := g.GetBlockByID(ctx, block.ID)
+			require.NoError(t, err)
+			assert.Equal(t, block.ID, cachedBlock.ID)
+			assert.Equal(t, block.Height, cachedBlock.Height)
+		}
+
+		m.AssertNumberOfCalls(t, "GetBlockByID", 1)
+	})
+
+	t.Run("Expire Latest Calls", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		m.On("GetLatestBlock", mock.Anything).Return(tests.NewBlock(), nil)
+
+		g := NewCacheGateway(m, NewMemoryCache(), 50*time.Millisecond)
+		_, _ = g.GetLatestBlock(ctx)
+		_, _ = g.GetLatestBlock(ctx)
+		m.AssertNumberOfCalls(t, "GetLatestBlock", 1)
+
+		time.Sleep(100 * time.Millisecond)
+		_, _ = g.GetLatestBlock(ctx)
+		m.AssertNumberOfCalls(t, "GetLatestBlock", 2)
+	})
+
+	t.Run("Invalidate Latest Calls On Send", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		account := tests.NewAccountWithAddress("01")
+		script := []byte("access(all) fun main(): Int { return 1 }")
+		args := []cadence.Value{cadence.NewInt(1)}
+		m.On("GetAccount", mock.Anything, account.Address).Return(account, nil)
+		m.On("ExecuteScript", mock.Anything, script, args).Return(cadence.NewInt(1), nil)
+		m.On("SendSignedTransaction", mock.Anything, mock.Anything).Return(tests.NewTransaction(), nil)
+
+		g := NewCacheGateway(m, NewMemoryCache(), time.Minute)
+		cachedAccount, err := g.GetAccount(ctx, account.Address)
+		require.NoError(t, err)
+		_, _ = g.GetAccount(ctx, account.Address)
+		value, err := g.ExecuteScript(ctx, script, args)
+		require.NoError(t, err)
+		_, _ = g.ExecuteScript(ctx, script, args)
+
+		assert.Equal(t, account.Address, cachedAccount.Address)
+		assert.Equal(t, account.Keys[0].PublicKey, cachedAccount.Keys[0].PublicKey)
+		assert.Equal(t, cadence.NewInt(1), value)
+		m.AssertNumberOfCalls(t, "GetAccount", 1)
+		m.AssertNumberOfCalls(t, "ExecuteScript", 1)
+
+		_, err = g.SendSignedTransaction(ctx, tests.NewTransaction())
+		require.NoError(t, err)
+
+		_, _ = g.GetAccount(ctx, account.Address)
+		_, _ = g.ExecuteScript(ctx, script, args)
+		m.AssertNumberOfCalls(t, "GetAccount", 2)
+		m.AssertNumberOfCalls(t, "ExecuteScript", 2)
+	})
+
+	t.Run("Cache Only Sealed Results", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		pending := tests.NewTransactionResult(nil)
+		pending.Status = flow.TransactionStatusPending
+		sealed := tests.NewTransactionResult([]flow.Event{*tests.NewEvent(
+			0,
+			"A.0000000000000001.Foo.Bar",
+			[]cadence.Field{{Type: cadence.StringType{}, Identifier: "bar"}},
+			[]cadence.Value{cadence.String("baz")},
+		)})
+		sealed.Status = flow.TransactionStatusSealed
+		id := flow.HexToID("01")
+		m.On("GetTransactionResult", mock.Anything, id, false).Return(pending, nil).Once()
+		m.On("GetTransactionResult", mock.Anything, id, false).Return(sealed, nil).Once()
+
+		g := NewCacheGateway(m, NewMemoryCache(), time.Minute)
+		result, err := g.GetTransactionResult(ctx, id, false)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusPending, result.Status)
+
+		for i := 0; i < 2; i++ {
+			result, err = g.GetTransactionResult(ctx, id, false)
+			require.NoError(t, err)
+			assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+			require.Len(t, result.Events, 1)
+			assert.Equal(t, `A.0000000000000001.Foo.Bar(bar: "baz")`, result.Events[0].Value.String())
+		}
+
+		m.AssertNumberOfCalls(t, "GetTransactionResult", 2)
+	})
+
+	t.Run("Do Not Cache Errors", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		m.On("GetBlockByHeight", mock.Anything, uint64(10)).Return(nil, assert.AnError).Once()
+		m.On("GetBlockByHeight", mock.Anything, uint64(10)).Return(tests.NewBlock(), nil).Once()
+
+		g := NewCacheGateway(m, NewMemoryCache(), time.Minute)
+		_, err := g.GetBlockByHeight(ctx, 10)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		_, err = g.GetBlockByHeight(ctx, 10)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Skip Emulator Calls", func(t *testing.T) {
+		calls := 0
+		emulator := &Mock{GetLatestBlockFunc: func(ctx context.Context) (*flow.Block, error) {
+			calls++
+			return tests.NewBlock(), nil
+		}}
+
+		g := NewCacheGateway(emulator, NewMemoryCache(), time.Minute)
+		_, _ = g.GetLatestBlock(ctx)
+		_, _ = g.GetLatestBlock(ctx)
+
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func Test_FileCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewFileCache(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Set("foo", []byte("bar"), 0))
+	require.NoError(t, cache.Set("expired", []byte("bar"), time.Nanosecond))
+	time.Sleep(time.Millisecond)
+
+	value, ok := cache.Get("foo")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("bar"), value)
+
+	_, ok = cache.Get("expired")
+	assert.False(t, ok)
+
+	// values are kept between runs
+	reopened, err := NewFileCache(dir)
+	require.NoError(t, err)
+	_, ok = reopened.Get("foo")
+	assert.True(t, ok)
+
+	require.NoError(t, cache.Clear())
+	_, ok = reopened.Get("foo")
+	assert.False(t, ok)
+}
@@ -20,19 +20,33 @@ package gateway
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	goRuntime "runtime"
+	"runtime/debug"
+	"time"
 
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/cadence/runtime"
 	"github.com/onflow/flow-emulator/adapters"
 	"github.com/onflow/flow-emulator/emulator"
+	"github.com/onflow/flow-emulator/server/access"
+	"github.com/onflow/flow-emulator/storage/remote"
+	"github.com/onflow/flow-emulator/storage/sqlite"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	flowGo "github.com/onflow/flow-go/model/flow"
 	"github.com/rs/zerolog"
-	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway/stats"
 )
 
 type EmulatorKey struct {
@@ -41,17 +55,20 @@ type EmulatorKey struct {
 	HashAlgo  crypto.HashAlgorithm
 }
 
+// emulatorModule is the module of the embedded emulator, used to report its version.
+const emulatorModule = "github.com/onflow/flow-emulator"
+
 type EmulatorGateway struct {
 	emulator        *emulator.Blockchain
 	adapter         *adapters.SDKAdapter
 	accessAdapter   *adapters.AccessAdapter
-	ctx             context.Context
 	logger          *zerolog.Logger
 	emulatorOptions []emulator.Option
-}
-
-func UnwrapStatusError(err error) error {
-	return fmt.Errorf(status.Convert(err).Message())
+	store           *sqlite.Store
+	remoteStore     *remote.Store
+	blockTime       time.Duration
+	blocks          *emulator.BlocksTicker
+	retention       *retentionStore
 }
 
 func NewEmulatorGateway(key *EmulatorKey) *EmulatorGateway {
@@ -62,7 +79,6 @@ func NewEmulatorGatewayWithOpts(key *EmulatorKey, opts ...func(*EmulatorGateway)
 
 	noopLogger := zerolog.Nop()
 	gateway := &EmulatorGateway{
-		ctx:             context.Background(),
 		logger:          &noopLogger,
 		emulatorOptions: []emulator.Option{},
 	}
@@ -74,7 +90,14 @@ func NewEmulatorGatewayWithOpts(key *EmulatorKey, opts ...func(*EmulatorGateway)
 	logger := zerolog.Nop()
 	gateway.adapter = adapters.NewSDKAdapter(&logger, gateway.emulator)
 	gateway.accessAdapter = adapters.NewAccessAdapter(&logger, gateway.emulator)
-	gateway.emulator.EnableAutoMine()
+	if gateway.blockTime > 0 {
+		gateway.blocks = emulator.NewBlocksTicker(gateway.emulator, gateway.blockTime)
+		go func() {
+			_ = gateway.blocks.Start()
+		}()
+	} else {
+		gateway.emulator.EnableAutoMine()
+	}
 	return gateway
 }
 
@@ -90,8 +113,303 @@ func WithEmulatorOptions(options ...emulator.Option) func(g *EmulatorGateway) {
 	}
 }
 
-func (g *EmulatorGateway) SetContext(ctx context.Context) {
-	g.ctx = ctx
+// WithBlockTime commits a block at the interval instead of a block for each transaction, so the transactions
+// sent in the same interval are included in the same block and the block timestamps advance with the interval.
+func WithBlockTime(blockTime time.Duration) func(g *EmulatorGateway) {
+	return func(g *EmulatorGateway) {
+		g.blockTime = blockTime
+	}
+}
+
+// WithPersistentStore keeps the emulator state in a store at the path, so the chain state is
+// maintained across process restarts. If the store already contains a chain it is resumed.
+//
+// An error is returned if the store can't be opened, for example if the path is not a directory.
+func WithPersistentStore(path string) (func(g *EmulatorGateway), error) {
+	store, err := openPersistentStore(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(g *EmulatorGateway) {
+		g.store = store
+		g.emulatorOptions = append(g.emulatorOptions, emulator.WithStore(store))
+	}, nil
+}
+
+// persistentStoreFile is the file the emulator keeps the chain state in, inside the store directory.
+const persistentStoreFile = "emulator.sqlite"
+
+// persistentStoreMarker is created next to the store files created by WithPersistentStore,
+// so ResetPersistentStore never removes a store it didn't create, like the standalone emulator database.
+const persistentStoreMarker = ".flow-cli-store"
+
+func openPersistentStore(path string) (*sqlite.Store, error) {
+	if path == "" {
+		return nil, fmt.Errorf("emulator store path must be provided")
+	}
+
+	info, err := os.Stat(path)
+	if err == nil && !info.IsDir() {
+		return nil, fmt.Errorf("emulator store path %s is not a directory", path)
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create emulator store at %s: %w", path, err)
+	}
+
+	_, err = os.Stat(filepath.Join(path, persistentStoreFile))
+	created := errors.Is(err, os.ErrNotExist)
+
+	store, err := sqlite.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open emulator store at %s: %w", path, err)
+	}
+
+	if created {
+		err := os.WriteFile(filepath.Join(path, persistentStoreMarker), nil, 0644)
+		if err != nil {
+			_ = store.Close()
+			return nil, fmt.Errorf("failed to create emulator store at %s: %w", path, err)
+		}
+	}
+
+	return store, nil
+}
+
+// WithRetention keeps only the number of latest blocks in memory, the older blocks are pruned together with
+// their collections, transactions, events and state, so the memory usage stays bounded in long running sessions.
+//
+// The pruned blocks can't be queried anymore and the state can't be rolled back, the retention replaces
+// the persistent store so it shouldn't be combined with WithPersistentStore.
+func WithRetention(blocks uint64) func(g *EmulatorGateway) {
+	return func(g *EmulatorGateway) {
+		g.retention = newRetentionStore(blocks)
+		g.emulatorOptions = append(g.emulatorOptions, emulator.WithStore(g.retention))
+	}
+}
+
+// WithEmulatorConfig applies the emulator configuration to the embedded emulator, keeping the chain state
+// in a persistent store at the configured database path if one is set.
+func WithEmulatorConfig(conf config.Emulator) (func(g *EmulatorGateway), error) {
+	if conf.DBPath == "" {
+		return func(g *EmulatorGateway) {}, nil
+	}
+	return WithPersistentStore(conf.DBPath)
+}
+
+// WithForkedStore forks the network with the chain ID at the block height, reading the state the emulator doesn't
+// have from the archive node of the network, so the transactions are executed on top of the network state.
+//
+// Only mainnet and testnet can be forked, an error is returned if the fork can't be created.
+func WithForkedStore(chainID flow.ChainID, height uint64) (func(g *EmulatorGateway), error) {
+	if chainID != flow.Mainnet && chainID != flow.Testnet {
+		return nil, fmt.Errorf("only mainnet and testnet can be forked, the network chain is %s", chainID)
+	}
+
+	base, err := sqlite.New(sqlite.InMemory)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := remote.New(base, remote.WithChainID(flowGo.ChainID(chainID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fork %s: %w", chainID, err)
+	}
+	if err := store.SetBlockHeight(height); err != nil {
+		return nil, fmt.Errorf("failed to fork %s at block height %d: %w", chainID, height, err)
+	}
+
+	return func(g *EmulatorGateway) {
+		g.store = base
+		g.remoteStore = store
+		g.emulatorOptions = append(
+			g.emulatorOptions,
+			emulator.WithStore(store),
+			emulator.WithChainID(flowGo.ChainID(chainID)),
+		)
+	}, nil
+}
+
+// ResetPersistentStore removes the emulator state persisted at the path by WithPersistentStore.
+//
+// Only the store files are removed, together with the path if nothing else is left in it. An error is returned
+// if the path contains an emulator store which wasn't created by WithPersistentStore, so it is never removed.
+func ResetPersistentStore(path string) error {
+	if path == "" {
+		return fmt.Errorf("emulator store path must be provided")
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("emulator store path %s is not a directory", path)
+	}
+
+	_, err = os.Stat(filepath.Join(path, persistentStoreMarker))
+	if errors.Is(err, os.ErrNotExist) {
+		_, err := os.Stat(filepath.Join(path, persistentStoreFile))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("emulator store at %s was not created by the CLI and will not be removed", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{
+		persistentStoreFile,
+		persistentStoreFile + "-journal",
+		persistentStoreFile + "-wal",
+		persistentStoreFile + "-shm",
+		persistentStoreMarker,
+	} {
+		err := os.Remove(filepath.Join(path, name))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	// the path is kept if it contains other files, like snapshots
+	_ = os.Remove(path)
+	return nil
+}
+
+// Close stops the block production and closes the persistent or forked store if the gateway was created with one.
+func (g *EmulatorGateway) Close() error {
+	if g.blocks != nil {
+		g.blocks.Stop()
+	}
+	if g.remoteStore != nil {
+		g.remoteStore.Stop()
+	}
+	if g.store == nil {
+		return nil
+	}
+	return g.store.Close()
+}
+
+// Serve exposes the emulator on the gRPC and REST access APIs until the context is cancelled, so external
+// tools like the dev wallet or frontends use the same chain state as the gateway. The admin API reports the
+// emulator stats. Zero port disables the server.
+func (g *EmulatorGateway) Serve(ctx context.Context, grpcPort int, restPort int, adminPort int) error {
+	type server interface {
+		Listen() error
+		Start() error
+		Stop()
+	}
+
+	chain := g.emulator.GetChain()
+	servers := make([]server, 0)
+	if grpcPort != 0 {
+		servers = append(servers, access.NewGRPCServer(g.logger, g.accessAdapter, chain, "", grpcPort, false))
+	}
+	if restPort != 0 {
+		rest, err := access.NewRestServer(g.logger, g.accessAdapter, chain, "", restPort, false)
+		if err != nil {
+			return err
+		}
+		servers = append(servers, rest)
+	}
+	if adminPort != 0 {
+		servers = append(servers, newAdminServer(g, adminPort))
+	}
+	if len(servers) == 0 {
+		return fmt.Errorf("at least one of the gRPC, REST or admin ports must be provided")
+	}
+
+	// listen on all ports before serving so a port already in use is reported immediately
+	for i, s := range servers {
+		if err := s.Listen(); err != nil {
+			for _, listening := range servers[:i] {
+				// the servers don't expose their listeners, but serving after the server is stopped
+				// returns immediately and closes the listener
+				listening.Stop()
+				_ = listening.Start()
+			}
+			return err
+		}
+	}
+
+	errs := make(chan error, len(servers))
+	for _, s := range servers {
+		go func(s server) {
+			errs <- s.Start()
+		}(s)
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+
+	for _, s := range servers {
+		s.Stop()
+	}
+	return err
+}
+
+// adminServer serves the emulator stats on the admin API, on the same path as the emulator admin API endpoints.
+type adminServer struct {
+	gateway  *EmulatorGateway
+	port     int
+	listener net.Listener
+	server   *http.Server
+}
+
+func newAdminServer(gateway *EmulatorGateway, port int) *adminServer {
+	s := &adminServer{gateway: gateway, port: port}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/emulator/stats", s.serveStats)
+	s.server = &http.Server{Handler: mux}
+	return s
+}
+
+func (s *adminServer) Listen() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on admin port %d: %w", s.port, err)
+	}
+	s.listener = listener
+	return nil
+}
+
+func (s *adminServer) Start() error {
+	err := s.server.Serve(s.listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (s *adminServer) Stop() {
+	_ = s.server.Close()
+}
+
+func (s *adminServer) serveStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	usage, err := s.gateway.GetEmulatorStats(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(usage)
 }
 
 func newEmulator(key *EmulatorKey, emulatorOptions ...emulator.Option) *emulator.Blockchain {
@@ -111,50 +429,79 @@ func newEmulator(key *EmulatorKey, emulatorOptions ...emulator.Option) *emulator
 	return b
 }
 
-func (g *EmulatorGateway) GetAccount(address flow.Address) (*flow.Account, error) {
-	account, err := g.adapter.GetAccount(g.ctx, address)
+func (g *EmulatorGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	account, err := g.adapter.GetAccount(ctx, address)
+	if err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+	return account, nil
+}
+
+func (g *EmulatorGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	account, err := g.adapter.GetAccountAtBlockHeight(ctx, address, height)
 	if err != nil {
 		return nil, UnwrapStatusError(err)
 	}
 	return account, nil
 }
 
-func (g *EmulatorGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
-	err := g.adapter.SendTransaction(context.Background(), *tx)
+func (g *EmulatorGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	err := g.adapter.SendTransaction(ctx, *tx)
 	if err != nil {
 		return nil, UnwrapStatusError(err)
 	}
 	return tx, nil
 }
 
-func (g *EmulatorGateway) GetTransactionResult(ID flow.Identifier, _ bool) (*flow.TransactionResult, error) {
-	result, err := g.adapter.GetTransactionResult(g.ctx, ID)
+func (g *EmulatorGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, _ bool) (*flow.TransactionResult, error) {
+	result, err := g.adapter.GetTransactionResult(ctx, ID)
 	if err != nil {
 		return nil, UnwrapStatusError(err)
 	}
 	return result, nil
 }
 
-func (g *EmulatorGateway) GetTransaction(id flow.Identifier) (*flow.Transaction, error) {
-	transaction, err := g.adapter.GetTransaction(g.ctx, id)
+func (g *EmulatorGateway) GetTransaction(ctx context.Context, id flow.Identifier) (*flow.Transaction, error) {
+	transaction, err := g.adapter.GetTransaction(ctx, id)
 	if err != nil {
 		return nil, UnwrapStatusError(err)
 	}
 	return transaction, nil
 }
 
-func (g *EmulatorGateway) GetTransactionResultsByBlockID(_ flow.Identifier) ([]*flow.TransactionResult, error) {
-	// TODO: implement
-	panic("GetTransactionResultsByBlockID not implemented")
+func (g *EmulatorGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	results, err := g.adapter.GetTransactionResultsByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+	return results, nil
+}
+
+func (g *EmulatorGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	txs, err := g.adapter.GetTransactionsByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+	return txs, nil
+}
+
+// GetSystemTransaction is not supported since the emulator doesn't execute a system chunk.
+func (g *EmulatorGateway) GetSystemTransaction(_ context.Context, _ flow.Identifier) (*flow.Transaction, error) {
+	return nil, fmt.Errorf("system transactions are not supported by the emulator")
 }
 
-func (g *EmulatorGateway) GetTransactionsByBlockID(_ flow.Identifier) ([]*flow.Transaction, error) {
-	// TODO: implement
-	panic("GetTransactionResultsByBlockID not implemented")
+// GetSystemTransactionResult is not supported since the emulator doesn't execute a system chunk.
+func (g *EmulatorGateway) GetSystemTransactionResult(_ context.Context, _ flow.Identifier) (*flow.TransactionResult, error) {
+	return nil, fmt.Errorf("system transactions are not supported by the emulator")
+}
+
+// GetExecutionResultForBlockID is not supported since the emulator doesn't produce execution results.
+func (g *EmulatorGateway) GetExecutionResultForBlockID(_ context.Context, _ flow.Identifier) (*flow.ExecutionResult, error) {
+	return nil, fmt.Errorf("execution results are not supported by the emulator")
 }
 
 func (g *EmulatorGateway) Ping() error {
-	err := g.adapter.Ping(g.ctx)
+	err := g.adapter.Ping(context.Background())
 	if err != nil {
 		return UnwrapStatusError(err)
 	}
@@ -168,6 +515,7 @@ type scriptQuery struct {
 }
 
 func (g *EmulatorGateway) executeScriptQuery(
+	ctx context.Context,
 	script []byte,
 	arguments []cadence.Value,
 	query scriptQuery,
@@ -179,11 +527,11 @@ func (g *EmulatorGateway) executeScriptQuery(
 
 	var result []byte
 	if query.id != flow.EmptyID {
-		result, err = g.adapter.ExecuteScriptAtBlockID(g.ctx, query.id, script, args)
+		result, err = g.adapter.ExecuteScriptAtBlockID(ctx, query.id, script, args)
 	} else if query.height > 0 {
-		result, err = g.adapter.ExecuteScriptAtBlockHeight(g.ctx, query.height, script, args)
+		result, err = g.adapter.ExecuteScriptAtBlockHeight(ctx, query.height, script, args)
 	} else {
-		result, err = g.adapter.ExecuteScriptAtLatestBlock(g.ctx, script, args)
+		result, err = g.adapter.ExecuteScriptAtLatestBlock(ctx, script, args)
 	}
 
 	if err != nil {
@@ -199,30 +547,42 @@ func (g *EmulatorGateway) executeScriptQuery(
 }
 
 func (g *EmulatorGateway) ExecuteScript(
+	ctx context.Context,
 	script []byte,
 	arguments []cadence.Value,
 ) (cadence.Value, error) {
-	return g.executeScriptQuery(script, arguments, scriptQuery{latest: true})
+	return g.executeScriptQuery(ctx, script, arguments, scriptQuery{latest: true})
 }
 
 func (g *EmulatorGateway) ExecuteScriptAtHeight(
+	ctx context.Context,
 	script []byte,
 	arguments []cadence.Value,
 	height uint64,
 ) (cadence.Value, error) {
-	return g.executeScriptQuery(script, arguments, scriptQuery{height: height})
+	return g.executeScriptQuery(ctx, script, arguments, scriptQuery{height: height})
 }
 
 func (g *EmulatorGateway) ExecuteScriptAtID(
+	ctx context.Context,
 	script []byte,
 	arguments []cadence.Value,
 	id flow.Identifier,
 ) (cadence.Value, error) {
-	return g.executeScriptQuery(script, arguments, scriptQuery{id: id})
+	return g.executeScriptQuery(ctx, script, arguments, scriptQuery{id: id})
 }
 
-func (g *EmulatorGateway) GetLatestBlock() (*flow.Block, error) {
-	block, _, err := g.adapter.GetLatestBlock(g.ctx, true)
+func (g *EmulatorGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	block, _, err := g.adapter.GetLatestBlock(ctx, true)
+	if err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+
+	return block, nil
+}
+
+func (g *EmulatorGateway) GetLatestFinalizedBlock(ctx context.Context) (*flow.Block, error) {
+	block, _, err := g.adapter.GetLatestBlock(ctx, false)
 	if err != nil {
 		return nil, UnwrapStatusError(err)
 	}
@@ -267,6 +627,7 @@ func convertBlock(block *flowGo.Block) *flow.Block {
 }
 
 func (g *EmulatorGateway) GetEvents(
+	ctx context.Context,
 	eventType string,
 	startHeight uint64,
 	endHeight uint64,
@@ -274,49 +635,112 @@ func (g *EmulatorGateway) GetEvents(
 	events := make([]flow.BlockEvents, 0)
 
 	for height := startHeight; height <= endHeight; height++ {
-		events = append(events, g.getBlockEvent(height, eventType))
+		events = append(events, g.getBlockEvent(ctx, height, eventType))
 	}
 
 	return events, nil
 }
 
-func (g *EmulatorGateway) getBlockEvent(height uint64, eventType string) flow.BlockEvents {
-	events, _ := g.adapter.GetEventsForHeightRange(g.ctx, eventType, height, height)
+func (g *EmulatorGateway) getBlockEvent(ctx context.Context, height uint64, eventType string) flow.BlockEvents {
+	events, _ := g.adapter.GetEventsForHeightRange(ctx, eventType, height, height)
 	return *events[0]
 }
 
-func (g *EmulatorGateway) GetCollection(id flow.Identifier) (*flow.Collection, error) {
-	collection, err := g.adapter.GetCollectionByID(g.ctx, id)
+// SubscribeEvents is not supported since the emulator doesn't provide the execution data API.
+func (g *EmulatorGateway) SubscribeEvents(_ context.Context, _ []string, _ uint64) (<-chan flow.BlockEvents, <-chan error, error) {
+	return nil, nil, fmt.Errorf("event subscriptions are not supported by the emulator")
+}
+
+func (g *EmulatorGateway) GetCollection(ctx context.Context, id flow.Identifier) (*flow.Collection, error) {
+	collection, err := g.adapter.GetCollectionByID(ctx, id)
 	if err != nil {
 		return nil, UnwrapStatusError(err)
 	}
 	return collection, nil
 }
 
-func (g *EmulatorGateway) GetBlockByID(id flow.Identifier) (*flow.Block, error) {
-	block, _, err := g.adapter.GetBlockByID(g.ctx, id)
+func (g *EmulatorGateway) GetBlockByID(ctx context.Context, id flow.Identifier) (*flow.Block, error) {
+	// the adapter reports missing blocks as internal errors, so the block is looked up first to keep the not found error
+	if _, err := g.emulator.GetBlockByID(flowGo.Identifier(id)); err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+
+	block, _, err := g.adapter.GetBlockByID(ctx, id)
 	if err != nil {
 		return nil, UnwrapStatusError(err)
 	}
 	return block, nil
 }
 
-func (g *EmulatorGateway) GetBlockByHeight(height uint64) (*flow.Block, error) {
-	block, _, err := g.adapter.GetBlockByHeight(g.ctx, height)
+func (g *EmulatorGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	// the adapter reports missing blocks as internal errors, so the block is looked up first to keep the not found error
+	if _, err := g.emulator.GetBlockByHeight(height); err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+
+	block, _, err := g.adapter.GetBlockByHeight(ctx, height)
 	if err != nil {
 		return nil, UnwrapStatusError(err)
 	}
 	return block, nil
 }
 
-func (g *EmulatorGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
-	snapshot, err := g.adapter.GetLatestProtocolStateSnapshot(g.ctx)
+func (g *EmulatorGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	snapshot, err := g.adapter.GetLatestProtocolStateSnapshot(ctx)
 	if err != nil {
 		return nil, UnwrapStatusError(err)
 	}
 	return snapshot, nil
 }
 
+func (g *EmulatorGateway) GetNetworkParameters(ctx context.Context) (flow.ChainID, error) {
+	return g.adapter.GetChainID(ctx), nil
+}
+
+// GetNodeVersion returns the version of the embedded emulator, or empty if it can't be determined.
+func (g *EmulatorGateway) GetNodeVersion(_ context.Context) (string, error) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", nil
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == emulatorModule {
+			return dep.Version, nil
+		}
+	}
+	return "", nil
+}
+
+// CreateSnapshot creates a named snapshot of the emulator state.
+func (g *EmulatorGateway) CreateSnapshot(_ context.Context, name string) error {
+	return g.emulator.CreateSnapshot(name)
+}
+
+// LoadSnapshot restores the emulator state to the named snapshot.
+func (g *EmulatorGateway) LoadSnapshot(_ context.Context, name string) error {
+	return g.emulator.LoadSnapshot(name)
+}
+
+// GetEmulatorStats returns the current usage of the emulator, the stored data counts are only
+// reported if the gateway was created with a retention.
+func (g *EmulatorGateway) GetEmulatorStats(_ context.Context) (*stats.Emulator, error) {
+	usage := stats.Emulator{}
+	if g.retention != nil {
+		usage = g.retention.stats()
+	} else {
+		latest, err := g.emulator.GetLatestBlock()
+		if err != nil {
+			return nil, UnwrapStatusError(err)
+		}
+		usage.LatestHeight = latest.Header.Height
+	}
+
+	var mem goRuntime.MemStats
+	goRuntime.ReadMemStats(&mem)
+	usage.HeapBytes = mem.HeapAlloc
+	return &usage, nil
+}
+
 // SecureConnection placeholder func to complete gateway interface implementation
 func (g *EmulatorGateway) SecureConnection() bool {
 	return false
@@ -329,3 +753,19 @@ func (g *EmulatorGateway) CoverageReport() *runtime.CoverageReport {
 func (g *EmulatorGateway) RollbackToBlockHeight(height uint64) error {
 	return g.emulator.RollbackToBlockHeight(height)
 }
+
+// Rollback rewinds the emulator state to the block height, removing all the blocks after it.
+func (g *EmulatorGateway) Rollback(_ context.Context, height uint64) error {
+	latest, err := g.emulator.GetLatestBlock()
+	if err != nil {
+		return UnwrapStatusError(err)
+	}
+	if height >= latest.Header.Height {
+		return &Error{
+			Type:    ErrOutOfRange,
+			Message: fmt.Sprintf("rollback height %d must be lower than the latest block height %d", height, latest.Header.Height),
+		}
+	}
+
+	return UnwrapStatusError(g.emulator.RollbackToBlockHeight(height))
+}
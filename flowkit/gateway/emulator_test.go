@@ -0,0 +1,151 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/stats"
+)
+
+func Test_EmulatorRollback(t *testing.T) {
+	gw := NewEmulatorGateway(nil)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := gw.emulator.CommitBlock()
+		require.NoError(t, err)
+	}
+
+	latest, err := gw.GetLatestBlock(ctx)
+	require.NoError(t, err)
+
+	t.Run("Rollback", func(t *testing.T) {
+		require.NoError(t, gw.Rollback(ctx, latest.Height-2))
+
+		block, err := gw.GetLatestBlock(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, latest.Height-2, block.Height)
+
+		_, err = gw.GetBlockByHeight(ctx, latest.Height)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Fail height not lower than latest", func(t *testing.T) {
+		err := gw.Rollback(ctx, latest.Height)
+		assert.ErrorIs(t, err, ErrOutOfRange)
+	})
+}
+
+func Test_EmulatorBlockTime(t *testing.T) {
+	gw := NewEmulatorGatewayWithOpts(nil, WithBlockTime(10*time.Millisecond))
+	defer gw.Close()
+	ctx := context.Background()
+
+	start, err := gw.GetLatestBlock(ctx)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		block, err := gw.GetLatestBlock(ctx)
+		return err == nil && block.Height > start.Height+1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_EmulatorRetention(t *testing.T) {
+	gw := NewEmulatorGatewayWithOpts(nil, WithRetention(3))
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		_, err := gw.emulator.CommitBlock()
+		require.NoError(t, err)
+	}
+
+	latest, err := gw.GetLatestBlock(ctx)
+	require.NoError(t, err)
+
+	t.Run("Prune", func(t *testing.T) {
+		_, err := gw.GetBlockByHeight(ctx, latest.Height-3)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		for height := latest.Height - 2; height <= latest.Height; height++ {
+			_, err := gw.GetBlockByHeight(ctx, height)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		usage, err := gw.GetEmulatorStats(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(3), usage.Blocks)
+		assert.Equal(t, uint64(3), usage.Retention)
+		assert.Equal(t, latest.Height, usage.LatestHeight)
+		assert.Equal(t, latest.Height-2, usage.LowestHeight)
+		assert.Equal(t, latest.Height-2, usage.Pruned)
+		assert.NotZero(t, usage.HeapBytes)
+	})
+
+	t.Run("Script at latest block", func(t *testing.T) {
+		_, err := gw.ExecuteScript(ctx, []byte("pub fun main(): Int { return 1 }"), nil)
+		assert.NoError(t, err)
+	})
+}
+
+func Test_EmulatorServeStats(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	gw := NewEmulatorGateway(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error)
+	go func() {
+		served <- gw.Serve(ctx, 0, 0, port)
+	}()
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = http.Get(fmt.Sprintf("http://127.0.0.1:%d/emulator/stats", port))
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	defer resp.Body.Close()
+
+	var usage stats.Emulator
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&usage))
+	assert.NotZero(t, usage.HeapBytes)
+
+	cancel()
+	assert.NoError(t, <-served)
+}
+
+func Test_EmulatorForkedStore(t *testing.T) {
+	_, err := WithForkedStore(flow.Emulator, 10)
+	assert.EqualError(t, err, "only mainnet and testnet can be forked, the network chain is flow-emulator")
+}
@@ -0,0 +1,160 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"errors"
+
+	"github.com/onflow/flow-emulator/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors returned by the gateways, the failure type can be checked using errors.Is.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrOutOfRange      = errors.New("out of range")
+	ErrRateLimited     = errors.New("rate limited")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrNotAllowed      = errors.New("not allowed")
+)
+
+// statusErrors maps the gRPC status codes to the gateway errors.
+var statusErrors = map[codes.Code]error{
+	codes.NotFound:          ErrNotFound,
+	codes.OutOfRange:        ErrOutOfRange,
+	codes.ResourceExhausted: ErrRateLimited,
+	codes.InvalidArgument:   ErrInvalidArgument,
+}
+
+// Error is a failed gateway call of a known type.
+//
+// It matches the type using errors.Is and wraps the original error, so the gRPC status is still available.
+type Error struct {
+	// Type is one of the gateway errors, such as ErrNotFound.
+	Type error
+	// Message describes the failure.
+	Message string
+	// Err is the original error returned by the access API or the emulator.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is the type of the error.
+func (e *Error) Is(target error) bool {
+	return target == e.Type
+}
+
+// GRPCStatus returns the gRPC status of the original error, or the status matching the error type.
+func (e *Error) GRPCStatus() *status.Status {
+	if s, ok := grpcStatus(e.Err); ok {
+		return s
+	}
+	for code, errType := range statusErrors {
+		if errType == e.Type {
+			return status.New(code, e.Message)
+		}
+	}
+	return status.New(codes.Unknown, e.Message)
+}
+
+// statusError converts an error carrying a gRPC status to a gateway error with the type matching the status code.
+//
+// Errors with other codes, or without a status, are returned unchanged.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if typed := typedError(err, err.Error()); typed != nil {
+		return typed
+	}
+	return err
+}
+
+// UnwrapStatusError converts an error returned by the emulator to an error with only the message.
+//
+// Errors carrying a gRPC status code, or of an emulator error type, matching a gateway error are returned
+// as the gateway Error type.
+func UnwrapStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+	if s, ok := grpcStatus(err); ok {
+		message = s.Message()
+	}
+	if typed := typedError(err, message); typed != nil {
+		return typed
+	}
+	return errors.New(message)
+}
+
+// typedError returns a gateway error with the message if the error status code matches one, otherwise nil.
+func typedError(err error, message string) error {
+	var gatewayErr *Error
+	if errors.As(err, &gatewayErr) {
+		return err
+	}
+
+	errType := errorType(err)
+	if errType == nil {
+		return nil
+	}
+
+	return &Error{
+		Type:    errType,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// errorType returns the gateway error matching the gRPC status code or the emulator error type, otherwise nil.
+func errorType(err error) error {
+	if s, ok := grpcStatus(err); ok {
+		return statusErrors[s.Code()]
+	}
+
+	var notFoundErr types.NotFoundError
+	var invalidArgumentErr *types.InvalidArgumentError
+	var invalidArgumentValueErr types.InvalidArgumentError
+	switch {
+	case errors.As(err, &notFoundErr):
+		return ErrNotFound
+	case errors.As(err, &invalidArgumentErr), errors.As(err, &invalidArgumentValueErr):
+		return ErrInvalidArgument
+	}
+	return nil
+}
+
+// grpcStatus returns the gRPC status carried by the error or any error it wraps.
+func grpcStatus(err error) (*status.Status, bool) {
+	var statusErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &statusErr) {
+		return nil, false
+	}
+	return statusErr.GRPCStatus(), true
+}
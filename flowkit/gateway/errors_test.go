@@ -0,0 +1,101 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_StatusError(t *testing.T) {
+	t.Run("Typed Errors", func(t *testing.T) {
+		types := map[codes.Code]error{
+			codes.NotFound:          ErrNotFound,
+			codes.OutOfRange:        ErrOutOfRange,
+			codes.ResourceExhausted: ErrRateLimited,
+			codes.InvalidArgument:   ErrInvalidArgument,
+		}
+		for code, errType := range types {
+			rpcErr := grpc.RPCError{GRPCErr: status.Error(code, "failure")}
+			err := fmt.Errorf("failed call: %w", statusError(rpcErr))
+
+			assert.ErrorIs(t, err, errType)
+			assert.Equal(t, code, status.Code(errors.Unwrap(err)))
+			assert.Equal(t, "failed call: "+rpcErr.Error(), err.Error())
+
+			var gatewayErr *Error
+			require.ErrorAs(t, err, &gatewayErr)
+			assert.Equal(t, "failure", gatewayErr.GRPCStatus().Message())
+		}
+	})
+
+	t.Run("Untyped Errors", func(t *testing.T) {
+		internal := status.Error(codes.Internal, "failure")
+		assert.Equal(t, internal, statusError(internal))
+
+		plain := errors.New("failure")
+		assert.Equal(t, plain, statusError(plain))
+
+		assert.NoError(t, statusError(nil))
+	})
+
+	t.Run("Unwrap Status Error", func(t *testing.T) {
+		err := UnwrapStatusError(status.Error(codes.NotFound, "account not found"))
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.EqualError(t, err, "account not found")
+
+		err = UnwrapStatusError(status.Error(codes.Internal, "failure"))
+		assert.NotErrorIs(t, err, ErrNotFound)
+		assert.EqualError(t, err, "failure")
+	})
+}
+
+func Test_EmulatorErrors(t *testing.T) {
+	pk, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, make([]byte, crypto.MinSeedLength))
+	require.NoError(t, err)
+
+	gw := NewEmulatorGateway(&EmulatorKey{
+		PublicKey: pk.PublicKey(),
+		SigAlgo:   crypto.ECDSA_P256,
+		HashAlgo:  crypto.SHA3_256,
+	})
+	ctx := context.Background()
+
+	_, err = gw.GetTransaction(ctx, flow.HexToID("01"))
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = gw.GetBlockByHeight(ctx, 100)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = gw.GetBlockByID(ctx, flow.HexToID("01"))
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = gw.GetAccount(ctx, flow.HexToAddress("ff"))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,318 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"google.golang.org/grpc/codes"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// unreachableCodes are the gRPC status codes signaling the access node can not be reached.
+var unreachableCodes = []codes.Code{codes.Unavailable}
+
+var _ Gateway = &FailoverGateway{}
+
+// FailoverGateway is a gateway decorator that switches to the next gateway when the current one is unreachable.
+//
+// Once a gateway fails the next one is used for all the following calls, a call is only
+// failed when none of the gateways can be reached.
+type FailoverGateway struct {
+	gateways []Gateway
+	current  int
+	mu       sync.Mutex
+}
+
+// NewFailoverGateway returns a new gateway failing over the provided gateways in order.
+func NewFailoverGateway(gateways ...Gateway) (*FailoverGateway, error) {
+	if len(gateways) == 0 {
+		return nil, fmt.Errorf("at least one gateway must be provided")
+	}
+
+	return &FailoverGateway{gateways: gateways}, nil
+}
+
+// NewGrpcFailoverGateway returns a new gateway connecting to the network host and failing over to the fallback hosts.
+//
+// Each host is connected with its own key, fallback hosts without a key use a secure connection only if
+// the network is secure.
+func NewGrpcFailoverGateway(network config.Network) (*FailoverGateway, error) {
+	gateways := make([]Gateway, 0, len(network.FallbackHosts)+1)
+	hosts := append([]config.FallbackHost{{Host: network.Host, Key: network.Key}}, network.FallbackHosts...)
+	for _, host := range hosts {
+		hostNetwork := network
+		hostNetwork.Host = host.Host
+		hostNetwork.Key = host.Key
+		hostNetwork.FallbackHosts = nil
+
+		gw, err := NewGrpcGateway(hostNetwork)
+		if err != nil {
+			return nil, err
+		}
+		gateways = append(gateways, gw)
+	}
+
+	return NewFailoverGateway(gateways...)
+}
+
+// gateway returns the gateway currently in use.
+func (g *FailoverGateway) gateway() (int, Gateway) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.current, g.gateways[g.current]
+}
+
+// failover switches from the failed gateway to the next one, unless another call already switched.
+func (g *FailoverGateway) failover(failed int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.current == failed {
+		g.current = (g.current + 1) % len(g.gateways)
+	}
+}
+
+// call tries the call on each gateway, starting with the current one, until one of them is reachable.
+func (g *FailoverGateway) call(call func(Gateway) error) error {
+	var err error
+	for range g.gateways {
+		index, gw := g.gateway()
+		err = call(gw)
+		if !hasStatusCode(err, unreachableCodes) {
+			return err
+		}
+		g.failover(index)
+	}
+
+	return err
+}
+
+func (g *FailoverGateway) GetAccount(ctx context.Context, address flow.Address) (account *flow.Account, err error) {
+	err = g.call(func(gw Gateway) error {
+		account, err = gw.GetAccount(ctx, address)
+		return err
+	})
+	return account, err
+}
+
+func (g *FailoverGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (account *flow.Account, err error) {
+	err = g.call(func(gw Gateway) error {
+		account, err = gw.GetAccountAtBlockHeight(ctx, address, height)
+		return err
+	})
+	return account, err
+}
+
+func (g *FailoverGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (sent *flow.Transaction, err error) {
+	err = g.call(func(gw Gateway) error {
+		sent, err = gw.SendSignedTransaction(ctx, tx)
+		return err
+	})
+	return sent, err
+}
+
+func (g *FailoverGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (tx *flow.Transaction, err error) {
+	err = g.call(func(gw Gateway) error {
+		tx, err = gw.GetTransaction(ctx, ID)
+		return err
+	})
+	return tx, err
+}
+
+func (g *FailoverGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) (results []*flow.TransactionResult, err error) {
+	err = g.call(func(gw Gateway) error {
+		results, err = gw.GetTransactionResultsByBlockID(ctx, blockID)
+		return err
+	})
+	return results, err
+}
+
+func (g *FailoverGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (result *flow.TransactionResult, err error) {
+	err = g.call(func(gw Gateway) error {
+		result, err = gw.GetTransactionResult(ctx, ID, waitSeal)
+		return err
+	})
+	return result, err
+}
+
+func (g *FailoverGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) (txs []*flow.Transaction, err error) {
+	err = g.call(func(gw Gateway) error {
+		txs, err = gw.GetTransactionsByBlockID(ctx, blockID)
+		return err
+	})
+	return txs, err
+}
+
+func (g *FailoverGateway) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (tx *flow.Transaction, err error) {
+	err = g.call(func(gw Gateway) error {
+		tx, err = gw.GetSystemTransaction(ctx, blockID)
+		return err
+	})
+	return tx, err
+}
+
+func (g *FailoverGateway) GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier) (result *flow.TransactionResult, err error) {
+	err = g.call(func(gw Gateway) error {
+		result, err = gw.GetSystemTransactionResult(ctx, blockID)
+		return err
+	})
+	return result, err
+}
+
+func (g *FailoverGateway) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (result *flow.ExecutionResult, err error) {
+	err = g.call(func(gw Gateway) error {
+		result, err = gw.GetExecutionResultForBlockID(ctx, blockID)
+		return err
+	})
+	return result, err
+}
+
+func (g *FailoverGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (value cadence.Value, err error) {
+	err = g.call(func(gw Gateway) error {
+		value, err = gw.ExecuteScript(ctx, script, arguments)
+		return err
+	})
+	return value, err
+}
+
+func (g *FailoverGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (value cadence.Value, err error) {
+	err = g.call(func(gw Gateway) error {
+		value, err = gw.ExecuteScriptAtHeight(ctx, script, arguments, height)
+		return err
+	})
+	return value, err
+}
+
+func (g *FailoverGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (value cadence.Value, err error) {
+	err = g.call(func(gw Gateway) error {
+		value, err = gw.ExecuteScriptAtID(ctx, script, arguments, ID)
+		return err
+	})
+	return value, err
+}
+
+func (g *FailoverGateway) GetLatestBlock(ctx context.Context) (block *flow.Block, err error) {
+	err = g.call(func(gw Gateway) error {
+		block, err = gw.GetLatestBlock(ctx)
+		return err
+	})
+	return block, err
+}
+
+func (g *FailoverGateway) GetLatestFinalizedBlock(ctx context.Context) (block *flow.Block, err error) {
+	err = g.call(func(gw Gateway) error {
+		block, err = gw.GetLatestFinalizedBlock(ctx)
+		return err
+	})
+	return block, err
+}
+
+func (g *FailoverGateway) GetBlockByHeight(ctx context.Context, height uint64) (block *flow.Block, err error) {
+	err = g.call(func(gw Gateway) error {
+		block, err = gw.GetBlockByHeight(ctx, height)
+		return err
+	})
+	return block, err
+}
+
+func (g *FailoverGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (block *flow.Block, err error) {
+	err = g.call(func(gw Gateway) error {
+		block, err = gw.GetBlockByID(ctx, ID)
+		return err
+	})
+	return block, err
+}
+
+func (g *FailoverGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) (events []flow.BlockEvents, err error) {
+	err = g.call(func(gw Gateway) error {
+		events, err = gw.GetEvents(ctx, eventType, startHeight, endHeight)
+		return err
+	})
+	return events, err
+}
+
+func (g *FailoverGateway) SubscribeEvents(ctx context.Context, eventTypes []string, startHeight uint64) (events <-chan flow.BlockEvents, errs <-chan error, err error) {
+	err = g.call(func(gw Gateway) error {
+		events, errs, err = gw.SubscribeEvents(ctx, eventTypes, startHeight)
+		return err
+	})
+	return events, errs, err
+}
+
+func (g *FailoverGateway) GetCollection(ctx context.Context, ID flow.Identifier) (collection *flow.Collection, err error) {
+	err = g.call(func(gw Gateway) error {
+		collection, err = gw.GetCollection(ctx, ID)
+		return err
+	})
+	return collection, err
+}
+
+func (g *FailoverGateway) GetLatestProtocolStateSnapshot(ctx context.Context) (snapshot []byte, err error) {
+	err = g.call(func(gw Gateway) error {
+		snapshot, err = gw.GetLatestProtocolStateSnapshot(ctx)
+		return err
+	})
+	return snapshot, err
+}
+
+func (g *FailoverGateway) GetNetworkParameters(ctx context.Context) (chainID flow.ChainID, err error) {
+	err = g.call(func(gw Gateway) error {
+		chainID, err = gw.GetNetworkParameters(ctx)
+		return err
+	})
+	return chainID, err
+}
+
+func (g *FailoverGateway) GetNodeVersion(ctx context.Context) (version string, err error) {
+	err = g.call(func(gw Gateway) error {
+		version, err = gw.GetNodeVersion(ctx)
+		return err
+	})
+	return version, err
+}
+
+func (g *FailoverGateway) Ping() error {
+	return g.call(func(gw Gateway) error {
+		return gw.Ping()
+	})
+}
+
+func (g *FailoverGateway) SecureConnection() bool {
+	_, gw := g.gateway()
+	return gw.SecureConnection()
+}
+
+// Close all the gateways holding any resources.
+func (g *FailoverGateway) Close() error {
+	var closeErr error
+	for _, gw := range g.gateways {
+		if closer, ok := gw.(io.Closer); ok {
+			if err := closer.Close(); err != nil && closeErr == nil {
+				closeErr = err
+			}
+		}
+	}
+	return closeErr
+}
@@ -0,0 +1,122 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func Test_FailoverGateway(t *testing.T) {
+	ctx := context.Background()
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+
+	t.Run("Fail Over To Next Host", func(t *testing.T) {
+		first, second := &mocks.Gateway{}, &mocks.Gateway{}
+		block := tests.NewBlock()
+		first.On("GetLatestBlock", mock.Anything).Return(nil, unavailable)
+		second.On("GetLatestBlock", mock.Anything).Return(block, nil)
+
+		g, err := NewFailoverGateway(first, second)
+		require.NoError(t, err)
+
+		result, err := g.GetLatestBlock(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, block, result)
+
+		// the reachable host is kept for the following calls
+		_, err = g.GetLatestBlock(ctx)
+		assert.NoError(t, err)
+		first.AssertNumberOfCalls(t, "GetLatestBlock", 1)
+		second.AssertNumberOfCalls(t, "GetLatestBlock", 2)
+	})
+
+	t.Run("Fail When All Hosts Unreachable", func(t *testing.T) {
+		first, second := &mocks.Gateway{}, &mocks.Gateway{}
+		first.On("Ping").Return(unavailable)
+		second.On("Ping").Return(unavailable)
+
+		g, err := NewFailoverGateway(first, second)
+		require.NoError(t, err)
+
+		err = g.Ping()
+		assert.ErrorIs(t, err, unavailable)
+		first.AssertNumberOfCalls(t, "Ping", 1)
+		second.AssertNumberOfCalls(t, "Ping", 1)
+	})
+
+	t.Run("Keep Host On Other Errors", func(t *testing.T) {
+		first, second := &mocks.Gateway{}, &mocks.Gateway{}
+		first.On("Ping").Return(status.Error(codes.Internal, "failure"))
+
+		g, err := NewFailoverGateway(first, second)
+		require.NoError(t, err)
+
+		assert.Error(t, g.Ping())
+		second.AssertNotCalled(t, "Ping")
+	})
+
+	t.Run("Fail Without Gateways", func(t *testing.T) {
+		_, err := NewFailoverGateway()
+		assert.EqualError(t, err, "at least one gateway must be provided")
+	})
+}
+
+func Test_GrpcFailoverGateway(t *testing.T) {
+	const key = "5000676131ad3e22d853a3f75a5b5d0db4236d08dd6612e2baad771014b5266a242bccecc3522ff7207ac357dbe4f225c709d9b273ac484fed5d13976a39bdcd"
+
+	t.Run("Connect Hosts With Own Keys", func(t *testing.T) {
+		g, err := NewGrpcFailoverGateway(config.Network{
+			Name: "mainnet",
+			Host: "access.mainnet.nodes.onflow.org:9000",
+			Key:  key,
+			FallbackHosts: []config.FallbackHost{
+				{Host: "access-001.mainnet.nodes.onflow.org:9000"},
+				{Host: "access-002.mainnet.nodes.onflow.org:9000", Key: key},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, g.gateways, 3)
+
+		secure := make([]bool, 0, len(g.gateways))
+		for _, gw := range g.gateways {
+			secure = append(secure, gw.(*GrpcGateway).secureClient)
+		}
+		assert.Equal(t, []bool{true, false, true}, secure)
+	})
+
+	t.Run("Fail Invalid Fallback Key", func(t *testing.T) {
+		_, err := NewGrpcFailoverGateway(config.Network{
+			Name:          "mainnet",
+			Host:          "access.mainnet.nodes.onflow.org:9000",
+			FallbackHosts: []config.FallbackHost{{Host: "access-001.mainnet.nodes.onflow.org:9000", Key: "invalid"}},
+		})
+		assert.ErrorContains(t, err, "invalid")
+	})
+}
@@ -19,29 +19,42 @@
 package gateway
 
 import (
+	"context"
+
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 )
 
 //go:generate  mockery --name=Gateway
 
-// Gateway describes blockchain access interface
+// Gateway describes blockchain access interface.
+//
+// All the calls are bound to the provided context, which can be used to cancel them or set a deadline.
+// Ping is the exception and doesn't accept a context as it's used by integrations outside the CLI.
 type Gateway interface {
-	GetAccount(flow.Address) (*flow.Account, error)
-	SendSignedTransaction(*flow.Transaction) (*flow.Transaction, error)
-	GetTransaction(flow.Identifier) (*flow.Transaction, error)
-	GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error)
-	GetTransactionResult(flow.Identifier, bool) (*flow.TransactionResult, error)
-	GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error)
-	ExecuteScript([]byte, []cadence.Value) (cadence.Value, error)
-	ExecuteScriptAtHeight([]byte, []cadence.Value, uint64) (cadence.Value, error)
-	ExecuteScriptAtID([]byte, []cadence.Value, flow.Identifier) (cadence.Value, error)
-	GetLatestBlock() (*flow.Block, error)
-	GetBlockByHeight(uint64) (*flow.Block, error)
-	GetBlockByID(flow.Identifier) (*flow.Block, error)
-	GetEvents(string, uint64, uint64) ([]flow.BlockEvents, error)
-	GetCollection(flow.Identifier) (*flow.Collection, error)
-	GetLatestProtocolStateSnapshot() ([]byte, error)
+	GetAccount(context.Context, flow.Address) (*flow.Account, error)
+	GetAccountAtBlockHeight(context.Context, flow.Address, uint64) (*flow.Account, error)
+	SendSignedTransaction(context.Context, *flow.Transaction) (*flow.Transaction, error)
+	GetTransaction(context.Context, flow.Identifier) (*flow.Transaction, error)
+	GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error)
+	GetTransactionResult(context.Context, flow.Identifier, bool) (*flow.TransactionResult, error)
+	GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error)
+	GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.Transaction, error)
+	GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier) (*flow.TransactionResult, error)
+	GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error)
+	ExecuteScript(context.Context, []byte, []cadence.Value) (cadence.Value, error)
+	ExecuteScriptAtHeight(context.Context, []byte, []cadence.Value, uint64) (cadence.Value, error)
+	ExecuteScriptAtID(context.Context, []byte, []cadence.Value, flow.Identifier) (cadence.Value, error)
+	GetLatestBlock(context.Context) (*flow.Block, error)
+	GetLatestFinalizedBlock(context.Context) (*flow.Block, error)
+	GetBlockByHeight(context.Context, uint64) (*flow.Block, error)
+	GetBlockByID(context.Context, flow.Identifier) (*flow.Block, error)
+	GetEvents(context.Context, string, uint64, uint64) ([]flow.BlockEvents, error)
+	SubscribeEvents(context.Context, []string, uint64) (<-chan flow.BlockEvents, <-chan error, error)
+	GetCollection(context.Context, flow.Identifier) (*flow.Collection, error)
+	GetLatestProtocolStateSnapshot(context.Context) ([]byte, error)
+	GetNetworkParameters(context.Context) (flow.ChainID, error)
+	GetNodeVersion(context.Context) (string, error)
 	Ping() error
 	SecureConnection() bool
 }
@@ -20,16 +20,22 @@ package gateway
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
 	"strings"
-	"time"
 
 	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow-go-sdk"
 	grpcAccess "github.com/onflow/flow-go-sdk/access/grpc"
 	"github.com/onflow/flow-go/utils/grpcutils"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	executiondata "github.com/onflow/flow/protobuf/go/flow/executiondata"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/onflow/flow-cli/flowkit/config"
 )
@@ -38,31 +44,94 @@ import (
 // https://github.com/onflow/flow-go/blob/master/utils/grpc/grpc.go#L5
 const maxGRPCMessageSize = 1024 * 1024 * 20
 
+// maxMessageSize returns the max size of the received messages configured for the network or the default.
+func maxMessageSize(network config.Network) int {
+	if network.MaxMessageSize > 0 {
+		return network.MaxMessageSize
+	}
+	return maxGRPCMessageSize
+}
+
+// RequestIDHeader is the gRPC metadata key and HTTP header carrying the request ID of the network,
+// which lets access node operators correlate the requests of a single command.
+const RequestIDHeader = "x-request-id"
+
+// requestIDDialOpts returns the dial options attaching the request ID to the metadata of each unary
+// and streaming call, no options are returned if the request ID is empty.
+func requestIDDialOpts(requestID string) []grpc.DialOption {
+	if requestID == "" {
+		return nil
+	}
+
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(
+			ctx context.Context,
+			method string,
+			req, reply any,
+			cc *grpc.ClientConn,
+			invoker grpc.UnaryInvoker,
+			opts ...grpc.CallOption,
+		) error {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIDHeader, requestID)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(
+			ctx context.Context,
+			desc *grpc.StreamDesc,
+			cc *grpc.ClientConn,
+			method string,
+			streamer grpc.Streamer,
+			opts ...grpc.CallOption,
+		) (grpc.ClientStream, error) {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIDHeader, requestID)
+			return streamer(ctx, desc, cc, method, opts...)
+		}),
+	}
+}
+
 // GrpcGateway is a gateway implementation that uses the Flow Access gRPC API.
 type GrpcGateway struct {
 	client       *grpcAccess.Client
-	ctx          context.Context
+	dialOpts     []grpc.DialOption
 	secureClient bool
+	host         string
+	requestID    string
+	sealPolling  SealPolling
 }
 
 // NewGrpcGateway returns a new gRPC gateway.
+//
+// If the network defines a key the connection is secured by pinning the access node
+// certificate to that key, if the network is marked as secure the connection uses TLS
+// verified against the system root certificates, otherwise an insecure connection is used.
 func NewGrpcGateway(network config.Network) (*GrpcGateway, error) {
+	if network.Key != "" {
+		return NewSecureGrpcGateway(network)
+	}
 
-	gClient, err := grpcAccess.NewClient(
-		network.Host,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
-	)
-	ctx := context.Background()
+	credential := insecure.NewCredentials()
+	if network.Secure {
+		credential = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(credential),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessageSize(network))),
+	}
+	dialOpts = append(dialOpts, requestIDDialOpts(network.RequestID)...)
 
+	gClient, err := grpcAccess.NewClient(network.Host, dialOpts...)
 	if err != nil || gClient == nil {
 		return nil, fmt.Errorf("failed to connect to host %s", network.Host)
 	}
 
 	return &GrpcGateway{
 		client:       gClient,
-		ctx:          ctx,
-		secureClient: false,
+		dialOpts:     dialOpts,
+		secureClient: network.Secure,
+		host:         network.Host,
+		requestID:    network.RequestID,
+		sealPolling:  DefaultSealPolling,
 	}, nil
 }
 
@@ -73,132 +142,348 @@ func NewSecureGrpcGateway(network config.Network) (*GrpcGateway, error) {
 		return nil, fmt.Errorf("failed to create secure GRPC dial options with network key \"%s\": %w", network.Key, err)
 	}
 
-	gClient, err := grpcAccess.NewClient(
-		network.Host,
+	dialOpts := []grpc.DialOption{
 		secureDialOpts,
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
-	)
-	ctx := context.Background()
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessageSize(network))),
+	}
+	dialOpts = append(dialOpts, requestIDDialOpts(network.RequestID)...)
 
+	gClient, err := grpcAccess.NewClient(network.Host, dialOpts...)
 	if err != nil || gClient == nil {
 		return nil, fmt.Errorf("failed to connect to host %s", network.Host)
 	}
 
 	return &GrpcGateway{
 		client:       gClient,
-		ctx:          ctx,
+		dialOpts:     dialOpts,
 		secureClient: true,
+		host:         network.Host,
+		requestID:    network.RequestID,
+		sealPolling:  DefaultSealPolling,
 	}, nil
 }
 
 // GetAccount gets an account by address from the Flow Access API.
-func (g *GrpcGateway) GetAccount(address flow.Address) (*flow.Account, error) {
-	account, err := g.client.GetAccountAtLatestBlock(g.ctx, address)
+func (g *GrpcGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	account, err := g.client.GetAccountAtLatestBlock(ctx, address)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get account with address %s: %w", address, err)
+		return nil, fmt.Errorf("failed to get account with address %s: %w", address, statusError(err))
+	}
+
+	return account, nil
+}
+
+// GetAccountAtBlockHeight gets an account by address as of the block height from the Flow Access API.
+func (g *GrpcGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	account, err := g.client.GetAccountAtBlockHeight(ctx, address, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account with address %s at block height %d: %w", address, height, statusError(err))
 	}
 
 	return account, nil
 }
 
 // SendSignedTransaction sends a transaction to flow that is already prepared and signed.
-func (g *GrpcGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
-	err := g.client.SendTransaction(g.ctx, *tx)
+func (g *GrpcGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	err := g.client.SendTransaction(ctx, *tx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to submit transaction: %w", err)
+		return nil, fmt.Errorf("failed to submit transaction: %w", statusError(err))
 	}
 
 	return tx, nil
 }
 
 // GetTransaction gets a transaction by ID from the Flow Access API.
-func (g *GrpcGateway) GetTransaction(ID flow.Identifier) (*flow.Transaction, error) {
-	return g.client.GetTransaction(g.ctx, ID)
+func (g *GrpcGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	tx, err := g.client.GetTransaction(ctx, ID)
+	return tx, statusError(err)
 }
 
-func (g *GrpcGateway) GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error) {
-	return g.client.GetTransactionResultsByBlockID(g.ctx, blockID)
+func (g *GrpcGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	results, err := g.client.GetTransactionResultsByBlockID(ctx, blockID)
+	return results, statusError(err)
 }
 
-func (g *GrpcGateway) GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error) {
-	return g.client.GetTransactionsByBlockID(g.ctx, blockID)
+func (g *GrpcGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	txs, err := g.client.GetTransactionsByBlockID(ctx, blockID)
+	return txs, statusError(err)
 }
 
-// GetTransactionResult gets a transaction result by ID from the Flow Access API.
-func (g *GrpcGateway) GetTransactionResult(ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
-	result, err := g.client.GetTransactionResult(g.ctx, ID)
+// GetSystemTransaction gets the system chunk transaction of the block from the Flow Access API.
+//
+// Access nodes return the system chunk transaction as the last transaction of the block.
+func (g *GrpcGateway) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.Transaction, error) {
+	txs, err := g.client.GetTransactionsByBlockID(ctx, blockID)
 	if err != nil {
-		return nil, err
+		return nil, statusError(err)
 	}
+	if len(txs) == 0 {
+		return nil, fmt.Errorf("block %s has no system transaction", blockID)
+	}
+
+	return txs[len(txs)-1], nil
+}
 
-	if result.Status != flow.TransactionStatusSealed && waitSeal {
-		time.Sleep(time.Second)
-		return g.GetTransactionResult(ID, waitSeal)
+// GetSystemTransactionResult gets the system chunk transaction result of the block from the Flow Access API.
+//
+// Access nodes return the system chunk transaction result as the last result of the block.
+func (g *GrpcGateway) GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier) (*flow.TransactionResult, error) {
+	results, err := g.client.GetTransactionResultsByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, statusError(err)
 	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("block %s has no system transaction result", blockID)
+	}
+
+	return results[len(results)-1], nil
+}
+
+// GetExecutionResultForBlockID gets the execution result of the block from the Flow Access API.
+func (g *GrpcGateway) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	result, err := g.client.GetExecutionResultForBlockID(ctx, blockID)
+	return result, statusError(err)
+}
 
-	return result, nil
+// SetSealPolling sets how the transaction results are polled while waiting for the transactions to be sealed.
+func (g *GrpcGateway) SetSealPolling(polling SealPolling) {
+	g.sealPolling = polling
+}
+
+// GetTransactionResult gets a transaction result by ID from the Flow Access API.
+//
+// When waiting for the seal the result is streamed if the access client supports it,
+// otherwise the result is polled with backoff until it is sealed or the context is done.
+func (g *GrpcGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	get := func(ctx context.Context) (*flow.TransactionResult, error) {
+		result, err := g.client.GetTransactionResult(ctx, ID)
+		return result, statusError(err)
+	}
+
+	if !waitSeal {
+		return get(ctx)
+	}
+	if subscriber, ok := any(g.client).(transactionStatusSubscriber); ok {
+		result, err := subscribeSeal(ctx, ID, subscriber)
+		return result, statusError(err)
+	}
+
+	return waitForSeal(ctx, ID, g.sealPolling, get)
 }
 
 // ExecuteScript executes a script on Flow through the Access API.
-func (g *GrpcGateway) ExecuteScript(script []byte, arguments []cadence.Value) (cadence.Value, error) {
-	return g.client.ExecuteScriptAtLatestBlock(g.ctx, script, arguments)
+func (g *GrpcGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	value, err := g.client.ExecuteScriptAtLatestBlock(ctx, script, arguments)
+	return value, statusError(err)
 }
 
 // ExecuteScriptAtHeight executes a script at block height.
-func (g *GrpcGateway) ExecuteScriptAtHeight(script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
-	return g.client.ExecuteScriptAtBlockHeight(g.ctx, height, script, arguments)
+func (g *GrpcGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	value, err := g.client.ExecuteScriptAtBlockHeight(ctx, height, script, arguments)
+	return value, statusError(err)
 }
 
 // ExecuteScriptAtID executes a script at block ID.
-func (g *GrpcGateway) ExecuteScriptAtID(script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
-	return g.client.ExecuteScriptAtBlockID(g.ctx, ID, script, arguments)
+func (g *GrpcGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	value, err := g.client.ExecuteScriptAtBlockID(ctx, ID, script, arguments)
+	return value, statusError(err)
 }
 
 // GetLatestBlock gets the latest block on Flow through the Access API.
-func (g *GrpcGateway) GetLatestBlock() (*flow.Block, error) {
-	return g.client.GetLatestBlock(g.ctx, true)
+func (g *GrpcGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	block, err := g.client.GetLatestBlock(ctx, true)
+	return block, statusError(err)
+}
+
+// GetLatestFinalizedBlock gets the latest finalized, but not necessarily sealed, block through the Access API.
+func (g *GrpcGateway) GetLatestFinalizedBlock(ctx context.Context) (*flow.Block, error) {
+	block, err := g.client.GetLatestBlock(ctx, false)
+	return block, statusError(err)
 }
 
 // GetBlockByID get block by ID from the Flow Access API.
-func (g *GrpcGateway) GetBlockByID(id flow.Identifier) (*flow.Block, error) {
-	return g.client.GetBlockByID(g.ctx, id)
+func (g *GrpcGateway) GetBlockByID(ctx context.Context, id flow.Identifier) (*flow.Block, error) {
+	block, err := g.client.GetBlockByID(ctx, id)
+	return block, statusError(err)
 }
 
 // GetBlockByHeight get block by height from the Flow Access API.
-func (g *GrpcGateway) GetBlockByHeight(height uint64) (*flow.Block, error) {
-	return g.client.GetBlockByHeight(g.ctx, height)
+func (g *GrpcGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	block, err := g.client.GetBlockByHeight(ctx, height)
+	return block, statusError(err)
 }
 
 // GetEvents gets events by name and block range from the Flow Access API.
 func (g *GrpcGateway) GetEvents(
+	ctx context.Context,
 	eventType string,
 	startHeight uint64,
 	endHeight uint64,
 ) ([]flow.BlockEvents, error) {
 
 	events, err := g.client.GetEventsForHeightRange(
-		g.ctx,
+		ctx,
 		eventType,
 		startHeight,
 		endHeight,
 	)
 
-	return events, err
+	return events, statusError(err)
+}
+
+// SubscribeEvents streams the events of the provided types from the Flow Execution Data API.
+//
+// Events are streamed for every sealed block starting at the start height, or at the latest sealed
+// block if the start height is zero, and the blocks without matching events are streamed as well so
+// the progress can be tracked. The subscription ends when the context is done or the stream fails,
+// in which case the error is sent on the error channel.
+func (g *GrpcGateway) SubscribeEvents(
+	ctx context.Context,
+	eventTypes []string,
+	startHeight uint64,
+) (<-chan flow.BlockEvents, <-chan error, error) {
+	conn, err := grpc.Dial(g.host, g.dialOpts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to host %s: %w", g.host, err)
+	}
+
+	stream, err := executiondata.NewExecutionDataAPIClient(conn).SubscribeEvents(
+		ctx,
+		&executiondata.SubscribeEventsRequest{
+			StartBlockHeight: startHeight,
+			Filter:           &executiondata.EventFilter{EventType: eventTypes},
+		},
+	)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("failed to subscribe to events: %w", statusError(err))
+	}
+
+	events := make(chan flow.BlockEvents)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+		defer conn.Close()
+
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil && err != io.EOF {
+					errs <- fmt.Errorf("event subscription failed: %w", statusError(err))
+				}
+				return
+			}
+
+			blockEvents, err := messageToBlockEvents(resp)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case events <- blockEvents:
+			}
+		}
+	}()
+
+	return events, errs, nil
+}
+
+// messageToBlockEvents converts the subscription response to the block events.
+func messageToBlockEvents(resp *executiondata.SubscribeEventsResponse) (flow.BlockEvents, error) {
+	blockEvents := flow.BlockEvents{
+		BlockID: flow.BytesToID(resp.GetBlockId()),
+		Height:  resp.GetBlockHeight(),
+		Events:  make([]flow.Event, 0, len(resp.GetEvents())),
+	}
+
+	for _, m := range resp.GetEvents() {
+		value, err := jsoncdc.Decode(nil, m.GetPayload())
+		if err != nil {
+			return flow.BlockEvents{}, fmt.Errorf("failed to decode event payload: %w", err)
+		}
+
+		event, ok := value.(cadence.Event)
+		if !ok {
+			return flow.BlockEvents{}, fmt.Errorf("failed to decode event payload: expected event value, got %s", value.Type().ID())
+		}
+
+		blockEvents.Events = append(blockEvents.Events, flow.Event{
+			Type:             m.GetType(),
+			TransactionID:    flow.BytesToID(m.GetTransactionId()),
+			TransactionIndex: int(m.GetTransactionIndex()),
+			EventIndex:       int(m.GetEventIndex()),
+			Value:            event,
+			Payload:          m.GetPayload(),
+		})
+	}
+
+	return blockEvents, nil
 }
 
 // GetCollection gets a collection by ID from the Flow Access API.
-func (g *GrpcGateway) GetCollection(id flow.Identifier) (*flow.Collection, error) {
-	return g.client.GetCollection(g.ctx, id)
+func (g *GrpcGateway) GetCollection(ctx context.Context, id flow.Identifier) (*flow.Collection, error) {
+	collection, err := g.client.GetCollection(ctx, id)
+	return collection, statusError(err)
 }
 
 // GetLatestProtocolStateSnapshot gets the latest finalized protocol state snapshot
-func (g *GrpcGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
-	return g.client.GetLatestProtocolStateSnapshot(g.ctx)
+func (g *GrpcGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	snapshot, err := g.client.GetLatestProtocolStateSnapshot(ctx)
+	return snapshot, statusError(err)
+}
+
+// GetNetworkParameters gets the chain ID of the network the access node is part of.
+func (g *GrpcGateway) GetNetworkParameters(ctx context.Context) (flow.ChainID, error) {
+	var chainID flow.ChainID
+	err := g.withAccessClient(func(client access.AccessAPIClient) error {
+		resp, err := client.GetNetworkParameters(ctx, &access.GetNetworkParametersRequest{})
+		if err != nil {
+			return statusError(err)
+		}
+		chainID = flow.ChainID(resp.GetChainId())
+		return nil
+	})
+	return chainID, err
+}
+
+// GetNodeVersion gets the semantic version of the software run by the access node.
+func (g *GrpcGateway) GetNodeVersion(ctx context.Context) (string, error) {
+	var version string
+	err := g.withAccessClient(func(client access.AccessAPIClient) error {
+		resp, err := client.GetNodeVersionInfo(ctx, &access.GetNodeVersionInfoRequest{})
+		if err != nil {
+			return statusError(err)
+		}
+		version = resp.GetInfo().GetSemver()
+		return nil
+	})
+	return version, err
+}
+
+// withAccessClient calls the access API directly for the methods not provided by the SDK client.
+func (g *GrpcGateway) withAccessClient(call func(access.AccessAPIClient) error) error {
+	conn, err := grpc.Dial(g.host, g.dialOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to host %s: %w", g.host, err)
+	}
+	defer conn.Close()
+
+	return call(access.NewAccessAPIClient(conn))
 }
 
 // Ping is used to check if the access node is alive and healthy.
 func (g *GrpcGateway) Ping() error {
-	return g.client.Ping(g.ctx)
+	return g.client.Ping(context.Background())
+}
+
+// Close the connection to the access node.
+func (g *GrpcGateway) Close() error {
+	return g.client.Close()
 }
 
 // SecureConnection is used to log warning if a service should be using a secure client but is not
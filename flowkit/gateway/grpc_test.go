@@ -0,0 +1,168 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	executiondata "github.com/onflow/flow/protobuf/go/flow/executiondata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func Test_MessageToBlockEvents(t *testing.T) {
+	event := tests.NewEvent(
+		1,
+		flow.EventAccountCreated,
+		[]cadence.Field{{Identifier: "address", Type: cadence.AddressType{}}},
+		[]cadence.Value{cadence.NewAddress(flow.HexToAddress("01"))},
+	)
+	payload, err := jsoncdc.Encode(event.Value)
+	require.NoError(t, err)
+
+	blockID := flow.HexToID("0a")
+	txID := flow.HexToID("0b")
+
+	t.Run("Success", func(t *testing.T) {
+		blockEvents, err := messageToBlockEvents(&executiondata.SubscribeEventsResponse{
+			BlockId:     blockID.Bytes(),
+			BlockHeight: 10,
+			Events: []*entities.Event{{
+				Type:             flow.EventAccountCreated,
+				TransactionId:    txID.Bytes(),
+				TransactionIndex: 2,
+				EventIndex:       1,
+				Payload:          payload,
+			}},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, blockID, blockEvents.BlockID)
+		assert.Equal(t, uint64(10), blockEvents.Height)
+		require.Len(t, blockEvents.Events, 1)
+		assert.Equal(t, flow.EventAccountCreated, blockEvents.Events[0].Type)
+		assert.Equal(t, txID, blockEvents.Events[0].TransactionID)
+		assert.Equal(t, 2, blockEvents.Events[0].TransactionIndex)
+		assert.Equal(t, 1, blockEvents.Events[0].EventIndex)
+		assert.Equal(t, "0x0000000000000001", blockEvents.Events[0].Value.Fields[0].String())
+	})
+
+	t.Run("Heartbeat", func(t *testing.T) {
+		blockEvents, err := messageToBlockEvents(&executiondata.SubscribeEventsResponse{
+			BlockId:     blockID.Bytes(),
+			BlockHeight: 11,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(11), blockEvents.Height)
+		assert.Len(t, blockEvents.Events, 0)
+	})
+
+	t.Run("Fail Invalid Payload", func(t *testing.T) {
+		_, err := messageToBlockEvents(&executiondata.SubscribeEventsResponse{
+			Events: []*entities.Event{{Type: flow.EventAccountCreated, Payload: []byte("invalid")}},
+		})
+		assert.ErrorContains(t, err, "failed to decode event payload")
+	})
+}
+
+func Test_MaxMessageSize(t *testing.T) {
+	assert.Equal(t, maxGRPCMessageSize, maxMessageSize(config.TestnetNetwork))
+
+	network := config.TestnetNetwork
+	network.MaxMessageSize = 50 * 1024 * 1024
+	assert.Equal(t, 50*1024*1024, maxMessageSize(network))
+}
+
+type requestIDServer struct {
+	access.UnimplementedAccessAPIServer
+	requestIDs chan []string
+}
+
+func (s *requestIDServer) Ping(ctx context.Context, _ *access.PingRequest) (*access.PingResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.requestIDs <- md.Get(RequestIDHeader)
+	return &access.PingResponse{}, nil
+}
+
+func Test_RequestID(t *testing.T) {
+	startServer := func(t *testing.T) (string, *requestIDServer) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		server := grpc.NewServer()
+		accessServer := &requestIDServer{requestIDs: make(chan []string, 1)}
+		access.RegisterAccessAPIServer(server, accessServer)
+		go func() { _ = server.Serve(listener) }()
+		t.Cleanup(server.Stop)
+
+		return listener.Addr().String(), accessServer
+	}
+
+	t.Run("gRPC Metadata", func(t *testing.T) {
+		host, server := startServer(t)
+		gw, err := NewGrpcGateway(config.Network{Name: "test", Host: host, RequestID: "a1b2c3"})
+		require.NoError(t, err)
+		defer gw.Close()
+
+		require.NoError(t, gw.Ping())
+		assert.Equal(t, []string{"a1b2c3"}, <-server.requestIDs)
+	})
+
+	t.Run("No Request ID", func(t *testing.T) {
+		host, server := startServer(t)
+		gw, err := NewGrpcGateway(config.Network{Name: "test", Host: host})
+		require.NoError(t, err)
+		defer gw.Close()
+
+		require.NoError(t, gw.Ping())
+		assert.Empty(t, <-server.requestIDs)
+	})
+
+	t.Run("Admin Header", func(t *testing.T) {
+		var header string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Get(RequestIDHeader)
+		}))
+		defer server.Close()
+
+		gw := &GrpcEmulatorGateway{GrpcGateway: &GrpcGateway{requestID: "a1b2c3"}}
+		req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := gw.doAdminRequest(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "a1b2c3", header)
+	})
+}
@@ -0,0 +1,302 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ Gateway = &MetricsGateway{}
+
+// MetricsGateway is a gateway decorator that records Prometheus metrics of the calls made to the access node.
+//
+// The number of calls, failed calls and the call duration are recorded for each method,
+// together with the time it takes for a sent transaction to be sealed.
+type MetricsGateway struct {
+	gateway  Gateway
+	calls    *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	sealing  prometheus.Histogram
+
+	mu   sync.Mutex
+	sent map[flow.Identifier]time.Time
+	now  func() time.Time
+}
+
+// NewMetricsGateway returns a new gateway recording the metrics of the provided gateway calls to the registerer.
+func NewMetricsGateway(gateway Gateway, registerer prometheus.Registerer) (*MetricsGateway, error) {
+	g := &MetricsGateway{
+		gateway: gateway,
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "flow",
+			Subsystem: "gateway",
+			Name:      "calls_total",
+			Help:      "Number of calls made to the access node.",
+		}, []string{"method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "flow",
+			Subsystem: "gateway",
+			Name:      "errors_total",
+			Help:      "Number of calls to the access node that returned an error.",
+		}, []string{"method"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "flow",
+			Subsystem: "gateway",
+			Name:      "call_duration_seconds",
+			Help:      "Duration of the calls made to the access node.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		sealing: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "flow",
+			Subsystem: "transaction",
+			Name:      "seal_duration_seconds",
+			Help:      "Time from sending a transaction until its result is sealed.",
+			Buckets:   prometheus.ExponentialBuckets(0.5, 2, 10),
+		}),
+		sent: make(map[flow.Identifier]time.Time),
+		now:  time.Now,
+	}
+
+	for _, c := range []prometheus.Collector{g.calls, g.errors, g.duration, g.sealing} {
+		if err := registerer.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+// observe records a call of the method started at the provided time.
+func (g *MetricsGateway) observe(method string, start time.Time, err error) {
+	g.calls.WithLabelValues(method).Inc()
+	g.duration.WithLabelValues(method).Observe(g.now().Sub(start).Seconds())
+	if err != nil {
+		g.errors.WithLabelValues(method).Inc()
+	}
+}
+
+func (g *MetricsGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	start := g.now()
+	account, err := g.gateway.GetAccount(ctx, address)
+	g.observe("GetAccount", start, err)
+	return account, err
+}
+
+func (g *MetricsGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	start := g.now()
+	account, err := g.gateway.GetAccountAtBlockHeight(ctx, address, height)
+	g.observe("GetAccountAtBlockHeight", start, err)
+	return account, err
+}
+
+// SendSignedTransaction records the time the transaction was sent, used to measure the time until it's sealed.
+func (g *MetricsGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	start := g.now()
+	sent, err := g.gateway.SendSignedTransaction(ctx, tx)
+	g.observe("SendSignedTransaction", start, err)
+	if err == nil {
+		g.mu.Lock()
+		g.sent[tx.ID()] = start
+		g.mu.Unlock()
+	}
+	return sent, err
+}
+
+func (g *MetricsGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	start := g.now()
+	tx, err := g.gateway.GetTransaction(ctx, ID)
+	g.observe("GetTransaction", start, err)
+	return tx, err
+}
+
+func (g *MetricsGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	start := g.now()
+	results, err := g.gateway.GetTransactionResultsByBlockID(ctx, blockID)
+	g.observe("GetTransactionResultsByBlockID", start, err)
+	return results, err
+}
+
+// GetTransactionResult records the sealing time of the transactions sent through this gateway.
+func (g *MetricsGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	start := g.now()
+	result, err := g.gateway.GetTransactionResult(ctx, ID, waitSeal)
+	g.observe("GetTransactionResult", start, err)
+
+	if err == nil && result != nil && result.Status == flow.TransactionStatusSealed {
+		g.mu.Lock()
+		if sent, ok := g.sent[ID]; ok {
+			g.sealing.Observe(g.now().Sub(sent).Seconds())
+			delete(g.sent, ID)
+		}
+		g.mu.Unlock()
+	}
+	return result, err
+}
+
+func (g *MetricsGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	start := g.now()
+	txs, err := g.gateway.GetTransactionsByBlockID(ctx, blockID)
+	g.observe("GetTransactionsByBlockID", start, err)
+	return txs, err
+}
+
+func (g *MetricsGateway) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.Transaction, error) {
+	start := g.now()
+	tx, err := g.gateway.GetSystemTransaction(ctx, blockID)
+	g.observe("GetSystemTransaction", start, err)
+	return tx, err
+}
+
+func (g *MetricsGateway) GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier) (*flow.TransactionResult, error) {
+	start := g.now()
+	result, err := g.gateway.GetSystemTransactionResult(ctx, blockID)
+	g.observe("GetSystemTransactionResult", start, err)
+	return result, err
+}
+
+func (g *MetricsGateway) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	start := g.now()
+	result, err := g.gateway.GetExecutionResultForBlockID(ctx, blockID)
+	g.observe("GetExecutionResultForBlockID", start, err)
+	return result, err
+}
+
+func (g *MetricsGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	start := g.now()
+	value, err := g.gateway.ExecuteScript(ctx, script, arguments)
+	g.observe("ExecuteScript", start, err)
+	return value, err
+}
+
+func (g *MetricsGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	start := g.now()
+	value, err := g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height)
+	g.observe("ExecuteScriptAtHeight", start, err)
+	return value, err
+}
+
+func (g *MetricsGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	start := g.now()
+	value, err := g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID)
+	g.observe("ExecuteScriptAtID", start, err)
+	return value, err
+}
+
+func (g *MetricsGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	start := g.now()
+	block, err := g.gateway.GetLatestBlock(ctx)
+	g.observe("GetLatestBlock", start, err)
+	return block, err
+}
+
+func (g *MetricsGateway) GetLatestFinalizedBlock(ctx context.Context) (*flow.Block, error) {
+	start := g.now()
+	block, err := g.gateway.GetLatestFinalizedBlock(ctx)
+	g.observe("GetLatestFinalizedBlock", start, err)
+	return block, err
+}
+
+func (g *MetricsGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	start := g.now()
+	block, err := g.gateway.GetBlockByHeight(ctx, height)
+	g.observe("GetBlockByHeight", start, err)
+	return block, err
+}
+
+func (g *MetricsGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	start := g.now()
+	block, err := g.gateway.GetBlockByID(ctx, ID)
+	g.observe("GetBlockByID", start, err)
+	return block, err
+}
+
+func (g *MetricsGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	start := g.now()
+	events, err := g.gateway.GetEvents(ctx, eventType, startHeight, endHeight)
+	g.observe("GetEvents", start, err)
+	return events, err
+}
+
+// SubscribeEvents only records opening the subscription, the streamed events are not recorded.
+func (g *MetricsGateway) SubscribeEvents(ctx context.Context, eventTypes []string, startHeight uint64) (<-chan flow.BlockEvents, <-chan error, error) {
+	start := g.now()
+	events, errs, err := g.gateway.SubscribeEvents(ctx, eventTypes, startHeight)
+	g.observe("SubscribeEvents", start, err)
+	return events, errs, err
+}
+
+func (g *MetricsGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	start := g.now()
+	collection, err := g.gateway.GetCollection(ctx, ID)
+	g.observe("GetCollection", start, err)
+	return collection, err
+}
+
+func (g *MetricsGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	start := g.now()
+	snapshot, err := g.gateway.GetLatestProtocolStateSnapshot(ctx)
+	g.observe("GetLatestProtocolStateSnapshot", start, err)
+	return snapshot, err
+}
+
+func (g *MetricsGateway) GetNetworkParameters(ctx context.Context) (flow.ChainID, error) {
+	start := g.now()
+	chainID, err := g.gateway.GetNetworkParameters(ctx)
+	g.observe("GetNetworkParameters", start, err)
+	return chainID, err
+}
+
+func (g *MetricsGateway) GetNodeVersion(ctx context.Context) (string, error) {
+	start := g.now()
+	version, err := g.gateway.GetNodeVersion(ctx)
+	g.observe("GetNodeVersion", start, err)
+	return version, err
+}
+
+func (g *MetricsGateway) Ping() error {
+	start := g.now()
+	err := g.gateway.Ping()
+	g.observe("Ping", start, err)
+	return err
+}
+
+func (g *MetricsGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
+
+// Unwrap returns the wrapped gateway, so the emulator admin of the gateway can be found with AsEmulatorAdmin.
+func (g *MetricsGateway) Unwrap() Gateway {
+	return g.gateway
+}
+
+// Close the decorated gateway if it holds any resources.
+func (g *MetricsGateway) Close() error {
+	if closer, ok := g.gateway.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
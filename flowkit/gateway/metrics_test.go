@@ -0,0 +1,96 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func Test_MetricsGateway(t *testing.T) {
+	m := NewMock()
+	m.GetLatestBlockFunc = func(ctx context.Context) (*flow.Block, error) {
+		return tests.NewBlock(), nil
+	}
+	m.GetAccountFunc = func(ctx context.Context, address flow.Address) (*flow.Account, error) {
+		return nil, errors.New("not found")
+	}
+	m.SendSignedTransactionFunc = func(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+		return tx, nil
+	}
+	m.GetTransactionResultFunc = func(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+		return &flow.TransactionResult{Status: flow.TransactionStatusSealed}, nil
+	}
+
+	registry := prometheus.NewRegistry()
+	g, err := NewMetricsGateway(m, registry)
+	require.NoError(t, err)
+
+	now := time.Unix(0, 0)
+	g.now = func() time.Time { return now }
+
+	t.Run("Calls", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, _ = g.GetLatestBlock(context.Background())
+		}
+		_, err := g.GetAccount(context.Background(), flow.HexToAddress("01"))
+		assert.Error(t, err)
+
+		assert.Equal(t, 3.0, testutil.ToFloat64(g.calls.WithLabelValues("GetLatestBlock")))
+		assert.Equal(t, 0.0, testutil.ToFloat64(g.errors.WithLabelValues("GetLatestBlock")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(g.calls.WithLabelValues("GetAccount")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(g.errors.WithLabelValues("GetAccount")))
+	})
+
+	t.Run("Transaction Sealing", func(t *testing.T) {
+		tx := tests.NewTransaction()
+		_, err := g.SendSignedTransaction(context.Background(), tx)
+		require.NoError(t, err)
+
+		now = now.Add(3 * time.Second)
+		_, err = g.GetTransactionResult(context.Background(), tx.ID(), true)
+		require.NoError(t, err)
+
+		// the sealing time is only observed once for each sent transaction
+		_, err = g.GetTransactionResult(context.Background(), tx.ID(), true)
+		require.NoError(t, err)
+
+		metric := &dto.Metric{}
+		require.NoError(t, g.sealing.Write(metric))
+		assert.Equal(t, uint64(1), metric.GetHistogram().GetSampleCount())
+		assert.Equal(t, 3.0, metric.GetHistogram().GetSampleSum())
+		assert.Empty(t, g.sent)
+	})
+
+	t.Run("Duplicate Registration", func(t *testing.T) {
+		_, err := NewMetricsGateway(m, registry)
+		assert.Error(t, err)
+	})
+}
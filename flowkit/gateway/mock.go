@@ -0,0 +1,360 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/stats"
+)
+
+// ErrNotMocked is returned by the Mock gateway when the called method has no function set.
+var ErrNotMocked = errors.New("gateway method is not mocked")
+
+// MockCall is a call recorded by the Mock gateway, the context is not included in the arguments.
+type MockCall struct {
+	Method string
+	Args   []any
+}
+
+// Mock is a programmable gateway for testing programs using flowkit without running the emulator.
+//
+// Each method is implemented by the matching function field, for example GetAccount calls GetAccountFunc.
+// Methods without a function return ErrNotMocked, SecureConnection returns false.
+// All the calls are recorded and can be inspected with Calls and CallsTo.
+//
+// The Mock implements EmulatorAdmin as well, so programs managing the emulator state can be tested.
+type Mock struct {
+	GetAccountFunc                     func(ctx context.Context, address flow.Address) (*flow.Account, error)
+	GetAccountAtBlockHeightFunc        func(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error)
+	SendSignedTransactionFunc          func(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error)
+	GetTransactionFunc                 func(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error)
+	GetTransactionResultsByBlockIDFunc func(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error)
+	GetTransactionResultFunc           func(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error)
+	GetTransactionsByBlockIDFunc       func(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error)
+	GetSystemTransactionFunc           func(ctx context.Context, blockID flow.Identifier) (*flow.Transaction, error)
+	GetSystemTransactionResultFunc     func(ctx context.Context, blockID flow.Identifier) (*flow.TransactionResult, error)
+	GetExecutionResultForBlockIDFunc   func(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error)
+	ExecuteScriptFunc                  func(ctx context.Context, script []byte, args []cadence.Value) (cadence.Value, error)
+	ExecuteScriptAtHeightFunc          func(ctx context.Context, script []byte, args []cadence.Value, height uint64) (cadence.Value, error)
+	ExecuteScriptAtIDFunc              func(ctx context.Context, script []byte, args []cadence.Value, ID flow.Identifier) (cadence.Value, error)
+	GetLatestBlockFunc                 func(ctx context.Context) (*flow.Block, error)
+	GetLatestFinalizedBlockFunc        func(ctx context.Context) (*flow.Block, error)
+	GetBlockByHeightFunc               func(ctx context.Context, height uint64) (*flow.Block, error)
+	GetBlockByIDFunc                   func(ctx context.Context, ID flow.Identifier) (*flow.Block, error)
+	GetEventsFunc                      func(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error)
+	SubscribeEventsFunc                func(ctx context.Context, eventTypes []string, startHeight uint64) (<-chan flow.BlockEvents, <-chan error, error)
+	GetCollectionFunc                  func(ctx context.Context, ID flow.Identifier) (*flow.Collection, error)
+	GetLatestProtocolStateSnapshotFunc func(ctx context.Context) ([]byte, error)
+	GetNetworkParametersFunc           func(ctx context.Context) (flow.ChainID, error)
+	GetNodeVersionFunc                 func(ctx context.Context) (string, error)
+	CreateSnapshotFunc                 func(ctx context.Context, name string) error
+	LoadSnapshotFunc                   func(ctx context.Context, name string) error
+	RollbackFunc                       func(ctx context.Context, height uint64) error
+	GetEmulatorStatsFunc               func(ctx context.Context) (*stats.Emulator, error)
+	PingFunc                           func() error
+	SecureConnectionFunc               func() bool
+
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+var _ Gateway = &Mock{}
+
+// NewMock returns a mock gateway without any methods set.
+func NewMock() *Mock {
+	return &Mock{}
+}
+
+// record the call of the method with the arguments.
+func (m *Mock) record(method string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, MockCall{Method: method, Args: args})
+}
+
+func notMocked(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotMocked, method)
+}
+
+// Calls returns all the calls made to the gateway in order.
+func (m *Mock) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockCall(nil), m.calls...)
+}
+
+// CallsTo returns the calls made to the method in order.
+func (m *Mock) CallsTo(method string) []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]MockCall, 0)
+	for _, call := range m.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset removes all the recorded calls, the functions are kept.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+func (m *Mock) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	m.record("GetAccount", address)
+	if m.GetAccountFunc == nil {
+		return nil, notMocked("GetAccount")
+	}
+	return m.GetAccountFunc(ctx, address)
+}
+
+func (m *Mock) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	m.record("GetAccountAtBlockHeight", address, height)
+	if m.GetAccountAtBlockHeightFunc == nil {
+		return nil, notMocked("GetAccountAtBlockHeight")
+	}
+	return m.GetAccountAtBlockHeightFunc(ctx, address, height)
+}
+
+func (m *Mock) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	m.record("SendSignedTransaction", tx)
+	if m.SendSignedTransactionFunc == nil {
+		return nil, notMocked("SendSignedTransaction")
+	}
+	return m.SendSignedTransactionFunc(ctx, tx)
+}
+
+func (m *Mock) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	m.record("GetTransaction", ID)
+	if m.GetTransactionFunc == nil {
+		return nil, notMocked("GetTransaction")
+	}
+	return m.GetTransactionFunc(ctx, ID)
+}
+
+func (m *Mock) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	m.record("GetTransactionResultsByBlockID", blockID)
+	if m.GetTransactionResultsByBlockIDFunc == nil {
+		return nil, notMocked("GetTransactionResultsByBlockID")
+	}
+	return m.GetTransactionResultsByBlockIDFunc(ctx, blockID)
+}
+
+func (m *Mock) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	m.record("GetTransactionResult", ID, waitSeal)
+	if m.GetTransactionResultFunc == nil {
+		return nil, notMocked("GetTransactionResult")
+	}
+	return m.GetTransactionResultFunc(ctx, ID, waitSeal)
+}
+
+func (m *Mock) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	m.record("GetTransactionsByBlockID", blockID)
+	if m.GetTransactionsByBlockIDFunc == nil {
+		return nil, notMocked("GetTransactionsByBlockID")
+	}
+	return m.GetTransactionsByBlockIDFunc(ctx, blockID)
+}
+
+func (m *Mock) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.Transaction, error) {
+	m.record("GetSystemTransaction", blockID)
+	if m.GetSystemTransactionFunc == nil {
+		return nil, notMocked("GetSystemTransaction")
+	}
+	return m.GetSystemTransactionFunc(ctx, blockID)
+}
+
+func (m *Mock) GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier) (*flow.TransactionResult, error) {
+	m.record("GetSystemTransactionResult", blockID)
+	if m.GetSystemTransactionResultFunc == nil {
+		return nil, notMocked("GetSystemTransactionResult")
+	}
+	return m.GetSystemTransactionResultFunc(ctx, blockID)
+}
+
+func (m *Mock) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	m.record("GetExecutionResultForBlockID", blockID)
+	if m.GetExecutionResultForBlockIDFunc == nil {
+		return nil, notMocked("GetExecutionResultForBlockID")
+	}
+	return m.GetExecutionResultForBlockIDFunc(ctx, blockID)
+}
+
+func (m *Mock) ExecuteScript(ctx context.Context, script []byte, args []cadence.Value) (cadence.Value, error) {
+	m.record("ExecuteScript", script, args)
+	if m.ExecuteScriptFunc == nil {
+		return nil, notMocked("ExecuteScript")
+	}
+	return m.ExecuteScriptFunc(ctx, script, args)
+}
+
+func (m *Mock) ExecuteScriptAtHeight(ctx context.Context, script []byte, args []cadence.Value, height uint64) (cadence.Value, error) {
+	m.record("ExecuteScriptAtHeight", script, args, height)
+	if m.ExecuteScriptAtHeightFunc == nil {
+		return nil, notMocked("ExecuteScriptAtHeight")
+	}
+	return m.ExecuteScriptAtHeightFunc(ctx, script, args, height)
+}
+
+func (m *Mock) ExecuteScriptAtID(ctx context.Context, script []byte, args []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	m.record("ExecuteScriptAtID", script, args, ID)
+	if m.ExecuteScriptAtIDFunc == nil {
+		return nil, notMocked("ExecuteScriptAtID")
+	}
+	return m.ExecuteScriptAtIDFunc(ctx, script, args, ID)
+}
+
+func (m *Mock) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	m.record("GetLatestBlock")
+	if m.GetLatestBlockFunc == nil {
+		return nil, notMocked("GetLatestBlock")
+	}
+	return m.GetLatestBlockFunc(ctx)
+}
+
+func (m *Mock) GetLatestFinalizedBlock(ctx context.Context) (*flow.Block, error) {
+	m.record("GetLatestFinalizedBlock")
+	if m.GetLatestFinalizedBlockFunc == nil {
+		return nil, notMocked("GetLatestFinalizedBlock")
+	}
+	return m.GetLatestFinalizedBlockFunc(ctx)
+}
+
+func (m *Mock) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	m.record("GetBlockByHeight", height)
+	if m.GetBlockByHeightFunc == nil {
+		return nil, notMocked("GetBlockByHeight")
+	}
+	return m.GetBlockByHeightFunc(ctx, height)
+}
+
+func (m *Mock) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	m.record("GetBlockByID", ID)
+	if m.GetBlockByIDFunc == nil {
+		return nil, notMocked("GetBlockByID")
+	}
+	return m.GetBlockByIDFunc(ctx, ID)
+}
+
+func (m *Mock) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	m.record("GetEvents", eventType, startHeight, endHeight)
+	if m.GetEventsFunc == nil {
+		return nil, notMocked("GetEvents")
+	}
+	return m.GetEventsFunc(ctx, eventType, startHeight, endHeight)
+}
+
+func (m *Mock) SubscribeEvents(ctx context.Context, eventTypes []string, startHeight uint64) (<-chan flow.BlockEvents, <-chan error, error) {
+	m.record("SubscribeEvents", eventTypes, startHeight)
+	if m.SubscribeEventsFunc == nil {
+		return nil, nil, notMocked("SubscribeEvents")
+	}
+	return m.SubscribeEventsFunc(ctx, eventTypes, startHeight)
+}
+
+func (m *Mock) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	m.record("GetCollection", ID)
+	if m.GetCollectionFunc == nil {
+		return nil, notMocked("GetCollection")
+	}
+	return m.GetCollectionFunc(ctx, ID)
+}
+
+func (m *Mock) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	m.record("GetLatestProtocolStateSnapshot")
+	if m.GetLatestProtocolStateSnapshotFunc == nil {
+		return nil, notMocked("GetLatestProtocolStateSnapshot")
+	}
+	return m.GetLatestProtocolStateSnapshotFunc(ctx)
+}
+
+func (m *Mock) GetNetworkParameters(ctx context.Context) (flow.ChainID, error) {
+	m.record("GetNetworkParameters")
+	if m.GetNetworkParametersFunc == nil {
+		return "", notMocked("GetNetworkParameters")
+	}
+	return m.GetNetworkParametersFunc(ctx)
+}
+
+func (m *Mock) GetNodeVersion(ctx context.Context) (string, error) {
+	m.record("GetNodeVersion")
+	if m.GetNodeVersionFunc == nil {
+		return "", notMocked("GetNodeVersion")
+	}
+	return m.GetNodeVersionFunc(ctx)
+}
+
+func (m *Mock) CreateSnapshot(ctx context.Context, name string) error {
+	m.record("CreateSnapshot", name)
+	if m.CreateSnapshotFunc == nil {
+		return notMocked("CreateSnapshot")
+	}
+	return m.CreateSnapshotFunc(ctx, name)
+}
+
+func (m *Mock) LoadSnapshot(ctx context.Context, name string) error {
+	m.record("LoadSnapshot", name)
+	if m.LoadSnapshotFunc == nil {
+		return notMocked("LoadSnapshot")
+	}
+	return m.LoadSnapshotFunc(ctx, name)
+}
+
+func (m *Mock) Rollback(ctx context.Context, height uint64) error {
+	m.record("Rollback", height)
+	if m.RollbackFunc == nil {
+		return notMocked("Rollback")
+	}
+	return m.RollbackFunc(ctx, height)
+}
+
+func (m *Mock) GetEmulatorStats(ctx context.Context) (*stats.Emulator, error) {
+	m.record("GetEmulatorStats")
+	if m.GetEmulatorStatsFunc == nil {
+		return nil, notMocked("GetEmulatorStats")
+	}
+	return m.GetEmulatorStatsFunc(ctx)
+}
+
+func (m *Mock) Ping() error {
+	m.record("Ping")
+	if m.PingFunc == nil {
+		return notMocked("Ping")
+	}
+	return m.PingFunc()
+}
+
+func (m *Mock) SecureConnection() bool {
+	m.record("SecureConnection")
+	if m.SecureConnectionFunc == nil {
+		return false
+	}
+	return m.SecureConnectionFunc()
+}
@@ -0,0 +1,80 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func Test_MockGateway(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Programmed Methods", func(t *testing.T) {
+		block := tests.NewBlock()
+		m := NewMock()
+		m.GetBlockByHeightFunc = func(_ context.Context, height uint64) (*flow.Block, error) {
+			assert.Equal(t, uint64(10), height)
+			return block, nil
+		}
+
+		var g Gateway = m
+		result, err := g.GetBlockByHeight(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, block, result)
+	})
+
+	t.Run("Not Mocked", func(t *testing.T) {
+		m := NewMock()
+
+		_, err := m.GetAccount(ctx, flow.HexToAddress("0x01"))
+		assert.ErrorIs(t, err, ErrNotMocked)
+		assert.EqualError(t, err, "gateway method is not mocked: GetAccount")
+		assert.ErrorIs(t, m.Ping(), ErrNotMocked)
+		assert.False(t, m.SecureConnection())
+	})
+
+	t.Run("Record Calls", func(t *testing.T) {
+		m := NewMock()
+		m.GetEventsFunc = func(context.Context, string, uint64, uint64) ([]flow.BlockEvents, error) {
+			return nil, nil
+		}
+
+		_, _ = m.GetEvents(ctx, "A.foo", 1, 10)
+		_, _ = m.GetLatestBlock(ctx)
+		_, _ = m.GetEvents(ctx, "A.bar", 11, 20)
+
+		assert.Equal(t, []MockCall{
+			{Method: "GetEvents", Args: []any{"A.foo", uint64(1), uint64(10)}},
+			{Method: "GetLatestBlock", Args: nil},
+			{Method: "GetEvents", Args: []any{"A.bar", uint64(11), uint64(20)}},
+		}, m.Calls())
+		assert.Len(t, m.CallsTo("GetEvents"), 2)
+		assert.Empty(t, m.CallsTo("Ping"))
+
+		m.Reset()
+		assert.Empty(t, m.Calls())
+	})
+}
@@ -27,16 +27,18 @@ import (
 )
 
 const (
-	GetAccountFunc            = "GetAccount"
-	SendSignedTransactionFunc = "SendSignedTransaction"
-	GetCollectionFunc         = "GetCollection"
-	GetTransactionResultFunc  = "GetTransactionResult"
-	GetEventsFunc             = "GetEvents"
-	GetLatestBlockFunc        = "GetLatestBlock"
-	GetBlockByHeightFunc      = "GetBlockByHeight"
-	GetBlockByIDFunc          = "GetBlockByID"
-	ExecuteScriptFunc         = "ExecuteScript"
-	GetTransactionFunc        = "GetTransaction"
+	GetAccountFunc              = "GetAccount"
+	SendSignedTransactionFunc   = "SendSignedTransaction"
+	GetCollectionFunc           = "GetCollection"
+	GetTransactionResultFunc    = "GetTransactionResult"
+	GetEventsFunc               = "GetEvents"
+	GetLatestBlockFunc          = "GetLatestBlock"
+	GetLatestFinalizedBlockFunc = "GetLatestFinalizedBlock"
+	GetBlockByHeightFunc        = "GetBlockByHeight"
+	GetBlockByIDFunc            = "GetBlockByID"
+	ExecuteScriptFunc           = "ExecuteScript"
+	GetTransactionFunc          = "GetTransaction"
+	GetNetworkParametersFunc    = "GetNetworkParameters"
 )
 
 type TestGateway struct {
@@ -47,6 +49,7 @@ type TestGateway struct {
 	GetTransactionResult           *mock.Call
 	GetEvents                      *mock.Call
 	GetLatestBlock                 *mock.Call
+	GetLatestFinalizedBlock        *mock.Call
 	GetBlockByHeight               *mock.Call
 	GetBlockByID                   *mock.Call
 	ExecuteScript                  *mock.Call
@@ -56,6 +59,7 @@ type TestGateway struct {
 	GetLatestProtocolStateSnapshot *mock.Call
 	Ping                           *mock.Call
 	SecureConnection               *mock.Call
+	GetNetworkParameters           *mock.Call
 }
 
 func DefaultMockGateway() *TestGateway {
@@ -64,39 +68,48 @@ func DefaultMockGateway() *TestGateway {
 		Mock: m,
 		SendSignedTransaction: m.On(
 			SendSignedTransactionFunc,
+			mock.Anything,
 			mock.AnythingOfType("*flow.Transaction"),
 		),
 		GetAccount: m.On(
 			GetAccountFunc,
+			mock.Anything,
 			mock.AnythingOfType("flow.Address"),
 		),
 		GetCollection: m.On(
 			GetCollectionFunc,
+			mock.Anything,
 			mock.AnythingOfType("flow.Identifier"),
 		),
 		GetTransactionResult: m.On(
 			GetTransactionResultFunc,
+			mock.Anything,
 			mock.AnythingOfType("flow.Identifier"),
 			mock.AnythingOfType("bool"),
 		),
 		GetTransaction: m.On(
 			GetTransactionFunc,
+			mock.Anything,
 			mock.AnythingOfType("flow.Identifier"),
 		),
 		GetEvents: m.On(
 			GetEventsFunc,
+			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("uint64"),
 			mock.AnythingOfType("uint64"),
 		),
 		ExecuteScript: m.On(
 			ExecuteScriptFunc,
+			mock.Anything,
 			mock.AnythingOfType("[]uint8"),
 			mock.AnythingOfType("[]cadence.Value"),
 		),
-		GetBlockByHeight: m.On(GetBlockByHeightFunc, mock.Anything),
-		GetBlockByID:     m.On(GetBlockByIDFunc, mock.Anything),
-		GetLatestBlock:   m.On(GetLatestBlockFunc),
+		GetBlockByHeight:        m.On(GetBlockByHeightFunc, mock.Anything, mock.Anything),
+		GetBlockByID:            m.On(GetBlockByIDFunc, mock.Anything, mock.Anything),
+		GetLatestBlock:          m.On(GetLatestBlockFunc, mock.Anything),
+		GetLatestFinalizedBlock: m.On(GetLatestFinalizedBlockFunc, mock.Anything),
+		GetNetworkParameters:    m.On(GetNetworkParametersFunc, mock.Anything),
 	}
 
 	// default return values
@@ -105,7 +118,7 @@ func DefaultMockGateway() *TestGateway {
 	})
 
 	t.GetAccount.Run(func(args mock.Arguments) {
-		addr := args.Get(0).(flow.Address)
+		addr := args.Get(1).(flow.Address)
 		t.GetAccount.Return(tests.NewAccountWithAddress(addr.String()), nil)
 	})
 
@@ -118,8 +131,10 @@ func DefaultMockGateway() *TestGateway {
 	t.GetTransactionResult.Return(tests.NewTransactionResult(nil), nil)
 	t.GetEvents.Return([]flow.BlockEvents{}, nil)
 	t.GetLatestBlock.Return(tests.NewBlock(), nil)
+	t.GetLatestFinalizedBlock.Return(tests.NewBlock(), nil)
 	t.GetBlockByHeight.Return(tests.NewBlock(), nil)
 	t.GetBlockByID.Return(tests.NewBlock(), nil)
+	t.GetNetworkParameters.Return(flow.Emulator, nil)
 
 	return t
 }
@@ -0,0 +1,302 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+)
+
+var _ Gateway = &RateLimitGateway{}
+
+// RateLimitGateway is a gateway decorator that limits the rate of the calls made to the access node.
+//
+// The calls are limited with a token bucket, allowing short bursts up to the number of requests per second,
+// calls exceeding the rate wait for the next token or until the caller context is done.
+type RateLimitGateway struct {
+	gateway Gateway
+	limiter *rateLimiter
+}
+
+// NewRateLimitGateway returns a new gateway limiting the calls of the provided gateway to the requests per second.
+func NewRateLimitGateway(gateway Gateway, requestsPerSecond float64) *RateLimitGateway {
+	return &RateLimitGateway{
+		gateway: gateway,
+		limiter: newRateLimiter(requestsPerSecond, time.Now),
+	}
+}
+
+// rateLimiter is a token bucket refilled at the rate of tokens per second up to the burst size.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newRateLimiter(rate float64, now func() time.Time) *rateLimiter {
+	burst := math.Max(1, math.Floor(rate))
+	return &rateLimiter{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   now(),
+		now:    now,
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns the token reserved by a call that didn't wait for it.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
+
+// wait blocks until the call is allowed by the rate or the context is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	delay := l.reserve()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+func (g *RateLimitGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetAccount(ctx, address)
+}
+
+func (g *RateLimitGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetAccountAtBlockHeight(ctx, address, height)
+}
+
+func (g *RateLimitGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.SendSignedTransaction(ctx, tx)
+}
+
+func (g *RateLimitGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetTransaction(ctx, ID)
+}
+
+func (g *RateLimitGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetTransactionResultsByBlockID(ctx, blockID)
+}
+
+func (g *RateLimitGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetTransactionResult(ctx, ID, waitSeal)
+}
+
+func (g *RateLimitGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetTransactionsByBlockID(ctx, blockID)
+}
+
+func (g *RateLimitGateway) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.Transaction, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetSystemTransaction(ctx, blockID)
+}
+
+func (g *RateLimitGateway) GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier) (*flow.TransactionResult, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetSystemTransactionResult(ctx, blockID)
+}
+
+func (g *RateLimitGateway) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetExecutionResultForBlockID(ctx, blockID)
+}
+
+func (g *RateLimitGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.ExecuteScript(ctx, script, arguments)
+}
+
+func (g *RateLimitGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height)
+}
+
+func (g *RateLimitGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID)
+}
+
+func (g *RateLimitGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetLatestBlock(ctx)
+}
+
+func (g *RateLimitGateway) GetLatestFinalizedBlock(ctx context.Context) (*flow.Block, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetLatestFinalizedBlock(ctx)
+}
+
+func (g *RateLimitGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetBlockByHeight(ctx, height)
+}
+
+func (g *RateLimitGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetBlockByID(ctx, ID)
+}
+
+func (g *RateLimitGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetEvents(ctx, eventType, startHeight, endHeight)
+}
+
+// SubscribeEvents only limits opening the subscription, the streamed events are not limited.
+func (g *RateLimitGateway) SubscribeEvents(ctx context.Context, eventTypes []string, startHeight uint64) (<-chan flow.BlockEvents, <-chan error, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, nil, err
+	}
+	return g.gateway.SubscribeEvents(ctx, eventTypes, startHeight)
+}
+
+func (g *RateLimitGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetCollection(ctx, ID)
+}
+
+func (g *RateLimitGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+	return g.gateway.GetLatestProtocolStateSnapshot(ctx)
+}
+
+func (g *RateLimitGateway) GetNetworkParameters(ctx context.Context) (flow.ChainID, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return "", err
+	}
+	return g.gateway.GetNetworkParameters(ctx)
+}
+
+func (g *RateLimitGateway) GetNodeVersion(ctx context.Context) (string, error) {
+	if err := g.limiter.wait(ctx); err != nil {
+		return "", err
+	}
+	return g.gateway.GetNodeVersion(ctx)
+}
+
+func (g *RateLimitGateway) Ping() error {
+	if err := g.limiter.wait(context.Background()); err != nil {
+		return err
+	}
+	return g.gateway.Ping()
+}
+
+func (g *RateLimitGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
+
+// Unwrap returns the wrapped gateway, so the emulator admin of the gateway can be found with AsEmulatorAdmin.
+func (g *RateLimitGateway) Unwrap() Gateway {
+	return g.gateway
+}
+
+// Close the decorated gateway if it holds any resources.
+func (g *RateLimitGateway) Close() error {
+	if closer, ok := g.gateway.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
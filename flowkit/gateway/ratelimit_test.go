@@ -0,0 +1,80 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func Test_RateLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newRateLimiter(2, func() time.Time { return now })
+
+	t.Run("Burst", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), limiter.reserve())
+		assert.Equal(t, time.Duration(0), limiter.reserve())
+		assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+		assert.Equal(t, time.Second, limiter.reserve())
+	})
+
+	t.Run("Refill", func(t *testing.T) {
+		// the bucket refills up to the burst size
+		now = now.Add(10 * time.Second)
+		assert.Equal(t, time.Duration(0), limiter.reserve())
+		assert.Equal(t, time.Duration(0), limiter.reserve())
+		assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+	})
+
+	t.Run("Canceled Wait", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, limiter.wait(ctx), context.Canceled)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		unlimited := newRateLimiter(0, func() time.Time { return now })
+		for i := 0; i < 10; i++ {
+			assert.NoError(t, unlimited.wait(context.Background()))
+		}
+	})
+}
+
+func Test_RateLimitGateway(t *testing.T) {
+	m := &mocks.Gateway{}
+	m.On("GetLatestBlock", mock.Anything).Return(tests.NewBlock(), nil)
+
+	g := NewRateLimitGateway(m, 20)
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		_, err := g.GetLatestBlock(context.Background())
+		assert.NoError(t, err)
+	}
+
+	// the first 20 calls are allowed by the burst, the remaining 5 calls are spread over 250ms
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	m.AssertNumberOfCalls(t, "GetLatestBlock", 25)
+}
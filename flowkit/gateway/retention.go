@@ -0,0 +1,285 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-emulator/storage"
+	"github.com/onflow/flow-emulator/types"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	flowGo "github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/stats"
+)
+
+// retentionStore is an in-memory emulator store keeping only the latest blocks, the older blocks are pruned
+// together with their collections, transactions, results, events and ledger state after each committed block.
+type retentionStore struct {
+	mu                 sync.RWMutex
+	retention          uint64
+	blockIDToHeight    map[flowGo.Identifier]uint64
+	blocks             map[uint64]flowGo.Block
+	collections        map[flowGo.Identifier]flowGo.LightCollection
+	transactions       map[flowGo.Identifier]flowGo.TransactionBody
+	transactionResults map[flowGo.Identifier]types.StorableTransactionResult
+	ledger             map[uint64]snapshot.SnapshotTree
+	events             map[uint64][]flowGo.Event
+	// collection and transaction IDs committed in the block at each height, so they can be pruned with the block
+	blockCollections  map[uint64][]flowGo.Identifier
+	blockTransactions map[uint64][]flowGo.Identifier
+	lowestHeight      uint64
+	blockHeight       uint64
+	pruned            uint64
+}
+
+var _ storage.Store = &retentionStore{}
+
+// newRetentionStore returns an in-memory store keeping the number of latest blocks, zero keeps all the blocks.
+func newRetentionStore(retention uint64) *retentionStore {
+	return &retentionStore{
+		retention:          retention,
+		blockIDToHeight:    make(map[flowGo.Identifier]uint64),
+		blocks:             make(map[uint64]flowGo.Block),
+		collections:        make(map[flowGo.Identifier]flowGo.LightCollection),
+		transactions:       make(map[flowGo.Identifier]flowGo.TransactionBody),
+		transactionResults: make(map[flowGo.Identifier]types.StorableTransactionResult),
+		ledger:             make(map[uint64]snapshot.SnapshotTree),
+		events:             make(map[uint64][]flowGo.Event),
+		blockCollections:   make(map[uint64][]flowGo.Identifier),
+		blockTransactions:  make(map[uint64][]flowGo.Identifier),
+	}
+}
+
+func (s *retentionStore) Start() error {
+	return nil
+}
+
+func (s *retentionStore) Stop() {}
+
+func (s *retentionStore) LatestBlockHeight(ctx context.Context) (uint64, error) {
+	block, err := s.LatestBlock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return block.Header.Height, nil
+}
+
+func (s *retentionStore) LatestBlock(_ context.Context) (flowGo.Block, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	block, ok := s.blocks[s.blockHeight]
+	if !ok {
+		return flowGo.Block{}, storage.ErrNotFound
+	}
+	return block, nil
+}
+
+func (s *retentionStore) StoreBlock(_ context.Context, block *flowGo.Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.storeBlock(block)
+	return nil
+}
+
+func (s *retentionStore) storeBlock(block *flowGo.Block) {
+	s.blocks[block.Header.Height] = *block
+	s.blockIDToHeight[block.ID()] = block.Header.Height
+	if block.Header.Height > s.blockHeight {
+		s.blockHeight = block.Header.Height
+	}
+}
+
+func (s *retentionStore) BlockByID(_ context.Context, blockID flowGo.Identifier) (*flowGo.Block, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	height, ok := s.blockIDToHeight[blockID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	block, ok := s.blocks[height]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return &block, nil
+}
+
+func (s *retentionStore) BlockByHeight(_ context.Context, height uint64) (*flowGo.Block, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	block, ok := s.blocks[height]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return &block, nil
+}
+
+func (s *retentionStore) CommitBlock(
+	_ context.Context,
+	block flowGo.Block,
+	collections []*flowGo.LightCollection,
+	transactions map[flowGo.Identifier]*flowGo.TransactionBody,
+	transactionResults map[flowGo.Identifier]*types.StorableTransactionResult,
+	executionSnapshot *snapshot.ExecutionSnapshot,
+	events []flowGo.Event,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(transactions) != len(transactionResults) {
+		return fmt.Errorf(
+			"transactions count (%d) does not match result count (%d)",
+			len(transactions),
+			len(transactionResults),
+		)
+	}
+
+	height := block.Header.Height
+	s.storeBlock(&block)
+
+	for _, col := range collections {
+		s.collections[col.ID()] = *col
+		s.blockCollections[height] = append(s.blockCollections[height], col.ID())
+	}
+	for _, tx := range transactions {
+		s.transactions[tx.ID()] = *tx
+		s.blockTransactions[height] = append(s.blockTransactions[height], tx.ID())
+	}
+	for txID, result := range transactionResults {
+		s.transactionResults[txID] = *result
+	}
+
+	s.ledger[height] = s.ledger[height-1].Append(executionSnapshot)
+	s.events[height] = append(s.events[height], events...)
+
+	s.prune()
+	return nil
+}
+
+// prune removes the blocks below the retained heights together with the data committed in them.
+func (s *retentionStore) prune() {
+	if s.retention == 0 || s.blockHeight < s.retention {
+		return
+	}
+
+	for ; s.lowestHeight <= s.blockHeight-s.retention; s.lowestHeight++ {
+		height := s.lowestHeight
+		if block, ok := s.blocks[height]; ok {
+			delete(s.blockIDToHeight, block.ID())
+			delete(s.blocks, height)
+			s.pruned++
+		}
+		for _, id := range s.blockCollections[height] {
+			delete(s.collections, id)
+		}
+		for _, id := range s.blockTransactions[height] {
+			delete(s.transactions, id)
+			delete(s.transactionResults, id)
+		}
+		delete(s.blockCollections, height)
+		delete(s.blockTransactions, height)
+		delete(s.ledger, height)
+		delete(s.events, height)
+	}
+}
+
+func (s *retentionStore) CollectionByID(_ context.Context, collectionID flowGo.Identifier) (flowGo.LightCollection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	col, ok := s.collections[collectionID]
+	if !ok {
+		return flowGo.LightCollection{}, storage.ErrNotFound
+	}
+	return col, nil
+}
+
+func (s *retentionStore) TransactionByID(_ context.Context, transactionID flowGo.Identifier) (flowGo.TransactionBody, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tx, ok := s.transactions[transactionID]
+	if !ok {
+		return flowGo.TransactionBody{}, storage.ErrNotFound
+	}
+	return tx, nil
+}
+
+func (s *retentionStore) TransactionResultByID(_ context.Context, transactionID flowGo.Identifier) (types.StorableTransactionResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result, ok := s.transactionResults[transactionID]
+	if !ok {
+		return types.StorableTransactionResult{}, storage.ErrNotFound
+	}
+	return result, nil
+}
+
+// LedgerByHeight returns the ledger state at the height, the state of the pruned heights is not found.
+func (s *retentionStore) LedgerByHeight(_ context.Context, height uint64) (snapshot.StorageSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if height < s.lowestHeight {
+		return nil, storage.ErrNotFound
+	}
+	return s.ledger[height], nil
+}
+
+func (s *retentionStore) EventsByHeight(_ context.Context, height uint64, eventType string) ([]flowGo.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]flowGo.Event, 0)
+	for _, event := range s.events[height] {
+		if eventType == "" || string(event.Type) == eventType {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// stats returns the number of the stored blocks, transactions, collections and events.
+func (s *retentionStore) stats() stats.Emulator {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var events uint64
+	for _, e := range s.events {
+		events += uint64(len(e))
+	}
+
+	return stats.Emulator{
+		Blocks:       uint64(len(s.blocks)),
+		Transactions: uint64(len(s.transactions)),
+		Collections:  uint64(len(s.collections)),
+		Events:       events,
+		LowestHeight: s.lowestHeight,
+		LatestHeight: s.blockHeight,
+		Retention:    s.retention,
+		Pruned:       s.pruned,
+	}
+}
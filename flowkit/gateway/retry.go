@@ -0,0 +1,346 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryConfig defines how failed gateway calls are retried.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts made for a call, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+	// Multiplier is applied to the delay after each retry.
+	Multiplier float64
+	// RetryableCodes are the gRPC status codes that are considered transient.
+	RetryableCodes []codes.Code
+}
+
+// DefaultRetryConfig retries unavailable and timed out calls up to four times with exponential backoff.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts:    4,
+	InitialBackoff: 250 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	RetryableCodes: []codes.Code{codes.Unavailable, codes.DeadlineExceeded},
+}
+
+var _ Gateway = &RetryGateway{}
+
+// RetryGateway is a gateway decorator that retries calls failing with transient errors.
+//
+// Errors are matched against the retryable codes by their gRPC status, so any gateway
+// returning errors that carry a gRPC status, wrapped or not, can be decorated.
+type RetryGateway struct {
+	gateway Gateway
+	config  RetryConfig
+	sleep   func(context.Context, time.Duration)
+}
+
+// NewRetryGateway returns a new gateway retrying the calls of the provided gateway.
+func NewRetryGateway(gateway Gateway, config RetryConfig) *RetryGateway {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	if config.Multiplier < 1 {
+		config.Multiplier = 1
+	}
+
+	return &RetryGateway{
+		gateway: gateway,
+		config:  config,
+		sleep:   sleep,
+	}
+}
+
+// sleep waits for the duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// retry calls the function until it succeeds, fails with a non retryable error or runs out of attempts.
+//
+// No more attempts are made once the context is done.
+func (g *RetryGateway) retry(ctx context.Context, call func() error) error {
+	backoff := g.config.InitialBackoff
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = call()
+		if err == nil || attempt >= g.config.MaxAttempts || !g.retryable(err) {
+			return err
+		}
+
+		g.sleep(ctx, backoff)
+		if ctx.Err() != nil {
+			return err
+		}
+
+		backoff = time.Duration(float64(backoff) * g.config.Multiplier)
+		if g.config.MaxBackoff > 0 && backoff > g.config.MaxBackoff {
+			backoff = g.config.MaxBackoff
+		}
+	}
+}
+
+// retryable checks whether the error carries a gRPC status with one of the retryable codes.
+func (g *RetryGateway) retryable(err error) bool {
+	return hasStatusCode(err, g.config.RetryableCodes)
+}
+
+// hasStatusCode checks whether the error, or any error it wraps, carries a gRPC status with one of the codes.
+func hasStatusCode(err error, statusCodes []codes.Code) bool {
+	var statusErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+
+	code := statusErr.GRPCStatus().Code()
+	for _, c := range statusCodes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *RetryGateway) GetAccount(ctx context.Context, address flow.Address) (account *flow.Account, err error) {
+	err = g.retry(ctx, func() error {
+		account, err = g.gateway.GetAccount(ctx, address)
+		return err
+	})
+	return account, err
+}
+
+func (g *RetryGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (account *flow.Account, err error) {
+	err = g.retry(ctx, func() error {
+		account, err = g.gateway.GetAccountAtBlockHeight(ctx, address, height)
+		return err
+	})
+	return account, err
+}
+
+func (g *RetryGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (sent *flow.Transaction, err error) {
+	err = g.retry(ctx, func() error {
+		sent, err = g.gateway.SendSignedTransaction(ctx, tx)
+		return err
+	})
+	return sent, err
+}
+
+func (g *RetryGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (tx *flow.Transaction, err error) {
+	err = g.retry(ctx, func() error {
+		tx, err = g.gateway.GetTransaction(ctx, ID)
+		return err
+	})
+	return tx, err
+}
+
+func (g *RetryGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) (results []*flow.TransactionResult, err error) {
+	err = g.retry(ctx, func() error {
+		results, err = g.gateway.GetTransactionResultsByBlockID(ctx, blockID)
+		return err
+	})
+	return results, err
+}
+
+func (g *RetryGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (result *flow.TransactionResult, err error) {
+	err = g.retry(ctx, func() error {
+		result, err = g.gateway.GetTransactionResult(ctx, ID, waitSeal)
+		return err
+	})
+	return result, err
+}
+
+func (g *RetryGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) (txs []*flow.Transaction, err error) {
+	err = g.retry(ctx, func() error {
+		txs, err = g.gateway.GetTransactionsByBlockID(ctx, blockID)
+		return err
+	})
+	return txs, err
+}
+
+func (g *RetryGateway) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (tx *flow.Transaction, err error) {
+	err = g.retry(ctx, func() error {
+		tx, err = g.gateway.GetSystemTransaction(ctx, blockID)
+		return err
+	})
+	return tx, err
+}
+
+func (g *RetryGateway) GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier) (result *flow.TransactionResult, err error) {
+	err = g.retry(ctx, func() error {
+		result, err = g.gateway.GetSystemTransactionResult(ctx, blockID)
+		return err
+	})
+	return result, err
+}
+
+func (g *RetryGateway) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (result *flow.ExecutionResult, err error) {
+	err = g.retry(ctx, func() error {
+		result, err = g.gateway.GetExecutionResultForBlockID(ctx, blockID)
+		return err
+	})
+	return result, err
+}
+
+func (g *RetryGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (value cadence.Value, err error) {
+	err = g.retry(ctx, func() error {
+		value, err = g.gateway.ExecuteScript(ctx, script, arguments)
+		return err
+	})
+	return value, err
+}
+
+func (g *RetryGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (value cadence.Value, err error) {
+	err = g.retry(ctx, func() error {
+		value, err = g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height)
+		return err
+	})
+	return value, err
+}
+
+func (g *RetryGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (value cadence.Value, err error) {
+	err = g.retry(ctx, func() error {
+		value, err = g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID)
+		return err
+	})
+	return value, err
+}
+
+func (g *RetryGateway) GetLatestBlock(ctx context.Context) (block *flow.Block, err error) {
+	err = g.retry(ctx, func() error {
+		block, err = g.gateway.GetLatestBlock(ctx)
+		return err
+	})
+	return block, err
+}
+
+func (g *RetryGateway) GetLatestFinalizedBlock(ctx context.Context) (block *flow.Block, err error) {
+	err = g.retry(ctx, func() error {
+		block, err = g.gateway.GetLatestFinalizedBlock(ctx)
+		return err
+	})
+	return block, err
+}
+
+func (g *RetryGateway) GetBlockByHeight(ctx context.Context, height uint64) (block *flow.Block, err error) {
+	err = g.retry(ctx, func() error {
+		block, err = g.gateway.GetBlockByHeight(ctx, height)
+		return err
+	})
+	return block, err
+}
+
+func (g *RetryGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (block *flow.Block, err error) {
+	err = g.retry(ctx, func() error {
+		block, err = g.gateway.GetBlockByID(ctx, ID)
+		return err
+	})
+	return block, err
+}
+
+func (g *RetryGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) (events []flow.BlockEvents, err error) {
+	err = g.retry(ctx, func() error {
+		events, err = g.gateway.GetEvents(ctx, eventType, startHeight, endHeight)
+		return err
+	})
+	return events, err
+}
+
+// SubscribeEvents retries opening the subscription, once opened the stream is not retried.
+func (g *RetryGateway) SubscribeEvents(ctx context.Context, eventTypes []string, startHeight uint64) (events <-chan flow.BlockEvents, errs <-chan error, err error) {
+	err = g.retry(ctx, func() error {
+		events, errs, err = g.gateway.SubscribeEvents(ctx, eventTypes, startHeight)
+		return err
+	})
+	return events, errs, err
+}
+
+func (g *RetryGateway) GetCollection(ctx context.Context, ID flow.Identifier) (collection *flow.Collection, err error) {
+	err = g.retry(ctx, func() error {
+		collection, err = g.gateway.GetCollection(ctx, ID)
+		return err
+	})
+	return collection, err
+}
+
+func (g *RetryGateway) GetLatestProtocolStateSnapshot(ctx context.Context) (snapshot []byte, err error) {
+	err = g.retry(ctx, func() error {
+		snapshot, err = g.gateway.GetLatestProtocolStateSnapshot(ctx)
+		return err
+	})
+	return snapshot, err
+}
+
+func (g *RetryGateway) GetNetworkParameters(ctx context.Context) (chainID flow.ChainID, err error) {
+	err = g.retry(ctx, func() error {
+		chainID, err = g.gateway.GetNetworkParameters(ctx)
+		return err
+	})
+	return chainID, err
+}
+
+func (g *RetryGateway) GetNodeVersion(ctx context.Context) (version string, err error) {
+	err = g.retry(ctx, func() error {
+		version, err = g.gateway.GetNodeVersion(ctx)
+		return err
+	})
+	return version, err
+}
+
+func (g *RetryGateway) Ping() error {
+	return g.retry(context.Background(), g.gateway.Ping)
+}
+
+func (g *RetryGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
+
+// Unwrap returns the wrapped gateway, so the emulator admin of the gateway can be found with AsEmulatorAdmin.
+func (g *RetryGateway) Unwrap() Gateway {
+	return g.gateway
+}
+
+// Close the decorated gateway if it holds any resources.
+func (g *RetryGateway) Close() error {
+	if closer, ok := g.gateway.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func newTestRetryGateway(m *mocks.Gateway, config RetryConfig) (*RetryGateway, *[]time.Duration) {
+	var delays []time.Duration
+	g := NewRetryGateway(m, config)
+	g.sleep = func(_ context.Context, d time.Duration) {
+		delays = append(delays, d)
+	}
+	return g, &delays
+}
+
+func Test_RetryGateway(t *testing.T) {
+	ctx := context.Background()
+	config := RetryConfig{
+		MaxAttempts:    4,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+		RetryableCodes: []codes.Code{codes.Unavailable, codes.DeadlineExceeded},
+	}
+	unavailable := fmt.Errorf("failed to get latest block: %w", status.Error(codes.Unavailable, "connection refused"))
+
+	t.Run("Retry Transient Errors", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		block := tests.NewBlock()
+		m.On("GetLatestBlock", mock.Anything).Return(nil, unavailable).Twice()
+		m.On("GetLatestBlock", mock.Anything).Return(block, nil).Once()
+
+		g, delays := newTestRetryGateway(m, config)
+		result, err := g.GetLatestBlock(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, block, result)
+		assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, *delays)
+		m.AssertNumberOfCalls(t, "GetLatestBlock", 3)
+	})
+
+	t.Run("Stop After Max Attempts", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		m.On("GetAccount", mock.Anything, mock.Anything).Return(nil, status.Error(codes.DeadlineExceeded, "timeout"))
+
+		g, delays := newTestRetryGateway(m, config)
+		_, err := g.GetAccount(ctx, flow.HexToAddress("01"))
+
+		assert.EqualError(t, err, "rpc error: code = DeadlineExceeded desc = timeout")
+		assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}, *delays)
+		m.AssertNumberOfCalls(t, "GetAccount", 4)
+	})
+
+	t.Run("Stop When Context Done", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		m.On("GetLatestBlock", mock.Anything).Return(nil, unavailable)
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		g, _ := newTestRetryGateway(m, config)
+		g.sleep = func(context.Context, time.Duration) {
+			cancel()
+		}
+		_, err := g.GetLatestBlock(cancelCtx)
+
+		assert.ErrorIs(t, err, unavailable)
+		m.AssertNumberOfCalls(t, "GetLatestBlock", 1)
+	})
+
+	t.Run("Fail Non Retryable Errors", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		m.On("Ping").Return(status.Error(codes.InvalidArgument, "invalid"))
+
+		g, delays := newTestRetryGateway(m, config)
+		err := g.Ping()
+
+		assert.Error(t, err)
+		assert.Empty(t, *delays)
+		m.AssertNumberOfCalls(t, "Ping", 1)
+	})
+
+	t.Run("Fail Errors Without Status", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		m.On("GetNodeVersion", mock.Anything).Return("", fmt.Errorf("not found"))
+
+		g, _ := newTestRetryGateway(m, config)
+		_, err := g.GetNodeVersion(ctx)
+
+		assert.EqualError(t, err, "not found")
+		m.AssertNumberOfCalls(t, "GetNodeVersion", 1)
+	})
+}
@@ -0,0 +1,141 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// SealPolling configures how often a gateway polls a transaction result while waiting for it to be sealed.
+//
+// The interval between the polls starts at Interval and grows by Multiplier after each poll
+// up to MaxInterval. A zero Timeout waits until the context is done.
+type SealPolling struct {
+	Interval    time.Duration
+	MaxInterval time.Duration
+	Multiplier  float64
+	Timeout     time.Duration
+}
+
+// DefaultSealPolling polls the result every second at first and backs off to every 5 seconds,
+// transactions are usually sealed within a few seconds so the first polls are the most frequent.
+var DefaultSealPolling = SealPolling{
+	Interval:    time.Second,
+	MaxInterval: 5 * time.Second,
+	Multiplier:  1.5,
+}
+
+// next returns the interval following the provided one.
+func (p SealPolling) next(interval time.Duration) time.Duration {
+	if p.Multiplier > 1 {
+		interval = time.Duration(float64(interval) * p.Multiplier)
+	}
+	if p.MaxInterval > 0 && interval > p.MaxInterval {
+		interval = p.MaxInterval
+	}
+	return interval
+}
+
+// transactionStatusSubscriber is implemented by access clients supporting the streaming transaction status API.
+//
+// The results are streamed as the transaction status changes, so no polling is needed to wait for the seal.
+type transactionStatusSubscriber interface {
+	SubscribeTransactionStatuses(ctx context.Context, txID flow.Identifier) (<-chan *flow.TransactionResult, <-chan error, error)
+}
+
+// waitForSeal gets the transaction result until it is sealed, polling it with the configured backoff.
+//
+// Waiting stops when the context is done or the polling timeout is reached, returning the context error.
+func waitForSeal(
+	ctx context.Context,
+	ID flow.Identifier,
+	polling SealPolling,
+	get func(context.Context) (*flow.TransactionResult, error),
+) (*flow.TransactionResult, error) {
+	if polling.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, polling.Timeout)
+		defer cancel()
+	}
+
+	interval := polling.Interval
+	for {
+		result, err := get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if result.Status == flow.TransactionStatusSealed {
+			return result, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, sealWaitError(ID, result.Status, ctx.Err())
+		case <-timer.C:
+		}
+
+		interval = polling.next(interval)
+	}
+}
+
+// subscribeSeal waits for the transaction to be sealed using the streaming transaction status API.
+func subscribeSeal(
+	ctx context.Context,
+	ID flow.Identifier,
+	subscriber transactionStatusSubscriber,
+) (*flow.TransactionResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results, errs, err := subscriber.SubscribeTransactionStatuses(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := flow.TransactionStatusUnknown
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, sealWaitError(ID, status, ctx.Err())
+		case err, ok := <-errs:
+			if ok && err != nil {
+				return nil, err
+			}
+			errs = nil
+		case result, ok := <-results:
+			if !ok {
+				return nil, fmt.Errorf("transaction %s status stream closed before the transaction was sealed", ID)
+			}
+			if result.Status == flow.TransactionStatusSealed {
+				return result, nil
+			}
+			status = result.Status
+		}
+	}
+}
+
+func sealWaitError(ID flow.Identifier, status flow.TransactionStatus, err error) error {
+	return fmt.Errorf("stopped waiting for transaction %s to be sealed with status %s: %w", ID, status, err)
+}
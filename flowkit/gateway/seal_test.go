@@ -0,0 +1,160 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type statusSubscriber struct {
+	results chan *flow.TransactionResult
+	errs    chan error
+}
+
+func (s statusSubscriber) SubscribeTransactionStatuses(_ context.Context, _ flow.Identifier) (<-chan *flow.TransactionResult, <-chan error, error) {
+	return s.results, s.errs, nil
+}
+
+func Test_SealPolling(t *testing.T) {
+	polling := SealPolling{
+		Interval:    time.Second,
+		MaxInterval: 3 * time.Second,
+		Multiplier:  2,
+	}
+
+	assert.Equal(t, 2*time.Second, polling.next(time.Second))
+	assert.Equal(t, 3*time.Second, polling.next(2*time.Second))
+	assert.Equal(t, 3*time.Second, polling.next(3*time.Second))
+	assert.Equal(t, time.Second, SealPolling{}.next(time.Second))
+}
+
+func Test_WaitForSeal(t *testing.T) {
+	ID := flow.HexToID("01")
+	polling := SealPolling{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond, Multiplier: 2}
+
+	t.Run("Sealed", func(t *testing.T) {
+		statuses := []flow.TransactionStatus{
+			flow.TransactionStatusPending,
+			flow.TransactionStatusExecuted,
+			flow.TransactionStatusSealed,
+		}
+		polls := 0
+		result, err := waitForSeal(context.Background(), ID, polling, func(context.Context) (*flow.TransactionResult, error) {
+			status := statuses[polls]
+			polls++
+			return &flow.TransactionResult{Status: status}, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+		assert.Equal(t, 3, polls)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		_, err := waitForSeal(context.Background(), ID, polling, func(context.Context) (*flow.TransactionResult, error) {
+			return nil, ErrNotFound
+		})
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		timeout := polling
+		timeout.Timeout = 10 * time.Millisecond
+		_, err := waitForSeal(context.Background(), ID, timeout, func(context.Context) (*flow.TransactionResult, error) {
+			return &flow.TransactionResult{Status: flow.TransactionStatusPending}, nil
+		})
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "stopped waiting for transaction")
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := waitForSeal(ctx, ID, SealPolling{Interval: time.Hour}, func(context.Context) (*flow.TransactionResult, error) {
+			cancel()
+			return &flow.TransactionResult{Status: flow.TransactionStatusPending}, nil
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func Test_SubscribeSeal(t *testing.T) {
+	ID := flow.HexToID("01")
+
+	t.Run("Sealed", func(t *testing.T) {
+		subscriber := statusSubscriber{
+			results: make(chan *flow.TransactionResult, 2),
+			errs:    make(chan error),
+		}
+		subscriber.results <- &flow.TransactionResult{Status: flow.TransactionStatusExecuted}
+		subscriber.results <- &flow.TransactionResult{Status: flow.TransactionStatusSealed}
+
+		result, err := subscribeSeal(context.Background(), ID, subscriber)
+
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		subscriber := statusSubscriber{
+			results: make(chan *flow.TransactionResult),
+			errs:    make(chan error, 1),
+		}
+		subscriber.errs <- errors.New("stream failed")
+
+		_, err := subscribeSeal(context.Background(), ID, subscriber)
+
+		assert.EqualError(t, err, "stream failed")
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		subscriber := statusSubscriber{
+			results: make(chan *flow.TransactionResult),
+			errs:    make(chan error),
+		}
+		close(subscriber.results)
+		close(subscriber.errs)
+
+		_, err := subscribeSeal(context.Background(), ID, subscriber)
+
+		assert.ErrorContains(t, err, "status stream closed")
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		subscriber := statusSubscriber{
+			results: make(chan *flow.TransactionResult),
+			errs:    make(chan error),
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := subscribeSeal(ctx, ID, subscriber)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,36 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package stats defines the resource usage reported by the gateways.
+package stats
+
+// Emulator is the current resource usage of an emulator.
+//
+// The stored data counts are only tracked by an emulator keeping a limited number of blocks in memory,
+// otherwise only the latest block height and the heap size are reported.
+type Emulator struct {
+	Blocks       uint64 `json:"blocks"`
+	Transactions uint64 `json:"transactions"`
+	Collections  uint64 `json:"collections"`
+	Events       uint64 `json:"events"`
+	LowestHeight uint64 `json:"lowestHeight"`
+	LatestHeight uint64 `json:"latestHeight"`
+	Retention    uint64 `json:"retention"`
+	Pruned       uint64 `json:"pruned"`
+	HeapBytes    uint64 `json:"heapBytes"`
+}
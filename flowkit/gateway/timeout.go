@@ -0,0 +1,214 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+)
+
+var _ Gateway = &TimeoutGateway{}
+
+// TimeoutGateway is a gateway decorator that limits the duration of each call.
+//
+// The timeout is applied on top of the context provided by the caller, so a call is
+// canceled when either the caller context is done or the timeout is reached.
+type TimeoutGateway struct {
+	gateway Gateway
+	timeout time.Duration
+}
+
+// NewTimeoutGateway returns a new gateway limiting the calls of the provided gateway to the timeout.
+func NewTimeoutGateway(gateway Gateway, timeout time.Duration) *TimeoutGateway {
+	return &TimeoutGateway{
+		gateway: gateway,
+		timeout: timeout,
+	}
+}
+
+// withTimeout returns the context bound to the timeout, a zero timeout doesn't limit the calls.
+func (g *TimeoutGateway) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if g.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, g.timeout)
+}
+
+func (g *TimeoutGateway) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetAccount(ctx, address)
+}
+
+func (g *TimeoutGateway) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetAccountAtBlockHeight(ctx, address, height)
+}
+
+func (g *TimeoutGateway) SendSignedTransaction(ctx context.Context, tx *flow.Transaction) (*flow.Transaction, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.SendSignedTransaction(ctx, tx)
+}
+
+func (g *TimeoutGateway) GetTransaction(ctx context.Context, ID flow.Identifier) (*flow.Transaction, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetTransaction(ctx, ID)
+}
+
+func (g *TimeoutGateway) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetTransactionResultsByBlockID(ctx, blockID)
+}
+
+func (g *TimeoutGateway) GetTransactionResult(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetTransactionResult(ctx, ID, waitSeal)
+}
+
+func (g *TimeoutGateway) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.Transaction, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetTransactionsByBlockID(ctx, blockID)
+}
+
+func (g *TimeoutGateway) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.Transaction, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetSystemTransaction(ctx, blockID)
+}
+
+func (g *TimeoutGateway) GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier) (*flow.TransactionResult, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetSystemTransactionResult(ctx, blockID)
+}
+
+func (g *TimeoutGateway) GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetExecutionResultForBlockID(ctx, blockID)
+}
+
+func (g *TimeoutGateway) ExecuteScript(ctx context.Context, script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.ExecuteScript(ctx, script, arguments)
+}
+
+func (g *TimeoutGateway) ExecuteScriptAtHeight(ctx context.Context, script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.ExecuteScriptAtHeight(ctx, script, arguments, height)
+}
+
+func (g *TimeoutGateway) ExecuteScriptAtID(ctx context.Context, script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.ExecuteScriptAtID(ctx, script, arguments, ID)
+}
+
+func (g *TimeoutGateway) GetLatestBlock(ctx context.Context) (*flow.Block, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetLatestBlock(ctx)
+}
+
+func (g *TimeoutGateway) GetLatestFinalizedBlock(ctx context.Context) (*flow.Block, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetLatestFinalizedBlock(ctx)
+}
+
+func (g *TimeoutGateway) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetBlockByHeight(ctx, height)
+}
+
+func (g *TimeoutGateway) GetBlockByID(ctx context.Context, ID flow.Identifier) (*flow.Block, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetBlockByID(ctx, ID)
+}
+
+func (g *TimeoutGateway) GetEvents(ctx context.Context, eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetEvents(ctx, eventType, startHeight, endHeight)
+}
+
+// SubscribeEvents is not limited by the timeout, since the subscription is meant to stay open
+// until the caller context is done.
+func (g *TimeoutGateway) SubscribeEvents(ctx context.Context, eventTypes []string, startHeight uint64) (<-chan flow.BlockEvents, <-chan error, error) {
+	return g.gateway.SubscribeEvents(ctx, eventTypes, startHeight)
+}
+
+func (g *TimeoutGateway) GetCollection(ctx context.Context, ID flow.Identifier) (*flow.Collection, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetCollection(ctx, ID)
+}
+
+func (g *TimeoutGateway) GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetLatestProtocolStateSnapshot(ctx)
+}
+
+func (g *TimeoutGateway) GetNetworkParameters(ctx context.Context) (flow.ChainID, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetNetworkParameters(ctx)
+}
+
+func (g *TimeoutGateway) GetNodeVersion(ctx context.Context) (string, error) {
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	return g.gateway.GetNodeVersion(ctx)
+}
+
+func (g *TimeoutGateway) Ping() error {
+	return g.gateway.Ping()
+}
+
+func (g *TimeoutGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
+
+// Unwrap returns the wrapped gateway, so the emulator admin of the gateway can be found with AsEmulatorAdmin.
+func (g *TimeoutGateway) Unwrap() Gateway {
+	return g.gateway
+}
+
+// Close the decorated gateway if it holds any resources.
+func (g *TimeoutGateway) Close() error {
+	if closer, ok := g.gateway.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
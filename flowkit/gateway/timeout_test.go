@@ -0,0 +1,92 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func Test_TimeoutGateway(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Apply Timeout", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		var callCtx context.Context
+		m.On("GetLatestBlock", mock.Anything).
+			Run(func(args mock.Arguments) {
+				callCtx = args.Get(0).(context.Context)
+			}).
+			Return(tests.NewBlock(), nil)
+
+		g := NewTimeoutGateway(m, time.Minute)
+		_, err := g.GetLatestBlock(ctx)
+		assert.NoError(t, err)
+
+		deadline, ok := callCtx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+		assert.ErrorIs(t, callCtx.Err(), context.Canceled, "context is released after the call")
+	})
+
+	t.Run("Keep Caller Deadline", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		var callCtx context.Context
+		m.On("GetCollection", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				callCtx = args.Get(0).(context.Context)
+			}).
+			Return(tests.NewCollection(), nil)
+
+		callerCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		g := NewTimeoutGateway(m, time.Hour)
+		_, err := g.GetCollection(callerCtx, tests.NewCollection().ID())
+		assert.NoError(t, err)
+
+		callerDeadline, _ := callerCtx.Deadline()
+		deadline, _ := callCtx.Deadline()
+		assert.Equal(t, callerDeadline, deadline)
+	})
+
+	t.Run("No Timeout", func(t *testing.T) {
+		m := &mocks.Gateway{}
+		var callCtx context.Context
+		m.On("GetLatestBlock", mock.Anything).
+			Run(func(args mock.Arguments) {
+				callCtx = args.Get(0).(context.Context)
+			}).
+			Return(tests.NewBlock(), nil)
+
+		g := NewTimeoutGateway(m, 0)
+		_, err := g.GetLatestBlock(ctx)
+		assert.NoError(t, err)
+
+		_, ok := callCtx.Deadline()
+		assert.False(t, ok)
+	})
+}
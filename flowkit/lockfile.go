@@ -0,0 +1,98 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// LockfileName is the name of the lockfile saved next to the configuration.
+const LockfileName = "flow.lock"
+
+// Lockfile records the resolved versions and integrity hashes of the external dependencies
+// the project uses, so they can be verified every time they are loaded.
+type Lockfile struct {
+	Sources   map[string]LockedSource           `json:"sources,omitempty"`
+	Aliases   map[string]map[string]LockedAlias `json:"aliases,omitempty"` // network name to contract name
+	Templates map[string]LockedTemplate         `json:"templates,omitempty"`
+}
+
+// LockedSource is a remote contract source pinned by the SHA3-256 hash of its content.
+type LockedSource struct {
+	Hash string `json:"hash"`
+}
+
+// LockedAlias is an aliased on-chain contract pinned by the SHA3-256 hash of the deployed code.
+type LockedAlias struct {
+	Address string `json:"address"`
+	Hash    string `json:"hash"`
+}
+
+// LockedTemplate is a project template pinned to the commit it was created from.
+type LockedTemplate struct {
+	Repo   string `json:"repo"`
+	Commit string `json:"commit"`
+}
+
+// ErrFrozenLockfile is returned when the lockfile is frozen and a dependency is missing or changed.
+var ErrFrozenLockfile = errors.New("lockfile is frozen")
+
+// LoadLockfile reads the lockfile or returns an empty lockfile if it doesn't exist yet.
+func LoadLockfile(reader ReaderWriter, filename string) (*Lockfile, error) {
+	lock := &Lockfile{}
+
+	data, err := reader.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("invalid lockfile %s: %w", filename, err)
+	}
+
+	return lock, nil
+}
+
+// Save the lockfile to the filename.
+func (l *Lockfile) Save(writer ReaderWriter, filename string) error {
+	data, err := json.MarshalIndent(l, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return writer.WriteFile(filename, append(data, '\n'), 0644)
+}
+
+// SourceLocations returns the locked source locations sorted.
+func (l *Lockfile) SourceLocations() []string {
+	locations := make([]string, 0, len(l.Sources))
+	for location := range l.Sources {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+
+	return locations
+}
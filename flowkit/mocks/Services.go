@@ -25,6 +25,8 @@ import (
 
 	project "github.com/onflow/flow-cli/flowkit/project"
 
+	stats "github.com/onflow/flow-cli/flowkit/gateway/stats"
+
 	transactions "github.com/onflow/flow-cli/flowkit/transactions"
 )
 
@@ -127,6 +129,55 @@ func (_m *Services) CreateAccount(_a0 context.Context, _a1 *accounts.Account, _a
 	return r0, r1, r2
 }
 
+// CreateAccountWithOptions provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Services) CreateAccountWithOptions(_a0 context.Context, _a1 *accounts.Account, _a2 []accounts.PublicKey, _a3 flowkit.CreateAccountOptions) (*flow.Account, flow.Identifier, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 *flow.Account
+	var r1 flow.Identifier
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *accounts.Account, []accounts.PublicKey, flowkit.CreateAccountOptions) (*flow.Account, flow.Identifier, error)); ok {
+		return rf(_a0, _a1, _a2, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *accounts.Account, []accounts.PublicKey, flowkit.CreateAccountOptions) *flow.Account); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *accounts.Account, []accounts.PublicKey, flowkit.CreateAccountOptions) flow.Identifier); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(flow.Identifier)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *accounts.Account, []accounts.PublicKey, flowkit.CreateAccountOptions) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateSnapshot provides a mock function with given fields: _a0, _a1
+func (_m *Services) CreateSnapshot(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeployProject provides a mock function with given fields: _a0, _a1
 func (_m *Services) DeployProject(_a0 context.Context, _a1 flowkit.UpdateContract) ([]*project.Contract, error) {
 	ret := _m.Called(_a0, _a1)
@@ -153,6 +204,32 @@ func (_m *Services) DeployProject(_a0 context.Context, _a1 flowkit.UpdateContrac
 	return r0, r1
 }
 
+// DeployProjectWithOptions provides a mock function with given fields: _a0, _a1
+func (_m *Services) DeployProjectWithOptions(_a0 context.Context, _a1 flowkit.DeployOptions) ([]*project.Contract, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*project.Contract
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flowkit.DeployOptions) ([]*project.Contract, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flowkit.DeployOptions) []*project.Contract); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*project.Contract)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flowkit.DeployOptions) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DerivePrivateKeyFromMnemonic provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *Services) DerivePrivateKeyFromMnemonic(_a0 context.Context, _a1 string, _a2 crypto.SigningAlgorithm, _a3 string) (crypto.PrivateKey, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -306,6 +383,32 @@ func (_m *Services) GetAccount(_a0 context.Context, _a1 flow.Address) (*flow.Acc
 	return r0, r1
 }
 
+// GetAccountAtBlockHeight provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Services) GetAccountAtBlockHeight(_a0 context.Context, _a1 flow.Address, _a2 uint64) (*flow.Account, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *flow.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) (*flow.Account, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) *flow.Account); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlock provides a mock function with given fields: _a0, _a1
 func (_m *Services) GetBlock(_a0 context.Context, _a1 flowkit.BlockQuery) (*flow.Block, error) {
 	ret := _m.Called(_a0, _a1)
@@ -358,6 +461,32 @@ func (_m *Services) GetCollection(_a0 context.Context, _a1 flow.Identifier) (*fl
 	return r0, r1
 }
 
+// GetEmulatorStats provides a mock function with given fields: _a0
+func (_m *Services) GetEmulatorStats(_a0 context.Context) (*stats.Emulator, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *stats.Emulator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*stats.Emulator, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *stats.Emulator); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*stats.Emulator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEvents provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
 func (_m *Services) GetEvents(_a0 context.Context, _a1 []string, _a2 uint64, _a3 uint64, _a4 *flowkit.EventWorker) ([]flow.BlockEvents, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
@@ -384,6 +513,41 @@ func (_m *Services) GetEvents(_a0 context.Context, _a1 []string, _a2 uint64, _a3
 	return r0, r1
 }
 
+// GetSystemTransaction provides a mock function with given fields: _a0, _a1
+func (_m *Services) GetSystemTransaction(_a0 context.Context, _a1 flow.Identifier) (*flow.Transaction, *flow.TransactionResult, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *flow.Transaction
+	var r1 *flow.TransactionResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) (*flow.Transaction, *flow.TransactionResult, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) *flow.Transaction); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier) *flow.TransactionResult); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*flow.TransactionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, flow.Identifier) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetTransactionByID provides a mock function with given fields: _a0, _a1, _a2
 func (_m *Services) GetTransactionByID(_a0 context.Context, _a1 flow.Identifier, _a2 bool) (*flow.Transaction, *flow.TransactionResult, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -454,6 +618,20 @@ func (_m *Services) GetTransactionsByBlockID(_a0 context.Context, _a1 flow.Ident
 	return r0, r1, r2
 }
 
+// LoadSnapshot provides a mock function with given fields: _a0, _a1
+func (_m *Services) LoadSnapshot(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Network provides a mock function with given fields:
 func (_m *Services) Network() config.Network {
 	ret := _m.Called()
@@ -508,6 +686,20 @@ func (_m *Services) RemoveContract(_a0 context.Context, _a1 *accounts.Account, _
 	return r0, r1
 }
 
+// Rollback provides a mock function with given fields: _a0, _a1
+func (_m *Services) Rollback(_a0 context.Context, _a1 uint64) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendSignedTransaction provides a mock function with given fields: _a0, _a1
 func (_m *Services) SendSignedTransaction(_a0 context.Context, _a1 *transactions.Transaction) (*flow.Transaction, *flow.TransactionResult, error) {
 	ret := _m.Called(_a0, _a1)
@@ -609,6 +801,104 @@ func (_m *Services) SignTransactionPayload(_a0 context.Context, _a1 *accounts.Ac
 	return r0, r1
 }
 
+// SubscribeBlocks provides a mock function with given fields: _a0, _a1
+func (_m *Services) SubscribeBlocks(_a0 context.Context, _a1 uint64) (<-chan *flow.Block, <-chan error, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 <-chan *flow.Block
+	var r1 <-chan error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (<-chan *flow.Block, <-chan error, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) <-chan *flow.Block); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *flow.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) <-chan error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64) error); ok {
+		r2 = rf(_a0, _a1)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SubscribeDecodedEvents provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Services) SubscribeDecodedEvents(_a0 context.Context, _a1 []string, _a2 uint64) (<-chan flowkit.DecodedEvent, <-chan error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 <-chan flowkit.DecodedEvent
+	var r1 <-chan error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, uint64) (<-chan flowkit.DecodedEvent, <-chan error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, uint64) <-chan flowkit.DecodedEvent); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan flowkit.DecodedEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, uint64) <-chan error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan error)
+		}
+	}
+
+	return r0, r1
+}
+
+// SubscribeEvents provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Services) SubscribeEvents(_a0 context.Context, _a1 []string, _a2 uint64) (<-chan flow.BlockEvents, <-chan error, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 <-chan flow.BlockEvents
+	var r1 <-chan error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, uint64) (<-chan flow.BlockEvents, <-chan error, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, uint64) <-chan flow.BlockEvents); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan flow.BlockEvents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, uint64) <-chan error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []string, uint64) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 type mockConstructorTestingTNewServices interface {
 	mock.TestingT
 	Cleanup(func())
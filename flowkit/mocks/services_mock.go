@@ -32,14 +32,19 @@ const (
 	addContractFunc                  = "AddContract"
 	buildTransactionFunc             = "BuildTransaction"
 	createAccountFunc                = "CreateAccount"
+	createSnapshotFunc               = "CreateSnapshot"
 	deployProjectFunc                = "DeployProject"
+	deployProjectWithOptionsFunc     = "DeployProjectWithOptions"
 	derivePrivateKeyFromMnemonicFunc = "DerivePrivateKeyFromMnemonic"
 	gatewayFunc                      = "Gateway"
 	generateKeyFunc                  = "GenerateKey"
 	generateMnemonicKeyFunc          = "GenerateMnemonicKey"
+	getAccountAtBlockHeightFunc      = "GetAccountAtBlockHeight"
 	getBlockFunc                     = "GetBlock"
+	getSystemTransactionFunc         = "GetSystemTransaction"
 	getTransactionByIDFunc           = "GetTransactionByID"
 	getTransactionsByBlockIDFunc     = "GetTransactionsByBlockID"
+	loadSnapshotFunc                 = "LoadSnapshot"
 	networkFunc                      = "Network"
 	pingFunc                         = "Ping"
 	removeContractFunc               = "RemoveContract"
@@ -54,14 +59,18 @@ type MockServices struct {
 	AddContract                  *mock.Call
 	BuildTransaction             *mock.Call
 	CreateAccount                *mock.Call
+	CreateSnapshot               *mock.Call
 	DeployProject                *mock.Call
+	DeployProjectWithOptions     *mock.Call
 	DerivePrivateKeyFromMnemonic *mock.Call
 	Gateway                      *mock.Call
 	GenerateKey                  *mock.Call
 	GenerateMnemonicKey          *mock.Call
 	GetBlock                     *mock.Call
+	GetSystemTransaction         *mock.Call
 	GetTransactionByID           *mock.Call
 	GetTransactionsByBlockID     *mock.Call
+	LoadSnapshot                 *mock.Call
 	Network                      *mock.Call
 	Ping                         *mock.Call
 	RemoveContract               *mock.Call
@@ -70,6 +79,7 @@ type MockServices struct {
 	SignTransactionPayload       *mock.Call
 	Test                         *mock.Call
 	GetAccount                   *mock.Call
+	GetAccountAtBlockHeight      *mock.Call
 	ExecuteScript                *mock.Call
 	SendSignedTransaction        *mock.Call
 	GetEvents                    *mock.Call
@@ -85,6 +95,12 @@ func DefaultMockServices() *MockServices {
 			mock.Anything,
 			mock.AnythingOfType("flow.Address"),
 		),
+		GetAccountAtBlockHeight: m.On(
+			getAccountAtBlockHeightFunc,
+			mock.Anything,
+			mock.AnythingOfType("flow.Address"),
+			mock.AnythingOfType("uint64"),
+		),
 		ExecuteScript: m.On(
 			mocks.ExecuteScriptFunc,
 			mock.Anything,
@@ -130,29 +146,39 @@ func DefaultMockServices() *MockServices {
 			mock.AnythingOfType("*accounts.Account"),
 			mock.AnythingOfType("[]accounts.PublicKey"),
 		),
+		CreateSnapshot: m.On(
+			createSnapshotFunc,
+			mock.Anything,
+			mock.AnythingOfType("string"),
+		),
 		DeployProject: m.On(
 			deployProjectFunc,
 			mock.Anything,
 			mock.AnythingOfType("flowkit.UpdateContract"),
 		),
+		DeployProjectWithOptions: m.On(
+			deployProjectWithOptionsFunc,
+			mock.Anything,
+			mock.AnythingOfType("flowkit.DeployOptions"),
+		),
 		DerivePrivateKeyFromMnemonic: m.On(
 			derivePrivateKeyFromMnemonicFunc,
 			mock.Anything,
 			mock.AnythingOfType("string"),
-			mock.AnythingOfType("crypto.SignatureAlgorithm"),
+			mock.AnythingOfType("crypto.SigningAlgorithm"),
 			mock.AnythingOfType("string"),
 		),
-		Gateway: m.On(gatewayFunc),
+		Gateway: m.On(gatewayFunc).Return(mocks.DefaultMockGateway().Mock),
 		GenerateKey: m.On(
 			generateKeyFunc,
 			mock.Anything,
-			mock.AnythingOfType("crypto.SignatureAlgorithm"),
+			mock.AnythingOfType("crypto.SigningAlgorithm"),
 			mock.AnythingOfType("string"),
 		),
 		GenerateMnemonicKey: m.On(
 			generateMnemonicKeyFunc,
 			mock.Anything,
-			mock.AnythingOfType("crypto.SignatureAlgorithm"),
+			mock.AnythingOfType("crypto.SigningAlgorithm"),
 			mock.AnythingOfType("string"),
 		),
 		GetBlock: m.On(
@@ -160,6 +186,11 @@ func DefaultMockServices() *MockServices {
 			mock.Anything,
 			mock.AnythingOfType("flowkit.BlockQuery"),
 		),
+		GetSystemTransaction: m.On(
+			getSystemTransactionFunc,
+			mock.Anything,
+			mock.AnythingOfType("flow.Identifier"),
+		),
 		GetTransactionByID: m.On(
 			getTransactionByIDFunc,
 			mock.Anything,
@@ -171,6 +202,11 @@ func DefaultMockServices() *MockServices {
 			mock.Anything,
 			mock.AnythingOfType("flow.Identifier"),
 		),
+		LoadSnapshot: m.On(
+			loadSnapshotFunc,
+			mock.Anything,
+			mock.AnythingOfType("string"),
+		),
 		RemoveContract: m.On(
 			removeContractFunc,
 			mock.Anything,
@@ -206,11 +242,17 @@ func DefaultMockServices() *MockServices {
 		t.GetAccount.Return(tests.NewAccountWithAddress(addr.String()), nil)
 	})
 
+	t.GetAccountAtBlockHeight.Run(func(args mock.Arguments) {
+		addr := args.Get(1).(flow.Address)
+		t.GetAccountAtBlockHeight.Return(tests.NewAccountWithAddress(addr.String()), nil)
+	})
+
 	t.ExecuteScript.Run(func(args mock.Arguments) {
 		t.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
 	})
 
 	t.GetTransactionByID.Return(tests.NewTransaction(), nil)
+	t.GetSystemTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
 	t.GetCollection.Return(tests.NewCollection(), nil)
 	t.GetEvents.Return([]flow.BlockEvents{}, nil)
 	t.GetBlock.Return(tests.NewBlock(), nil)
@@ -218,6 +260,8 @@ func DefaultMockServices() *MockServices {
 	t.RemoveContract.Return(flow.EmptyID, nil)
 	t.CreateAccount.Return(tests.NewAccountWithAddress("0x01"), flow.EmptyID, nil)
 	t.Network.Return(config.EmulatorNetwork)
+	t.CreateSnapshot.Return(nil)
+	t.LoadSnapshot.Return(nil)
 
 	return t
 }
@@ -20,6 +20,7 @@ package output
 
 import (
 	"fmt"
+	"os"
 	"runtime"
 )
 
@@ -32,8 +33,116 @@ const (
 	italic  = "\033[3m"
 )
 
-func printColor(msg string, color string) string {
+// Color modes define when the colors are used in the output.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// Theme defines ANSI escape codes used for each kind of colored output.
+type Theme struct {
+	Error     string
+	Success   string
+	Highlight string
+	Bold      string
+	Italic    string
+}
+
+// DefaultTheme is used if no other theme is selected.
+var DefaultTheme = Theme{
+	Error:     red,
+	Success:   green,
+	Highlight: magenta,
+	Bold:      bold,
+	Italic:    italic,
+}
+
+// HighContrastTheme uses bold and bright colors for better readability.
+var HighContrastTheme = Theme{
+	Error:     "\033[1;91m",
+	Success:   "\033[1;92m",
+	Highlight: "\033[1;96m",
+	Bold:      "\033[1;97m",
+	Italic:    "\033[1;4m",
+}
+
+// Themes contains all the available themes by name.
+var Themes = map[string]Theme{
+	"default":       DefaultTheme,
+	"high-contrast": HighContrastTheme,
+}
+
+var (
+	colorMode = ColorAuto
+	theme     = DefaultTheme
+)
+
+// SetColorMode sets when the colors should be used, valid modes are "auto", "always" and "never".
+//
+// In the auto mode colors are disabled if the NO_COLOR environment variable is set or the output is not a terminal.
+// Colors are never used in the plain output mode.
+func SetColorMode(mode string) error {
+	switch mode {
+	case ColorAuto, ColorAlways, ColorNever:
+		colorMode = mode
+		return nil
+	default:
+		return fmt.Errorf("invalid color mode %s, valid options: %s, %s, %s", mode, ColorAuto, ColorAlways, ColorNever)
+	}
+}
+
+// SetTheme sets the color theme by name.
+func SetTheme(name string) error {
+	t, ok := Themes[name]
+	if !ok {
+		return fmt.Errorf("invalid color theme %s, valid options: default, high-contrast", name)
+	}
+	theme = t
+	return nil
+}
+
+// ColorsEnabled checks whether the output should contain colors.
+func ColorsEnabled() bool {
+	if plain {
+		return false
+	}
+
+	switch colorMode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+		return false
+	}
+
 	if runtime.GOOS == "windows" {
+		return false
+	}
+
+	return IsTerminal()
+}
+
+// IsTerminal checks whether the standard output is an interactive terminal.
+func IsTerminal() bool {
+	return isTerminal(os.Stdout)
+}
+
+// isTerminal checks whether the file is an interactive terminal.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func printColor(msg string, color string) string {
+	if !ColorsEnabled() {
 		return msg
 	}
 
@@ -41,21 +150,21 @@ func printColor(msg string, color string) string {
 }
 
 func Red(msg string) string {
-	return printColor(msg, red)
+	return printColor(msg, theme.Error)
 }
 
 func Green(msg string) string {
-	return printColor(msg, green)
+	return printColor(msg, theme.Success)
 }
 
 func Magenta(msg string) string {
-	return printColor(msg, magenta)
+	return printColor(msg, theme.Highlight)
 }
 
 func Bold(msg string) string {
-	return printColor(msg, bold)
+	return printColor(msg, theme.Bold)
 }
 
 func Italic(msg string) string {
-	return printColor(msg, italic)
+	return printColor(msg, theme.Italic)
 }
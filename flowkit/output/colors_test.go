@@ -0,0 +1,73 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Colors(t *testing.T) {
+	t.Cleanup(func() {
+		_ = SetColorMode(ColorAuto)
+		_ = SetTheme("default")
+	})
+
+	t.Run("Never", func(t *testing.T) {
+		assert.NoError(t, SetColorMode(ColorNever))
+		assert.Equal(t, "error", Red("error"))
+	})
+
+	t.Run("Always", func(t *testing.T) {
+		assert.NoError(t, SetColorMode(ColorAlways))
+		assert.Equal(t, "\033[31merror\033[0m", Red("error"))
+
+		assert.NoError(t, SetTheme("high-contrast"))
+		assert.Equal(t, "\033[1;91merror\033[0m", Red("error"))
+	})
+
+	t.Run("No Color Env", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		assert.NoError(t, SetColorMode(ColorAuto))
+		assert.False(t, ColorsEnabled())
+	})
+
+	t.Run("Auto Not Terminal", func(t *testing.T) {
+		if runtime.GOOS == "windows" || IsTerminal() {
+			t.Skip("requires non-terminal output")
+		}
+		assert.NoError(t, SetColorMode(ColorAuto))
+		assert.Equal(t, "success", Green("success"))
+	})
+
+	t.Run("Plain", func(t *testing.T) {
+		t.Cleanup(func() { SetPlain(false) })
+		SetPlain(true)
+		assert.NoError(t, SetColorMode(ColorAlways))
+		assert.Equal(t, "error", Red("error"))
+		assert.Equal(t, "", ErrorEmoji())
+	})
+
+	t.Run("Fail Invalid", func(t *testing.T) {
+		assert.EqualError(t, SetColorMode("sometimes"), "invalid color mode sometimes, valid options: auto, always, never")
+		assert.EqualError(t, SetTheme("neon"), "invalid color theme neon, valid options: default, high-contrast")
+	})
+}
@@ -21,7 +21,7 @@ package output
 import "runtime"
 
 func printEmoji(emoji string) string {
-	if runtime.GOOS == "windows" {
+	if plain || runtime.GOOS == "windows" {
 		return ""
 	}
 
@@ -82,6 +82,13 @@ func (s *StdoutLogger) StartProgress(msg string) {
 		s.spinner.Stop()
 	}
 
+	// progress is reported as a discrete line, spinners are read out by screen readers on every frame
+	if plain {
+		s.spinner = nil
+		s.log(msg, InfoLog)
+		return
+	}
+
 	s.spinner = NewSpinner(msg, "")
 	s.spinner.Start()
 }
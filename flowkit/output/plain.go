@@ -0,0 +1,33 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+var plain = false
+
+// SetPlain enables the plain output mode meant for screen readers and log collectors.
+//
+// In the plain mode colors and emojis are disabled and progress is reported as log lines instead of spinners.
+func SetPlain(enabled bool) {
+	plain = enabled
+}
+
+// PlainEnabled checks whether the plain output mode is enabled.
+func PlainEnabled() bool {
+	return plain
+}
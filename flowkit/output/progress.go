@@ -0,0 +1,83 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// ProgressStatus is the status of a long running operation reported in a progress event.
+type ProgressStatus string
+
+const (
+	ProgressStarted   ProgressStatus = "started"
+	ProgressUpdated   ProgressStatus = "progress"
+	ProgressCompleted ProgressStatus = "completed"
+	ProgressFailed    ProgressStatus = "failed"
+)
+
+// ProgressEvent is a machine-readable progress event of a long running operation, meant for the tools
+// wrapping the CLI to render progress without parsing the human-readable output.
+type ProgressEvent struct {
+	// Operation reporting the progress, such as deploy, blocks or test.
+	Operation string         `json:"operation"`
+	Status    ProgressStatus `json:"status"`
+	// Step of the operation the event is about, such as the deployed contract or the test file.
+	Step string `json:"step,omitempty"`
+	// Current is the number of completed units of the operation out of the total, the total is zero if unknown.
+	Current uint64    `json:"current"`
+	Total   uint64    `json:"total"`
+	Message string    `json:"message,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+var progress = struct {
+	sync.Mutex
+	writer io.Writer
+}{}
+
+// SetProgressJSON enables writing the progress events as newline delimited JSON to the writer,
+// progress events are disabled if the writer is nil.
+func SetProgressJSON(writer io.Writer) {
+	progress.Lock()
+	defer progress.Unlock()
+	progress.writer = writer
+}
+
+// ReportProgress writes the progress event if progress events are enabled.
+func ReportProgress(event ProgressEvent) {
+	progress.Lock()
+	defer progress.Unlock()
+	if progress.writer == nil {
+		return
+	}
+
+	if event.Time.IsZero() {
+		event.Time = now().UTC()
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	_, _ = progress.writer.Write(append(line, '\n'))
+}
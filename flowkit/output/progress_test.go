@@ -0,0 +1,58 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReportProgress(t *testing.T) {
+	t.Cleanup(func() {
+		SetProgressJSON(nil)
+		now = time.Now
+	})
+	now = func() time.Time { return time.Date(2020, 6, 4, 16, 43, 21, 0, time.UTC) }
+
+	t.Run("Disabled", func(t *testing.T) {
+		var b bytes.Buffer
+		SetProgressJSON(&b)
+		SetProgressJSON(nil)
+
+		ReportProgress(ProgressEvent{Operation: "deploy", Status: ProgressStarted})
+		assert.Empty(t, b.String())
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		var b bytes.Buffer
+		SetProgressJSON(&b)
+
+		ReportProgress(ProgressEvent{Operation: "deploy", Status: ProgressStarted, Total: 2})
+		ReportProgress(ProgressEvent{Operation: "deploy", Status: ProgressUpdated, Step: "Foo", Current: 1, Total: 2, Message: "deployed"})
+
+		assert.Equal(t,
+			`{"operation":"deploy","status":"started","current":0,"total":2,"time":"2020-06-04T16:43:21Z"}`+"\n"+
+				`{"operation":"deploy","status":"progress","step":"Foo","current":1,"total":2,"message":"deployed","time":"2020-06-04T16:43:21Z"}`+"\n",
+			b.String(),
+		)
+	})
+}
@@ -20,6 +20,7 @@ package output
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/gosuri/uilive"
@@ -46,7 +47,16 @@ func (s *Spinner) Start() {
 }
 
 func (s *Spinner) run() {
+	// the spinner is written to the standard error, so it never mixes with the result,
+	// and only on a terminal since the animation would fill piped output with control sequences
+	if !isTerminal(os.Stderr) {
+		<-s.done
+		close(s.done)
+		return
+	}
+
 	writer := uilive.New()
+	writer.Out = os.Stderr
 
 	ticker := time.NewTicker(100 * time.Millisecond)
 
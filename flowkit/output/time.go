@@ -0,0 +1,110 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Time formats define how the timestamps are rendered in the output.
+const (
+	TimeISO      = "iso"
+	TimeUnix     = "unix"
+	TimeRelative = "relative"
+)
+
+// TimezoneLocal is the timezone name selecting the local timezone of the system.
+const TimezoneLocal = "local"
+
+var (
+	timeFormat   = TimeISO
+	timeLocation = time.UTC
+	now          = time.Now
+)
+
+// SetTimeFormat sets how the timestamps are rendered, valid formats are "iso", "unix" and "relative".
+func SetTimeFormat(format string) error {
+	switch format {
+	case TimeISO, TimeUnix, TimeRelative:
+		timeFormat = format
+		return nil
+	default:
+		return fmt.Errorf("invalid time format %s, valid options: %s, %s, %s", format, TimeISO, TimeUnix, TimeRelative)
+	}
+}
+
+// SetTimezone sets the timezone the ISO timestamps are rendered in, by IANA name such as "Europe/Berlin",
+// "UTC" or "local" for the timezone of the system.
+func SetTimezone(name string) error {
+	if name == TimezoneLocal {
+		timeLocation = time.Local
+		return nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %s: %w", name, err)
+	}
+	timeLocation = location
+	return nil
+}
+
+// FormatTime renders the timestamp using the selected time format and timezone.
+func FormatTime(t time.Time) string {
+	switch timeFormat {
+	case TimeUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case TimeRelative:
+		return relativeTime(now().Sub(t))
+	default:
+		return t.In(timeLocation).Format(time.RFC3339)
+	}
+}
+
+// relativeTime renders the time elapsed since the timestamp in the largest whole unit.
+func relativeTime(elapsed time.Duration) string {
+	suffix := "ago"
+	if elapsed < 0 {
+		elapsed = -elapsed
+		suffix = "from now"
+	}
+
+	units := []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+		{"second", time.Second},
+	}
+
+	for _, unit := range units {
+		if count := int64(elapsed / unit.duration); count > 0 {
+			if count > 1 {
+				return fmt.Sprintf("%d %ss %s", count, unit.name, suffix)
+			}
+			return fmt.Sprintf("1 %s %s", unit.name, suffix)
+		}
+	}
+
+	return "just now"
+}
@@ -0,0 +1,68 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FormatTime(t *testing.T) {
+	t.Cleanup(func() {
+		_ = SetTimeFormat(TimeISO)
+		_ = SetTimezone("UTC")
+		now = time.Now
+	})
+
+	timestamp := time.Date(2020, 6, 4, 16, 43, 21, 0, time.UTC)
+
+	t.Run("ISO", func(t *testing.T) {
+		assert.NoError(t, SetTimeFormat(TimeISO))
+		assert.Equal(t, "2020-06-04T16:43:21Z", FormatTime(timestamp))
+
+		assert.NoError(t, SetTimezone("America/New_York"))
+		assert.Equal(t, "2020-06-04T12:43:21-04:00", FormatTime(timestamp))
+		assert.Equal(t, "2020-06-04T12:43:21-04:00", FormatTime(timestamp.In(time.Local)))
+		assert.NoError(t, SetTimezone("UTC"))
+	})
+
+	t.Run("Unix", func(t *testing.T) {
+		assert.NoError(t, SetTimeFormat(TimeUnix))
+		assert.Equal(t, "1591289001", FormatTime(timestamp))
+	})
+
+	t.Run("Relative", func(t *testing.T) {
+		assert.NoError(t, SetTimeFormat(TimeRelative))
+		now = func() time.Time { return timestamp.Add(3*time.Hour + 20*time.Minute) }
+		assert.Equal(t, "3 hours ago", FormatTime(timestamp))
+
+		now = func() time.Time { return timestamp.Add(-time.Minute) }
+		assert.Equal(t, "1 minute from now", FormatTime(timestamp))
+
+		now = func() time.Time { return timestamp }
+		assert.Equal(t, "just now", FormatTime(timestamp))
+	})
+
+	t.Run("Fail Invalid", func(t *testing.T) {
+		assert.EqualError(t, SetTimeFormat("rfc"), "invalid time format rfc, valid options: iso, unix, relative")
+		assert.ErrorContains(t, SetTimezone("Mars/Olympus"), "invalid timezone Mars/Olympus")
+	})
+}
@@ -0,0 +1,221 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// ABI is a machine-readable description of the public interface of a contract.
+//
+// Only members accessible outside the contract are included, which are declared with pub or pub(set) access.
+type ABI struct {
+	Name      string        `json:"name"`
+	Kind      string        `json:"kind"`
+	Fields    []ABIField    `json:"fields"`
+	Functions []ABIFunction `json:"functions"`
+	Events    []ABIEvent    `json:"events"`
+	Types     []ABIType     `json:"types"`
+}
+
+// ABIParameter describes a function or event parameter.
+type ABIParameter struct {
+	Label string `json:"label,omitempty"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+}
+
+// ABIField describes a public field.
+type ABIField struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Access string `json:"access"`
+	Kind   string `json:"kind"`
+}
+
+// ABIFunction describes a public function.
+type ABIFunction struct {
+	Name       string         `json:"name"`
+	Access     string         `json:"access"`
+	Parameters []ABIParameter `json:"parameters"`
+	ReturnType string         `json:"returnType,omitempty"`
+}
+
+// ABIEvent describes an event emitted by the contract.
+type ABIEvent struct {
+	Name       string         `json:"name"`
+	Parameters []ABIParameter `json:"parameters"`
+}
+
+// ABIType describes a resource, struct, enum or interface declared in the contract.
+type ABIType struct {
+	Name         string        `json:"name"`
+	Kind         string        `json:"kind"`
+	Conformances []string      `json:"conformances,omitempty"`
+	Fields       []ABIField    `json:"fields"`
+	Functions    []ABIFunction `json:"functions"`
+}
+
+// ABI generates the description of the contract public interface.
+func (p *Program) ABI() (*ABI, error) {
+	if contract := p.astProgram.SoleContractDeclaration(); contract != nil {
+		return newABI(
+			contract.Identifier.Identifier,
+			contract.CompositeKind.Keyword(),
+			contract.Members,
+		), nil
+	}
+
+	if contractInterface := p.astProgram.SoleContractInterfaceDeclaration(); contractInterface != nil {
+		return newABI(
+			contractInterface.Identifier.Identifier,
+			interfaceKind(contractInterface.CompositeKind),
+			contractInterface.Members,
+		), nil
+	}
+
+	return nil, fmt.Errorf("the code must declare exactly one contract or contract interface")
+}
+
+func newABI(name string, kind string, members *ast.Members) *ABI {
+	abi := &ABI{
+		Name:      name,
+		Kind:      kind,
+		Fields:    abiFields(members),
+		Functions: abiFunctions(members),
+		Events:    make([]ABIEvent, 0),
+		Types:     make([]ABIType, 0),
+	}
+
+	for _, composite := range members.Composites() {
+		if composite.CompositeKind == common.CompositeKindEvent {
+			event := ABIEvent{
+				Name:       composite.Identifier.Identifier,
+				Parameters: make([]ABIParameter, 0),
+			}
+			// event parameters are declared as the parameters of the event initializer
+			for _, initializer := range composite.Members.Initializers() {
+				event.Parameters = abiParameters(initializer.FunctionDeclaration.ParameterList)
+			}
+			abi.Events = append(abi.Events, event)
+			continue
+		}
+
+		if !isPublic(composite.Access) {
+			continue
+		}
+
+		conformances := make([]string, 0, len(composite.Conformances))
+		for _, conformance := range composite.Conformances {
+			conformances = append(conformances, conformance.String())
+		}
+
+		abi.Types = append(abi.Types, ABIType{
+			Name:         composite.Identifier.Identifier,
+			Kind:         composite.CompositeKind.Keyword(),
+			Conformances: conformances,
+			Fields:       abiFields(composite.Members),
+			Functions:    abiFunctions(composite.Members),
+		})
+	}
+
+	for _, declaration := range members.Interfaces() {
+		if !isPublic(declaration.Access) {
+			continue
+		}
+
+		abi.Types = append(abi.Types, ABIType{
+			Name:      declaration.Identifier.Identifier,
+			Kind:      interfaceKind(declaration.CompositeKind),
+			Fields:    abiFields(declaration.Members),
+			Functions: abiFunctions(declaration.Members),
+		})
+	}
+
+	return abi
+}
+
+func abiFields(members *ast.Members) []ABIField {
+	fields := make([]ABIField, 0)
+	for _, field := range members.Fields() {
+		if !isPublic(field.Access) {
+			continue
+		}
+
+		fields = append(fields, ABIField{
+			Name:   field.Identifier.Identifier,
+			Type:   typeAnnotationString(field.TypeAnnotation),
+			Access: field.Access.Keyword(),
+			Kind:   field.VariableKind.Keyword(),
+		})
+	}
+	return fields
+}
+
+func abiFunctions(members *ast.Members) []ABIFunction {
+	functions := make([]ABIFunction, 0)
+	for _, function := range members.Functions() {
+		if !isPublic(function.Access) {
+			continue
+		}
+
+		functions = append(functions, ABIFunction{
+			Name:       function.Identifier.Identifier,
+			Access:     function.Access.Keyword(),
+			Parameters: abiParameters(function.ParameterList),
+			ReturnType: typeAnnotationString(function.ReturnTypeAnnotation),
+		})
+	}
+	return functions
+}
+
+func abiParameters(list *ast.ParameterList) []ABIParameter {
+	parameters := make([]ABIParameter, 0)
+	if list == nil {
+		return parameters
+	}
+
+	for _, parameter := range list.Parameters {
+		parameters = append(parameters, ABIParameter{
+			Label: parameter.Label,
+			Name:  parameter.Identifier.Identifier,
+			Type:  typeAnnotationString(parameter.TypeAnnotation),
+		})
+	}
+	return parameters
+}
+
+// typeAnnotationString returns the type as written in the code, or empty string if no type is declared.
+func typeAnnotationString(annotation *ast.TypeAnnotation) string {
+	if annotation == nil || annotation.Type == nil {
+		return ""
+	}
+	return annotation.String()
+}
+
+func interfaceKind(kind common.CompositeKind) string {
+	return fmt.Sprintf("%s interface", kind.Keyword())
+}
+
+func isPublic(access ast.Access) bool {
+	return access == ast.AccessPublic || access == ast.AccessPublicSettable
+}
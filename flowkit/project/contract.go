@@ -31,6 +31,7 @@ type Contract struct {
 	AccountAddress flow.Address
 	AccountName    string
 	Args           []cadence.Value
+	Mocked         bool // code is read from the mock source of the contract
 }
 
 func NewContract(
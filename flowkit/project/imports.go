@@ -21,7 +21,9 @@ package project
 import (
 	"fmt"
 	"path"
+	"strings"
 
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/flow-go-sdk"
 )
 
@@ -48,42 +50,137 @@ func (i *ImportReplacer) Replace(program *Program) (*Program, error) {
 	contractsLocations := i.getContractsLocations()
 
 	for _, imp := range imports {
-		// check if import by path exists (e.g. import X from ["./X.cdc"])
-		importLocation := path.Clean(absolutePath(program.Location(), imp))
-		address, isPath := contractsLocations[importLocation]
-		if isPath {
-			program.replaceImport(imp, address)
-			continue
-		}
-		// check if import by identifier exists (e.g. import ["X"])
-		address, isIdentifier := contractsLocations[imp]
-		if isIdentifier {
-			program.replaceImport(imp, address)
-			continue
+		location, ok := resolveImport(program, imp, contractsLocations)
+		if !ok {
+			return nil, fmt.Errorf("import %s could not be resolved from provided contracts", imp)
 		}
 
-		return nil, fmt.Errorf("import %s could not be resolved from provided contracts", imp)
+		program.replaceImport(imp, location.address.String())
 	}
 
 	return program, nil
 }
 
+// ImportSource describes where the address of an import is resolved from.
+type ImportSource string
+
+const (
+	ImportSourceDeployment ImportSource = "deployment" // contract deployed by the project on the network
+	ImportSourceAlias      ImportSource = "alias"      // contract aliased to an address on the network
+	ImportSourceAddress    ImportSource = "address"    // import already using an address, left unchanged
+	ImportSourceBuiltin    ImportSource = "builtin"    // built-in contract like Crypto, left unchanged
+	ImportSourceUnresolved ImportSource = "unresolved" // import not matching any deployment or alias
+)
+
+// ImportResolution describes how a single import of the program is resolved.
+type ImportResolution struct {
+	Names    []string     // imported declarations, empty when the whole location is imported
+	Location string       // location as written in the program
+	Address  flow.Address // resolved address, empty for built-in and unresolved imports
+	Source   ImportSource
+}
+
+// Resolve returns how each import of the program is resolved, in the order the imports are declared.
+//
+// Unlike Replace, imports which can't be resolved don't fail, they are returned with the unresolved source.
+func (i *ImportReplacer) Resolve(program *Program) []ImportResolution {
+	contractsLocations := i.getContractsLocations()
+
+	declarations := program.astProgram.ImportDeclarations()
+	resolutions := make([]ImportResolution, 0, len(declarations))
+	for _, declaration := range declarations {
+		resolution := ImportResolution{
+			Names:    make([]string, 0, len(declaration.Identifiers)),
+			Location: declaration.Location.String(),
+		}
+		for _, identifier := range declaration.Identifiers {
+			resolution.Names = append(resolution.Names, identifier.Identifier)
+		}
+
+		switch location := declaration.Location.(type) {
+		case common.StringLocation:
+			resolution.Source = ImportSourceUnresolved
+			if resolved, ok := resolveImport(program, location.String(), contractsLocations); ok {
+				resolution.Address = resolved.address
+				resolution.Source = resolved.source
+			}
+		case common.AddressLocation:
+			resolution.Location = location.Address.HexWithPrefix()
+			resolution.Address = flow.Address(location.Address)
+			resolution.Source = ImportSourceAddress
+		default:
+			resolution.Source = ImportSourceBuiltin
+		}
+
+		resolutions = append(resolutions, resolution)
+	}
+
+	return resolutions
+}
+
+// resolvedLocation is the address a contract location resolves to and where it was resolved from.
+type resolvedLocation struct {
+	address flow.Address
+	source  ImportSource
+}
+
+// resolveImport resolves the import by the path relative to the program or by the contract name.
+func resolveImport(program *Program, imp string, contractsLocations map[string]resolvedLocation) (resolvedLocation, bool) {
+	// check if import by path exists (e.g. import X from ["./X.cdc"])
+	importLocation := CleanLocation(absolutePath(program.Location(), imp))
+	if location, isPath := contractsLocations[importLocation]; isPath {
+		return location, true
+	}
+
+	// check if import by identifier exists (e.g. import ["X"])
+	location, isIdentifier := contractsLocations[imp]
+	return location, isIdentifier
+}
+
 // getContractsLocations return a map with contract locations as keys and addresses where they are deployed as values.
-func (i *ImportReplacer) getContractsLocations() map[string]string {
-	locationAddress := make(map[string]string)
+func (i *ImportReplacer) getContractsLocations() map[string]resolvedLocation {
+	locationAddress := make(map[string]resolvedLocation)
 	for _, contract := range i.contracts {
-		locationAddress[path.Clean(contract.Location())] = contract.AccountAddress.String()
+		deployed := resolvedLocation{address: contract.AccountAddress, source: ImportSourceDeployment}
+		locationAddress[CleanLocation(contract.Location())] = deployed
 		// add also by name since we might use the new import schema
-		locationAddress[contract.Name] = contract.AccountAddress.String()
+		locationAddress[contract.Name] = deployed
 	}
 
 	for source, target := range i.aliases {
-		locationAddress[path.Clean(source)] = flow.HexToAddress(target).String()
+		locationAddress[CleanLocation(source)] = resolvedLocation{address: flow.HexToAddress(target), source: ImportSourceAlias}
 	}
 
 	return locationAddress
 }
 
 func absolutePath(basePath, relativePath string) string {
-	return path.Join(path.Dir(basePath), relativePath)
+	for _, scheme := range []string{HTTPSScheme, IPFSScheme} {
+		if strings.HasPrefix(basePath, scheme) {
+			return scheme + path.Join(path.Dir(strings.TrimPrefix(basePath, scheme)), CleanLocation(relativePath))
+		}
+	}
+
+	return path.Join(path.Dir(CleanLocation(basePath)), CleanLocation(relativePath))
+}
+
+// CleanLocation returns the shortest equivalent of the location using forward slashes as separators.
+//
+// Locations can come from configuration written on any platform, so Windows separators are converted
+// to make the same location always match regardless of the platform it was written on.
+func CleanLocation(location string) string {
+	if IsRemoteLocation(location) {
+		return location
+	}
+	return path.Clean(strings.ReplaceAll(location, `\`, "/"))
+}
+
+const (
+	HTTPSScheme = "https://"
+	IPFSScheme  = "ipfs://"
+)
+
+// IsRemoteLocation checks whether the location is a https:// or ipfs:// URL instead of a file.
+func IsRemoteLocation(location string) bool {
+	return strings.HasPrefix(location, HTTPSScheme) || strings.HasPrefix(location, IPFSScheme)
 }
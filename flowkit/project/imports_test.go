@@ -135,4 +135,73 @@ func TestResolver(t *testing.T) {
 		assert.Equal(t, cleanCode(expected), cleanCode(replaced.Code()))
 	})
 
+	t.Run("Resolve Windows paths", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("Kibble", `.\contracts\Kibble.cdc`, nil, flow.HexToAddress("0x1"), "", nil),
+		}
+
+		aliases := map[string]string{
+			`contracts\FT.cdc`: flow.HexToAddress("0x2").String(),
+		}
+
+		replacer := NewImportReplacer(contracts, aliases)
+
+		code := []byte(`
+			import Kibble from "../contracts/Kibble.cdc"
+			import FT from "../contracts/FT.cdc"
+			pub fun main() {}
+		`)
+		program, err := NewProgram(code, nil, `scripts\foo.cdc`)
+		require.NoError(t, err)
+
+		replaced, err := replacer.Replace(program)
+		require.NoError(t, err)
+
+		expected := []byte(`
+			import Kibble from 0x0000000000000001
+			import FT from 0x0000000000000002
+			pub fun main() {}
+		`)
+
+		assert.Equal(t, cleanCode(expected), cleanCode(replaced.Code()))
+	})
+
+	t.Run("Resolve import sources", func(t *testing.T) {
+		replacer := NewImportReplacer(
+			[]*Contract{NewContract("Kibble", "./tests/Kibble.cdc", nil, flow.HexToAddress("0x1"), "", nil)},
+			map[string]string{"./tests/NFT.cdc": flow.HexToAddress("0x4").String()},
+		)
+
+		code := []byte(`
+			import Kibble from "./Kibble.cdc"
+			import NFT from "./NFT.cdc"
+			import "Missing"
+			import Crypto
+			import Foo from 0x0000000000000005
+			pub fun main() {}
+		`)
+		program, err := NewProgram(code, nil, "./tests/foo.cdc")
+		require.NoError(t, err)
+
+		assert.Equal(t, []ImportResolution{
+			{Names: []string{"Kibble"}, Location: "./Kibble.cdc", Address: flow.HexToAddress("0x1"), Source: ImportSourceDeployment},
+			{Names: []string{"NFT"}, Location: "./NFT.cdc", Address: flow.HexToAddress("0x4"), Source: ImportSourceAlias},
+			{Names: []string{}, Location: "Missing", Source: ImportSourceUnresolved},
+			{Names: []string{}, Location: "Crypto", Source: ImportSourceBuiltin},
+			{Names: []string{"Foo"}, Location: "0x0000000000000005", Address: flow.HexToAddress("0x5"), Source: ImportSourceAddress},
+		}, replacer.Resolve(program))
+	})
+}
+
+func TestCleanLocation(t *testing.T) {
+	locations := map[string]string{
+		"./contracts/Foo.cdc":          "contracts/Foo.cdc",
+		`.\contracts\Foo.cdc`:          "contracts/Foo.cdc",
+		`cadence\contracts\..\Foo.cdc`: "cadence/Foo.cdc",
+		"Foo.cdc":                      "Foo.cdc",
+	}
+
+	for location, expected := range locations {
+		assert.Equal(t, expected, CleanLocation(location))
+	}
 }
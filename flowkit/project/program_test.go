@@ -160,4 +160,80 @@ func TestProgram(t *testing.T) {
 		assert.Equal(t, string(replaced), string(program.Code()))
 	})
 
+	t.Run("ABI", func(t *testing.T) {
+		code := []byte(`
+			pub contract Foo {
+				pub let name: String
+				access(contract) var counter: Int
+
+				pub event Deposited(id: UInt64, to: Address?)
+
+				pub resource interface Receiver {
+					pub fun deposit(token: @NFT)
+				}
+
+				pub resource NFT: Receiver {
+					pub let id: UInt64
+					pub fun deposit(token: @NFT) { destroy token }
+					init(id: UInt64) { self.id = id }
+				}
+
+				pub fun mint(_ id: UInt64, amount a: UFix64): @NFT {
+					emit Deposited(id: id, to: nil)
+					return <-create NFT(id: id)
+				}
+
+				access(self) fun increment() {}
+
+				init() {
+					self.name = "foo"
+					self.counter = 0
+				}
+			}
+		`)
+
+		program, err := NewProgram(code, nil, "")
+		require.NoError(t, err)
+
+		abi, err := program.ABI()
+		require.NoError(t, err)
+
+		assert.Equal(t, "Foo", abi.Name)
+		assert.Equal(t, "contract", abi.Kind)
+		assert.Equal(t, []ABIField{{Name: "name", Type: "String", Access: "pub", Kind: "let"}}, abi.Fields)
+
+		require.Len(t, abi.Functions, 1)
+		assert.Equal(t, ABIFunction{
+			Name:   "mint",
+			Access: "pub",
+			Parameters: []ABIParameter{
+				{Label: "_", Name: "id", Type: "UInt64"},
+				{Label: "amount", Name: "a", Type: "UFix64"},
+			},
+			ReturnType: "@NFT",
+		}, abi.Functions[0])
+
+		assert.Equal(t, []ABIEvent{{
+			Name: "Deposited",
+			Parameters: []ABIParameter{
+				{Name: "id", Type: "UInt64"},
+				{Name: "to", Type: "Address?"},
+			},
+		}}, abi.Events)
+
+		require.Len(t, abi.Types, 2)
+		assert.Equal(t, "NFT", abi.Types[0].Name)
+		assert.Equal(t, "resource", abi.Types[0].Kind)
+		assert.Equal(t, []string{"Receiver"}, abi.Types[0].Conformances)
+		assert.Len(t, abi.Types[0].Functions, 1)
+		assert.Equal(t, "Receiver", abi.Types[1].Name)
+		assert.Equal(t, "resource interface", abi.Types[1].Kind)
+
+		program, err = NewProgram([]byte(`pub fun main() {}`), nil, "")
+		require.NoError(t, err)
+
+		_, err = program.ABI()
+		assert.EqualError(t, err, "the code must declare exactly one contract or contract interface")
+	})
+
 }
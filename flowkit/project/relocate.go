@@ -0,0 +1,121 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// Kind of the declarations in the program.
+type Kind string
+
+const (
+	KindContract    Kind = "contract"
+	KindTransaction Kind = "transaction"
+	KindScript      Kind = "script"
+	KindTest        Kind = "test"
+)
+
+// Kind returns whether the program declares a contract, a transaction, a script or tests.
+//
+// Programs importing the Test framework are tests, and programs not declaring any of them
+// are returned as scripts, since a script only needs to declare functions.
+func (p *Program) Kind() Kind {
+	for _, declaration := range p.astProgram.ImportDeclarations() {
+		if _, ok := declaration.Location.(common.IdentifierLocation); ok && declaration.Location.String() == "Test" {
+			return KindTest
+		}
+	}
+
+	if len(p.astProgram.TransactionDeclarations()) > 0 {
+		return KindTransaction
+	}
+
+	for _, declaration := range p.astProgram.CompositeDeclarations() {
+		if declaration.CompositeKind == common.CompositeKindContract {
+			return KindContract
+		}
+	}
+	for _, declaration := range p.astProgram.InterfaceDeclarations() {
+		if declaration.CompositeKind == common.CompositeKindContract {
+			return KindContract
+		}
+	}
+
+	return KindScript
+}
+
+// RelocateImports returns the program moved to the location, with the relative file imports rewritten
+// so they keep pointing to the same files.
+//
+// The moved locations map the previous locations of the imported files to the locations they are moved to,
+// the imports of files which aren't moved are rewritten relative to the new location of the program.
+func RelocateImports(program *Program, location string, moved map[string]string) (*Program, error) {
+	if IsRemoteLocation(program.Location()) {
+		return nil, fmt.Errorf("remote program %s can't be relocated", program.Location())
+	}
+
+	code := string(program.Code())
+	for _, imp := range program.imports() {
+		if !strings.HasSuffix(imp, ".cdc") || IsRemoteLocation(imp) {
+			continue // import by the contract name or from a remote location doesn't depend on the program location
+		}
+
+		target := CleanLocation(absolutePath(program.Location(), imp))
+		if movedTarget, ok := moved[target]; ok {
+			target = CleanLocation(movedTarget)
+		}
+
+		relocated, err := relativeLocation(location, target)
+		if err != nil {
+			return nil, err
+		}
+		if relocated == imp {
+			continue
+		}
+
+		importRegex := regexp.MustCompile(fmt.Sprintf(`(import\s+[\w\s,]+\s+from\s+)"%s"`, regexp.QuoteMeta(imp)))
+		code = importRegex.ReplaceAllString(code, fmt.Sprintf(`${1}"%s"`, relocated))
+	}
+
+	return NewProgram([]byte(code), program.args, location)
+}
+
+// relativeLocation returns the location of the target relative to the directory of the location.
+func relativeLocation(location string, target string) (string, error) {
+	relative, err := filepath.Rel(
+		filepath.FromSlash(path.Dir(CleanLocation(location))),
+		filepath.FromSlash(target),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to relocate import %s: %w", target, err)
+	}
+
+	relative = filepath.ToSlash(relative)
+	if !strings.HasPrefix(relative, "../") {
+		relative = "./" + relative
+	}
+	return relative, nil
+}
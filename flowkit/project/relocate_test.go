@@ -0,0 +1,97 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelocateImports(t *testing.T) {
+
+	t.Run("Relocate with moved imports", func(t *testing.T) {
+		program, err := NewProgram([]byte(`
+			import Kibble from "./Kibble.cdc"
+			import FT from "../shared/FT.cdc"
+			import NFT from "./NFT.cdc"
+			import "Foo"
+			import Crypto
+			pub fun main() {}
+		`), nil, "scripts/get.cdc")
+		require.NoError(t, err)
+
+		moved := map[string]string{
+			"scripts/Kibble.cdc": "cadence/contracts/Kibble.cdc",
+			"shared/FT.cdc":      "cadence/contracts/FT.cdc",
+		}
+
+		relocated, err := RelocateImports(program, "cadence/scripts/get.cdc", moved)
+		require.NoError(t, err)
+
+		assert.Equal(t, "cadence/scripts/get.cdc", relocated.Location())
+		assert.Equal(t, cleanCode([]byte(`
+			import Kibble from "../contracts/Kibble.cdc"
+			import FT from "../contracts/FT.cdc"
+			import NFT from "../../scripts/NFT.cdc"
+			import "Foo"
+			import Crypto
+			pub fun main() {}
+		`)), cleanCode(relocated.Code()))
+	})
+
+	t.Run("Relocate to same directory", func(t *testing.T) {
+		program, err := NewProgram([]byte(`import FT from "./FT.cdc" pub contract Foo {}`), nil, "Foo.cdc")
+		require.NoError(t, err)
+
+		relocated, err := RelocateImports(program, "contracts/Foo.cdc", map[string]string{"FT.cdc": "contracts/FT.cdc"})
+		require.NoError(t, err)
+
+		assert.Equal(t, `import FT from "./FT.cdc" pub contract Foo {}`, string(relocated.Code()))
+	})
+
+	t.Run("Fail relocating remote program", func(t *testing.T) {
+		program, err := NewProgram([]byte(`pub fun main() {}`), nil, "https://example.com/get.cdc")
+		require.NoError(t, err)
+
+		_, err = RelocateImports(program, "scripts/get.cdc", nil)
+		assert.EqualError(t, err, "remote program https://example.com/get.cdc can't be relocated")
+	})
+}
+
+func TestProgramKind(t *testing.T) {
+	tests := []struct {
+		code string
+		kind Kind
+	}{
+		{code: `pub contract Foo {}`, kind: KindContract},
+		{code: `pub contract interface Foo {}`, kind: KindContract},
+		{code: `transaction { prepare(signer: AuthAccount) {} }`, kind: KindTransaction},
+		{code: `pub fun main(): Int { return 1 }`, kind: KindScript},
+		{code: `import Test
+			pub fun testFoo() {}`, kind: KindTest},
+	}
+
+	for _, test := range tests {
+		program, err := NewProgram([]byte(test.code), nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, test.kind, program.Kind(), test.code)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+const (
+	debugPragma    = "#debug"
+	endDebugPragma = "#enddebug"
+)
+
+var constantRegex = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// Transform applies the source transformations defined on the deployment to the contract code.
+//
+// Debug blocks are removed first, so they can contain code that would not be valid for other builds,
+// then the constants are injected and at last the log statements are removed.
+func Transform(code []byte, transforms config.ContractTransforms) ([]byte, error) {
+	var err error
+	if transforms.StripDebug {
+		code, err = stripDebug(code)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(transforms.Constants) > 0 {
+		code = injectConstants(code, transforms.Constants)
+	}
+
+	if transforms.StripLogs {
+		code, err = stripLogs(code)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return code, nil
+}
+
+// stripDebug removes all the lines between the #debug and #enddebug pragmas including the pragmas.
+//
+// Pragmas can be written in a line comment (// #debug) so the source remains valid Cadence.
+func stripDebug(code []byte) ([]byte, error) {
+	lines := strings.SplitAfter(string(code), "\n")
+	result := make([]string, 0, len(lines))
+
+	debugLine := 0
+	for i, line := range lines {
+		pragma := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "//"))
+
+		switch {
+		case pragma == debugPragma && debugLine != 0:
+			return nil, fmt.Errorf("nested %s pragma on line %d", debugPragma, i+1)
+		case pragma == debugPragma:
+			debugLine = i + 1
+		case pragma == endDebugPragma && debugLine == 0:
+			return nil, fmt.Errorf("%s pragma on line %d without a matching %s pragma", endDebugPragma, i+1, debugPragma)
+		case pragma == endDebugPragma:
+			debugLine = 0
+		case debugLine == 0:
+			result = append(result, line)
+		}
+	}
+
+	if debugLine != 0 {
+		return nil, fmt.Errorf("missing %s pragma for the %s pragma on line %d", endDebugPragma, debugPragma, debugLine)
+	}
+
+	return []byte(strings.Join(result, "")), nil
+}
+
+// injectConstants replaces the ${NAME} placeholders with the constant values, unknown placeholders are left unchanged.
+func injectConstants(code []byte, constants map[string]string) []byte {
+	return constantRegex.ReplaceAllFunc(code, func(placeholder []byte) []byte {
+		name := constantRegex.FindSubmatch(placeholder)[1]
+		if value, ok := constants[string(name)]; ok {
+			return []byte(value)
+		}
+		return placeholder
+	})
+}
+
+// stripLogs removes all the log() statements from the code.
+func stripLogs(code []byte) ([]byte, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse code for removing log statements: %w", err)
+	}
+
+	type sourceRange struct{ start, end int }
+	ranges := make([]sourceRange, 0)
+
+	ast.Inspect(program, func(element ast.Element) bool {
+		statement, ok := element.(*ast.ExpressionStatement)
+		if !ok || !isLogInvocation(statement.Expression) {
+			return true
+		}
+
+		start, end := statementRange(code, statement.StartPosition().Offset, statement.EndPosition(nil).Offset)
+		ranges = append(ranges, sourceRange{start, end})
+		return false
+	})
+
+	// remove from the end, so the offsets of the remaining ranges stay valid
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start > ranges[j].start
+	})
+
+	result := append([]byte{}, code...)
+	for _, r := range ranges {
+		result = append(result[:r.start], result[r.end:]...)
+	}
+
+	return result, nil
+}
+
+func isLogInvocation(expression ast.Expression) bool {
+	invocation, ok := expression.(*ast.InvocationExpression)
+	if !ok {
+		return false
+	}
+
+	identifier, ok := invocation.InvokedExpression.(*ast.IdentifierExpression)
+	return ok && identifier.Identifier.Identifier == "log"
+}
+
+// statementRange returns the range of the statement to remove, including the trailing semicolon,
+// and the whole line if the statement is the only thing on it.
+func statementRange(code []byte, start int, end int) (int, int) {
+	end++ // end offset is inclusive
+
+	after := end
+	for after < len(code) && (code[after] == ' ' || code[after] == '\t') {
+		after++
+	}
+	if after < len(code) && code[after] == ';' {
+		end = after + 1
+	}
+
+	lineStart := start
+	for lineStart > 0 && (code[lineStart-1] == ' ' || code[lineStart-1] == '\t') {
+		lineStart--
+	}
+	lineEnd := end
+	for lineEnd < len(code) && (code[lineEnd] == ' ' || code[lineEnd] == '\t' || code[lineEnd] == '\r') {
+		lineEnd++
+	}
+
+	ownLine := (lineStart == 0 || code[lineStart-1] == '\n') && (lineEnd == len(code) || code[lineEnd] == '\n')
+	if !ownLine {
+		return start, end
+	}
+
+	if lineEnd < len(code) {
+		lineEnd++ // newline
+	}
+
+	return lineStart, lineEnd
+}
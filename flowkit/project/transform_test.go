@@ -0,0 +1,90 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func TestTransform(t *testing.T) {
+	code := []byte(`pub contract Foo {
+    pub let version: String
+
+    init() {
+        self.version = "${VERSION}"
+        log("init")
+        // #debug
+        self.debug()
+        // #enddebug
+        if true { log(self.version); }
+    }
+
+    pub fun debug() {
+        log("debug")
+    }
+}
+`)
+
+	t.Run("All", func(t *testing.T) {
+		result, err := Transform(code, config.ContractTransforms{
+			StripLogs:  true,
+			StripDebug: true,
+			Constants:  map[string]string{"VERSION": "1.0.0"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `pub contract Foo {
+    pub let version: String
+
+    init() {
+        self.version = "1.0.0"
+        if true {  }
+    }
+
+    pub fun debug() {
+    }
+}
+`, string(result))
+	})
+
+	t.Run("None", func(t *testing.T) {
+		result, err := Transform(code, config.ContractTransforms{})
+		require.NoError(t, err)
+		assert.Equal(t, string(code), string(result))
+	})
+
+	t.Run("Unknown constant", func(t *testing.T) {
+		result := injectConstants([]byte(`let a = "${A}"; let b = "${B}"`), map[string]string{"A": "a"})
+		assert.Equal(t, `let a = "a"; let b = "${B}"`, string(result))
+	})
+
+	t.Run("Fail missing end pragma", func(t *testing.T) {
+		_, err := Transform([]byte("// #debug\nlet a = 1\n"), config.ContractTransforms{StripDebug: true})
+		assert.EqualError(t, err, "missing #enddebug pragma for the #debug pragma on line 1")
+	})
+
+	t.Run("Fail end pragma without start", func(t *testing.T) {
+		_, err := Transform([]byte("let a = 1\n#enddebug\n"), config.ContractTransforms{StripDebug: true})
+		assert.EqualError(t, err, "#enddebug pragma on line 2 without a matching #debug pragma")
+	})
+}
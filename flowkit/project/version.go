@@ -0,0 +1,59 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"regexp"
+	"strings"
+)
+
+// VersionConstant is the name of the constant placeholder, ${CONTRACT_VERSION}, replaced with the contract version.
+const VersionConstant = "CONTRACT_VERSION"
+
+const versionStampPrefix = "// flow:version "
+
+var versionStampRegex = regexp.MustCompile(`(?m)^// flow:version (\S+)[ \t]*\r?\n?`)
+
+// StampVersion injects the version into the contract code before it is deployed.
+//
+// The ${CONTRACT_VERSION} placeholders are replaced with the version and a version comment is added
+// at the top of the code, so the deployed version can be read back from the code on the network.
+// An existing version comment is replaced, so the code can be stamped repeatedly.
+func StampVersion(code []byte, version string) []byte {
+	if version == "" {
+		return code
+	}
+
+	code = injectConstants(code, map[string]string{VersionConstant: version})
+	code = versionStampRegex.ReplaceAll(code, nil)
+
+	return append([]byte(versionStampPrefix+version+"\n"), code...)
+}
+
+// DeployedVersion reads the version from the version comment in the deployed contract code.
+//
+// If the code was not stamped with a version an empty string is returned.
+func DeployedVersion(code []byte) string {
+	match := versionStampRegex.FindSubmatch(code)
+	if match == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(match[1]))
+}
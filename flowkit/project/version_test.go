@@ -0,0 +1,48 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StampVersion(t *testing.T) {
+	code := []byte(`pub contract Foo {
+	pub let version: String
+	init() { self.version = "${CONTRACT_VERSION}" }
+}`)
+
+	stamped := StampVersion(code, "1.2.0")
+	assert.Equal(t, `// flow:version 1.2.0
+pub contract Foo {
+	pub let version: String
+	init() { self.version = "1.2.0" }
+}`, string(stamped))
+	assert.Equal(t, "1.2.0", DeployedVersion(stamped))
+
+	restamped := StampVersion(stamped, "1.3.0")
+	assert.Equal(t, "1.3.0", DeployedVersion(restamped))
+	assert.Equal(t, "// flow:version 1.3.0\npub contract Foo {", string(restamped[:40]))
+	assert.Len(t, versionStampRegex.FindAllIndex(restamped, -1), 1)
+
+	assert.Equal(t, code, StampVersion(code, ""))
+	assert.Equal(t, "", DeployedVersion(code))
+}
@@ -0,0 +1,103 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// sequenceMismatch matches the error of a transaction proposed with a wrong sequence number,
+// capturing the current sequence number of the key.
+var sequenceMismatch = regexp.MustCompile(`has sequence number (\d+), but given \d+`)
+
+type sequenceKey struct {
+	address flow.Address
+	index   int
+}
+
+// sequenceTracker tracks the sequence numbers of the proposal keys used by the transactions sent in this process.
+//
+// The sequence number of an account fetched from the access node doesn't yet reflect the transactions that are not
+// sealed, so transactions sent in a quick succession by the same proposer would reuse the same sequence number.
+// A nil tracker doesn't track any sequence numbers.
+type sequenceTracker struct {
+	mu   sync.Mutex
+	next map[sequenceKey]uint64
+}
+
+func newSequenceTracker() *sequenceTracker {
+	return &sequenceTracker{
+		next: make(map[sequenceKey]uint64),
+	}
+}
+
+// sequenceNumber returns the sequence number the key should propose with,
+// which is the fetched sequence number unless a higher one is tracked.
+func (s *sequenceTracker) sequenceNumber(address flow.Address, index int, fetched uint64) uint64 {
+	if s == nil {
+		return fetched
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if next, ok := s.next[sequenceKey{address, index}]; ok && next > fetched {
+		return next
+	}
+	return fetched
+}
+
+// sent tracks the sequence number of the sent transaction proposal key as used.
+func (s *sequenceTracker) sent(key flow.ProposalKey) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := sequenceKey{key.Address, key.KeyIndex}
+	if key.SequenceNumber+1 > s.next[k] {
+		s.next[k] = key.SequenceNumber + 1
+	}
+}
+
+// recover resets the tracked sequence number of the proposal key to the current one if the error
+// is a sequence number mismatch, and reports whether it was.
+func (s *sequenceTracker) recover(key flow.ProposalKey, err error) bool {
+	if s == nil || err == nil {
+		return false
+	}
+
+	match := sequenceMismatch.FindStringSubmatch(err.Error())
+	if match == nil {
+		return false
+	}
+	current, parseErr := strconv.ParseUint(match[1], 10, 64)
+	if parseErr != nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next[sequenceKey{key.Address, key.KeyIndex}] = current
+	return true
+}
@@ -0,0 +1,110 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+)
+
+func Test_SequenceTracker(t *testing.T) {
+	address := flow.HexToAddress("01")
+	key := flow.ProposalKey{Address: address, KeyIndex: 0, SequenceNumber: 4}
+
+	t.Run("Track Sent", func(t *testing.T) {
+		s := newSequenceTracker()
+		assert.Equal(t, uint64(4), s.sequenceNumber(address, 0, 4))
+
+		s.sent(key)
+		assert.Equal(t, uint64(5), s.sequenceNumber(address, 0, 4))
+		assert.Equal(t, uint64(7), s.sequenceNumber(address, 0, 7))
+		assert.Equal(t, uint64(4), s.sequenceNumber(address, 1, 4))
+	})
+
+	t.Run("Recover Mismatch", func(t *testing.T) {
+		s := newSequenceTracker()
+		s.sent(flow.ProposalKey{Address: address, KeyIndex: 0, SequenceNumber: 9})
+
+		mismatch := errors.New("[Error Code: 1007] invalid proposal key: public key 0 on account 0000000000000001 has sequence number 6, but given 10")
+		assert.True(t, s.recover(key, mismatch))
+		assert.Equal(t, uint64(6), s.sequenceNumber(address, 0, 2))
+
+		assert.False(t, s.recover(key, errors.New("failure")))
+		assert.False(t, s.recover(key, nil))
+	})
+
+	t.Run("Nil Tracker", func(t *testing.T) {
+		var s *sequenceTracker
+		s.sent(key)
+		assert.Equal(t, uint64(4), s.sequenceNumber(address, 0, 4))
+		assert.False(t, s.recover(key, errors.New("has sequence number 6, but given 10")))
+	})
+}
+
+func Test_SendSequenceNumbers(t *testing.T) {
+	state, flowkit, gw := setup()
+	flowkit.sequences = newSequenceTracker()
+	serviceAcc, _ := state.EmulatorServiceAccount()
+	roles := transactions.AddressesRoles{
+		Proposer:    serviceAcc.Address,
+		Authorizers: []flow.Address{serviceAcc.Address},
+		Payer:       serviceAcc.Address,
+	}
+	script := Script{Code: tests.TransactionSimple.Source}
+
+	// the account isn't updated by the sent transactions, like an access node that didn't seal them yet
+	account := tests.NewAccountWithAddress(serviceAcc.Address.String())
+	gw.GetAccount.Run(func(_ mock.Arguments) {
+		gw.GetAccount.Return(account, nil)
+	})
+
+	build := func() *transactions.Transaction {
+		tx, err := flowkit.BuildTransaction(ctx, roles, 0, script, flow.DefaultTransactionGasLimit)
+		require.NoError(t, err)
+		return tx
+	}
+
+	tx := build()
+	fetched := tx.FlowTransaction().ProposalKey.SequenceNumber
+
+	_, _, err := flowkit.SendSignedTransaction(ctx, tx)
+	require.NoError(t, err)
+	assert.Equal(t, fetched+1, build().FlowTransaction().ProposalKey.SequenceNumber)
+
+	// the key was used by another process, so the tracked sequence number is behind
+	mismatch := tests.NewTransactionResult(nil)
+	mismatch.Error = fmt.Errorf(
+		"invalid proposal key: public key 0 on account %s has sequence number %d, but given %d",
+		serviceAcc.Address, fetched+5, fetched+1,
+	)
+	gw.GetTransactionResult.Return(mismatch, nil)
+
+	_, _, err = flowkit.SendSignedTransaction(ctx, build())
+	require.NoError(t, err)
+	assert.Equal(t, fetched+5, build().FlowTransaction().ProposalKey.SequenceNumber)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/gateway/stats"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/flowkit/project"
 	"github.com/onflow/flow-cli/flowkit/transactions"
@@ -44,12 +45,19 @@ type Services interface {
 	// GetAccount fetches account on the Flow network.
 	GetAccount(context.Context, flow.Address) (*flow.Account, error)
 
+	// GetAccountAtBlockHeight fetches account on the Flow network as of the block height.
+	GetAccountAtBlockHeight(context.Context, flow.Address, uint64) (*flow.Account, error)
+
 	// CreateAccount on the Flow network with the provided keys and using the signer for creation transaction.
 	// Returns the newly created account as well as the ID of the transaction that created the account.
 	//
 	// Keys is a slice but only one can be passed as well. If the transaction fails or there are other issues an error is returned.
 	CreateAccount(context.Context, *accounts.Account, []accounts.PublicKey) (*flow.Account, flow.Identifier, error)
 
+	// CreateAccountWithOptions creates the account same as CreateAccount, but allows running the setup code
+	// declaring the function setup(account: AuthAccount) on the new account in the creation transaction.
+	CreateAccountWithOptions(context.Context, *accounts.Account, []accounts.PublicKey, CreateAccountOptions) (*flow.Account, flow.Identifier, error)
+
 	// AddContract to the Flow account provided and return the transaction ID.
 	//
 	// If the contract already exists on the account the operation will fail and error will be returned.
@@ -65,6 +73,11 @@ type Services interface {
 	// GetBlock by the query from Flow blockchain. Query can define a block by ID, block by height or require the latest block.
 	GetBlock(context.Context, BlockQuery) (*flow.Block, error)
 
+	// SubscribeBlocks streams the sealed blocks starting at the provided height, or at the latest sealed block if the
+	// height is zero. The blocks are sent in order on the returned channel until the context is done, and a failure
+	// fetching the blocks is sent on the error channel.
+	SubscribeBlocks(context.Context, uint64) (<-chan *flow.Block, <-chan error, error)
+
 	// GetCollection by the ID from Flow network.
 	GetCollection(context.Context, flow.Identifier) (*flow.Collection, error)
 
@@ -76,6 +89,17 @@ type Services interface {
 	// if not provided only a single worker will be used.
 	GetEvents(context.Context, []string, uint64, uint64, *EventWorker) ([]flow.BlockEvents, error)
 
+	// SubscribeEvents streams the events of the provided types for each sealed block, starting at the provided height
+	// or at the latest sealed block if the height is zero. The events are sent on the returned channel until
+	// the context is done, and a subscription failure is sent on the error channel.
+	SubscribeEvents(context.Context, []string, uint64) (<-chan flow.BlockEvents, <-chan error, error)
+
+	// SubscribeDecodedEvents streams the decoded events of the provided types, starting at the provided height or at
+	// the latest sealed block if the height is zero. The past blocks are fetched in chunks and the stream is resumed
+	// from the next block if it fails, the events are sent in order until the context is done, and the error is sent
+	// on the error channel if the subscription keeps failing.
+	SubscribeDecodedEvents(context.Context, []string, uint64) (<-chan DecodedEvent, <-chan error)
+
 	// GenerateKey using the signature algorithm and optional seed. If seed is not provided a random safe seed will be generated.
 	GenerateKey(context.Context, crypto.SignatureAlgorithm, string) (crypto.PrivateKey, error)
 
@@ -93,6 +117,10 @@ type Services interface {
 	// If contracts already exist use UpdateExistingContract(bool) to define whether a contract should be updated or not.
 	DeployProject(context.Context, UpdateContract) ([]*project.Contract, error)
 
+	// DeployProjectWithOptions deploys the project contracts same as DeployProject, but allows choosing the network
+	// and reports the progress of each contract deployment to the OnProgress callback.
+	DeployProjectWithOptions(context.Context, DeployOptions) ([]*project.Contract, error)
+
 	// ExecuteScript on the Flow network and return the Cadence value as a result. The script is executed at the
 	// block provided as part of the ScriptQuery value.
 	ExecuteScript(context.Context, Script, ScriptQuery) (cadence.Value, error)
@@ -102,6 +130,10 @@ type Services interface {
 
 	GetTransactionsByBlockID(context.Context, flow.Identifier) ([]*flow.Transaction, []*flow.TransactionResult, error)
 
+	// GetSystemTransaction from the Flow network including the transaction result, the system transaction
+	// is executed in the system chunk of each block and handles protocol logic such as fee deduction.
+	GetSystemTransaction(context.Context, flow.Identifier) (*flow.Transaction, *flow.TransactionResult, error)
+
 	// BuildTransaction builds a new transaction type for later signing and submitting to the network.
 	//
 	// AddressesRoles type defines the address for each role (payer, proposer, authorizers) and the script defines the transaction content.
@@ -120,4 +152,22 @@ type Services interface {
 	// SendTransaction will build and send a transaction to the Flow network, using the accounts provided for each role and
 	// contain the script. Transaction as well as transaction result will be returned in case the transaction is successfully submitted.
 	SendTransaction(context.Context, transactions.AccountRoles, Script, uint64) (*flow.Transaction, *flow.TransactionResult, error)
+
+	// CreateSnapshot of the current emulator state with the provided name.
+	//
+	// Snapshots are only supported by the emulator, and require the emulator to be started with snapshots enabled.
+	CreateSnapshot(context.Context, string) error
+
+	// LoadSnapshot restores the emulator state to the snapshot with the provided name.
+	LoadSnapshot(context.Context, string) error
+
+	// Rollback the emulator state to the provided block height, removing all the blocks after it.
+	//
+	// Rollback is only supported by the emulator.
+	Rollback(context.Context, uint64) error
+
+	// GetEmulatorStats returns the current usage of the emulator, such as the number of stored blocks and transactions.
+	//
+	// Stats are only supported by the emulator.
+	GetEmulatorStats(context.Context) (*stats.Emulator, error)
 }
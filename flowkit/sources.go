@@ -0,0 +1,224 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+)
+
+// sourcesCacheDir is the folder relative to the configuration where fetched remote sources are cached.
+const sourcesCacheDir = ".flow/sources"
+
+// ipfsGateway is used to fetch ipfs:// sources, it can be changed with the FLOW_IPFS_GATEWAY environment variable.
+const ipfsGateway = "https://ipfs.io/ipfs/"
+
+// fetchSource downloads the remote source, it's a variable so it can be replaced in tests.
+var fetchSource = func(location string) ([]byte, error) {
+	url := location
+	if strings.HasPrefix(location, project.IPFSScheme) {
+		gateway := os.Getenv("FLOW_IPFS_GATEWAY")
+		if gateway == "" {
+			gateway = ipfsGateway
+		}
+		url = strings.TrimSuffix(gateway, "/") + "/" + strings.TrimPrefix(location, project.IPFSScheme)
+	}
+
+	client := http.Client{Timeout: 30 * time.Second}
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", res.Status)
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+// readSource reads the contract code from a file or from a remote location.
+//
+// Remote sources are pinned in the lockfile by the hash of the content the first time they are fetched,
+// and later loads are verified against it. Verified sources are cached, so they are only fetched once.
+func (p *State) readSource(location string) ([]byte, error) {
+	if !project.IsRemoteLocation(location) {
+		return p.readerWriter.ReadFile(location)
+	}
+
+	lockPath := p.LockfilePath()
+	lock, err := LoadLockfile(p.readerWriter, lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	locked, isLocked := lock.Sources[location]
+	if !isLocked && p.frozenLockfile {
+		return nil, fmt.Errorf("%w: contract source %s is missing in %s", ErrFrozenLockfile, location, LockfileName)
+	}
+	if isLocked {
+		cached, err := p.readerWriter.ReadFile(p.sourceCachePath(locked.Hash))
+		if err == nil && sourceHash(cached) == locked.Hash {
+			return cached, nil
+		}
+	}
+
+	code, err := fetchSource(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch contract source %s: %w", location, err)
+	}
+
+	hash := sourceHash(code)
+	if isLocked && hash != locked.Hash {
+		return nil, fmt.Errorf(
+			"contract source %s does not match the hash in %s, expected %s but got %s",
+			location,
+			LockfileName,
+			locked.Hash,
+			hash,
+		)
+	}
+
+	if !isLocked {
+		if lock.Sources == nil {
+			lock.Sources = make(map[string]LockedSource)
+		}
+		lock.Sources[location] = LockedSource{Hash: hash}
+		if err := lock.Save(p.readerWriter, lockPath); err != nil {
+			return nil, fmt.Errorf("failed to save lockfile: %w", err)
+		}
+	}
+
+	// caching is best effort, the source is verified on every load anyway
+	if mkdir, ok := p.readerWriter.(interface {
+		MkdirAll(string, os.FileMode) error
+	}); ok {
+		_ = mkdir.MkdirAll(p.projectPath(sourcesCacheDir), 0755)
+	}
+	_ = p.readerWriter.WriteFile(p.sourceCachePath(hash), code, 0644)
+
+	return code, nil
+}
+
+// verifyAliases checks the code of the aliased contracts on the network matches the hashes in the lockfile.
+//
+// New aliases are added to the lockfile and changed aliases are updated with a warning, unless the lockfile
+// is frozen in which case an error is returned, so deployments don't silently change when upstream code moves.
+func (f *Flowkit) verifyAliases(ctx context.Context, state *State) error {
+	lockPath := state.LockfilePath()
+	lock, err := LoadLockfile(state.readerWriter, lockPath)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, contract := range *state.Contracts() {
+		alias := contract.Aliases.ByNetwork(f.network.Name)
+		if alias == nil {
+			continue
+		}
+
+		account, err := f.GetAccount(ctx, alias.Address)
+		if err != nil {
+			return fmt.Errorf("failed to get aliased contract %s: %w", contract.Name, err)
+		}
+		code, ok := account.Contracts[contract.Name]
+		if !ok {
+			continue // deployment will fail on the missing import
+		}
+
+		current := LockedAlias{Address: fmt.Sprintf("0x%s", alias.Address), Hash: sourceHash(code)}
+		locked, isLocked := lock.Aliases[f.network.Name][contract.Name]
+		if isLocked && locked == current {
+			continue
+		}
+
+		if state.frozenLockfile {
+			if !isLocked {
+				return fmt.Errorf("%w: aliased contract %s on network %s is missing in %s", ErrFrozenLockfile, contract.Name, f.network.Name, LockfileName)
+			}
+			return fmt.Errorf("%w: aliased contract %s on network %s changed since it was locked", ErrFrozenLockfile, contract.Name, f.network.Name)
+		}
+
+		if isLocked {
+			f.logger.Info(fmt.Sprintf(
+				"%s Aliased contract %s on network %s changed since it was locked, updating %s",
+				output.WarningEmoji(),
+				contract.Name,
+				f.network.Name,
+				LockfileName,
+			))
+		}
+
+		if lock.Aliases == nil {
+			lock.Aliases = make(map[string]map[string]LockedAlias)
+		}
+		if lock.Aliases[f.network.Name] == nil {
+			lock.Aliases[f.network.Name] = make(map[string]LockedAlias)
+		}
+		lock.Aliases[f.network.Name][contract.Name] = current
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return lock.Save(state.readerWriter, lockPath)
+}
+
+// LockfilePath returns the path of the lockfile next to the configuration.
+func (p *State) LockfilePath() string {
+	return p.projectPath(LockfileName)
+}
+
+// SetFrozenLockfile defines whether missing or changed dependencies fail instead of updating the lockfile.
+func (p *State) SetFrozenLockfile(frozen bool) {
+	p.frozenLockfile = frozen
+}
+
+// projectPath returns the path relative to the configuration if it was loaded from a single location.
+func (p *State) projectPath(name string) string {
+	if len(p.confLoader.LoadedLocations) == 1 {
+		return filepath.Join(filepath.Dir(p.confLoader.LoadedLocations[0]), name)
+	}
+
+	return name
+}
+
+func (p *State) sourceCachePath(hash string) string {
+	return p.projectPath(filepath.Join(sourcesCacheDir, fmt.Sprintf("%s.cdc", hash)))
+}
+
+func sourceHash(code []byte) string {
+	return hex.EncodeToString(crypto.NewSHA3_256().ComputeHash(code))
+}
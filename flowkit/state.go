@@ -21,8 +21,8 @@ package flowkit
 import (
 	"fmt"
 	"os"
-	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/pkg/errors"
@@ -31,6 +31,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/config/json"
+	"github.com/onflow/flow-cli/flowkit/config/toml"
 	"github.com/onflow/flow-cli/flowkit/project"
 )
 
@@ -42,10 +43,15 @@ type ReaderWriter interface {
 
 // State manages the state for a Flow project.
 type State struct {
-	conf         *config.Config
-	confLoader   *config.Loader
-	readerWriter ReaderWriter
-	accounts     *accounts.Accounts
+	conf           *config.Config
+	confLoader     *config.Loader
+	readerWriter   ReaderWriter
+	accounts       *accounts.Accounts
+	frozenLockfile bool
+	variables      map[string]bool
+	profile        string
+	// paths the configuration was loaded with, empty if the state was initialized.
+	paths []string
 }
 
 // ReaderWriter retrieve current file reader writer.
@@ -58,9 +64,110 @@ func (p *State) ReadFile(source string) ([]byte, error) {
 	return p.readerWriter.ReadFile(source)
 }
 
-// SaveDefault saves to default path.
+// ConfigPaths returns the locations the configuration was loaded from.
+func (p *State) ConfigPaths() []string {
+	return p.confLoader.LoadedLocations
+}
+
+// PrivateConfigPath returns the location of the private configuration loaded on top of the project
+// configuration, or an empty string if there is none.
+func (p *State) PrivateConfigPath() string {
+	return p.confLoader.PrivateLocation
+}
+
+// UserConfigPath returns the location of the user configuration loaded below the project configuration,
+// or an empty string if there is none.
+func (p *State) UserConfigPath() string {
+	return p.confLoader.UserLocation
+}
+
+// ConfigConflicts returns the configuration values overridden by a later configuration file when
+// multiple configuration files were loaded.
+func (p *State) ConfigConflicts() []config.Conflict {
+	return p.confLoader.Conflicts
+}
+
+// SetConfigLockWait changes how long saving the configuration waits for the lock held by another process.
+func (p *State) SetConfigLockWait(wait time.Duration) {
+	p.confLoader.SetLockWait(wait)
+}
+
+// LockConfig locks the loaded configuration until the returned unlock function is called, so no other flow
+// command saves it in the meantime, and reloads it, so the changes made while holding the lock are applied
+// to the latest saved configuration and can't be lost to a save of another flow command.
+//
+// Changes made to the state before locking are discarded by the reload, so the configuration must be
+// locked before it's changed.
+func (p *State) LockConfig() (func(), error) {
+	paths := append([]string{}, p.ConfigPaths()...)
+	if private := p.PrivateConfigPath(); private != "" {
+		paths = append(paths, private)
+	}
+
+	unlock, err := p.confLoader.Lock(paths...)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.paths) == 0 {
+		return unlock, nil
+	}
+
+	conf, err := loadConfig(p.confLoader, p.paths)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	accs, err := accounts.FromConfig(conf)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	p.conf = conf
+	p.accounts = &accs
+
+	if p.profile != "" {
+		if err := p.ApplyProfile(p.profile); err != nil {
+			unlock()
+			return nil, err
+		}
+	}
+
+	return unlock, nil
+}
+
+// ApplyProfile overrides the networks, accounts and deployments with the ones defined by the profile
+// of the configuration. The overridden entries are not saved, so the profile only applies to this state.
+func (p *State) ApplyProfile(name string) error {
+	p.conf.Accounts = accounts.ToConfig(*p.accounts)
+	conf, err := p.confLoader.ApplyProfile(p.conf, name)
+	if err != nil {
+		return err
+	}
+
+	accs, err := accounts.FromConfig(conf)
+	if err != nil {
+		return err
+	}
+
+	p.conf = conf
+	p.accounts = &accs
+	p.profile = name
+	return nil
+}
+
+// SaveDefault saves to the existing local configuration path, or to the default path if none exists.
 func (p *State) SaveDefault() error {
-	return p.Save(config.DefaultPath)
+	return p.Save(p.localPath())
+}
+
+// localPath returns the first existing local configuration path, or the default path if none exists.
+func (p *State) localPath() string {
+	for _, path := range config.LocalPaths {
+		if _, err := p.readerWriter.ReadFile(path); err == nil {
+			return path
+		}
+	}
+	return config.DefaultPath
 }
 
 // SaveEdited saves configuration to valid path.
@@ -71,12 +178,18 @@ func (p *State) SaveEdited(paths []string) error {
 	}
 	// if default paths and local config doesn't exist don't allow updating global config
 	if config.IsDefaultPath(paths) {
-		_, err := p.confLoader.Load([]string{config.DefaultPath}) // check if default is present
-		if err != nil {
-			return fmt.Errorf("default configuration not found, please initialize it first or specify another configuration file")
-		} else {
-			return p.SaveDefault()
+		for _, path := range config.LocalPaths { // check if default is present
+			if conf, err := p.confLoader.Load([]string{path}); err == nil {
+				// loading resets the applied profile, so it must be applied again for its entries not to be saved
+				if p.profile != "" {
+					if _, err := p.confLoader.ApplyProfile(conf, p.profile); err != nil {
+						return err
+					}
+				}
+				return p.Save(path)
+			}
 		}
+		return fmt.Errorf("default configuration not found, please initialize it first or specify another configuration file")
 	}
 
 	return p.Save(paths[0])
@@ -88,7 +201,7 @@ func (p *State) Save(path string) error {
 	err := p.confLoader.Save(p.conf, path)
 
 	if err != nil {
-		return fmt.Errorf("failed to save project configuration to: %s", path)
+		return fmt.Errorf("failed to save project configuration to: %s: %w", path, err)
 	}
 
 	return nil
@@ -139,8 +252,11 @@ func (p *State) SetEmulatorKey(privateKey crypto.PrivateKey) {
 //
 // Build contract slice based on the network provided, check the deployment section for that network
 // and retrieve the account by name, then add the accounts address on the contract as a destination.
+// Contracts whose deployment conditions are not met by the network variables are skipped and contracts
+// defining a mock source use the mock code on the emulator. Versioned contracts are stamped with their version.
 func (p *State) DeploymentContractsByNetwork(network config.Network) ([]*project.Contract, error) {
 	contracts := make([]*project.Contract, 0)
+	variables := p.Variables(network)
 
 	// get deployments for the specified network
 	for _, deploy := range p.conf.Deployments.ByNetwork(network.Name) {
@@ -156,29 +272,40 @@ func (p *State) DeploymentContractsByNetwork(network config.Network) ([]*project
 				return nil, err
 			}
 
-			location := c.Location
-			// if we loaded config from a single location, we should make the path of contracts defined in config relative to
-			// config path we have provided, this will make cases where we execute loading in different path than config work
-			if len(p.confLoader.LoadedLocations) == 1 {
-				location = filepath.Join(
-					filepath.Dir(p.confLoader.LoadedLocations[0]),
-					location,
-				)
+			// skip optional contracts whose conditions are not met on this network
+			if !config.ConditionMet(c.OnlyIf, variables) || !config.ConditionMet(deploymentContract.OnlyIf, variables) {
+				continue
+			}
+
+			location := p.configRelativeLocation(c.Location)
+
+			// the mock source replaces the contract code on the emulator, the location is kept so imports still resolve
+			mocked := c.MockSource != "" && network.Name == config.EmulatorNetwork.Name
+			source := location
+			if mocked {
+				source = p.configRelativeLocation(c.MockSource)
 			}
 
-			code, err := p.readerWriter.ReadFile(location)
+			code, err := p.readSource(source)
 			if err != nil {
 				return nil, errors.Wrap(err, "deployment by network failed to read contract code")
 			}
 
+			code, err = project.Transform(code, deploymentContract.Transforms)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to transform contract %s", c.Name)
+			}
+			code = project.StampVersion(code, c.Version)
+
 			contract := project.NewContract(
 				c.Name,
-				path.Clean(location),
+				location,
 				code,
 				account.Address,
 				account.Name,
 				deploymentContract.Args,
 			)
+			contract.Mocked = mocked
 
 			contracts = append(contracts, contract)
 		}
@@ -187,6 +314,32 @@ func (p *State) DeploymentContractsByNetwork(network config.Network) ([]*project
 	return contracts, nil
 }
 
+// configRelativeLocation cleans the location and makes it relative to the configuration and its source root.
+//
+// If we loaded config from a single location, we should make the path of contracts defined in config relative to
+// config path we have provided, this will make cases where we execute loading in different path than config work.
+func (p *State) configRelativeLocation(location string) string {
+	location = project.CleanLocation(p.conf.SourceLocation(location))
+	if len(p.confLoader.LoadedLocations) == 1 && !project.IsRemoteLocation(location) {
+		location = project.CleanLocation(filepath.Join(
+			filepath.Dir(p.confLoader.LoadedLocations[0]),
+			location,
+		))
+	}
+
+	return location
+}
+
+// SetVariables overrides the variables of the network environments used to evaluate the deployment conditions.
+func (p *State) SetVariables(variables map[string]bool) {
+	p.variables = variables
+}
+
+// Variables returns the variables used to evaluate the deployment conditions on the network.
+func (p *State) Variables(network config.Network) map[string]bool {
+	return p.conf.Environments.Variables(network.Name, p.variables)
+}
+
 // AccountsForNetwork returns all accounts used on a network defined by deployments.
 func (p *State) AccountsForNetwork(network config.Network) *accounts.Accounts {
 	exists := make(map[string]bool, 0)
@@ -213,8 +366,9 @@ func (p *State) AliasesForNetwork(network config.Network) project.LocationAliase
 	for _, contract := range p.conf.Contracts {
 		if contract.IsAliased() && contract.Aliases.ByNetwork(network.Name) != nil {
 			alias := contract.Aliases.ByNetwork(network.Name).Address.String()
-			aliases[path.Clean(contract.Location)] = alias // alias for import by file location
-			aliases[contract.Name] = alias                 // alias for import by name
+			location := project.CleanLocation(p.conf.SourceLocation(contract.Location))
+			aliases[location] = alias      // alias for import by file location
+			aliases[contract.Name] = alias // alias for import by name
 		}
 	}
 
@@ -227,23 +381,35 @@ func Load(configFilePaths []string, readerWriter ReaderWriter) (*State, error) {
 
 	// here we add all available parsers (more to add yaml etc...)
 	confLoader.AddConfigParser(json.NewParser())
-	conf, err := confLoader.Load(configFilePaths)
+	confLoader.AddConfigParser(toml.NewParser())
+	conf, err := loadConfig(confLoader, configFilePaths)
 	if err != nil {
 		return nil, err
 	}
-	// only add a default emulator in the config if the emulator account is present in accounts
-	_, err = conf.Accounts.ByName(config.DefaultEmulator.ServiceAccount)
-	if err == nil && len(conf.Emulators) == 0 {
-		conf.Emulators.AddOrUpdate("", config.DefaultEmulator)
-	}
 	proj, err := newProject(conf, confLoader, readerWriter)
 	if err != nil {
 		return nil, fmt.Errorf("invalid project configuration: %s", err)
 	}
+	proj.paths = configFilePaths
 
 	return proj, nil
 }
 
+// loadConfig loads the configuration from the paths with the loader.
+func loadConfig(confLoader *config.Loader, paths []string) (*config.Config, error) {
+	conf, err := confLoader.Load(paths)
+	if err != nil {
+		return nil, err
+	}
+	// only add a default emulator in the config if the emulator account is present in accounts
+	_, err = conf.Accounts.ByName(config.DefaultEmulator.ServiceAccount)
+	if err == nil && len(conf.Emulators) == 0 {
+		conf.Emulators.AddOrUpdate("", config.DefaultEmulator)
+	}
+
+	return conf, nil
+}
+
 // Init initializes a new Flow project.
 func Init(
 	readerWriter ReaderWriter,
@@ -257,6 +423,7 @@ func Init(
 
 	loader := config.NewLoader(readerWriter)
 	loader.AddConfigParser(json.NewParser())
+	loader.AddConfigParser(toml.NewParser())
 
 	return &State{
 		confLoader:   loader,
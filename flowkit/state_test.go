@@ -356,6 +356,39 @@ func Test_GetContractsByNameSimple(t *testing.T) {
 	assert.Equal(t, account.Address, contracts[0].AccountAddress)
 }
 
+func Test_GetContractsByNameWindowsPath(t *testing.T) {
+	p := generateAliasesProject()
+	p.conf.Contracts[0].Location = `..\hungry-kitties\cadence\contracts\NonFungibleToken.cdc`
+	p.conf.Contracts[1].Location = `..\hungry-kitties\cadence\contracts\FungibleToken.cdc`
+	path := "../hungry-kitties/cadence/contracts/NonFungibleToken.cdc"
+	af.WriteFile(path, []byte("pub contract{}"), os.ModePerm)
+
+	contracts, err := p.DeploymentContractsByNetwork(config.EmulatorNetwork)
+	require.NoError(t, err)
+	require.Len(t, contracts, 1)
+	assert.Equal(t, path, contracts[0].Location())
+
+	aliases := p.AliasesForNetwork(config.EmulatorNetwork)
+	assert.Equal(t, "ee82856bf20e2aa6", aliases["../hungry-kitties/cadence/contracts/FungibleToken.cdc"])
+}
+
+func Test_GetContractsBySourceRoot(t *testing.T) {
+	p := generateAliasesProject()
+	p.conf.SourceRoot = "cadence"
+	p.conf.Contracts[0].Location = "contracts/NonFungibleToken.cdc"
+	p.conf.Contracts[1].Location = "./contracts/FungibleToken.cdc"
+	path := "cadence/contracts/NonFungibleToken.cdc"
+	af.WriteFile(path, []byte("pub contract{}"), os.ModePerm)
+
+	contracts, err := p.DeploymentContractsByNetwork(config.EmulatorNetwork)
+	require.NoError(t, err)
+	require.Len(t, contracts, 1)
+	assert.Equal(t, path, contracts[0].Location())
+
+	aliases := p.AliasesForNetwork(config.EmulatorNetwork)
+	assert.Equal(t, "ee82856bf20e2aa6", aliases["cadence/contracts/FungibleToken.cdc"])
+}
+
 func Test_EmulatorConfigSimple(t *testing.T) {
 	p := generateSimpleProject()
 	emulatorServiceAccount, _ := p.EmulatorServiceAccount()
@@ -673,6 +706,104 @@ func Test_Saving(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_LockConfig(t *testing.T) {
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	require.NoError(t, fs.WriteFile("flow.json", []byte(`{"networks": {"emulator": "127.0.0.1:3569"}}`), 0644))
+
+	state, err := Load([]string{"flow.json"}, fs)
+	require.NoError(t, err)
+	state.SetConfigLockWait(0)
+
+	// saved by another command after the state was loaded
+	other, err := Load([]string{"flow.json"}, fs)
+	require.NoError(t, err)
+	other.Networks().AddOrUpdate(config.Network{Name: "foo", Host: "127.0.0.1:3000"})
+	require.NoError(t, other.SaveEdited([]string{"flow.json"}))
+
+	unlock, err := state.LockConfig()
+	require.NoError(t, err)
+
+	t.Run("Reload configuration", func(t *testing.T) {
+		_, err := state.Networks().ByName("foo")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail saving while locked", func(t *testing.T) {
+		other.SetConfigLockWait(0)
+		err := other.SaveEdited([]string{"flow.json"})
+		assert.ErrorIs(t, err, config.ErrLocked)
+	})
+
+	t.Run("Save while locked", func(t *testing.T) {
+		state.Networks().AddOrUpdate(config.Network{Name: "bar", Host: "127.0.0.1:3001"})
+		require.NoError(t, state.SaveEdited([]string{"flow.json"}))
+		unlock()
+
+		loaded, err := Load([]string{"flow.json"}, fs)
+		require.NoError(t, err)
+		assert.Len(t, *loaded.Networks(), 3)
+
+		exists, _ := fs.Exists(config.LockPath("flow.json"))
+		assert.False(t, exists)
+	})
+}
+
+func Test_ApplyProfile(t *testing.T) {
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	configJson := []byte(`{
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+			}
+		},
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		},
+		"profiles": {
+			"staging": {
+				"networks": { "emulator": "127.0.0.1:3570" },
+				"accounts": {
+					"emulator-account": {
+						"address": "01cf0e2f2f715450",
+						"key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+					}
+				}
+			}
+		}
+	}`)
+	require.NoError(t, fs.WriteFile(config.DefaultPath, configJson, 0644))
+
+	state, err := Load(config.DefaultPaths(), fs)
+	require.NoError(t, err)
+
+	assert.EqualError(t, state.ApplyProfile("prod"), "profile prod does not exist, available profiles: staging")
+	require.NoError(t, state.ApplyProfile("staging"))
+
+	network, err := state.Networks().ByName("emulator")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:3570", network.Host)
+	account, err := state.Accounts().ByName("emulator-account")
+	require.NoError(t, err)
+	assert.Equal(t, "01cf0e2f2f715450", account.Address.String())
+
+	// the entries overridden by the profile are not saved
+	require.NoError(t, state.SaveEdited(config.DefaultPaths()))
+	saved, err := Load(config.DefaultPaths(), fs)
+	require.NoError(t, err)
+	network, err = saved.Networks().ByName("emulator")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:3569", network.Host)
+	account, err = saved.Accounts().ByName("emulator-account")
+	require.NoError(t, err)
+	assert.Equal(t, "f8d6e0586b0a20c7", account.Address.String())
+	assert.Len(t, saved.Config().Profiles, 1)
+
+	// the changes to the entries overridden by the profile can't be saved
+	state.Networks().AddOrUpdate(config.Network{Name: "emulator", Host: "127.0.0.1:3571"})
+	assert.Error(t, state.SaveEdited(config.DefaultPaths()))
+}
+
 // ensures that default emulator values are in config when no emulator is defined in flow.json
 func Test_DefaultEmulatorNotPresentInConfig(t *testing.T) {
 	configJson := []byte(`{
@@ -887,3 +1018,157 @@ func Test_CustomEmulatorValuesInConfig(t *testing.T) {
 	assert.Equal(t, state.conf, &cfg)
 	assert.NoError(t, err)
 }
+
+func Test_GetContractsRemoteSource(t *testing.T) {
+	const location = "https://example.com/contracts/Foo.cdc"
+	code := []byte("pub contract Foo {}")
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	p := generateSimpleProject()
+	p.conf.Contracts[0].Location = location
+	state, err := newProject(p.conf, config.NewLoader(fs), fs)
+	require.NoError(t, err)
+
+	fetched := 0
+	defaultFetch := fetchSource
+	t.Cleanup(func() { fetchSource = defaultFetch })
+	fetchSource = func(url string) ([]byte, error) {
+		assert.Equal(t, location, url)
+		fetched++
+		return code, nil
+	}
+
+	contracts, err := state.DeploymentContractsByNetwork(config.EmulatorNetwork)
+	require.NoError(t, err)
+	require.Len(t, contracts, 1)
+	assert.Equal(t, location, contracts[0].Location())
+	assert.Equal(t, code, contracts[0].Code())
+
+	lock, err := LoadLockfile(fs, LockfileName)
+	require.NoError(t, err)
+	assert.Equal(t, sourceHash(code), lock.Sources[location].Hash)
+
+	// loaded from the cache
+	_, err = state.DeploymentContractsByNetwork(config.EmulatorNetwork)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetched)
+
+	// upstream changed and cache is gone
+	require.NoError(t, fs.RemoveAll(sourcesCacheDir))
+	code = []byte("pub contract Foo { pub fun bar() {} }")
+	_, err = state.DeploymentContractsByNetwork(config.EmulatorNetwork)
+	assert.ErrorContains(t, err, "contract source https://example.com/contracts/Foo.cdc does not match the hash in flow.lock")
+
+	// sources missing in the frozen lockfile fail
+	state.SetFrozenLockfile(true)
+	state.conf.Contracts[0].Location = "ipfs://bafybeigdyrzt/Foo.cdc"
+	_, err = state.DeploymentContractsByNetwork(config.EmulatorNetwork)
+	assert.ErrorIs(t, err, ErrFrozenLockfile)
+}
+
+func Test_DeploymentContractsConditions(t *testing.T) {
+	b := []byte(`{
+		"contracts": {
+			"Foo": "./Foo.cdc",
+			"Mocks": {
+				"source": "./Mocks.cdc",
+				"onlyIf": "mocks"
+			}
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		},
+		"deployments": {
+			"emulator": {
+				"emulator-account": [
+					"Foo",
+					"Mocks",
+					{ "name": "Foo", "args": [], "onlyIf": "!production" }
+				]
+			}
+		},
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		},
+		"environments": {
+			"emulator": { "mocks": true }
+		}
+	}`)
+
+	af := afero.Afero{Fs: afero.NewMemMapFs()}
+	require.NoError(t, afero.WriteFile(af.Fs, "flow.json", b, 0644))
+	require.NoError(t, afero.WriteFile(af.Fs, "Foo.cdc", []byte("pub contract Foo {}"), 0644))
+	require.NoError(t, afero.WriteFile(af.Fs, "Mocks.cdc", []byte("pub contract Mocks {}"), 0644))
+
+	state, err := Load([]string{"flow.json"}, af)
+	require.NoError(t, err)
+
+	names := func() []string {
+		contracts, err := state.DeploymentContractsByNetwork(config.EmulatorNetwork)
+		require.NoError(t, err)
+
+		names := make([]string, 0)
+		for _, c := range contracts {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"Foo", "Mocks", "Foo"}, names())
+
+	state.SetVariables(map[string]bool{"mocks": false, "production": true})
+	assert.Equal(t, []string{"Foo"}, names())
+
+	state.SetVariables(nil)
+	assert.Equal(t, map[string]bool{"mocks": true}, state.Variables(config.EmulatorNetwork))
+	assert.Empty(t, state.Variables(config.TestnetNetwork))
+}
+
+func Test_DeploymentContractsMockSource(t *testing.T) {
+	b := []byte(`{
+		"contracts": {
+			"Oracle": {
+				"source": "./Oracle.cdc",
+				"mockSource": "./mocks/Oracle.cdc"
+			}
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		},
+		"networks": {
+			"emulator": "127.0.0.1:3569",
+			"testnet": "access.devnet.nodes.onflow.org:9000"
+		},
+		"deployments": {
+			"emulator": { "emulator-account": ["Oracle"] },
+			"testnet": { "emulator-account": ["Oracle"] }
+		}
+	}`)
+
+	af := afero.Afero{Fs: afero.NewMemMapFs()}
+	require.NoError(t, afero.WriteFile(af.Fs, "flow.json", b, 0644))
+	require.NoError(t, afero.WriteFile(af.Fs, "Oracle.cdc", []byte("pub contract Oracle {}"), 0644))
+	require.NoError(t, afero.WriteFile(af.Fs, "mocks/Oracle.cdc", []byte("pub contract Oracle { /* stub */ }"), 0644))
+
+	state, err := Load([]string{"flow.json"}, af)
+	require.NoError(t, err)
+
+	contracts, err := state.DeploymentContractsByNetwork(config.EmulatorNetwork)
+	require.NoError(t, err)
+	require.Len(t, contracts, 1)
+	assert.True(t, contracts[0].Mocked)
+	assert.Equal(t, "Oracle.cdc", contracts[0].Location())
+	assert.Equal(t, "pub contract Oracle { /* stub */ }", string(contracts[0].Code()))
+
+	contracts, err = state.DeploymentContractsByNetwork(config.TestnetNetwork)
+	require.NoError(t, err)
+	require.Len(t, contracts, 1)
+	assert.False(t, contracts[0].Mocked)
+	assert.Equal(t, "pub contract Oracle {}", string(contracts[0].Code()))
+}
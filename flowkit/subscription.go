@@ -0,0 +1,215 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// DecodedEvent is an event received by a subscription with the values decoded by the field names.
+type DecodedEvent struct {
+	Event
+	BlockID          flow.Identifier
+	BlockHeight      uint64
+	TransactionID    flow.Identifier
+	TransactionIndex int
+	EventIndex       int
+}
+
+// subscriptionRetries is the number of consecutive failures after which a subscription fails.
+const subscriptionRetries = 5
+
+// subscriptionBackoff is the delay before the first retry of a failed subscription, doubled for each next retry.
+var subscriptionBackoff = 500 * time.Millisecond
+
+// eventSubscription follows the events of the types from the next block height.
+type eventSubscription struct {
+	flowkit   *Flowkit
+	types     []string
+	next      uint64
+	streaming bool
+	events    chan DecodedEvent
+}
+
+// SubscribeDecodedEvents streams the decoded events of the provided types, starting at the provided height
+// or at the latest sealed block if the height is zero.
+//
+// The blocks up to the latest sealed block are fetched in chunks, after which the events are streamed from the
+// execution data API, or polled if the gateway doesn't support event subscriptions. Failed requests and broken
+// streams are retried from the next block with a backoff, so no events are skipped or repeated. The events are
+// sent in the order they were emitted until the context is done, or the error is sent on the error channel if
+// the subscription keeps failing.
+func (f *Flowkit) SubscribeDecodedEvents(
+	ctx context.Context,
+	types []string,
+	startHeight uint64,
+) (<-chan DecodedEvent, <-chan error) {
+	sub := &eventSubscription{
+		flowkit:   f,
+		types:     types,
+		next:      startHeight,
+		streaming: true,
+		events:    make(chan DecodedEvent),
+	}
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(sub.events)
+		defer close(errs)
+
+		if len(types) == 0 {
+			errs <- fmt.Errorf("at least one event type must be provided")
+			return
+		}
+
+		if err := sub.run(ctx); err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+
+	return sub.events, errs
+}
+
+// run follows the events until the context is done or the retries are exhausted.
+func (s *eventSubscription) run(ctx context.Context) error {
+	failures := 0
+	for {
+		progressed, err := s.follow(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if progressed {
+			failures = 0
+		}
+
+		delay := blockPollInterval
+		if err != nil {
+			failures++
+			if failures > subscriptionRetries {
+				return err
+			}
+			delay = subscriptionBackoff << (failures - 1)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+// follow catches up to the latest sealed block and then streams the new events, it returns whether
+// any blocks were processed before it stopped.
+func (s *eventSubscription) follow(ctx context.Context) (bool, error) {
+	latest, err := s.flowkit.gateway.GetLatestBlock(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error fetching block: %w", err)
+	}
+	if s.next == 0 {
+		s.next = latest.Height
+	}
+
+	start := s.next
+	for s.next <= latest.Height {
+		end := s.next + defaultBatchSize - 1
+		if end > latest.Height {
+			end = latest.Height
+		}
+
+		blockEvents, err := s.flowkit.getEventsInRange(ctx, s.types, BlockRange{Start: s.next, End: end})
+		if err != nil {
+			return s.next > start, fmt.Errorf("error fetching events in blocks %d to %d: %w", s.next, end, err)
+		}
+		if !s.send(ctx, decodeBlockEvents(blockEvents)) {
+			return true, nil
+		}
+		s.next = end + 1
+	}
+
+	if !s.streaming {
+		return s.next > start, nil
+	}
+
+	blocks, errs, err := s.flowkit.gateway.SubscribeEvents(ctx, s.types, s.next)
+	if err != nil {
+		// the events are polled from now on if the gateway doesn't support subscriptions
+		s.streaming = false
+		return s.next > start, nil
+	}
+
+	for blockEvents := range blocks {
+		if blockEvents.Height < s.next {
+			continue
+		}
+		if !s.send(ctx, decodeBlockEvents([]flow.BlockEvents{blockEvents})) {
+			return true, nil
+		}
+		s.next = blockEvents.Height + 1
+	}
+
+	return s.next > start, <-errs
+}
+
+// send the events to the subscriber, false is returned if the context is done.
+func (s *eventSubscription) send(ctx context.Context, events []DecodedEvent) bool {
+	for _, event := range events {
+		select {
+		case <-ctx.Done():
+			return false
+		case s.events <- event:
+		}
+	}
+	return true
+}
+
+// decodeBlockEvents decodes the events of the blocks, sorted in the order they were emitted.
+func decodeBlockEvents(blockEvents []flow.BlockEvents) []DecodedEvent {
+	events := make([]DecodedEvent, 0)
+	for _, block := range blockEvents {
+		for _, event := range block.Events {
+			events = append(events, DecodedEvent{
+				Event:            NewEvent(event),
+				BlockID:          block.BlockID,
+				BlockHeight:      block.Height,
+				TransactionID:    event.TransactionID,
+				TransactionIndex: event.TransactionIndex,
+				EventIndex:       event.EventIndex,
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.BlockHeight != b.BlockHeight {
+			return a.BlockHeight < b.BlockHeight
+		}
+		if a.TransactionIndex != b.TransactionIndex {
+			return a.TransactionIndex < b.TransactionIndex
+		}
+		return a.EventIndex < b.EventIndex
+	})
+
+	return events
+}
@@ -157,6 +157,77 @@ func NewCreateAccount(
 	return newFromTemplate(template, signer)
 }
 
+// createAccountWithSetupTemplate creates the account with the keys and runs the setup function declared
+// by the template code, which is inserted before the transaction, on the new account.
+const createAccountWithSetupTemplate = `%s
+transaction(publicKeys: [Crypto.KeyListEntry]) {
+	prepare(signer: AuthAccount) {
+		let account = AuthAccount(payer: signer)
+
+		// add all the keys to the account
+		for key in publicKeys {
+			account.keys.add(publicKey: key.publicKey, hashAlgorithm: key.hashAlgorithm, weight: key.weight)
+		}
+
+		setup(account: account)
+	}
+}`
+
+// NewCreateAccountWithSetup creates new transaction for account which runs the setup code on the new account.
+//
+// The setup code must declare the function setup(account: AuthAccount), together with the imports it uses.
+func NewCreateAccountWithSetup(
+	signer *accounts.Account,
+	keys []*flow.AccountKey,
+	setup []byte,
+) (*Transaction, error) {
+	program, err := parser.ParseProgram(nil, setup, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the setup code: %w", err)
+	}
+	if !declaresSetup(program) {
+		return nil, fmt.Errorf("the setup code must declare the function setup(account: AuthAccount)")
+	}
+
+	keyList := make([]cadence.Value, len(keys))
+	for i, key := range keys {
+		keyList[i], err = templates.AccountKeyToCadenceCryptoKey(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	code := string(setup)
+	if !importsCrypto(program) {
+		code = "import Crypto\n" + code
+	}
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(fmt.Sprintf(createAccountWithSetupTemplate, code))).
+		AddRawArgument(jsoncdc.MustEncode(cadence.NewArray(keyList))).
+		AddAuthorizer(signer.Address)
+
+	return newFromTemplate(tx, signer)
+}
+
+func declaresSetup(program *ast.Program) bool {
+	for _, declaration := range program.FunctionDeclarations() {
+		if declaration.Identifier.Identifier == "setup" {
+			return true
+		}
+	}
+	return false
+}
+
+func importsCrypto(program *ast.Program) bool {
+	for _, declaration := range program.ImportDeclarations() {
+		if declaration.Location.String() == "Crypto" {
+			return true
+		}
+	}
+	return false
+}
+
 func newFromTemplate(templateTx *flow.Transaction, signer *accounts.Account) (*Transaction, error) {
 	tx := &Transaction{tx: templateTx}
 
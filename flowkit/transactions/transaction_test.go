@@ -85,3 +85,38 @@ func TestNew(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, signed.FlowTransaction().EnvelopeSignatures, 1)
 }
+
+func TestNewCreateAccountWithSetup(t *testing.T) {
+	signer, _ := accounts.NewEmulatorAccount(crypto.ECDSA_P256, crypto.SHA3_256)
+	pkey, _ := signer.Key.PrivateKey()
+	keys := []*flow.AccountKey{{
+		PublicKey: (*pkey).PublicKey(),
+		SigAlgo:   crypto.ECDSA_P256,
+		HashAlgo:  crypto.SHA3_256,
+		Weight:    flow.AccountKeyWeightThreshold,
+	}}
+
+	t.Run("Success", func(t *testing.T) {
+		setup := []byte(`
+			import FungibleToken from 0xee82856bf20e2aa6
+
+			pub fun setup(account: AuthAccount) {}
+		`)
+
+		tx, err := transactions.NewCreateAccountWithSetup(signer, keys, setup)
+		assert.NoError(t, err)
+
+		script := string(tx.FlowTransaction().Script)
+		assert.Contains(t, script, "import Crypto\n")
+		assert.Contains(t, script, string(setup))
+		assert.Contains(t, script, "setup(account: account)")
+		assert.Len(t, tx.FlowTransaction().Arguments, 1)
+		assert.Equal(t, []flow.Address{signer.Address}, tx.FlowTransaction().Authorizers)
+		assert.Equal(t, signer.Address, tx.FlowTransaction().Payer)
+	})
+
+	t.Run("Fail missing setup", func(t *testing.T) {
+		_, err := transactions.NewCreateAccountWithSetup(signer, keys, []byte("pub fun main() {}"))
+		assert.EqualError(t, err, "the setup code must declare the function setup(account: AuthAccount)")
+	})
+}
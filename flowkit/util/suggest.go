@@ -0,0 +1,45 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util contains helpers shared by the flowkit packages and the tools built on flowkit.
+package util
+
+import (
+	"strings"
+
+	"github.com/texttheater/golang-levenshtein/levenshtein"
+)
+
+// ClosestMatch returns the candidate closest to the name ignoring the case, or an empty string if no candidate is
+// close enough to be a likely typo of the name. The first of the equally close candidates is returned.
+func ClosestMatch(name string, candidates []string) string {
+	closest := ""
+	closestDistance := len(name)/3 + 2 // allow roughly one typo for every three characters
+	for _, candidate := range candidates {
+		distance := levenshtein.DistanceForStrings(
+			[]rune(strings.ToLower(name)),
+			[]rune(strings.ToLower(candidate)),
+			levenshtein.DefaultOptionsWithSub,
+		)
+		if distance < closestDistance {
+			closest = candidate
+			closestDistance = distance
+		}
+	}
+	return closest
+}
@@ -44,6 +44,13 @@ func init() {
 	createCommand.AddToParent(Cmd)
 	stakingCommand.AddToParent(Cmd)
 	getCommand.AddToParent(Cmd)
+	storageDiffCommand.AddToParent(Cmd)
+	syncCommand.AddToParent(Cmd)
+	sequenceCommand.AddToParent(Cmd)
+	subscribeCommand.AddToParent(Cmd)
+	importCommand.AddToParent(Cmd)
+	exportCommand.AddToParent(Cmd)
+	diffCommand.AddToParent(Cmd)
 }
 
 // accountResult represent result from all account commands.
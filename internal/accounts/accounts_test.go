@@ -19,12 +19,14 @@
 package accounts
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/onflow/flow-cli/flowkit/accounts"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
@@ -32,6 +34,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
@@ -248,6 +252,68 @@ func Test_Create(t *testing.T) {
 	})
 }
 
+func Test_CreateTemplate(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	pkey := "014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"
+	createFlags.Keys = []string{pkey}
+	createFlags.SigAlgo = []string{"ECDSA_P256"}
+	createFlags.HashAlgo = []string{"SHA3_256"}
+	createFlags.Weights = []int{1000}
+	defer func() { createFlags.Template = "" }()
+
+	t.Run("Success built-in template", func(t *testing.T) {
+		createFlags.Template = "nft-user"
+
+		srv.Mock.
+			On("CreateAccountWithOptions", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				options := args.Get(3).(flowkit.CreateAccountOptions)
+				assert.Equal(t, "nft-user", options.Setup.Location)
+				assert.Contains(t, string(options.Setup.Code), "import ExampleNFT from 0xf8d6e0586b0a20c7")
+				assert.Contains(t, string(options.Setup.Code), "pub fun setup(account: AuthAccount)")
+			}).
+			Return(tests.NewAccountWithAddress("0x01"), flow.EmptyID, nil).
+			Once()
+
+		result, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
+	t.Run("Success project template", func(t *testing.T) {
+		code := []byte("pub fun setup(account: AuthAccount) {}")
+		require.NoError(t, rw.WriteFile("user.cdc", code, 0644))
+		createFlags.Template = "user.cdc"
+
+		srv.Mock.
+			On("CreateAccountWithOptions", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				options := args.Get(3).(flowkit.CreateAccountOptions)
+				assert.Equal(t, flowkit.Script{Code: code, Location: "user.cdc"}, options.Setup)
+			}).
+			Return(tests.NewAccountWithAddress("0x01"), flow.EmptyID, nil).
+			Once()
+
+		result, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
+	t.Run("Fail unknown template", func(t *testing.T) {
+		createFlags.Template = "foo"
+
+		result, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "template foo doesn't exist, available templates are nft-user, fusd-user, or provide the path to a .cdc template file")
+		assert.Nil(t, result)
+	})
+
+	t.Run("Fail template not available on network", func(t *testing.T) {
+		_, err := templateSetup("nft-user", state, "testnet")
+		assert.EqualError(t, err, "template nft-user is not available on network testnet, the ExampleNFT contract is missing")
+	})
+}
+
 func Test_Get(t *testing.T) {
 	srv, _, _ := util.TestMocks(t)
 
@@ -264,6 +330,23 @@ func Test_Get(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 	})
+
+	t.Run("Success at block height", func(t *testing.T) {
+		inArgs := []string{"0x01"}
+		getFlags.BlockHeight = 100
+
+		srv.GetAccountAtBlockHeight.Run(func(args mock.Arguments) {
+			assert.Equal(t, "0000000000000001", args.Get(1).(flow.Address).String())
+			assert.Equal(t, uint64(100), args.Get(2).(uint64))
+			srv.GetAccountAtBlockHeight.Return(tests.NewAccountWithAddress(inArgs[0]), nil)
+		})
+
+		result, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, nil, srv.Mock)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+
+		getFlags.BlockHeight = 0
+	})
 }
 
 func Test_Result(t *testing.T) {
@@ -312,3 +395,504 @@ Contracts (hidden, use --include contracts)`, "\n"), result.String())
 	}, result.JSON())
 
 }
+
+func storageValue(balance uint64, used uint64, paths map[string]string, values map[string]cadence.Value) cadence.Value {
+	pairs := make([]cadence.KeyValuePair, 0, len(paths))
+	for path, typ := range paths {
+		pairs = append(pairs, cadence.KeyValuePair{Key: cadence.String(path), Value: cadence.String(typ)})
+	}
+
+	valuePairs := make([]cadence.KeyValuePair, 0, len(values))
+	for path, value := range values {
+		valuePairs = append(valuePairs, cadence.KeyValuePair{Key: cadence.String(path), Value: value})
+	}
+
+	return cadence.NewStruct([]cadence.Value{
+		cadence.UFix64(balance),
+		cadence.UInt64(used),
+		cadence.NewDictionary(pairs),
+		cadence.NewDictionary(valuePairs),
+	}).WithType(&cadence.StructType{
+		QualifiedIdentifier: "StorageInfo",
+		Fields: []cadence.Field{
+			{Identifier: "balance"},
+			{Identifier: "used"},
+			{Identifier: "paths"},
+			{Identifier: "values"},
+		},
+	})
+}
+
+func Test_StorageDiff(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		storageDiffFlags.From = "10"
+		storageDiffFlags.To = 20
+		t.Cleanup(func() { storageDiffFlags = flagsStorageDiff{} })
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			query := args.Get(2).(flowkit.ScriptQuery)
+			if query.Height == 10 {
+				srv.ExecuteScript.Return(storageValue(100, 500, map[string]string{
+					"/storage/flowTokenVault": "A.0ae53cb6e3f42a79.FlowToken.Vault",
+					"/storage/kitty":          "A.01.Kitty.Collection",
+					"/storage/old":            "A.01.Old.Resource",
+					"/storage/counter":        "Int",
+				}, map[string]cadence.Value{
+					"/storage/counter": cadence.NewInt(1),
+				}), nil)
+				return
+			}
+			assert.Equal(t, uint64(20), query.Height)
+			srv.ExecuteScript.Return(storageValue(80, 600, map[string]string{
+				"/storage/flowTokenVault": "A.0ae53cb6e3f42a79.FlowToken.Vault",
+				"/storage/kitty":          "A.01.Kitty.NewCollection",
+				"/storage/new":            "A.01.New.Resource",
+				"/storage/counter":        "Int",
+			}, map[string]cadence.Value{
+				"/storage/counter": cadence.NewInt(2),
+			}), nil)
+		})
+
+		result, err := storageDiff([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, nil, srv.Mock)
+		require.NoError(t, err)
+
+		diff := result.(*StorageDiff)
+		assert.Equal(t, map[string]string{"/storage/new": "A.01.New.Resource"}, diff.added)
+		assert.Equal(t, map[string]string{"/storage/old": "A.01.Old.Resource"}, diff.removed)
+		assert.Equal(t, []changedPath{{
+			path:         "/storage/counter",
+			from:         "Int",
+			to:           "Int",
+			valueChanged: true,
+		}, {
+			path: "/storage/kitty",
+			from: "A.01.Kitty.Collection",
+			to:   "A.01.Kitty.NewCollection",
+		}}, diff.changed)
+		assert.Equal(t, "-0.00000020", diff.balanceDelta.String())
+		assert.Equal(t, int64(100), diff.usedDelta)
+		assert.Equal(t, "Added: 1, Removed: 1, Changed: 2, Balance Change: -0.00000020", diff.Oneliner())
+		assert.Contains(t, diff.String(), "/storage/counter\tInt (value changed)")
+	})
+
+	t.Run("Success from height zero", func(t *testing.T) {
+		storageDiffFlags.From = "0"
+		t.Cleanup(func() { storageDiffFlags = flagsStorageDiff{} })
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			query := args.Get(2).(flowkit.ScriptQuery)
+			if !query.Latest {
+				assert.Equal(t, flowkit.ScriptQuery{Height: 0}, query)
+			}
+			srv.ExecuteScript.Return(storageValue(100, 500, map[string]string{}, nil), nil)
+		})
+
+		result, err := storageDiff([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, nil, srv.Mock)
+		require.NoError(t, err)
+		assert.False(t, result.(*StorageDiff).Modified())
+	})
+
+	t.Run("Fail missing from", func(t *testing.T) {
+		_, err := storageDiff([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, nil, srv.Mock)
+		assert.EqualError(t, err, "provide the block height to compare from using the --from flag")
+	})
+}
+
+func Test_Sync(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+
+	service, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	privateKey, err := service.Key.PrivateKey()
+	require.NoError(t, err)
+
+	otherKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte("seedseedseedseedseedseedseedseedseedseed"))
+	require.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			account := tests.NewAccountWithAddress(service.Address.String())
+			account.Keys = []*flow.AccountKey{{
+				Index:     0,
+				PublicKey: (*privateKey).PublicKey(),
+				SigAlgo:   crypto.ECDSA_P256,
+				HashAlgo:  crypto.SHA3_256,
+				Weight:    1000,
+				Revoked:   true,
+			}, {
+				Index:     1,
+				PublicKey: otherKey.PublicKey(),
+				SigAlgo:   crypto.ECDSA_P256,
+				HashAlgo:  crypto.SHA3_256,
+				Weight:    1000,
+			}, {
+				Index:     2,
+				PublicKey: (*privateKey).PublicKey(),
+				SigAlgo:   crypto.ECDSA_P256,
+				HashAlgo:  crypto.SHA2_256,
+				Weight:    1000,
+			}}
+			srv.GetAccount.Return(account, nil)
+		})
+
+		result, err := syncAccount(
+			[]string{service.Name},
+			command.GlobalFlags{ConfigPaths: []string{"sync.json"}},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+		require.NoError(t, err)
+		sync := result.(*syncResult)
+		assert.Equal(t, 2, sync.matched.Index)
+		assert.Len(t, sync.changes, 2)
+
+		updated, err := state.Accounts().ByName(service.Name)
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated.Key.Index())
+		assert.Equal(t, crypto.SHA2_256, updated.Key.HashAlgo())
+	})
+
+	t.Run("No matching key", func(t *testing.T) {
+		account, err := state.Accounts().ByName(service.Name)
+		require.NoError(t, err)
+
+		sync, err := syncKey(account, []*flow.AccountKey{{
+			Index:     0,
+			PublicKey: otherKey.PublicKey(),
+			SigAlgo:   crypto.ECDSA_P256,
+			HashAlgo:  crypto.SHA3_256,
+			Weight:    1000,
+		}})
+		require.NoError(t, err)
+		assert.Nil(t, sync.matched)
+		assert.Empty(t, sync.changes)
+		assert.Equal(t, "No active key on the network matches the key of account emulator-account", sync.Oneliner())
+	})
+}
+
+func Test_Sequence(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(args.Get(1).(flow.Address).String())
+		account.Keys = []*flow.AccountKey{
+			{Index: 0, SequenceNumber: 12},
+			{Index: 1, SequenceNumber: 3},
+		}
+		srv.GetAccount.Return(account, nil)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		sequenceFlags.KeyIndex = -1
+		result, err := sequence([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "0: 12, 1: 3", result.Oneliner())
+	})
+
+	t.Run("Success key index", func(t *testing.T) {
+		sequenceFlags.KeyIndex = 1
+		result, err := sequence([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "1: 3", result.Oneliner())
+	})
+
+	t.Run("Fail missing key index", func(t *testing.T) {
+		sequenceFlags.KeyIndex = 5
+		_, err := sequence([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "key with index 5 not found on account 0000000000000001")
+	})
+
+	t.Run("Changes", func(t *testing.T) {
+		changes := sequenceChanges(
+			[]*flow.AccountKey{{Index: 0, SequenceNumber: 1}},
+			[]*flow.AccountKey{{Index: 0, SequenceNumber: 3}, {Index: 1, SequenceNumber: 0}},
+		)
+		assert.Equal(t, []string{
+			"key 0 sequence number 1 → 3",
+			"key 1 added with sequence number 0",
+		}, changes)
+	})
+}
+
+func Test_Subscribe(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+	address := flow.HexToAddress("0x01")
+
+	eventType := &cadence.EventType{
+		QualifiedIdentifier: "A.0ae53cb6e3f42a79.FlowToken.TokensDeposited",
+		Fields: []cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "to", Type: &cadence.OptionalType{Type: cadence.AddressType{}}},
+		},
+	}
+	deposit := func(to flow.Address) flow.Event {
+		return flow.Event{
+			Type: eventType.QualifiedIdentifier,
+			Value: cadence.NewEvent([]cadence.Value{
+				cadence.UFix64(100),
+				cadence.NewOptional(cadence.NewAddress(to)),
+			}).WithType(eventType),
+		}
+	}
+
+	latest := uint64(2)
+	srv.GetBlock.Run(func(args mock.Arguments) {
+		query := args.Get(1).(flowkit.BlockQuery)
+		block := tests.NewBlock()
+		block.Height = query.Height
+		if query.Latest {
+			block.Height = latest
+		}
+		block.ID = flow.Identifier{byte(block.Height)}
+		srv.GetBlock.Return(block, nil)
+	})
+	srv.GetTransactionsByBlockID.Run(func(args mock.Arguments) {
+		id := args.Get(1).(flow.Identifier)
+		events := []flow.Event{deposit(flow.HexToAddress("0x02"))}
+		if id[0]%2 == 1 {
+			events = append(events, deposit(address))
+		}
+		srv.GetTransactionsByBlockID.Return(nil, []*flow.TransactionResult{{Events: events}}, nil)
+	})
+
+	sub := &subscription{flow: srv.Mock, address: address, next: 0}
+
+	matched, err := sub.poll()
+	require.NoError(t, err)
+	require.Len(t, matched, 1)
+	assert.Equal(t, uint64(1), matched[0].height)
+	assert.Equal(t, uint64(3), sub.next)
+
+	latest = 3
+	matched, err = sub.poll()
+	require.NoError(t, err)
+	require.Len(t, matched, 1)
+	assert.Equal(t, uint64(3), matched[0].height)
+
+	t.Run("Nested references", func(t *testing.T) {
+		nested := cadence.NewArray([]cadence.Value{
+			cadence.NewDictionary([]cadence.KeyValuePair{{
+				Key:   cadence.String("owner"),
+				Value: cadence.NewOptional(cadence.NewAddress(address)),
+			}}),
+		})
+		assert.True(t, referencesAddress(nested, cadence.Address(address)))
+		assert.False(t, referencesAddress(nested, cadence.Address(flow.HexToAddress("0x02"))))
+		assert.False(t, referencesAddress(cadence.NewOptional(nil), cadence.Address(address)))
+	})
+}
+
+func Test_Import(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte("seedseedseedseedseedseedseedseedseedseed"))
+	require.NoError(t, err)
+	otherKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte("otherseedotherseedotherseedotherseedother"))
+	require.NoError(t, err)
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(args.Get(1).(flow.Address).String())
+		account.Keys = []*flow.AccountKey{{
+			Index:     0,
+			PublicKey: otherKey.PublicKey(),
+			SigAlgo:   crypto.ECDSA_P256,
+			HashAlgo:  crypto.SHA3_256,
+			Weight:    1000,
+		}, {
+			Index:     1,
+			PublicKey: privateKey.PublicKey(),
+			SigAlgo:   crypto.ECDSA_P256,
+			HashAlgo:  crypto.SHA2_256,
+			Weight:    1000,
+		}}
+		srv.GetAccount.Return(account, nil)
+	})
+
+	t.Run("Success from FCL wallet", func(t *testing.T) {
+		wallet := fmt.Sprintf(`{"address": "0x01", "privateKey": "%s", "keyId": 1}`, privateKey.String())
+		_ = rw.WriteFile("wallet.json", []byte(wallet), 0644)
+		importFlags = flagsImport{Name: "alice", FromFCL: "wallet.json", KeyIndex: -1, SigAlgo: "ECDSA_P256"}
+
+		_, err := importAccount([]string{}, command.GlobalFlags{ConfigPaths: []string{"import.json"}}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		account, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("0x01"), account.Address)
+		assert.Equal(t, 1, account.Key.Index())
+		assert.Equal(t, crypto.SHA2_256, account.Key.HashAlgo())
+		assert.Equal(t, "alice.pkey", account.Key.ToConfig().Location)
+
+		saved, err := rw.ReadFile("alice.pkey")
+		require.NoError(t, err)
+		assert.Equal(t, privateKey.String(), string(saved))
+	})
+
+	t.Run("Success from private key file", func(t *testing.T) {
+		_ = rw.WriteFile("bob.pkey", []byte(privateKey.String()+"\n"), 0600)
+		importFlags = flagsImport{Name: "bob", FromPkey: "bob.pkey", Address: "0x02", KeyIndex: -1, SigAlgo: "ECDSA_P256"}
+
+		_, err := importAccount([]string{}, command.GlobalFlags{ConfigPaths: []string{"import.json"}}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		account, err := state.Accounts().ByName("bob")
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("0x02"), account.Address)
+		assert.Equal(t, "bob.pkey", account.Key.ToConfig().Location)
+	})
+
+	t.Run("Fail key not on account", func(t *testing.T) {
+		_ = rw.WriteFile("carol.pkey", []byte(privateKey.String()), 0600)
+		importFlags = flagsImport{Name: "carol", FromPkey: "carol.pkey", Address: "0x03", KeyIndex: 0, SigAlgo: "ECDSA_P256"}
+
+		_, err := importAccount([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "the private key doesn't match the key 0 of account 0000000000000003")
+	})
+
+	t.Run("Fail both sources", func(t *testing.T) {
+		importFlags = flagsImport{Name: "dave", FromPkey: "carol.pkey", FromFCL: "wallet.json", KeyIndex: -1}
+
+		_, err := importAccount([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "provide exactly one of the --from-fcl or --from-pkey flags")
+	})
+
+	t.Run("Fail existing name", func(t *testing.T) {
+		importFlags = flagsImport{Name: "alice", FromPkey: "bob.pkey", Address: "0x02", KeyIndex: -1}
+
+		_, err := importAccount([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "account with name alice already exists in the configuration")
+	})
+}
+
+func Test_Export(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	service, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	privateKey, err := service.Key.PrivateKey()
+	require.NoError(t, err)
+
+	t.Run("Success without private key", func(t *testing.T) {
+		exportFlags = flagsExport{Format: "json"}
+		result, err := exportAccount([]string{service.Name}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		assert.NotContains(t, result.String(), "privateKey")
+		assert.Contains(t, result.String(), (*privateKey).PublicKey().String())
+		assert.Contains(t, result.String(), `"address": "0xf8d6e0586b0a20c7"`)
+	})
+
+	t.Run("Success env", func(t *testing.T) {
+		exportFlags = flagsExport{Format: "env", IncludePrivateKey: true}
+		result, err := exportAccount([]string{service.Name}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		assert.Contains(t, result.String(), "FLOW_EMULATOR_ACCOUNT_ADDRESS=0xf8d6e0586b0a20c7\n")
+		assert.Contains(t, result.String(), "FLOW_EMULATOR_ACCOUNT_KEY_INDEX=0\n")
+		assert.Contains(t, result.String(), fmt.Sprintf("FLOW_EMULATOR_ACCOUNT_PRIVATE_KEY=%s\n", (*privateKey).String()))
+	})
+
+	t.Run("Success FCL import round trip", func(t *testing.T) {
+		exportFlags = flagsExport{Format: "fcl", IncludePrivateKey: true}
+		result, err := exportAccount([]string{service.Name}, command.GlobalFlags{Yes: true}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		_ = rw.WriteFile("exported.json", []byte(result.String()), 0600)
+		source, err := readImportSource(rw, flagsImport{FromFCL: "exported.json", KeyIndex: -1, SigAlgo: "ECDSA_P256"})
+		require.NoError(t, err)
+		assert.Equal(t, service.Address, source.address)
+		assert.Equal(t, 0, source.keyIndex)
+		assert.True(t, (*privateKey).Equals(source.privateKey))
+	})
+
+	t.Run("Fail private key without confirmation", func(t *testing.T) {
+		exportFlags = flagsExport{Format: "json", IncludePrivateKey: true}
+		_, err := exportAccount([]string{service.Name}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "exporting the private key must be confirmed with the --yes flag")
+	})
+
+	t.Run("Fail invalid format", func(t *testing.T) {
+		exportFlags = flagsExport{Format: "yaml"}
+		_, err := exportAccount([]string{service.Name}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "unsupported export format yaml, valid formats are: fcl, json, env")
+	})
+}
+
+func Test_Diff(t *testing.T) {
+	_, state, _ := util.TestMocks(t)
+
+	testnetAddress := flow.HexToAddress("0x01")
+	mainnetAddress := flow.HexToAddress("0x02")
+	state.Accounts().AddOrUpdate(&accounts.Account{Name: "testnet-admin", Address: testnetAddress})
+	state.Accounts().AddOrUpdate(&accounts.Account{Name: "mainnet-admin", Address: mainnetAddress})
+
+	newKey := func(seed byte) *flow.AccountKey {
+		pk, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte(strings.Repeat(string(seed), 32)))
+		require.NoError(t, err)
+		return &flow.AccountKey{PublicKey: pk.PublicKey(), Weight: flow.AccountKeyWeightThreshold}
+	}
+	shared, testnetOnly := newKey('a'), newKey('b')
+
+	fetched := map[string]*flow.Account{
+		"testnet": {
+			Address:   testnetAddress,
+			Contracts: map[string][]byte{"Foo": []byte("pub contract Foo {}"), "Bar": []byte("pub contract Bar {}")},
+			Keys:      []*flow.AccountKey{shared, testnetOnly},
+		},
+		"mainnet": {
+			Address:   mainnetAddress,
+			Contracts: map[string][]byte{"Foo": []byte("pub contract Foo { pub fun fix() {} }"), "Bar": []byte("pub contract Bar {}")},
+			Keys:      []*flow.AccountKey{shared},
+		},
+	}
+	networkGateway = func(_ *flowkit.State, network config.Network) (gateway.Gateway, error) {
+		gw := gateway.NewMock()
+		gw.GetAccountFunc = func(_ context.Context, address flow.Address) (*flow.Account, error) {
+			account := fetched[network.Name]
+			assert.Equal(t, account.Address, address)
+			return account, nil
+		}
+		return gw, nil
+	}
+	t.Cleanup(func() {
+		networkGateway = func(_ *flowkit.State, network config.Network) (gateway.Gateway, error) {
+			return gateway.NewGrpcGateway(network)
+		}
+		diffFlags = flagsDiff{}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		diffFlags.Networks = []string{"testnet", "mainnet"}
+
+		result, err := diff([]string{"admin"}, command.GlobalFlags{}, util.NoLogger, nil, state)
+		require.NoError(t, err)
+
+		r := result.(*accountDiffResult)
+		require.Len(t, r.contracts, 2)
+		assert.Equal(t, "Bar", r.contracts[0].name)
+		assert.False(t, r.contracts[0].drifted())
+		assert.Equal(t, "Foo", r.contracts[1].name)
+		assert.True(t, r.contracts[1].drifted())
+
+		require.Len(t, r.keys, 2)
+		assert.Equal(t, 1, countDrifted(r.keys))
+		assert.Equal(t, "Networks: testnet,mainnet, Drifted Contracts: 1, Drifted Keys: 1", r.Oneliner())
+	})
+
+	t.Run("Fail missing account", func(t *testing.T) {
+		diffFlags.Networks = []string{"testnet", "emulator"}
+
+		_, err := diff([]string{"admin"}, command.GlobalFlags{}, util.NoLogger, nil, state)
+		assert.EqualError(t, err, "account for network emulator not found, add an account named emulator-admin or admin to the configuration")
+	})
+
+	t.Run("Fail single network", func(t *testing.T) {
+		diffFlags.Networks = []string{"testnet"}
+
+		_, err := diff([]string{"admin"}, command.GlobalFlags{}, util.NoLogger, nil, state)
+		assert.EqualError(t, err, "provide at least two networks to compare using the --networks flag")
+	})
+}
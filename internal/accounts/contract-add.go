@@ -32,6 +32,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit/arguments"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/history"
 )
 
 type deployContractFlags struct {
@@ -101,6 +102,16 @@ func deployContract(update bool, flags *deployContractFlags) command.RunWithStat
 			deployFunc,
 		)
 
+		_ = history.RecordTransaction(
+			state.ReaderWriter(),
+			map[bool]string{true: "accounts update-contract", false: "accounts add-contract"}[update],
+			flow.Network(),
+			fmt.Sprintf("%s to account 0x%s", filename, to.Address),
+			txID,
+			nil,
+			err,
+		)
+
 		if err != nil {
 			if txID != flowsdk.EmptyID {
 				logger.Info(fmt.Sprintf(
@@ -114,8 +125,13 @@ func deployContract(update bool, flags *deployContractFlags) command.RunWithStat
 			return nil, err
 		}
 
-		err = state.SaveDefault()
+		unlock, err := state.LockConfig()
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
 
+		err = state.SaveDefault()
 		if err != nil {
 			return nil, err
 		}
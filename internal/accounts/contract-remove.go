@@ -29,6 +29,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/history"
 )
 
 type flagsRemoveContract struct {
@@ -65,6 +66,17 @@ func removeContract(
 	}
 
 	id, err := flow.RemoveContract(context.Background(), from, contractName)
+
+	_ = history.RecordTransaction(
+		state.ReaderWriter(),
+		"accounts remove-contract",
+		flow.Network(),
+		fmt.Sprintf("%s from account 0x%s", contractName, from.Address),
+		id,
+		nil,
+		err,
+	)
+
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +84,12 @@ func removeContract(
 	removeFromState := util.RemoveContractFromFlowJSONPrompt(contractName)
 
 	if removeFromState {
+		unlock, err := state.LockConfig()
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+
 		// If a network flag is provided, remove from that networks deployments
 		// Otherwise, remove from all deployments
 		if flagsRemove.Network != "" {
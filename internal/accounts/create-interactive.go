@@ -23,10 +23,10 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
@@ -34,13 +34,12 @@ import (
 
 	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -48,14 +47,14 @@ import (
 //
 // This process takes the user through couple of steps with prompts asking for them to provide name and network,
 // and it then uses account creation APIs to automatically create the account on the network as well as save it.
-func createInteractive(state *flowkit.State) error {
+func createInteractive(state *flowkit.State, template string) error {
 	log := output.NewStdoutLogger(output.InfoLog)
 	name := util.AccountNamePrompt(state.Accounts().Names())
 	networkName, selectedNetwork := util.CreateAccountNetworkPrompt()
 	privateFile := fmt.Sprintf("%s.pkey", name)
 
 	// create new gateway based on chosen network
-	gw, err := gateway.NewGrpcGateway(selectedNetwork)
+	gw, err := command.GatewayFactory(state, state.ReaderWriter())(selectedNetwork)
 	if err != nil {
 		return err
 	}
@@ -69,10 +68,13 @@ func createInteractive(state *flowkit.State) error {
 	log.StartProgress(fmt.Sprintf("Creating account %s on %s...", name, networkName))
 
 	var account *accounts.Account
-	if selectedNetwork == config.EmulatorNetwork {
-		account, err = createEmulatorAccount(state, flow, name, key)
+	if selectedNetwork.Name == config.EmulatorNetwork.Name {
+		account, err = createEmulatorAccount(state, flow, name, key, template)
 		log.StopProgress()
 		log.Info(output.Italic("\nPlease note that the newly-created account will only be available while you keep the emulator service running. If you restart the emulator service, all accounts will be reset. If you want to persist accounts between restarts, please use the '--persist' flag when starting the flow emulator.\n"))
+	} else if template != "" {
+		log.StopProgress()
+		return fmt.Errorf("account templates are only supported on the emulator when creating the account interactively")
 	} else {
 		account, err = createNetworkAccount(state, flow, name, key, privateFile, selectedNetwork)
 		log.StopProgress()
@@ -89,6 +91,12 @@ func createInteractive(state *flowkit.State) error {
 		output.Bold(networkName)),
 	)
 
+	unlock, err := state.LockConfig()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	state.Accounts().AddOrUpdate(account)
 	err = state.SaveDefault()
 	if err != nil {
@@ -99,7 +107,7 @@ func createInteractive(state *flowkit.State) error {
 		"Here’s a summary of all the actions that were taken",
 		fmt.Sprintf("Added the new account to %s.", output.Bold("flow.json")),
 	}
-	if selectedNetwork != config.EmulatorNetwork {
+	if selectedNetwork.Name != config.EmulatorNetwork.Name {
 		items = append(items,
 			fmt.Sprintf("Saved the private key to %s.", output.Bold(privateFile)),
 			fmt.Sprintf("Added %s to %s.", output.Bold(privateFile), output.Bold(".gitignore")),
@@ -144,7 +152,7 @@ func createNetworkAccount(
 		return nil, err
 	}
 
-	err = state.ReaderWriter().WriteFile(privateFile, []byte(key.String()), os.FileMode(0644))
+	err = util.WritePrivateFile(privateFile, []byte(key.String()), state.ReaderWriter())
 	if err != nil {
 		return nil, fmt.Errorf("failed saving private key: %w", err)
 	}
@@ -161,14 +169,16 @@ func createEmulatorAccount(
 	flow flowkit.Services,
 	name string,
 	key crypto.PrivateKey,
+	template string,
 ) (*accounts.Account, error) {
 	signer, err := state.EmulatorServiceAccount()
 	if err != nil {
 		return nil, err
 	}
 
-	networkAccount, _, err := flow.CreateAccount(
-		context.Background(),
+	networkAccount, _, err := createAccount(
+		state,
+		flow,
 		signer,
 		[]accounts.PublicKey{{
 			Public:   key.PublicKey(),
@@ -176,6 +186,7 @@ func createEmulatorAccount(
 			SigAlgo:  defaultSignAlgo,
 			HashAlgo: defaultHashAlgo,
 		}},
+		template,
 	)
 	if err != nil {
 		return nil, err
@@ -191,7 +202,7 @@ func createEmulatorAccount(
 func getAccountCreationResult(flow flowkit.Services, id flowsdk.Identifier) (*flowsdk.TransactionResult, error) {
 	_, result, err := flow.GetTransactionByID(context.Background(), id, true)
 	if err != nil {
-		if status.Code(err) == codes.NotFound { // if transaction not yet propagated, wait for it
+		if errors.Is(err, gateway.ErrNotFound) { // if transaction not yet propagated, wait for it
 			time.Sleep(1 * time.Second)
 			return getAccountCreationResult(flow, id)
 		}
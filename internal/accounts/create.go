@@ -19,7 +19,6 @@
 package accounts
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -31,6 +30,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/history"
 )
 
 type flagsCreate struct {
@@ -40,15 +40,25 @@ type flagsCreate struct {
 	SigAlgo  []string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm used to generate the keys"`
 	HashAlgo []string `default:"SHA3_256" flag:"hash-algo" info:"Hash used for the digest"`
 	Include  []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Template string   `default:"" flag:"template" info:"Account template name or path to a template file, setting up the account in the creation transaction"`
 }
 
 var createFlags = flagsCreate{}
 
 var createCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "create",
-		Short:   "Create a new account on network",
-		Example: `flow accounts create --key d651f1931a2...8745`,
+		Use:   "create",
+		Short: "Create a new account on network",
+		Long: `Create a new account on network.
+
+The account can be set up in the creation transaction using a template, for example to link a collection
+so the account is immediately usable. A template is the path to a project template file, declaring the
+function setup(account: AuthAccount) with the imports it uses, or one of the templates:
+
+` + templatesHelp(),
+		Example: `flow accounts create --key d651f1931a2...8745
+flow accounts create --key d651f1931a2...8745 --template nft-user
+flow accounts create --key d651f1931a2...8745 --template ./templates/marketplace-user.cdc`,
 	},
 	Flags: &createFlags,
 	RunS:  create,
@@ -67,7 +77,7 @@ func create(
 	weightFlag := createFlags.Weights
 
 	if len(keysFlag) == 0 { // if user doesn't provide any flags go into interactive mode
-		return nil, createInteractive(state)
+		return nil, createInteractive(state, createFlags.Template)
 	}
 
 	signer, err := state.Accounts().ByName(createFlags.Signer)
@@ -113,11 +123,14 @@ func create(
 		}
 	}
 
-	account, _, err := flow.CreateAccount(
-		context.Background(),
-		signer,
-		keys,
-	)
+	account, id, err := createAccount(state, flow, signer, keys, createFlags.Template)
+
+	details := ""
+	if account != nil {
+		details = fmt.Sprintf("created account 0x%s", account.Address)
+	}
+	_ = history.RecordTransaction(state.ReaderWriter(), "accounts create", flow.Network(), details, id, nil, err)
+
 	if err != nil {
 		return nil, err
 	}
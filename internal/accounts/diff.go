@@ -0,0 +1,305 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsDiff struct {
+	Networks []string `default:"" flag:"networks" info:"Networks to compare the account on, e.g. testnet,mainnet"`
+}
+
+var diffFlags = flagsDiff{}
+
+var diffCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "diff <name>",
+		Short: "Compare the contracts and keys of an account across networks",
+		Long: "Compare the contracts and keys of an account across networks.\n\n" +
+			"The account is resolved on each network from the account named <network>-<name> in the configuration, " +
+			"or from the account named <name> if there is no network specific account.",
+		Example: "flow accounts diff admin --networks testnet,mainnet",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &diffFlags,
+	RunS:  diff,
+}
+
+// networkGateway creates the gateway used to fetch the account on the network.
+var networkGateway = func(state *flowkit.State, network config.Network) (gateway.Gateway, error) {
+	return command.GatewayFactory(state, state.ReaderWriter())(network)
+}
+
+func diff(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if len(diffFlags.Networks) < 2 {
+		return nil, fmt.Errorf("provide at least two networks to compare using the --networks flag")
+	}
+
+	accounts := make([]*flowsdk.Account, 0, len(diffFlags.Networks))
+	for _, name := range diffFlags.Networks {
+		network, err := state.Networks().ByName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		address, err := diffAccountAddress(state, args[0], network.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		gw, err := networkGateway(state, *network)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.StartProgress(fmt.Sprintf("Loading account 0x%s on %s...", address, network.Name))
+		account, err := gw.GetAccount(context.Background(), address)
+		logger.StopProgress()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account 0x%s on %s: %w", address, network.Name, err)
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return diffAccounts(diffFlags.Networks, accounts), nil
+}
+
+// diffAccountAddress resolves the address of the account on the network, the network specific
+// account named <network>-<name> is used if configured, otherwise the account named <name>.
+func diffAccountAddress(state *flowkit.State, name string, network string) (flowsdk.Address, error) {
+	for _, accountName := range []string{fmt.Sprintf("%s-%s", network, name), name} {
+		if account, err := state.Accounts().ByName(accountName); err == nil {
+			return account.Address, nil
+		}
+	}
+
+	return flowsdk.EmptyAddress, fmt.Errorf(
+		"account for network %s not found, add an account named %s-%s or %s to the configuration",
+		network,
+		network,
+		name,
+		name,
+	)
+}
+
+// codeHash returns the hex encoded SHA2-256 hash of the contract code.
+func codeHash(code []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(code))
+}
+
+// keyState describes the account key properties compared across networks.
+func keyState(key *flowsdk.AccountKey) string {
+	if key.Revoked {
+		return "revoked"
+	}
+	return fmt.Sprintf("weight %d", key.Weight)
+}
+
+// drift is a contract or a key compared across networks, values are empty on the networks it's missing on.
+type drift struct {
+	name   string
+	values []string
+}
+
+// drifted returns true if the value is not the same on all the networks.
+func (d drift) drifted() bool {
+	for _, v := range d.values {
+		if v != d.values[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// diffAccounts compares the contracts and keys of the accounts fetched from the networks.
+func diffAccounts(networks []string, accounts []*flowsdk.Account) *accountDiffResult {
+	contracts := make(map[string][]string)
+	keys := make(map[string][]string)
+
+	for i, account := range accounts {
+		for name, code := range account.Contracts {
+			if _, ok := contracts[name]; !ok {
+				contracts[name] = make([]string, len(accounts))
+			}
+			contracts[name][i] = codeHash(code)
+		}
+
+		for _, key := range account.Keys {
+			id := fmt.Sprintf("%x", key.PublicKey.Encode())
+			if _, ok := keys[id]; !ok {
+				keys[id] = make([]string, len(accounts))
+			}
+			keys[id][i] = keyState(key)
+		}
+	}
+
+	addresses := make([]flowsdk.Address, len(accounts))
+	for i, account := range accounts {
+		addresses[i] = account.Address
+	}
+
+	return &accountDiffResult{
+		networks:  networks,
+		addresses: addresses,
+		contracts: sortedDrifts(contracts),
+		keys:      sortedDrifts(keys),
+	}
+}
+
+func sortedDrifts(values map[string][]string) []drift {
+	drifts := make([]drift, 0, len(values))
+	for name, v := range values {
+		drifts = append(drifts, drift{name: name, values: v})
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].name < drifts[j].name
+	})
+	return drifts
+}
+
+type accountDiffResult struct {
+	networks  []string
+	addresses []flowsdk.Address
+	contracts []drift
+	keys      []drift
+}
+
+func countDrifted(drifts []drift) int {
+	count := 0
+	for _, d := range drifts {
+		if d.drifted() {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *accountDiffResult) JSON() any {
+	toJSON := func(drifts []drift) []map[string]any {
+		result := make([]map[string]any, 0, len(drifts))
+		for _, d := range drifts {
+			values := make(map[string]string)
+			for i, network := range r.networks {
+				values[network] = d.values[i]
+			}
+			result = append(result, map[string]any{
+				"name":    d.name,
+				"values":  values,
+				"drifted": d.drifted(),
+			})
+		}
+		return result
+	}
+
+	addresses := make(map[string]string)
+	for i, network := range r.networks {
+		addresses[network] = r.addresses[i].Hex()
+	}
+
+	return map[string]any{
+		"addresses": addresses,
+		"contracts": toJSON(r.contracts),
+		"keys":      toJSON(r.keys),
+	}
+}
+
+func (r *accountDiffResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	writeRow := func(name string, values []string, drifted bool) {
+		marker := " "
+		if drifted {
+			marker = output.Red("~")
+		}
+		_, _ = fmt.Fprintf(writer, "%s %s\t%s\n", marker, name, strings.Join(values, "\t"))
+	}
+	orDash := func(value string) string {
+		if value == "" {
+			return "-"
+		}
+		return value
+	}
+	shorten := func(value string) string {
+		if len(value) > 16 {
+			return value[:16]
+		}
+		return orDash(value)
+	}
+
+	addresses := make([]string, len(r.addresses))
+	for i, address := range r.addresses {
+		addresses[i] = "0x" + address.Hex()
+	}
+	_, _ = fmt.Fprintf(writer, "  Network\t%s\n", strings.Join(r.networks, "\t"))
+	_, _ = fmt.Fprintf(writer, "  Address\t%s\n", strings.Join(addresses, "\t"))
+
+	_, _ = fmt.Fprintf(writer, "\nContracts\n")
+	for _, c := range r.contracts {
+		values := make([]string, len(c.values))
+		for i, v := range c.values {
+			values[i] = shorten(v)
+		}
+		writeRow(c.name, values, c.drifted())
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nKeys\n")
+	for _, k := range r.keys {
+		values := make([]string, len(k.values))
+		for i, v := range k.values {
+			values[i] = orDash(v)
+		}
+		writeRow(shorten(k.name), values, k.drifted())
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *accountDiffResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Networks: %s, Drifted Contracts: %d, Drifted Keys: %d",
+		strings.Join(r.networks, ","),
+		countDrifted(r.contracts),
+		countDrifted(r.keys),
+	)
+}
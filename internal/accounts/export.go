@@ -0,0 +1,194 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsExport struct {
+	Format            string `default:"json" flag:"format" info:"Export format (fcl, json, env)"`
+	IncludePrivateKey bool   `default:"false" flag:"include-private-key" info:"Include the private key in the export, requires --yes"`
+}
+
+var exportFlags = flagsExport{}
+
+var exportCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "export <name>",
+		Short: "Export an account from the configuration to a portable format",
+		Example: `flow accounts export alice --format fcl --save alice.json
+flow accounts export alice --format env --include-private-key --yes`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &exportFlags,
+	RunS:  exportAccount,
+}
+
+const (
+	exportFormatFCL  = "fcl"
+	exportFormatJSON = "json"
+	exportFormatEnv  = "env"
+)
+
+func exportAccount(
+	args []string,
+	globalFlags command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	switch exportFlags.Format {
+	case exportFormatFCL, exportFormatJSON, exportFormatEnv:
+	default:
+		return nil, fmt.Errorf("unsupported export format %s, valid formats are: fcl, json, env", exportFlags.Format)
+	}
+	if exportFlags.IncludePrivateKey && !globalFlags.Yes {
+		return nil, fmt.Errorf("exporting the private key must be confirmed with the --yes flag")
+	}
+
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return newExportResult(account, exportFlags.Format, exportFlags.IncludePrivateKey)
+}
+
+// exportResult is a sanitized account descriptor, the private key is only included when explicitly requested.
+type exportResult struct {
+	format     string
+	name       string
+	address    string
+	keyType    string
+	keyIndex   int
+	sigAlgo    string
+	hashAlgo   string
+	publicKey  string
+	privateKey string
+}
+
+func newExportResult(account *accounts.Account, format string, includePrivateKey bool) (*exportResult, error) {
+	result := &exportResult{
+		format:   format,
+		name:     account.Name,
+		address:  "0x" + account.Address.Hex(),
+		keyType:  string(account.Key.Type()),
+		keyIndex: account.Key.Index(),
+		sigAlgo:  account.Key.SigAlgo().String(),
+		hashAlgo: account.Key.HashAlgo().String(),
+	}
+
+	// the public key is only known for keys with an accessible private key, KMS keys never expose it
+	privateKey, err := account.Key.PrivateKey()
+	if err == nil && privateKey != nil && *privateKey != nil {
+		result.publicKey = (*privateKey).PublicKey().String()
+		if includePrivateKey {
+			result.privateKey = (*privateKey).String()
+		}
+	} else if includePrivateKey {
+		return nil, fmt.Errorf("private key of account %s can not be exported from key type %s", account.Name, account.Key.Type())
+	}
+
+	return result, nil
+}
+
+func (r *exportResult) descriptor() map[string]any {
+	if r.format == exportFormatFCL {
+		// same format accepted by accounts import --from-fcl
+		descriptor := map[string]any{
+			"address":            r.address,
+			"keyId":              r.keyIndex,
+			"signatureAlgorithm": r.sigAlgo,
+			"hashAlgorithm":      r.hashAlgo,
+		}
+		if r.publicKey != "" {
+			descriptor["publicKey"] = r.publicKey
+		}
+		if r.privateKey != "" {
+			descriptor["privateKey"] = r.privateKey
+		}
+		return descriptor
+	}
+
+	key := map[string]any{
+		"type":               r.keyType,
+		"index":              r.keyIndex,
+		"signatureAlgorithm": r.sigAlgo,
+		"hashAlgorithm":      r.hashAlgo,
+	}
+	if r.publicKey != "" {
+		key["publicKey"] = r.publicKey
+	}
+	if r.privateKey != "" {
+		key["privateKey"] = r.privateKey
+	}
+	return map[string]any{
+		"name":    r.name,
+		"address": r.address,
+		"key":     key,
+	}
+}
+
+var envNameSanitizer = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// env formats the account as environment variables prefixed with the account name, e.g. FLOW_ALICE_ADDRESS.
+func (r *exportResult) env() string {
+	prefix := fmt.Sprintf("FLOW_%s_", envNameSanitizer.ReplaceAllString(strings.ToUpper(r.name), "_"))
+	lines := []string{
+		fmt.Sprintf("%sADDRESS=%s", prefix, r.address),
+		fmt.Sprintf("%sKEY_INDEX=%d", prefix, r.keyIndex),
+		fmt.Sprintf("%sSIG_ALGO=%s", prefix, r.sigAlgo),
+		fmt.Sprintf("%sHASH_ALGO=%s", prefix, r.hashAlgo),
+	}
+	if r.publicKey != "" {
+		lines = append(lines, fmt.Sprintf("%sPUBLIC_KEY=%s", prefix, r.publicKey))
+	}
+	if r.privateKey != "" {
+		lines = append(lines, fmt.Sprintf("%sPRIVATE_KEY=%s", prefix, r.privateKey))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (r *exportResult) JSON() any {
+	return r.descriptor()
+}
+
+func (r *exportResult) String() string {
+	if r.format == exportFormatEnv {
+		return r.env()
+	}
+
+	data, _ := json.MarshalIndent(r.descriptor(), "", "  ")
+	return string(data)
+}
+
+func (r *exportResult) Oneliner() string {
+	return fmt.Sprintf("Address: %s, Key Index: %d", r.address, r.keyIndex)
+}
@@ -31,7 +31,8 @@ import (
 )
 
 type flagsGet struct {
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	Include     []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	BlockHeight uint64   `default:"0" flag:"block-height" info:"Block height to get the account at, defaults to the latest sealed block"`
 }
 
 var getFlags = flagsGet{}
@@ -40,7 +41,7 @@ var getCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "get <address>",
 		Short:   "Gets an account by address",
-		Example: "flow accounts get f8d6e0586b0a20c7",
+		Example: "flow accounts get f8d6e0586b0a20c7\nflow accounts get 0x1654653399040a61 --block-height 54000000 --network mainnet",
 		Args:    cobra.ExactArgs(1),
 	},
 	Flags: &getFlags,
@@ -59,7 +60,13 @@ func get(
 	logger.StartProgress(fmt.Sprintf("Loading account %s...", address))
 	defer logger.StopProgress()
 
-	account, err := flow.GetAccount(context.Background(), address)
+	var account *flowsdk.Account
+	var err error
+	if getFlags.BlockHeight != 0 {
+		account, err = flow.GetAccountAtBlockHeight(context.Background(), address, getFlags.BlockHeight)
+	} else {
+		account, err = flow.GetAccount(context.Background(), address)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,245 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsImport struct {
+	Name     string `default:"" flag:"name" info:"Name of the account in the configuration"`
+	FromFCL  string `default:"" flag:"from-fcl" info:"Import the account from a FCL wallet JSON file"`
+	FromPkey string `default:"" flag:"from-pkey" info:"Import the account using the hex encoded private key stored in the file"`
+	Address  string `default:"" flag:"address" info:"Account address, required with --from-pkey"`
+	KeyIndex int    `default:"-1" flag:"key-index" info:"Index of the account key, by default the key is found by matching the public key"`
+	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the private key"`
+}
+
+var importFlags = flagsImport{}
+
+var importCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "import",
+		Short: "Import an existing account to the configuration",
+		Example: `flow accounts import --from-fcl wallet.json --name alice --network testnet
+flow accounts import --from-pkey alice.pkey --address 0x7e60df042a9c0868 --name alice --network testnet`,
+		Args: cobra.NoArgs,
+	},
+	Flags: &importFlags,
+	RunS:  importAccount,
+}
+
+// fclAccount is the account format used by FCL wallets like the dev-wallet.
+type fclAccount struct {
+	Address            string `json:"address"`
+	PrivateKey         string `json:"privateKey"`
+	KeyID              *int   `json:"keyId"`
+	KeyIndex           *int   `json:"keyIndex"`
+	SignatureAlgorithm string `json:"signatureAlgorithm"`
+}
+
+// importSource is the key material and address read from the import flags.
+type importSource struct {
+	address    flowsdk.Address
+	privateKey crypto.PrivateKey
+	keyIndex   int
+	// keyFile is the location of the private key file, the key is saved to a new file if empty
+	keyFile string
+}
+
+func importAccount(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if importFlags.Name == "" {
+		return nil, fmt.Errorf("the --name flag is required")
+	}
+	if _, err := state.Accounts().ByName(importFlags.Name); err == nil {
+		return nil, fmt.Errorf("account with name %s already exists in the configuration", importFlags.Name)
+	}
+
+	source, err := readImportSource(state.ReaderWriter(), importFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Validating key for account %s...", source.address))
+	onChain, err := flow.GetAccount(context.Background(), source.address)
+	logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := matchImportKey(source, onChain.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if key.Weight < flowsdk.AccountKeyWeightThreshold {
+		logger.Info(fmt.Sprintf(
+			"%s warning: key %d has weight %d and can't sign transactions alone",
+			output.WarningEmoji(),
+			key.Index,
+			key.Weight,
+		))
+	}
+
+	keyFile := source.keyFile
+	if keyFile == "" {
+		keyFile = fmt.Sprintf("%s.pkey", importFlags.Name)
+		err = util.WritePrivateFile(keyFile, []byte(source.privateKey.String()), state.ReaderWriter())
+		if err != nil {
+			return nil, fmt.Errorf("failed saving private key: %w", err)
+		}
+		err = util.AddToGitIgnore(keyFile, state.ReaderWriter())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unlock, err := state.LockConfig()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	account := &accounts.Account{
+		Name:    importFlags.Name,
+		Address: source.address,
+		Key:     accounts.NewFileKey(keyFile, key.Index, key.SigAlgo, key.HashAlgo),
+	}
+	state.Accounts().AddOrUpdate(account)
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResult{Account: onChain}, nil
+}
+
+// readImportSource reads the account address and private key from the FCL wallet file or the private key file.
+func readImportSource(reader flowkit.ReaderWriter, flags flagsImport) (*importSource, error) {
+	if (flags.FromFCL == "") == (flags.FromPkey == "") {
+		return nil, fmt.Errorf("provide exactly one of the --from-fcl or --from-pkey flags")
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(flags.SigAlgo)
+	source := &importSource{
+		address:  flowsdk.HexToAddress(flags.Address),
+		keyIndex: flags.KeyIndex,
+	}
+
+	var privateKey string
+	if flags.FromPkey != "" {
+		if flags.Address == "" {
+			return nil, fmt.Errorf("the --address flag is required with --from-pkey")
+		}
+
+		data, err := reader.ReadFile(flags.FromPkey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key file: %w", err)
+		}
+		privateKey = string(data)
+		source.keyFile = flags.FromPkey
+	} else {
+		data, err := reader.ReadFile(flags.FromFCL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read FCL wallet file: %w", err)
+		}
+
+		var wallet fclAccount
+		if err := json.Unmarshal(data, &wallet); err != nil {
+			return nil, fmt.Errorf("failed to parse FCL wallet file: %w", err)
+		}
+		if wallet.PrivateKey == "" {
+			return nil, fmt.Errorf("FCL wallet file doesn't contain a private key")
+		}
+
+		privateKey = wallet.PrivateKey
+		if flags.Address == "" {
+			source.address = flowsdk.HexToAddress(wallet.Address)
+		}
+		if source.keyIndex < 0 && wallet.KeyID != nil {
+			source.keyIndex = *wallet.KeyID
+		}
+		if source.keyIndex < 0 && wallet.KeyIndex != nil {
+			source.keyIndex = *wallet.KeyIndex
+		}
+		if wallet.SignatureAlgorithm != "" {
+			sigAlgo = crypto.StringToSignatureAlgorithm(wallet.SignatureAlgorithm)
+		}
+	}
+
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm")
+	}
+	if source.address == flowsdk.EmptyAddress {
+		return nil, fmt.Errorf("account address is missing")
+	}
+
+	key, err := crypto.DecodePrivateKeyHex(sigAlgo, strings.TrimPrefix(strings.TrimSpace(privateKey), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	source.privateKey = key
+
+	return source, nil
+}
+
+// matchImportKey finds the active account key with the public key of the imported private key.
+func matchImportKey(source *importSource, keys []*flowsdk.AccountKey) (*flowsdk.AccountKey, error) {
+	publicKey := source.privateKey.PublicKey()
+	revoked := false
+	for _, key := range keys {
+		if source.keyIndex >= 0 && key.Index != source.keyIndex {
+			continue
+		}
+		if !key.PublicKey.Equals(publicKey) {
+			continue
+		}
+		if key.Revoked {
+			revoked = true
+			continue
+		}
+		return key, nil
+	}
+
+	if revoked {
+		return nil, fmt.Errorf("the private key matches only revoked keys of account %s", source.address)
+	}
+	if source.keyIndex >= 0 {
+		return nil, fmt.Errorf("the private key doesn't match the key %d of account %s", source.keyIndex, source.address)
+	}
+	return nil, fmt.Errorf("the private key doesn't match any key of account %s", source.address)
+}
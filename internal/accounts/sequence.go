@@ -0,0 +1,190 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsSequence struct {
+	KeyIndex int  `default:"-1" flag:"key-index" info:"Only show the sequence number of the key at the index"`
+	Watch    bool `default:"false" flag:"watch" info:"Keep polling and print the sequence numbers when they change"`
+	Interval int  `default:"2" flag:"interval" info:"Polling interval in seconds used with --watch"`
+}
+
+var sequenceFlags = flagsSequence{}
+
+var sequenceCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "sequence <address|name>",
+		Short:   "Show the sequence numbers of the account keys",
+		Example: "flow accounts sequence f8d6e0586b0a20c7 --key-index 0 --watch",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &sequenceFlags,
+	Run:   sequence,
+}
+
+func sequence(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	address := flowsdk.HexToAddress(args[0])
+	// project configuration is optional, it is only used for resolving account names
+	if state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter); err == nil {
+		if account, err := state.Accounts().ByName(args[0]); err == nil {
+			address = account.Address
+		}
+	}
+
+	keys, err := keySequences(flow, address, sequenceFlags.KeyIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sequenceFlags.Watch {
+		return &sequenceResult{address: address, keys: keys}, nil
+	}
+
+	if sequenceFlags.Interval <= 0 {
+		return nil, fmt.Errorf("the --interval must be a positive number of seconds")
+	}
+
+	logger.Info(fmt.Sprintf("Watching sequence numbers of account %s, press Ctrl+C to stop\n", address))
+	logger.Info((&sequenceResult{address: address, keys: keys}).String())
+
+	ticker := time.NewTicker(time.Duration(sequenceFlags.Interval) * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		current, err := keySequences(flow, address, sequenceFlags.KeyIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, change := range sequenceChanges(keys, current) {
+			logger.Info(fmt.Sprintf("%s %s", time.Now().Format("15:04:05"), change))
+		}
+		keys = current
+	}
+
+	return nil, nil
+}
+
+// keySequences returns the keys of the account, or only the key at the index if the index is not negative.
+func keySequences(flow flowkit.Services, address flowsdk.Address, keyIndex int) ([]*flowsdk.AccountKey, error) {
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyIndex < 0 {
+		return account.Keys, nil
+	}
+
+	for _, key := range account.Keys {
+		if key.Index == keyIndex {
+			return []*flowsdk.AccountKey{key}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("key with index %d not found on account %s", keyIndex, address)
+}
+
+// sequenceChanges describes the sequence number changes between the previous and current keys.
+func sequenceChanges(previous []*flowsdk.AccountKey, current []*flowsdk.AccountKey) []string {
+	sequences := make(map[int]uint64, len(previous))
+	for _, key := range previous {
+		sequences[key.Index] = key.SequenceNumber
+	}
+
+	changes := make([]string, 0)
+	for _, key := range current {
+		before, ok := sequences[key.Index]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("key %d added with sequence number %d", key.Index, key.SequenceNumber))
+		case before != key.SequenceNumber:
+			changes = append(changes, fmt.Sprintf("key %d sequence number %d → %d", key.Index, before, key.SequenceNumber))
+		}
+	}
+
+	return changes
+}
+
+type sequenceResult struct {
+	address flowsdk.Address
+	keys    []*flowsdk.AccountKey
+}
+
+func (r *sequenceResult) JSON() any {
+	keys := make([]map[string]any, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, map[string]any{
+			"index":          key.Index,
+			"sequenceNumber": key.SequenceNumber,
+			"revoked":        key.Revoked,
+		})
+	}
+
+	return map[string]any{
+		"address": r.address.String(),
+		"keys":    keys,
+	}
+}
+
+func (r *sequenceResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Key Index\tSequence Number\tRevoked\n")
+	for _, key := range r.keys {
+		_, _ = fmt.Fprintf(writer, "%d\t%d\t%t\n", key.Index, key.SequenceNumber, key.Revoked)
+	}
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (r *sequenceResult) Oneliner() string {
+	var b bytes.Buffer
+	for i, key := range r.keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		_, _ = fmt.Fprintf(&b, "%d: %d", key.Index, key.SequenceNumber)
+	}
+
+	return b.String()
+}
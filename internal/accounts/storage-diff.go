@@ -0,0 +1,345 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsStorageDiff struct {
+	From string `flag:"from" info:"Block height to compare storage from"`
+	To   uint64 `flag:"to" info:"Block height to compare storage to, defaults to the latest block"`
+}
+
+var storageDiffFlags = flagsStorageDiff{}
+
+var storageDiffCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "storage-diff <address>",
+		Short:   "Show storage changes of an account between two block heights",
+		Example: "flow accounts storage-diff f8d6e0586b0a20c7 --from 10 --to 20",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &storageDiffFlags,
+	Run:   storageDiff,
+}
+
+// storageScript returns the account balance, used storage and the type and value stored at each storage path.
+//
+// Resources can't be copied out of storage, so references to them are returned, which are exported as the resources.
+const storageScript = `
+pub struct StorageInfo {
+	pub let balance: UFix64
+	pub let used: UInt64
+	pub let paths: {String: String}
+	pub let values: {String: AnyStruct}
+
+	init(balance: UFix64, used: UInt64, paths: {String: String}, values: {String: AnyStruct}) {
+		self.balance = balance
+		self.used = used
+		self.paths = paths
+		self.values = values
+	}
+}
+
+pub fun main(address: Address): StorageInfo {
+	let account = getAuthAccount(address)
+	let paths: {String: String} = {}
+	let values: {String: AnyStruct} = {}
+
+	account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+		paths[path.toString()] = type.identifier
+		if type.isSubtype(of: Type<@AnyResource>()) {
+			values[path.toString()] = account.borrow<&AnyResource>(from: path)
+		} else {
+			values[path.toString()] = account.copy<AnyStruct>(from: path)
+		}
+		return true
+	})
+
+	return StorageInfo(balance: account.balance, used: account.storageUsed, paths: paths, values: values)
+}
+`
+
+func storageDiff(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	address := flowsdk.HexToAddress(args[0])
+
+	if storageDiffFlags.From == "" {
+		return nil, fmt.Errorf("provide the block height to compare from using the --from flag")
+	}
+	fromHeight, err := strconv.ParseUint(storageDiffFlags.From, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --from block height %s", storageDiffFlags.From)
+	}
+	if storageDiffFlags.To != 0 && storageDiffFlags.To < fromHeight {
+		return nil, fmt.Errorf("the --to height must be greater than the --from height")
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading storage for account %s...", address))
+	defer logger.StopProgress()
+
+	from, err := ReadStorage(flow, address, flowkit.ScriptQuery{Height: fromHeight})
+	if err != nil {
+		return nil, err
+	}
+
+	toQuery := flowkit.LatestScriptQuery
+	if storageDiffFlags.To != 0 {
+		toQuery = flowkit.ScriptQuery{Height: storageDiffFlags.To}
+	}
+	to, err := ReadStorage(flow, address, toQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffStorage(address, from, to), nil
+}
+
+// StorageSnapshot of an account at a specific block.
+type StorageSnapshot struct {
+	Balance cadence.UFix64
+	Used    uint64
+	// Paths map storage paths to the identifier of the stored type.
+	Paths map[string]string
+	// Values map storage paths to the JSON-CDC encoding of the stored value.
+	Values map[string]string
+}
+
+// ReadStorage reads the account storage at the block defined by the query.
+func ReadStorage(flow flowkit.Services, address flowsdk.Address, query flowkit.ScriptQuery) (*StorageSnapshot, error) {
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code: []byte(storageScript),
+			Args: []cadence.Value{cadence.NewAddress(address)},
+		},
+		query,
+	)
+	if err != nil {
+		if query.Latest {
+			return nil, fmt.Errorf("failed to read account storage: %w", err)
+		}
+		return nil, fmt.Errorf(
+			"failed to read account storage at height %d, make sure the network keeps state for that height: %w",
+			query.Height,
+			err,
+		)
+	}
+
+	return newStorageSnapshot(value)
+}
+
+func newStorageSnapshot(value cadence.Value) (*StorageSnapshot, error) {
+	info, ok := value.(cadence.Struct)
+	if !ok || info.StructType == nil {
+		return nil, fmt.Errorf("unexpected storage result: %s", value)
+	}
+
+	snapshot := &StorageSnapshot{
+		Paths:  make(map[string]string),
+		Values: make(map[string]string),
+	}
+
+	for i, field := range info.StructType.Fields {
+		switch field.Identifier {
+		case "balance":
+			snapshot.Balance, _ = info.Fields[i].(cadence.UFix64)
+		case "used":
+			used, _ := info.Fields[i].(cadence.UInt64)
+			snapshot.Used = uint64(used)
+		case "paths":
+			paths, _ := info.Fields[i].(cadence.Dictionary)
+			for _, pair := range paths.Pairs {
+				path, _ := pair.Key.(cadence.String)
+				typ, _ := pair.Value.(cadence.String)
+				snapshot.Paths[string(path)] = string(typ)
+			}
+		case "values":
+			values, _ := info.Fields[i].(cadence.Dictionary)
+			for _, pair := range values.Pairs {
+				path, _ := pair.Key.(cadence.String)
+				encoded, err := jsoncdc.Encode(pair.Value)
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode value stored at %s: %w", path, err)
+				}
+				snapshot.Values[string(path)] = string(encoded)
+			}
+		}
+	}
+
+	return snapshot, nil
+}
+
+type changedPath struct {
+	path string
+	from string
+	to   string
+	// valueChanged is set if the stored value changed while the type stayed the same.
+	valueChanged bool
+}
+
+// DiffStorage compares the storage snapshots and returns the differences.
+//
+// A path is changed if the type or the value stored at it changed.
+func DiffStorage(address flowsdk.Address, from *StorageSnapshot, to *StorageSnapshot) *StorageDiff {
+	result := &StorageDiff{
+		address:      address,
+		balanceDelta: cadence.Fix64(to.Balance) - cadence.Fix64(from.Balance),
+		usedDelta:    int64(to.Used) - int64(from.Used),
+		added:        make(map[string]string),
+		removed:      make(map[string]string),
+		changed:      make([]changedPath, 0),
+	}
+
+	for path, typ := range to.Paths {
+		fromType, ok := from.Paths[path]
+		if !ok {
+			result.added[path] = typ
+		} else if fromType != typ {
+			result.changed = append(result.changed, changedPath{path: path, from: fromType, to: typ})
+		} else if from.Values[path] != to.Values[path] {
+			result.changed = append(result.changed, changedPath{path: path, from: fromType, to: typ, valueChanged: true})
+		}
+	}
+
+	for path, typ := range from.Paths {
+		if _, ok := to.Paths[path]; !ok {
+			result.removed[path] = typ
+		}
+	}
+
+	sort.Slice(result.changed, func(i, j int) bool {
+		return result.changed[i].path < result.changed[j].path
+	})
+
+	return result
+}
+
+// StorageDiff contains storage changes of an account.
+type StorageDiff struct {
+	address      flowsdk.Address
+	balanceDelta cadence.Fix64
+	usedDelta    int64
+	added        map[string]string
+	removed      map[string]string
+	changed      []changedPath
+}
+
+// PathsChanged returns true if any storage path was added, removed or changed.
+func (r *StorageDiff) PathsChanged() bool {
+	return len(r.added) > 0 || len(r.removed) > 0 || len(r.changed) > 0
+}
+
+// Modified returns true if any storage path changed or the amount of used storage changed.
+func (r *StorageDiff) Modified() bool {
+	return r.PathsChanged() || r.usedDelta != 0
+}
+
+func (r *StorageDiff) JSON() any {
+	changed := make([]map[string]any, 0, len(r.changed))
+	for _, c := range r.changed {
+		changed = append(changed, map[string]any{
+			"path":         c.path,
+			"from":         c.from,
+			"to":           c.to,
+			"valueChanged": c.valueChanged,
+		})
+	}
+
+	return map[string]any{
+		"address":      r.address.Hex(),
+		"balanceDelta": r.balanceDelta.String(),
+		"storageDelta": r.usedDelta,
+		"added":        r.added,
+		"removed":      r.removed,
+		"changed":      changed,
+	}
+}
+
+func (r *StorageDiff) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address\t 0x%s\n", r.address)
+	_, _ = fmt.Fprintf(writer, "Balance Change\t %s\n", r.balanceDelta)
+	_, _ = fmt.Fprintf(writer, "Storage Change\t %d bytes\n", r.usedDelta)
+
+	if !r.PathsChanged() {
+		_, _ = fmt.Fprintf(writer, "\nNo storage paths changed\n")
+		_ = writer.Flush()
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nStorage Paths\n")
+	for _, path := range sortedKeys(r.added) {
+		_, _ = fmt.Fprintf(writer, "    %s %s\t%s\n", output.Green("+"), path, r.added[path])
+	}
+	for _, path := range sortedKeys(r.removed) {
+		_, _ = fmt.Fprintf(writer, "    %s %s\t%s\n", output.Red("-"), path, r.removed[path])
+	}
+	for _, c := range r.changed {
+		if c.valueChanged {
+			_, _ = fmt.Fprintf(writer, "    %s %s\t%s (value changed)\n", output.Magenta("~"), c.path, c.to)
+		} else {
+			_, _ = fmt.Fprintf(writer, "    %s %s\t%s -> %s\n", output.Magenta("~"), c.path, c.from, c.to)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *StorageDiff) Oneliner() string {
+	return fmt.Sprintf(
+		"Added: %d, Removed: %d, Changed: %d, Balance Change: %s",
+		len(r.added),
+		len(r.removed),
+		len(r.changed),
+		r.balanceDelta,
+	)
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
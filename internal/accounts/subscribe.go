@@ -0,0 +1,191 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsSubscribe struct {
+	Start    uint64 `default:"0" flag:"start" info:"Block height to start tracking events from, defaults to the latest sealed block"`
+	Interval int    `default:"2" flag:"interval" info:"Polling interval in seconds for new blocks"`
+}
+
+var subscribeFlags = flagsSubscribe{}
+
+var subscribeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "subscribe <address|name>",
+		Short:   "Track events referencing the account as they are emitted",
+		Example: "flow accounts subscribe 0x7e60df042a9c0868 --network testnet",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &subscribeFlags,
+	Run:   subscribe,
+}
+
+func subscribe(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	address := flowsdk.HexToAddress(args[0])
+	// project configuration is optional, it is only used for resolving account names
+	if state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter); err == nil {
+		if account, err := state.Accounts().ByName(args[0]); err == nil {
+			address = account.Address
+		}
+	}
+
+	if subscribeFlags.Interval <= 0 {
+		return nil, fmt.Errorf("the --interval must be a positive number of seconds")
+	}
+
+	next := subscribeFlags.Start
+	if next == 0 {
+		latest, err := flow.GetBlock(context.Background(), flowkit.LatestBlockQuery)
+		if err != nil {
+			return nil, err
+		}
+		next = latest.Height
+	}
+
+	sub := &subscription{flow: flow, address: address, next: next}
+	logger.Info(fmt.Sprintf("Tracking events referencing account %s from block %d, press Ctrl+C to stop\n", address, next))
+
+	ticker := time.NewTicker(time.Duration(subscribeFlags.Interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		matched, err := sub.poll()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range matched {
+			logger.Info(fmt.Sprintf(
+				"Block %d  %s  %s  tx %s",
+				event.height,
+				event.Type,
+				event.Value.String(),
+				event.TransactionID,
+			))
+		}
+
+		<-ticker.C
+	}
+}
+
+// accountEvent is an event referencing the subscribed account and the height of the block it was emitted in.
+type accountEvent struct {
+	flowsdk.Event
+	height uint64
+}
+
+// subscription tracks the events referencing an address in the blocks sealed since the last poll.
+type subscription struct {
+	flow    flowkit.Services
+	address flowsdk.Address
+	next    uint64
+}
+
+// poll scans the blocks from the next unprocessed height up to the latest sealed block.
+func (s *subscription) poll() ([]accountEvent, error) {
+	ctx := context.Background()
+	latest, err := s.flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]accountEvent, 0)
+	for ; s.next <= latest.Height; s.next++ {
+		block := latest
+		if s.next != latest.Height {
+			block, err = s.flow.GetBlock(ctx, flowkit.BlockQuery{Height: s.next})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		_, results, err := s.flow.GetTransactionsByBlockID(ctx, block.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions for block %d: %w", s.next, err)
+		}
+
+		for _, result := range results {
+			for _, event := range result.Events {
+				if referencesAddress(event.Value, cadence.Address(s.address)) {
+					matched = append(matched, accountEvent{Event: event, height: s.next})
+				}
+			}
+		}
+	}
+
+	return matched, nil
+}
+
+// referencesAddress returns true if the address is contained in the value, nested values are scanned recursively.
+func referencesAddress(value cadence.Value, address cadence.Address) bool {
+	var values []cadence.Value
+	switch v := value.(type) {
+	case cadence.Address:
+		return v == address
+	case cadence.Optional:
+		return v.Value != nil && referencesAddress(v.Value, address)
+	case cadence.PathCapability:
+		return v.Address == address
+	case cadence.IDCapability:
+		return v.Address == address
+	case cadence.Array:
+		values = v.Values
+	case cadence.Dictionary:
+		for _, pair := range v.Pairs {
+			values = append(values, pair.Key, pair.Value)
+		}
+	case cadence.Struct:
+		values = v.Fields
+	case cadence.Resource:
+		values = v.Fields
+	case cadence.Event:
+		values = v.Fields
+	case cadence.Contract:
+		values = v.Fields
+	case cadence.Enum:
+		values = v.Fields
+	}
+
+	for _, nested := range values {
+		if referencesAddress(nested, address) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsSync struct{}
+
+var syncFlags = flagsSync{}
+
+var syncCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "sync <name>",
+		Short:   "Sync the account key configuration with the keys on the network",
+		Example: "flow accounts sync my-account --network testnet",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &syncFlags,
+	RunS:  syncAccount,
+}
+
+func syncAccount(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Loading keys for account %s...", account.Address))
+	onChain, err := flow.GetAccount(context.Background(), account.Address)
+	logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := syncKey(account, onChain.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case result.matched == nil:
+		logger.Info(fmt.Sprintf("%s warning: the key configured for account %s matches no key on the network", output.WarningEmoji(), account.Name))
+	case result.matched.Revoked:
+		logger.Info(fmt.Sprintf("%s warning: the key configured for account %s is revoked on the network", output.WarningEmoji(), account.Name))
+	}
+
+	if len(result.changes) > 0 {
+		unlock, err := state.LockConfig()
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+
+		state.Accounts().AddOrUpdate(account)
+		err = state.SaveEdited(globalFlags.ConfigPaths)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// syncKey finds the on-chain key matching the account key and updates the key index and algorithms to match it.
+//
+// The key is matched by the public key when the private key is accessible, otherwise by the configured index.
+// Active keys are preferred over revoked keys when the same public key was added more than once.
+func syncKey(account *accounts.Account, keys []*flowsdk.AccountKey) (*syncResult, error) {
+	result := &syncResult{name: account.Name, keys: keys}
+	conf := account.Key.ToConfig()
+
+	var publicKey crypto.PublicKey
+	if privateKey, err := account.Key.PrivateKey(); err == nil && privateKey != nil {
+		publicKey = (*privateKey).PublicKey()
+	}
+
+	for _, key := range keys {
+		var matches bool
+		if publicKey != nil {
+			matches = key.PublicKey.Equals(publicKey)
+		} else {
+			matches = key.Index == conf.Index
+		}
+
+		if matches && (result.matched == nil || result.matched.Revoked) {
+			result.matched = key
+		}
+	}
+
+	if result.matched == nil || result.matched.Revoked {
+		return result, nil
+	}
+
+	if conf.Index != result.matched.Index {
+		result.changes = append(result.changes, fmt.Sprintf("key index %d → %d", conf.Index, result.matched.Index))
+		conf.Index = result.matched.Index
+	}
+	if conf.SigAlgo != result.matched.SigAlgo {
+		result.changes = append(result.changes, fmt.Sprintf("signature algorithm %s → %s", conf.SigAlgo, result.matched.SigAlgo))
+		conf.SigAlgo = result.matched.SigAlgo
+	}
+	if conf.HashAlgo != result.matched.HashAlgo {
+		result.changes = append(result.changes, fmt.Sprintf("hash algorithm %s → %s", conf.HashAlgo, result.matched.HashAlgo))
+		conf.HashAlgo = result.matched.HashAlgo
+	}
+
+	if len(result.changes) == 0 {
+		return result, nil
+	}
+
+	key, err := accounts.KeyFromConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	account.Key = key
+
+	return result, nil
+}
+
+type syncResult struct {
+	name    string
+	keys    []*flowsdk.AccountKey
+	matched *flowsdk.AccountKey
+	changes []string
+}
+
+func (r *syncResult) JSON() any {
+	keys := make([]map[string]any, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, map[string]any{
+			"index":     key.Index,
+			"publicKey": fmt.Sprintf("%x", key.PublicKey.Encode()),
+			"sigAlgo":   key.SigAlgo.String(),
+			"hashAlgo":  key.HashAlgo.String(),
+			"weight":    key.Weight,
+			"revoked":   key.Revoked,
+			"local":     key == r.matched,
+		})
+	}
+
+	return map[string]any{
+		"account": r.name,
+		"keys":    keys,
+		"matched": r.matched != nil && !r.matched.Revoked,
+		"changes": r.changes,
+	}
+}
+
+func (r *syncResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Index\tPublic Key\tSig Algo\tHash Algo\tWeight\tRevoked\tLocal\n")
+	for _, key := range r.keys {
+		local := ""
+		if key == r.matched {
+			local = "✓"
+		}
+		_, _ = fmt.Fprintf(
+			writer,
+			"%d\t%x\t%s\t%s\t%d\t%t\t%s\n",
+			key.Index, key.PublicKey.Encode(), key.SigAlgo, key.HashAlgo, key.Weight, key.Revoked, local,
+		)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\n%s\n", r.Oneliner())
+	for _, change := range r.changes {
+		_, _ = fmt.Fprintf(writer, "  %s\n", change)
+	}
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (r *syncResult) Oneliner() string {
+	switch {
+	case r.matched == nil || r.matched.Revoked:
+		return fmt.Sprintf("No active key on the network matches the key of account %s", r.name)
+	case len(r.changes) == 0:
+		return fmt.Sprintf("Account %s is up to date", r.name)
+	default:
+		return fmt.Sprintf("Account %s updated in the configuration", r.name)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+//go:embed templates/*.cdc
+var templateFiles embed.FS
+
+type accountTemplate struct {
+	Name        string
+	Description string
+	// Contracts imported by the template, which must be available on the network.
+	Contracts []string
+}
+
+// templates contains the account templates shipped with the CLI, the code is stored in the templates folder
+// under the same name.
+var templates = []accountTemplate{{
+	Name:        "nft-user",
+	Description: "Set up the ExampleNFT collection, available on the emulator started with --contracts",
+	Contracts:   []string{"NonFungibleToken", "MetadataViews", "ExampleNFT"},
+}, {
+	Name:        "fusd-user",
+	Description: "Set up the FUSD vault with the receiver and balance capabilities",
+	Contracts:   []string{"FungibleToken", "FUSD"},
+}}
+
+// createAccount creates the account with the keys, set up by the template if one is provided.
+func createAccount(
+	state *flowkit.State,
+	flow flowkit.Services,
+	signer *accounts.Account,
+	keys []accounts.PublicKey,
+	template string,
+) (*flowsdk.Account, flowsdk.Identifier, error) {
+	if template == "" {
+		return flow.CreateAccount(context.Background(), signer, keys)
+	}
+
+	setup, err := templateSetup(template, state, flow.Network().Name)
+	if err != nil {
+		return nil, flowsdk.EmptyID, err
+	}
+
+	return flow.CreateAccountWithOptions(context.Background(), signer, keys, flowkit.CreateAccountOptions{Setup: setup})
+}
+
+// templateSetup returns the setup code of the account template, which is either the name of a template
+// shipped with the CLI or the path to a project template file declaring the setup(account: AuthAccount) function.
+func templateSetup(template string, state *flowkit.State, network string) (flowkit.Script, error) {
+	if strings.HasSuffix(template, ".cdc") {
+		code, err := state.ReadFile(template)
+		if err != nil {
+			return flowkit.Script{}, fmt.Errorf("error loading template: %w", err)
+		}
+		return flowkit.Script{Code: code, Location: template}, nil
+	}
+
+	for _, t := range templates {
+		if t.Name != template {
+			continue
+		}
+
+		for _, contract := range t.Contracts {
+			if _, ok := util.CoreContracts[network][contract]; !ok {
+				return flowkit.Script{}, fmt.Errorf("template %s is not available on network %s, the %s contract is missing", template, network, contract)
+			}
+		}
+
+		code, err := templateFiles.ReadFile(fmt.Sprintf("templates/%s.cdc", t.Name))
+		if err != nil {
+			return flowkit.Script{}, err
+		}
+		code, err = util.ReplaceCoreImports(code, network)
+		if err != nil {
+			return flowkit.Script{}, err
+		}
+
+		return flowkit.Script{Code: code, Location: template}, nil
+	}
+
+	return flowkit.Script{}, fmt.Errorf("template %s doesn't exist, available templates are %s, or provide the path to a .cdc template file", template, templateNames())
+}
+
+func templateNames() string {
+	names := make([]string, len(templates))
+	for i, t := range templates {
+		names[i] = t.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// templatesHelp describes the account templates shipped with the CLI.
+func templatesHelp() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+	for _, t := range templates {
+		_, _ = fmt.Fprintf(writer, "  %s\t%s\n", t.Name, t.Description)
+	}
+	_ = writer.Flush()
+	return b.String()
+}
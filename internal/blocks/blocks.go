@@ -25,6 +25,7 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/events"
 	"github.com/onflow/flow-cli/internal/util"
@@ -39,13 +40,18 @@ var Cmd = &cobra.Command{
 
 func init() {
 	getCommand.AddToParent(Cmd)
+	followCommand.AddToParent(Cmd)
+	executionResultCommand.AddToParent(Cmd)
 }
 
 type blockResult struct {
-	block       *flow.Block
-	events      []flow.BlockEvents
-	collections []*flow.Collection
-	included    []string
+	block        *flow.Block
+	events       []flow.BlockEvents
+	collections  []*flow.Collection
+	systemTx     *flow.Transaction
+	systemResult *flow.TransactionResult
+	included     []string
+	chainID      flow.ChainID
 }
 
 func (r *blockResult) JSON() any {
@@ -73,6 +79,24 @@ func (r *blockResult) JSON() any {
 	}
 
 	result["collection"] = collections
+
+	if r.systemTx != nil {
+		systemTx := make(map[string]any)
+		systemTx["id"] = r.systemTx.ID().String()
+		systemTx["status"] = r.systemResult.Status.String()
+		if r.systemResult.Error != nil {
+			systemTx["error"] = r.systemResult.Error.Error()
+		}
+
+		events := make([]string, 0, len(r.systemResult.Events))
+		for _, event := range r.systemResult.Events {
+			events = append(events, event.Type)
+		}
+		systemTx["events"] = events
+
+		result["systemTransaction"] = systemTx
+	}
+
 	return result
 }
 
@@ -93,7 +117,7 @@ func (r *blockResult) String() string {
 
 	_, _ = fmt.Fprintf(writer, "Block ID\t%s\n", r.block.ID)
 	_, _ = fmt.Fprintf(writer, "Parent ID\t%s\n", r.block.ParentID)
-	_, _ = fmt.Fprintf(writer, "Proposal Timestamp\t%s\n", r.block.Timestamp)
+	_, _ = fmt.Fprintf(writer, "Proposal Timestamp\t%s\n", output.FormatTime(r.block.Timestamp))
 	_, _ = fmt.Fprintf(writer, "Proposal Timestamp Unix\t%d\n", r.block.Timestamp.Unix())
 	_, _ = fmt.Fprintf(writer, "Height\t%v\n", r.block.Height)
 	_, _ = fmt.Fprintf(writer, "Status\t%s\n", blockStatusToString(r.block.Status))
@@ -112,10 +136,21 @@ func (r *blockResult) String() string {
 		}
 	}
 
+	if r.systemTx != nil {
+		_, _ = fmt.Fprintf(writer, "System Transaction\t%s\n", r.systemTx.ID())
+		_, _ = fmt.Fprintf(writer, "    Status:\t%s\n", r.systemResult.Status)
+		if r.systemResult.Error != nil {
+			_, _ = fmt.Fprintf(writer, "    Error:\t%s\n", r.systemResult.Error)
+		}
+		for i, event := range r.systemResult.Events {
+			_, _ = fmt.Fprintf(writer, "    Event %d:\t%s\n", i, event.Type)
+		}
+	}
+
 	if len(r.events) > 0 {
 		_, _ = fmt.Fprintf(writer, "\n")
 
-		e := events.EventResult{BlockEvents: r.events}
+		e := events.EventResult{BlockEvents: r.events, ChainID: r.chainID}
 		_, _ = fmt.Fprintf(writer, "%s", e.String())
 	}
 
@@ -19,14 +19,20 @@
 package blocks
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
@@ -59,6 +65,122 @@ func Test_GetBlock(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.NoError(t, err)
 	})
+
+	t.Run("Success System Transaction", func(t *testing.T) {
+		inArgs := []string{"100"}
+		blockFlags.Events = ""
+		blockFlags.Include = []string{"system-transaction"}
+
+		returnBlock := tests.NewBlock()
+		srv.GetBlock.Return(returnBlock, nil)
+
+		systemTx := tests.NewTransaction()
+		systemResult := tests.NewTransactionResult([]flow.Event{{Type: "A.f919ee77447b7497.FlowFees.FeesDeducted"}})
+		systemResult.Status = flow.TransactionStatusSealed
+		srv.GetSystemTransaction.Run(func(args mock.Arguments) {
+			assert.Equal(t, returnBlock.ID, args.Get(1).(flow.Identifier))
+		}).Return(systemTx, systemResult, nil)
+
+		result, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		output := result.String()
+		assert.Contains(t, output, systemTx.ID().String())
+		assert.Contains(t, output, "SEALED")
+		assert.Contains(t, output, "A.f919ee77447b7497.FlowFees.FeesDeducted")
+
+		systemJSON := result.JSON().(map[string]any)["systemTransaction"].(map[string]any)
+		assert.Equal(t, systemTx.ID().String(), systemJSON["id"])
+		assert.Equal(t, []string{"A.f919ee77447b7497.FlowFees.FeesDeducted"}, systemJSON["events"])
+	})
+}
+
+func Test_BlockQueryAliases(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+	ctx := context.Background()
+
+	// blocks are produced every second starting at genesis, latest block is at height 1000
+	genesis := time.Date(2023, 6, 1, 15, 0, 0, 0, time.UTC)
+	srv.GetBlock.Run(func(args mock.Arguments) {
+		query := args.Get(1).(flowkit.BlockQuery)
+		block := tests.NewBlock()
+		block.Height = query.Height
+		if query.Latest {
+			block.Height = 1000
+		}
+		block.Timestamp = genesis.Add(time.Duration(block.Height) * time.Second)
+		srv.GetBlock.Return(block, nil)
+	})
+
+	t.Run("Relative height", func(t *testing.T) {
+		query, err := util.BlockQuery(ctx, srv.Mock, "latest-100")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(900), query.Height)
+
+		_, err = util.BlockQuery(ctx, srv.Mock, "latest-2000")
+		assert.EqualError(t, err, "relative height latest-2000 is before the first block")
+	})
+
+	t.Run("Timestamp", func(t *testing.T) {
+		query, err := util.BlockQuery(ctx, srv.Mock, "2023-06-01T15:05:00Z")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(300), query.Height)
+
+		_, err = util.BlockQuery(ctx, srv.Mock, "2023-06-01T16:00:00Z")
+		assert.EqualError(t, err, "time 2023-06-01T16:00:00Z is after the latest block")
+	})
+
+	t.Run("Timestamp with blocks not found", func(t *testing.T) {
+		srv, _, _ := util.TestMocks(t)
+		srv.GetBlock.Run(func(args mock.Arguments) {
+			query := args.Get(1).(flowkit.BlockQuery)
+			if !query.Latest && query.Height < 500 {
+				srv.GetBlock.Return(nil, fmt.Errorf("error fetching block: %w", gateway.ErrNotFound))
+				return
+			}
+
+			block := tests.NewBlock()
+			block.Height = query.Height
+			if query.Latest {
+				block.Height = 1000
+			}
+			block.Timestamp = genesis.Add(time.Duration(block.Height) * time.Second)
+			srv.GetBlock.Return(block, nil)
+		})
+
+		query, err := util.BlockQuery(ctx, srv.Mock, "2023-06-01T15:05:00Z")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(500), query.Height)
+	})
+
+	t.Run("Fail timestamp", func(t *testing.T) {
+		srv, _, _ := util.TestMocks(t)
+		srv.GetBlock.Run(func(args mock.Arguments) {
+			query := args.Get(1).(flowkit.BlockQuery)
+			if !query.Latest {
+				srv.GetBlock.Return(nil, fmt.Errorf("error fetching block: %w", gateway.ErrRateLimited))
+				return
+			}
+
+			block := tests.NewBlock()
+			block.Height = 1000
+			block.Timestamp = genesis.Add(1000 * time.Second)
+			srv.GetBlock.Return(block, nil)
+		})
+
+		_, err := util.BlockQuery(ctx, srv.Mock, "2023-06-01T15:05:00Z")
+		assert.EqualError(t, err, "failed to get block at height 500: error fetching block: rate limited")
+	})
+
+	t.Run("Height", func(t *testing.T) {
+		height, err := util.BlockHeight(ctx, srv.Mock, "final")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1000), height)
+
+		height, err = util.BlockHeight(ctx, srv.Mock, "42")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(42), height)
+	})
 }
 
 func Test_Result(t *testing.T) {
@@ -70,7 +192,7 @@ func Test_Result(t *testing.T) {
 	assert.Equal(t, strings.TrimPrefix(`
 Block ID		0202020202020202020202020202020202020202020202020202020202020202
 Parent ID		0303030303030303030303030303030303030303030303030303030303030303
-Proposal Timestamp	2020-06-04 16:43:21 +0000 UTC
+Proposal Timestamp	2020-06-04T16:43:21Z
 Proposal Timestamp Unix	1591289001
 Height			1
 Status			Unknown
@@ -98,3 +220,116 @@ Total Collections	3
 		result.JSON(),
 	)
 }
+
+func Test_Follow(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	block := tests.NewBlock()
+	block.Timestamp = time.Date(2023, 6, 1, 15, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		followFlags.SinceHeight = 5
+		blocks := make(chan *flow.Block)
+		errs := make(chan error)
+		close(blocks)
+		close(errs)
+
+		srv.Mock.
+			On("SubscribeBlocks", mock.Anything, uint64(5)).
+			Return((<-chan *flow.Block)(blocks), (<-chan error)(errs), nil).
+			Once()
+
+		result, err := follow(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Follow Blocks", func(t *testing.T) {
+		blocks := make(chan *flow.Block, 1)
+		errs := make(chan error, 1)
+		blocks <- block
+		errs <- context.Canceled
+		close(blocks)
+		close(errs)
+
+		var out strings.Builder
+		err := followBlocks(&out, blocks, errs, "text")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, fmt.Sprintf(
+			"Height %d\tID %s\tCollections %d\tTimestamp 2023-06-01T15:00:00Z\n",
+			block.Height, block.ID, len(block.CollectionGuarantees),
+		), out.String())
+	})
+
+	t.Run("Follow Blocks JSON", func(t *testing.T) {
+		blocks := make(chan *flow.Block, 2)
+		errs := make(chan error)
+		blocks <- block
+		blocks <- block
+		close(blocks)
+		close(errs)
+
+		var out strings.Builder
+		err := followBlocks(&out, blocks, errs, "json")
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+
+		var line map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &line))
+		assert.Equal(t, block.ID.String(), line["blockId"])
+		assert.Equal(t, float64(block.Height), line["height"])
+		assert.Equal(t, "2023-06-01T15:00:00Z", line["timestamp"])
+	})
+}
+
+func Test_ExecutionResult(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	block := tests.NewBlock()
+	srv.GetBlock.Return(block, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		result := &flow.ExecutionResult{
+			BlockID:          block.ID,
+			PreviousResultID: flow.HexToID("01"),
+			Chunks: []*flow.Chunk{{
+				Index:                0,
+				CollectionIndex:      0,
+				NumberOfTransactions: 2,
+				TotalComputationUsed: 150,
+				EventCollection:      []byte{0xab, 0xcd},
+			}},
+			ServiceEvents: []*flow.ServiceEvent{{
+				Type:    "EpochSetup",
+				Payload: []byte(`{"Counter":1}`),
+			}},
+		}
+		srv.Gateway.Return(&gateway.Mock{
+			GetExecutionResultForBlockIDFunc: func(_ context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error) {
+				assert.Equal(t, block.ID, blockID)
+				return result, nil
+			},
+		})
+
+		res, err := executionResult([]string{"sealed"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		output := res.String()
+		assert.Contains(t, output, block.ID.String())
+		assert.Contains(t, output, "Event Collection Hash\tabcd")
+		assert.Contains(t, output, "EpochSetup\t13 bytes")
+
+		resultJSON := res.JSON().(map[string]any)
+		assert.Equal(t, block.Height, resultJSON["height"])
+		assert.Equal(t, json.RawMessage(`{"Counter":1}`), resultJSON["serviceEvents"].([]map[string]any)[0]["payload"])
+	})
+
+	t.Run("Fail not supported", func(t *testing.T) {
+		srv.Gateway.Return(&gateway.Mock{})
+
+		_, err := executionResult([]string{"sealed"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "failed to get the execution result of block")
+	})
+}
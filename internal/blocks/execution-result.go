@@ -0,0 +1,170 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsExecutionResult struct{}
+
+var executionResultFlags = flagsExecutionResult{}
+
+var executionResultCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "execution-result <block_id|latest|sealed|final|latest-N|block_height|timestamp>",
+		Short: "Get the execution result of the block",
+		Long: `Get the execution result of the block, listing the chunks with their start and end state commitments
+and event collection hashes, and the service events emitted by the block.
+
+The execution result can be compared with the results reported by the execution nodes when debugging
+node issues, and the event collection hashes used to verify the events emitted in each chunk.`,
+		Example: "flow blocks execution-result sealed --network mainnet\nflow blocks execution-result 55000000 --network mainnet --output json",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &executionResultFlags,
+	Run:   executionResult,
+}
+
+func executionResult(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	query, err := util.BlockQuery(context.Background(), flow, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress("Fetching Execution Result...")
+	defer logger.StopProgress()
+
+	block, err := flow.GetBlock(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := flow.Gateway().GetExecutionResultForBlockID(context.Background(), block.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the execution result of block %s: %w", block.ID, err)
+	}
+
+	return &executionResultResult{
+		height: block.Height,
+		result: result,
+	}, nil
+}
+
+type executionResultResult struct {
+	height uint64
+	result *flowsdk.ExecutionResult
+}
+
+var _ command.Result = &executionResultResult{}
+
+// serviceEventPayload returns the payload as JSON if it's valid JSON, otherwise hex encoded.
+func serviceEventPayload(payload []byte) any {
+	if json.Valid(payload) {
+		return json.RawMessage(payload)
+	}
+	return hex.EncodeToString(payload)
+}
+
+func (r *executionResultResult) JSON() any {
+	chunks := make([]map[string]any, 0, len(r.result.Chunks))
+	for _, chunk := range r.result.Chunks {
+		chunks = append(chunks, map[string]any{
+			"index":               chunk.Index,
+			"collectionIndex":     chunk.CollectionIndex,
+			"transactions":        chunk.NumberOfTransactions,
+			"computationUsed":     chunk.TotalComputationUsed,
+			"startState":          hex.EncodeToString(chunk.StartState[:]),
+			"endState":            hex.EncodeToString(chunk.EndState[:]),
+			"eventCollectionHash": hex.EncodeToString(chunk.EventCollection),
+		})
+	}
+
+	serviceEvents := make([]map[string]any, 0, len(r.result.ServiceEvents))
+	for _, event := range r.result.ServiceEvents {
+		serviceEvents = append(serviceEvents, map[string]any{
+			"type":    event.Type,
+			"payload": serviceEventPayload(event.Payload),
+		})
+	}
+
+	return map[string]any{
+		"blockId":          r.result.BlockID.String(),
+		"height":           r.height,
+		"previousResultId": r.result.PreviousResultID.String(),
+		"chunks":           chunks,
+		"serviceEvents":    serviceEvents,
+	}
+}
+
+func (r *executionResultResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Block ID\t%s\n", r.result.BlockID)
+	_, _ = fmt.Fprintf(writer, "Block Height\t%d\n", r.height)
+	_, _ = fmt.Fprintf(writer, "Previous Result ID\t%s\n", r.result.PreviousResultID)
+	_, _ = fmt.Fprintf(writer, "Total Chunks\t%d\n", len(r.result.Chunks))
+
+	for _, chunk := range r.result.Chunks {
+		_, _ = fmt.Fprintf(writer, "\nChunk %d\n", chunk.Index)
+		_, _ = fmt.Fprintf(writer, "    Collection Index\t%d\n", chunk.CollectionIndex)
+		_, _ = fmt.Fprintf(writer, "    Transactions\t%d\n", chunk.NumberOfTransactions)
+		_, _ = fmt.Fprintf(writer, "    Computation Used\t%d\n", chunk.TotalComputationUsed)
+		_, _ = fmt.Fprintf(writer, "    Start State\t%x\n", chunk.StartState[:])
+		_, _ = fmt.Fprintf(writer, "    End State\t%x\n", chunk.EndState[:])
+		_, _ = fmt.Fprintf(writer, "    Event Collection Hash\t%x\n", []byte(chunk.EventCollection))
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nService Events\t%d\n", len(r.result.ServiceEvents))
+	for _, event := range r.result.ServiceEvents {
+		_, _ = fmt.Fprintf(writer, "    %s\t%d bytes\n", event.Type, len(event.Payload))
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *executionResultResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Block: %s, Height: %d, Chunks: %d, Service Events: %d",
+		r.result.BlockID,
+		r.height,
+		len(r.result.Chunks),
+		len(r.result.ServiceEvents),
+	)
+}
@@ -0,0 +1,110 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsFollow struct {
+	SinceHeight uint64 `default:"0" flag:"since-height" info:"Block height to start from, past blocks are printed before following new blocks. Defaults to the latest sealed block"`
+}
+
+var followFlags = flagsFollow{}
+
+var followCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "follow",
+		Short: "Print new blocks as they are sealed",
+		Example: `flow blocks follow --network mainnet
+flow blocks follow --since-height 55000000 --network mainnet
+flow blocks follow --output json | jq .height`,
+		Args: cobra.NoArgs,
+	},
+	Flags: &followFlags,
+	Run:   follow,
+}
+
+func follow(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	// the subscription ends when the command is interrupted
+	ctx := command.Interrupted()
+	blocks, errs, err := flow.SubscribeBlocks(ctx, followFlags.SinceHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Following sealed blocks, press Ctrl+C to stop.\n")
+
+	// blocks are printed as they are received, so there is no result left to print once the subscription ends
+	err = followBlocks(os.Stdout, blocks, errs, globalFlags.Format)
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// followBlocks writes a line for each block as it is received until the subscription ends,
+// and returns the error the subscription ended with.
+//
+// Each block is written as a JSON line when using the JSON format, so the output can be piped to other tools.
+func followBlocks(w io.Writer, blocks <-chan *flowsdk.Block, errs <-chan error, format string) error {
+	for block := range blocks {
+		if format == "json" {
+			out, err := json.Marshal(map[string]any{
+				"height":           block.Height,
+				"blockId":          block.ID.String(),
+				"totalCollections": len(block.CollectionGuarantees),
+				"timestamp":        block.Timestamp.UTC().Format(time.RFC3339Nano),
+			})
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(w, string(out))
+			continue
+		}
+
+		_, _ = fmt.Fprintf(
+			w,
+			"Height %d\tID %s\tCollections %d\tTimestamp %s\n",
+			block.Height,
+			block.ID,
+			len(block.CollectionGuarantees),
+			output.FormatTime(block.Timestamp),
+		)
+	}
+
+	return <-errs
+}
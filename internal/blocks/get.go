@@ -27,20 +27,22 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsBlocks struct {
 	Events  string   `default:"" flag:"events" info:"List events of this type for the block"`
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: transactions."`
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: transactions, system-transaction."`
 }
 
 var blockFlags = flagsBlocks{}
 
 var getCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "get <block_id|latest|block_height>",
+		Use:     "get <block_id|latest|sealed|final|latest-N|block_height|timestamp>",
 		Short:   "Get block info",
-		Example: "flow blocks get latest --network testnet",
+		Example: "flow blocks get latest --network testnet\nflow blocks get latest-100\nflow blocks get 2023-06-01T15:00:00Z",
 		Args:    cobra.ExactArgs(1),
 	},
 	Flags: &blockFlags,
@@ -55,7 +57,7 @@ func get(
 	flow flowkit.Services,
 ) (command.Result, error) {
 
-	query, err := flowkit.NewBlockQuery(args[0])
+	query, err := util.BlockQuery(context.Background(), flow, args[0])
 	if err != nil {
 		return nil, err
 	}
@@ -67,9 +69,10 @@ func get(
 		return nil, err
 	}
 
-	var events []flowsdk.BlockEvents
+	var blockEvents []flowsdk.BlockEvents
+	var chainID flowsdk.ChainID
 	if blockFlags.Events != "" {
-		events, err = flow.GetEvents(
+		blockEvents, err = flow.GetEvents(
 			context.Background(),
 			[]string{blockFlags.Events},
 			block.Height,
@@ -79,6 +82,7 @@ func get(
 		if err != nil {
 			return nil, err
 		}
+		chainID = events.ChainID(flow)
 	}
 
 	collections := make([]*flowsdk.Collection, 0)
@@ -92,10 +96,22 @@ func get(
 		}
 	}
 
+	var systemTx *flowsdk.Transaction
+	var systemResult *flowsdk.TransactionResult
+	if command.ContainsFlag(blockFlags.Include, "system-transaction") {
+		systemTx, systemResult, err = flow.GetSystemTransaction(context.Background(), block.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &blockResult{
-		block:       block,
-		events:      events,
-		collections: collections,
-		included:    blockFlags.Include,
+		block:        block,
+		events:       blockEvents,
+		collections:  collections,
+		systemTx:     systemTx,
+		systemResult: systemResult,
+		chainID:      chainID,
+		included:     blockFlags.Include,
 	}, nil
 }
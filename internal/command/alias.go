@@ -0,0 +1,123 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ExpandAliases replaces the user-defined alias used as the first argument with the arguments it stands for.
+//
+// Aliases can refer to other aliases, which are expanded in turn, and an alias referring back to itself is
+// reported as an error. Commands always take precedence over aliases with the same name.
+func ExpandAliases(root *cobra.Command, args []string, aliases map[string]string) ([]string, error) {
+	var chain []string
+	for len(args) > 0 && !isCommand(root, args[0]) {
+		name := strings.ToLower(args[0])
+		value, ok := aliases[name]
+		if !ok {
+			break
+		}
+
+		for _, used := range chain {
+			if used == name {
+				return nil, fmt.Errorf("alias cycle detected: %s -> %s", strings.Join(chain, " -> "), name)
+			}
+		}
+		chain = append(chain, name)
+
+		expanded, err := splitArgs(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alias %s: %w", name, err)
+		}
+		if len(expanded) > 0 && expanded[0] == root.Name() {
+			expanded = expanded[1:]
+		}
+
+		args = append(expanded, args[1:]...)
+	}
+
+	return args, nil
+}
+
+// isCommand checks whether the name refers to a command of the root command.
+func isCommand(root *cobra.Command, name string) bool {
+	if name == "help" || name == "completion" {
+		return true
+	}
+
+	cmd, _, err := root.Find([]string{name})
+	return err == nil && cmd != root
+}
+
+// splitArgs splits the value into arguments separated by whitespace the way a shell would,
+// respecting single and double quotes and backslash escapes.
+func splitArgs(value string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range value {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %s", value)
+	}
+	if escaped {
+		return nil, fmt.Errorf("unterminated escape in %s", value)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
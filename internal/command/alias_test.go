@@ -0,0 +1,91 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExpandAliases(t *testing.T) {
+	root := &cobra.Command{Use: "flow"}
+	project := &cobra.Command{Use: "project"}
+	project.AddCommand(&cobra.Command{Use: "deploy", Run: func(*cobra.Command, []string) {}})
+	root.AddCommand(project)
+
+	aliases := map[string]string{
+		"dep":     "project deploy --network testnet --update",
+		"d":       "dep --show-diff",
+		"full":    "flow project deploy",
+		"project": "version",
+		"quoted":  `scripts execute script.cdc "Meow Woof" 'it''s'`,
+		"a":       "b",
+		"b":       "a",
+		"invalid": `scripts execute "unterminated`,
+	}
+
+	t.Run("Expand alias", func(t *testing.T) {
+		args, err := ExpandAliases(root, []string{"dep", "--log", "debug"}, aliases)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"project", "deploy", "--network", "testnet", "--update", "--log", "debug"}, args)
+	})
+
+	t.Run("Expand nested alias", func(t *testing.T) {
+		args, err := ExpandAliases(root, []string{"d"}, aliases)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"project", "deploy", "--network", "testnet", "--update", "--show-diff"}, args)
+	})
+
+	t.Run("Strip command name", func(t *testing.T) {
+		args, err := ExpandAliases(root, []string{"full"}, aliases)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"project", "deploy"}, args)
+	})
+
+	t.Run("Quoted arguments", func(t *testing.T) {
+		args, err := ExpandAliases(root, []string{"quoted"}, aliases)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"scripts", "execute", "script.cdc", "Meow Woof", "its"}, args)
+	})
+
+	t.Run("Commands take precedence", func(t *testing.T) {
+		args, err := ExpandAliases(root, []string{"project", "deploy"}, aliases)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"project", "deploy"}, args)
+	})
+
+	t.Run("Unknown arguments unchanged", func(t *testing.T) {
+		args, err := ExpandAliases(root, []string{"--network", "testnet"}, aliases)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"--network", "testnet"}, args)
+	})
+
+	t.Run("Fail cycle", func(t *testing.T) {
+		_, err := ExpandAliases(root, []string{"a"}, aliases)
+		assert.EqualError(t, err, "alias cycle detected: a -> b -> a")
+	})
+
+	t.Run("Fail invalid alias", func(t *testing.T) {
+		_, err := ExpandAliases(root, []string{"invalid"}, aliases)
+		assert.EqualError(t, err, `invalid alias invalid: unterminated quote in scripts execute "unterminated`)
+	})
+}
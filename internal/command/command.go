@@ -19,22 +19,27 @@
 package command
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/user"
+	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/dukex/mixpanel"
 	"github.com/getsentry/sentry-go"
+	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
@@ -66,17 +71,25 @@ type RunWithState func(
 ) (Result, error)
 
 type Command struct {
-	Cmd    *cobra.Command
-	Flags  any
-	Run    run
-	RunS   RunWithState
-	Status *int
+	Cmd          *cobra.Command
+	Flags        any
+	Run          run
+	RunS         RunWithState
+	Status       *int
+	Deprecations []Deprecation
 }
 
 const (
 	formatText   = "text"
 	formatInline = "inline"
 	formatJSON   = "json"
+	formatCSV    = "csv"
+)
+
+const (
+	cacheNone   = "none"
+	cacheMemory = "memory"
+	cacheDisk   = "disk"
 )
 
 const (
@@ -103,19 +116,64 @@ func (c Command) AddToParent(parent *cobra.Command) {
 		// initialize file loader used in commands
 		loader := &afero.Afero{Fs: afero.NewOsFs()}
 
+		defaults, err := userDefaults(loader, config.UserPath())
+		handleError("User Config Error", err)
+		handleError("User Config Error", applyUserDefaults(cmd, defaults))
+
 		// if we receive a config error that isn't missing config we should handle it
 		state, confErr := flowkit.Load(Flags.ConfigPaths, loader)
 		if !errors.Is(confErr, config.ErrDoesNotExist) {
 			handleError("Config Error", confErr)
 		}
+		if Flags.Profile != "" {
+			if state == nil {
+				handleError("Profile Error", fmt.Errorf("profile %s requires a configuration, please initialize it first", Flags.Profile))
+			}
+			handleError("Profile Error", state.ApplyProfile(Flags.Profile))
+		}
 
 		network, err := resolveHost(state, Flags.Host, Flags.HostNetworkKey, Flags.Network)
 		handleError("Host Error", err)
+		if Flags.MaxMessageSize > 0 {
+			network.MaxMessageSize = Flags.MaxMessageSize
+		}
+		if Flags.RateLimit > 0 {
+			network.RequestsPerSecond = Flags.RateLimit
+		}
+		requestID = newRequestID()
+		network.RequestID = requestID
 
-		clientGateway, err := createGateway(*network)
+		newGateway := GatewayFactory(state, loader)
+		clientGateway, err := newGateway(*network)
 		handleError("Gateway Error", err)
+		var metricsServer *http.Server
+		if Flags.MetricsAddress != "" {
+			clientGateway, metricsServer, err = serveMetrics(Flags.MetricsAddress, clientGateway)
+			handleError("Metrics Error", err)
+		}
+
+		handleError("Output Error", output.SetColorMode(Flags.Color))
+		handleError("Output Error", output.SetTheme(Flags.Theme))
+		output.SetPlain(Flags.Plain)
+		if Flags.ProgressJSON {
+			output.SetProgressJSON(os.Stderr)
+		}
+		handleError("Output Error", output.SetTimeFormat(Flags.TimeFormat))
+		handleError("Output Error", output.SetTimezone(Flags.Timezone))
 
 		logger := createLogger(Flags.Log, Flags.Format)
+		if Flags.Log == logLevelDebug {
+			logger.Debug(fmt.Sprintf("Request ID: %s", requestID))
+		}
+
+		stopListening := commandLifecycle.listen(logger)
+		defer stopListening()
+		if closer, ok := clientGateway.(io.Closer); ok {
+			OnShutdown("gateway connection", closer.Close)
+		}
+		if metricsServer != nil {
+			OnShutdown("metrics server", metricsServer.Close)
+		}
 
 		// initialize services
 		flow := flowkit.NewFlowkit(state, *network, clientGateway, logger)
@@ -125,6 +183,25 @@ func (c Command) AddToParent(parent *cobra.Command) {
 			checkVersion(logger)
 		}
 
+		if state != nil {
+			checkSecretFiles(state, logger)
+			state.SetFrozenLockfile(Flags.FrozenLockfile)
+			state.SetConfigLockWait(Flags.ConfigLockWait)
+
+			variables, err := parseVariables(Flags.Vars)
+			handleError("Variable Error", err)
+			state.SetVariables(variables)
+		}
+
+		deprecations := usedDeprecations(cmd, args, c.Deprecations)
+		if Flags.Strict || settings.StrictModeEnabled() {
+			handleError("Strict Mode Error", checkStrict(cmd, args, state, deprecations))
+		}
+		warnDeprecations(deprecations, logger, os.Stderr, Flags.Format)
+
+		hooksAllowed := trustHooks(state, Flags.AllowHooks)
+		handleError("Hook Error", runHooks(config.HookStagePre, cmd, args, state, *network, hooksAllowed, logger))
+
 		// record command usage
 		wg := sync.WaitGroup{}
 		go UsageMetrics(c.Cmd, &wg)
@@ -143,6 +220,10 @@ func (c Command) AddToParent(parent *cobra.Command) {
 			panic("command implementation needs to provide run functionality")
 		}
 
+		commandLifecycle.shutdown(logger)
+		if code := commandLifecycle.exitCode(); code != 0 {
+			os.Exit(code)
+		}
 		handleError("Command Error", err)
 
 		// Do not print a result if none is provided.
@@ -150,32 +231,164 @@ func (c Command) AddToParent(parent *cobra.Command) {
 		// This is useful for interactive commands that do not
 		// require a printed summary (e.g. flow accounts create).
 		if result == nil {
+			handleError("Hook Error", runHooks(config.HookStagePost, cmd, args, state, *network, hooksAllowed, logger))
 			return
 		}
 
 		// format output result
-		formattedResult, err := formatResult(result, Flags.Filter, Flags.Format)
+		formattedResult, err := formatResult(result, Flags.Filter, Flags.Format, Flags.Plain)
 		handleError("Result", err)
 
 		// output result
-		err = outputResult(formattedResult, Flags.Save, Flags.Format, Flags.Filter)
+		err = outputResult(formattedResult, Flags.Save, Flags.Format, Flags.Filter, Flags.MaxLines, Flags.Full)
 		handleError("Output Error", err)
 
+		handleError("Hook Error", runHooks(config.HookStagePost, cmd, args, state, *network, hooksAllowed, logger))
+
 		wg.Wait()
 	}
 
 	bindFlags(c)
+	annotateDeprecations(c.Cmd, c.Deprecations)
 	parent.AddCommand(c.Cmd)
 }
 
+// requestID correlates all the gateway requests of the running command, it is sent to the
+// access node and shown with the errors so users can reference it when reporting issues.
+var requestID string
+
+// newRequestID returns a random ID for the gateway requests of a command.
+func newRequestID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// GatewayFactory returns the function creating the gateways of the commands, so the gateways created by a command
+// for other networks access the networks the same way, with the same retries, rate limits, allowlist, cache and timeout.
+func GatewayFactory(state *flowkit.State, reader flowkit.ReaderWriter) func(config.Network) (gateway.Gateway, error) {
+	return func(network config.Network) (gateway.Gateway, error) {
+		gw, err := createGateway(network, emulatorAdminPort(state))
+		if err != nil {
+			return nil, err
+		}
+		gw, err = operationsGateway(gw, reader, util.AllowlistPath(state))
+		if err != nil {
+			return nil, fmt.Errorf("operations mode error: %w", err)
+		}
+		if Flags.Cache != cacheNone {
+			cache, err := createCache(Flags.Cache, network)
+			if err != nil {
+				return nil, err
+			}
+			gw = gateway.NewCacheGateway(gw, cache, Flags.CacheTTL)
+		}
+		if Flags.Timeout > 0 {
+			gw = gateway.NewTimeoutGateway(gw, Flags.Timeout)
+		}
+		return gw, nil
+	}
+}
+
 // createGateway creates a gateway to be used, defaults to grpc but can support others.
-func createGateway(network config.Network) (gateway.Gateway, error) {
-	// create secure grpc client if hostNetworkKey provided
-	if network.Key != "" {
-		return gateway.NewSecureGrpcGateway(network)
+//
+// Networks with fallback hosts fail over to the next host when the current one is unreachable,
+// and calls failing with transient errors are retried using the default retry configuration.
+func createGateway(network config.Network, adminPort int) (gateway.Gateway, error) {
+	var gw gateway.Gateway
+	var err error
+	if len(network.FallbackHosts) > 0 {
+		gw, err = gateway.NewGrpcFailoverGateway(network)
+	} else if network.Name == config.EmulatorNetwork.Name {
+		gw, err = gateway.NewGrpcEmulatorGateway(network, adminPort)
+	} else {
+		gw, err = gateway.NewGrpcGateway(network)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// limit the rate below the retries, so retried calls are limited as well
+	if network.RequestsPerSecond > 0 {
+		gw = gateway.NewRateLimitGateway(gw, network.RequestsPerSecond)
+	}
+
+	return gateway.NewRetryGateway(gw, gateway.DefaultRetryConfig), nil
+}
+
+// emulatorAdminPort returns the admin API port of the default emulator in the configuration, or the default port.
+func emulatorAdminPort(state *flowkit.State) int {
+	if state != nil {
+		if emulator := state.Config().Emulators.Default(); emulator != nil && emulator.AdminPort != 0 {
+			return emulator.AdminPort
+		}
+	}
+	return gateway.DefaultEmulatorAdminPort
+}
 
-	return gateway.NewGrpcGateway(network)
+// operationsGateway only allows the transactions and scripts in the allowlist to be sent with the gateway
+// if the project is in operations mode and the gateway is connected to mainnet, otherwise the gateway is
+// returned unchanged.
+//
+// The network is identified by the chain ID reported by the host rather than by the network name, so the
+// allowlist can't be bypassed by naming the network differently. The chain ID is only fetched once code is
+// sent, so the commands not sending code work without the network.
+func operationsGateway(gw gateway.Gateway, reader flowkit.ReaderWriter, path string) (gateway.Gateway, error) {
+	allowlist, err := util.LoadAllowlist(reader, path)
+	if err != nil || allowlist == nil {
+		return gw, err
+	}
+
+	return gateway.NewChainAllowlistGateway(gw, allowlist.Hashes(), flowsdk.Mainnet), nil
+}
+
+// createCache creates the cache of the gateway responses.
+//
+// The on-disk cache is kept in the user cache directory with a separate directory for each network host.
+func createCache(mode string, network config.Network) (gateway.Cache, error) {
+	switch mode {
+	case cacheMemory:
+		return gateway.NewMemoryCache(), nil
+	case cacheDisk:
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the cache directory: %w", err)
+		}
+
+		host := strings.NewReplacer(":", "_", "/", "_", "\\", "_").Replace(network.Host)
+		return gateway.NewFileCache(filepath.Join(dir, "flow-cli", "gateway", fmt.Sprintf("%s-%s", network.Name, host)))
+	default:
+		return nil, fmt.Errorf("invalid cache %s, options: %s, %s, %s", mode, cacheNone, cacheMemory, cacheDisk)
+	}
+}
+
+// parseVariables parses the variables provided as name=value pairs, where the value is a boolean.
+//
+// A variable provided without a value, like --var feature_x, is set to true.
+func parseVariables(vars []string) (map[string]bool, error) {
+	variables := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		name, value, hasValue := strings.Cut(v, "=")
+		name = strings.TrimSpace(name)
+		if name == "" || config.ValidateCondition(name) != nil || strings.HasPrefix(name, "!") {
+			return nil, fmt.Errorf("invalid variable name in %s", v)
+		}
+
+		enabled := true
+		if hasValue {
+			parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of variable %s, expected true or false", name)
+			}
+			enabled = parsed
+		}
+
+		variables[name] = enabled
+	}
+
+	return variables, nil
 }
 
 // resolveHost from the flags provided.
@@ -279,6 +492,18 @@ func checkVersion(logger output.Logger) {
 	}
 }
 
+// checkSecretFiles warns if any of the files containing private keys can be read by other users.
+func checkSecretFiles(state *flowkit.State, logger output.Logger) {
+	for _, file := range util.InsecureFiles(util.SecretFiles(state)) {
+		logger.Info(fmt.Sprintf(
+			"%s  Permission warning: %s contains private keys and is accessible by other users, restrict it by running 'chmod 600 %s'.",
+			output.WarningEmoji(),
+			file,
+			file,
+		))
+	}
+}
+
 func isDevelopment() bool {
 	return build.Semver() == "undefined"
 }
@@ -356,4 +581,24 @@ type GlobalFlags struct {
 	Yes              bool
 	ConfigPaths      []string
 	SkipVersionCheck bool
+	FrozenLockfile   bool
+	Strict           bool
+	MaxLines         int
+	Full             bool
+	Color            string
+	Theme            string
+	Plain            bool
+	ProgressJSON     bool
+	TimeFormat       string
+	Timezone         string
+	Timeout          time.Duration
+	Cache            string
+	CacheTTL         time.Duration
+	Vars             []string
+	MaxMessageSize   int
+	RateLimit        float64
+	MetricsAddress   string
+	ConfigLockWait   time.Duration
+	Profile          string
+	AllowHooks       bool
 }
@@ -0,0 +1,144 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_CreateCache(t *testing.T) {
+	network := config.Network{Name: "testnet", Host: "access.devnet.nodes.onflow.org:9000"}
+
+	t.Run("Memory", func(t *testing.T) {
+		cache, err := createCache(cacheMemory, network)
+		require.NoError(t, err)
+		assert.IsType(t, &gateway.MemoryCache{}, cache)
+	})
+
+	t.Run("Disk", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("cache directory is set with XDG_CACHE_HOME")
+		}
+
+		dir := t.TempDir()
+		t.Setenv("XDG_CACHE_HOME", dir)
+
+		cache, err := createCache(cacheDisk, network)
+		require.NoError(t, err)
+		require.NoError(t, cache.Set("foo", []byte("bar"), 0))
+
+		entries, err := os.ReadDir(filepath.Join(dir, "flow-cli", "gateway", "testnet-access.devnet.nodes.onflow.org_9000"))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := createCache("redis", network)
+		assert.EqualError(t, err, "invalid cache redis, options: none, memory, disk")
+	})
+}
+
+func Test_OperationsGateway(t *testing.T) {
+	rw, _ := tests.ReaderWriter()
+
+	t.Run("Not operations mode", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+
+		result, err := operationsGateway(gw.Mock, rw, util.AllowlistFile)
+		require.NoError(t, err)
+		assert.Equal(t, gw.Mock, result)
+	})
+
+	require.NoError(t, util.Allowlist{{File: "tx.cdc", Hash: "abc"}}.Save(rw, util.AllowlistFile))
+
+	t.Run("Operations mode", func(t *testing.T) {
+		gw := mocks.DefaultMockGateway()
+
+		result, err := operationsGateway(gw.Mock, rw, util.AllowlistFile)
+		require.NoError(t, err)
+		assert.IsType(t, &gateway.AllowlistGateway{}, result)
+		// the network is not accessed until code is sent
+		gw.Mock.AssertNotCalled(t, "GetNetworkParameters", mock.Anything)
+	})
+
+	t.Run("Fail invalid allowlist", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("invalid.json", []byte("{"), 0644))
+
+		_, err := operationsGateway(mocks.DefaultMockGateway().Mock, rw, "invalid.json")
+		assert.ErrorContains(t, err, "failed to parse allowlist file invalid.json")
+	})
+}
+
+func Test_ParseVariables(t *testing.T) {
+	variables, err := parseVariables([]string{"feature_x=true", "mocks=false", "debug"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"feature_x": true, "mocks": false, "debug": true}, variables)
+
+	_, err = parseVariables([]string{"feature_x=yes"})
+	assert.EqualError(t, err, "invalid value of variable feature_x, expected true or false")
+
+	_, err = parseVariables([]string{"!mocks=true"})
+	assert.EqualError(t, err, "invalid variable name in !mocks=true")
+
+	_, err = parseVariables([]string{"=true"})
+	assert.EqualError(t, err, "invalid variable name in =true")
+}
+
+func Test_ServeMetrics(t *testing.T) {
+	m := gateway.NewMock()
+	m.GetLatestBlockFunc = func(ctx context.Context) (*flow.Block, error) {
+		return tests.NewBlock(), nil
+	}
+
+	gw, server, err := serveMetrics("127.0.0.1:0", m)
+	require.NoError(t, err)
+	defer server.Close()
+
+	_, err = gw.GetLatestBlock(context.Background())
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	server.Handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, metricsPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, strings.Contains(recorder.Body.String(), `flow_gateway_calls_total{method="GetLatestBlock"} 1`))
+}
+
+func Test_NewRequestID(t *testing.T) {
+	id := newRequestID()
+	assert.Len(t, id, 16)
+	assert.NotEqual(t, id, newRequestID())
+}
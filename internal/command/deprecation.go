@@ -0,0 +1,128 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/i18n"
+)
+
+// Deprecation describes a deprecated command flag or usage, what replaces it and when it is going to be removed.
+//
+// A deprecated usage not tied to a flag is described by Usage and detected from the command arguments by Used.
+type Deprecation struct {
+	Flag        string                   // name of the deprecated flag
+	Usage       string                   // description of the deprecated usage, if not deprecating a flag
+	Used        func(args []string) bool // reports whether the arguments use the deprecated usage
+	Replacement string                   // usage replacing the flag, e.g. "--args-json"
+	Since       string                   // version in which the flag was deprecated
+	RemovedIn   string                   // version in which the flag is scheduled to be removed
+}
+
+// Message returns the deprecation warning.
+func (d Deprecation) Message() string {
+	message := fmt.Sprintf("flag --%s is deprecated", d.Flag)
+	if d.Flag == "" {
+		message = fmt.Sprintf("%s is deprecated", d.Usage)
+	}
+	if d.Since != "" {
+		message += fmt.Sprintf(" since %s", d.Since)
+	}
+	if d.RemovedIn != "" {
+		message += fmt.Sprintf(" and will be removed in %s", d.RemovedIn)
+	}
+	if d.Replacement != "" {
+		message += fmt.Sprintf(", use %s instead", d.Replacement)
+	}
+	return message
+}
+
+// JSON returns the machine-readable deprecation warning.
+func (d Deprecation) JSON() map[string]string {
+	return map[string]string{
+		"warning":     "deprecation",
+		"flag":        d.Flag,
+		"usage":       d.Usage,
+		"replacement": d.Replacement,
+		"since":       d.Since,
+		"removedIn":   d.RemovedIn,
+		"message":     d.Message(),
+	}
+}
+
+// annotateDeprecations adds the deprecation notice to the usage of each deprecated flag.
+//
+// The flags stay visible in the help, unlike flags deprecated with cobra, so the replacement can be discovered.
+func annotateDeprecations(cmd *cobra.Command, deprecations []Deprecation) {
+	for _, d := range deprecations {
+		if d.Flag == "" {
+			continue
+		}
+
+		flag := cmd.Flags().Lookup(d.Flag)
+		if flag == nil {
+			panic(fmt.Sprintf("deprecated flag --%s is not defined on command %s", d.Flag, cmd.Name()))
+		}
+
+		notice := i18n.T("deprecated")
+		if d.Replacement != "" {
+			notice = fmt.Sprintf(i18n.T("deprecated, use %s instead"), d.Replacement)
+		}
+		flag.Usage = fmt.Sprintf("%s (%s)", i18n.T(flag.Usage), notice)
+	}
+}
+
+// usedDeprecations returns the deprecations of the flags provided and the usages matching the arguments
+// when invoking the command.
+func usedDeprecations(cmd *cobra.Command, args []string, deprecations []Deprecation) []Deprecation {
+	used := make([]Deprecation, 0)
+	for _, d := range deprecations {
+		if d.Flag == "" {
+			if d.Used != nil && d.Used(args) {
+				used = append(used, d)
+			}
+			continue
+		}
+
+		if flag := cmd.Flags().Lookup(d.Flag); flag != nil && flag.Changed {
+			used = append(used, d)
+		}
+	}
+	return used
+}
+
+// warnDeprecations emits a warning for each used deprecation, as one JSON object per line on the
+// writer when the JSON output format is used, since logging is then disabled.
+func warnDeprecations(deprecations []Deprecation, logger output.Logger, writer io.Writer, format string) {
+	for _, d := range deprecations {
+		if format == formatJSON {
+			warning, _ := json.Marshal(d.JSON())
+			_, _ = fmt.Fprintln(writer, string(warning))
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("%s  Deprecation warning: %s.", output.WarningEmoji(), d.Message()))
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+func TestDeprecations(t *testing.T) {
+	deprecations := []Deprecation{{
+		Flag:        "arg",
+		Replacement: "--args-json",
+		Since:       "v1.2.0",
+		RemovedIn:   "v2.0.0",
+	}, {
+		Flag: "results",
+	}}
+
+	cmd := &cobra.Command{Use: "send"}
+	cmd.Flags().String("arg", "", "argument in Type:Value format")
+	cmd.Flags().Bool("results", false, "display the results")
+	cmd.Flags().String("args-json", "", "arguments in JSON-Cadence format")
+	annotateDeprecations(cmd, deprecations)
+	require.NoError(t, cmd.ParseFlags([]string{"--arg", "String:foo"}))
+
+	t.Run("Annotate Usage", func(t *testing.T) {
+		assert.Equal(t, "argument in Type:Value format (deprecated, use --args-json instead)", cmd.Flags().Lookup("arg").Usage)
+		assert.Equal(t, "display the results (deprecated)", cmd.Flags().Lookup("results").Usage)
+	})
+
+	t.Run("Used Deprecations", func(t *testing.T) {
+		used := usedDeprecations(cmd, []string{}, deprecations)
+		require.Len(t, used, 1)
+		assert.Equal(t, "flag --arg is deprecated since v1.2.0 and will be removed in v2.0.0, use --args-json instead", used[0].Message())
+		assert.Equal(t, "flag --results is deprecated", deprecations[1].Message())
+	})
+
+	t.Run("Warn JSON", func(t *testing.T) {
+		var b bytes.Buffer
+		warnDeprecations(usedDeprecations(cmd, []string{}, deprecations), output.NewStdoutLogger(output.NoneLog), &b, formatJSON)
+
+		assert.JSONEq(t, `{
+			"warning": "deprecation",
+			"flag": "arg",
+			"usage": "",
+			"replacement": "--args-json",
+			"since": "v1.2.0",
+			"removedIn": "v2.0.0",
+			"message": "flag --arg is deprecated since v1.2.0 and will be removed in v2.0.0, use --args-json instead"
+		}`, b.String())
+	})
+
+	t.Run("Fail Strict", func(t *testing.T) {
+		err := checkStrict(cmd, []string{}, nil, usedDeprecations(cmd, []string{}, deprecations))
+		assert.EqualError(t, err, "strict mode violations:\n  - flag --arg is deprecated since v1.2.0 and will be removed in v2.0.0, use --args-json instead")
+	})
+
+	t.Run("Used Usage Deprecation", func(t *testing.T) {
+		usage := []Deprecation{{
+			Usage:       "sending without arguments",
+			Used:        func(args []string) bool { return len(args) == 0 },
+			Replacement: "'flow dev'",
+		}}
+		annotateDeprecations(cmd, usage)
+
+		used := usedDeprecations(cmd, []string{}, usage)
+		require.Len(t, used, 1)
+		assert.Equal(t, "sending without arguments is deprecated, use 'flow dev' instead", used[0].Message())
+		assert.Empty(t, usedDeprecations(cmd, []string{"tx.cdc"}, usage))
+	})
+
+	t.Run("Fail Undefined Flag", func(t *testing.T) {
+		assert.Panics(t, func() {
+			annotateDeprecations(&cobra.Command{Use: "get"}, []Deprecation{{Flag: "missing"}})
+		})
+	})
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -41,6 +43,26 @@ var Flags = GlobalFlags{
 	Yes:              false,
 	ConfigPaths:      config.DefaultPaths(),
 	SkipVersionCheck: false,
+	FrozenLockfile:   false,
+	Strict:           false,
+	MaxLines:         0,
+	Full:             false,
+	Color:            output.ColorAuto,
+	Theme:            "default",
+	Plain:            false,
+	ProgressJSON:     false,
+	TimeFormat:       output.TimeISO,
+	Timezone:         "UTC",
+	Timeout:          0,
+	Cache:            cacheNone,
+	CacheTTL:         gateway.DefaultCacheTTL,
+	Vars:             []string{},
+	MaxMessageSize:   0,
+	RateLimit:        0,
+	MetricsAddress:   "",
+	ConfigLockWait:   config.DefaultLockWait,
+	Profile:          "",
+	AllowHooks:       false,
 }
 
 // InitFlags init all the global persistent flags.
@@ -74,7 +96,7 @@ func InitFlags(cmd *cobra.Command) {
 		"output",
 		"o",
 		Flags.Format,
-		"Output format, options: \"text\", \"json\", \"inline\"",
+		"Output format, options: \"text\", \"json\", \"inline\", \"csv\"",
 	)
 
 	cmd.PersistentFlags().StringVarP(
@@ -124,6 +146,166 @@ func InitFlags(cmd *cobra.Command) {
 		Flags.SkipVersionCheck,
 		"Skip version check during start up",
 	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.FrozenLockfile,
+		"frozen-lockfile",
+		"",
+		Flags.FrozenLockfile,
+		"Fail instead of updating flow.lock when external dependencies are missing or changed",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.Strict,
+		"strict",
+		"",
+		Flags.Strict,
+		"Fail on deprecated flags, unknown JSON-Cadence fields, ignored arguments and unused configuration, can be enabled by default with 'flow settings strict enable'",
+	)
+
+	cmd.PersistentFlags().IntVarP(
+		&Flags.MaxLines,
+		"max-lines",
+		"",
+		Flags.MaxLines,
+		"Maximum number of lines of the result to display",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.Full,
+		"full",
+		"",
+		Flags.Full,
+		"Display the full result without truncating or paging",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Color,
+		"color",
+		"",
+		Flags.Color,
+		"Use colors in the output, options: \"auto\", \"always\", \"never\"",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Theme,
+		"theme",
+		"",
+		Flags.Theme,
+		"Color theme, options: \"default\", \"high-contrast\"",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.Plain,
+		"plain",
+		"",
+		Flags.Plain,
+		"Screen reader friendly output without spinners, colors or tables, results are printed as key: value lines",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.ProgressJSON,
+		"progress-json",
+		"",
+		Flags.ProgressJSON,
+		"Write progress events of long operations such as deployments, block scans and tests as JSON lines to stderr",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.TimeFormat,
+		"time-format",
+		"",
+		Flags.TimeFormat,
+		"Format of the timestamps in the output, options: \"iso\", \"unix\", \"relative\"",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Timezone,
+		"timezone",
+		"",
+		Flags.Timezone,
+		"Timezone of the ISO timestamps in the output, such as \"UTC\", \"Europe/Berlin\" or \"local\"",
+	)
+
+	cmd.PersistentFlags().DurationVarP(
+		&Flags.Timeout,
+		"timeout",
+		"",
+		Flags.Timeout,
+		"Maximum duration of each request to the access node, including waiting for a transaction to be sealed, e.g. 30s, requests are not limited by default",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Cache,
+		"cache",
+		"",
+		Flags.Cache,
+		"Cache read-only access node responses, options: \"none\", \"memory\", \"disk\" (kept between runs)",
+	)
+
+	cmd.PersistentFlags().DurationVarP(
+		&Flags.CacheTTL,
+		"cache-ttl",
+		"",
+		Flags.CacheTTL,
+		"How long cached responses depending on the latest block, like accounts and script results, are used",
+	)
+
+	cmd.PersistentFlags().IntVarP(
+		&Flags.MaxMessageSize,
+		"grpc-max-message-size",
+		"",
+		Flags.MaxMessageSize,
+		"Maximum size in bytes of the messages received from the access node, overrides the network maxMessageSize configuration, defaults to 20MB",
+	)
+
+	cmd.PersistentFlags().Float64VarP(
+		&Flags.RateLimit,
+		"requests-per-second",
+		"",
+		Flags.RateLimit,
+		"Maximum number of requests per second sent to the access node, overrides the network requestsPerSecond configuration, requests are not limited by default",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.MetricsAddress,
+		"metrics-addr",
+		"",
+		Flags.MetricsAddress,
+		"Serve Prometheus metrics of the access node calls on the address under /metrics while the command runs, e.g. localhost:9090, metrics are disabled by default",
+	)
+
+	cmd.PersistentFlags().DurationVarP(
+		&Flags.ConfigLockWait,
+		"config-lock-wait",
+		"",
+		Flags.ConfigLockWait,
+		"How long to wait for another flow command saving the configuration before failing, e.g. 30s, 0 fails immediately",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Profile,
+		"profile",
+		"",
+		Flags.Profile,
+		"Profile of the configuration overriding the networks, accounts and deployments, e.g. --profile staging",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.AllowHooks,
+		"allow-hooks",
+		"",
+		Flags.AllowHooks,
+		"Run the hooks defined in the project configuration without asking to trust them",
+	)
+
+	cmd.PersistentFlags().StringSliceVarP(
+		&Flags.Vars,
+		"var",
+		"",
+		Flags.Vars,
+		"Set a variable used by the onlyIf deployment conditions, e.g. --var feature_x=true, overrides the environments configuration",
+	)
 }
 
 // bindFlags bind all the flags needed.
@@ -0,0 +1,201 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/settings"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// hookOutput is where the output of the hooks is written, stdout is kept for the command result.
+var hookOutput io.Writer = os.Stderr
+
+// projectHooks returns the project hooks of the stage matching the command.
+//
+// Hooks match a command by its name or by its full path with the parts joined by a dash,
+// so both pre-deploy and pre-project-deploy run before the project deploy command.
+func projectHooks(state *flowkit.State, stage string, cmd *cobra.Command) config.Hooks {
+	if state == nil {
+		return nil
+	}
+
+	names := []string{cmd.Name()}
+	path := strings.ReplaceAll(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "), " ", "-")
+	if path != cmd.Name() {
+		names = append(names, path)
+	}
+
+	return state.Config().Hooks.ForCommand(stage, names...)
+}
+
+// hookEnv returns the environment of the hook, exporting the context of the command it runs around.
+func hookEnv(hook config.Hook, cmd *cobra.Command, args []string, state *flowkit.State, network config.Network) []string {
+	configPaths := make([]string, 0)
+	if state != nil {
+		configPaths = state.ConfigPaths()
+	}
+
+	return append(
+		os.Environ(),
+		fmt.Sprintf("FLOW_HOOK=%s", hook.Name),
+		fmt.Sprintf("FLOW_COMMAND=%s", strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")),
+		fmt.Sprintf("FLOW_ARGS=%s", strings.Join(args, " ")),
+		fmt.Sprintf("FLOW_NETWORK=%s", network.Name),
+		fmt.Sprintf("FLOW_HOST=%s", network.Host),
+		fmt.Sprintf("FLOW_CONFIG_PATHS=%s", strings.Join(configPaths, string(filepath.ListSeparator))),
+	)
+}
+
+// hooksAllowed decides whether the project hooks are allowed to run.
+type hooksAllowed func() (bool, error)
+
+// trustHooks returns the function deciding whether the project hooks are allowed to run.
+//
+// Hooks run when allowed with the --allow-hooks flag or when the user trusted the hooks of the project. The trust is
+// recorded for the project configuration paths and the hash of the hooks, so the user is asked again in an interactive
+// terminal when the project or its hooks change. The decision is made once for all the hooks of the command.
+func trustHooks(state *flowkit.State, allowed bool) hooksAllowed {
+	decided := allowed
+	return func() (bool, error) {
+		if decided {
+			return allowed, nil
+		}
+		decided = true
+
+		project, hash, err := projectHooksHash(state)
+		if err != nil {
+			return false, err
+		}
+		if settings.HooksTrusted(project, hash) {
+			allowed = true
+			return allowed, nil
+		}
+
+		if !term.IsTerminal(int(os.Stdin.Fd())) || !util.ConfirmHooksPrompt(state.Config().Hooks) {
+			return false, nil
+		}
+		allowed = true
+		if err := settings.TrustHooks(project, hash); err != nil {
+			return allowed, fmt.Errorf("failed to save the trusted hooks: %w", err)
+		}
+		return allowed, nil
+	}
+}
+
+// projectHooksHash returns the project identified by the absolute paths of its configuration files and the hash of
+// the project hooks.
+func projectHooksHash(state *flowkit.State) (string, string, error) {
+	paths := make([]string, 0, len(state.ConfigPaths()))
+	for _, path := range state.ConfigPaths() {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return "", "", err
+		}
+		paths = append(paths, abs)
+	}
+
+	hooks, err := json.Marshal(state.Config().Hooks)
+	if err != nil {
+		return "", "", err
+	}
+	hash := sha256.Sum256(hooks)
+
+	return strings.Join(paths, string(filepath.ListSeparator)), hex.EncodeToString(hash[:]), nil
+}
+
+// runHooks executes the project hooks of the stage matching the command in the order they are matched.
+//
+// A failing hook stops the execution of the remaining hooks and returns an error, so a failing
+// pre hook prevents the command from running.
+//
+// Hooks execute commands defined by the project configuration, so they only run when allowed with the
+// --allow-hooks flag or trusted by the user for the project, otherwise they are skipped with a warning.
+func runHooks(
+	stage string,
+	cmd *cobra.Command,
+	args []string,
+	state *flowkit.State,
+	network config.Network,
+	allowed hooksAllowed,
+	logger output.Logger,
+) error {
+	hooks := projectHooks(state, stage, cmd)
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	run, err := allowed()
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		if !run {
+			logger.Info(fmt.Sprintf(
+				"%s  Skipping %s hook: %s, hooks only run with --allow-hooks or after trusting the hooks of this project when asked",
+				output.WarningEmoji(),
+				hook.Name,
+				hook.Command,
+			))
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("Running %s hook: %s", hook.Name, hook.Command))
+
+		err := runHook(hook, hookEnv(hook, cmd, args, state, network))
+		if err != nil {
+			return fmt.Errorf("%s hook failed: %w", hook.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// runHook executes the hook command using the system shell.
+func runHook(hook config.Hook, env []string) error {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	c := exec.Command(shell, flag, hook.Command)
+	c.Env = env
+	c.Stdin = os.Stdin
+	c.Stdout = hookOutput
+	c.Stderr = hookOutput
+
+	return c.Run()
+}
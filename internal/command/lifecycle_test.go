@@ -0,0 +1,78 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+func TestLifecycle(t *testing.T) {
+	l := &lifecycle{}
+	executed := make([]string, 0)
+
+	l.register("first", func() error {
+		executed = append(executed, "first")
+		return nil
+	})
+	l.register("second", func() error {
+		executed = append(executed, "second")
+		return fmt.Errorf("failure")
+	})
+
+	l.shutdown(output.NewStdoutLogger(output.NoneLog))
+	l.shutdown(output.NewStdoutLogger(output.NoneLog))
+
+	// cleanups run in reverse order, only once, and a failure doesn't prevent others from running
+	assert.Equal(t, []string{"second", "first"}, executed)
+}
+
+func TestInterrupted(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals can't be sent to the process on windows")
+	}
+
+	l := &lifecycle{}
+	stop := l.listen(output.NewStdoutLogger(output.NoneLog))
+	defer stop()
+
+	ctx := l.notify()
+	assert.NoError(t, ctx.Err())
+	assert.Equal(t, 0, l.exitCode())
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGTERM))
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("command was not interrupted")
+	}
+	assert.Equal(t, 128+int(syscall.SIGTERM), l.exitCode())
+}
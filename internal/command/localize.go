@@ -0,0 +1,47 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/onflow/flow-cli/internal/i18n"
+)
+
+// Localize translates the help texts of the command and all its subcommands to the locale
+// selected with the FLOW_LANG environment variable, texts without a translation are left unchanged.
+func Localize(cmd *cobra.Command) {
+	cmd.Short = i18n.T(cmd.Short)
+	cmd.Long = i18n.T(cmd.Long)
+
+	for _, group := range cmd.Groups() {
+		group.Title = i18n.T(group.Title)
+	}
+
+	localizeFlag := func(flag *pflag.Flag) {
+		flag.Usage = i18n.T(flag.Usage)
+	}
+	cmd.Flags().VisitAll(localizeFlag)
+	cmd.PersistentFlags().VisitAll(localizeFlag)
+
+	for _, sub := range cmd.Commands() {
+		Localize(sub)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/internal/i18n"
+)
+
+func Test_Localize(t *testing.T) {
+	t.Setenv(i18n.EnvLang, "es")
+
+	root := &cobra.Command{Use: "flow"}
+	root.PersistentFlags().String("network", "", "Network from configuration file")
+	root.AddGroup(&cobra.Group{ID: "project", Title: "🏄 Flow Project"})
+
+	sub := &cobra.Command{Use: "execute", Short: "Execute a script", Long: "Not translated"}
+	sub.Flags().String("args-json", "", "arguments in JSON-Cadence format")
+	root.AddCommand(sub)
+
+	Localize(root)
+
+	assert.Equal(t, "Red del archivo de configuración", root.PersistentFlags().Lookup("network").Usage)
+	assert.Equal(t, "🏄 Proyecto de Flow", root.Groups()[0].Title)
+	assert.Equal(t, "Ejecutar un script", sub.Short)
+	assert.Equal(t, "Not translated", sub.Long)
+	assert.Equal(t, "argumentos en formato JSON-Cadence", sub.Flags().Lookup("args-json").Usage)
+}
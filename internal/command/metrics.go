@@ -0,0 +1,60 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+// metricsPath is the path the metrics are served on.
+const metricsPath = "/metrics"
+
+// serveMetrics records the metrics of the gateway calls and serves them in the Prometheus format on the address
+// while the command is running. The returned server must be closed when the command finishes.
+func serveMetrics(address string, gw gateway.Gateway) (gateway.Gateway, *http.Server, error) {
+	registry := prometheus.NewRegistry()
+	metricsGateway, err := gateway.NewMetricsGateway(gw, registry)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Handler: mux}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			handleError("Metrics Error", err)
+		}
+	}()
+
+	return metricsGateway, server, nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+// truncateLines limits the output to the max number of lines and appends a notice about the hidden lines.
+func truncateLines(result string, maxLines int) string {
+	lines := strings.Split(result, "\n")
+	if maxLines <= 0 || len(lines) <= maxLines {
+		return result
+	}
+
+	return fmt.Sprintf(
+		"%s\n\n... %d more lines hidden, use --full to show all",
+		strings.Join(lines[:maxLines], "\n"),
+		len(lines)-maxLines,
+	)
+}
+
+// shouldPage returns true if the output is written to an interactive terminal, a pager is configured
+// using the PAGER environment variable and the result doesn't fit the terminal height.
+//
+// Results are never paged in the plain output mode.
+func shouldPage(result string) bool {
+	if os.Getenv("PAGER") == "" || output.PlainEnabled() {
+		return false
+	}
+
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return false
+	}
+
+	_, height, err := term.GetSize(fd)
+	if err != nil {
+		return false
+	}
+
+	return strings.Count(result, "\n") >= height
+}
+
+// page writes the result to the pager defined by the PAGER environment variable.
+//
+// It returns false if the pager could not be started, in which case nothing was written and the result
+// should be printed instead. Once the pager started it has shown the result, even if it exits with an error.
+func page(result string) bool {
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		return false
+	}
+
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdin = strings.NewReader(result)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Start(); err != nil {
+		return false
+	}
+
+	_ = cmd.Wait()
+	return true
+}
@@ -0,0 +1,83 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+func Test_TruncateLines(t *testing.T) {
+	t.Run("Shorter than max lines", func(t *testing.T) {
+		assert.Equal(t, "a\nb", truncateLines("a\nb", 2))
+	})
+
+	t.Run("No limit", func(t *testing.T) {
+		assert.Equal(t, "a\nb\nc", truncateLines("a\nb\nc", 0))
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		assert.Equal(
+			t,
+			"a\nb\n\n... 2 more lines hidden, use --full to show all",
+			truncateLines("a\nb\nc\nd", 2),
+		)
+	})
+}
+
+func Test_ShouldPage(t *testing.T) {
+	t.Run("No pager configured", func(t *testing.T) {
+		t.Setenv("PAGER", "")
+		assert.False(t, shouldPage("a\nb\nc"))
+	})
+
+	t.Run("Plain output", func(t *testing.T) {
+		t.Setenv("PAGER", "less")
+		output.SetPlain(true)
+		t.Cleanup(func() { output.SetPlain(false) })
+
+		assert.False(t, shouldPage("a\nb\nc"))
+	})
+
+	t.Run("Not a terminal", func(t *testing.T) {
+		// the standard output of the tests is not an interactive terminal
+		t.Setenv("PAGER", "less")
+		assert.False(t, shouldPage("a\nb\nc"))
+	})
+}
+
+func Test_Page(t *testing.T) {
+	t.Run("Pager started", func(t *testing.T) {
+		t.Setenv("PAGER", "true")
+		assert.True(t, page("result"))
+	})
+
+	t.Run("Pager exits with an error", func(t *testing.T) {
+		t.Setenv("PAGER", "false")
+		assert.True(t, page("result"))
+	})
+
+	t.Run("Pager not found", func(t *testing.T) {
+		t.Setenv("PAGER", "flow-missing-pager")
+		assert.False(t, page("result"))
+	})
+}
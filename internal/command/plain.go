@@ -0,0 +1,98 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// plainResult formats the result as stable key: value lines derived from the JSON result.
+//
+// Nested values are flattened to dot separated keys with list items keyed by their index,
+// e.g. "keys.0.weight: 1000", so every value is on its own line and can be read out or parsed in order.
+func plainResult(result Result) (string, error) {
+	data, err := json.Marshal(result.JSON())
+	if err != nil {
+		return "", err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return "", err
+	}
+
+	var lines []string
+	plainLines("", value, &lines)
+	return strings.Join(lines, "\n"), nil
+}
+
+func plainLines(key string, value any, lines *[]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			*lines = append(*lines, plainLine(key, ""))
+			return
+		}
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			plainLines(plainKey(key, k), v[k], lines)
+		}
+	case []any:
+		if len(v) == 0 {
+			*lines = append(*lines, plainLine(key, ""))
+			return
+		}
+
+		for i, item := range v {
+			plainLines(plainKey(key, fmt.Sprintf("%d", i)), item, lines)
+		}
+	case nil:
+		*lines = append(*lines, plainLine(key, ""))
+	default:
+		// multi-line values like code are escaped to keep one value per line
+		*lines = append(*lines, plainLine(key, strings.ReplaceAll(fmt.Sprintf("%v", v), "\n", `\n`)))
+	}
+}
+
+func plainKey(parent string, key string) string {
+	if parent == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", parent, key)
+}
+
+func plainLine(key string, value string) string {
+	if key == "" {
+		return value
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%s: %s", key, value), " ")
+}
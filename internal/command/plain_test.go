@@ -0,0 +1,77 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testResult struct {
+	json any
+}
+
+func (r *testResult) JSON() any        { return r.json }
+func (r *testResult) String() string   { return "text" }
+func (r *testResult) Oneliner() string { return "inline" }
+
+func Test_PlainResult(t *testing.T) {
+	t.Run("Nested values", func(t *testing.T) {
+		result := &testResult{map[string]any{
+			"address": "01cf0e2f2f715450",
+			"balance": "0.00100000",
+			"keys": []any{
+				map[string]any{"index": 0, "weight": 1000},
+			},
+			"contracts": []any{},
+			"code":      "pub fun main() {\n}",
+			"events":    nil,
+		}}
+
+		plain, err := formatResult(result, "", formatText, true)
+		require.NoError(t, err)
+		assert.Equal(t, `address: 01cf0e2f2f715450
+balance: 0.00100000
+code: pub fun main() {\n}
+contracts:
+events:
+keys.0.index: 0
+keys.0.weight: 1000`, plain)
+	})
+
+	t.Run("Scalar value", func(t *testing.T) {
+		plain, err := formatResult(&testResult{"100.0"}, "", formatText, true)
+		require.NoError(t, err)
+		assert.Equal(t, "100.0", plain)
+	})
+
+	t.Run("Only for text format", func(t *testing.T) {
+		result := &testResult{map[string]any{"a": 1}}
+
+		text, err := formatResult(result, "", formatText, false)
+		require.NoError(t, err)
+		assert.Equal(t, "text", text)
+
+		inline, err := formatResult(result, "", formatInline, true)
+		require.NoError(t, err)
+		assert.Equal(t, "inline", inline)
+	})
+}
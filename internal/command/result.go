@@ -30,7 +30,9 @@ import (
 	"golang.org/x/exp/maps"
 
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/i18n"
 )
 
 // Result interface describes all the formats for the result output.
@@ -43,6 +45,11 @@ type Result interface {
 	JSON() any
 }
 
+// CSVResult is implemented by results which can be exported as CSV rows.
+type CSVResult interface {
+	CSV() ([]byte, error)
+}
+
 // ContainsFlag checks if output flag is present for the provided field.
 func ContainsFlag(flags []string, field string) bool {
 	for _, n := range flags {
@@ -55,7 +62,7 @@ func ContainsFlag(flags []string, field string) bool {
 }
 
 // formatResult formats a result for printing.
-func formatResult(result Result, filterFlag string, formatFlag string) (string, error) {
+func formatResult(result Result, filterFlag string, formatFlag string, plain bool) (string, error) {
 	if result == nil {
 		return "", fmt.Errorf("missing result")
 	}
@@ -75,27 +82,51 @@ func formatResult(result Result, filterFlag string, formatFlag string) (string,
 		return string(jsonRes), nil
 	case formatInline:
 		return result.Oneliner(), nil
+	case formatCSV:
+		csvRes, ok := result.(CSVResult)
+		if !ok {
+			return "", fmt.Errorf("csv format is not supported by this command")
+		}
+		data, err := csvRes.CSV()
+		return string(data), err
 	default:
+		if plain {
+			return plainResult(result)
+		}
 		return result.String(), nil
 	}
 }
 
 // outputResult to selected media.
-func outputResult(result string, saveFlag string, formatFlag string, filterFlag string) error {
+//
+// Human-readable output is truncated to max lines if provided, or paged using the configured pager
+// when printed to an interactive terminal, unless the full flag is set.
+func outputResult(result string, saveFlag string, formatFlag string, filterFlag string, maxLines int, full bool) error {
 	if saveFlag != "" {
 		af := afero.Afero{
 			Fs: afero.NewOsFs(),
 		}
 
 		fmt.Printf("%s result saved to: %s \n", output.SaveEmoji(), saveFlag)
-		return af.WriteFile(saveFlag, []byte(result), 0644)
+		// saved results can contain private keys or signatures
+		return af.WriteFile(saveFlag, []byte(result), 0600)
 	}
 
-	if formatFlag == formatInline || filterFlag != "" {
+	if formatFlag == formatInline || formatFlag == formatCSV || filterFlag != "" {
 		_, _ = fmt.Fprintf(os.Stdout, "%s", result)
-	} else { // default normal output
-		_, _ = fmt.Fprintf(os.Stdout, "\n%s\n\n", result)
+		return nil
+	}
+
+	if formatFlag == formatText && !full {
+		if maxLines > 0 {
+			result = truncateLines(result, maxLines)
+		} else if shouldPage(result) && page(result) {
+			return nil
+		}
 	}
+
+	// default normal output
+	_, _ = fmt.Fprintf(os.Stdout, "\n%s\n\n", result)
 	return nil
 }
 
@@ -123,42 +154,59 @@ func handleError(description string, err error) {
 		return
 	}
 
+	// the request ID is only relevant for the errors returned by the access node
+	var gatewayErr *gateway.Error
+	networkErr := errors.As(err, &gatewayErr) || strings.Contains(err.Error(), "transport:")
+
 	// TODO(sideninja): refactor this to better handle errors not by string matching
 	// handle rpc error
 	switch t := err.(type) {
 	case *grpc.RPCError:
+		networkErr = true
 		_, _ = fmt.Fprintf(os.Stderr, "%s Grpc Error: %s \n", output.ErrorEmoji(), t.GRPCStatus().Err().Error())
 	default:
 		if errors.Is(err, config.ErrOutdatedFormat) {
-			_, _ = fmt.Fprintf(os.Stderr, "%s Config Error: %s \n", output.ErrorEmoji(), err.Error())
+			_, _ = fmt.Fprintf(os.Stderr, "%s %s: %s \n", output.ErrorEmoji(), i18n.T("Config Error"), err.Error())
 			_, _ = fmt.Fprintf(os.Stderr, "%s Please reset configuration using: 'flow init --reset'. Read more about new configuration here: https://github.com/onflow/flow-cli/releases/tag/v0.17.0", output.TryEmoji())
 		} else if errors.Is(err, config.ErrDoesNotExist) {
-			_, _ = fmt.Fprintf(os.Stderr, "%s Config Error: %s \n", output.ErrorEmoji(), err.Error())
-			_, _ = fmt.Fprintf(os.Stderr, "%s Please create configuration using: flow init", output.TryEmoji())
+			_, _ = fmt.Fprintf(os.Stderr, "%s %s: %s \n", output.ErrorEmoji(), i18n.T("Config Error"), err.Error())
+			_, _ = fmt.Fprintf(os.Stderr, "%s %s", output.TryEmoji(), i18n.T("Please create configuration using: flow init"))
 		} else if strings.Contains(err.Error(), "transport:") {
 			_, _ = fmt.Fprintf(os.Stderr, "%s %s \n", output.ErrorEmoji(), strings.Split(err.Error(), "transport:")[1])
-			_, _ = fmt.Fprintf(os.Stderr, "%s Make sure your emulator is running or connection address is correct.", output.TryEmoji())
-		} else if strings.Contains(err.Error(), "NotFound desc =") {
-			_, _ = fmt.Fprintf(os.Stderr, "%s Not Found:%s \n", output.ErrorEmoji(), strings.Split(err.Error(), "NotFound desc =")[1])
-		} else if strings.Contains(err.Error(), "code = InvalidArgument desc = ") {
-			desc := strings.Split(err.Error(), "code = InvalidArgument desc = ")
-			_, _ = fmt.Fprintf(os.Stderr, "%s Invalid argument: %s \n", output.ErrorEmoji(), desc[len(desc)-1])
+			_, _ = fmt.Fprintf(os.Stderr, "%s %s", output.TryEmoji(), i18n.T("Make sure your emulator is running or connection address is correct."))
+		} else if errors.Is(err, gateway.ErrNotFound) {
+			_, _ = fmt.Fprintf(os.Stderr, "%s Not Found: %s \n", output.ErrorEmoji(), gatewayErrorMessage(err))
+		} else if errors.Is(err, gateway.ErrInvalidArgument) {
+			_, _ = fmt.Fprintf(os.Stderr, "%s Invalid argument: %s \n", output.ErrorEmoji(), gatewayErrorMessage(err))
 			if strings.Contains(err.Error(), "is invalid for chain") {
 				_, _ = fmt.Fprintf(os.Stderr, "%s Check you are connecting to the correct network or account address you use is correct.", output.TryEmoji())
 			} else {
-				_, _ = fmt.Fprintf(os.Stderr, "%s Check your argument and flags value, you can use --help.", output.TryEmoji())
+				_, _ = fmt.Fprintf(os.Stderr, "%s %s", output.TryEmoji(), i18n.T("Check your argument and flags value, you can use --help."))
 			}
 		} else if strings.Contains(err.Error(), "invalid signature:") {
 			_, _ = fmt.Fprintf(os.Stderr, "%s Invalid signature: %s \n", output.ErrorEmoji(), strings.Split(err.Error(), "invalid signature:")[1])
 			_, _ = fmt.Fprintf(os.Stderr, "%s Check the signer private key is provided or is in the correct format. If running emulator, make sure it's using the same configuration as this command.", output.TryEmoji())
 		} else if strings.Contains(err.Error(), "signature could not be verified using public key with") {
-			_, _ = fmt.Fprintf(os.Stderr, "%s %s: %s \n", output.ErrorEmoji(), description, err)
+			_, _ = fmt.Fprintf(os.Stderr, "%s %s: %s \n", output.ErrorEmoji(), i18n.T(description), err)
 			_, _ = fmt.Fprintf(os.Stderr, "%s If you are running emulator locally make sure that the emulator was started with the same config as used in this command. \nTry restarting the emulator.", output.TryEmoji())
 		} else {
-			_, _ = fmt.Fprintf(os.Stderr, "%s %s: %s", output.ErrorEmoji(), description, err)
+			_, _ = fmt.Fprintf(os.Stderr, "%s %s: %s", output.ErrorEmoji(), i18n.T(description), err)
 		}
 	}
 
+	if networkErr && requestID != "" {
+		_, _ = fmt.Fprintf(os.Stderr, "\n%s Request ID: %s, include it when reporting the issue to the access node operator.", output.TryEmoji(), requestID)
+	}
+
 	fmt.Println()
 	os.Exit(1)
 }
+
+// gatewayErrorMessage returns the status message of a failed gateway call, without the gRPC details.
+func gatewayErrorMessage(err error) string {
+	var gatewayErr *gateway.Error
+	if errors.As(err, &gatewayErr) {
+		return gatewayErr.GRPCStatus().Message()
+	}
+	return err.Error()
+}
@@ -0,0 +1,139 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/config/json"
+	"github.com/onflow/flow-cli/flowkit/config/toml"
+)
+
+// argsJSONFlag is the name of the flag commands use to accept arguments in the JSON-Cadence format.
+const argsJSONFlag = "args-json"
+
+// checkStrict validates the command invocation when strict mode is enabled.
+//
+// Usage the CLI otherwise tolerates is reported: used deprecations, flags deprecated with cobra, unknown
+// JSON-Cadence fields, positional arguments ignored in favour of the JSON-Cadence arguments, unused configuration
+// and environment variables referenced in the configuration which are not set.
+func checkStrict(cmd *cobra.Command, args []string, state *flowkit.State, deprecations []Deprecation) error {
+	violations := make([]string, 0)
+
+	for _, d := range deprecations {
+		violations = append(violations, d.Message())
+	}
+
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if flag.Deprecated != "" {
+			violations = append(violations, fmt.Sprintf("flag --%s is deprecated: %s", flag.Name, flag.Deprecated))
+		}
+	})
+
+	if flag := cmd.Flags().Lookup(argsJSONFlag); flag != nil && flag.Changed {
+		if err := arguments.ValidateJSON(flag.Value.String()); err != nil {
+			violations = append(violations, fmt.Sprintf("invalid --%s: %s", argsJSONFlag, err))
+		}
+		if len(args) > 1 {
+			violations = append(violations, fmt.Sprintf(
+				"arguments %s are ignored when --%s is provided",
+				strings.Join(args[1:], " "),
+				argsJSONFlag,
+			))
+		}
+	}
+
+	if state != nil {
+		configViolations, err := unusedConfig(state)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, configViolations...)
+		violations = append(violations, unsetConfigEnv(state)...)
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("strict mode violations:\n  - %s", strings.Join(violations, "\n  - "))
+	}
+
+	return nil
+}
+
+// unsetConfigEnv reports the environment variables referenced in the network hosts which are not set,
+// and are therefore left unexpanded in the configuration.
+func unsetConfigEnv(state *flowkit.State) []string {
+	violations := make([]string, 0)
+	for _, network := range *state.Networks() {
+		for _, host := range network.Hosts() {
+			for _, env := range config.UnsetEnv(host) {
+				violations = append(violations, fmt.Sprintf("environment variable %s referenced by network %s is not set", env, network.Name))
+			}
+		}
+	}
+	return violations
+}
+
+// unusedConfig reports configuration entries that are ignored, which are unknown fields
+// in the configuration files and contracts that are neither deployed nor aliased.
+func unusedConfig(state *flowkit.State) ([]string, error) {
+	violations := make([]string, 0)
+
+	for _, path := range state.ConfigPaths() {
+		raw, err := state.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration %s: %w", path, err)
+		}
+
+		unknownFields := json.UnknownFields
+		if filepath.Ext(path) == ".toml" {
+			unknownFields = toml.UnknownFields
+		}
+
+		fields, err := unknownFields(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse configuration %s: %w", path, err)
+		}
+		for _, field := range fields {
+			violations = append(violations, fmt.Sprintf("unknown field \"%s\" in %s", field, path))
+		}
+	}
+
+	deployed := make(map[string]bool)
+	for _, deployment := range *state.Deployments() {
+		for _, contract := range deployment.Contracts {
+			deployed[contract.Name] = true
+		}
+	}
+
+	for _, contract := range *state.Contracts() {
+		if !deployed[contract.Name] && len(contract.Aliases) == 0 {
+			violations = append(violations, fmt.Sprintf("contract %s is neither deployed nor aliased on any network", contract.Name))
+		}
+	}
+
+	return violations, nil
+}
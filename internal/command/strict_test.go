@@ -0,0 +1,105 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/tests"
+)
+
+func newStrictTestCommand(t *testing.T, flags ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "execute"}
+	cmd.Flags().String("args-json", "", "")
+	cmd.Flags().String("arg", "", "")
+	require.NoError(t, cmd.Flags().MarkDeprecated("arg", "use --args-json instead"))
+	require.NoError(t, cmd.ParseFlags(flags))
+	return cmd
+}
+
+func TestCheckStrict(t *testing.T) {
+	t.Run("Valid Usage", func(t *testing.T) {
+		cmd := newStrictTestCommand(t, "--args-json", `[{"type": "String", "value": "foo"}]`)
+
+		err := checkStrict(cmd, []string{"script.cdc"}, nil, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail Arguments", func(t *testing.T) {
+		cmd := newStrictTestCommand(t,
+			"--arg", "String:foo",
+			"--args-json", `[{"type": "String", "value": "foo", "name": "bar"}]`,
+		)
+
+		err := checkStrict(cmd, []string{"script.cdc", "foo"}, nil, nil)
+		assert.EqualError(t, err, `strict mode violations:
+  - flag --arg is deprecated: use --args-json instead
+  - invalid --args-json: argument 0 contains unknown field "name"
+  - arguments foo are ignored when --args-json is provided`)
+	})
+
+	t.Run("Fail Unused Configuration", func(t *testing.T) {
+		rw, _ := tests.ReaderWriter()
+		require.NoError(t, rw.WriteFile("flow.json", []byte(`{
+			"contracts": {
+				"Foo": "./Foo.cdc",
+				"Bar": "./Bar.cdc",
+				"Token": {"source": "./Token.cdc", "aliases": {"testnet": "0x9a0766d93b6608b7"}}
+			},
+			"networks": {"emulator": "127.0.0.1:3569", "testnet": "access.devnet.nodes.onflow.org:9000"},
+			"accounts": {"emulator-account": {"address": "f8d6e0586b0a20c7", "key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"}},
+			"deployments": {"emulator": {"emulator-account": ["Foo"]}},
+			"deployment": {"testnet": {"emulator-account": ["Bar"]}}
+		}`), 0644))
+
+		state, err := flowkit.Load([]string{"flow.json"}, rw)
+		require.NoError(t, err)
+
+		err = checkStrict(newStrictTestCommand(t), []string{}, state, nil)
+		assert.EqualError(t, err, `strict mode violations:
+  - unknown field "deployment" in flow.json
+  - contract Bar is neither deployed nor aliased on any network`)
+	})
+
+	t.Run("Fail Unset Environment Variable", func(t *testing.T) {
+		t.Setenv("FLOW_TEST_ACCESS_HOST", "access.devnet.nodes.onflow.org")
+
+		rw, _ := tests.ReaderWriter()
+		require.NoError(t, rw.WriteFile("flow.json", []byte(`{
+			"networks": {"testnet": "${FLOW_TEST_ACCESS_HOST}:9000", "mainnet": "${FLOW_TEST_UNSET}:9000"},
+			"accounts": {"emulator-account": {"address": "f8d6e0586b0a20c7", "key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"}}
+		}`), 0644))
+
+		state, err := flowkit.Load([]string{"flow.json"}, rw)
+		require.NoError(t, err)
+
+		testnet, err := state.Networks().ByName("testnet")
+		require.NoError(t, err)
+		assert.Equal(t, "access.devnet.nodes.onflow.org:9000", testnet.Host)
+
+		err = checkStrict(newStrictTestCommand(t), []string{}, state, nil)
+		assert.EqualError(t, err, `strict mode violations:
+  - environment variable FLOW_TEST_UNSET referenced by network mainnet is not set`)
+	})
+}
@@ -0,0 +1,84 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// userDefaults returns the default flag values defined in the user configuration, by the flag name.
+func userDefaults(readerWriter flowkit.ReaderWriter, path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	raw, err := readerWriter.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var user struct {
+		Defaults map[string]string `json:"defaults"`
+	}
+	if err := json.Unmarshal(raw, &user); err != nil {
+		return nil, fmt.Errorf("invalid user configuration %s: %w", path, err)
+	}
+
+	return user.Defaults, nil
+}
+
+// applyUserDefaults sets the flags not provided on the command line to the defaults of the user configuration.
+//
+// The network default is not used if the host is provided, since both can't be used together.
+func applyUserDefaults(cmd *cobra.Command, defaults map[string]string) error {
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		if name == "network" {
+			if host := cmd.Flags().Lookup("host"); host != nil && host.Changed {
+				continue
+			}
+		}
+
+		if err := cmd.Flags().Set(name, defaults[name]); err != nil {
+			return fmt.Errorf("invalid default %s in user configuration %s: %w", name, config.UserPath(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserDefaults(t *testing.T) {
+	fs := &afero.Afero{Fs: afero.NewMemMapFs()}
+	require.NoError(t, fs.WriteFile("config.json", []byte(`{
+		"defaults": { "network": "testnet", "signer": "alice", "output": "json" },
+		"networks": { "testnet": "access.devnet.nodes.onflow.org:9000" }
+	}`), 0600))
+
+	defaults, err := userDefaults(fs, "config.json")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"network": "testnet", "signer": "alice", "output": "json"}, defaults)
+
+	missing, err := userDefaults(fs, "missing.json")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "send"}
+		cmd.Flags().String("network", "emulator", "")
+		cmd.Flags().String("host", "", "")
+		cmd.Flags().String("signer", "", "")
+		cmd.Flags().String("output", "text", "")
+		require.NoError(t, cmd.ParseFlags(args))
+		return cmd
+	}
+
+	t.Run("Apply", func(t *testing.T) {
+		cmd := newCmd("--output", "inline")
+		require.NoError(t, applyUserDefaults(cmd, defaults))
+
+		assert.Equal(t, "testnet", cmd.Flags().Lookup("network").Value.String())
+		assert.Equal(t, "alice", cmd.Flags().Lookup("signer").Value.String())
+		// flags provided on the command line take precedence
+		assert.Equal(t, "inline", cmd.Flags().Lookup("output").Value.String())
+	})
+
+	t.Run("Host", func(t *testing.T) {
+		cmd := newCmd("--host", "127.0.0.1:3569")
+		require.NoError(t, applyUserDefaults(cmd, defaults))
+		assert.Equal(t, "emulator", cmd.Flags().Lookup("network").Value.String())
+	})
+
+	t.Run("Unknown Flags", func(t *testing.T) {
+		cmd := &cobra.Command{Use: "version"}
+		require.NoError(t, applyUserDefaults(cmd, defaults))
+	})
+}
@@ -0,0 +1,273 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package completion
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	Bash = "bash"
+	Zsh  = "zsh"
+	Fish = "fish"
+)
+
+// Shells are the shells the completion can be installed for.
+var Shells = []string{Bash, Zsh, Fish}
+
+// zshFunctionsDir is the directory added to the zsh function path for the completion file.
+const zshFunctionsDir = ".zfunc"
+
+var installCommand = &cobra.Command{
+	Use:   "install [bash|zsh|fish]",
+	Short: "Install the shell completion for the current shell",
+	Long: `Install the shell completion for the current shell, or the provided shell.
+
+The completion file is written to the location the shell loads completions from, and the shell
+is started to verify the completion loads. For zsh the completion directory is added to the
+function path in ~/.zshrc if it isn't already.`,
+	Example:   "flow completion install",
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: Shells,
+	RunE:      handleInstall,
+}
+
+// rootCommand is the command the completion is generated for.
+var rootCommand *cobra.Command
+
+// AddInstallCommand adds the install command to the default completion command of the root command.
+func AddInstallCommand(root *cobra.Command) {
+	rootCommand = root
+	root.InitDefaultCompletionCmd()
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == "completion" {
+			cmd.AddCommand(installCommand)
+		}
+	}
+}
+
+func handleInstall(cmd *cobra.Command, args []string) error {
+	shell := DetectShell()
+	if len(args) > 0 {
+		shell = args[0]
+	}
+	if shell == "" {
+		return fmt.Errorf("failed to detect the shell, provide the shell as an argument: %s", strings.Join(Shells, ", "))
+	}
+
+	path, err := Install(cmd.Root(), shell)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Completion for %s was installed in %s\n", shell, path)
+
+	if shell == Zsh {
+		added, err := addZshFunctionPath()
+		if err != nil {
+			return err
+		}
+		if added {
+			fmt.Printf("Completion directory was added to the function path in %s\n", zshrcPath())
+		}
+	}
+
+	loaded, err := Verify(shell, path)
+	if err != nil {
+		fmt.Printf("Completion could not be verified: %s\n", err)
+		return nil
+	}
+	if !loaded {
+		return fmt.Errorf("completion installed in %s doesn't load in %s", path, shell)
+	}
+
+	fmt.Println("Completion loads correctly, restart the shell to use it")
+	return nil
+}
+
+// DetectShell returns the name of the shell the user is running, or an empty string if it is not supported.
+func DetectShell() string {
+	if runtime.GOOS == "windows" {
+		return ""
+	}
+
+	shell := filepath.Base(os.Getenv("SHELL"))
+	for _, s := range Shells {
+		if shell == s {
+			return s
+		}
+	}
+	return ""
+}
+
+// Path returns the location of the completion file the shell loads automatically.
+func Path(shell string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch shell {
+	case Bash:
+		dataHome := os.Getenv("XDG_DATA_HOME")
+		if dataHome == "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(dataHome, "bash-completion", "completions", "flow"), nil
+	case Zsh:
+		return filepath.Join(home, zshFunctionsDir, "_flow"), nil
+	case Fish:
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(home, ".config")
+		}
+		return filepath.Join(configHome, "fish", "completions", "flow.fish"), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %s, supported shells: %s", shell, strings.Join(Shells, ", "))
+	}
+}
+
+// Generate writes the completion script of the root command for the shell.
+func Generate(root *cobra.Command, shell string, w io.Writer) error {
+	switch shell {
+	case Bash:
+		return root.GenBashCompletionV2(w, true)
+	case Zsh:
+		return root.GenZshCompletion(w)
+	case Fish:
+		return root.GenFishCompletion(w, true)
+	default:
+		return fmt.Errorf("unsupported shell %s, supported shells: %s", shell, strings.Join(Shells, ", "))
+	}
+}
+
+// Install writes the completion file for the shell and returns its location.
+func Install(root *cobra.Command, shell string) (string, error) {
+	path, err := Path(shell)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	if err := Generate(root, shell, &b); err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create completion directory: %w", err)
+	}
+	if err := os.WriteFile(path, b.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("failed to write completion file: %w", err)
+	}
+
+	return path, nil
+}
+
+// Stale checks whether the completion file installed for the shell differs from the completion of the
+// root command, which happens when it was installed by a version of the CLI with different commands.
+//
+// A missing completion file is not stale, and no path is returned if the install command wasn't added.
+func Stale(shell string) (path string, stale bool, err error) {
+	if rootCommand == nil {
+		return "", false, nil
+	}
+
+	path, err = Path(shell)
+	if err != nil {
+		return "", false, err
+	}
+
+	installed, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return path, false, nil
+	}
+	if err != nil {
+		return path, false, err
+	}
+
+	var b bytes.Buffer
+	if err := Generate(rootCommand, shell, &b); err != nil {
+		return path, false, err
+	}
+
+	return path, !bytes.Equal(installed, b.Bytes()), nil
+}
+
+// Verify starts the shell to check the completion file loads and registers the completion for flow.
+//
+// An error is returned if the shell can't be started.
+func Verify(shell string, path string) (bool, error) {
+	var script string
+	switch shell {
+	case Bash:
+		script = fmt.Sprintf("source %q && complete -p flow", path)
+	case Zsh:
+		script = fmt.Sprintf("autoload -U compinit && compinit -u && source %q && (( $+functions[_flow] ))", path)
+	case Fish:
+		script = fmt.Sprintf("source %q; and complete -c flow | string length -q", path)
+	default:
+		return false, fmt.Errorf("unsupported shell %s", shell)
+	}
+
+	bin, err := exec.LookPath(shell)
+	if err != nil {
+		return false, fmt.Errorf("%s is not installed", shell)
+	}
+
+	err = exec.Command(bin, "-c", script).Run()
+	return err == nil, nil
+}
+
+func zshrcPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".zshrc")
+}
+
+// addZshFunctionPath adds the completion directory to the zsh function path in ~/.zshrc, unless it is
+// already there, and reports whether it was added.
+func addZshFunctionPath() (bool, error) {
+	path := zshrcPath()
+	rc, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if strings.Contains(string(rc), zshFunctionsDir) {
+		return false, nil
+	}
+
+	setup := fmt.Sprintf("\n# flow completion\nfpath=(~/%s $fpath)\nautoload -U compinit && compinit\n", zshFunctionsDir)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(setup); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,92 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package completion
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Install(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+
+	root := &cobra.Command{Use: "flow"}
+	root.AddCommand(&cobra.Command{Use: "deploy", Run: func(*cobra.Command, []string) {}})
+	AddInstallCommand(root)
+
+	t.Run("Detect Shell", func(t *testing.T) {
+		t.Setenv("SHELL", "/usr/bin/zsh")
+		assert.Equal(t, Zsh, DetectShell())
+
+		t.Setenv("SHELL", "/bin/tcsh")
+		assert.Equal(t, "", DetectShell())
+	})
+
+	t.Run("Paths", func(t *testing.T) {
+		path, err := Path(Zsh)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(home, ".zfunc", "_flow"), path)
+
+		path, err = Path(Fish)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(home, "config", "fish", "completions", "flow.fish"), path)
+
+		_, err = Path("tcsh")
+		assert.EqualError(t, err, "unsupported shell tcsh, supported shells: bash, zsh, fish")
+	})
+
+	t.Run("Install Bash", func(t *testing.T) {
+		path, stale, err := Stale(Bash)
+		require.NoError(t, err)
+		assert.False(t, stale)
+
+		installed, err := Install(root, Bash)
+		require.NoError(t, err)
+		assert.Equal(t, path, installed)
+
+		_, stale, err = Stale(Bash)
+		require.NoError(t, err)
+		assert.False(t, stale)
+
+		if _, err := exec.LookPath(Bash); err != nil {
+			t.Skip("requires bash")
+		}
+		loaded, err := Verify(Bash, installed)
+		require.NoError(t, err)
+		assert.True(t, loaded)
+	})
+
+	t.Run("Zsh Function Path", func(t *testing.T) {
+		added, err := addZshFunctionPath()
+		require.NoError(t, err)
+		assert.True(t, added)
+
+		added, err = addZshFunctionPath()
+		require.NoError(t, err)
+		assert.False(t, added)
+	})
+}
@@ -97,6 +97,12 @@ func addAccount(
 		Key:     accountKey,
 	}
 
+	unlock, err := state.LockConfig()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	hexKey := accounts.NewHexKeyFromPrivateKey(account.Key.Index, account.Key.HashAlgo, account.Key.PrivateKey)
 	state.Accounts().AddOrUpdate(&accounts.Account{
 		Name:    account.Name,
@@ -94,6 +94,12 @@ func addContract(
 		)
 	}
 
+	unlock, err := state.LockConfig()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	state.Contracts().AddOrUpdate(contract)
 
 	err = state.SaveEdited(globalFlags.ConfigPaths)
@@ -65,6 +65,12 @@ func addDeployment(
 		raw = util.NewDeploymentPrompt(*state.Networks(), state.Config().Accounts, *state.Contracts())
 	}
 
+	unlock, err := state.LockConfig()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	deployment := state.Deployments().ByAccountAndNetwork(raw.Account, raw.Network)
 	if deployment == nil {
 		// add deployment if non-existing
@@ -66,6 +66,12 @@ func addNetwork(
 		raw = util.NewNetworkPrompt()
 	}
 
+	unlock, err := state.LockConfig()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	state.Networks().AddOrUpdate(config.Network{
 		Name: raw["name"],
 		Host: raw["host"],
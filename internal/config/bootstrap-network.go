@@ -0,0 +1,222 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsBootstrapNetwork struct {
+	Name       string `default:"private" flag:"name" info:"Network name"`
+	Host       string `default:"" flag:"host" info:"Flow Access API gRPC host address"`
+	ServiceKey string `default:"" flag:"service-key" info:"Hex encoded private key of the network service account"`
+	SigAlgo    string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the service key"`
+}
+
+var bootstrapNetworkFlags = flagsBootstrapNetwork{}
+
+var bootstrapNetworkCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "bootstrap-network",
+		Short:   "Add a remote emulator or private network and its service account to the configuration",
+		Example: "flow config bootstrap-network --name private --host 10.0.0.5:3569 --service-key 1a2b...",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &bootstrapNetworkFlags,
+	RunS:  bootstrapNetwork,
+}
+
+// noopTransaction is sent by the service account to validate the key can sign for it.
+const noopTransaction = `transaction { prepare(signer: AuthAccount) {} }`
+
+// bootstrapChains are probed for the service account, networks such as localnet
+// share the service address with the emulator.
+var bootstrapChains = []flowsdk.ChainID{
+	flowsdk.Emulator,
+	flowsdk.Testnet,
+	flowsdk.Sandboxnet,
+	flowsdk.Mainnet,
+}
+
+func bootstrapNetwork(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if bootstrapNetworkFlags.Host == "" || bootstrapNetworkFlags.ServiceKey == "" {
+		return nil, fmt.Errorf("the --host and --service-key flags are required")
+	}
+
+	network := config.Network{
+		Name: bootstrapNetworkFlags.Name,
+		Host: bootstrapNetworkFlags.Host,
+	}
+	gw, err := command.GatewayFactory(state, state.ReaderWriter())(network)
+	if err != nil {
+		return nil, err
+	}
+	if closer, ok := gw.(io.Closer); ok {
+		defer func() { _ = closer.Close() }()
+	}
+
+	flow := flowkit.NewFlowkit(state, network, gw, output.NewStdoutLogger(output.NoneLog))
+	result, err := bootstrap(flow, state, logger, bootstrapNetworkFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// bootstrap probes the network for the service account of the key, validates it can sign
+// transactions and adds the network and the service account to the state.
+func bootstrap(
+	flow flowkit.Services,
+	state *flowkit.State,
+	logger output.Logger,
+	flags flagsBootstrapNetwork,
+) (*result, error) {
+	sigAlgo := crypto.StringToSignatureAlgorithm(flags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm %s", flags.SigAlgo)
+	}
+	privateKey, err := crypto.DecodePrivateKeyHex(sigAlgo, strings.TrimPrefix(flags.ServiceKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid service key: %w", err)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Probing network at %s...", flow.Network().Host))
+	defer logger.StopProgress()
+
+	if err := flow.Ping(); err != nil {
+		return nil, fmt.Errorf("network at %s is not reachable: %w", flow.Network().Host, err)
+	}
+
+	chainID, address, key, err := findServiceAccount(flow, privateKey.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	account := &accounts.Account{
+		Name:    fmt.Sprintf("%s-service", flags.Name),
+		Address: address,
+		Key:     accounts.NewHexKeyFromPrivateKey(key.Index, key.HashAlgo, privateKey),
+	}
+
+	logger.StartProgress("Validating the service key by sending a transaction...")
+	_, txResult, err := flow.SendTransaction(
+		context.Background(),
+		transactions.AccountRoles{
+			Proposer:    *account,
+			Authorizers: []accounts.Account{*account},
+			Payer:       *account,
+		},
+		flowkit.Script{Code: []byte(noopTransaction)},
+		flowsdk.DefaultTransactionGasLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send the validation transaction: %w", err)
+	}
+	if txResult.Error != nil {
+		return nil, fmt.Errorf("validation transaction failed: %w", txResult.Error)
+	}
+
+	// keep the service key out of the configuration file
+	keyFile := fmt.Sprintf("%s.pkey", account.Name)
+	err = util.WritePrivateFile(keyFile, []byte(privateKey.String()), state.ReaderWriter())
+	if err != nil {
+		return nil, fmt.Errorf("failed saving service key: %w", err)
+	}
+	err = util.AddToGitIgnore(keyFile, state.ReaderWriter())
+	if err != nil {
+		return nil, err
+	}
+	account.Key = accounts.NewFileKey(keyFile, key.Index, key.SigAlgo, key.HashAlgo)
+
+	unlock, err := state.LockConfig()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	state.Networks().AddOrUpdate(flow.Network())
+	state.Accounts().AddOrUpdate(account)
+
+	return &result{
+		result: fmt.Sprintf(
+			"Network %s (chain %s) and service account %s with address 0x%s added to the configuration",
+			flow.Network().Name,
+			chainID,
+			account.Name,
+			address.Hex(),
+		),
+	}, nil
+}
+
+// findServiceAccount derives the chain ID by finding the service account holding the public key.
+func findServiceAccount(
+	flow flowkit.Services,
+	publicKey crypto.PublicKey,
+) (flowsdk.ChainID, flowsdk.Address, *flowsdk.AccountKey, error) {
+	var found []string
+	for _, chainID := range bootstrapChains {
+		address := flowsdk.ServiceAddress(chainID)
+		account, err := flow.GetAccount(context.Background(), address)
+		if err != nil {
+			continue // the service address of other chains doesn't exist
+		}
+
+		for _, key := range account.Keys {
+			if !key.Revoked && key.PublicKey.Equals(publicKey) {
+				return chainID, address, key, nil
+			}
+		}
+		found = append(found, fmt.Sprintf("0x%s", address.Hex()))
+	}
+
+	if len(found) > 0 {
+		return "", flowsdk.EmptyAddress, nil, fmt.Errorf(
+			"the service key doesn't match any active key of the service account %s",
+			strings.Join(found, ", "),
+		)
+	}
+	return "", flowsdk.EmptyAddress, nil, fmt.Errorf("no service account found on the network")
+}
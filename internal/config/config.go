@@ -30,8 +30,12 @@ var Cmd = &cobra.Command{
 
 func init() {
 	initCommand.AddToParent(Cmd)
+	bootstrapNetworkCommand.AddToParent(Cmd)
+	showCommand.AddToParent(Cmd)
 	Cmd.AddCommand(addCmd)
 	Cmd.AddCommand(removeCmd)
+	Cmd.AddCommand(validateCmd)
+	Cmd.AddCommand(globalCmd)
 }
 
 type result struct {
@@ -0,0 +1,168 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_BootstrapNetwork(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	serviceKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte("seedseedseedseedseedseedseedseedseedseed"))
+	require.NoError(t, err)
+	otherKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte("otherseedotherseedotherseedotherseedother"))
+	require.NoError(t, err)
+
+	network := config.Network{Name: "private", Host: "10.0.0.5:3569"}
+	srv.Network.Return(network)
+	srv.Ping.Return(nil)
+
+	// only the testnet service account exists on the network
+	serviceAddress := flow.ServiceAddress(flow.Testnet)
+	serviceAccount := func(key crypto.PrivateKey) {
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			address := args.Get(1).(flow.Address)
+			if address != serviceAddress {
+				srv.GetAccount.Return(nil, fmt.Errorf("account not found"))
+				return
+			}
+			account := tests.NewAccountWithAddress(address.String())
+			account.Keys = []*flow.AccountKey{{
+				Index:     0,
+				PublicKey: key.PublicKey(),
+				SigAlgo:   crypto.ECDSA_P256,
+				HashAlgo:  crypto.SHA2_256,
+				Weight:    flow.AccountKeyWeightThreshold,
+			}}
+			srv.GetAccount.Return(account, nil)
+		})
+	}
+
+	flags := flagsBootstrapNetwork{Name: "private", ServiceKey: serviceKey.String(), SigAlgo: "ECDSA_P256"}
+
+	t.Run("Success", func(t *testing.T) {
+		serviceAccount(serviceKey)
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			assert.Equal(t, serviceAddress, roles.Payer.Address)
+			script := args.Get(2).(flowkit.Script)
+			assert.Equal(t, noopTransaction, string(script.Code))
+		}).Return(nil, &flow.TransactionResult{Status: flow.TransactionStatusSealed}, nil)
+
+		result, err := bootstrap(srv.Mock, state, util.NoLogger, flags)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(
+			"Network private (chain flow-testnet) and service account private-service with address 0x%s added to the configuration",
+			serviceAddress.Hex(),
+		), result.String())
+
+		saved, err := state.Networks().ByName("private")
+		require.NoError(t, err)
+		assert.Equal(t, network.Host, saved.Host)
+
+		account, err := state.Accounts().ByName("private-service")
+		require.NoError(t, err)
+		assert.Equal(t, serviceAddress, account.Address)
+		assert.Equal(t, crypto.SHA2_256, account.Key.HashAlgo())
+		assert.Equal(t, "private-service.pkey", account.Key.ToConfig().Location)
+
+		key, err := rw.ReadFile("private-service.pkey")
+		require.NoError(t, err)
+		assert.Equal(t, serviceKey.String(), string(key))
+	})
+
+	t.Run("Fail key mismatch", func(t *testing.T) {
+		serviceAccount(otherKey)
+
+		_, err := bootstrap(srv.Mock, state, util.NoLogger, flags)
+		assert.EqualError(t, err, fmt.Sprintf(
+			"the service key doesn't match any active key of the service account 0x%s",
+			serviceAddress.Hex(),
+		))
+	})
+}
+
+func Test_Show(t *testing.T) {
+	_, _, rw := util.TestMocks(t)
+
+	require.NoError(t, rw.WriteFile("flow.json", []byte(`{
+		"networks": { "testnet": "access.devnet.nodes.onflow.org:9000" },
+		"contracts": { "Foo": { "source": "./foo.cdc", "aliases": { "testnet": "9a0766d93b6608b7" } } }
+	}`), 0644))
+	require.NoError(t, rw.WriteFile("private.json", []byte(`{
+		"networks": { "testnet": "access.testnet.nodes.onflow.org:9000" }
+	}`), 0644))
+
+	state, err := flowkit.Load([]string{"flow.json", "private.json"}, rw)
+	require.NoError(t, err)
+
+	t.Run("Files", func(t *testing.T) {
+		showFlags.Resolved = false
+		result, err := show(nil, command.GlobalFlags{}, util.NoLogger, nil, state)
+		require.NoError(t, err)
+		assert.Equal(t, "flow.json, private.json", result.Oneliner())
+		assert.Contains(t, result.String(), "# private.json\n{")
+	})
+
+	t.Run("Resolved", func(t *testing.T) {
+		showFlags.Resolved = true
+		defer func() { showFlags.Resolved = false }()
+
+		result, err := show(nil, command.GlobalFlags{}, util.NoLogger, nil, state)
+		require.NoError(t, err)
+
+		out := result.String()
+		assert.Contains(t, out, "access.testnet.nodes.onflow.org:9000")
+		assert.Contains(t, out, "9a0766d93b6608b7")
+		assert.Contains(t, out, "Overridden values:\n  - networks.testnet defined in flow.json is overridden by private.json")
+	})
+
+	t.Run("Private", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("flow.private.json", []byte(`{
+			"accounts": { "alice": { "address": "01cf0e2f2f715450", "key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7" } }
+		}`), 0644))
+
+		state, err := flowkit.Load([]string{"flow.json"}, rw)
+		require.NoError(t, err)
+		assert.Equal(t, "flow.private.json", state.PrivateConfigPath())
+
+		showFlags.Resolved = true
+		defer func() { showFlags.Resolved = false }()
+
+		result, err := show(nil, command.GlobalFlags{}, util.NoLogger, nil, state)
+		require.NoError(t, err)
+		assert.Contains(t, result.String(), "# resolved from flow.json, flow.private.json\n")
+		assert.Contains(t, result.String(), "01cf0e2f2f715450")
+	})
+}
@@ -0,0 +1,250 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	configJson "github.com/onflow/flow-cli/flowkit/config/json"
+)
+
+// globalCmd commands are not command.Command, so an invalid user configuration can still be fixed with them.
+var globalCmd = &cobra.Command{
+	Use:   "global",
+	Short: "Manage the user configuration shared by all projects",
+	Long: `Manage the user configuration in ~/.flow/config.json shared by all the projects of the user.
+
+The user configuration can define networks and accounts, which are used by the projects that don't
+define them, and the defaults of the command flags, such as the network, signer or output,
+which are used if the flag is not provided:
+
+  {
+    "defaults": { "network": "testnet", "signer": "alice", "output": "json" },
+    "networks": { "testnet": "access.devnet.nodes.onflow.org:9000" },
+    "accounts": { "alice": { "address": "179b6b1cb6755e31", "key": "$ALICE_KEY" } }
+  }`,
+	TraverseChildren: true,
+}
+
+var globalSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a value in the user configuration",
+	Long: `Set a value in the user configuration at the key, with the nested fields separated by dots.
+
+Values starting with { or [ are parsed as JSON, other values are set as strings.`,
+	Example: `flow config global set defaults.network testnet
+flow config global set networks.testnet access.devnet.nodes.onflow.org:9000
+flow config global set accounts.alice '{"address": "179b6b1cb6755e31", "key": "$ALICE_KEY"}'`,
+	Args:          cobra.ExactArgs(2),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		path := config.UserPath()
+		if err := setUserValue(&afero.Afero{Fs: afero.NewOsFs()}, path, args[0], args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Set %s in the user configuration %s\n", args[0], path)
+		return nil
+	},
+}
+
+var globalGetCmd = &cobra.Command{
+	Use:   "get [<key>]",
+	Short: "Get a value from the user configuration",
+	Long:  "Get a value from the user configuration at the key, with the nested fields separated by dots, or the whole user configuration if no key is provided.",
+	Example: `flow config global get defaults.network
+flow config global get accounts`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		key := ""
+		if len(args) == 1 {
+			key = args[0]
+		}
+		value, err := getUserValue(&afero.Afero{Fs: afero.NewOsFs()}, config.UserPath(), key)
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+		return nil
+	},
+}
+
+func init() {
+	globalCmd.AddCommand(globalSetCmd)
+	globalCmd.AddCommand(globalGetCmd)
+}
+
+// userSections are the top level fields allowed in the user configuration.
+var userSections = map[string]bool{"$schema": true, "defaults": true, "networks": true, "accounts": true}
+
+// readUserConfig reads the user configuration, an empty configuration is returned if it doesn't exist.
+func readUserConfig(readerWriter flowkit.ReaderWriter, path string) (map[string]any, error) {
+	raw, err := readerWriter.ReadFile(path)
+	if os.IsNotExist(err) {
+		return make(map[string]any), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	doc := make(map[string]any)
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("invalid user configuration %s: %w", path, err)
+	}
+	return doc, nil
+}
+
+// setUserValue sets the value at the key of the user configuration, and saves it if it is still valid.
+func setUserValue(fs *afero.Afero, path string, key string, value string) error {
+	doc, err := readUserConfig(fs, path)
+	if err != nil {
+		return err
+	}
+
+	fields := strings.Split(key, ".")
+	for _, field := range fields {
+		if field == "" {
+			return fmt.Errorf("invalid key %s, separate the nested fields with dots, e.g. defaults.network", key)
+		}
+	}
+
+	var parsed any = value
+	if trimmed := strings.TrimSpace(value); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &parsed); err != nil {
+			return fmt.Errorf("invalid JSON value for %s: %w", key, err)
+		}
+	}
+
+	node := doc
+	for i, field := range fields[:len(fields)-1] {
+		child, ok := node[field].(map[string]any)
+		if !ok {
+			if _, exists := node[field]; exists {
+				return fmt.Errorf("%s is not an object", strings.Join(fields[:i+1], "."))
+			}
+			child = make(map[string]any)
+			node[field] = child
+		}
+		node = child
+	}
+	node[fields[len(fields)-1]] = parsed
+
+	if err := validateUserConfig(doc); err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(doc, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := fs.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// the user configuration can contain private keys so it should only be accessible by the owner
+	return fs.WriteFile(path, raw, 0600)
+}
+
+// validateUserConfig checks the user configuration only contains string defaults, and networks
+// and accounts in the configuration format.
+func validateUserConfig(doc map[string]any) error {
+	unknown := make([]string, 0)
+	for section := range doc {
+		if !userSections[section] {
+			unknown = append(unknown, section)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("the user configuration can only contain networks, accounts and defaults, found %s", strings.Join(unknown, ", "))
+	}
+
+	if defaults, ok := doc["defaults"]; ok {
+		flags, ok := defaults.(map[string]any)
+		if !ok {
+			return fmt.Errorf("defaults must be an object of flag names and values")
+		}
+		for name, value := range flags {
+			if _, ok := value.(string); !ok {
+				return fmt.Errorf("defaults.%s must be a string", name)
+			}
+		}
+	}
+
+	conf := make(map[string]any)
+	for section, value := range doc {
+		if section != "defaults" {
+			conf[section] = value
+		}
+	}
+	raw, err := json.Marshal(conf)
+	if err != nil {
+		return err
+	}
+	if errs := configJson.Validate(raw); len(errs) > 0 {
+		return errs
+	}
+	if _, err := configJson.NewParser().Deserialize(raw); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return nil
+}
+
+// getUserValue returns the value at the key of the user configuration, or the whole configuration if the key is empty.
+func getUserValue(readerWriter flowkit.ReaderWriter, path string, key string) (string, error) {
+	doc, err := readUserConfig(readerWriter, path)
+	if err != nil {
+		return "", err
+	}
+
+	var node any = doc
+	if key != "" {
+		for _, field := range strings.Split(key, ".") {
+			object, ok := node.(map[string]any)
+			if ok {
+				node, ok = object[field]
+			}
+			if !ok {
+				return "", fmt.Errorf("%s is not set in the user configuration %s", key, path)
+			}
+		}
+	}
+
+	if value, ok := node.(string); ok {
+		return value, nil
+	}
+
+	raw, err := json.MarshalIndent(node, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
@@ -0,0 +1,83 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Global(t *testing.T) {
+	fs := &afero.Afero{Fs: afero.NewMemMapFs()}
+	path := "/home/.flow/config.json"
+
+	t.Run("Set", func(t *testing.T) {
+		require.NoError(t, setUserValue(fs, path, "defaults.network", "testnet"))
+		require.NoError(t, setUserValue(fs, path, "networks.testnet", "access.devnet.nodes.onflow.org:9000"))
+		require.NoError(t, setUserValue(fs, path, "accounts.alice", `{
+			"address": "179b6b1cb6755e31",
+			"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+		}`))
+
+		info, err := fs.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, "-rw-------", info.Mode().Perm().String())
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		value, err := getUserValue(fs, path, "defaults.network")
+		require.NoError(t, err)
+		assert.Equal(t, "testnet", value)
+
+		value, err = getUserValue(fs, path, "accounts.alice.address")
+		require.NoError(t, err)
+		assert.Equal(t, "179b6b1cb6755e31", value)
+
+		value, err = getUserValue(fs, path, "networks")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"testnet": "access.devnet.nodes.onflow.org:9000"}`, value)
+
+		_, err = getUserValue(fs, path, "defaults.signer")
+		assert.EqualError(t, err, "defaults.signer is not set in the user configuration /home/.flow/config.json")
+	})
+
+	t.Run("Fail invalid", func(t *testing.T) {
+		err := setUserValue(fs, path, "contracts.Foo", "./foo.cdc")
+		assert.EqualError(t, err, "the user configuration can only contain networks, accounts and defaults, found contracts")
+
+		err = setUserValue(fs, path, "defaults.network.name", "testnet")
+		assert.EqualError(t, err, "defaults.network is not an object")
+
+		err = setUserValue(fs, path, "networks.testnet", `{"hots": "access.devnet.nodes.onflow.org:9000"}`)
+		assert.EqualError(t, err, "invalid configuration:\n"+
+			"  - networks.testnet: missing required field \"host\"\n"+
+			"  - networks.testnet.hots: unknown field, did you mean \"host\"?")
+
+		err = setUserValue(fs, path, "defaults", `{"yes": true}`)
+		assert.EqualError(t, err, "defaults.yes must be a string")
+
+		// the invalid values are not saved
+		value, err := getUserValue(fs, path, "defaults.network")
+		require.NoError(t, err)
+		assert.Equal(t, "testnet", value)
+	})
+}
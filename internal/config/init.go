@@ -38,6 +38,7 @@ type flagsInit struct {
 	ServiceKeyHashAlgo string `default:"SHA3_256" flag:"service-hash-algo" info:"Service account key hash algorithm"`
 	Reset              bool   `default:"false" flag:"reset" info:"Reset configuration file"`
 	Global             bool   `default:"false" flag:"global" info:"Initialize global user configuration"`
+	TOML               bool   `default:"false" flag:"toml" info:"Initialize the configuration in the TOML format as flow.toml"`
 }
 
 var InitFlag = flagsInit{}
@@ -85,7 +86,13 @@ func Initialise(
 	}
 
 	path := config.DefaultPath
+	if InitFlag.TOML {
+		path = config.DefaultTOMLPath
+	}
 	if InitFlag.Global {
+		if InitFlag.TOML {
+			return nil, fmt.Errorf("the global configuration is only supported in the JSON format")
+		}
 		path = config.GlobalPath()
 	}
 
@@ -101,6 +108,14 @@ func Initialise(
 		return nil, err
 	}
 
+	// the private configuration holds the keys of the local accounts, so it should never be committed
+	if !InitFlag.Global {
+		err = util.AddToGitIgnore(config.PrivatePath(path), readerWriter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &initResult{State: state}, nil
 }
 
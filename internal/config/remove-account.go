@@ -56,7 +56,13 @@ func removeAccount(
 		name = util.RemoveAccountPrompt(state.Config().Accounts)
 	}
 
-	err := state.Accounts().Remove(name)
+	unlock, err := state.LockConfig()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	err = state.Accounts().Remove(name)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +56,13 @@ func removeContract(
 		name = util.RemoveContractPrompt(*state.Contracts())
 	}
 
-	err := state.Contracts().Remove(name)
+	unlock, err := state.LockConfig()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	err = state.Contracts().Remove(name)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +58,13 @@ func removeDeployment(
 		account, network = util.RemoveDeploymentPrompt(*state.Deployments())
 	}
 
-	err := state.Deployments().Remove(account, network)
+	unlock, err := state.LockConfig()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	err = state.Deployments().Remove(account, network)
 	if err != nil {
 		return nil, err
 	}
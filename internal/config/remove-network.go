@@ -56,7 +56,13 @@ func removeNetwork(
 		name = util.RemoveNetworkPrompt(*state.Networks())
 	}
 
-	err := state.Networks().Remove(name)
+	unlock, err := state.LockConfig()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	err = state.Networks().Remove(name)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,178 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	configJson "github.com/onflow/flow-cli/flowkit/config/json"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsShow struct {
+	Resolved bool `default:"false" flag:"resolved" info:"Show the effective configuration merged from all the configuration files"`
+}
+
+var showFlags = flagsShow{}
+
+var showCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "show",
+		Short: "Show the configuration",
+		Long: `Show the configuration files, or the effective configuration merged from all of them.
+
+Multiple configuration files provided with the -f flag are merged from left to right, so a value
+defined in a later file overrides the value of an earlier file. Contracts are merged by field and
+their aliases by network, deployments by the deployed contracts and environments by variable,
+while emulators, networks, accounts and hooks are replaced as a whole.
+
+The private configuration next to the project configuration, such as flow.private.json for flow.json,
+is merged on top of it, its accounts override the project accounts with the same name.
+
+The networks and accounts of the user configuration in ~/.flow/config.json are added if the project
+doesn't define them.`,
+		Example: "flow config show\nflow config show --resolved -f flow.json -f private.json",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &showFlags,
+	RunS:  show,
+}
+
+func show(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if !showFlags.Resolved {
+		files := make([]configFile, 0)
+		for _, path := range state.ConfigPaths() {
+			raw, err := state.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read configuration %s: %w", path, err)
+			}
+			files = append(files, configFile{path: path, content: raw})
+		}
+		return &showResult{files: files}, nil
+	}
+
+	raw, err := configJson.NewParser().Serialize(state.Config())
+	if err != nil {
+		return nil, err
+	}
+
+	paths := state.ConfigPaths()
+	if private := state.PrivateConfigPath(); private != "" {
+		paths = append(append([]string{}, paths...), private)
+	}
+	if user := state.UserConfigPath(); user != "" {
+		paths = append(append([]string{}, paths...), user)
+	}
+
+	return &showResult{
+		resolved:  raw,
+		paths:     paths,
+		conflicts: state.ConfigConflicts(),
+	}, nil
+}
+
+type configFile struct {
+	path    string
+	content []byte
+}
+
+type showResult struct {
+	files     []configFile
+	resolved  []byte
+	paths     []string
+	conflicts []config.Conflict
+}
+
+func (r *showResult) JSON() any {
+	if r.resolved == nil {
+		files := make(map[string]string)
+		for _, file := range r.files {
+			files[file.path] = string(file.content)
+		}
+		return files
+	}
+
+	conflicts := make([]map[string]string, 0)
+	for _, c := range r.conflicts {
+		conflicts = append(conflicts, map[string]string{
+			"path":     c.Path,
+			"location": c.Location,
+			"override": c.Override,
+		})
+	}
+
+	return map[string]any{
+		"config":    json.RawMessage(r.resolved),
+		"locations": r.paths,
+		"conflicts": conflicts,
+	}
+}
+
+func (r *showResult) String() string {
+	var b bytes.Buffer
+
+	if r.resolved == nil {
+		for i, file := range r.files {
+			if i > 0 {
+				b.WriteString("\n\n")
+			}
+			_, _ = fmt.Fprintf(&b, "# %s\n%s", file.path, strings.TrimRight(string(file.content), "\n"))
+		}
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(&b, "# resolved from %s\n%s", strings.Join(r.paths, ", "), r.resolved)
+	if len(r.conflicts) > 0 {
+		b.WriteString("\n\nOverridden values:")
+		for _, c := range r.conflicts {
+			_, _ = fmt.Fprintf(&b, "\n  - %s", c)
+		}
+	}
+	return b.String()
+}
+
+func (r *showResult) Oneliner() string {
+	if r.resolved == nil {
+		paths := make([]string, len(r.files))
+		for i, file := range r.files {
+			paths[i] = file.path
+		}
+		return strings.Join(paths, ", ")
+	}
+
+	var b bytes.Buffer
+	if err := json.Compact(&b, r.resolved); err != nil {
+		return string(r.resolved)
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/config/json"
+	"github.com/onflow/flow-cli/flowkit/config/toml"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+// validateCmd is not a command.Command since those fail on an invalid configuration before running.
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the configuration",
+	Long: `Validate the configuration files against the configuration format, reporting the path of each
+invalid value with what was expected and a suggestion for misspelled fields, and then check the
+configuration references such as deployed contracts and accounts exist.`,
+	Example:       "flow config validate\nflow config validate -f flow.json -f private.json",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		paths, err := validateConfig(command.Flags.ConfigPaths, &afero.Afero{Fs: afero.NewOsFs()})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Configuration is valid: %s\n", strings.Join(paths, ", "))
+		return nil
+	},
+}
+
+// validateConfig validates the configuration at the paths and returns the validated paths, the local
+// configuration or the global one if it doesn't exist is validated for the default paths, together with
+// its private configuration if it exists.
+func validateConfig(paths []string, rw flowkit.ReaderWriter) ([]string, error) {
+	if config.IsDefaultPath(paths) {
+		candidates := append(append([]string{}, config.LocalPaths...), config.GlobalPath())
+		paths = nil
+		for _, path := range candidates {
+			if _, err := rw.ReadFile(path); err == nil {
+				paths = []string{path}
+				break
+			}
+		}
+		if paths == nil {
+			return nil, config.ErrDoesNotExist
+		}
+	}
+
+	// the private configuration is loaded on top of a single project configuration
+	files := paths
+	if len(paths) == 1 {
+		private := config.PrivatePath(paths[0])
+		if _, err := rw.ReadFile(private); err == nil {
+			files = []string{paths[0], private}
+		}
+	}
+
+	invalid := make([]string, 0)
+	for _, path := range files {
+		raw, err := rw.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration %s: %w", path, err)
+		}
+
+		validate := json.Validate
+		if filepath.Ext(path) == ".toml" {
+			validate = toml.Validate
+		}
+		if errs := validate(raw); len(errs) > 0 {
+			invalid = append(invalid, fmt.Sprintf("%s: %s", path, errs))
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, errors.New(strings.Join(invalid, "\n"))
+	}
+
+	// the format is valid, so loading reports the invalid references between the configuration entries
+	if _, err := flowkit.Load(paths, rw); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_Validate(t *testing.T) {
+	newFS := func(files map[string]string) *afero.Afero {
+		fs := &afero.Afero{Fs: afero.NewMemMapFs()}
+		for path, content := range files {
+			require.NoError(t, fs.WriteFile(path, []byte(content), 0644))
+		}
+		return fs
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		fs := newFS(map[string]string{"flow.json": `{ "contracts": { "Foo": "./foo.cdc" } }`})
+
+		paths, err := validateConfig(config.DefaultPaths(), fs)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"flow.json"}, paths)
+	})
+
+	t.Run("Valid TOML", func(t *testing.T) {
+		fs := newFS(map[string]string{"flow.toml": "[contracts]\nFoo = \"./foo.cdc\"\n"})
+
+		paths, err := validateConfig(config.DefaultPaths(), fs)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"flow.toml"}, paths)
+	})
+
+	t.Run("Fail schema", func(t *testing.T) {
+		fs := newFS(map[string]string{
+			"flow.json":    `{ "contract": { "Foo": "./foo.cdc" } }`,
+			"private.json": `{ "networks": { "testnet": { "hots": "access.devnet.nodes.onflow.org:9000" } } }`,
+		})
+
+		_, err := validateConfig([]string{"flow.json", "private.json"}, fs)
+		assert.EqualError(t, err, "flow.json: invalid configuration:\n"+
+			"  - contract: unknown field, did you mean \"contracts\"?\n"+
+			"private.json: invalid configuration:\n"+
+			"  - networks.testnet: missing required field \"host\"\n"+
+			"  - networks.testnet.hots: unknown field, did you mean \"host\"?")
+	})
+
+	t.Run("Fail references", func(t *testing.T) {
+		fs := newFS(map[string]string{"flow.json": `{ "deployments": { "testnet": { "alice": ["Foo"] } } }`})
+
+		_, err := validateConfig([]string{"flow.json"}, fs)
+		assert.EqualError(t, err, "invalid configuration: deployment contains nonexisting network testnet")
+	})
+
+	t.Run("Valid private", func(t *testing.T) {
+		fs := newFS(map[string]string{
+			"flow.json":         `{ "contracts": { "Foo": "./foo.cdc" } }`,
+			"flow.private.json": `{ "accounts": { "alice": { "address": "01cf0e2f2f715450", "key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7" } } }`,
+		})
+
+		paths, err := validateConfig(config.DefaultPaths(), fs)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"flow.json", "flow.private.json"}, paths)
+	})
+
+	t.Run("Fail private", func(t *testing.T) {
+		fs := newFS(map[string]string{
+			"flow.json":         `{ "contracts": { "Foo": "./foo.cdc" } }`,
+			"flow.private.json": `{ "acounts": {} }`,
+		})
+
+		_, err := validateConfig(config.DefaultPaths(), fs)
+		assert.EqualError(t, err, "flow.private.json: invalid configuration:\n"+
+			"  - acounts: unknown field, did you mean \"accounts\"?")
+	})
+
+	t.Run("Fail missing", func(t *testing.T) {
+		_, err := validateConfig(config.DefaultPaths(), newFS(nil))
+		assert.ErrorIs(t, err, config.ErrDoesNotExist)
+	})
+}
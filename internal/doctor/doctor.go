@@ -0,0 +1,190 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package doctor
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/completion"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsDoctor struct{}
+
+var doctorFlags = flagsDoctor{}
+
+var Command = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the project for common security and configuration issues",
+		Long: `Check the project for common security and configuration issues.
+
+Files containing private keys must only be accessible by the owner, and the installed shell
+completions must match the commands of the CLI.`,
+		Example: "flow doctor",
+		GroupID: "security",
+	},
+	Flags: &doctorFlags,
+	RunS:  doctor,
+}
+
+func doctor(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	return &result{
+		checks: append(secretFileChecks(state), completionChecks()...),
+	}, nil
+}
+
+// check is a single diagnostic performed on the project.
+type check struct {
+	name    string
+	passed  bool
+	message string
+}
+
+// secretFileChecks checks that every file containing private keys is only accessible by the owner.
+func secretFileChecks(state *flowkit.State) []check {
+	insecure := make(map[string]bool)
+	files := util.SecretFiles(state)
+	for _, file := range util.InsecureFiles(files) {
+		insecure[file] = true
+	}
+
+	checks := make([]check, 0, len(files))
+	for _, file := range files {
+		c := check{
+			name:    fmt.Sprintf("Secret file %s", file),
+			passed:  !insecure[file],
+			message: "accessible only by the owner",
+		}
+		if insecure[file] {
+			c.message = fmt.Sprintf("accessible by other users, restrict it by running 'chmod 600 %s'", file)
+		}
+		checks = append(checks, c)
+	}
+
+	return checks
+}
+
+// completionChecks checks that the installed shell completions were generated for the current commands,
+// completions installed by another version of the CLI complete removed commands and miss the new ones.
+func completionChecks() []check {
+	checks := make([]check, 0)
+	for _, shell := range completion.Shells {
+		path, stale, err := completion.Stale(shell)
+		if err != nil {
+			checks = append(checks, check{
+				name:    fmt.Sprintf("Completion for %s", shell),
+				message: err.Error(),
+			})
+			continue
+		}
+		if !config.Exists(path) {
+			continue
+		}
+
+		c := check{
+			name:    fmt.Sprintf("Completion for %s", shell),
+			passed:  !stale,
+			message: fmt.Sprintf("up to date in %s", path),
+		}
+		if stale {
+			c.message = fmt.Sprintf("outdated in %s, update it by running 'flow completion install %s'", path, shell)
+		}
+		checks = append(checks, c)
+	}
+
+	return checks
+}
+
+type result struct {
+	checks []check
+}
+
+func (r *result) failed() int {
+	failed := 0
+	for _, c := range r.checks {
+		if !c.passed {
+			failed++
+		}
+	}
+	return failed
+}
+
+func (r *result) JSON() any {
+	checks := make([]map[string]any, 0, len(r.checks))
+	for _, c := range r.checks {
+		checks = append(checks, map[string]any{
+			"name":    c.name,
+			"passed":  c.passed,
+			"message": c.message,
+		})
+	}
+
+	return map[string]any{
+		"checks": checks,
+		"failed": r.failed(),
+	}
+}
+
+func (r *result) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for _, c := range r.checks {
+		icon := output.OkEmoji()
+		if !c.passed {
+			icon = output.ErrorEmoji()
+		}
+		_, _ = fmt.Fprintf(writer, "%s %s\t%s\n", icon, c.name, c.message)
+	}
+
+	if r.failed() == 0 {
+		_, _ = fmt.Fprintf(writer, "\n%s\n", output.Green("No issues found"))
+	} else {
+		_, _ = fmt.Fprintf(writer, "\n%s\n", output.Red(fmt.Sprintf("%d issue(s) found", r.failed())))
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *result) Oneliner() string {
+	failed := make([]string, 0)
+	for _, c := range r.checks {
+		if !c.passed {
+			failed = append(failed, c.name)
+		}
+	}
+
+	return fmt.Sprintf("Failed: %s", strings.Join(failed, ", "))
+}
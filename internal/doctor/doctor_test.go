@@ -0,0 +1,91 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/completion"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Doctor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file mode permissions are not used on windows")
+	}
+
+	srv, state, _ := util.TestMocks(t)
+
+	dir := t.TempDir()
+	secure := filepath.Join(dir, "alice.pkey")
+	insecure := filepath.Join(dir, "bob.pkey")
+	require.NoError(t, os.WriteFile(secure, []byte("key"), 0600))
+	require.NoError(t, os.WriteFile(insecure, []byte("key"), 0644))
+
+	state.Config().Accounts = append(state.Config().Accounts,
+		config.Account{Name: "alice", Key: config.AccountKey{Type: config.KeyTypeFile, Location: secure}},
+		config.Account{Name: "bob", Key: config.AccountKey{Type: config.KeyTypeFile, Location: insecure}},
+	)
+
+	res, err := doctor([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+	require.NoError(t, err)
+
+	r := res.(*result)
+	require.Len(t, r.checks, 2)
+	assert.True(t, r.checks[0].passed)
+	assert.False(t, r.checks[1].passed)
+	assert.Equal(t, 1, r.failed())
+	assert.Contains(t, r.String(), "chmod 600 "+insecure)
+}
+
+func Test_DoctorCompletion(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	root := &cobra.Command{Use: "flow"}
+	root.AddCommand(&cobra.Command{Use: "deploy", Run: func(*cobra.Command, []string) {}})
+	completion.AddInstallCommand(root)
+
+	assert.Empty(t, completionChecks())
+
+	path, err := completion.Install(root, completion.Bash)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".local", "share", "bash-completion", "completions", "flow"), path)
+
+	checks := completionChecks()
+	require.Len(t, checks, 1)
+	assert.True(t, checks[0].passed)
+
+	require.NoError(t, os.WriteFile(path, []byte("complete -F _flow_removed flow"), 0644))
+	checks = completionChecks()
+	require.Len(t, checks, 1)
+	assert.False(t, checks[0].passed)
+	assert.Contains(t, checks[0].message, "flow completion install bash")
+}
@@ -0,0 +1,481 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsBackfill struct {
+	Accounts []string `default:"" flag:"accounts" info:"Comma separated addresses of the network accounts to copy into the emulator"`
+}
+
+var backfillFlags = flagsBackfill{}
+
+var BackfillCmd = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "backfill --accounts <addresses>",
+		Short: "Copy accounts from a live network into the running emulator",
+		Long: `Copy the contracts and storage of the accounts from a live network into the running emulator,
+at the emulator addresses the accounts are aliased to in the configuration.
+
+An account is aliased to the emulator address of the contracts it holds, defined by the contract
+aliases or deployments of both networks. The emulator account must be configured, so the changes can be signed.
+
+Contract imports and stored values referring to the copied accounts are updated to the aliased addresses.
+Only stored structs are copied, resources can't be created outside of their contracts and are skipped.`,
+		Example: "flow emulator backfill --network testnet --accounts 0x01,0x02",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &backfillFlags,
+	RunS:  backfill,
+}
+
+// emulatorServices connects to the running emulator configured in the state.
+var emulatorServices = func(state *flowkit.State, logger output.Logger) (flowkit.Services, error) {
+	network, err := state.Networks().ByName(config.EmulatorNetwork.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	gw, err := command.GatewayFactory(state, state.ReaderWriter())(*network)
+	if err != nil {
+		return nil, err
+	}
+
+	return flowkit.NewFlowkit(state, *network, gw, logger), nil
+}
+
+const backfillStorageScript = `
+pub struct BackfillStorage {
+	pub let values: {String: AnyStruct}
+	pub let resources: [String]
+
+	init(values: {String: AnyStruct}, resources: [String]) {
+		self.values = values
+		self.resources = resources
+	}
+}
+
+pub fun main(address: Address): BackfillStorage {
+	let account = getAuthAccount(address)
+	let values: {String: AnyStruct} = {}
+	let resources: [String] = []
+
+	account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+		if type.isSubtype(of: Type<@AnyResource>()) {
+			resources.append(path.toString())
+		} else {
+			values[path.toString()] = account.copy<AnyStruct>(from: path)!
+		}
+		return true
+	})
+
+	return BackfillStorage(values: values, resources: resources)
+}
+`
+
+const backfillSaveTransaction = `
+transaction(identifier: String, value: AnyStruct) {
+	prepare(signer: AuthAccount) {
+		let path = StoragePath(identifier: identifier)!
+		if signer.type(at: path) != nil {
+			let replaced = signer.load<AnyStruct>(from: path)
+		}
+		signer.save(value, to: path)
+	}
+}
+`
+
+func backfill(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	source := flow.Network()
+	if source.Name == config.EmulatorNetwork.Name {
+		return nil, fmt.Errorf("select the network to copy the accounts from with the --network flag")
+	}
+	if len(backfillFlags.Accounts) == 0 {
+		return nil, fmt.Errorf("provide the accounts to copy with the --accounts flag")
+	}
+
+	addresses := aliasedAddresses(state, source.Name)
+	emulatorFlow, err := emulatorServices(state, logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := emulatorFlow.Ping(); err != nil {
+		return nil, fmt.Errorf("emulator is not running, start it with 'flow emulator': %w", err)
+	}
+
+	ctx := context.Background()
+	result := &backfillResult{network: source.Name}
+	for _, a := range backfillFlags.Accounts {
+		address := flowsdk.HexToAddress(strings.TrimSpace(a))
+		target, ok := addresses[address]
+		if !ok {
+			return nil, fmt.Errorf(
+				"account 0x%s has no aliased address on the emulator, add %s and emulator aliases for its contracts to the configuration",
+				address,
+				source.Name,
+			)
+		}
+		signer, err := state.Accounts().ByAddress(target)
+		if err != nil {
+			return nil, fmt.Errorf("no account with the emulator address 0x%s is configured to sign the changes", target)
+		}
+
+		logger.StartProgress(fmt.Sprintf("Copying account 0x%s to the emulator account 0x%s...", address, target))
+		account, err := backfillAccount(ctx, flow, emulatorFlow, address, signer, addresses)
+		logger.StopProgress()
+		if err != nil {
+			return nil, err
+		}
+		result.accounts = append(result.accounts, account)
+	}
+
+	return result, nil
+}
+
+// aliasedAddresses maps the addresses of the contracts on the network to their addresses on the emulator.
+//
+// The contract addresses are defined by the contract aliases, or by the deployments of the contracts.
+func aliasedAddresses(state *flowkit.State, network string) map[flowsdk.Address]flowsdk.Address {
+	contractAddresses := func(network string) map[string]flowsdk.Address {
+		addresses := make(map[string]flowsdk.Address)
+		for _, deployment := range state.Deployments().ByNetwork(network) {
+			account, err := state.Accounts().ByName(deployment.Account)
+			if err != nil {
+				continue
+			}
+			for _, contract := range deployment.Contracts {
+				addresses[contract.Name] = account.Address
+			}
+		}
+		for _, contract := range *state.Contracts() {
+			if alias := contract.Aliases.ByNetwork(network); alias != nil {
+				addresses[contract.Name] = alias.Address
+			}
+		}
+		return addresses
+	}
+
+	emulatorAddresses := contractAddresses(config.EmulatorNetwork.Name)
+	addresses := make(map[flowsdk.Address]flowsdk.Address)
+	for name, address := range contractAddresses(network) {
+		if emulatorAddress, ok := emulatorAddresses[name]; ok {
+			addresses[address] = emulatorAddress
+		}
+	}
+
+	return addresses
+}
+
+// replaceAddresses replaces the network addresses in the code or encoded values with the aliased emulator addresses.
+func replaceAddresses(code []byte, addresses map[flowsdk.Address]flowsdk.Address) []byte {
+	for from, to := range addresses {
+		code = bytes.ReplaceAll(code, []byte("0x"+from.Hex()), []byte("0x"+to.Hex()))
+		code = bytes.ReplaceAll(code, []byte("A."+from.Hex()+"."), []byte("A."+to.Hex()+"."))
+	}
+	return code
+}
+
+// backfillAccount copies the contracts and stored structs of the network account to the emulator account of the signer.
+func backfillAccount(
+	ctx context.Context,
+	flow flowkit.Services,
+	emulatorFlow flowkit.Services,
+	address flowsdk.Address,
+	signer *accounts.Account,
+	addresses map[flowsdk.Address]flowsdk.Address,
+) (*backfilledAccount, error) {
+	account, err := flow.GetAccount(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account 0x%s: %w", address, err)
+	}
+
+	result := &backfilledAccount{
+		address: address,
+		target:  signer.Address,
+		skipped: make(map[string]string),
+	}
+
+	result.contracts, err = backfillContracts(ctx, emulatorFlow, signer, account.Contracts, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := flow.ExecuteScript(
+		ctx,
+		flowkit.Script{
+			Code: []byte(backfillStorageScript),
+			Args: []cadence.Value{cadence.NewAddress(address)},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the storage of account 0x%s: %w", address, err)
+	}
+	values, resources, err := storedValues(value)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range resources {
+		result.skipped[path] = "resources can't be copied"
+	}
+
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		err := saveValue(ctx, emulatorFlow, signer, path, values[path], addresses)
+		if err != nil {
+			result.skipped[path] = err.Error()
+			continue
+		}
+		result.storage = append(result.storage, path)
+	}
+
+	return result, nil
+}
+
+// backfillContracts adds or updates the contracts on the emulator account, returning the names of the changed contracts.
+//
+// Contracts importing each other from the same account are added once the imported contracts are,
+// so the contracts are added in rounds until all are added or a round adds none.
+func backfillContracts(
+	ctx context.Context,
+	emulatorFlow flowkit.Services,
+	signer *accounts.Account,
+	contracts map[string][]byte,
+	addresses map[flowsdk.Address]flowsdk.Address,
+) ([]string, error) {
+	existing, err := emulatorFlow.GetAccount(ctx, signer.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the emulator account 0x%s: %w", signer.Address, err)
+	}
+
+	pending := make([]string, 0, len(contracts))
+	for name, code := range contracts {
+		if !bytes.Equal(existing.Contracts[name], replaceAddresses(code, addresses)) {
+			pending = append(pending, name)
+		}
+	}
+	sort.Strings(pending)
+
+	changed := make([]string, 0, len(pending))
+	for len(pending) > 0 {
+		failed := make([]string, 0)
+		var lastErr error
+		for _, name := range pending {
+			_, _, err := emulatorFlow.AddContract(
+				ctx,
+				signer,
+				flowkit.Script{Code: replaceAddresses(contracts[name], addresses)},
+				flowkit.UpdateExistingContract(true),
+			)
+			if err != nil {
+				failed = append(failed, name)
+				lastErr = fmt.Errorf("failed to copy contract %s to the emulator account 0x%s: %w", name, signer.Address, err)
+				continue
+			}
+			changed = append(changed, name)
+		}
+
+		if len(failed) == len(pending) {
+			return nil, lastErr
+		}
+		pending = failed
+	}
+
+	return changed, nil
+}
+
+// storedValues returns the stored structs by path and the paths of the stored resources from the storage script result.
+func storedValues(value cadence.Value) (map[string]cadence.Value, []string, error) {
+	storage, ok := value.(cadence.Struct)
+	if !ok || storage.StructType == nil {
+		return nil, nil, fmt.Errorf("unexpected storage result: %s", value)
+	}
+
+	values := make(map[string]cadence.Value)
+	resources := make([]string, 0)
+	for i, field := range storage.StructType.Fields {
+		switch field.Identifier {
+		case "values":
+			dictionary, _ := storage.Fields[i].(cadence.Dictionary)
+			for _, pair := range dictionary.Pairs {
+				path, _ := pair.Key.(cadence.String)
+				values[string(path)] = pair.Value
+			}
+		case "resources":
+			array, _ := storage.Fields[i].(cadence.Array)
+			for _, path := range array.Values {
+				p, _ := path.(cadence.String)
+				resources = append(resources, string(p))
+			}
+		}
+	}
+
+	return values, resources, nil
+}
+
+// saveValue saves the value to the storage path of the emulator account, replacing the stored value.
+func saveValue(
+	ctx context.Context,
+	emulatorFlow flowkit.Services,
+	signer *accounts.Account,
+	path string,
+	value cadence.Value,
+	addresses map[flowsdk.Address]flowsdk.Address,
+) error {
+	encoded, err := jsoncdc.Encode(value)
+	if err != nil {
+		return err
+	}
+	value, err = jsoncdc.Decode(nil, replaceAddresses(encoded, addresses))
+	if err != nil {
+		return err
+	}
+
+	_, txResult, err := emulatorFlow.SendTransaction(
+		ctx,
+		transactions.AccountRoles{
+			Proposer:    *signer,
+			Authorizers: []accounts.Account{*signer},
+			Payer:       *signer,
+		},
+		flowkit.Script{
+			Code: []byte(backfillSaveTransaction),
+			Args: []cadence.Value{cadence.String(strings.TrimPrefix(path, "/storage/")), value},
+		},
+		flowsdk.DefaultTransactionGasLimit,
+	)
+	if err != nil {
+		return err
+	}
+	if txResult.Error != nil {
+		return txResult.Error
+	}
+
+	return nil
+}
+
+type backfilledAccount struct {
+	address   flowsdk.Address
+	target    flowsdk.Address
+	contracts []string
+	storage   []string
+	// skipped maps the storage paths that weren't copied to the reason.
+	skipped map[string]string
+}
+
+type backfillResult struct {
+	network  string
+	accounts []*backfilledAccount
+}
+
+var _ command.Result = &backfillResult{}
+
+func (r *backfillResult) JSON() any {
+	result := make([]map[string]any, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		result = append(result, map[string]any{
+			"address":   "0x" + account.address.Hex(),
+			"emulator":  "0x" + account.target.Hex(),
+			"contracts": account.contracts,
+			"storage":   account.storage,
+			"skipped":   account.skipped,
+		})
+	}
+	return result
+}
+
+func (r *backfillResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for i, account := range r.accounts {
+		if i > 0 {
+			_, _ = fmt.Fprintf(writer, "\n")
+		}
+		_, _ = fmt.Fprintf(writer, "Account\t%s (%s)\n", "0x"+account.address.Hex(), r.network)
+		_, _ = fmt.Fprintf(writer, "Emulator Account\t%s\n", "0x"+account.target.Hex())
+		_, _ = fmt.Fprintf(writer, "Contracts Copied\t%d\n", len(account.contracts))
+		for _, name := range account.contracts {
+			_, _ = fmt.Fprintf(writer, "\t%s\n", name)
+		}
+		_, _ = fmt.Fprintf(writer, "Storage Copied\t%d\n", len(account.storage))
+		for _, path := range account.storage {
+			_, _ = fmt.Fprintf(writer, "\t%s\n", path)
+		}
+		if len(account.skipped) > 0 {
+			_, _ = fmt.Fprintf(writer, "%s Storage Skipped\t%d\n", output.WarningEmoji(), len(account.skipped))
+			paths := make([]string, 0, len(account.skipped))
+			for path := range account.skipped {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			for _, path := range paths {
+				_, _ = fmt.Fprintf(writer, "\t%s: %s\n", path, account.skipped[path])
+			}
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *backfillResult) Oneliner() string {
+	accounts := make([]string, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		accounts = append(accounts, fmt.Sprintf(
+			"%s -> %s: %d contracts, %d storage paths, %d skipped",
+			"0x"+account.address.Hex(),
+			"0x"+account.target.Hex(),
+			len(account.contracts),
+			len(account.storage),
+			len(account.skipped),
+		))
+	}
+	return strings.Join(accounts, "; ")
+}
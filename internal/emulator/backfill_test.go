@@ -0,0 +1,125 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/mocks"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Backfill(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	defer func(original func(*flowkit.State, output.Logger) (flowkit.Services, error)) {
+		emulatorServices = original
+	}(emulatorServices)
+	t.Cleanup(func() { backfillFlags = flagsBackfill{} })
+
+	source := flowsdk.HexToAddress("7e60df042a9c0868")
+	service := flowsdk.HexToAddress("f8d6e0586b0a20c7")
+	state.Contracts().AddOrUpdate(config.Contract{
+		Name:     "Foo",
+		Location: "Foo.cdc",
+		Aliases: config.Aliases{
+			{Network: config.TestnetNetwork.Name, Address: source},
+			{Network: config.EmulatorNetwork.Name, Address: service},
+		},
+	})
+
+	srv.Network.Return(config.TestnetNetwork)
+	srv.GetAccount.Run(func(mock.Arguments) {}).Return(&flowsdk.Account{
+		Address: source,
+		Contracts: map[string][]byte{
+			"Foo": []byte("pub contract Foo {}"),
+			"Bar": []byte("import Foo from 0x7e60df042a9c0868\npub contract Bar {}"),
+		},
+	}, nil)
+	srv.ExecuteScript.Run(func(mock.Arguments) {}).Return(cadence.NewStruct([]cadence.Value{
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.String("/storage/owner"), Value: cadence.NewAddress(source)},
+		}),
+		cadence.NewArray([]cadence.Value{cadence.String("/storage/vault")}),
+	}).WithType(&cadence.StructType{
+		QualifiedIdentifier: "BackfillStorage",
+		Fields: []cadence.Field{
+			{Identifier: "values", Type: cadence.NewDictionaryType(cadence.StringType{}, cadence.AnyStructType{})},
+			{Identifier: "resources", Type: cadence.NewVariableSizedArrayType(cadence.StringType{})},
+		},
+	}), nil)
+
+	emu := mocks.DefaultMockServices()
+	emu.Ping.Return(nil)
+	emu.GetAccount.Run(func(mock.Arguments) {}).Return(&flowsdk.Account{
+		Address:   service,
+		Contracts: map[string][]byte{"Foo": []byte("pub contract Foo {}")},
+	}, nil)
+	emu.AddContract.Run(func(args mock.Arguments) {
+		assert.Equal(t, service, args.Get(1).(*accounts.Account).Address)
+		assert.Equal(t, "import Foo from 0xf8d6e0586b0a20c7\npub contract Bar {}", string(args.Get(2).(flowkit.Script).Code))
+	})
+	emu.SendTransaction.Run(func(args mock.Arguments) {
+		script := args.Get(2).(flowkit.Script)
+		assert.Equal(t, []cadence.Value{cadence.String("owner"), cadence.NewAddress(service)}, script.Args)
+	}).Return(nil, &flowsdk.TransactionResult{}, nil)
+	emulatorServices = func(*flowkit.State, output.Logger) (flowkit.Services, error) {
+		return emu.Mock, nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		backfillFlags.Accounts = []string{"0x7e60df042a9c0868"}
+
+		result, err := backfill(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		accounts := result.(*backfillResult).accounts
+		require.Len(t, accounts, 1)
+		assert.Equal(t, service, accounts[0].target)
+		assert.Equal(t, []string{"Bar"}, accounts[0].contracts)
+		assert.Equal(t, []string{"/storage/owner"}, accounts[0].storage)
+		assert.Equal(t, map[string]string{"/storage/vault": "resources can't be copied"}, accounts[0].skipped)
+		emu.Mock.AssertNumberOfCalls(t, "AddContract", 1)
+	})
+
+	t.Run("Fail not aliased", func(t *testing.T) {
+		backfillFlags.Accounts = []string{"0x01"}
+
+		_, err := backfill(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.ErrorContains(t, err, "account 0x0000000000000001 has no aliased address on the emulator")
+	})
+
+	t.Run("Fail emulator network", func(t *testing.T) {
+		srv.Network.Return(config.EmulatorNetwork)
+		backfillFlags.Accounts = []string{"0x7e60df042a9c0868"}
+
+		_, err := backfill(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "select the network to copy the accounts from with the --network flag")
+	})
+}
@@ -0,0 +1,120 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsRollback struct{}
+
+var rollbackFlags = flagsRollback{}
+
+var RollbackCmd = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "rollback <height>",
+		Short: "Rollback the emulator state to a block height",
+		Long: `Rollback the emulator state to a block height, removing all the blocks after it
+together with their transactions and state changes.
+
+Only the emulator network can be rolled back, the rollback is confirmed unless the --yes flag is provided.`,
+		Example: "flow emulator rollback 42",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &rollbackFlags,
+	Run:   rollback,
+}
+
+func rollback(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	height, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block height %s", args[0])
+	}
+
+	network := flow.Network().Name
+	if network != config.EmulatorNetwork.Name {
+		return nil, fmt.Errorf("only the %s network can be rolled back, the network is %s", config.EmulatorNetwork.Name, network)
+	}
+	if !globalFlags.Yes && !util.ConfirmRollbackPrompt(height) {
+		return nil, fmt.Errorf("rollback canceled")
+	}
+
+	ctx := context.Background()
+	logger.StartProgress(fmt.Sprintf("Rolling back the emulator to block height %d...", height))
+	defer logger.StopProgress()
+
+	err = flow.Rollback(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rollbackResult{
+		BlockID: block.ID.String(),
+		Height:  block.Height,
+	}, nil
+}
+
+type rollbackResult struct {
+	BlockID string
+	Height  uint64
+}
+
+func (r *rollbackResult) JSON() any {
+	return map[string]any{
+		"blockID": r.BlockID,
+		"height":  r.Height,
+	}
+}
+
+func (r *rollbackResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+	_, _ = fmt.Fprintf(writer, "Emulator rolled back to the block\n")
+	_, _ = fmt.Fprintf(writer, "Block ID\t%s\n", r.BlockID)
+	_, _ = fmt.Fprintf(writer, "Height\t%d", r.Height)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *rollbackResult) Oneliner() string {
+	return fmt.Sprintf("%s (%d)", r.BlockID, r.Height)
+}
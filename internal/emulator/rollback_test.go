@@ -0,0 +1,59 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Rollback(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		block := tests.NewBlock()
+		srv.Mock.On("Rollback", mock.Anything, uint64(1)).Return(nil)
+		srv.GetBlock.Return(block, nil)
+
+		result, err := rollback([]string{"1"}, command.GlobalFlags{Yes: true}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, &rollbackResult{BlockID: block.ID.String(), Height: block.Height}, result)
+	})
+
+	t.Run("Fail invalid height", func(t *testing.T) {
+		_, err := rollback([]string{"foo"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid block height foo")
+	})
+
+	t.Run("Fail non-emulator network", func(t *testing.T) {
+		srv.Network.Return(config.TestnetNetwork)
+		t.Cleanup(func() { srv.Network.Return(config.EmulatorNetwork) })
+
+		_, err := rollback([]string{"1"}, command.GlobalFlags{Yes: true}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "only the emulator network can be rolled back, the network is testnet")
+	})
+}
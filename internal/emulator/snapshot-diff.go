@@ -0,0 +1,307 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/accounts"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// flowSupplyScript returns the total FLOW supply on the emulator.
+const flowSupplyScript = `
+import FlowToken from 0x0ae53cb6e3f42a79
+
+pub fun main(): UFix64 {
+	return FlowToken.totalSupply
+}
+`
+
+// emulatorState of all the accounts on the emulator at the moment of reading.
+type emulatorState struct {
+	accounts map[flowsdk.Address]*accountState
+	supply   cadence.UFix64
+}
+
+type accountState struct {
+	contracts map[string][]byte
+	storage   *accounts.StorageSnapshot
+}
+
+// readEmulatorState reads all the accounts on the emulator.
+//
+// Emulator generates addresses in sequence, so accounts are read in that order until the first account that is not found.
+func readEmulatorState(flow flowkit.Services) (*emulatorState, error) {
+	state := &emulatorState{
+		accounts: make(map[flowsdk.Address]*accountState),
+	}
+
+	generator := flowsdk.NewAddressGenerator(flowsdk.Emulator)
+	for {
+		address := generator.NextAddress()
+		account, err := flow.GetAccount(context.Background(), address)
+		if errors.Is(err, gateway.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read account 0x%s: %w", address.Hex(), err)
+		}
+
+		storage, err := accounts.ReadStorage(flow, address, flowkit.LatestScriptQuery)
+		if err != nil {
+			return nil, err
+		}
+
+		state.accounts[address] = &accountState{
+			contracts: account.Contracts,
+			storage:   storage,
+		}
+	}
+
+	if len(state.accounts) == 0 {
+		return nil, fmt.Errorf("failed to read emulator accounts")
+	}
+
+	supply, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{Code: []byte(flowSupplyScript)},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read FLOW supply: %w", err)
+	}
+	state.supply, _ = supply.(cadence.UFix64)
+
+	return state, nil
+}
+
+// snapshotFile is the path of the snapshot the emulator persisted in the database directory.
+func snapshotFile(dbPath string, name string) string {
+	return filepath.Join(dbPath, fmt.Sprintf("snapshot_%s", name))
+}
+
+// snapshotState reads the state of the snapshot persisted in the emulator database directory.
+//
+// The snapshot is copied and opened in an embedded emulator, so the state of the running emulator is not changed.
+func snapshotState(state *flowkit.State, dbPath string, name string) (*emulatorState, error) {
+	dir, err := os.MkdirTemp("", "flow-snapshot-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	err = copyFile(snapshotFile(dbPath, name), filepath.Join(dir, "emulator.sqlite"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy snapshot '%s': %w", name, err)
+	}
+
+	store, err := gateway.WithPersistentStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	emulator := gateway.NewEmulatorGatewayWithOpts(nil, store)
+	defer emulator.Close()
+
+	logger := output.NewStdoutLogger(output.NoneLog)
+	return readEmulatorState(flowkit.NewFlowkit(state, config.EmulatorNetwork, emulator, logger))
+}
+
+func copyFile(from string, to string) error {
+	source, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	destination, err := os.Create(to)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(destination, source); err != nil {
+		_ = destination.Close()
+		return err
+	}
+	return destination.Close()
+}
+
+type contractChange struct {
+	address flowsdk.Address
+	name    string
+	change  string
+}
+
+const (
+	contractAdded   = "added"
+	contractRemoved = "removed"
+	contractUpdated = "updated"
+)
+
+// diffEmulatorStates compares the state of two snapshots.
+func diffEmulatorStates(from string, to string, a *emulatorState, b *emulatorState) *snapshotDiffResult {
+	result := &snapshotDiffResult{
+		from:        from,
+		to:          to,
+		created:     make([]flowsdk.Address, 0),
+		contracts:   make([]contractChange, 0),
+		storage:     make(map[flowsdk.Address]*accounts.StorageDiff),
+		supplyDelta: cadence.Fix64(b.supply) - cadence.Fix64(a.supply),
+	}
+
+	empty := &accounts.StorageSnapshot{Paths: make(map[string]string)}
+
+	for address, after := range b.accounts {
+		before, ok := a.accounts[address]
+		if !ok {
+			result.created = append(result.created, address)
+			before = &accountState{contracts: make(map[string][]byte), storage: empty}
+		}
+
+		for name, code := range after.contracts {
+			previous, exists := before.contracts[name]
+			if !exists {
+				result.contracts = append(result.contracts, contractChange{address, name, contractAdded})
+			} else if !bytes.Equal(previous, code) {
+				result.contracts = append(result.contracts, contractChange{address, name, contractUpdated})
+			}
+		}
+		for name := range before.contracts {
+			if _, exists := after.contracts[name]; !exists {
+				result.contracts = append(result.contracts, contractChange{address, name, contractRemoved})
+			}
+		}
+
+		storage := accounts.DiffStorage(address, before.storage, after.storage)
+		if storage.Modified() {
+			result.storage[address] = storage
+		}
+	}
+
+	sort.Slice(result.created, func(i, j int) bool {
+		return result.created[i].Hex() < result.created[j].Hex()
+	})
+	sort.Slice(result.contracts, func(i, j int) bool {
+		if result.contracts[i].address != result.contracts[j].address {
+			return result.contracts[i].address.Hex() < result.contracts[j].address.Hex()
+		}
+		return result.contracts[i].name < result.contracts[j].name
+	})
+
+	return result
+}
+
+type snapshotDiffResult struct {
+	from        string
+	to          string
+	created     []flowsdk.Address
+	contracts   []contractChange
+	storage     map[flowsdk.Address]*accounts.StorageDiff
+	supplyDelta cadence.Fix64
+}
+
+func (r *snapshotDiffResult) storageAddresses() []flowsdk.Address {
+	addresses := make([]flowsdk.Address, 0, len(r.storage))
+	for address := range r.storage {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+	return addresses
+}
+
+func (r *snapshotDiffResult) JSON() any {
+	created := make([]string, 0, len(r.created))
+	for _, address := range r.created {
+		created = append(created, "0x"+address.Hex())
+	}
+
+	contracts := make([]map[string]string, 0, len(r.contracts))
+	for _, c := range r.contracts {
+		contracts = append(contracts, map[string]string{
+			"address": "0x" + c.address.Hex(),
+			"name":    c.name,
+			"change":  c.change,
+		})
+	}
+
+	storage := make(map[string]any, len(r.storage))
+	for address, diff := range r.storage {
+		storage["0x"+address.Hex()] = diff.JSON()
+	}
+
+	return map[string]any{
+		"from":             r.from,
+		"to":               r.to,
+		"accountsCreated":  created,
+		"contractsChanged": contracts,
+		"storageModified":  storage,
+		"supplyDelta":      r.supplyDelta.String(),
+	}
+}
+
+func (r *snapshotDiffResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Snapshots\t%s -> %s\n", r.from, r.to)
+	_, _ = fmt.Fprintf(writer, "FLOW Supply Change\t%s\n", r.supplyDelta)
+
+	_, _ = fmt.Fprintf(writer, "\nAccounts Created\t%d\n", len(r.created))
+	for _, address := range r.created {
+		_, _ = fmt.Fprintf(writer, "    %s 0x%s\n", output.Green("+"), address.Hex())
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nContracts Changed\t%d\n", len(r.contracts))
+	for _, c := range r.contracts {
+		_, _ = fmt.Fprintf(writer, "    0x%s.%s\t%s\n", c.address.Hex(), c.name, c.change)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nStorage Modified\t%d\n", len(r.storage))
+	for _, address := range r.storageAddresses() {
+		_, _ = fmt.Fprintf(writer, "    0x%s\t%s\n", address.Hex(), r.storage[address].Oneliner())
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *snapshotDiffResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Accounts Created: %d, Contracts Changed: %d, Storage Modified: %d, FLOW Supply Change: %s",
+		len(r.created),
+		len(r.contracts),
+		len(r.storage),
+		r.supplyDelta,
+	)
+}
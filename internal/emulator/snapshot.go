@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -35,16 +36,18 @@ import (
 	"github.com/onflow/flow-cli/internal/util"
 )
 
-type SnapshotFlag struct{}
+type SnapshotFlag struct {
+	DBPath string `default:"./flowdb" flag:"dbpath" info:"Database directory of the emulator, used to read the snapshots to diff"`
+}
 
 var snapshotFlag = SnapshotFlag{}
 
 var SnapshotCmd = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "snapshot <create|load|list> [snapshotName]",
-		Short:   "Create/Load/List emulator snapshots",
+		Use:     "snapshot <create|load|list|diff> [snapshotName] [otherSnapshotName]",
+		Short:   "Create/Load/List/Diff emulator snapshots",
 		Example: "flow emulator snapshot create testSnapshot",
-		Args:    cobra.RangeArgs(1, 2),
+		Args:    cobra.RangeArgs(1, 3),
 	},
 	Flags: &snapshotFlag,
 	Run:   snapshot,
@@ -181,18 +184,23 @@ const (
 	snapshotCommandList   snapshotCommand = "list"
 	snapshotCommandCreate snapshotCommand = "create"
 	snapshotCommandLoad   snapshotCommand = "load"
+	snapshotCommandDiff   snapshotCommand = "diff"
 )
 
 func snapshot(
 	args []string,
-	_ command.GlobalFlags,
-	_ output.Logger,
-	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
 	_ flowkit.Services,
 ) (command.Result, error) {
 
 	subCommand := args[0]
 
+	if snapshotCommand(subCommand) == snapshotCommandDiff {
+		return snapshotDiff(args, globalFlags, logger, readerWriter)
+	}
+
 	snapshots, err := listSnapshot()
 	if err != nil {
 		return nil, err
@@ -247,7 +255,49 @@ func snapshot(
 		return &result, nil
 
 	default:
-		return nil, fmt.Errorf("invalid snapshot command: valid commands are: 'list', 'create', 'load'")
+		return nil, fmt.Errorf("invalid snapshot command: valid commands are: 'list', 'create', 'load', 'diff'")
+	}
+
+}
+
+// snapshotDiff compares the snapshots persisted by the emulator, the emulator must be started with
+// the persist and snapshot flags for the snapshots to be written to the database directory.
+func snapshotDiff(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+) (command.Result, error) {
+	if len(args) < 3 {
+		return nil, fmt.Errorf("snapshot diff command requires two snapshot name arguments")
+	}
+	from, to := args[1], args[2]
+	for _, name := range []string{from, to} {
+		if _, err := os.Stat(snapshotFile(snapshotFlag.DBPath, name)); err != nil {
+			return nil, fmt.Errorf(
+				"snapshot '%s' does not exist in %s, start the emulator with --persist --snapshot to write snapshots to the database directory",
+				name,
+				snapshotFlag.DBPath,
+			)
+		}
+	}
+
+	state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Comparing snapshots %s and %s...", from, to))
+	defer logger.StopProgress()
+
+	fromState, err := snapshotState(state, snapshotFlag.DBPath, from)
+	if err != nil {
+		return nil, err
+	}
+	toState, err := snapshotState(state, snapshotFlag.DBPath, to)
+	if err != nil {
+		return nil, err
 	}
 
+	return diffEmulatorStates(from, to, fromState, toState), nil
 }
@@ -0,0 +1,162 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	internalAccounts "github.com/onflow/flow-cli/internal/accounts"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_SnapshotDiff(t *testing.T) {
+	service := flowsdk.HexToAddress("f8d6e0586b0a20c7")
+	alice := flowsdk.HexToAddress("01cf0e2f2f715450")
+
+	before := &emulatorState{
+		supply: cadence.UFix64(1000),
+		accounts: map[flowsdk.Address]*accountState{
+			service: {
+				contracts: map[string][]byte{"Foo": []byte("pub contract Foo {}")},
+				storage:   &internalAccounts.StorageSnapshot{Used: 100, Paths: map[string]string{}},
+			},
+		},
+	}
+
+	after := &emulatorState{
+		supply: cadence.UFix64(1500),
+		accounts: map[flowsdk.Address]*accountState{
+			service: {
+				contracts: map[string][]byte{
+					"Foo": []byte("pub contract Foo { pub let a: Int }"),
+					"Bar": []byte("pub contract Bar {}"),
+				},
+				storage: &internalAccounts.StorageSnapshot{Used: 100, Paths: map[string]string{}},
+			},
+			alice: {
+				contracts: map[string][]byte{},
+				storage: &internalAccounts.StorageSnapshot{Used: 50, Paths: map[string]string{
+					"/storage/flowTokenVault": "A.0ae53cb6e3f42a79.FlowToken.Vault",
+				}},
+			},
+		},
+	}
+
+	result := diffEmulatorStates("a", "b", before, after)
+
+	assert.Equal(t, []flowsdk.Address{alice}, result.created)
+	assert.Equal(t, []contractChange{
+		{address: service, name: "Bar", change: contractAdded},
+		{address: service, name: "Foo", change: contractUpdated},
+	}, result.contracts)
+	assert.Len(t, result.storage, 1)
+	assert.Contains(t, result.storage, alice)
+	assert.Equal(t, "0.00000500", result.supplyDelta.String())
+	assert.Equal(
+		t,
+		"Accounts Created: 1, Contracts Changed: 2, Storage Modified: 1, FLOW Supply Change: 0.00000500",
+		result.Oneliner(),
+	)
+}
+
+func Test_SnapshotState(t *testing.T) {
+	_, state, _ := util.TestMocks(t)
+	service, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	privateKey, err := service.Key.PrivateKey()
+	require.NoError(t, err)
+
+	dbPath := filepath.Join(t.TempDir(), "flowdb")
+	store, err := gateway.WithPersistentStore(dbPath)
+	require.NoError(t, err)
+	emulator := gateway.NewEmulatorGatewayWithOpts(&gateway.EmulatorKey{
+		PublicKey: (*privateKey).PublicKey(),
+		SigAlgo:   service.Key.SigAlgo(),
+		HashAlgo:  service.Key.HashAlgo(),
+	}, store)
+	flow := flowkit.NewFlowkit(state, config.EmulatorNetwork, emulator, output.NewStdoutLogger(output.NoneLog))
+
+	ctx := context.Background()
+	require.NoError(t, emulator.CreateSnapshot(ctx, "before"))
+	created, _, err := flow.CreateAccount(ctx, service, []accounts.PublicKey{{
+		Public:   tests.PubKeys()[0],
+		Weight:   flowsdk.AccountKeyWeightThreshold,
+		SigAlgo:  crypto.ECDSA_P256,
+		HashAlgo: crypto.SHA3_256,
+	}})
+	require.NoError(t, err)
+	require.NoError(t, emulator.CreateSnapshot(ctx, "after"))
+	latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+	require.NoError(t, err)
+
+	before, err := snapshotState(state, dbPath, "before")
+	require.NoError(t, err)
+	after, err := snapshotState(state, dbPath, "after")
+	require.NoError(t, err)
+
+	result := diffEmulatorStates("before", "after", before, after)
+	assert.Equal(t, []flowsdk.Address{created.Address}, result.created)
+
+	// the emulator keeps its state while the snapshots are read
+	current, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+	require.NoError(t, err)
+	assert.Equal(t, latest.Height, current.Height)
+	require.NoError(t, emulator.Close())
+
+	_, err = snapshotState(state, dbPath, "missing")
+	assert.ErrorContains(t, err, "failed to copy snapshot 'missing'")
+}
+
+func Test_ReadEmulatorState(t *testing.T) {
+	t.Run("Fail reading account", func(t *testing.T) {
+		srv, _, _ := util.TestMocks(t)
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			srv.GetAccount.Return(nil, errors.New("connection refused"))
+		})
+
+		_, err := readEmulatorState(srv.Mock)
+		assert.ErrorContains(t, err, "connection refused")
+	})
+
+	t.Run("Stop at account not found", func(t *testing.T) {
+		srv, _, _ := util.TestMocks(t)
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			srv.GetAccount.Return(nil, &gateway.Error{Type: gateway.ErrNotFound, Message: "account not found"})
+		})
+
+		_, err := readEmulatorState(srv.Mock)
+		assert.EqualError(t, err, "failed to read emulator accounts")
+	})
+}
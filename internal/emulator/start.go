@@ -111,6 +111,9 @@ func init() {
 	Cmd.Short = "Run Flow network for development"
 	Cmd.GroupID = "tools"
 	SnapshotCmd.AddToParent(Cmd)
+	BackfillCmd.AddToParent(Cmd)
+	RollbackCmd.AddToParent(Cmd)
+	StatsCmd.AddToParent(Cmd)
 }
 
 func exitf(code int, msg string, args ...any) {
@@ -0,0 +1,117 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/gateway/stats"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsStats struct{}
+
+var statsFlags = flagsStats{}
+
+var StatsCmd = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "stats",
+		Short: "Show the emulator memory usage",
+		Long: `Show the memory usage of the in-process emulator started with 'flow dev', together with the number
+of blocks, transactions, collections and events it keeps when started with a retention.`,
+		Example: "flow emulator stats",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &statsFlags,
+	Run:   emulatorStats,
+}
+
+func emulatorStats(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	logger.StartProgress("Fetching the emulator stats...")
+	defer logger.StopProgress()
+
+	usage, err := flow.GetEmulatorStats(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return &statsResult{usage}, nil
+}
+
+type statsResult struct {
+	*stats.Emulator
+}
+
+func (r *statsResult) JSON() any {
+	return r.Emulator
+}
+
+func (r *statsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+	_, _ = fmt.Fprintf(writer, "Heap\t%s\n", formatBytes(r.HeapBytes))
+	_, _ = fmt.Fprintf(writer, "Latest Height\t%d\n", r.LatestHeight)
+	if r.Retention > 0 {
+		_, _ = fmt.Fprintf(writer, "Lowest Height\t%d\n", r.LowestHeight)
+		_, _ = fmt.Fprintf(writer, "Retention\t%d blocks\n", r.Retention)
+		_, _ = fmt.Fprintf(writer, "Pruned\t%d blocks\n", r.Pruned)
+		_, _ = fmt.Fprintf(writer, "Blocks\t%d\n", r.Blocks)
+		_, _ = fmt.Fprintf(writer, "Collections\t%d\n", r.Collections)
+		_, _ = fmt.Fprintf(writer, "Transactions\t%d\n", r.Transactions)
+		_, _ = fmt.Fprintf(writer, "Events\t%d\n", r.Events)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *statsResult) Oneliner() string {
+	if r.Retention > 0 {
+		return fmt.Sprintf("%s, %d blocks, %d transactions", formatBytes(r.HeapBytes), r.Blocks, r.Transactions)
+	}
+	return formatBytes(r.HeapBytes)
+}
+
+// formatBytes formats the size in the largest binary unit, e.g. 12.5 MiB.
+func formatBytes(size uint64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
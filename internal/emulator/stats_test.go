@@ -0,0 +1,59 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/stats"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Stats(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		usage := &stats.Emulator{Blocks: 3, Transactions: 2, Retention: 3, HeapBytes: 3 * 1024 * 1024}
+		srv.Mock.On("GetEmulatorStats", mock.Anything).Return(usage, nil).Once()
+
+		result, err := emulatorStats([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, &statsResult{usage}, result)
+		assert.Equal(t, "3.0 MiB, 3 blocks, 2 transactions", result.Oneliner())
+	})
+
+	t.Run("Fail not supported", func(t *testing.T) {
+		srv.Mock.On("GetEmulatorStats", mock.Anything).Return(nil, fmt.Errorf("not supported")).Once()
+
+		_, err := emulatorStats([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "not supported")
+	})
+}
+
+func Test_FormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "2.0 GiB", formatBytes(2*1024*1024*1024))
+}
@@ -0,0 +1,216 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+// checkpoint of an events scan, saved after each scanned chunk of blocks so the scan can be resumed.
+//
+// The scanned events are appended to a separate results file, so the checkpoint only records the next height to scan.
+type checkpoint struct {
+	Network    string   `json:"network"`
+	EventTypes []string `json:"eventTypes"`
+	Start      uint64   `json:"start"`
+	End        uint64   `json:"end"`
+	Next       uint64   `json:"next"`
+}
+
+func newCheckpoint(network string, eventTypes []string, start uint64, end uint64) *checkpoint {
+	return &checkpoint{
+		Network:    network,
+		EventTypes: eventTypes,
+		Start:      start,
+		End:        end,
+		Next:       start,
+	}
+}
+
+// loadCheckpoint loads the checkpoint and makes sure it was saved by the scan of the same events on the same network.
+func loadCheckpoint(reader flowkit.ReaderWriter, filename string, network string, eventTypes []string) (*checkpoint, error) {
+	data, err := reader.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint, run the command without --resume to start a new scan: %w", err)
+	}
+
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s: %w", filename, err)
+	}
+
+	if cp.Network != network || !slices.Equal(cp.EventTypes, eventTypes) {
+		return nil, fmt.Errorf(
+			"checkpoint %s was saved for %s on network %s, run the command without --resume to start a new scan",
+			filename,
+			strings.Join(cp.EventTypes, ", "),
+			cp.Network,
+		)
+	}
+
+	return &cp, nil
+}
+
+func (c *checkpoint) save(writer flowkit.ReaderWriter, filename string) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return writer.WriteFile(filename, data, 0644)
+}
+
+// chunkSize returns the number of blocks fetched concurrently by the workers.
+func chunkSize(cp *checkpoint, worker *flowkit.EventWorker) uint64 {
+	chunk := uint64(worker.Count) * worker.BlocksPerWorker
+	if chunk == 0 {
+		chunk = cp.End - cp.Start + 1
+	}
+	return chunk
+}
+
+// fetchEvents fetches events from the next height to the end in chunks of blocks, each chunk is fetched
+// concurrently by the workers and passed to the handler.
+func fetchEvents(
+	services flowkit.Services,
+	logger output.Logger,
+	cp *checkpoint,
+	worker *flowkit.EventWorker,
+	handle func(blockEvents []flow.BlockEvents, end uint64) error,
+) error {
+	// a resumed checkpoint may already cover the whole range
+	if cp.Next > cp.End {
+		return nil
+	}
+
+	iterator := flowkit.BlockRangeIterator(
+		services.Gateway(),
+		cp.Next,
+		cp.End,
+		flowkit.BlockRangeOptions{BatchSize: chunkSize(cp, worker)},
+	)
+	return iterator.ForEach(context.Background(), func(ctx context.Context, _ gateway.Gateway, batch flowkit.BlockRange) error {
+		logger.StartProgress(fmt.Sprintf("Fetching events from block %d to %d of %d...", batch.Start, batch.End, cp.End))
+		blockEvents, err := services.GetEvents(ctx, cp.EventTypes, batch.Start, batch.End, worker)
+		if err != nil {
+			return err
+		}
+		return handle(blockEvents, batch.End)
+	})
+}
+
+// scanEvents fetches events in chunks of blocks, each chunk is fetched concurrently by the workers.
+//
+// If the range spans multiple chunks the events are indexed to the results file with a checkpoint saved
+// after each chunk, so the scan interrupted by network errors or rate limits can be resumed with the --resume flag.
+func scanEvents(
+	services flowkit.Services,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	cp *checkpoint,
+	worker *flowkit.EventWorker,
+	checkpointFile string,
+	resultsFile string,
+) ([]flow.BlockEvents, error) {
+	resumed := cp.Next != cp.Start
+	if !resumed && cp.End-cp.Start < chunkSize(cp, worker) {
+		events := make([]flow.BlockEvents, 0)
+		err := fetchEvents(services, logger, cp, worker, func(blockEvents []flow.BlockEvents, end uint64) error {
+			events = append(events, blockEvents...)
+			cp.Next = end + 1
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return events, nil
+	}
+
+	err := indexEvents(services, logger, readerWriter, cp, worker, checkpointFile, resultsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := readResults(readerWriter, resultsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// scan is completed, so the checkpoint and the results are no longer needed
+	if remover, ok := readerWriter.(interface{ Remove(string) error }); ok {
+		_ = remover.Remove(checkpointFile)
+		_ = remover.Remove(resultsFile)
+	}
+
+	return events, nil
+}
+
+// indexEvents fetches events in chunks of blocks and appends them to the results file, saving the checkpoint
+// after each chunk. A new scan starts with an empty results file, while a resumed scan drops the results
+// appended after the checkpoint was saved.
+//
+// The checkpoint is kept after the scan is completed, so resuming a completed scan is a no-op.
+func indexEvents(
+	services flowkit.Services,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	cp *checkpoint,
+	worker *flowkit.EventWorker,
+	checkpointFile string,
+	resultsFile string,
+) error {
+	var err error
+	if cp.Next == cp.Start {
+		err = readerWriter.WriteFile(resultsFile, nil, 0644)
+	} else {
+		err = truncateResults(readerWriter, resultsFile, cp.Next)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to prepare results %s: %w", resultsFile, err)
+	}
+	if err := cp.save(readerWriter, checkpointFile); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	err = fetchEvents(services, logger, cp, worker, func(blockEvents []flow.BlockEvents, end uint64) error {
+		if err := appendResults(readerWriter, resultsFile, blockEvents); err != nil {
+			return fmt.Errorf("failed to save results: %w", err)
+		}
+
+		cp.Next = end + 1
+		if err := cp.save(readerWriter, checkpointFile); err != nil {
+			return fmt.Errorf("failed to save checkpoint: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w\nscan stopped at block %d, rerun the command with --resume to continue", err, cp.Next)
+	}
+
+	return nil
+}
@@ -28,7 +28,9 @@ import (
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
 
+	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -41,11 +43,14 @@ var Cmd = &cobra.Command{
 
 func init() {
 	getCommand.AddToParent(Cmd)
+	subscribeCommand.AddToParent(Cmd)
 }
 
 type EventResult struct {
 	BlockEvents []flow.BlockEvents
 	Events      []flow.Event
+	// ChainID of the network the events were emitted on, used for labelling the system events.
+	ChainID flow.ChainID
 }
 
 func (e *EventResult) JSON() any {
@@ -54,7 +59,7 @@ func (e *EventResult) JSON() any {
 	for _, blockEvent := range e.BlockEvents {
 		if len(blockEvent.Events) > 0 {
 			for _, event := range blockEvent.Events {
-				result = append(result, map[string]any{
+				eventResult := map[string]any{
 					"blockID":       blockEvent.Height,
 					"index":         event.EventIndex,
 					"type":          event.Type,
@@ -62,7 +67,11 @@ func (e *EventResult) JSON() any {
 					"values": json.RawMessage(
 						jsoncdc.MustEncode(event.Value),
 					),
-				})
+				}
+				if system, ok := lookupSystemEvent(event.Type, e.ChainID); ok {
+					eventResult["name"] = system.label
+				}
+				result = append(result, eventResult)
 			}
 		}
 	}
@@ -70,6 +79,92 @@ func (e *EventResult) JSON() any {
 	return result
 }
 
+// parquetColumns are the columns present for every event, event fields are added as columns after them.
+var parquetColumns = []string{"block_height", "block_id", "transaction_id", "transaction_index", "event_index", "event_type"}
+
+// Parquet exports the events as a table with a row per event and a column per event field.
+//
+// Field values are stored as strings, so all Cadence values can be represented without losing precision,
+// and fields missing on an event type are left empty.
+func (e *EventResult) Parquet() ([]byte, error) {
+	type row struct {
+		block *flow.BlockEvents
+		event flow.Event
+	}
+
+	rows := make([]row, 0)
+	for i := range e.BlockEvents {
+		for _, event := range e.BlockEvents[i].Events {
+			rows = append(rows, row{block: &e.BlockEvents[i], event: event})
+		}
+	}
+	for _, event := range e.Events {
+		rows = append(rows, row{event: event})
+	}
+
+	fields := make([]string, 0)
+	fieldColumns := make(map[string]int)
+	for _, r := range rows {
+		if r.event.Value.EventType == nil {
+			continue
+		}
+		for _, field := range r.event.Value.EventType.Fields {
+			if _, ok := fieldColumns[field.Identifier]; !ok {
+				fieldColumns[field.Identifier] = len(fields)
+				fields = append(fields, field.Identifier)
+			}
+		}
+	}
+
+	columns := []util.ParquetColumn{
+		{Name: parquetColumns[0], Type: util.ParquetInt64, Optional: true},
+		{Name: parquetColumns[1], Type: util.ParquetByteArray, Optional: true},
+		{Name: parquetColumns[2], Type: util.ParquetByteArray},
+		{Name: parquetColumns[3], Type: util.ParquetInt64},
+		{Name: parquetColumns[4], Type: util.ParquetInt64},
+		{Name: parquetColumns[5], Type: util.ParquetByteArray},
+	}
+	for _, field := range fields {
+		name := field
+		if slices.Contains(parquetColumns, name) {
+			name = fmt.Sprintf("field_%s", name)
+		}
+		columns = append(columns, util.ParquetColumn{Name: name, Type: util.ParquetByteArray, Optional: true})
+	}
+
+	for i := range columns {
+		columns[i].Values = make([]any, len(rows))
+	}
+	for i, r := range rows {
+		if r.block != nil {
+			columns[0].Values[i] = int64(r.block.Height)
+			columns[1].Values[i] = r.block.BlockID.String()
+		}
+		columns[2].Values[i] = r.event.TransactionID.String()
+		columns[3].Values[i] = int64(r.event.TransactionIndex)
+		columns[4].Values[i] = int64(r.event.EventIndex)
+		columns[5].Values[i] = r.event.Type
+
+		if r.event.Value.EventType == nil {
+			continue
+		}
+		for j, field := range r.event.Value.EventType.Fields {
+			columns[len(parquetColumns)+fieldColumns[field.Identifier]].Values[i] = parquetValue(r.event.Value.Fields[j])
+		}
+	}
+
+	return util.WriteParquet(columns)
+}
+
+// parquetValue returns the string representation of the value, strings are not quoted.
+func parquetValue(value cadence.Value) string {
+	if s, ok := value.(cadence.String); ok {
+		return string(s)
+	}
+
+	return value.String()
+}
+
 func (e *EventResult) String() string {
 	var b bytes.Buffer
 	writer := util.CreateTabWriter(&b)
@@ -77,13 +172,16 @@ func (e *EventResult) String() string {
 	for _, blockEvent := range e.BlockEvents {
 		if len(blockEvent.Events) > 0 {
 			_, _ = fmt.Fprintf(writer, "Events Block #%v:", blockEvent.Height)
-			eventsString(writer, blockEvent.Events)
+			if !blockEvent.BlockTimestamp.IsZero() {
+				_, _ = fmt.Fprintf(writer, "\n    Timestamp\t%s", output.FormatTime(blockEvent.BlockTimestamp))
+			}
+			eventsString(writer, blockEvent.Events, e.ChainID)
 			_, _ = fmt.Fprintf(writer, "\n")
 		}
 	}
 
 	// if we have events passed directly and not in relation to block
-	eventsString(writer, e.Events)
+	eventsString(writer, e.Events, e.ChainID)
 
 	_ = writer.Flush()
 	return b.String()
@@ -107,20 +205,29 @@ func (e *EventResult) Oneliner() string {
 	return result
 }
 
-func eventsString(writer io.Writer, events []flow.Event) {
+func eventsString(writer io.Writer, events []flow.Event, chainID flow.ChainID) {
 	for _, event := range events {
-		eventString(writer, event)
+		eventString(writer, event, chainID)
 	}
 }
 
-func eventString(writer io.Writer, event flow.Event) {
+func eventString(writer io.Writer, event flow.Event, chainID flow.ChainID) {
 	_, _ = fmt.Fprintf(writer, "\n    Index\t%d\n", event.EventIndex)
 	_, _ = fmt.Fprintf(writer, "    Type\t%s\n", event.Type)
+
+	system, isSystem := lookupSystemEvent(event.Type, chainID)
+	if isSystem {
+		_, _ = fmt.Fprintf(writer, "    Name\t%s\n", system.label)
+	}
+
 	_, _ = fmt.Fprintf(writer, "    Tx ID\t%s\n", event.TransactionID)
 	_, _ = fmt.Fprintf(writer, "    Values\n")
 
 	for i, field := range event.Value.EventType.Fields {
 		value := event.Value.Fields[i]
+		if isSystem {
+			field.Identifier = system.fieldLabel(field.Identifier)
+		}
 		printField(writer, field, value)
 	}
 }
@@ -19,16 +19,22 @@
 package events
 
 import (
+	"context"
+	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/stdlib"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
@@ -38,9 +44,9 @@ func Test_Get(t *testing.T) {
 	srv, _, rw := util.TestMocks(t)
 
 	t.Run("Success", func(t *testing.T) {
-		inArgs := []string{"test.event"}
-		eventsFlags.Start = 10
-		eventsFlags.End = 20
+		inArgs := []string{"flow.AccountCreated"}
+		eventsFlags.Start = "10"
+		eventsFlags.End = "20"
 
 		result, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
 		assert.NoError(t, err)
@@ -48,9 +54,9 @@ func Test_Get(t *testing.T) {
 	})
 
 	t.Run("Success not passed start end", func(t *testing.T) {
-		inArgs := []string{"test.event"}
-		eventsFlags.Start = 0
-		eventsFlags.End = 0
+		inArgs := []string{"flow.AccountCreated"}
+		eventsFlags.Start = ""
+		eventsFlags.End = ""
 
 		srv.GetBlock.Run(func(args mock.Arguments) {
 			query := args.Get(1).(flowkit.BlockQuery)
@@ -63,15 +69,215 @@ func Test_Get(t *testing.T) {
 	})
 
 	t.Run("Fail invalid range", func(t *testing.T) {
-		inArgs := []string{"test.event"}
-		eventsFlags.Start = 20
-		eventsFlags.End = 0
+		inArgs := []string{"flow.AccountCreated"}
+		eventsFlags.Start = "20"
+		eventsFlags.End = ""
 
 		result, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
 		assert.EqualError(t, err, "please provide either both start and end for range or only last flag")
 		assert.Nil(t, result)
 	})
 
+	t.Run("Success parquet", func(t *testing.T) {
+		inArgs := []string{"flow.AccountCreated"}
+		eventsFlags.Start = "10"
+		eventsFlags.End = "20"
+		eventsFlags.Format = "parquet"
+		defer func() { eventsFlags.Format = "" }()
+
+		result, err := get(inArgs, command.GlobalFlags{Save: "events.parquet"}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(result.String(), "PAR1"))
+	})
+
+	t.Run("Fail parquet", func(t *testing.T) {
+		inArgs := []string{"flow.AccountCreated"}
+		eventsFlags.Start = "10"
+		eventsFlags.End = "20"
+		eventsFlags.Format = "parquet"
+		defer func() { eventsFlags.Format = "" }()
+
+		_, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "parquet export must be saved to a file using the --save flag")
+
+		_, err = get(inArgs, command.GlobalFlags{Save: "events.parquet", Format: "json"}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "parquet export can not be combined with the --output, --filter or --plain flags")
+
+		eventsFlags.Format = "avro"
+		_, err = get(inArgs, command.GlobalFlags{Save: "events.avro"}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "unsupported format avro, options: \"parquet\"")
+	})
+}
+
+func Test_ResolveEventType(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	ctx := context.Background()
+
+	state.Contracts().AddOrUpdate(config.Contract{
+		Name:     "Hello",
+		Location: "Hello.cdc",
+		Aliases:  config.Aliases{{Network: config.EmulatorNetwork.Name, Address: flow.HexToAddress("0x01")}},
+	})
+
+	account := tests.NewAccountWithAddress("0x01")
+	account.Contracts = map[string][]byte{
+		"Hello": []byte(`pub contract Hello { pub event Greeted(name: String) }`),
+	}
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		srv.GetAccount.Return(account, nil)
+	})
+
+	t.Run("Success system event", func(t *testing.T) {
+		eventType, err := resolveEventType(ctx, "flow.AccountCreated", srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "flow.AccountCreated", eventType)
+	})
+
+	t.Run("Success shorthand", func(t *testing.T) {
+		eventType, err := resolveEventType(ctx, "Hello.Greeted", srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "A.0000000000000001.Hello.Greeted", eventType)
+	})
+
+	t.Run("Success resolving accounts once", func(t *testing.T) {
+		srv.Mock.Calls = nil
+
+		eventTypes, err := resolveEventTypes(ctx, []string{"Hello.Greeted", "A.0000000000000001.Hello.Greeted"}, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A.0000000000000001.Hello.Greeted", "A.0000000000000001.Hello.Greeted"}, eventTypes)
+		srv.Mock.AssertNumberOfCalls(t, "GetAccount", 1)
+	})
+
+	t.Run("Fail shorthand without configuration", func(t *testing.T) {
+		_, err := resolveEventType(ctx, "Hello.Greeted", srv.Mock, nil)
+		assert.EqualError(t, err, "event type Hello.Greeted can only be resolved with a project configuration, use the full event type A.{address}.Hello.Greeted instead")
+	})
+
+	t.Run("Fail event typo", func(t *testing.T) {
+		_, err := resolveEventType(ctx, "A.0000000000000001.Hello.Greted", srv.Mock, state)
+		assert.EqualError(t, err, "event Greted is not declared in contract Hello, did you mean Greeted?")
+	})
+
+	t.Run("Fail contract typo", func(t *testing.T) {
+		_, err := resolveEventType(ctx, "A.0000000000000001.Helo.Greeted", srv.Mock, state)
+		assert.EqualError(t, err, "contract Helo is not deployed to account 0x0000000000000001, did you mean Hello?")
+
+		_, err = resolveEventType(ctx, "Helo.Greeted", srv.Mock, state)
+		assert.EqualError(t, err, "contract Helo is not deployed or aliased on network emulator, did you mean Hello?")
+	})
+}
+
+func Test_ScanCheckpoint(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	const filename = "checkpoint.json"
+	const results = "results.ndjson"
+	eventTypes := []string{"flow.AccountCreated"}
+	worker := &flowkit.EventWorker{Count: 1, BlocksPerWorker: 5}
+
+	event := flow.Event{
+		Type:          "flow.AccountCreated",
+		TransactionID: flow.HexToID("01"),
+		Value: cadence.NewEvent([]cadence.Value{cadence.String("0x01")}).WithType(cadence.NewEventType(
+			stdlib.FlowLocation{},
+			"AccountCreated",
+			[]cadence.Field{{Identifier: "address", Type: cadence.StringType{}}},
+			nil,
+		)),
+	}
+
+	calls := 0
+	srv.GetEvents.Run(func(args mock.Arguments) {
+		calls++
+		start := args.Get(2).(uint64)
+		switch calls {
+		case 1:
+			assert.Equal(t, uint64(0), start)
+			srv.GetEvents.Return([]flow.BlockEvents{{Height: 3, Events: []flow.Event{event}}}, nil)
+		case 2:
+			srv.GetEvents.Return(nil, fmt.Errorf("rate limited"))
+		default:
+			assert.Equal(t, uint64(5), start)
+			srv.GetEvents.Return([]flow.BlockEvents{{Height: 7, Events: []flow.Event{event}}}, nil)
+		}
+	})
+
+	cp := newCheckpoint("emulator", eventTypes, 0, 9)
+	_, err := scanEvents(srv.Mock, util.NoLogger, rw, cp, worker, filename, results)
+	assert.EqualError(t, err, "rate limited\nscan stopped at block 5, rerun the command with --resume to continue")
+
+	_, err = loadCheckpoint(rw, filename, "testnet", eventTypes)
+	assert.ErrorContains(t, err, "was saved for flow.AccountCreated on network emulator")
+
+	cp, err = loadCheckpoint(rw, filename, "emulator", eventTypes)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), cp.Next)
+
+	// events appended after the checkpoint was saved are dropped on resume
+	err = appendResults(rw, results, []flow.BlockEvents{{Height: 6, Events: []flow.Event{event}}})
+	require.NoError(t, err)
+	err = appendFile(rw, results, []byte(`{"id":`))
+	require.NoError(t, err)
+
+	events, err := scanEvents(srv.Mock, util.NoLogger, rw, cp, worker, filename, results)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, uint64(3), events[0].Height)
+	assert.Equal(t, event.Value.String(), events[0].Events[0].Value.String())
+	assert.Equal(t, uint64(7), events[1].Height)
+
+	_, err = rw.ReadFile(filename)
+	assert.Error(t, err, "checkpoint should be removed after the scan completes")
+	_, err = rw.ReadFile(results)
+	assert.Error(t, err, "results should be removed after the scan completes")
+}
+
+func Test_Index(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	event := flow.Event{
+		Type:          "flow.AccountCreated",
+		TransactionID: flow.HexToID("01"),
+		Value: cadence.NewEvent([]cadence.Value{cadence.String("0x01")}).WithType(cadence.NewEventType(
+			stdlib.FlowLocation{},
+			"AccountCreated",
+			[]cadence.Field{{Identifier: "address", Type: cadence.StringType{}}},
+			nil,
+		)),
+	}
+
+	srv.GetEvents.Run(func(args mock.Arguments) {
+		start := args.Get(2).(uint64)
+		srv.GetEvents.Return([]flow.BlockEvents{{Height: start}, {Height: start + 1, Events: []flow.Event{event}}}, nil)
+	})
+
+	indexFlags.Start = "10"
+	indexFlags.End = "19"
+	indexFlags.Workers = 1
+	indexFlags.Batch = 5
+	indexFlags.File = "events.ndjson"
+	indexFlags.Checkpoint = "checkpoint.json"
+	t.Cleanup(func() { indexFlags = flagsIndex{} })
+
+	result, err := index([]string{"flow.AccountCreated"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"file": "events.ndjson", "start": uint64(10), "end": uint64(19)}, result.JSON())
+
+	events, err := readResults(rw, "events.ndjson")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, uint64(11), events[0].Height)
+	assert.Equal(t, uint64(16), events[1].Height)
+
+	// the checkpoint is kept, so resuming the completed indexing doesn't fetch events again
+	indexFlags.Resume = true
+	srv.Mock.Calls = nil
+	_, err = index([]string{"flow.AccountCreated"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+	require.NoError(t, err)
+	srv.Mock.AssertNotCalled(t, "GetEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	events, err = readResults(rw, "events.ndjson")
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
 }
 
 func Test_Result(t *testing.T) {
@@ -94,6 +300,7 @@ func Test_Result(t *testing.T) {
 
 	assert.Equal(t, strings.TrimPrefix(`
 Events Block #1:
+    Timestamp	2020-06-04T16:43:21Z
     Index	0
     Type	A.foo
     Tx ID	0000000000000000000000000000000000000000000000000000000000000000
@@ -110,3 +317,170 @@ Events Block #1:
 		"values":        json.RawMessage{0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x7b, 0x22, 0x69, 0x64, 0x22, 0x3a, 0x22, 0x41, 0x2e, 0x66, 0x6f, 0x6f, 0x22, 0x2c, 0x22, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x3a, 0x5b, 0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x22, 0x31, 0x22, 0x2c, 0x22, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3a, 0x22, 0x49, 0x6e, 0x74, 0x22, 0x7d, 0x2c, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x22, 0x62, 0x61, 0x72, 0x22, 0x7d, 0x5d, 0x7d, 0x2c, 0x22, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3a, 0x22, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x7d, 0xa},
 	}}, event.JSON())
 }
+
+func Test_Subscribe(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	event := *tests.NewEvent(
+		0,
+		"A.foo",
+		[]cadence.Field{{Type: cadence.StringType{}, Identifier: "bar"}},
+		[]cadence.Value{cadence.String("baz")},
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		subscribeFlags.SinceHeight = 10
+		events := make(chan flow.BlockEvents)
+		errs := make(chan error)
+		close(events)
+		close(errs)
+
+		srv.Mock.
+			On("SubscribeEvents", mock.Anything, []string{"flow.AccountCreated"}, uint64(10)).
+			Return((<-chan flow.BlockEvents)(events), (<-chan error)(errs), nil).
+			Once()
+
+		result, err := subscribe([]string{"flow.AccountCreated"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Fail Subscription", func(t *testing.T) {
+		subscribeFlags.SinceHeight = 0
+		srv.Mock.
+			On("SubscribeEvents", mock.Anything, []string{"flow.AccountCreated"}, uint64(0)).
+			Return(nil, nil, fmt.Errorf("subscriptions not supported")).
+			Once()
+
+		result, err := subscribe([]string{"flow.AccountCreated"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "subscriptions not supported")
+		assert.Nil(t, result)
+	})
+
+	t.Run("Follow Events", func(t *testing.T) {
+		events := make(chan flow.BlockEvents, 3)
+		errs := make(chan error, 1)
+		events <- flow.BlockEvents{Height: 1, Events: []flow.Event{event}}
+		events <- flow.BlockEvents{Height: 2}
+		events <- flow.BlockEvents{Height: 3, Events: []flow.Event{event}}
+		errs <- fmt.Errorf("stream closed")
+		close(events)
+		close(errs)
+
+		var out strings.Builder
+		err := followEvents(&out, events, errs, "text", flow.Emulator)
+		assert.EqualError(t, err, "stream closed")
+		assert.Contains(t, out.String(), "Events Block #1:")
+		assert.NotContains(t, out.String(), "Events Block #2:")
+		assert.Contains(t, out.String(), "Events Block #3:")
+	})
+
+	t.Run("Follow Events JSON", func(t *testing.T) {
+		events := make(chan flow.BlockEvents, 2)
+		errs := make(chan error)
+		events <- flow.BlockEvents{Height: 1, Events: []flow.Event{event}}
+		events <- flow.BlockEvents{Height: 2, Events: []flow.Event{event}}
+		close(events)
+		close(errs)
+
+		var out strings.Builder
+		err := followEvents(&out, events, errs, "json", flow.Emulator)
+		assert.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+		var line []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &line))
+		assert.Equal(t, float64(2), line[0]["blockID"])
+	})
+}
+
+func Test_SystemEvents(t *testing.T) {
+	t.Run("Lookup", func(t *testing.T) {
+		event, ok := lookupSystemEvent("A.8624b52f9ddcd04a.FlowEpoch.EpochCommit", flow.Mainnet)
+		assert.True(t, ok)
+		assert.Equal(t, "Epoch Commit", event.label)
+
+		event, ok = lookupSystemEvent("flow.AccountCreated", "")
+		assert.True(t, ok)
+		assert.Equal(t, "Account Created", event.label)
+
+		_, ok = lookupSystemEvent("A.8624b52f9ddcd04a.Foo.EpochCommit", flow.Mainnet)
+		assert.False(t, ok)
+	})
+
+	t.Run("Lookup only system addresses", func(t *testing.T) {
+		_, ok := lookupSystemEvent("A.8624b52f9ddcd04a.FlowEpoch.EpochCommit", flow.Testnet)
+		assert.False(t, ok)
+
+		_, ok = lookupSystemEvent("A.01cf0e2f2f715450.FlowFees.FeesDeducted", flow.Mainnet)
+		assert.False(t, ok)
+
+		_, ok = lookupSystemEvent("A.f919ee77447b7497.FlowFees.FeesDeducted", "")
+		assert.False(t, ok)
+
+		event, ok := lookupSystemEvent("A.e5a8b7f23e8b548f.FlowFees.FeesDeducted", flow.Emulator)
+		assert.True(t, ok)
+		assert.Equal(t, "Fees Deducted", event.label)
+	})
+
+	t.Run("Result", func(t *testing.T) {
+		event := EventResult{
+			BlockEvents: []flow.BlockEvents{{
+				Height: 1,
+				Events: []flow.Event{
+					*tests.NewEvent(
+						0,
+						"A.f919ee77447b7497.FlowFees.FeeParametersChanged",
+						[]cadence.Field{{Type: cadence.UFix64Type{}, Identifier: "surgeFactor"}},
+						[]cadence.Value{cadence.UFix64(100000000)},
+					),
+				},
+			}},
+			ChainID: flow.Mainnet,
+		}
+
+		output := event.String()
+		assert.Contains(t, output, "Fee Parameters Changed")
+		assert.Contains(t, output, "- Surge Factor (UFix64): 1.00000000")
+
+		result := event.JSON().([]any)
+		assert.Equal(t, "Fee Parameters Changed", result[0].(map[string]any)["name"])
+	})
+}
+
+func Test_Parquet(t *testing.T) {
+	block := tests.NewBlock()
+	event := EventResult{
+		BlockEvents: []flow.BlockEvents{{
+			BlockID: block.ID,
+			Height:  block.Height,
+			Events: []flow.Event{
+				*tests.NewEvent(
+					0,
+					"A.foo",
+					[]cadence.Field{{Type: cadence.StringType{}, Identifier: "bar"}},
+					[]cadence.Value{cadence.String("hello")},
+				),
+				*tests.NewEvent(
+					1,
+					"A.baz",
+					[]cadence.Field{{Type: cadence.IntType{}, Identifier: "event_type"}},
+					[]cadence.Value{cadence.NewInt(1)},
+				),
+			},
+		}},
+	}
+
+	data, err := event.Parquet()
+	require.NoError(t, err)
+
+	assert.Equal(t, "PAR1", string(data[:4]))
+	assert.Equal(t, "PAR1", string(data[len(data)-4:]))
+	footerLength := binary.LittleEndian.Uint32(data[len(data)-8 : len(data)-4])
+	footer := string(data[len(data)-8-int(footerLength) : len(data)-8])
+	for _, column := range append(parquetColumns, "bar", "field_event_type") {
+		assert.Contains(t, footer, column)
+	}
+	assert.Contains(t, string(data), "hello")
+}
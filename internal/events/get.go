@@ -27,14 +27,19 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsEvents struct {
-	Start   uint64 `flag:"start" info:"Start block height"`
-	End     uint64 `flag:"end" info:"End block height"`
-	Last    uint64 `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
-	Workers int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
-	Batch   uint64 `default:"25" flag:"batch" info:"Number of blocks each worker will fetch"`
+	Start      string `flag:"start" info:"Start block height, can also be 'latest', 'sealed', 'final', 'latest-N' or an RFC 3339 timestamp"`
+	End        string `flag:"end" info:"End block height, can also be 'latest', 'sealed', 'final', 'latest-N' or an RFC 3339 timestamp"`
+	Last       uint64 `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
+	Workers    int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
+	Batch      uint64 `default:"25" flag:"batch" info:"Number of blocks each worker will fetch"`
+	Resume     bool   `default:"false" flag:"resume" info:"Resume the scan from the checkpoint saved by a previous interrupted run"`
+	Checkpoint string `default:".flow-events-checkpoint.json" flag:"checkpoint" info:"File where the scan checkpoint is saved when scanning a range larger than workers times batch blocks"`
+	Results    string `default:".flow-events-results.ndjson" flag:"results" info:"File where the scanned events are appended until the scan is completed"`
+	Format     string `default:"" flag:"format" info:"Export the events in a file format saved with the --save flag, options: \"parquet\""`
 }
 
 var eventsFlags = flagsEvents{}
@@ -50,64 +55,172 @@ flow events get A.1654653399040a61.FlowToken.TokensDeposited
 #specify manual start and stop blocks
 flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 11559500 --end 11559600
 
+#long scans save a checkpoint after each batch of blocks, interrupted scans can be resumed
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --resume
+
+#heights can be relative to the latest block or resolved from a time
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 2023-06-01T15:00:00Z --end latest
+
 #in order to get and event from the 20 latest blocks on a network run
 flow events get A.1654653399040a61.FlowToken.TokensDeposited --last 20 --network mainnet
 
 #if you want to fetch multiple event types that is done by sending in more events. Even fetching will be done in parallel.
 flow events get A.1654653399040a61.FlowToken.TokensDeposited A.1654653399040a61.FlowToken.TokensWithdrawn
+
+#contracts deployed or aliased in the project configuration can be referenced by name
+flow events get FlowToken.TokensDeposited --network mainnet
+
+#export the events as a Parquet table with a row per event and a column per event field
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --last 100 --format parquet --save events.parquet
 	`,
 	},
 	Flags: &eventsFlags,
 	Run:   get,
 }
 
+func init() {
+	getCommand.Cmd.ValidArgsFunction = completeEventTypes
+}
+
 func get(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
-	_ flowkit.ReaderWriter,
+	readerWriter flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
-	var err error
-	start := eventsFlags.Start
-	end := eventsFlags.End
-	last := eventsFlags.Last
+	// project configuration is optional, it is only used for resolving event type shorthands
+	state, err := loadProject(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
 
-	// handle if not passing start and end
-	if start == 0 && end == 0 {
-		latest, err := flow.GetBlock(
-			context.Background(),
-			flowkit.BlockQuery{Latest: true},
-		)
+	if err := validateExport(eventsFlags.Format, globalFlags); err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := resolveEventTypes(context.Background(), args, flow, state)
+	if err != nil {
+		return nil, err
+	}
+
+	var cp *checkpoint
+	if eventsFlags.Resume {
+		cp, err = loadCheckpoint(readerWriter, eventsFlags.Checkpoint, flow.Network().Name, eventTypes)
 		if err != nil {
 			return nil, err
 		}
-		end = latest.Height
-
-		start = end - last
-		if end < last {
-			start = 0
+	} else {
+		start, end, err := eventsRange(flow)
+		if err != nil {
+			return nil, err
 		}
-	} else if start == 0 || end == 0 {
-		return nil, fmt.Errorf("please provide either both start and end for range or only last flag")
+		cp = newCheckpoint(flow.Network().Name, eventTypes, start, end)
 	}
 
-	logger.StartProgress("Fetching events...")
 	defer logger.StopProgress()
 
-	events, err := flow.GetEvents(
-		context.Background(),
-		args,
-		start,
-		end,
+	events, err := scanEvents(
+		flow,
+		logger,
+		readerWriter,
+		cp,
 		&flowkit.EventWorker{
 			Count:           eventsFlags.Workers,
 			BlocksPerWorker: eventsFlags.Batch,
 		},
+		eventsFlags.Checkpoint,
+		eventsFlags.Results,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return &EventResult{BlockEvents: events}, nil
+	result := &EventResult{BlockEvents: events, ChainID: ChainID(flow)}
+	if eventsFlags.Format == formatParquet {
+		data, err := result.Parquet()
+		if err != nil {
+			return nil, err
+		}
+		return &exportResult{data: data}, nil
+	}
+
+	return result, nil
+}
+
+const formatParquet = "parquet"
+
+// validateExport checks the export format is supported and the export is saved to a file unchanged.
+func validateExport(format string, globalFlags command.GlobalFlags) error {
+	if format == "" {
+		return nil
+	}
+
+	if format != formatParquet {
+		return fmt.Errorf("unsupported format %s, options: \"parquet\"", format)
+	}
+	if globalFlags.Save == "" {
+		return fmt.Errorf("%s export must be saved to a file using the --save flag", format)
+	}
+	if (globalFlags.Format != "" && globalFlags.Format != "text") || globalFlags.Filter != "" || globalFlags.Plain {
+		return fmt.Errorf("%s export can not be combined with the --output, --filter or --plain flags", format)
+	}
+
+	return nil
+}
+
+// exportResult contains the events exported in a binary file format, which is saved to a file as is.
+type exportResult struct {
+	data []byte
+}
+
+func (r *exportResult) String() string {
+	return string(r.data)
+}
+
+func (r *exportResult) Oneliner() string {
+	return string(r.data)
+}
+
+func (r *exportResult) JSON() any {
+	return r.data
+}
+
+// eventsRange resolves the start and end heights from the flags.
+func eventsRange(flow flowkit.Services) (uint64, uint64, error) {
+	// handle if not passing start and end
+	if eventsFlags.Start == "" && eventsFlags.End == "" {
+		latest, err := flow.GetBlock(
+			context.Background(),
+			flowkit.BlockQuery{Latest: true},
+		)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		end := latest.Height
+		start := end - eventsFlags.Last
+		if end < eventsFlags.Last {
+			start = 0
+		}
+		return start, end, nil
+	}
+
+	if eventsFlags.Start == "" || eventsFlags.End == "" {
+		return 0, 0, fmt.Errorf("please provide either both start and end for range or only last flag")
+	}
+
+	start, err := util.BlockHeight(context.Background(), flow, eventsFlags.Start)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid start: %w", err)
+	}
+	end, err := util.BlockHeight(context.Background(), flow, eventsFlags.End)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid end: %w", err)
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("cannot have end height (%d) of block range less that start height (%d)", end, start)
+	}
+
+	return start, end, nil
 }
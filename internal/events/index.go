@@ -0,0 +1,161 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsIndex struct {
+	Start      string `default:"" flag:"start" info:"Start block height, can also be 'latest-N' or an RFC 3339 timestamp"`
+	End        string `default:"sealed" flag:"end" info:"End block height, can also be 'latest', 'sealed', 'final', 'latest-N' or an RFC 3339 timestamp"`
+	Workers    int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
+	Batch      uint64 `default:"25" flag:"batch" info:"Number of blocks each worker will fetch"`
+	File       string `default:"events.ndjson" flag:"file" info:"File the events are appended to as newline delimited JSON"`
+	Resume     bool   `default:"false" flag:"resume" info:"Resume the indexing from the checkpoint saved by a previous interrupted run"`
+	Checkpoint string `default:".flow-index-checkpoint.json" flag:"checkpoint" info:"File where the indexing checkpoint is saved"`
+}
+
+var indexFlags = flagsIndex{}
+
+// IndexCommand indexes the events of a block range into a file, which unlike the events get command
+// doesn't keep the events in memory, so it's suitable for very large ranges.
+var IndexCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "index <event_name>",
+		Short: "Index events in a block range into a file",
+		Args:  cobra.MinimumNArgs(1),
+		Example: `#index the events since the block height into events.ndjson
+flow index A.1654653399040a61.FlowToken.TokensDeposited --start 11559500 --network mainnet
+
+#a checkpoint is saved after each batch of blocks, interrupted indexing can be resumed
+flow index A.1654653399040a61.FlowToken.TokensDeposited --resume --network mainnet
+
+#index multiple event types from a time until a block height into a file
+flow index FlowToken.TokensDeposited FlowToken.TokensWithdrawn --start 2023-06-01T15:00:00Z --end 11600000 --file tokens.ndjson`,
+	},
+	Flags: &indexFlags,
+	Run:   index,
+}
+
+func init() {
+	IndexCommand.Cmd.ValidArgsFunction = completeEventTypes
+}
+
+func index(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	state, err := loadProject(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := resolveEventTypes(context.Background(), args, flow, state)
+	if err != nil {
+		return nil, err
+	}
+
+	var cp *checkpoint
+	if indexFlags.Resume {
+		cp, err = loadCheckpoint(readerWriter, indexFlags.Checkpoint, flow.Network().Name, eventTypes)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if indexFlags.Start == "" {
+			return nil, fmt.Errorf("provide the start of the range with the --start flag")
+		}
+
+		start, err := util.BlockHeight(context.Background(), flow, indexFlags.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start: %w", err)
+		}
+		end, err := util.BlockHeight(context.Background(), flow, indexFlags.End)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end: %w", err)
+		}
+		if end < start {
+			return nil, fmt.Errorf("cannot have end height (%d) of block range less that start height (%d)", end, start)
+		}
+		cp = newCheckpoint(flow.Network().Name, eventTypes, start, end)
+	}
+
+	defer logger.StopProgress()
+
+	err = indexEvents(
+		flow,
+		logger,
+		readerWriter,
+		cp,
+		&flowkit.EventWorker{
+			Count:           indexFlags.Workers,
+			BlocksPerWorker: indexFlags.Batch,
+		},
+		indexFlags.Checkpoint,
+		indexFlags.File,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &indexResult{file: indexFlags.File, start: cp.Start, end: cp.End}, nil
+}
+
+type indexResult struct {
+	file  string
+	start uint64
+	end   uint64
+}
+
+func (r *indexResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Events indexed\n\n", output.SuccessEmoji())
+	_, _ = fmt.Fprintf(writer, "Blocks\t %d to %d\n", r.start, r.end)
+	_, _ = fmt.Fprintf(writer, "File\t %s\n", r.file)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *indexResult) JSON() any {
+	return map[string]any{
+		"file":  r.file,
+		"start": r.start,
+		"end":   r.end,
+	}
+}
+
+func (r *indexResult) Oneliner() string {
+	return r.file
+}
@@ -0,0 +1,290 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/flowkit/util"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+// loadProject loads the project configuration, which is optional for the events commands since it's only used
+// for resolving event type shorthands, so nil is returned if the configuration doesn't exist.
+func loadProject(configPaths []string, readerWriter flowkit.ReaderWriter) (*flowkit.State, error) {
+	state, err := flowkit.Load(configPaths, readerWriter)
+	if errors.Is(err, config.ErrDoesNotExist) {
+		return nil, nil
+	}
+	return state, err
+}
+
+// resolveEventTypes resolves all the event types, see resolveEventType.
+func resolveEventTypes(
+	ctx context.Context,
+	eventTypes []string,
+	services flowkit.Services,
+	state *flowkit.State,
+) ([]string, error) {
+	resolver := newEventTypeResolver(services, state)
+	resolved := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		eventType, err := resolver.resolve(ctx, eventType)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, eventType)
+	}
+	return resolved, nil
+}
+
+// resolveEventType resolves the shorthand Contract.Event event type using the project deployments and aliases,
+// and validates that the event type is declared in the deployed contract.
+//
+// Event types which are not declared by contracts, like flow.AccountCreated, are returned unchanged.
+func resolveEventType(
+	ctx context.Context,
+	eventType string,
+	services flowkit.Services,
+	state *flowkit.State,
+) (string, error) {
+	return newEventTypeResolver(services, state).resolve(ctx, eventType)
+}
+
+// eventTypeResolver resolves event types, fetching each account with the declaring contracts only once.
+type eventTypeResolver struct {
+	services flowkit.Services
+	state    *flowkit.State
+	accounts map[flow.Address]*flow.Account
+}
+
+func newEventTypeResolver(services flowkit.Services, state *flowkit.State) *eventTypeResolver {
+	return &eventTypeResolver{
+		services: services,
+		state:    state,
+		accounts: make(map[flow.Address]*flow.Account),
+	}
+}
+
+func (r *eventTypeResolver) resolve(ctx context.Context, eventType string) (string, error) {
+	parts := strings.Split(eventType, ".")
+
+	if len(parts) == 2 && parts[0] != "flow" {
+		// shorthand can only be resolved from the project configuration
+		if r.state == nil {
+			return "", fmt.Errorf(
+				"event type %s can only be resolved with a project configuration, use the full event type A.{address}.%s instead",
+				eventType,
+				eventType,
+			)
+		}
+
+		network := r.services.Network()
+		address, err := contractAddress(r.state, network, parts[0])
+		if err != nil {
+			return "", err
+		}
+
+		parts = []string{"A", address.Hex(), parts[0], parts[1]}
+		eventType = strings.Join(parts, ".")
+	}
+
+	if len(parts) != 4 || parts[0] != "A" {
+		return eventType, nil
+	}
+
+	account, err := r.account(ctx, flow.HexToAddress(parts[1]))
+	if err != nil {
+		return "", fmt.Errorf("failed to validate event type: %w", err)
+	}
+
+	err = validateEventType(account, parts[2], parts[3])
+	if err != nil {
+		return "", err
+	}
+
+	return eventType, nil
+}
+
+// account returns the account at the address, fetching it only the first time.
+func (r *eventTypeResolver) account(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	if account, ok := r.accounts[address]; ok {
+		return account, nil
+	}
+
+	account, err := r.services.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	r.accounts[address] = account
+	return account, nil
+}
+
+// contractAddress returns the address of the contract deployed or aliased on the network.
+func contractAddress(state *flowkit.State, network config.Network, name string) (flow.Address, error) {
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return flow.EmptyAddress, err
+	}
+
+	for _, contract := range contracts {
+		if contract.Name == name {
+			return contract.AccountAddress, nil
+		}
+	}
+
+	contract, err := state.Contracts().ByName(name)
+	if err == nil && contract.Aliases.ByNetwork(network.Name) != nil {
+		return contract.Aliases.ByNetwork(network.Name).Address, nil
+	}
+
+	return flow.EmptyAddress, fmt.Errorf(
+		"contract %s is not deployed or aliased on network %s%s",
+		name,
+		network.Name,
+		suggestion(name, append(deployedNames(contracts), aliasedNames(state, network)...)),
+	)
+}
+
+// validateEventType checks the event is declared in the contract deployed to the account.
+func validateEventType(account *flow.Account, contractName string, eventName string) error {
+	code, ok := account.Contracts[contractName]
+	if !ok {
+		return fmt.Errorf(
+			"contract %s is not deployed to account 0x%s%s",
+			contractName,
+			account.Address.Hex(),
+			suggestion(contractName, maps.Keys(account.Contracts)),
+		)
+	}
+
+	events, err := ContractEvents(code)
+	if err != nil {
+		return nil // contracts we can't parse are not validated
+	}
+
+	for _, event := range events {
+		if event == eventName {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"event %s is not declared in contract %s%s",
+		eventName,
+		contractName,
+		suggestion(eventName, events),
+	)
+}
+
+// ContractEvents returns names of the events declared in the contract code.
+func ContractEvents(code []byte) ([]string, error) {
+	program, err := project.NewProgram(code, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	abi, err := program.ABI()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]string, 0, len(abi.Events))
+	for _, event := range abi.Events {
+		events = append(events, event.Name)
+	}
+	return events, nil
+}
+
+// completeEventTypes suggests event types declared by the contracts deployed in the project.
+func completeEventTypes(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	state, err := flowkit.Load(command.Flags.ConfigPaths, &afero.Afero{Fs: afero.NewOsFs()})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	network, err := state.Networks().ByName(command.Flags.Network)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	contracts, err := state.DeploymentContractsByNetwork(*network)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	suggestions := make([]string, 0)
+	for _, contract := range contracts {
+		events, err := ContractEvents(contract.Code())
+		if err != nil {
+			continue
+		}
+
+		for _, event := range events {
+			suggestions = append(suggestions,
+				fmt.Sprintf("%s.%s", contract.Name, event),
+				fmt.Sprintf("A.%s.%s.%s", contract.AccountAddress.Hex(), contract.Name, event),
+			)
+		}
+	}
+
+	sort.Strings(suggestions)
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func deployedNames(contracts []*project.Contract) []string {
+	names := make([]string, 0, len(contracts))
+	for _, contract := range contracts {
+		names = append(names, contract.Name)
+	}
+	return names
+}
+
+func aliasedNames(state *flowkit.State, network config.Network) []string {
+	names := make([]string, 0)
+	for _, contract := range *state.Contracts() {
+		if contract.Aliases.ByNetwork(network.Name) != nil {
+			names = append(names, contract.Name)
+		}
+	}
+	return names
+}
+
+// suggestion returns a hint with the closest candidate to the name, or empty string if no candidate is close enough.
+func suggestion(name string, candidates []string) string {
+	sort.Strings(candidates)
+
+	closest := util.ClosestMatch(name, candidates)
+	if closest == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %s?", closest)
+}
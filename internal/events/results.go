@@ -0,0 +1,211 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/afero"
+
+	"github.com/onflow/flow-cli/flowkit"
+)
+
+// indexedBlock is a line of the results file, which contains the events of a block as newline delimited JSON.
+type indexedBlock struct {
+	ID        string         `json:"id"`
+	Height    uint64         `json:"height"`
+	Timestamp time.Time      `json:"timestamp"`
+	Events    []indexedEvent `json:"events"`
+}
+
+type indexedEvent struct {
+	Type             string          `json:"type"`
+	TransactionID    string          `json:"transactionId"`
+	TransactionIndex int             `json:"transactionIndex"`
+	EventIndex       int             `json:"eventIndex"`
+	Value            json.RawMessage `json:"value"`
+}
+
+// appendResults appends the blocks with events to the results file, blocks without events are skipped.
+func appendResults(readerWriter flowkit.ReaderWriter, filename string, blockEvents []flow.BlockEvents) error {
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	for _, block := range blockEvents {
+		if len(block.Events) == 0 {
+			continue
+		}
+
+		events := make([]indexedEvent, 0, len(block.Events))
+		for _, event := range block.Events {
+			value, err := jsoncdc.Encode(event.Value)
+			if err != nil {
+				return err
+			}
+
+			events = append(events, indexedEvent{
+				Type:             event.Type,
+				TransactionID:    event.TransactionID.String(),
+				TransactionIndex: event.TransactionIndex,
+				EventIndex:       event.EventIndex,
+				Value:            value,
+			})
+		}
+
+		err := encoder.Encode(indexedBlock{
+			ID:        block.BlockID.String(),
+			Height:    block.Height,
+			Timestamp: block.BlockTimestamp,
+			Events:    events,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if data.Len() == 0 {
+		return nil
+	}
+	return appendFile(readerWriter, filename, data.Bytes())
+}
+
+// appendFile appends the data to the file without rewriting it, if the reader writer supports opening files.
+func appendFile(readerWriter flowkit.ReaderWriter, filename string, data []byte) error {
+	fs, ok := readerWriter.(interface {
+		OpenFile(string, int, os.FileMode) (afero.File, error)
+	})
+	if !ok {
+		existing, err := readerWriter.ReadFile(filename)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return readerWriter.WriteFile(filename, append(existing, data...), 0644)
+	}
+
+	file, err := fs.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// truncateResults drops the blocks at or after the height from the results file, which were appended
+// by an interrupted scan before the checkpoint was saved.
+//
+// The last line is dropped if it's incomplete, since the scan could be interrupted while appending.
+func truncateResults(readerWriter flowkit.ReaderWriter, filename string, height uint64) error {
+	blocks, err := readIndexedBlocks(readerWriter, filename, true)
+	if err != nil {
+		return err
+	}
+
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	for _, block := range blocks {
+		if block.Height >= height {
+			continue
+		}
+		if err := encoder.Encode(block); err != nil {
+			return err
+		}
+	}
+
+	return readerWriter.WriteFile(filename, data.Bytes(), 0644)
+}
+
+// readResults reads the block events from the results file.
+func readResults(readerWriter flowkit.ReaderWriter, filename string) ([]flow.BlockEvents, error) {
+	blocks, err := readIndexedBlocks(readerWriter, filename, false)
+	if err != nil {
+		return nil, err
+	}
+
+	blockEvents := make([]flow.BlockEvents, 0, len(blocks))
+	for _, block := range blocks {
+		events := make([]flow.Event, 0, len(block.Events))
+		for _, event := range block.Events {
+			value, err := jsoncdc.Decode(nil, event.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			cadenceEvent, ok := value.(cadence.Event)
+			if !ok {
+				return nil, fmt.Errorf("invalid event value in results %s at height %d", filename, block.Height)
+			}
+
+			events = append(events, flow.Event{
+				Type:             event.Type,
+				TransactionID:    flow.HexToID(event.TransactionID),
+				TransactionIndex: event.TransactionIndex,
+				EventIndex:       event.EventIndex,
+				Value:            cadenceEvent,
+				Payload:          event.Value,
+			})
+		}
+
+		blockEvents = append(blockEvents, flow.BlockEvents{
+			BlockID:        flow.HexToID(block.ID),
+			Height:         block.Height,
+			BlockTimestamp: block.Timestamp,
+			Events:         events,
+		})
+	}
+
+	return blockEvents, nil
+}
+
+func readIndexedBlocks(readerWriter flowkit.ReaderWriter, filename string, dropIncomplete bool) ([]indexedBlock, error) {
+	data, err := readerWriter.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	blocks := make([]indexedBlock, 0, len(lines))
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+
+		var block indexedBlock
+		if err := json.Unmarshal(line, &block); err != nil {
+			// the last line is only complete once the newline is appended
+			if dropIncomplete && i == len(lines)-1 {
+				break
+			}
+			return nil, fmt.Errorf("invalid results %s at line %d: %w", filename, i+1, err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
@@ -0,0 +1,131 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsSubscribe struct {
+	SinceHeight uint64 `default:"0" flag:"since-height" info:"Block height to start from, past blocks are backfilled before following new blocks. Defaults to the latest sealed block"`
+}
+
+var subscribeFlags = flagsSubscribe{}
+
+var subscribeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "subscribe <event_name>",
+		Short: "Subscribe to events as blocks are sealed",
+		Args:  cobra.MinimumNArgs(1),
+		Example: `#print deposit events as new blocks are sealed until interrupted
+flow events subscribe A.1654653399040a61.FlowToken.TokensDeposited --network mainnet
+
+#backfill the events starting at a past block height before following new blocks
+flow events subscribe A.1654653399040a61.FlowToken.TokensDeposited --since-height 55000000 --network mainnet
+
+#print each block of events as a JSON line
+flow events subscribe A.1654653399040a61.FlowToken.TokensDeposited A.1654653399040a61.FlowToken.TokensWithdrawn --output json
+	`,
+	},
+	Flags: &subscribeFlags,
+	Run:   subscribe,
+}
+
+func init() {
+	subscribeCommand.Cmd.ValidArgsFunction = completeEventTypes
+}
+
+func subscribe(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	// project configuration is optional, it is only used for resolving event type shorthands
+	state, err := loadProject(globalFlags.ConfigPaths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := resolveEventTypes(context.Background(), args, flow, state)
+	if err != nil {
+		return nil, err
+	}
+
+	// the subscription ends when the command is interrupted
+	ctx := command.Interrupted()
+	events, errs, err := flow.SubscribeEvents(ctx, eventTypes, subscribeFlags.SinceHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("Subscribed to %s, press Ctrl+C to stop.\n", strings.Join(eventTypes, ", ")))
+
+	// events are printed as they are received, so there is no result left to print once the subscription ends
+	err = followEvents(os.Stdout, events, errs, globalFlags.Format, ChainID(flow))
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// followEvents writes the blocks containing events as they are received until the subscription ends,
+// and returns the error the subscription ended with.
+//
+// Each block is written as a JSON line when using the JSON format, so the output can be piped to other tools.
+func followEvents(
+	w io.Writer,
+	events <-chan flow.BlockEvents,
+	errs <-chan error,
+	format string,
+	chainID flow.ChainID,
+) error {
+	for blockEvents := range events {
+		if len(blockEvents.Events) == 0 {
+			continue
+		}
+
+		result := &EventResult{BlockEvents: []flow.BlockEvents{blockEvents}, ChainID: chainID}
+		if format == "json" {
+			out, err := json.Marshal(result.JSON())
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(w, string(out))
+			continue
+		}
+
+		_, _ = fmt.Fprint(w, result.String())
+	}
+
+	return <-errs
+}
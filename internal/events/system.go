@@ -0,0 +1,189 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	flowGo "github.com/onflow/flow-go/model/flow"
+
+	"github.com/onflow/flow-cli/flowkit"
+)
+
+// systemEvent describes a known protocol or service event with human-readable labels for its fields.
+type systemEvent struct {
+	label  string
+	fields map[string]string
+}
+
+// systemEvents contains known events emitted by the protocol (flow.*) or by service contracts.
+// Service contract events are keyed by contract and event name since the contract address differs between networks,
+// the address of the contract on the chain is checked when looking up the event.
+var systemEvents = map[string]systemEvent{
+	"flow.AccountCreated": {
+		label:  "Account Created",
+		fields: map[string]string{"address": "Address"},
+	},
+	"flow.AccountKeyAdded": {
+		label:  "Account Key Added",
+		fields: map[string]string{"address": "Address", "publicKey": "Public Key"},
+	},
+	"flow.AccountKeyRemoved": {
+		label:  "Account Key Removed",
+		fields: map[string]string{"address": "Address", "publicKey": "Public Key"},
+	},
+	"flow.AccountContractAdded": {
+		label:  "Account Contract Added",
+		fields: map[string]string{"address": "Address", "codeHash": "Code Hash", "contract": "Contract"},
+	},
+	"flow.AccountContractUpdated": {
+		label:  "Account Contract Updated",
+		fields: map[string]string{"address": "Address", "codeHash": "Code Hash", "contract": "Contract"},
+	},
+	"flow.AccountContractRemoved": {
+		label:  "Account Contract Removed",
+		fields: map[string]string{"address": "Address", "codeHash": "Code Hash", "contract": "Contract"},
+	},
+	"FlowEpoch.EpochStart": {
+		label: "Epoch Start",
+		fields: map[string]string{
+			"counter":            "Epoch Counter",
+			"firstView":          "First View",
+			"stakingPhaseLength": "Staking Phase Length",
+			"totalStaked":        "Total Staked",
+			"totalRewardsPayout": "Total Rewards Payout",
+		},
+	},
+	"FlowEpoch.EpochSetup": {
+		label: "Epoch Setup",
+		fields: map[string]string{
+			"counter":            "Epoch Counter",
+			"nodeInfo":           "Participants",
+			"firstView":          "First View",
+			"finalView":          "Final View",
+			"collectorClusters":  "Collector Clusters",
+			"randomSource":       "Random Source",
+			"DKGPhase1FinalView": "DKG Phase 1 Final View",
+			"DKGPhase2FinalView": "DKG Phase 2 Final View",
+			"DKGPhase3FinalView": "DKG Phase 3 Final View",
+		},
+	},
+	"FlowEpoch.EpochCommit": {
+		label: "Epoch Commit",
+		fields: map[string]string{
+			"counter":    "Epoch Counter",
+			"clusterQCs": "Cluster Quorum Certificates",
+			"dkgPubKeys": "DKG Public Keys",
+		},
+	},
+	"NodeVersionBeacon.VersionBeacon": {
+		label: "Version Beacon",
+		fields: map[string]string{
+			"versionBoundaries": "Version Boundaries",
+			"sequence":          "Sequence",
+		},
+	},
+	"FlowFees.FeesDeducted": {
+		label: "Fees Deducted",
+		fields: map[string]string{
+			"amount":          "Amount",
+			"inclusionEffort": "Inclusion Effort",
+			"executionEffort": "Execution Effort",
+		},
+	},
+	"FlowFees.FeeParametersChanged": {
+		label: "Fee Parameters Changed",
+		fields: map[string]string{
+			"surgeFactor":         "Surge Factor",
+			"inclusionEffortCost": "Inclusion Effort Cost",
+			"executionEffortCost": "Execution Effort Cost",
+		},
+	},
+	"FlowServiceAccount.TransactionFeeUpdated": {
+		label:  "Transaction Fee Updated",
+		fields: map[string]string{"newFee": "New Fee"},
+	},
+	"FlowServiceAccount.AccountCreationFeeUpdated": {
+		label:  "Account Creation Fee Updated",
+		fields: map[string]string{"newFee": "New Fee"},
+	},
+}
+
+// lookupSystemEvent returns the system event description for the event type if the event is known.
+//
+// Protocol events are matched by their full type, and contract events (A.<address>.<contract>.<event>)
+// are matched by the contract and event name if the contract is deployed to the system contract address
+// on the chain, so user contracts with the same name are not labelled as system events.
+func lookupSystemEvent(eventType string, chainID flow.ChainID) (systemEvent, bool) {
+	if event, ok := systemEvents[eventType]; ok {
+		return event, true
+	}
+
+	parts := strings.Split(eventType, ".")
+	if len(parts) != 4 || parts[0] != "A" {
+		return systemEvent{}, false
+	}
+
+	address, ok := systemContractAddresses(chainID)[parts[2]]
+	if !ok || address != flow.HexToAddress(parts[1]) {
+		return systemEvent{}, false
+	}
+
+	event, ok := systemEvents[strings.Join(parts[2:], ".")]
+	return event, ok
+}
+
+// systemContractAddresses returns the addresses of the contracts emitting the system events on the chain,
+// or no addresses if the chain is not known.
+func systemContractAddresses(chainID flow.ChainID) map[string]flow.Address {
+	contracts, err := systemcontracts.SystemContractsForChain(flowGo.ChainID(chainID))
+	if err != nil {
+		return nil
+	}
+
+	chain := flowGo.ChainID(chainID).Chain()
+	return map[string]flow.Address{
+		contracts.Epoch.Name:             flow.Address(contracts.Epoch.Address),
+		contracts.NodeVersionBeacon.Name: flow.Address(contracts.NodeVersionBeacon.Address),
+		"FlowFees":                       flow.Address(environment.FlowFeesAddress(chain)),
+		"FlowServiceAccount":             flow.Address(chain.ServiceAddress()),
+	}
+}
+
+// ChainID returns the chain ID of the network used for labelling the system events,
+// or an empty chain ID if it can't be fetched, in which case only protocol events are labelled.
+func ChainID(flow flowkit.Services) flow.ChainID {
+	chainID, err := flow.Gateway().GetNetworkParameters(context.Background())
+	if err != nil {
+		return ""
+	}
+	return chainID
+}
+
+// fieldLabel returns a human-readable label for the event field or the field identifier if not known.
+func (s systemEvent) fieldLabel(identifier string) string {
+	if label, ok := s.fields[identifier]; ok {
+		return label
+	}
+	return identifier
+}
@@ -0,0 +1,97 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsABI struct{}
+
+var abiFlags = flagsABI{}
+
+var abiCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "abi <contract name | filename>",
+		Short:   "Generate a JSON description of the contract public interface",
+		Example: "flow generate abi FungibleToken\nflow generate abi ./contracts/Foo.cdc --save abi.json",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &abiFlags,
+	RunS:  abi,
+}
+
+func abi(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	location := args[0]
+	// argument can be a name of the contract defined in the configuration
+	if contract, err := state.Contracts().ByName(location); err == nil {
+		location = state.Config().SourceLocation(contract.Location)
+	}
+
+	code, err := state.ReadFile(location)
+	if err != nil {
+		return nil, fmt.Errorf("error loading contract file: %w", err)
+	}
+
+	program, err := project.NewProgram(code, nil, location)
+	if err != nil {
+		return nil, err
+	}
+
+	contractABI, err := program.ABI()
+	if err != nil {
+		return nil, err
+	}
+
+	return &abiResult{contractABI}, nil
+}
+
+type abiResult struct {
+	*project.ABI
+}
+
+func (r *abiResult) JSON() any {
+	return r.ABI
+}
+
+func (r *abiResult) String() string {
+	out, _ := json.MarshalIndent(r.ABI, "", "  ")
+	return string(out)
+}
+
+func (r *abiResult) Oneliner() string {
+	return fmt.Sprintf(
+		"%s %s: %d functions, %d events, %d types",
+		r.Kind, r.Name, len(r.Functions), len(r.Events), len(r.Types),
+	)
+}
@@ -0,0 +1,391 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsFCLBundle struct {
+	Dir string `default:"fcl" flag:"dir" info:"Directory where the TypeScript modules are written"`
+}
+
+var fclBundleFlags = flagsFCLBundle{}
+
+var fclBundleCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "fcl-bundle [<cadence directory>]",
+		Short:   "Generate TypeScript modules for using the Cadence files with FCL",
+		Example: "flow generate fcl-bundle ./cadence --dir ./web/src/flow",
+		Args:    cobra.MaximumNArgs(1),
+	},
+	Flags: &fclBundleFlags,
+	RunS:  fclBundle,
+}
+
+const defaultCadenceDir = "cadence"
+
+func fclBundle(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	dir := defaultCadenceDir
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	// the directories are listed and created with the file system of the state, like the files are read and written
+	fs, ok := state.ReaderWriter().(afero.Fs)
+	if !ok {
+		return nil, fmt.Errorf("listing the Cadence files is not supported by the project file system")
+	}
+
+	files, err := cadenceFiles(fs, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Cadence files: %w", err)
+	}
+
+	locations, err := networkLocations(state)
+	if err != nil {
+		return nil, err
+	}
+
+	generated := make([]string, 0, len(files))
+	for _, file := range files {
+		code, err := state.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		module, err := fclModule(code, filepath.ToSlash(file), locations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate module for %s: %w", file, err)
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return nil, err
+		}
+		target := filepath.Join(fclBundleFlags.Dir, strings.TrimSuffix(rel, filepath.Ext(rel))+".ts")
+
+		if err := fs.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, err
+		}
+		if err := state.ReaderWriter().WriteFile(target, module, 0644); err != nil {
+			return nil, err
+		}
+
+		logger.Debug(fmt.Sprintf("generated %s from %s", target, file))
+		generated = append(generated, target)
+	}
+
+	return &fclBundleResult{files: generated}, nil
+}
+
+// cadenceFiles returns all the Cadence files inside the directory, including nested directories.
+func cadenceFiles(fs afero.Fs, dir string) ([]string, error) {
+	files := make([]string, 0)
+	err := afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && filepath.Ext(path) == ".cdc" {
+			files = append(files, path)
+		}
+		return nil
+	})
+
+	return files, err
+}
+
+// networkLocations returns addresses of contracts on each network, keyed by the contract location and name,
+// the same way imports are resolved when deploying the project.
+func networkLocations(state *flowkit.State) (map[string]map[string]string, error) {
+	locations := make(map[string]map[string]string)
+	for _, network := range *state.Networks() {
+		contracts, err := state.DeploymentContractsByNetwork(network)
+		if err != nil {
+			return nil, err
+		}
+
+		addresses := make(map[string]string)
+		for _, contract := range contracts {
+			address := fmt.Sprintf("0x%s", contract.AccountAddress.Hex())
+			addresses[project.CleanLocation(contract.Location())] = address
+			addresses[contract.Name] = address
+		}
+		for location, address := range state.AliasesForNetwork(network) {
+			addresses[location] = fmt.Sprintf("0x%s", strings.TrimPrefix(address, "0x"))
+		}
+
+		locations[network.Name] = addresses
+	}
+
+	return locations, nil
+}
+
+type fclImport struct {
+	name     string
+	location string
+}
+
+// placeholder used in place of the contract address, following the FCL address replacement convention.
+func (i fclImport) placeholder() string {
+	return fmt.Sprintf("0x%s", i.name)
+}
+
+// fclModule generates a TypeScript module for the Cadence code, containing the code with imports replaced
+// by address placeholders, the addresses for each network and an argument builder.
+func fclModule(code []byte, location string, locations map[string]map[string]string) ([]byte, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	template := []byte(string(code))
+	imports := make([]fclImport, 0)
+	declarations := program.ImportDeclarations()
+	// replace imports from the last one, so the offsets of the preceding imports stay valid
+	for i := len(declarations) - 1; i >= 0; i-- {
+		declaration := declarations[i]
+		stringLocation, ok := declaration.Location.(common.StringLocation)
+		if !ok {
+			continue
+		}
+
+		imp := fclImport{
+			name:     string(stringLocation),
+			location: string(stringLocation),
+		}
+		if len(declaration.Identifiers) > 0 {
+			imp.name = declaration.Identifiers[0].Identifier
+		}
+		imports = append([]fclImport{imp}, imports...)
+
+		replacement := fmt.Sprintf("import %s from %s", imp.name, imp.placeholder())
+		start, end := declaration.StartPos.Offset, declaration.EndPos.Offset+1
+		template = append(template[:start], append([]byte(replacement), template[end:]...)...)
+	}
+
+	parameters, isInteraction := interactionParameters(program)
+
+	var b bytes.Buffer
+	_, _ = fmt.Fprintf(&b, "// Code generated by flow generate fcl-bundle from %s. DO NOT EDIT.\n\n", location)
+	_, _ = fmt.Fprintf(&b, "export const code = `%s`;\n\n", escapeTemplateLiteral(string(template)))
+
+	_, _ = fmt.Fprintf(&b, "export const addresses: Record<string, Record<string, string>> = {\n")
+	for _, network := range sortedNetworks(locations) {
+		_, _ = fmt.Fprintf(&b, "  %q: {\n", network)
+		for _, imp := range imports {
+			address, ok := resolveImport(locations[network], location, imp.location)
+			if ok {
+				_, _ = fmt.Fprintf(&b, "    %q: %q,\n", imp.placeholder(), address)
+			}
+		}
+		_, _ = fmt.Fprintf(&b, "  },\n")
+	}
+	_, _ = fmt.Fprintf(&b, "};\n\n")
+
+	_, _ = fmt.Fprint(&b, codeForFunction)
+
+	if isInteraction {
+		names := make([]string, 0, len(parameters))
+		builders := make([]string, 0, len(parameters))
+		for _, parameter := range parameters {
+			fclType, tsType, err := argumentType(parameter.TypeAnnotation.Type)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", parameter.Identifier.Identifier, err)
+			}
+			names = append(names, fmt.Sprintf("%s: %s", parameter.Identifier.Identifier, tsType))
+			builders = append(builders, fmt.Sprintf("    arg(%s, %s),\n", parameter.Identifier.Identifier, fclType))
+		}
+
+		_, _ = fmt.Fprintf(&b, "\nexport function args(%s) {\n", strings.Join(names, ", "))
+		_, _ = fmt.Fprintf(&b, "  return (arg: any, t: any) => [\n%s  ];\n}\n", strings.Join(builders, ""))
+	}
+
+	return b.Bytes(), nil
+}
+
+const codeForFunction = `// codeFor returns the code with the import placeholders replaced by the contract addresses on the network.
+export function codeFor(network: string): string {
+  const replacements = addresses[network];
+  if (!replacements) {
+    throw new Error("unknown network " + network);
+  }
+  return Object.keys(replacements).reduce(
+    (result, placeholder) => result.replace(new RegExp(placeholder + "\\b", "g"), replacements[placeholder]),
+    code,
+  );
+}
+`
+
+// resolveImport finds the address of the import, first by the location relative to the file and then by the contract name.
+func resolveImport(addresses map[string]string, location string, imp string) (string, bool) {
+	relative := project.CleanLocation(path.Join(path.Dir(location), imp))
+	if address, ok := addresses[relative]; ok {
+		return address, true
+	}
+	address, ok := addresses[imp]
+	return address, ok
+}
+
+// interactionParameters returns the parameters of the transaction or the script main function,
+// and whether the program is a transaction or a script at all.
+func interactionParameters(program *ast.Program) ([]*ast.Parameter, bool) {
+	if transaction := program.SoleTransactionDeclaration(); transaction != nil {
+		if transaction.ParameterList == nil {
+			return nil, true
+		}
+		return transaction.ParameterList.Parameters, true
+	}
+
+	for _, function := range program.FunctionDeclarations() {
+		if function.Identifier.Identifier == "main" {
+			if function.ParameterList == nil {
+				return nil, true
+			}
+			return function.ParameterList.Parameters, true
+		}
+	}
+
+	return nil, false
+}
+
+var fclNumberTypes = map[string]bool{
+	"Int": true, "Int8": true, "Int16": true, "Int32": true, "Int64": true, "Int128": true, "Int256": true,
+	"UInt": true, "UInt8": true, "UInt16": true, "UInt32": true, "UInt64": true, "UInt128": true, "UInt256": true,
+	"Word8": true, "Word16": true, "Word32": true, "Word64": true,
+	"Fix64": true, "UFix64": true,
+}
+
+var fclPathTypes = map[string]bool{
+	"Path": true, "StoragePath": true, "PublicPath": true, "PrivatePath": true, "CapabilityPath": true,
+}
+
+// argumentType returns the FCL type and the TypeScript type for the Cadence argument type.
+func argumentType(t ast.Type) (string, string, error) {
+	switch t := t.(type) {
+	case *ast.NominalType:
+		name := t.String()
+		switch {
+		case name == "Bool":
+			return "t.Bool", "boolean", nil
+		case name == "String" || name == "Character" || name == "Address" || fclNumberTypes[name]:
+			// FCL expects numbers as strings to keep the precision
+			return fmt.Sprintf("t.%s", name), "string", nil
+		case fclPathTypes[name]:
+			return "t.Path", "{ domain: string; identifier: string }", nil
+		}
+	case *ast.OptionalType:
+		fclType, tsType, err := argumentType(t.Type)
+		if err != nil {
+			return "", "", err
+		}
+		return fmt.Sprintf("t.Optional(%s)", fclType), fmt.Sprintf("%s | null", tsType), nil
+	case *ast.VariableSizedType:
+		fclType, tsType, err := argumentType(t.Type)
+		if err != nil {
+			return "", "", err
+		}
+		return fmt.Sprintf("t.Array(%s)", fclType), fmt.Sprintf("Array<%s>", tsType), nil
+	case *ast.ConstantSizedType:
+		fclType, tsType, err := argumentType(t.Type)
+		if err != nil {
+			return "", "", err
+		}
+		return fmt.Sprintf("t.Array(%s)", fclType), fmt.Sprintf("Array<%s>", tsType), nil
+	case *ast.DictionaryType:
+		keyFCL, keyTS, err := argumentType(t.KeyType)
+		if err != nil {
+			return "", "", err
+		}
+		valueFCL, valueTS, err := argumentType(t.ValueType)
+		if err != nil {
+			return "", "", err
+		}
+		return fmt.Sprintf("t.Dictionary({ key: %s, value: %s })", keyFCL, valueFCL),
+			fmt.Sprintf("Array<{ key: %s; value: %s }>", keyTS, valueTS),
+			nil
+	}
+
+	return "", "", fmt.Errorf("argument type %s is not supported", t.String())
+}
+
+// escapeTemplateLiteral escapes the code so it can be used inside a TypeScript template literal.
+func escapeTemplateLiteral(code string) string {
+	return strings.NewReplacer(`\`, `\\`, "`", "\\`", "${", "\\${").Replace(code)
+}
+
+func sortedNetworks(locations map[string]map[string]string) []string {
+	networks := make([]string, 0, len(locations))
+	for network := range locations {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+type fclBundleResult struct {
+	files []string
+}
+
+func (r *fclBundleResult) JSON() any {
+	return map[string]any{
+		"files": r.files,
+	}
+}
+
+func (r *fclBundleResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for _, file := range r.files {
+		_, _ = fmt.Fprintf(writer, "%s %s\n", output.SuccessEmoji(), file)
+	}
+	_, _ = fmt.Fprintf(writer, "\nGenerated %d TypeScript modules\n", len(r.files))
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *fclBundleResult) Oneliner() string {
+	return strings.Join(r.files, ", ")
+}
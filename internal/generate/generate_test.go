@@ -0,0 +1,128 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_ABI(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	code := []byte(`
+		pub contract Foo {
+			pub event Created(id: UInt64)
+			pub fun create(id: UInt64) {}
+		}
+	`)
+	require.NoError(t, rw.WriteFile("Foo.cdc", code, 0644))
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Foo", Location: "Foo.cdc"})
+
+	t.Run("Success by name", func(t *testing.T) {
+		res, err := abi([]string{"Foo"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		r := res.(*abiResult)
+		assert.Equal(t, "Foo", r.Name)
+		assert.Len(t, r.Functions, 1)
+		assert.Len(t, r.Events, 1)
+		assert.Equal(t, "contract Foo: 1 functions, 1 events, 0 types", r.Oneliner())
+	})
+
+	t.Run("Success by filename", func(t *testing.T) {
+		res, err := abi([]string{"Foo.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "Foo", res.(*abiResult).Name)
+	})
+
+	t.Run("Fail missing file", func(t *testing.T) {
+		_, err := abi([]string{"Bar"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.ErrorContains(t, err, "error loading contract file")
+	})
+}
+
+func Test_FCLModule(t *testing.T) {
+	locations := map[string]map[string]string{
+		"emulator": {
+			"contracts/Foo.cdc": "0xf8d6e0586b0a20c7",
+			"Foo":               "0xf8d6e0586b0a20c7",
+		},
+		"testnet": {
+			"Bar": "0x9a0766d93b6608b7",
+		},
+	}
+
+	t.Run("Transaction", func(t *testing.T) {
+		code := []byte("import Foo from \"../contracts/Foo.cdc\"\nimport \"Bar\"\n\ntransaction(to: Address, amount: UFix64, ids: [UInt64], memo: String?) {}\n")
+
+		module, err := fclModule(code, "transactions/send.cdc", locations)
+		require.NoError(t, err)
+
+		out := string(module)
+		assert.Contains(t, out, "export const code = `import Foo from 0xFoo\nimport Bar from 0xBar\n")
+		assert.Contains(t, out, "  \"emulator\": {\n    \"0xFoo\": \"0xf8d6e0586b0a20c7\",\n  },\n")
+		assert.Contains(t, out, "  \"testnet\": {\n    \"0xBar\": \"0x9a0766d93b6608b7\",\n  },\n")
+		assert.Contains(t, out, "export function args(to: string, amount: string, ids: Array<string>, memo: string | null) {")
+		assert.Contains(t, out, "    arg(ids, t.Array(t.UInt64)),\n")
+		assert.Contains(t, out, "    arg(memo, t.Optional(t.String)),\n")
+	})
+
+	t.Run("Contract", func(t *testing.T) {
+		module, err := fclModule([]byte("pub contract Foo { pub let bar: String\n init() { self.bar = \"`${x}`\" } }"), "Foo.cdc", locations)
+		require.NoError(t, err)
+		assert.Contains(t, string(module), "self.bar = \"\\`\\${x}\\`\"")
+		assert.NotContains(t, string(module), "export function args")
+	})
+
+	t.Run("Fail unsupported argument", func(t *testing.T) {
+		_, err := fclModule([]byte("pub fun main(foo: Foo.Bar): Int { return 1 }"), "get.cdc", locations)
+		assert.EqualError(t, err, "parameter foo: argument type Foo.Bar is not supported")
+	})
+}
+
+func Test_FCLBundle(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	fclBundleFlags.Dir = "fcl"
+
+	require.NoError(t, rw.WriteFile("cadence/scripts/get_balance.cdc", []byte("pub fun main(): Int { return 1 }"), 0644))
+	require.NoError(t, rw.WriteFile("cadence/transactions/transfer.cdc", []byte("transaction(amount: UFix64) {}"), 0644))
+	require.NoError(t, rw.WriteFile("cadence/README.md", []byte("# Cadence"), 0644))
+
+	t.Run("Success", func(t *testing.T) {
+		res, err := fclBundle(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "fcl/scripts/get_balance.ts, fcl/transactions/transfer.ts", res.Oneliner())
+
+		module, err := rw.ReadFile("fcl/transactions/transfer.ts")
+		require.NoError(t, err)
+		assert.Contains(t, string(module), "transaction(amount: UFix64) {}")
+	})
+
+	t.Run("Fail missing directory", func(t *testing.T) {
+		_, err := fclBundle([]string{"missing"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.ErrorContains(t, err, "failed to read Cadence files")
+	})
+}
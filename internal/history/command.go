@@ -0,0 +1,128 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsHistory struct {
+	All   bool `default:"false" flag:"all" info:"Show history for all networks instead of only the selected network"`
+	Limit int  `default:"20" flag:"limit" info:"Maximum number of most recent entries to show, use 0 to show all"`
+	Clear bool `default:"false" flag:"clear" info:"Clear the history"`
+}
+
+var historyFlags = flagsHistory{}
+
+var Command = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "history",
+		Short:   "Show history of commands that interacted with the network",
+		Example: "flow history --network testnet",
+		GroupID: "project",
+	},
+	Flags: &historyFlags,
+	Run:   history,
+}
+
+func history(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	rw flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if historyFlags.Clear {
+		if err := Clear(rw); err != nil {
+			return nil, err
+		}
+		logger.Info(fmt.Sprintf("%s History cleared", output.SuccessEmoji()))
+		return nil, nil
+	}
+
+	entries, err := Load(rw)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		if historyFlags.All || e.Network == flow.Network().Name {
+			filtered = append(filtered, e)
+		}
+	}
+
+	if historyFlags.Limit > 0 && len(filtered) > historyFlags.Limit {
+		filtered = filtered[len(filtered)-historyFlags.Limit:]
+	}
+
+	return &historyResult{entries: filtered}, nil
+}
+
+type historyResult struct {
+	entries []Entry
+}
+
+func (r *historyResult) JSON() any {
+	return r.entries
+}
+
+func (r *historyResult) String() string {
+	if len(r.entries) == 0 {
+		return "No history found"
+	}
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Time\tNetwork\tCommand\tStatus\tTransaction\tRequest ID\tDetails\n")
+	for _, e := range r.entries {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			output.FormatTime(e.Time),
+			e.Network,
+			e.Command,
+			e.Status,
+			e.Transaction,
+			e.RequestID,
+			e.Details,
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *historyResult) Oneliner() string {
+	var b bytes.Buffer
+	for _, e := range r.entries {
+		_, _ = fmt.Fprintf(&b, "%s %s %s %s %s\n", e.Time.Format(time.RFC3339), e.Network, e.Command, e.Status, e.Transaction)
+	}
+	return b.String()
+}
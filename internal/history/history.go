@@ -0,0 +1,145 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// FileName is the project-local file where the history of commands is stored.
+const FileName = ".flow-history.json"
+
+// maxEntries limits the number of entries kept in the history file, oldest entries are dropped first.
+const maxEntries = 1000
+
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+// Entry is a record of a single command that interacted with the network.
+type Entry struct {
+	Time        time.Time `json:"time"`
+	Command     string    `json:"command"`
+	Network     string    `json:"network"`
+	Transaction string    `json:"transaction,omitempty"`
+	Status      string    `json:"status"`
+	Details     string    `json:"details,omitempty"`
+	RequestID   string    `json:"requestId,omitempty"`
+}
+
+// Load all the entries from the history file, if the file doesn't exist no entries are returned.
+func Load(reader flowkit.ReaderWriter) ([]Entry, error) {
+	data, err := reader.ReadFile(FileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Entry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse history file %s: %w", FileName, err)
+	}
+
+	return entries, nil
+}
+
+// Record appends the entry to the history file.
+func Record(rw flowkit.ReaderWriter, entry Entry) error {
+	entries, err := Load(rw)
+	if err != nil {
+		return err
+	}
+
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	entries = append(entries, entry)
+	if len(entries) > maxEntries {
+		entries = entries[len(entries)-maxEntries:]
+	}
+
+	return save(rw, entries)
+}
+
+// RecordTransaction appends the outcome of a transaction sent by the command to the history file,
+// together with the request ID the command used on the network.
+func RecordTransaction(
+	rw flowkit.ReaderWriter,
+	command string,
+	network config.Network,
+	details string,
+	txID flow.Identifier,
+	result *flow.TransactionResult,
+	err error,
+) error {
+	entry := Entry{
+		Command:   command,
+		Network:   network.Name,
+		Status:    StatusSuccess,
+		Details:   details,
+		RequestID: network.RequestID,
+	}
+
+	if txID != flow.EmptyID {
+		entry.Transaction = txID.String()
+	}
+	if err == nil && result != nil && result.Error != nil {
+		err = result.Error
+	}
+	if err != nil {
+		entry.Status = StatusFailed
+		entry.Details = err.Error()
+		if details != "" {
+			entry.Details = fmt.Sprintf("%s (%s)", details, err.Error())
+		}
+	}
+
+	return Record(rw, entry)
+}
+
+// Clear removes all the entries from the history file.
+func Clear(rw flowkit.ReaderWriter) error {
+	return save(rw, []Entry{})
+}
+
+func save(rw flowkit.ReaderWriter, entries []Entry) error {
+	data, err := json.MarshalIndent(entries, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := rw.WriteFile(FileName, data, 0644); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package history
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_History(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	t.Run("Empty", func(t *testing.T) {
+		entries, err := Load(rw)
+		require.NoError(t, err)
+		assert.Len(t, entries, 0)
+	})
+
+	t.Run("Record", func(t *testing.T) {
+		err := RecordTransaction(rw, "transactions send", config.EmulatorNetwork, "tx.cdc", util.TestID, tests.NewTransactionResult(nil), nil)
+		require.NoError(t, err)
+
+		err = RecordTransaction(rw, "transactions send", config.Network{Name: "testnet", RequestID: "a1b2c3"}, "tx.cdc", flow.EmptyID, nil, fmt.Errorf("failure"))
+		require.NoError(t, err)
+
+		entries, err := Load(rw)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, util.TestID.String(), entries[0].Transaction)
+		assert.Equal(t, StatusSuccess, entries[0].Status)
+		assert.Equal(t, StatusFailed, entries[1].Status)
+		assert.Equal(t, "tx.cdc (failure)", entries[1].Details)
+		assert.Equal(t, "a1b2c3", entries[1].RequestID)
+	})
+
+	t.Run("Filter by network", func(t *testing.T) {
+		result, err := history([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Len(t, result.(*historyResult).entries, 1)
+
+		historyFlags.All = true
+		result, err = history([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Len(t, result.(*historyResult).entries, 2)
+		historyFlags.All = false
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		historyFlags.Clear = true
+		result, err := history([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+		historyFlags.Clear = false
+
+		entries, err := Load(rw)
+		require.NoError(t, err)
+		assert.Len(t, entries, 0)
+	})
+}
@@ -0,0 +1,111 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package i18n provides the message catalog used to localize user-facing CLI messages.
+//
+// Messages are identified by their English text, which is also the fallback when the selected
+// locale has no catalog or the catalog has no translation for the message. Catalogs are stored
+// in the locales folder as JSON objects mapping the English message to its translation, named
+// after the language they translate to, e.g. es.json.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+// EnvLang is the environment variable used to select the locale, e.g. FLOW_LANG=es.
+const EnvLang = "FLOW_LANG"
+
+// DefaultLocale is the locale messages are written in.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var catalogs = loadCatalogs()
+
+func loadCatalogs() map[string]map[string]string {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	loaded := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("invalid message catalog %s: %s", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))] = messages
+	}
+
+	return loaded
+}
+
+// Locale returns the locale selected with the FLOW_LANG environment variable.
+//
+// Values in the POSIX format like es_ES.UTF-8 are accepted, the region is used when a catalog
+// for it exists, otherwise the language catalog is used. Unsupported locales resolve to the default locale.
+func Locale() string {
+	return resolveLocale(os.Getenv(EnvLang))
+}
+
+func resolveLocale(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, ".@"); i >= 0 {
+		value = value[:i]
+	}
+	value = strings.ReplaceAll(value, "_", "-")
+
+	if _, ok := catalogs[value]; ok {
+		return value
+	}
+	if language, _, found := strings.Cut(value, "-"); found {
+		if _, ok := catalogs[language]; ok {
+			return language
+		}
+	}
+
+	return DefaultLocale
+}
+
+// T returns the translation of the message in the selected locale.
+//
+// The message is returned unchanged if it has no translation.
+func T(message string) string {
+	if translated, ok := catalogs[Locale()][message]; ok && translated != "" {
+		return translated
+	}
+	return message
+}
+
+// Errorf formats the translated format string according to the format specifiers
+// and returns the error, wrapping errors the same way as fmt.Errorf.
+func Errorf(format string, args ...any) error {
+	return fmt.Errorf(T(format), args...)
+}
@@ -0,0 +1,86 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package i18n
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Locale(t *testing.T) {
+	tests := map[string]string{
+		"":            DefaultLocale,
+		"en":          DefaultLocale,
+		"es":          "es",
+		"ES":          "es",
+		"es_ES.UTF-8": "es",
+		"es-MX":       "es",
+		"es_ES@euro":  "es",
+		"xx":          DefaultLocale,
+	}
+
+	for value, locale := range tests {
+		t.Setenv(EnvLang, value)
+		assert.Equal(t, locale, Locale(), value)
+	}
+}
+
+func Test_T(t *testing.T) {
+	t.Run("Default locale", func(t *testing.T) {
+		t.Setenv(EnvLang, "")
+		assert.Equal(t, "Execute a script", T("Execute a script"))
+	})
+
+	t.Run("Translated", func(t *testing.T) {
+		t.Setenv(EnvLang, "es")
+		assert.Equal(t, "Ejecutar un script", T("Execute a script"))
+	})
+
+	t.Run("Missing translation", func(t *testing.T) {
+		t.Setenv(EnvLang, "es")
+		assert.Equal(t, "not translated", T("not translated"))
+	})
+}
+
+func Test_Errorf(t *testing.T) {
+	t.Setenv(EnvLang, "es")
+	inner := errors.New("file does not exist")
+
+	err := Errorf("error loading script file: %w", inner)
+	assert.EqualError(t, err, "error al cargar el archivo del script: file does not exist")
+	assert.ErrorIs(t, err, inner)
+}
+
+func Test_Catalogs(t *testing.T) {
+	verbs := regexp.MustCompile(`%[a-z%]`)
+
+	for locale, messages := range catalogs {
+		for message, translated := range messages {
+			expected := verbs.FindAllString(message, -1)
+			actual := verbs.FindAllString(translated, -1)
+			sort.Strings(expected)
+			sort.Strings(actual)
+			assert.Equal(t, expected, actual, "format verbs of the %s translation of %q", locale, message)
+		}
+	}
+}
@@ -21,6 +21,8 @@ package keys
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
@@ -34,6 +36,9 @@ type flagsGenerate struct {
 	Mnemonic       string `flag:"mnemonic" info:"Mnemonic seed to use"`
 	DerivationPath string `default:"m/44'/539'/0'/0/0" flag:"derivationPath" info:"Derivation path"`
 	KeySigAlgo     string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	KeyHashAlgo    string `default:"SHA3_256" flag:"hash-algo" info:"Hash algorithm to pair with the keys"`
+	Count          int    `default:"1" flag:"count" info:"Number of key-pairs to generate"`
+	NoMnemonic     bool   `default:"false" flag:"no-mnemonic" info:"Generate keys from a random seed instead of deriving them from a mnemonic"`
 }
 
 var generateFlags = flagsGenerate{}
@@ -42,7 +47,7 @@ var generateCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "generate",
 		Short:   "Generate a new key-pair",
-		Example: "flow keys generate",
+		Example: "flow keys generate\nflow keys generate --count 10 --sig-algo ECDSA_secp256k1 --output json",
 	},
 	Flags: &generateFlags,
 	Run:   generate,
@@ -60,10 +65,62 @@ func generate(
 		return nil, fmt.Errorf("invalid signature algorithm: %s", generateFlags.KeySigAlgo)
 	}
 
+	hashAlgo := crypto.StringToHashAlgorithm(generateFlags.KeyHashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", generateFlags.KeyHashAlgo)
+	}
+
+	if generateFlags.Count < 1 {
+		return nil, fmt.Errorf("count must be at least 1")
+	}
+	if generateFlags.NoMnemonic && generateFlags.Mnemonic != "" {
+		return nil, fmt.Errorf("can not use both mnemonic and no-mnemonic flags")
+	}
+
+	keys := make(keysResult, 0, generateFlags.Count)
+	for i := 0; i < generateFlags.Count; i++ {
+		key, err := generateKey(flow, sigAlgo, i)
+		if err != nil {
+			return nil, err
+		}
+		key.hashAlgo = hashAlgo
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 1 {
+		return keys[0], nil
+	}
+	return keys, nil
+}
+
+// generateKey generates the key at the index.
+//
+// Keys are derived from a newly generated mnemonic for each index, unless a mnemonic is provided,
+// in which case the index is added to the last derivation path index, so keys can be recovered from a single mnemonic.
+func generateKey(flow flowkit.Services, sigAlgo crypto.SignatureAlgorithm, index int) (*keyResult, error) {
+	if generateFlags.NoMnemonic {
+		privateKey, err := flow.GenerateKey(context.Background(), sigAlgo, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return &keyResult{
+			privateKey: privateKey,
+			publicKey:  privateKey.PublicKey(),
+			sigAlgo:    sigAlgo,
+		}, nil
+	}
+
 	var err error
+	derivationPath := generateFlags.DerivationPath
 	mnemonic := generateFlags.Mnemonic
 	if mnemonic == "" {
-		_, mnemonic, err = flow.GenerateMnemonicKey(context.Background(), sigAlgo, generateFlags.DerivationPath)
+		_, mnemonic, err = flow.GenerateMnemonicKey(context.Background(), sigAlgo, derivationPath)
+		if err != nil {
+			return nil, err
+		}
+	} else if index > 0 {
+		derivationPath, err = offsetDerivationPath(derivationPath, index)
 		if err != nil {
 			return nil, err
 		}
@@ -73,7 +130,7 @@ func generate(
 		context.Background(),
 		mnemonic,
 		sigAlgo,
-		generateFlags.DerivationPath,
+		derivationPath,
 	)
 	if err != nil {
 		return nil, err
@@ -84,6 +141,17 @@ func generate(
 		publicKey:      privateKey.PublicKey(),
 		sigAlgo:        sigAlgo,
 		mnemonic:       mnemonic,
-		derivationPath: generateFlags.DerivationPath,
+		derivationPath: derivationPath,
 	}, nil
 }
+
+// offsetDerivationPath adds the offset to the last index of the derivation path, e.g. m/44'/539'/0'/0/0 becomes m/44'/539'/0'/0/2.
+func offsetDerivationPath(path string, offset int) (string, error) {
+	i := strings.LastIndex(path, "/")
+	index, err := strconv.Atoi(path[i+1:])
+	if i < 0 || err != nil {
+		return "", fmt.Errorf("derivation path %s must end with a non-hardened index to derive multiple keys", path)
+	}
+
+	return fmt.Sprintf("%s/%d", path[:i], index+offset), nil
+}
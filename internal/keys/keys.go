@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
@@ -69,6 +70,14 @@ func (k *keyResult) JSON() any {
 		result["derivationPath"] = k.derivationPath
 	}
 
+	if k.sigAlgo != crypto.UnknownSignatureAlgorithm {
+		result["signatureAlgorithm"] = k.sigAlgo.String()
+	}
+
+	if k.hashAlgo != crypto.UnknownHashAlgorithm {
+		result["hashAlgorithm"] = k.hashAlgo.String()
+	}
+
 	return result
 }
 
@@ -125,3 +134,30 @@ func (k *keyResult) Oneliner() string {
 
 	return result
 }
+
+// keysResult contains multiple keys, which are output as a list.
+type keysResult []*keyResult
+
+func (k keysResult) JSON() any {
+	result := make([]any, 0, len(k))
+	for _, key := range k {
+		result = append(result, key.JSON())
+	}
+	return result
+}
+
+func (k keysResult) String() string {
+	keys := make([]string, 0, len(k))
+	for _, key := range k {
+		keys = append(keys, key.String())
+	}
+	return strings.Join(keys, "\n")
+}
+
+func (k keysResult) Oneliner() string {
+	keys := make([]string, 0, len(k))
+	for _, key := range k {
+		keys = append(keys, key.Oneliner())
+	}
+	return strings.Join(keys, "\n")
+}
@@ -23,6 +23,8 @@ import (
 
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
@@ -147,4 +149,51 @@ func Test_Generate(t *testing.T) {
 		_, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
 		assert.EqualError(t, err, "invalid signature algorithm: invalid")
 	})
+
+	t.Run("Success multiple keys from mnemonic", func(t *testing.T) {
+		generateFlags.KeySigAlgo = "ECDSA_P256"
+		generateFlags.KeyHashAlgo = "SHA2_256"
+		generateFlags.Mnemonic = "test mnemonic"
+		generateFlags.DerivationPath = "m/44'/539'/0'/0/0"
+		generateFlags.Count = 3
+		defer func() {
+			generateFlags.Mnemonic = ""
+			generateFlags.Count = 1
+		}()
+
+		key, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, make([]byte, crypto.MinSeedLength))
+		require.NoError(t, err)
+
+		paths := make([]string, 0)
+		srv.DerivePrivateKeyFromMnemonic.Run(func(args mock.Arguments) {
+			paths = append(paths, args.Get(3).(string))
+			srv.DerivePrivateKeyFromMnemonic.Return(key, nil)
+		})
+
+		result, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		keys := result.(keysResult)
+		require.Len(t, keys, 3)
+		assert.Equal(t, []string{"m/44'/539'/0'/0/0", "m/44'/539'/0'/0/1", "m/44'/539'/0'/0/2"}, paths)
+		assert.Equal(t, "SHA2_256", keys[0].JSON().(map[string]any)["hashAlgorithm"])
+		assert.Len(t, keys.JSON(), 3)
+	})
+
+	t.Run("Fail invalid count", func(t *testing.T) {
+		generateFlags.Count = 0
+		defer func() { generateFlags.Count = 1 }()
+
+		_, err := generate([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "count must be at least 1")
+	})
+
+	t.Run("Offset derivation path", func(t *testing.T) {
+		path, err := offsetDerivationPath("m/44'/539'/0'/0/3", 2)
+		require.NoError(t, err)
+		assert.Equal(t, "m/44'/539'/0'/0/5", path)
+
+		_, err = offsetDerivationPath("m/44'/539'/0'", 1)
+		assert.EqualError(t, err, "derivation path m/44'/539'/0' must end with a non-hardened index to derive multiple keys")
+	})
 }
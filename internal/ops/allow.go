@@ -0,0 +1,126 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ops
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsAllow struct{}
+
+var allowFlags = flagsAllow{}
+
+var allowCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "allow <filename> [<filename> ...]",
+		Short: "Allow transactions or scripts to be sent to mainnet",
+		Long: `Allow transactions or scripts to be sent to mainnet, enabling operations mode if it's not enabled yet.
+
+The code is hashed with the imports resolved for mainnet, so allow the file again after the code or
+the mainnet deployments of the imported contracts change.`,
+		Example: "flow ops allow transactions/transfer_tokens.cdc scripts/get_balance.cdc",
+		Args:    cobra.MinimumNArgs(1),
+	},
+	Flags: &allowFlags,
+	RunS:  allow,
+}
+
+func allow(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	network, err := state.Networks().ByName(config.MainnetNetwork.Name)
+	if err != nil {
+		return nil, fmt.Errorf("operations mode requires the mainnet network in the configuration")
+	}
+
+	allowlist, err := util.LoadAllowlist(state.ReaderWriter(), util.AllowlistPath(state))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range args {
+		code, err := state.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error loading file: %w", err)
+		}
+
+		resolved, err := resolveImports(state, *network, code, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve imports of %s: %w", file, err)
+		}
+
+		entry := util.AllowlistEntry{File: file, Hash: gateway.CodeHash(resolved)}
+		allowlist = withEntry(allowlist, entry)
+	}
+
+	err = allowlist.Save(state.ReaderWriter(), util.AllowlistPath(state))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("%s Allowed %d files on mainnet in %s", output.SuccessEmoji(), len(args), util.AllowlistFile))
+	return &allowlistResult{allowlist}, nil
+}
+
+// resolveImports replaces the imports in the code with the addresses of the contracts on the network,
+// matching the code sent to the network.
+func resolveImports(state *flowkit.State, network config.Network, code []byte, location string) ([]byte, error) {
+	program, err := project.NewProgram(code, nil, location)
+	if err != nil {
+		return nil, err
+	}
+	if !program.HasImports() {
+		return program.Code(), nil
+	}
+
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err = project.NewImportReplacer(contracts, state.AliasesForNetwork(network)).Replace(program)
+	if err != nil {
+		return nil, err
+	}
+	return program.Code(), nil
+}
+
+// withEntry returns the allowlist with the entry, replacing the existing entry of the same file.
+func withEntry(allowlist util.Allowlist, entry util.AllowlistEntry) util.Allowlist {
+	for i, existing := range allowlist {
+		if existing.File == entry.File {
+			allowlist[i] = entry
+			return allowlist
+		}
+	}
+	return append(allowlist, entry)
+}
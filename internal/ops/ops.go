@@ -0,0 +1,75 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ops
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "ops",
+	Short: "Manage the code allowed on mainnet in operations mode",
+	Long: `Manage the transactions and scripts allowed to be sent to mainnet in operations mode.
+
+Operations mode is enabled when the project contains the flow-ops.json allowlist, any transaction
+or script sent to mainnet must then have its code hash in the allowlist. Commit the allowlist,
+so changes to the code allowed on mainnet go through the review.`,
+	TraverseChildren: true,
+	GroupID:          "security",
+}
+
+func init() {
+	allowCommand.AddToParent(Cmd)
+	revokeCommand.AddToParent(Cmd)
+	listCommand.AddToParent(Cmd)
+}
+
+type allowlistResult struct {
+	util.Allowlist
+}
+
+func (r *allowlistResult) JSON() any {
+	return r.Allowlist
+}
+
+func (r *allowlistResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "File\tHash\n")
+	for _, entry := range r.Allowlist {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\n", entry.File, entry.Hash)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *allowlistResult) Oneliner() string {
+	files := make([]string, 0, len(r.Allowlist))
+	for _, entry := range r.Allowlist {
+		files = append(files, entry.File)
+	}
+	return strings.Join(files, ", ")
+}
@@ -0,0 +1,99 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Allowlist(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	state.Networks().AddOrUpdate(config.MainnetNetwork)
+
+	script := []byte("pub fun main(): Int { return 1 }")
+	transaction := []byte("transaction { prepare(signer: AuthAccount) {} }")
+	require.NoError(t, rw.WriteFile("script.cdc", script, 0644))
+	require.NoError(t, rw.WriteFile("transaction.cdc", transaction, 0644))
+
+	t.Run("Allow", func(t *testing.T) {
+		result, err := allow([]string{"transaction.cdc", "script.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+
+		allowlist, err := util.LoadAllowlist(rw, util.AllowlistFile)
+		require.NoError(t, err)
+		assert.Equal(t, util.Allowlist{
+			{File: "script.cdc", Hash: gateway.CodeHash(script)},
+			{File: "transaction.cdc", Hash: gateway.CodeHash(transaction)},
+		}, allowlist)
+	})
+
+	t.Run("Allow Changed", func(t *testing.T) {
+		changed := []byte("pub fun main(): Int { return 2 }")
+		require.NoError(t, rw.WriteFile("script.cdc", changed, 0644))
+
+		_, err := allow([]string{"script.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		allowlist, err := util.LoadAllowlist(rw, util.AllowlistFile)
+		require.NoError(t, err)
+		assert.Len(t, allowlist, 2)
+		assert.Equal(t, gateway.CodeHash(changed), allowlist[0].Hash)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		_, err := revoke([]string{"transaction.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		result, err := list(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "script.cdc", result.Oneliner())
+
+		_, err = revoke([]string{"transaction.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "transaction.cdc is not in the allowlist")
+	})
+
+	t.Run("Fail Missing File", func(t *testing.T) {
+		_, err := allow([]string{"missing.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.ErrorContains(t, err, "error loading file")
+	})
+
+	t.Run("Allow Next To Configuration", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("other/flow.json", []byte(`{"networks": {"mainnet": "access.mainnet.nodes.onflow.org:9000"}}`), 0644))
+		require.NoError(t, rw.WriteFile("other/script.cdc", script, 0644))
+		other, err := flowkit.Load([]string{"other/flow.json"}, rw)
+		require.NoError(t, err)
+
+		_, err = allow([]string{"other/script.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, other)
+		require.NoError(t, err)
+
+		allowlist, err := util.LoadAllowlist(rw, "other/flow-ops.json")
+		require.NoError(t, err)
+		assert.Len(t, allowlist, 1)
+	})
+}
@@ -0,0 +1,82 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package ops
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsRevoke struct{}
+
+var revokeFlags = flagsRevoke{}
+
+var revokeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "revoke <filename> [<filename> ...]",
+		Short:   "Remove transactions or scripts from the code allowed on mainnet",
+		Example: "flow ops revoke transactions/transfer_tokens.cdc",
+		Args:    cobra.MinimumNArgs(1),
+	},
+	Flags: &revokeFlags,
+	RunS:  revoke,
+}
+
+func revoke(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	allowlist, err := util.LoadAllowlist(state.ReaderWriter(), util.AllowlistPath(state))
+	if err != nil {
+		return nil, err
+	}
+	if allowlist == nil {
+		return nil, fmt.Errorf("operations mode is not enabled, %s doesn't exist", util.AllowlistFile)
+	}
+
+	for _, file := range args {
+		found := false
+		for i, entry := range allowlist {
+			if entry.File == file {
+				allowlist = append(allowlist[:i], allowlist[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s is not in the allowlist", file)
+		}
+	}
+
+	err = allowlist.Save(state.ReaderWriter(), util.AllowlistPath(state))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("%s Revoked %d files on mainnet in %s", output.SuccessEmoji(), len(args), util.AllowlistFile))
+	return &allowlistResult{allowlist}, nil
+}
@@ -0,0 +1,426 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsAttest struct {
+	Signer string `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the attestation"`
+	File   string `default:"attestation.json" flag:"file" info:"Filename to save the attestation to"`
+}
+
+var attestFlags = flagsAttest{}
+
+var attestCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "attest",
+		Short:   "Create a signed attestation of the deployed contract code",
+		Example: "flow project attest --signer testnet-account --network testnet",
+	},
+	Flags: &attestFlags,
+	RunS:  attest,
+}
+
+type flagsVerifyAttestation struct {
+	Signer string `default:"" flag:"signer" info:"Address or account name from configuration of the account expected to sign the attestation"`
+}
+
+var verifyAttestationFlags = flagsVerifyAttestation{}
+
+var verifyAttestationCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "verify-attestation <filename>",
+		Short:   "Verify the deployed contract code matches a signed attestation",
+		Example: "flow project verify-attestation attestation.json --signer 0x01cf0e2f2f715450 --network testnet",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &verifyAttestationFlags,
+	Run:   verifyAttestation,
+}
+
+// attestationDomainTag is prepended to the attestation before signing, so the signature
+// can't be used as a signature of a transaction or any other message.
+const attestationDomainTag = "FLOW-CLI-ATTESTATION-V1"
+
+// attestation of the code deployed for the project contracts on a network signed by an account key.
+type attestation struct {
+	Network   string             `json:"network"`
+	CreatedAt string             `json:"createdAt"`
+	Signer    attestationSigner  `json:"signer"`
+	Contracts []attestedContract `json:"contracts"`
+	Signature string             `json:"signature,omitempty"`
+}
+
+type attestationSigner struct {
+	Address   string `json:"address"`
+	KeyIndex  int    `json:"keyIndex"`
+	PublicKey string `json:"publicKey"`
+	SigAlgo   string `json:"sigAlgo"`
+	HashAlgo  string `json:"hashAlgo"`
+}
+
+type attestedContract struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Hash    string `json:"hash"`
+}
+
+// message returns the signed content, the attestation without the signature.
+func (a attestation) message() ([]byte, error) {
+	a.Signature = ""
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(attestationDomainTag), b...), nil
+}
+
+func attest(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	signer, err := state.Accounts().ByName(attestFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := state.DeploymentContractsByNetwork(flow.Network())
+	if err != nil {
+		return nil, err
+	}
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("no contracts are deployed on network %s in the configuration", flow.Network().Name)
+	}
+
+	logger.StartProgress("Reading deployed contracts...")
+	defer logger.StopProgress()
+
+	onChain := newDeployedCode(flow)
+	result := attestation{
+		Network:   flow.Network().Name,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Contracts: make([]attestedContract, 0, len(contracts)),
+	}
+	for _, contract := range contracts {
+		code, err := onChain.code(contract.AccountAddress, contract.Name)
+		if err != nil {
+			return nil, err
+		}
+		if code == nil {
+			return nil, fmt.Errorf(
+				"contract %s is not deployed to account 0x%s on network %s",
+				contract.Name,
+				contract.AccountAddress,
+				flow.Network().Name,
+			)
+		}
+
+		result.Contracts = append(result.Contracts, attestedContract{
+			Name:    contract.Name,
+			Address: fmt.Sprintf("0x%s", contract.AccountAddress),
+			Hash:    codeHash(code),
+		})
+	}
+
+	accountSigner, err := signer.Key.Signer(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	result.Signer = attestationSigner{
+		Address:   fmt.Sprintf("0x%s", signer.Address),
+		KeyIndex:  signer.Key.Index(),
+		PublicKey: hex.EncodeToString(accountSigner.PublicKey().Encode()),
+		SigAlgo:   signer.Key.SigAlgo().String(),
+		HashAlgo:  signer.Key.HashAlgo().String(),
+	}
+
+	message, err := result.message()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := accountSigner.Sign(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the attestation: %w", err)
+	}
+	result.Signature = hex.EncodeToString(signature)
+
+	data, err := json.MarshalIndent(result, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	err = state.ReaderWriter().WriteFile(attestFlags.File, data, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save the attestation: %w", err)
+	}
+
+	return &attestationResult{attestation: result, file: attestFlags.File}, nil
+}
+
+func verifyAttestation(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	// anyone can sign an attestation, so the signer included in it must match the expected signer
+	expectedSigner, err := resolveSigner(verifyAttestationFlags.Signer, globalFlags, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readerWriter.ReadFile(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("error loading attestation file: %w", err)
+	}
+
+	var attested attestation
+	if err := json.Unmarshal(data, &attested); err != nil {
+		return nil, fmt.Errorf("invalid attestation file: %w", err)
+	}
+
+	if attested.Network != flow.Network().Name {
+		return nil, fmt.Errorf(
+			"attestation was created for network %s, use --network %s to verify it",
+			attested.Network,
+			attested.Network,
+		)
+	}
+
+	if len(attested.Contracts) == 0 {
+		return nil, fmt.Errorf("attestation does not include any contracts")
+	}
+
+	if flowsdk.HexToAddress(attested.Signer.Address) != expectedSigner {
+		return nil, fmt.Errorf(
+			"attestation was signed by account %s, expected signer 0x%s",
+			attested.Signer.Address,
+			expectedSigner,
+		)
+	}
+
+	if err := verifySignature(attested); err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress("Verifying deployed contracts...")
+	defer logger.StopProgress()
+
+	if err := verifySigner(flow, attested.Signer); err != nil {
+		return nil, err
+	}
+
+	onChain := newDeployedCode(flow)
+	mismatched := make([]string, 0)
+	for _, contract := range attested.Contracts {
+		code, err := onChain.code(flowsdk.HexToAddress(contract.Address), contract.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case code == nil:
+			logger.Info(fmt.Sprintf("%s Contract %s was removed from account %s", output.ErrorEmoji(), contract.Name, contract.Address))
+			mismatched = append(mismatched, contract.Name)
+		case codeHash(code) != contract.Hash:
+			logger.Info(fmt.Sprintf("%s Contract %s on account %s was changed", output.ErrorEmoji(), contract.Name, contract.Address))
+			mismatched = append(mismatched, contract.Name)
+		}
+	}
+
+	if len(mismatched) > 0 {
+		return nil, fmt.Errorf("deployed code does not match the attestation for contracts: %s", strings.Join(mismatched, ", "))
+	}
+
+	return &attestationResult{attestation: attested, verified: true}, nil
+}
+
+// resolveSigner resolves the expected signer of the attestation from the account name in the project
+// configuration or from the address.
+func resolveSigner(signer string, globalFlags command.GlobalFlags, readerWriter flowkit.ReaderWriter) (flowsdk.Address, error) {
+	if signer == "" {
+		return flowsdk.EmptyAddress, fmt.Errorf("provide the address or the account name of the expected attestation signer with the --signer flag")
+	}
+
+	// project configuration is optional, it is only used for resolving account names
+	state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter)
+	if err != nil && !errors.Is(err, config.ErrDoesNotExist) {
+		return flowsdk.EmptyAddress, err
+	}
+	if state != nil {
+		if account, err := state.Accounts().ByName(signer); err == nil {
+			return account.Address, nil
+		}
+	}
+
+	address := flowsdk.HexToAddress(signer)
+	if _, err := util.GetAddressNetwork(address); err != nil {
+		return flowsdk.EmptyAddress, fmt.Errorf("signer %s is not an address or an account name from the configuration", signer)
+	}
+
+	return address, nil
+}
+
+// verifySignature checks the attestation was signed by the signer key included in the attestation.
+func verifySignature(attested attestation) error {
+	publicKey, err := crypto.DecodePublicKeyHex(
+		crypto.StringToSignatureAlgorithm(attested.Signer.SigAlgo),
+		attested.Signer.PublicKey,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid attestation signer public key: %w", err)
+	}
+
+	hasher, err := crypto.NewHasher(crypto.StringToHashAlgorithm(attested.Signer.HashAlgo))
+	if err != nil {
+		return fmt.Errorf("invalid attestation signer hash algorithm: %w", err)
+	}
+
+	signature, err := hex.DecodeString(attested.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid attestation signature: %w", err)
+	}
+
+	message, err := attested.message()
+	if err != nil {
+		return err
+	}
+
+	valid, err := publicKey.Verify(signature, message, hasher)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("attestation signature is not valid")
+	}
+
+	return nil
+}
+
+// verifySigner checks the signer key is an active key of the signer account on the network.
+func verifySigner(flow flowkit.Services, signer attestationSigner) error {
+	account, err := flow.GetAccount(context.Background(), flowsdk.HexToAddress(signer.Address))
+	if err != nil {
+		return err
+	}
+
+	for _, key := range account.Keys {
+		if key.Index != signer.KeyIndex {
+			continue
+		}
+
+		if !key.Revoked && hex.EncodeToString(key.PublicKey.Encode()) == signer.PublicKey {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("attestation signer key %d is not an active key of account %s", signer.KeyIndex, signer.Address)
+}
+
+func codeHash(code []byte) string {
+	return hex.EncodeToString(crypto.NewSHA3_256().ComputeHash(code))
+}
+
+// deployedCode fetches the contracts deployed on accounts, fetching each account only once.
+type deployedCode struct {
+	flow     flowkit.Services
+	accounts map[flowsdk.Address]*flowsdk.Account
+}
+
+func newDeployedCode(flow flowkit.Services) *deployedCode {
+	return &deployedCode{
+		flow:     flow,
+		accounts: make(map[flowsdk.Address]*flowsdk.Account),
+	}
+}
+
+// code returns the deployed code of the contract or nil if the contract is not deployed on the account.
+func (d *deployedCode) code(address flowsdk.Address, name string) ([]byte, error) {
+	account, ok := d.accounts[address]
+	if !ok {
+		var err error
+		account, err = d.flow.GetAccount(context.Background(), address)
+		if err != nil {
+			return nil, err
+		}
+		d.accounts[address] = account
+	}
+
+	return account.Contracts[name], nil
+}
+
+type attestationResult struct {
+	attestation
+	file     string
+	verified bool
+}
+
+func (r *attestationResult) JSON() any {
+	return r.attestation
+}
+
+func (r *attestationResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Network\t%s\n", r.Network)
+	_, _ = fmt.Fprintf(writer, "Created At\t%s\n", r.CreatedAt)
+	_, _ = fmt.Fprintf(writer, "Signer\t%s (key %d)\n", r.Signer.Address, r.Signer.KeyIndex)
+	_, _ = fmt.Fprintf(writer, "\nContract\tAddress\tCode Hash\n")
+	for _, contract := range r.Contracts {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", contract.Name, contract.Address, contract.Hash)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\n%s\n", r.Oneliner())
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (r *attestationResult) Oneliner() string {
+	if r.verified {
+		return fmt.Sprintf("Deployed code of %d contracts matches the attestation", len(r.Contracts))
+	}
+
+	return fmt.Sprintf("Attestation of %d contracts saved to %s", len(r.Contracts), r.file)
+}
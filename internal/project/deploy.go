@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
@@ -32,12 +33,15 @@ import (
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/flowkit/project"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/history"
+	"github.com/onflow/flow-cli/internal/i18n"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsDeploy struct {
-	Update   bool `flag:"update" default:"false" info:"use update flag to update existing contracts"`
-	ShowDiff bool `flag:"show-diff" default:"false" info:"use show-diff flag to show diff between existing and new contracts on update"`
+	Update      bool `flag:"update" default:"false" info:"use update flag to update existing contracts"`
+	ShowDiff    bool `flag:"show-diff" default:"false" info:"use show-diff flag to show diff between existing and new contracts on update"`
+	Interactive bool `flag:"interactive" default:"false" info:"choose the network and contracts to deploy interactively"`
 }
 
 var deployFlags = flagsDeploy{}
@@ -59,8 +63,11 @@ func deploy(
 	flow flowkit.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
+	if deployFlags.Interactive {
+		return deployInteractive(global, logger, flow, state)
+	}
 
-	if flow.Network() == config.MainnetNetwork { // if using mainnet check for standard contract usage
+	if flow.Network().Name == config.MainnetNetwork.Name { // if using mainnet check for standard contract usage
 		err := checkForStandardContractUsageOnMainnet(state, logger, global.Yes)
 		if err != nil {
 			return nil, err
@@ -73,6 +80,31 @@ func deploy(
 	}
 
 	c, err := flow.DeployProject(context.Background(), deployFunc)
+	return deployOutcome(c, err, flow.Network(), logger, state)
+}
+
+// deployOutcome records the deployment in the history and converts it into the command result.
+func deployOutcome(
+	c []*project.Contract,
+	err error,
+	network config.Network,
+	logger output.Logger,
+	state *flowkit.State,
+) (command.Result, error) {
+	deployed := make([]string, 0, len(c))
+	for _, contract := range c {
+		deployed = append(deployed, fmt.Sprintf("%s -> 0x%s", contract.Name, contract.AccountAddress))
+	}
+	_ = history.RecordTransaction(
+		state.ReaderWriter(),
+		"project deploy",
+		network,
+		strings.Join(deployed, ", "),
+		flowsdk.EmptyID,
+		nil,
+		err,
+	)
+
 	if err != nil {
 		var projectErr *flowkit.ProjectDeploymentError
 		if errors.As(err, &projectErr) {
@@ -84,7 +116,7 @@ func deploy(
 					err.Error(),
 				))
 			}
-			return nil, fmt.Errorf("failed deploying all contracts")
+			return nil, i18n.Errorf("failed deploying all contracts")
 		}
 		return nil, err
 	}
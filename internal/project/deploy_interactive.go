@@ -0,0 +1,202 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+const (
+	planNew       = "new"
+	planChanged   = "changed"
+	planUnchanged = "unchanged"
+)
+
+// plannedContract is a contract in the deployment plan together with the change it introduces on the network.
+type plannedContract struct {
+	contract *project.Contract
+	status   string
+	diff     string
+	selected bool
+}
+
+// deployInteractive walks the user through the network selection, shows the deployment plan,
+// lets the user choose the contracts to deploy and deploys them once confirmed.
+func deployInteractive(
+	global command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	networks := deploymentNetworks(state, flow.Network().Name)
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("no deployments found in the configuration")
+	}
+
+	network := util.DeployNetworkPrompt(networks)
+	if network != flow.Network().Name {
+		n, err := state.Networks().ByName(network)
+		if err != nil {
+			return nil, err
+		}
+		n.RequestID = flow.Network().RequestID
+		gw, err := command.GatewayFactory(state, state.ReaderWriter())(*n)
+		if err != nil {
+			return nil, err
+		}
+		flow = flowkit.NewFlowkit(state, *n, gw, logger)
+	}
+
+	if network == config.MainnetNetwork.Name {
+		if err := checkForStandardContractUsageOnMainnet(state, logger, global.Yes); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.StartProgress(fmt.Sprintf("Fetching deployed contracts from %s...", network))
+	plan, err := deploymentPlan(context.Background(), flow, state)
+	logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(plan))
+	selected := make([]bool, len(plan))
+	for i, p := range plan {
+		logger.Info(fmt.Sprintf("%s -> 0x%s [%s]", output.Bold(p.contract.Name), p.contract.AccountAddress, p.status))
+		if p.diff != "" {
+			logger.Info(p.diff)
+		}
+		names[i] = p.contract.Name
+		selected[i] = p.selected
+	}
+
+	selected = util.ToggleContractsPrompt(names, selected)
+	contracts := make([]string, 0, len(plan))
+	for i, name := range names {
+		if selected[i] {
+			contracts = append(contracts, name)
+		}
+	}
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("no contracts selected for deployment")
+	}
+
+	if !util.ConfirmDeploymentPrompt(len(contracts), network) {
+		return nil, fmt.Errorf("deployment cancelled")
+	}
+
+	c, err := flow.DeployProjectWithOptions(context.Background(), flowkit.DeployOptions{
+		Update:    flowkit.UpdateExistingContract(true),
+		Contracts: contracts,
+	})
+	return deployOutcome(c, err, flow.Network(), logger, state)
+}
+
+// deploymentNetworks returns the networks with deployments, starting with the current network.
+func deploymentNetworks(state *flowkit.State, current string) []string {
+	networks := make([]string, 0)
+	for _, n := range *state.Networks() {
+		if len(state.Deployments().ByNetwork(n.Name)) == 0 {
+			continue
+		}
+		if n.Name == current {
+			networks = append([]string{n.Name}, networks...)
+			continue
+		}
+		networks = append(networks, n.Name)
+	}
+	return networks
+}
+
+// deploymentPlan compares the contracts deployed on the network with the contracts in the project.
+//
+// New and changed contracts are selected for the deployment by default.
+func deploymentPlan(ctx context.Context, flow flowkit.Services, state *flowkit.State) ([]*plannedContract, error) {
+	contracts, err := state.DeploymentContractsByNetwork(flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := state.AliasesForNetwork(flow.Network())
+	deployment, err := project.NewDeployment(contracts, aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted, err := deployment.Sort()
+	if err != nil {
+		return nil, err
+	}
+
+	importReplacer := project.NewImportReplacer(contracts, aliases)
+	accounts := make(map[flowsdk.Address]*flowsdk.Account)
+	plan := make([]*plannedContract, 0, len(sorted))
+	for _, contract := range sorted {
+		account, ok := accounts[contract.AccountAddress]
+		if !ok {
+			account, err = flow.GetAccount(ctx, contract.AccountAddress)
+			if err != nil {
+				return nil, err
+			}
+			accounts[contract.AccountAddress] = account
+		}
+
+		program, err := project.NewProgram(contract.Code(), contract.Args, contract.Location())
+		if err != nil {
+			return nil, err
+		}
+		if program.HasImports() {
+			program, err = importReplacer.Replace(program)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		plan = append(plan, newPlannedContract(contract, program.Code(), account.Contracts))
+	}
+
+	return plan, nil
+}
+
+func newPlannedContract(contract *project.Contract, code []byte, deployed map[string][]byte) *plannedContract {
+	existing, ok := deployed[contract.Name]
+	switch {
+	case !ok:
+		return &plannedContract{contract: contract, status: planNew, selected: true}
+	case bytes.Equal(existing, code):
+		return &plannedContract{contract: contract, status: planUnchanged}
+	default:
+		dmp := diffmatchpatch.New()
+		diff := dmp.DiffPrettyText(dmp.DiffMain(string(existing), string(code), false))
+		return &plannedContract{contract: contract, status: planChanged, diff: diff, selected: true}
+	}
+}
@@ -0,0 +1,342 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsInventory struct{}
+
+var inventoryFlags = flagsInventory{}
+
+var inventoryCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "inventory",
+		Short:   "List the contracts, token vaults and NFT collections owned by the project accounts",
+		Example: "flow project inventory --network testnet",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &inventoryFlags,
+	RunS:  inventory,
+}
+
+// vaultsScript returns the balance of the fungible token vaults stored in the account by the vault type.
+const vaultsScript = `
+import FungibleToken from 0xFungibleToken
+
+pub fun main(address: Address): {String: UFix64} {
+	let account = getAuthAccount(address)
+	let vaults: {String: UFix64} = {}
+
+	account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+		if type.isSubtype(of: Type<@AnyResource{FungibleToken.Balance}>()) {
+			if let vault = account.borrow<&AnyResource{FungibleToken.Balance}>(from: path) {
+				vaults[type.identifier] = (vaults[type.identifier] ?? 0.0) + vault.balance
+			}
+		}
+		return true
+	})
+
+	return vaults
+}
+`
+
+// collectionsScript returns the number of NFTs in the collections stored in the account by the collection type.
+const collectionsScript = `
+import NonFungibleToken from 0xNonFungibleToken
+
+pub fun main(address: Address): {String: Int} {
+	let account = getAuthAccount(address)
+	let collections: {String: Int} = {}
+
+	account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+		if type.isSubtype(of: Type<@AnyResource{NonFungibleToken.CollectionPublic}>()) {
+			if let collection = account.borrow<&AnyResource{NonFungibleToken.CollectionPublic}>(from: path) {
+				collections[type.identifier] = (collections[type.identifier] ?? 0) + collection.getIDs().length
+			}
+		}
+		return true
+	})
+
+	return collections
+}
+`
+
+// networkChains are the chains of the networks the account addresses are checked against.
+var networkChains = map[string]flowsdk.ChainID{
+	config.EmulatorNetwork.Name: flowsdk.Emulator,
+	config.TestnetNetwork.Name:  flowsdk.Testnet,
+	config.MainnetNetwork.Name:  flowsdk.Mainnet,
+}
+
+func inventory(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	network := flow.Network()
+
+	vaultsCode, err := util.ReplaceCoreImports([]byte(vaultsScript), network.Name)
+	if err != nil {
+		return nil, fmt.Errorf("inventory is not available on network %s, supported networks are emulator, testnet and mainnet", network.Name)
+	}
+	collectionsCode, _ := util.ReplaceCoreImports([]byte(collectionsScript), network.Name)
+
+	result := &inventoryResult{network: network.Name}
+	owned := make([]accounts.Account, 0)
+	for _, account := range *state.Accounts() {
+		chain, ok := networkChains[network.Name]
+		if ok && !account.Address.IsValid(chain) {
+			result.skipped = append(result.skipped, account.Name)
+			continue
+		}
+		owned = append(owned, account)
+	}
+	if len(owned) == 0 {
+		return result, nil
+	}
+
+	logger.StartProgress("Checking the NFT standard contract...")
+	result.listsCollections = nftStandardDeployed(flow, network.Name)
+	logger.StopProgress()
+
+	for _, account := range owned {
+		logger.StartProgress(fmt.Sprintf("Loading inventory of account %s...", account.Name))
+		entry := accountInventory(flow, account, vaultsCode, collectionsCode, result.listsCollections)
+		logger.StopProgress()
+
+		result.accounts = append(result.accounts, entry)
+	}
+
+	return result, nil
+}
+
+// nftStandardDeployed checks the NFT standard contract is deployed on the network, since it's
+// only deployed on the emulator when it's started with the --contracts flag.
+func nftStandardDeployed(flow flowkit.Services, network string) bool {
+	account, err := flow.GetAccount(context.Background(), flowsdk.HexToAddress(util.CoreContracts[network]["NonFungibleToken"]))
+	if err != nil {
+		return false
+	}
+	_, ok := account.Contracts["NonFungibleToken"]
+	return ok
+}
+
+type tokenBalance struct {
+	Type    string `json:"type"`
+	Balance string `json:"balance"`
+}
+
+type collectionCount struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+type accountEntry struct {
+	Name        string            `json:"name"`
+	Address     string            `json:"address"`
+	Contracts   []string          `json:"contracts"`
+	Vaults      []tokenBalance    `json:"vaults"`
+	Collections []collectionCount `json:"collections,omitempty"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// accountInventory loads the inventory of the account, a failure is reported on the entry so
+// the rest of the accounts are still listed.
+func accountInventory(
+	flow flowkit.Services,
+	account accounts.Account,
+	vaultsCode []byte,
+	collectionsCode []byte,
+	withCollections bool,
+) accountEntry {
+	entry := accountEntry{
+		Name:      account.Name,
+		Address:   "0x" + account.Address.Hex(),
+		Contracts: make([]string, 0),
+		Vaults:    make([]tokenBalance, 0),
+	}
+
+	onChain, err := flow.GetAccount(context.Background(), account.Address)
+	if err != nil {
+		entry.Error = fmt.Sprintf("failed to get account: %s", err)
+		return entry
+	}
+	for name := range onChain.Contracts {
+		entry.Contracts = append(entry.Contracts, name)
+	}
+	sort.Strings(entry.Contracts)
+
+	vaults, err := executeInventoryScript(flow, account.Address, vaultsCode)
+	if err != nil {
+		entry.Error = fmt.Sprintf("failed to read vaults: %s", err)
+		return entry
+	}
+	for _, pair := range vaults.Pairs {
+		vaultType, _ := pair.Key.(cadence.String)
+		balance, _ := pair.Value.(cadence.UFix64)
+		entry.Vaults = append(entry.Vaults, tokenBalance{Type: string(vaultType), Balance: balance.String()})
+	}
+	sort.Slice(entry.Vaults, func(i, j int) bool {
+		return entry.Vaults[i].Type < entry.Vaults[j].Type
+	})
+
+	if !withCollections {
+		return entry
+	}
+
+	entry.Collections = make([]collectionCount, 0)
+	collections, err := executeInventoryScript(flow, account.Address, collectionsCode)
+	if err != nil {
+		entry.Error = fmt.Sprintf("failed to read collections: %s", err)
+		return entry
+	}
+	for _, pair := range collections.Pairs {
+		collectionType, _ := pair.Key.(cadence.String)
+		count, _ := pair.Value.(cadence.Int)
+		entry.Collections = append(entry.Collections, collectionCount{Type: string(collectionType), Count: count.Int()})
+	}
+	sort.Slice(entry.Collections, func(i, j int) bool {
+		return entry.Collections[i].Type < entry.Collections[j].Type
+	})
+
+	return entry
+}
+
+func executeInventoryScript(flow flowkit.Services, address flowsdk.Address, code []byte) (cadence.Dictionary, error) {
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code: code,
+			Args: []cadence.Value{cadence.NewAddress(address)},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return cadence.Dictionary{}, err
+	}
+
+	dictionary, ok := value.(cadence.Dictionary)
+	if !ok {
+		return cadence.Dictionary{}, fmt.Errorf("unexpected script result: %s", value)
+	}
+	return dictionary, nil
+}
+
+// typeName returns the contract and declaration of the type with the address of the contract,
+// e.g. A.0ae53cb6e3f42a79.FlowToken.Vault is returned as FlowToken.Vault (0x0ae53cb6e3f42a79).
+func typeName(identifier string) string {
+	parts := strings.SplitN(identifier, ".", 3)
+	if len(parts) != 3 || parts[0] != "A" {
+		return identifier
+	}
+	return fmt.Sprintf("%s (0x%s)", parts[2], parts[1])
+}
+
+type inventoryResult struct {
+	network  string
+	accounts []accountEntry
+	skipped  []string
+	// listsCollections is false if the NFT standard contract isn't deployed on the network.
+	listsCollections bool
+}
+
+func (r *inventoryResult) JSON() any {
+	skipped := r.skipped
+	if skipped == nil {
+		skipped = make([]string, 0)
+	}
+	accounts := r.accounts
+	if accounts == nil {
+		accounts = make([]accountEntry, 0)
+	}
+
+	return map[string]any{
+		"network":  r.network,
+		"accounts": accounts,
+		"skipped":  skipped,
+	}
+}
+
+func (r *inventoryResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Network\t%s\n\n", r.network)
+	if len(r.accounts) == 0 {
+		_, _ = fmt.Fprintf(writer, "No accounts on the network in the configuration\n")
+	} else {
+		_, _ = fmt.Fprintf(writer, "Account\tAddress\tKind\tName\tAmount\n")
+	}
+	for _, account := range r.accounts {
+		if account.Error != "" {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\terror\t%s\t\n", account.Name, account.Address, account.Error)
+		}
+		for _, contract := range account.Contracts {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\tcontract\t%s\t\n", account.Name, account.Address, contract)
+		}
+		for _, vault := range account.Vaults {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\tvault\t%s\t%s\n", account.Name, account.Address, typeName(vault.Type), vault.Balance)
+		}
+		for _, collection := range account.Collections {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\tcollection\t%s\t%d\n", account.Name, account.Address, typeName(collection.Type), collection.Count)
+		}
+	}
+
+	if len(r.accounts) > 0 && !r.listsCollections {
+		_, _ = fmt.Fprintf(writer, "\nNFT collections are not listed since the NFT standard contract isn't deployed on the network\n")
+	}
+	if len(r.skipped) > 0 {
+		_, _ = fmt.Fprintf(writer, "\nSkipped accounts with addresses of another network: %s\n", strings.Join(r.skipped, ", "))
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *inventoryResult) Oneliner() string {
+	contracts, vaults, collections := 0, 0, 0
+	for _, account := range r.accounts {
+		contracts += len(account.Contracts)
+		vaults += len(account.Vaults)
+		collections += len(account.Collections)
+	}
+	return fmt.Sprintf(
+		"Network: %s, Accounts: %d, Contracts: %d, Vaults: %d, Collections: %d",
+		r.network, len(r.accounts), contracts, vaults, collections,
+	)
+}
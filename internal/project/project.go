@@ -31,4 +31,11 @@ var Cmd = &cobra.Command{
 
 func init() {
 	DeployCommand.AddToParent(Cmd)
+	attestCommand.AddToParent(Cmd)
+	verifyAttestationCommand.AddToParent(Cmd)
+	statsCommand.AddToParent(Cmd)
+	resolveCommand.AddToParent(Cmd)
+	versionsCommand.AddToParent(Cmd)
+	restructureCommand.AddToParent(Cmd)
+	inventoryCommand.AddToParent(Cmd)
 }
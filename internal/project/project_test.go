@@ -19,15 +19,29 @@
 package project
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -76,3 +90,513 @@ func Test_ProjectDeploy(t *testing.T) {
 	})
 
 }
+
+func Test_Attestation(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	service, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	privateKey, err := service.Key.PrivateKey()
+	require.NoError(t, err)
+
+	code := []byte("pub contract Foo {}")
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Foo", Location: "./foo.cdc"})
+	_ = rw.WriteFile("./foo.cdc", code, 0677)
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.EmulatorNetwork.Name,
+		Account:   service.Name,
+		Contracts: []config.ContractDeployment{{Name: "Foo"}},
+	})
+
+	deployed := func(code []byte) {
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			account := tests.NewAccountWithAddress(service.Address.String())
+			account.Keys = []*flow.AccountKey{{
+				Index:     0,
+				PublicKey: (*privateKey).PublicKey(),
+				SigAlgo:   service.Key.SigAlgo(),
+				HashAlgo:  service.Key.HashAlgo(),
+				Weight:    1000,
+			}}
+			account.Contracts = map[string][]byte{"Foo": code}
+			srv.GetAccount.Return(account, nil)
+		})
+	}
+
+	attestFlags.Signer = service.Name
+	attestFlags.File = "attestation.json"
+	verifyAttestationFlags.Signer = service.Address.String()
+	t.Cleanup(func() { verifyAttestationFlags = flagsVerifyAttestation{} })
+
+	t.Run("Success", func(t *testing.T) {
+		deployed(code)
+
+		result, err := attest([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "Attestation of 1 contracts saved to attestation.json", result.Oneliner())
+
+		result, err = verifyAttestation([]string{"attestation.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "Deployed code of 1 contracts matches the attestation", result.Oneliner())
+	})
+
+	t.Run("Fail changed code", func(t *testing.T) {
+		deployed([]byte("pub contract Foo { pub let bar: Int; init() { self.bar = 1 } }"))
+
+		_, err := verifyAttestation([]string{"attestation.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "deployed code does not match the attestation for contracts: Foo")
+	})
+
+	t.Run("Fail tampered attestation", func(t *testing.T) {
+		deployed([]byte("pub contract Foo { pub let bar: Int; init() { self.bar = 1 } }"))
+
+		data, err := rw.ReadFile("attestation.json")
+		require.NoError(t, err)
+		var attested attestation
+		require.NoError(t, json.Unmarshal(data, &attested))
+		attested.Contracts[0].Hash = codeHash([]byte("pub contract Foo { pub let bar: Int; init() { self.bar = 1 } }"))
+		data, _ = json.Marshal(attested)
+		_ = rw.WriteFile("tampered.json", data, 0644)
+
+		_, err = verifyAttestation([]string{"tampered.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "attestation signature is not valid")
+	})
+
+	t.Run("Fail unexpected signer", func(t *testing.T) {
+		deployed(code)
+
+		verifyAttestationFlags.Signer = "0x01cf0e2f2f715450"
+		defer func() { verifyAttestationFlags.Signer = service.Address.String() }()
+
+		_, err := verifyAttestation([]string{"attestation.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "attestation was signed by account 0xf8d6e0586b0a20c7, expected signer 0x01cf0e2f2f715450")
+
+		verifyAttestationFlags.Signer = ""
+		_, err = verifyAttestation([]string{"attestation.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "provide the address or the account name of the expected attestation signer with the --signer flag")
+	})
+
+	t.Run("Fail no contracts", func(t *testing.T) {
+		deployed(code)
+
+		data, err := rw.ReadFile("attestation.json")
+		require.NoError(t, err)
+		var attested attestation
+		require.NoError(t, json.Unmarshal(data, &attested))
+		attested.Contracts = nil
+		data, _ = json.Marshal(attested)
+		_ = rw.WriteFile("empty.json", data, 0644)
+
+		_, err = verifyAttestation([]string{"empty.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "attestation does not include any contracts")
+	})
+}
+
+func Test_Stats(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	service, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Bar", Location: "./bar.cdc"})
+	_ = rw.WriteFile("./bar.cdc", []byte(`
+		pub contract Bar {
+			pub event Minted(id: UInt64)
+			pub event Burned(id: UInt64)
+		}`), 0677)
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.EmulatorNetwork.Name,
+		Account:   service.Name,
+		Contracts: []config.ContractDeployment{{Name: "Bar"}},
+	})
+
+	minted := fmt.Sprintf("A.%s.Bar.Minted", service.Address.Hex())
+	burned := fmt.Sprintf("A.%s.Bar.Burned", service.Address.Hex())
+	day := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	latest := tests.NewBlock()
+	latest.Height = 1200
+	srv.GetBlock.Return(latest, nil)
+
+	var ranges [][2]uint64
+	srv.GetEvents.Run(func(args mock.Arguments) {
+		assert.Equal(t, []string{burned, minted}, args.Get(1).([]string))
+		start, end := args.Get(2).(uint64), args.Get(3).(uint64)
+		ranges = append(ranges, [2]uint64{start, end})
+
+		srv.GetEvents.Return([]flow.BlockEvents{{
+			Height:         start,
+			BlockTimestamp: day.Add(time.Duration(len(ranges)-1) * 24 * time.Hour),
+			Events:         []flow.Event{{Type: minted}, {Type: minted}, {Type: burned}},
+		}}, nil)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		statsFlags.SinceHeight = 100
+		statsFlags.Workers = 2
+		statsFlags.Batch = 250
+
+		result, err := stats([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		assert.Equal(t, [][2]uint64{{100, 599}, {600, 1099}, {1100, 1200}}, ranges)
+
+		summary := result.(*statsResult)
+		assert.Equal(t, map[string]int{minted: 6, burned: 3}, summary.totals)
+		assert.Equal(t, []string{"2023-06-01", "2023-06-02", "2023-06-03"}, summary.days())
+		assert.Equal(t, map[string]int{minted: 2, burned: 1}, summary.counts["2023-06-02"])
+		assert.Equal(t, "Blocks: 100 - 1200, Events: 9, Days: 3", result.Oneliner())
+	})
+
+	t.Run("Fail missing since height", func(t *testing.T) {
+		statsFlags.SinceHeight = 0
+		_, err := stats([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "the since-height flag is required")
+	})
+}
+
+func Test_Resolve(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	service, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Bar", Location: "./bar.cdc"})
+	_ = rw.WriteFile("./bar.cdc", []byte(`pub contract Bar {}`), 0677)
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.EmulatorNetwork.Name,
+		Account:   service.Name,
+		Contracts: []config.ContractDeployment{{Name: "Bar"}},
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		_ = rw.WriteFile("./script.cdc", []byte(`
+			import "Bar"
+			import Crypto
+			import Foo from 0x01
+			pub fun main() {}`), 0677)
+
+		result, err := resolve([]string{"./script.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		resolved := result.(*resolveResult)
+		require.Len(t, resolved.resolutions, 3)
+		assert.Equal(t, project.ImportSourceDeployment, resolved.resolutions[0].Source)
+		assert.Equal(t, project.ImportSourceBuiltin, resolved.resolutions[1].Source)
+		assert.Equal(t, project.ImportSourceAddress, resolved.resolutions[2].Source)
+		assert.Contains(t, string(resolved.code), fmt.Sprintf("import Bar from 0x%s", service.Address.Hex()))
+		assert.Contains(t, result.String(), "Import")
+		assert.Equal(t, "Imports: 3, Unresolved: 0, Network: emulator", result.Oneliner())
+	})
+
+	t.Run("Unresolved imports", func(t *testing.T) {
+		code := `
+			import "Missing"
+			pub fun main() {}`
+		_ = rw.WriteFile("./missing.cdc", []byte(code), 0677)
+
+		result, err := resolve([]string{"./missing.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		assert.Equal(t, code, string(result.(*resolveResult).code))
+		assert.Contains(t, result.String(), "1 imports can't be resolved on network emulator")
+	})
+
+	t.Run("Fail missing file", func(t *testing.T) {
+		_, err := resolve([]string{"./nope.cdc"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.ErrorContains(t, err, "error loading file ./nope.cdc")
+	})
+}
+
+func Test_Versions(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	service, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	for _, name := range []string{"Foo", "Bar", "Baz", "Qux"} {
+		location := fmt.Sprintf("./%s.cdc", name)
+		state.Contracts().AddOrUpdate(config.Contract{Name: name, Location: location, Version: "1.1.0"})
+		_ = rw.WriteFile(location, []byte(fmt.Sprintf("pub contract %s {}", name)), 0677)
+	}
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.EmulatorNetwork.Name,
+		Account:   service.Name,
+		Contracts: []config.ContractDeployment{{Name: "Foo"}, {Name: "Bar"}, {Name: "Baz"}, {Name: "Qux"}},
+	})
+
+	account := &flow.Account{
+		Address: service.Address,
+		Contracts: map[string][]byte{
+			"Foo": project.StampVersion([]byte("pub contract Foo {}"), "1.1.0"),
+			"Bar": project.StampVersion([]byte("pub contract Bar {}"), "1.0.0"),
+			"Baz": []byte("pub contract Baz {}"),
+		},
+	}
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		srv.GetAccount.Return(account, nil)
+	})
+
+	result, err := versions([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+	require.NoError(t, err)
+	srv.Mock.AssertNumberOfCalls(t, "GetAccount", 1)
+
+	contracts := result.(*versionsResult).contracts
+	require.Len(t, contracts, 4)
+	assert.Equal(t, contractVersion{
+		Name:       "Foo",
+		Address:    "0x" + service.Address.Hex(),
+		Configured: "1.1.0",
+		Deployed:   "1.1.0",
+		Status:     versionUpToDate,
+	}, contracts[0])
+	assert.Equal(t, versionOutdated, contracts[1].Status)
+	assert.Equal(t, "1.0.0", contracts[1].Deployed)
+	assert.Equal(t, versionUnversioned, contracts[2].Status)
+	assert.Equal(t, versionNotDeployed, contracts[3].Status)
+	assert.Equal(t, "Network: emulator, Contracts: 4, Outdated: 1", result.Oneliner())
+}
+
+func Test_DeploymentPlan(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	service, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	for _, name := range []string{"Foo", "Bar", "Baz"} {
+		location := fmt.Sprintf("./%s.cdc", name)
+		state.Contracts().AddOrUpdate(config.Contract{Name: name, Location: location})
+		_ = rw.WriteFile(location, []byte(fmt.Sprintf("pub contract %s {}", name)), 0677)
+	}
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.EmulatorNetwork.Name,
+		Account:   service.Name,
+		Contracts: []config.ContractDeployment{{Name: "Foo"}, {Name: "Bar"}, {Name: "Baz"}},
+	})
+
+	account := &flow.Account{
+		Address: service.Address,
+		Contracts: map[string][]byte{
+			"Foo": []byte("pub contract Foo {}"),
+			"Bar": []byte("pub contract Bar { pub let a: Int }"),
+		},
+	}
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		srv.GetAccount.Return(account, nil)
+	})
+
+	plan, err := deploymentPlan(context.Background(), srv.Mock, state)
+	require.NoError(t, err)
+	srv.Mock.AssertNumberOfCalls(t, "GetAccount", 1)
+
+	statuses := make(map[string]*plannedContract)
+	for _, p := range plan {
+		statuses[p.contract.Name] = p
+	}
+	require.Len(t, statuses, 3)
+
+	assert.Equal(t, planUnchanged, statuses["Foo"].status)
+	assert.False(t, statuses["Foo"].selected)
+	assert.Equal(t, planChanged, statuses["Bar"].status)
+	assert.True(t, statuses["Bar"].selected)
+	assert.NotEmpty(t, statuses["Bar"].diff)
+	assert.Equal(t, planNew, statuses["Baz"].status)
+	assert.True(t, statuses["Baz"].selected)
+
+	t.Run("Networks", func(t *testing.T) {
+		state.Networks().AddOrUpdate(config.TestnetNetwork)
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.TestnetNetwork.Name,
+			Account:   service.Name,
+			Contracts: []config.ContractDeployment{{Name: "Foo"}},
+		})
+
+		networks := deploymentNetworks(state, config.TestnetNetwork.Name)
+		assert.Equal(t, []string{config.TestnetNetwork.Name, config.EmulatorNetwork.Name}, networks)
+	})
+}
+
+func Test_ServeWebhook(t *testing.T) {
+	hook := &pushWebhook{
+		secret:  []byte("secret"),
+		branch:  "main",
+		trigger: make(chan string, 1),
+	}
+
+	send := func(event string, payload string, secret string) int {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(payload))
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+		req.Header.Set("X-GitHub-Event", event)
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		rec := httptest.NewRecorder()
+		hook.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("Deploy push to branch", func(t *testing.T) {
+		code := send("push", `{"ref": "refs/heads/main", "after": "abc"}`, "secret")
+		assert.Equal(t, http.StatusAccepted, code)
+		assert.Equal(t, "abc", <-hook.trigger)
+	})
+
+	t.Run("Coalesce pushes while queued", func(t *testing.T) {
+		send("push", `{"ref": "refs/heads/main", "after": "abc"}`, "secret")
+		code := send("push", `{"ref": "refs/heads/main", "after": "def"}`, "secret")
+		assert.Equal(t, http.StatusAccepted, code)
+		assert.Equal(t, "abc", <-hook.trigger)
+		assert.Len(t, hook.trigger, 0)
+	})
+
+	t.Run("Ignore other branches", func(t *testing.T) {
+		code := send("push", `{"ref": "refs/heads/dev", "after": "abc"}`, "secret")
+		assert.Equal(t, http.StatusAccepted, code)
+		assert.Len(t, hook.trigger, 0)
+	})
+
+	t.Run("Ping", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send("ping", `{}`, "secret"))
+	})
+
+	t.Run("Fail invalid signature", func(t *testing.T) {
+		code := send("push", `{"ref": "refs/heads/main", "after": "abc"}`, "other")
+		assert.Equal(t, http.StatusUnauthorized, code)
+		assert.Len(t, hook.trigger, 0)
+	})
+}
+
+func Test_ServePolicy(t *testing.T) {
+	_, state, rw := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		_ = rw.WriteFile("policy.json", []byte(`{
+			"network": "testnet",
+			"host": "127.0.0.1:3569",
+			"accounts": {"testnet-account": "0x01"},
+			"update": true,
+			"contracts": ["Foo"]
+		}`), 0644)
+
+		policy, err := loadPolicy(rw, "policy.json")
+		require.NoError(t, err)
+		assert.Equal(t, &deployPolicy{
+			Network:   "testnet",
+			Host:      "127.0.0.1:3569",
+			Accounts:  map[string]string{"testnet-account": "0x01"},
+			Update:    true,
+			Contracts: []string{"Foo"},
+		}, policy)
+	})
+
+	t.Run("Fail missing host", func(t *testing.T) {
+		_ = rw.WriteFile("policy.json", []byte(`{"network": "testnet"}`), 0644)
+
+		_, err := loadPolicy(rw, "policy.json")
+		assert.EqualError(t, err, "invalid deployment policy policy.json: the host is required")
+	})
+
+	state.Networks().AddOrUpdate(config.Network{
+		Name:          "testnet",
+		Host:          "access.devnet.nodes.onflow.org:9000",
+		FallbackHosts: []config.FallbackHost{{Host: "access-001.devnet.nodes.onflow.org:9000"}},
+	})
+	state.Accounts().AddOrUpdate(&accounts.Account{Name: "testnet-account", Address: flow.HexToAddress("0x01")})
+	state.Deployments().AddOrUpdate(config.Deployment{Network: "testnet", Account: "testnet-account"})
+
+	t.Run("Pin network", func(t *testing.T) {
+		policy := &deployPolicy{Network: "testnet", Host: "127.0.0.1:3569", Accounts: map[string]string{"testnet-account": "0x01"}}
+
+		network, err := policy.check(state)
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:3569", network.Host)
+		assert.Empty(t, network.FallbackHosts)
+	})
+
+	t.Run("Fail account not pinned", func(t *testing.T) {
+		policy := &deployPolicy{Network: "testnet", Host: "127.0.0.1:3569"}
+
+		_, err := policy.check(state)
+		assert.EqualError(t, err, "account testnet-account is not allowed to be deployed to by the policy")
+	})
+
+	t.Run("Fail account address changed", func(t *testing.T) {
+		policy := &deployPolicy{Network: "testnet", Host: "127.0.0.1:3569", Accounts: map[string]string{"testnet-account": "0x02"}}
+
+		_, err := policy.check(state)
+		assert.EqualError(t, err, "account testnet-account has address 0000000000000001, the policy pins the address 0000000000000002")
+	})
+
+	t.Run("Fail missing network", func(t *testing.T) {
+		_ = rw.WriteFile("policy.json", []byte(`{"host": "127.0.0.1:3569", "update": true}`), 0644)
+
+		_, err := loadPolicy(rw, "policy.json")
+		assert.EqualError(t, err, "invalid deployment policy policy.json: the network is required")
+	})
+}
+
+func Test_Inventory(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	srv.Network.Return(config.EmulatorNetwork)
+
+	service, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	state.Accounts().AddOrUpdate(&accounts.Account{Name: "testnet-account", Address: flow.HexToAddress("0x9a0766d93b6608b7")})
+	alice := &accounts.Account{Name: "alice", Address: flow.HexToAddress("0x01cf0e2f2f715450")}
+	state.Accounts().AddOrUpdate(alice)
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		address := args.Get(1).(flow.Address)
+		switch address {
+		case service.Address:
+			account := tests.NewAccountWithAddress(address.String())
+			account.Contracts = map[string][]byte{"NonFungibleToken": nil, "ExampleNFT": nil}
+			srv.GetAccount.Return(account, nil)
+		default:
+			srv.GetAccount.Return(nil, fmt.Errorf("account not found"))
+		}
+	})
+	srv.ExecuteScript.Run(func(args mock.Arguments) {
+		script := args.Get(1).(flowkit.Script)
+		if strings.Contains(string(script.Code), "FungibleToken.Balance") {
+			balance, _ := cadence.NewUFix64("10.5")
+			srv.ExecuteScript.Return(cadence.NewDictionary([]cadence.KeyValuePair{{
+				Key:   cadence.String("A.0ae53cb6e3f42a79.FlowToken.Vault"),
+				Value: balance,
+			}}), nil)
+			return
+		}
+		srv.ExecuteScript.Return(cadence.NewDictionary([]cadence.KeyValuePair{{
+			Key:   cadence.String("A.f8d6e0586b0a20c7.ExampleNFT.Collection"),
+			Value: cadence.NewInt(3),
+		}}), nil)
+	})
+
+	result, err := inventory([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+	require.NoError(t, err)
+
+	owned := result.(*inventoryResult)
+	assert.Equal(t, []string{"testnet-account"}, owned.skipped)
+	require.Len(t, owned.accounts, 2)
+	assert.Equal(t, accountEntry{
+		Name:        service.Name,
+		Address:     "0x" + service.Address.Hex(),
+		Contracts:   []string{"ExampleNFT", "NonFungibleToken"},
+		Vaults:      []tokenBalance{{Type: "A.0ae53cb6e3f42a79.FlowToken.Vault", Balance: "10.50000000"}},
+		Collections: []collectionCount{{Type: "A.f8d6e0586b0a20c7.ExampleNFT.Collection", Count: 3}},
+	}, owned.accounts[0])
+	assert.Equal(t, "alice", owned.accounts[1].Name)
+	assert.Equal(t, "failed to get account: account not found", owned.accounts[1].Error)
+
+	assert.Contains(t, result.String(), "FlowToken.Vault (0x0ae53cb6e3f42a79)")
+	assert.Contains(t, result.String(), "Skipped accounts with addresses of another network: testnet-account")
+	assert.Equal(t, "Network: emulator, Accounts: 2, Contracts: 2, Vaults: 1, Collections: 1", result.Oneliner())
+
+	t.Run("Fail unsupported network", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Network.Return(config.Network{Name: "custom", Host: "127.0.0.1:3569"})
+
+		_, err := inventory([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "inventory is not available on network custom, supported networks are emulator, testnet and mainnet")
+	})
+}
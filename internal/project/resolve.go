@@ -0,0 +1,170 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsResolve struct{}
+
+var resolveFlags = flagsResolve{}
+
+var resolveCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "resolve <filename>",
+		Short:   "Show how the imports of a Cadence file are resolved on a network",
+		Example: "flow project resolve ./cadence/scripts/get_balance.cdc --network testnet",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &resolveFlags,
+	RunS:  resolve,
+}
+
+func resolve(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	filename := args[0]
+	code, err := state.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading file %s: %w", filename, err)
+	}
+
+	program, err := project.NewProgram(code, nil, filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	contracts, err := state.DeploymentContractsByNetwork(flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	// the imports are resolved the same way as when deploying or executing on the network
+	replacer := project.NewImportReplacer(contracts, state.AliasesForNetwork(flow.Network()))
+	result := &resolveResult{
+		network:     flow.Network().Name,
+		code:        code,
+		resolutions: replacer.Resolve(program),
+	}
+
+	if result.unresolved() == 0 {
+		program, err = replacer.Replace(program)
+		if err != nil {
+			return nil, err
+		}
+		result.code = program.Code()
+	}
+
+	return result, nil
+}
+
+type resolveResult struct {
+	network     string
+	code        []byte
+	resolutions []project.ImportResolution
+}
+
+// unresolved returns the number of imports which can't be resolved on the network.
+func (r *resolveResult) unresolved() int {
+	count := 0
+	for _, resolution := range r.resolutions {
+		if resolution.Source == project.ImportSourceUnresolved {
+			count++
+		}
+	}
+	return count
+}
+
+func importAddress(resolution project.ImportResolution) string {
+	if resolution.Source == project.ImportSourceBuiltin || resolution.Source == project.ImportSourceUnresolved {
+		return ""
+	}
+	return "0x" + resolution.Address.Hex()
+}
+
+func (r *resolveResult) JSON() any {
+	imports := make([]any, 0, len(r.resolutions))
+	for _, resolution := range r.resolutions {
+		imports = append(imports, map[string]any{
+			"names":    resolution.Names,
+			"location": resolution.Location,
+			"address":  importAddress(resolution),
+			"source":   string(resolution.Source),
+		})
+	}
+
+	return map[string]any{
+		"network":  r.network,
+		"code":     string(r.code),
+		"imports":  imports,
+		"resolved": r.unresolved() == 0,
+	}
+}
+
+func (r *resolveResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if unresolved := r.unresolved(); unresolved > 0 {
+		_, _ = fmt.Fprintf(writer, "%d imports can't be resolved on network %s, the code is shown as written\n\n", unresolved, r.network)
+	}
+
+	_, _ = fmt.Fprintf(writer, "%s\n\n", strings.TrimRight(string(r.code), "\n"))
+
+	if len(r.resolutions) == 0 {
+		_, _ = fmt.Fprintf(writer, "No imports\n")
+		_ = writer.Flush()
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(writer, "Import\tLocation\tAddress\tSource\n")
+	for _, resolution := range r.resolutions {
+		names := strings.Join(resolution.Names, ", ")
+		if names == "" {
+			names = resolution.Location
+		}
+		address := importAddress(resolution)
+		if address == "" {
+			address = "-"
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", names, resolution.Location, address, resolution.Source)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *resolveResult) Oneliner() string {
+	return fmt.Sprintf("Imports: %d, Unresolved: %d, Network: %s", len(r.resolutions), r.unresolved(), r.network)
+}
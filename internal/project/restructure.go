@@ -0,0 +1,340 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsRestructure struct {
+	SourceRoot string `default:"cadence" flag:"source-root" info:"Directory the Cadence files are moved into"`
+	DryRun     bool   `default:"false" flag:"dry-run" info:"List the files which would be moved without changing the project"`
+}
+
+var restructureFlags = flagsRestructure{}
+
+var restructureCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "restructure",
+		Short:   "Move the project Cadence files into the conventional project layout",
+		Long:    "Move the contracts, scripts, transactions and tests into their directories inside the source root, rewrite the relative imports of the moved files and update the contract sources in the configuration",
+		Example: "flow project restructure\nflow project restructure --source-root src --dry-run",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &restructureFlags,
+	RunS:  restructure,
+}
+
+// layoutDirectories are the directories inside the source root each kind of program is moved into.
+var layoutDirectories = map[project.Kind]string{
+	project.KindContract:    "contracts",
+	project.KindScript:      "scripts",
+	project.KindTransaction: "transactions",
+	project.KindTest:        "tests",
+}
+
+// ignoredDirectories are not searched for Cadence files, besides the hidden directories.
+var ignoredDirectories = map[string]bool{
+	"node_modules": true,
+	"imports":      true,
+}
+
+func restructure(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	dir := "."
+	if paths := state.ConfigPaths(); len(paths) == 1 {
+		dir = filepath.Dir(paths[0])
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if !restructureFlags.DryRun {
+		unlock, err := state.LockConfig()
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+
+	}
+
+	fs := &afero.Afero{Fs: afero.NewBasePathFs(afero.NewOsFs(), dir)}
+	result, err := restructureProject(fs, state.Config(), restructureFlags.SourceRoot, restructureFlags.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	if restructureFlags.DryRun {
+		return result, nil
+	}
+
+	if err := state.SaveEdited(globalFlags.ConfigPaths); err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("%s Project restructured into %s", output.SuccessEmoji(), result.sourceRoot))
+
+	return result, nil
+}
+
+// restructureMove is a Cadence file moved into the conventional project layout.
+type restructureMove struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// restructureProject moves the Cadence files of the project into the conventional layout inside the source root,
+// and updates the configuration to define the source root and the contract sources relative to it.
+//
+// Files already inside the source root are not moved, but the relative imports of all the files are rewritten
+// to the new locations of the files they import. Neither the files nor the configuration are changed if dry run
+// is enabled.
+func restructureProject(fs *afero.Afero, conf *config.Config, sourceRoot string, dryRun bool) (*restructureResult, error) {
+	root := project.CleanLocation(sourceRoot)
+	if err := config.ValidateSourceRoot(root); err != nil {
+		return nil, err
+	}
+	if root == "." {
+		return nil, fmt.Errorf("source root %s must be a directory inside the project", sourceRoot)
+	}
+
+	files, err := cadenceFiles(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	// contracts are moved based on the configuration, so even contracts that can't be parsed end in the right directory
+	contractFiles := make(map[string]bool)
+	for _, contract := range conf.Contracts {
+		for _, location := range []string{contract.Location, contract.MockSource} {
+			if location != "" && !project.IsRemoteLocation(location) {
+				contractFiles[project.CleanLocation(conf.SourceLocation(location))] = true
+			}
+		}
+	}
+
+	programs := make(map[string]*project.Program)
+	moved := make(map[string]string)
+	targets := make(map[string]string)
+	result := &restructureResult{sourceRoot: root, dryRun: dryRun}
+
+	for _, file := range files {
+		code, err := fs.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		program, err := project.NewProgram(code, nil, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		programs[file] = program
+
+		if insideRoot(root, file) {
+			continue
+		}
+
+		kind := program.Kind()
+		if contractFiles[file] {
+			kind = project.KindContract
+		} else if strings.HasSuffix(file, "_test.cdc") {
+			kind = project.KindTest
+		}
+
+		target := path.Join(root, layoutDirectories[kind], path.Base(file))
+		if other, ok := targets[target]; ok {
+			return nil, fmt.Errorf("files %s and %s would both be moved to %s", other, file, target)
+		}
+		if exists, _ := fs.Exists(target); exists {
+			return nil, fmt.Errorf("file %s can't be moved to %s which already exists", file, target)
+		}
+
+		targets[target] = file
+		moved[file] = target
+		result.moves = append(result.moves, restructureMove{From: file, To: target, Kind: string(kind)})
+	}
+
+	rewritten := make(map[string][]byte)
+	for _, file := range files {
+		location := file
+		if target, ok := moved[file]; ok {
+			location = target
+		}
+
+		relocated, err := project.RelocateImports(programs[file], location, moved)
+		if err != nil {
+			return nil, err
+		}
+		changed := !bytes.Equal(relocated.Code(), programs[file].Code())
+		if location != file || changed {
+			rewritten[file] = relocated.Code()
+		}
+		if location == file && changed {
+			result.rewritten = append(result.rewritten, file)
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	for _, file := range files {
+		code, ok := rewritten[file]
+		if !ok {
+			continue
+		}
+		if err := moveFile(fs, file, moved[file], code); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, contract := range conf.Contracts {
+		conf.Contracts[i].Location = rootRelativeLocation(conf, root, contract.Location, moved)
+		conf.Contracts[i].MockSource = rootRelativeLocation(conf, root, contract.MockSource, moved)
+	}
+	conf.SourceRoot = root
+
+	return result, nil
+}
+
+// cadenceFiles returns the sorted Cadence files of the project, skipping the hidden and dependency directories.
+func cadenceFiles(fs *afero.Afero) ([]string, error) {
+	files := make([]string, 0)
+	err := fs.Walk(".", func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if file != "." && (strings.HasPrefix(info.Name(), ".") || ignoredDirectories[info.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(file) == ".cdc" {
+			files = append(files, project.CleanLocation(file))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// moveFile writes the code to the target and removes the file, or overwrites the file if there is no target.
+func moveFile(fs *afero.Afero, file string, target string, code []byte) error {
+	if target == "" {
+		return fs.WriteFile(file, code, 0644)
+	}
+
+	if err := fs.MkdirAll(path.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", target, err)
+	}
+	if err := fs.WriteFile(target, code, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	return fs.Remove(file)
+}
+
+// rootRelativeLocation returns the location of the contract source after the move relative to the source root.
+func rootRelativeLocation(conf *config.Config, root string, location string, moved map[string]string) string {
+	if location == "" || project.IsRemoteLocation(location) || filepath.IsAbs(location) {
+		return location
+	}
+
+	current := project.CleanLocation(conf.SourceLocation(location))
+	if target, ok := moved[current]; ok {
+		current = target
+	}
+
+	relative, err := filepath.Rel(filepath.FromSlash(root), filepath.FromSlash(current))
+	if err != nil {
+		return location
+	}
+	return filepath.ToSlash(relative)
+}
+
+func insideRoot(root string, file string) bool {
+	return strings.HasPrefix(file, root+"/")
+}
+
+type restructureResult struct {
+	sourceRoot string
+	moves      []restructureMove
+	rewritten  []string
+	dryRun     bool
+}
+
+func (r *restructureResult) JSON() any {
+	return map[string]any{
+		"sourceRoot": r.sourceRoot,
+		"moved":      r.moves,
+		"rewritten":  r.rewritten,
+		"dryRun":     r.dryRun,
+	}
+}
+
+func (r *restructureResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if len(r.moves) == 0 {
+		_, _ = fmt.Fprintf(writer, "All Cadence files are already inside %s\n", r.sourceRoot)
+	} else {
+		_, _ = fmt.Fprintf(writer, "From\tTo\tKind\n")
+		for _, move := range r.moves {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", move.From, move.To, move.Kind)
+		}
+	}
+	for _, file := range r.rewritten {
+		_, _ = fmt.Fprintf(writer, "Imports rewritten in %s\n", file)
+	}
+	if r.dryRun {
+		_, _ = fmt.Fprintf(writer, "\nDry run, no files were changed\n")
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *restructureResult) Oneliner() string {
+	return fmt.Sprintf("Source root: %s, Moved: %d, Rewritten: %d", r.sourceRoot, len(r.moves), len(r.rewritten))
+}
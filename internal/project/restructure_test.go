@@ -0,0 +1,143 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func restructureFiles(t *testing.T, files map[string]string) *afero.Afero {
+	fs := &afero.Afero{Fs: afero.NewMemMapFs()}
+	for name, code := range files {
+		require.NoError(t, fs.WriteFile(name, []byte(code), 0644))
+	}
+	return fs
+}
+
+func Test_Restructure(t *testing.T) {
+	files := map[string]string{
+		"contracts/Foo.cdc":       `pub contract Foo {}`,
+		"contracts/FooMock.cdc":   `pub contract Foo {}`,
+		"Bar.cdc":                 `import Foo from "./contracts/Foo.cdc" pub contract Bar {}`,
+		"get.cdc":                 `import Bar from "./Bar.cdc" pub fun main() {}`,
+		"txs/setup.cdc":           `import Foo from "../contracts/Foo.cdc" transaction {}`,
+		"Foo_test.cdc":            `import Test pub fun testFoo() {}`,
+		"cadence/scripts/old.cdc": `import Bar from "../../Bar.cdc" pub fun main() {}`,
+		".flow/sources/hash.cdc":  `pub contract Cached {}`,
+		"imports/0x1/FT.cdc":      `pub contract FT {}`,
+	}
+
+	newConfig := func() *config.Config {
+		return &config.Config{
+			Contracts: config.Contracts{
+				{Name: "Foo", Location: "./contracts/Foo.cdc", MockSource: "contracts/FooMock.cdc"},
+				{Name: "Bar", Location: "Bar.cdc"},
+				{Name: "FT", Location: "imports/0x1/FT.cdc"},
+				{Name: "Remote", Location: "https://example.com/Remote.cdc"},
+			},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		fs := restructureFiles(t, files)
+		conf := newConfig()
+
+		result, err := restructureProject(fs, conf, "cadence", false)
+		require.NoError(t, err)
+
+		assert.Equal(t, []restructureMove{
+			{From: "Bar.cdc", To: "cadence/contracts/Bar.cdc", Kind: "contract"},
+			{From: "Foo_test.cdc", To: "cadence/tests/Foo_test.cdc", Kind: "test"},
+			{From: "contracts/Foo.cdc", To: "cadence/contracts/Foo.cdc", Kind: "contract"},
+			{From: "contracts/FooMock.cdc", To: "cadence/contracts/FooMock.cdc", Kind: "contract"},
+			{From: "get.cdc", To: "cadence/scripts/get.cdc", Kind: "script"},
+			{From: "txs/setup.cdc", To: "cadence/transactions/setup.cdc", Kind: "transaction"},
+		}, result.moves)
+		assert.Equal(t, []string{"cadence/scripts/old.cdc"}, result.rewritten)
+
+		expected := map[string]string{
+			"cadence/contracts/Bar.cdc":      `import Foo from "./Foo.cdc" pub contract Bar {}`,
+			"cadence/scripts/get.cdc":        `import Bar from "../contracts/Bar.cdc" pub fun main() {}`,
+			"cadence/transactions/setup.cdc": `import Foo from "../contracts/Foo.cdc" transaction {}`,
+			"cadence/scripts/old.cdc":        `import Bar from "../contracts/Bar.cdc" pub fun main() {}`,
+			"cadence/tests/Foo_test.cdc":     `import Test pub fun testFoo() {}`,
+			".flow/sources/hash.cdc":         `pub contract Cached {}`,
+			"imports/0x1/FT.cdc":             `pub contract FT {}`,
+			"cadence/contracts/FooMock.cdc":  `pub contract Foo {}`,
+		}
+		for name, code := range expected {
+			content, err := fs.ReadFile(name)
+			require.NoError(t, err, name)
+			assert.Equal(t, code, string(content), name)
+		}
+		for _, name := range []string{"Bar.cdc", "get.cdc", "txs/setup.cdc", "contracts/Foo.cdc"} {
+			exists, _ := fs.Exists(name)
+			assert.False(t, exists, name)
+		}
+
+		assert.Equal(t, "cadence", conf.SourceRoot)
+		assert.Equal(t, "contracts/Foo.cdc", conf.Contracts[0].Location)
+		assert.Equal(t, "contracts/FooMock.cdc", conf.Contracts[0].MockSource)
+		assert.Equal(t, "contracts/Bar.cdc", conf.Contracts[1].Location)
+		assert.Equal(t, "../imports/0x1/FT.cdc", conf.Contracts[2].Location)
+		assert.Equal(t, "https://example.com/Remote.cdc", conf.Contracts[3].Location)
+		assert.Equal(t, "imports/0x1/FT.cdc", conf.SourceLocation(conf.Contracts[2].Location))
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		fs := restructureFiles(t, files)
+		conf := newConfig()
+
+		result, err := restructureProject(fs, conf, "cadence", true)
+		require.NoError(t, err)
+		assert.Len(t, result.moves, 6)
+		assert.Contains(t, result.String(), "Dry run, no files were changed")
+
+		assert.Equal(t, newConfig(), conf)
+		content, err := fs.ReadFile("get.cdc")
+		require.NoError(t, err)
+		assert.Equal(t, files["get.cdc"], string(content))
+	})
+
+	t.Run("Fail conflicting files", func(t *testing.T) {
+		fs := restructureFiles(t, map[string]string{
+			"a/get.cdc": `pub fun main() {}`,
+			"b/get.cdc": `pub fun main() {}`,
+		})
+
+		_, err := restructureProject(fs, &config.Config{}, "cadence", false)
+		assert.EqualError(t, err, "files a/get.cdc and b/get.cdc would both be moved to cadence/scripts/get.cdc")
+	})
+
+	t.Run("Fail invalid source root", func(t *testing.T) {
+		fs := restructureFiles(t, nil)
+
+		_, err := restructureProject(fs, &config.Config{}, "../cadence", false)
+		assert.EqualError(t, err, "source root ../cadence must be a directory inside the project")
+
+		_, err = restructureProject(fs, &config.Config{}, ".", false)
+		assert.EqualError(t, err, "source root . must be a directory inside the project")
+	})
+}
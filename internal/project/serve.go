@@ -0,0 +1,374 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsServe struct {
+	DeployOnPush bool   `default:"false" flag:"deploy-on-push" info:"Deploy the project when a push to the branch is received on the GitHub webhook"`
+	Branch       string `default:"main" flag:"branch" info:"Branch the pushes are deployed from"`
+	Port         int    `default:"8090" flag:"port" info:"Port the webhook is served on"`
+	Secret       string `default:"" flag:"secret" info:"Secret the webhook payloads are signed with, defaults to the FLOW_WEBHOOK_SECRET environment variable"`
+	Policy       string `default:"deploy-policy.json" flag:"policy" info:"File with the deployment policy"`
+	Pull         bool   `default:"true" flag:"pull" info:"Pull the branch before deploying, disable if the checkout is updated by another process"`
+}
+
+var serveFlags = flagsServe{}
+
+var ServeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "serve --deploy-on-push",
+		Short: "Serve a webhook deploying the project on every push",
+		Long: `Serve a GitHub push webhook deploying the project on every push to the branch.
+
+The payloads must be signed with the webhook secret. On each push the branch is pulled
+into the working directory, unless disabled, and the project is deployed as allowed by the policy.
+Pushes received while a deployment is running are deployed together once it finishes.
+
+The policy is a JSON file read when the server starts, so it can't be changed by a push:
+{
+  "network": "testnet",
+  "host": "access.devnet.nodes.onflow.org:9000",
+  "accounts": {"testnet-account": "0x01cf0e2f2f715450"},
+  "update": true,
+  "contracts": ["Foo", "Bar"]
+}
+The network and host are required, the project is deployed to the host of the policy and with the key
+of the policy if provided, ignoring the network host in the configuration. Accounts pin the address of
+each account the contracts are deployed to, deploying to an account not in the policy fails. Update
+allows updating already deployed contracts and contracts limits the deployable contracts, any contract
+is deployable if not provided.`,
+		Example: "FLOW_WEBHOOK_SECRET=... flow serve --deploy-on-push --branch main --policy deploy-policy.json",
+		Args:    cobra.NoArgs,
+		GroupID: "project",
+	},
+	Flags: &serveFlags,
+	RunS:  serve,
+}
+
+// webhookSecretEnv is the environment variable the webhook secret is read from if not provided with the flag.
+const webhookSecretEnv = "FLOW_WEBHOOK_SECRET"
+
+// maxPayloadSize limits the size of the webhook payloads read.
+const maxPayloadSize = 25 << 20
+
+// deployPolicy restricts the deployments triggered by the webhook.
+type deployPolicy struct {
+	// Network the project is deployed to.
+	Network string `json:"network"`
+	// Host of the network access API, used instead of the host in the configuration.
+	Host string `json:"host"`
+	// Key of the network host for secure connections, used instead of the key in the configuration.
+	Key string `json:"key,omitempty"`
+	// Accounts the contracts can be deployed to, by name with the pinned address.
+	Accounts map[string]string `json:"accounts"`
+	// Update allows updating contracts already deployed on the network.
+	Update bool `json:"update"`
+	// Contracts that can be deployed, any contract can be deployed if empty.
+	Contracts []string `json:"contracts"`
+}
+
+func serve(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if !serveFlags.DeployOnPush {
+		return nil, fmt.Errorf("no mode selected, use the --deploy-on-push flag")
+	}
+
+	secret := serveFlags.Secret
+	if secret == "" {
+		secret = os.Getenv(webhookSecretEnv)
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("the webhook secret is required, provide it with the --secret flag or the %s environment variable", webhookSecretEnv)
+	}
+
+	policy, err := loadPolicy(state.ReaderWriter(), serveFlags.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	hook := &pushWebhook{
+		secret:  []byte(secret),
+		branch:  serveFlags.Branch,
+		trigger: make(chan string, 1),
+	}
+	deployer := &pushDeployer{
+		configPaths:  state.ConfigPaths(),
+		readerWriter: state.ReaderWriter(),
+		policy:       policy,
+		branch:       serveFlags.Branch,
+		pull:         serveFlags.Pull,
+		logger:       logger,
+	}
+	go func() {
+		for commit := range hook.trigger {
+			deployer.deploy(commit)
+		}
+	}()
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", serveFlags.Port))
+	if err != nil {
+		return nil, err
+	}
+	server := &http.Server{Handler: hook}
+	command.OnShutdown("webhook server", server.Close)
+
+	// the server stops when the command is interrupted
+	interrupted := command.Interrupted()
+	go func() {
+		<-interrupted.Done()
+		_ = server.Close()
+	}()
+
+	logger.Info(fmt.Sprintf(
+		"%s Deploying pushes to %s on %s, webhook listening on port %d",
+		output.GoEmoji(),
+		serveFlags.Branch,
+		policy.Network,
+		serveFlags.Port,
+	))
+
+	err = server.Serve(listener)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// loadPolicy reads the deployment policy from the file.
+func loadPolicy(readerWriter flowkit.ReaderWriter, path string) (*deployPolicy, error) {
+	data, err := readerWriter.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployment policy: %w", err)
+	}
+
+	var policy deployPolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("invalid deployment policy %s: %w", path, err)
+	}
+	if policy.Network == "" {
+		return nil, fmt.Errorf("invalid deployment policy %s: the network is required", path)
+	}
+	if policy.Host == "" {
+		return nil, fmt.Errorf("invalid deployment policy %s: the host is required", path)
+	}
+
+	return &policy, nil
+}
+
+// pushWebhook receives the GitHub push events and triggers a deployment for the pushes to the branch.
+type pushWebhook struct {
+	secret []byte
+	branch string
+	// trigger receives the pushed commit, a push is dropped if a deployment is already queued
+	// since the queued deployment pulls the latest commit anyway.
+	trigger chan string
+}
+
+type pushPayload struct {
+	Ref   string `json:"ref"`
+	After string `json:"after"`
+}
+
+func (h *pushWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+	if !h.validSignature(r.Header.Get("X-Hub-Signature-256"), body) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Header.Get("X-GitHub-Event") {
+	case "ping":
+		w.WriteHeader(http.StatusOK)
+		return
+	case "push":
+	default:
+		http.Error(w, "event ignored", http.StatusAccepted)
+		return
+	}
+
+	var payload pushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if payload.Ref != fmt.Sprintf("refs/heads/%s", h.branch) {
+		http.Error(w, "branch ignored", http.StatusAccepted)
+		return
+	}
+
+	select {
+	case h.trigger <- payload.After:
+	default:
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// validSignature checks the payload is signed with the secret using HMAC SHA-256.
+func (h *pushWebhook) validSignature(signature string, body []byte) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(body)
+	return hmac.Equal(expected, mac.Sum(nil))
+}
+
+// pushDeployer pulls the pushed branch and deploys the project as allowed by the policy.
+type pushDeployer struct {
+	configPaths  []string
+	readerWriter flowkit.ReaderWriter
+	policy       *deployPolicy
+	branch       string
+	pull         bool
+	logger       output.Logger
+}
+
+func (d *pushDeployer) deploy(commit string) {
+	d.logger.Info(fmt.Sprintf("Deploying commit %s to %s...", commit, d.policy.Network))
+
+	_, err := d.run()
+	if err != nil {
+		d.logger.Error(fmt.Sprintf("Failed to deploy commit %s: %s", commit, err))
+		return
+	}
+	d.logger.Info(fmt.Sprintf("%s Deployed commit %s to %s", output.SuccessEmoji(), commit, d.policy.Network))
+}
+
+func (d *pushDeployer) run() (command.Result, error) {
+	if d.pull {
+		out, err := exec.Command("git", "pull", "--ff-only", "origin", d.branch).CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("failed to pull %s: %w\n%s", d.branch, err, out)
+		}
+	}
+
+	// the configuration may be changed by the push
+	state, err := flowkit.Load(d.configPaths, d.readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := d.policy.check(state)
+	if err != nil {
+		return nil, err
+	}
+
+	gw, err := command.GatewayFactory(state, d.readerWriter)(*network)
+	if err != nil {
+		return nil, err
+	}
+	if closer, ok := gw.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	flow := flowkit.NewFlowkit(state, *network, gw, d.logger)
+	c, err := flow.DeployProject(context.Background(), flowkit.UpdateExistingContract(d.policy.Update))
+	return deployOutcome(c, err, *network, d.logger, state)
+}
+
+// check validates the deployments of the configuration against the policy and returns the network pinned
+// to the host and key of the policy, since the configuration may be changed by the push.
+func (p *deployPolicy) check(state *flowkit.State) (*config.Network, error) {
+	network, err := state.Networks().ByName(p.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned := *network
+	pinned.Host = p.Host
+	pinned.Key = p.Key
+	pinned.FallbackHosts = nil
+
+	for _, deployment := range state.Deployments().ByNetwork(p.Network) {
+		address, ok := p.Accounts[deployment.Account]
+		if !ok {
+			return nil, fmt.Errorf("account %s is not allowed to be deployed to by the policy", deployment.Account)
+		}
+
+		account, err := state.Accounts().ByName(deployment.Account)
+		if err != nil {
+			return nil, err
+		}
+		if expected := flowsdk.HexToAddress(address); account.Address != expected {
+			return nil, fmt.Errorf(
+				"account %s has address %s, the policy pins the address %s",
+				deployment.Account,
+				account.Address,
+				expected,
+			)
+		}
+	}
+
+	if len(p.Contracts) > 0 {
+		contracts, err := state.DeploymentContractsByNetwork(pinned)
+		if err != nil {
+			return nil, err
+		}
+		for _, contract := range contracts {
+			if !slices.Contains(p.Contracts, contract.Name) {
+				return nil, fmt.Errorf("contract %s is not allowed to be deployed by the policy", contract.Name)
+			}
+		}
+	}
+
+	return &pinned, nil
+}
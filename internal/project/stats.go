@@ -0,0 +1,238 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsStats struct {
+	SinceHeight uint64 `default:"0" flag:"since-height" info:"Block height to start aggregating events from"`
+	EndHeight   uint64 `default:"0" flag:"end-height" info:"Block height to stop aggregating events at, defaults to the latest sealed block"`
+	Workers     int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
+	Batch       uint64 `default:"250" flag:"batch" info:"Number of blocks each worker will fetch"`
+}
+
+var statsFlags = flagsStats{}
+
+var statsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "stats",
+		Short:   "Aggregate events emitted by the project contracts per day",
+		Example: "flow project stats --network mainnet --since-height 55000000",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &statsFlags,
+	RunS:  stats,
+}
+
+func stats(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if statsFlags.SinceHeight == 0 {
+		return nil, fmt.Errorf("the since-height flag is required")
+	}
+	if statsFlags.Workers <= 0 || statsFlags.Batch == 0 {
+		return nil, fmt.Errorf("workers and batch must be greater than zero")
+	}
+
+	eventTypes, err := projectEventTypes(state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	end := statsFlags.EndHeight
+	if end == 0 {
+		latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+		if err != nil {
+			return nil, err
+		}
+		end = latest.Height
+	}
+	if end < statsFlags.SinceHeight {
+		return nil, fmt.Errorf("since height %d is after the end height %d", statsFlags.SinceHeight, end)
+	}
+
+	defer logger.StopProgress()
+
+	// query the range in chunks sized to the worker pool so only the counts are kept in memory
+	result := newStatsResult(statsFlags.SinceHeight, end)
+	worker := &flowkit.EventWorker{
+		Count:           statsFlags.Workers,
+		BlocksPerWorker: statsFlags.Batch,
+	}
+	iterator := flowkit.BlockRangeIterator(flow.Gateway(), statsFlags.SinceHeight, end, flowkit.BlockRangeOptions{
+		BatchSize: uint64(statsFlags.Workers) * statsFlags.Batch,
+	})
+	err = iterator.ForEach(ctx, func(ctx context.Context, _ gateway.Gateway, batch flowkit.BlockRange) error {
+		logger.StartProgress(fmt.Sprintf("Aggregating events in blocks %d - %d of %d...", batch.Start, batch.End, end))
+
+		blockEvents, err := flow.GetEvents(ctx, eventTypes, batch.Start, batch.End, worker)
+		if err != nil {
+			return err
+		}
+		result.add(blockEvents)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// projectEventTypes returns the fully qualified types of all events declared by the contracts deployed on the network.
+func projectEventTypes(state *flowkit.State, network config.Network) ([]string, error) {
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("no contracts are deployed on network %s in the configuration", network.Name)
+	}
+
+	eventTypes := make([]string, 0)
+	for _, contract := range contracts {
+		names, err := events.ContractEvents(contract.Code())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse contract %s: %w", contract.Name, err)
+		}
+		for _, name := range names {
+			eventTypes = append(eventTypes, fmt.Sprintf("A.%s.%s.%s", contract.AccountAddress.Hex(), contract.Name, name))
+		}
+	}
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("contracts deployed on network %s don't declare any events", network.Name)
+	}
+
+	sort.Strings(eventTypes)
+	return eventTypes, nil
+}
+
+type statsResult struct {
+	startHeight uint64
+	endHeight   uint64
+	// counts by day and event type
+	counts map[string]map[string]int
+	totals map[string]int
+}
+
+func newStatsResult(start uint64, end uint64) *statsResult {
+	return &statsResult{
+		startHeight: start,
+		endHeight:   end,
+		counts:      make(map[string]map[string]int),
+		totals:      make(map[string]int),
+	}
+}
+
+// add counts the events by the UTC day of the block they were emitted in.
+func (s *statsResult) add(blockEvents []flowsdk.BlockEvents) {
+	for _, block := range blockEvents {
+		day := block.BlockTimestamp.UTC().Format("2006-01-02")
+		for _, event := range block.Events {
+			if s.counts[day] == nil {
+				s.counts[day] = make(map[string]int)
+			}
+			s.counts[day][event.Type]++
+			s.totals[event.Type]++
+		}
+	}
+}
+
+func (s *statsResult) days() []string {
+	days := make([]string, 0, len(s.counts))
+	for day := range s.counts {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	return days
+}
+
+func sortedTypes(counts map[string]int) []string {
+	types := make([]string, 0, len(counts))
+	for eventType := range counts {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func (s *statsResult) JSON() any {
+	return map[string]any{
+		"startHeight": s.startHeight,
+		"endHeight":   s.endHeight,
+		"days":        s.counts,
+		"totals":      s.totals,
+	}
+}
+
+func (s *statsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Blocks\t%d - %d\n\n", s.startHeight, s.endHeight)
+	if len(s.totals) == 0 {
+		_, _ = fmt.Fprintf(writer, "No events emitted in the block range\n")
+		_ = writer.Flush()
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(writer, "Day\tEvent\tCount\n")
+	for _, day := range s.days() {
+		for _, eventType := range sortedTypes(s.counts[day]) {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%d\n", day, eventType, s.counts[day][eventType])
+		}
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nEvent\tTotal\n")
+	for _, eventType := range sortedTypes(s.totals) {
+		_, _ = fmt.Fprintf(writer, "%s\t%d\n", eventType, s.totals[eventType])
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (s *statsResult) Oneliner() string {
+	total := 0
+	for _, count := range s.totals {
+		total += count
+	}
+	return fmt.Sprintf("Blocks: %d - %d, Events: %d, Days: %d", s.startHeight, s.endHeight, total, len(s.counts))
+}
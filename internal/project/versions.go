@@ -0,0 +1,186 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsVersions struct{}
+
+var versionsFlags = flagsVersions{}
+
+var versionsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "versions",
+		Short:   "Compare the configured contract versions with the versions deployed on the network",
+		Example: "flow project versions --network mainnet",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &versionsFlags,
+	RunS:  versions,
+}
+
+const (
+	versionUpToDate    = "up to date"
+	versionOutdated    = "outdated"
+	versionNotDeployed = "not deployed"
+	versionUnversioned = "unversioned"
+)
+
+type contractVersion struct {
+	Name       string `json:"name"`
+	Address    string `json:"address"`
+	Configured string `json:"configured"`
+	Deployed   string `json:"deployed"`
+	Status     string `json:"status"`
+}
+
+func versions(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	contracts, err := state.DeploymentContractsByNetwork(flow.Network())
+	if err != nil {
+		return nil, err
+	}
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("no contracts are deployed on network %s in the configuration", flow.Network().Name)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Fetching deployed contracts from %s...", flow.Network().Name))
+	defer logger.StopProgress()
+
+	accounts := make(map[flowsdk.Address]*flowsdk.Account)
+	result := &versionsResult{network: flow.Network().Name}
+	for _, contract := range contracts {
+		account, ok := accounts[contract.AccountAddress]
+		if !ok {
+			account, err = flow.GetAccount(context.Background(), contract.AccountAddress)
+			if err != nil {
+				return nil, err
+			}
+			accounts[contract.AccountAddress] = account
+		}
+
+		configured := ""
+		if c, err := state.Contracts().ByName(contract.Name); err == nil {
+			configured = c.Version
+		}
+
+		result.contracts = append(result.contracts, newContractVersion(contract, configured, account.Contracts))
+	}
+
+	return result, nil
+}
+
+// newContractVersion compares the configured version of the contract with the version stamped in the deployed code.
+func newContractVersion(contract *project.Contract, configured string, deployed map[string][]byte) contractVersion {
+	version := contractVersion{
+		Name:       contract.Name,
+		Address:    "0x" + contract.AccountAddress.Hex(),
+		Configured: configured,
+	}
+
+	code, ok := deployed[contract.Name]
+	switch {
+	case !ok:
+		version.Status = versionNotDeployed
+	case project.DeployedVersion(code) == "":
+		version.Status = versionUnversioned
+	default:
+		version.Deployed = project.DeployedVersion(code)
+		version.Status = versionOutdated
+		if version.Deployed == configured {
+			version.Status = versionUpToDate
+		}
+	}
+
+	return version
+}
+
+type versionsResult struct {
+	network   string
+	contracts []contractVersion
+}
+
+func (r *versionsResult) count(status string) int {
+	count := 0
+	for _, c := range r.contracts {
+		if c.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *versionsResult) JSON() any {
+	return r.contracts
+}
+
+func (r *versionsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Contract\tAddress\tConfigured\tDeployed\tStatus\n")
+	for _, c := range r.contracts {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\n",
+			c.Name,
+			c.Address,
+			valueOrDash(c.Configured),
+			valueOrDash(c.Deployed),
+			c.Status,
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *versionsResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Network: %s, Contracts: %d, Outdated: %d",
+		r.network,
+		len(r.contracts),
+		r.count(versionOutdated),
+	)
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
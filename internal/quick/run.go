@@ -19,51 +19,48 @@
 package quick
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/scripts"
 )
 
 type flagsRun struct {
+	ArgsJSON string `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
 }
 
 var runFlags = flagsRun{}
 
-// RunCommand This command will act as an alias for running the emulator and deploying the contracts
+// RunCommand runs a script from the library of scripts embedded in the CLI, without arguments it lists
+// the available scripts. Starting the emulator and deploying the contracts is deprecated in favour of 'flow dev'.
 var RunCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "run",
-		Short:   "Start emulator and deploy all project contracts",
-		Example: "flow run",
+		Use:     "run [<script-name> <argument> <argument> ...]",
+		Short:   "Run a script from the built-in scripts library",
+		Example: "flow run get-flow-balance 0x1654653399040a61 --network mainnet",
 		GroupID: "project",
 	},
 	Flags: &runFlags,
-	Run: func(
-		_ []string,
-		_ command.GlobalFlags,
-		_ output.Logger,
-		_ flowkit.ReaderWriter,
-		_ flowkit.Services,
-	) (command.Result, error) {
-		fmt.Println("⚠️Deprecation notice: Use 'flow dev' command.")
-		return &runResult{}, nil
-	},
-}
-
-type runResult struct{}
-
-func (r *runResult) JSON() any {
-	return nil
+	Run:   run,
+	Deprecations: []command.Deprecation{{
+		Usage:       "running without arguments to start the emulator and deploy the contracts",
+		Used:        func(args []string) bool { return len(args) == 0 },
+		Replacement: "'flow dev'",
+	}},
 }
 
-func (r *runResult) String() string {
-	return ""
-}
+func run(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if len(args) == 0 {
+		return scripts.Library(), nil
+	}
 
-func (r *runResult) Oneliner() string {
-	return ""
+	return scripts.ExecuteLibrary(args, runFlags.ArgsJSON, flow)
 }
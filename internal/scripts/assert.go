@@ -0,0 +1,188 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsAssert struct {
+	ArgsJSON    string `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	BlockID     string `default:"" flag:"block-id" info:"block ID to execute the script at"`
+	BlockHeight uint64 `default:"" flag:"block-height" info:"block height to execute the script at"`
+	Expect      string `default:"" flag:"expect" info:"Expected result in the Cadence value format, e.g. '\"100.0\"' for a string"`
+	ExpectJSON  string `default:"" flag:"expect-json" info:"File containing the expected result in JSON-Cadence format"`
+}
+
+var assertFlags = flagsAssert{}
+
+var assertCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "assert <filename> [<argument> <argument> ...]",
+		Short: "Execute a script and fail if the result doesn't match the expected value",
+		Example: `flow scripts assert get_balance.cdc 0x01 --expect 100.00000000
+flow scripts assert get_name.cdc --expect '"Meow"'
+flow scripts assert get_nfts.cdc --expect-json expected.json`,
+		Args: cobra.MinimumNArgs(1),
+	},
+	Flags: &assertFlags,
+	Run:   assertScript,
+}
+
+func assertScript(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if (assertFlags.Expect == "") == (assertFlags.ExpectJSON == "") {
+		return nil, fmt.Errorf("provide exactly one of the --expect or --expect-json flags")
+	}
+
+	var expected []byte
+	if assertFlags.ExpectJSON != "" {
+		data, err := readerWriter.ReadFile(assertFlags.ExpectJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error loading expected result file: %w", err)
+		}
+		expected = data
+	}
+
+	value, err := executeScript(args, flagsScripts{
+		ArgsJSON:    assertFlags.ArgsJSON,
+		BlockID:     assertFlags.BlockID,
+		BlockHeight: assertFlags.BlockHeight,
+	}, readerWriter, flow)
+	if err != nil {
+		return nil, err
+	}
+
+	if expected != nil {
+		err = assertJSON(value, expected)
+	} else {
+		err = assertValue(value, assertFlags.Expect)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &assertResult{value}, nil
+}
+
+// assertValue compares the result to the expected value in the Cadence value format.
+func assertValue(value cadence.Value, expected string) error {
+	actual := value.String()
+	if strings.TrimSpace(expected) == actual {
+		return nil
+	}
+
+	return fmt.Errorf("script result doesn't match the expected value:\n%s", lineDiff(expected, actual))
+}
+
+// assertJSON compares the result to the expected value in the JSON-Cadence format.
+func assertJSON(value cadence.Value, expected []byte) error {
+	expectedValue, err := jsoncdc.Decode(nil, expected)
+	if err != nil {
+		return fmt.Errorf("failed to decode the expected result: %w", err)
+	}
+
+	// values are compared by their canonical encoding, the diff is shown on indented encodings
+	expectedEncoded, err := jsoncdc.Encode(expectedValue)
+	if err != nil {
+		return err
+	}
+	actualEncoded, err := jsoncdc.Encode(value)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(expectedEncoded, actualEncoded) {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"script result doesn't match the expected value:\n%s",
+		lineDiff(indentJSON(expectedEncoded), indentJSON(actualEncoded)),
+	)
+}
+
+func indentJSON(data []byte) string {
+	var b bytes.Buffer
+	if err := json.Indent(&b, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return b.String()
+}
+
+// lineDiff returns the lines of the expected and actual values, prefixed with - for expected
+// lines missing in the actual value and + for lines only present in the actual value.
+func lineDiff(expected string, actual string) string {
+	dmp := diffmatchpatch.New()
+	expectedChars, actualChars, lines := dmp.DiffLinesToChars(
+		strings.TrimSuffix(expected, "\n")+"\n",
+		strings.TrimSuffix(actual, "\n")+"\n",
+	)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(expectedChars, actualChars, false), lines)
+
+	var b strings.Builder
+	for _, diff := range diffs {
+		prefix := "  "
+		switch diff.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix = "- "
+		case diffmatchpatch.DiffInsert:
+			prefix = "+ "
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(diff.Text, "\n"), "\n") {
+			b.WriteString(prefix + line + "\n")
+		}
+	}
+	return b.String()
+}
+
+type assertResult struct {
+	cadence.Value
+}
+
+func (r *assertResult) JSON() any {
+	return map[string]any{
+		"passed": true,
+		"result": json.RawMessage(jsoncdc.MustEncode(r.Value)),
+	}
+}
+
+func (r *assertResult) String() string {
+	return fmt.Sprintf("%s Assertion passed, result: %s\n", output.SuccessEmoji(), r.Value)
+}
+
+func (r *assertResult) Oneliner() string {
+	return r.Value.String()
+}
@@ -0,0 +1,318 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsBatch struct {
+	Input       string   `default:"" flag:"input" info:"CSV file with a row of arguments for each script execution"`
+	Map         []string `default:"" flag:"map" info:"Argument of a script parameter built from the row columns, e.g. 'address=$1'"`
+	Header      bool     `default:"false" flag:"header" info:"Skip the first row of the input containing the column names"`
+	Concurrency int      `default:"10" flag:"concurrency" info:"Maximum number of scripts executed at the same time"`
+	BlockID     string   `default:"" flag:"block-id" info:"block ID to execute the scripts at"`
+	BlockHeight uint64   `default:"" flag:"block-height" info:"block height to execute the scripts at"`
+}
+
+var batchFlags = flagsBatch{}
+
+var executeBatchCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "execute-batch <filename> --input <csv>",
+		Short: "Execute a script once for each row of a CSV file",
+		Long: `Execute a script once for each row of a CSV file and aggregate the results.
+
+Each script parameter is mapped to a template referencing the row columns with $1, $2, ...,
+without any mappings the columns are passed as the arguments in the order of the parameters.
+Executions that fail are reported with the error, use the csv or json output for reporting.`,
+		Example: `flow scripts execute-batch get_balance.cdc --input accounts.csv --map 'address=$1'
+flow scripts execute-batch get_balance.cdc --input accounts.csv --header --output csv --save balances.csv`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &batchFlags,
+	Run:   executeBatch,
+}
+
+var columnReference = regexp.MustCompile(`\$(\d+)`)
+
+func executeBatch(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if batchFlags.Input == "" {
+		return nil, fmt.Errorf("the --input flag is required")
+	}
+	if batchFlags.Concurrency <= 0 {
+		return nil, fmt.Errorf("concurrency must be greater than zero")
+	}
+	if batchFlags.BlockHeight != 0 && batchFlags.BlockID != "" {
+		return nil, fmt.Errorf("provide either the --block-height or the --block-id flag, not both")
+	}
+
+	filename := args[0]
+	code, err := readerWriter.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading script file: %w", err)
+	}
+
+	params, err := arguments.ParameterNames(code)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing script: %w", err)
+	}
+
+	templates, err := parseTemplates(batchFlags.Map, params)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := readerWriter.ReadFile(batchFlags.Input)
+	if err != nil {
+		return nil, fmt.Errorf("error loading input file: %w", err)
+	}
+	reader := csv.NewReader(bytes.NewReader(input))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing input file: %w", err)
+	}
+	if batchFlags.Header && len(rows) > 0 {
+		rows = rows[1:]
+	}
+
+	// parse all the rows first, so an invalid input is reported before any script is executed
+	executions := make([]batchExecution, len(rows))
+	for i, row := range rows {
+		rowArgs, err := expandTemplates(templates, row)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+
+		scriptArgs, err := arguments.ParseWithoutType(rowArgs, code, filename)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: error parsing script arguments: %w", i+1, err)
+		}
+
+		executions[i] = batchExecution{Row: i + 1, Arguments: rowArgs, args: scriptArgs}
+	}
+
+	query := flowkit.ScriptQuery{}
+	if batchFlags.BlockHeight != 0 {
+		query.Height = batchFlags.BlockHeight
+	} else if batchFlags.BlockID != "" {
+		query.ID = flowsdk.HexToID(batchFlags.BlockID)
+	} else {
+		// execute all the rows at the same block, so the results are consistent
+		block, err := flow.GetBlock(context.Background(), flowkit.LatestBlockQuery)
+		if err != nil {
+			return nil, err
+		}
+		query.Height = block.Height
+	}
+
+	logger.StartProgress(fmt.Sprintf("Executing script for %d rows...", len(executions)))
+	defer logger.StopProgress()
+
+	limit := make(chan struct{}, batchFlags.Concurrency)
+	var wg sync.WaitGroup
+	for i := range executions {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(execution *batchExecution) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+
+			execution.value, execution.err = flow.ExecuteScript(
+				context.Background(),
+				flowkit.Script{
+					Code:     code,
+					Args:     execution.args,
+					Location: filename,
+				},
+				query,
+			)
+		}(&executions[i])
+	}
+	wg.Wait()
+
+	return &batchResult{params: params, executions: executions}, nil
+}
+
+// parseTemplates returns the argument template of each parameter from the mappings in the param=template format,
+// without mappings the parameters are mapped to the columns in order.
+func parseTemplates(mappings []string, params []string) ([]string, error) {
+	byName := make(map[string]string)
+	for _, mapping := range mappings {
+		if mapping == "" {
+			continue
+		}
+
+		name, template, found := strings.Cut(mapping, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid mapping %s, expected format param=template", mapping)
+		}
+		byName[strings.TrimSpace(name)] = template
+	}
+
+	templates := make([]string, len(params))
+	for i, param := range params {
+		if len(byName) == 0 {
+			templates[i] = fmt.Sprintf("$%d", i+1)
+			continue
+		}
+
+		template, ok := byName[param]
+		if !ok {
+			return nil, fmt.Errorf("parameter %s is not mapped", param)
+		}
+		templates[i] = template
+		delete(byName, param)
+	}
+
+	for name := range byName {
+		return nil, fmt.Errorf("mapping %s is not a script parameter", name)
+	}
+
+	return templates, nil
+}
+
+// expandTemplates replaces the column references in the templates with the row values.
+func expandTemplates(templates []string, row []string) ([]string, error) {
+	args := make([]string, len(templates))
+	for i, template := range templates {
+		var err error
+		args[i] = columnReference.ReplaceAllStringFunc(template, func(ref string) string {
+			column, _ := strconv.Atoi(ref[1:])
+			if column < 1 || column > len(row) {
+				err = fmt.Errorf("column %s does not exist, the row has %d columns", ref, len(row))
+				return ""
+			}
+			return row[column-1]
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return args, nil
+}
+
+type batchExecution struct {
+	Row       int
+	Arguments []string
+	args      []cadence.Value
+	value     cadence.Value
+	err       error
+}
+
+type batchResult struct {
+	params     []string
+	executions []batchExecution
+}
+
+func (r *batchResult) JSON() any {
+	result := make([]any, 0, len(r.executions))
+	for _, e := range r.executions {
+		item := map[string]any{
+			"row":       e.Row,
+			"arguments": e.Arguments,
+		}
+		if e.err != nil {
+			item["error"] = e.err.Error()
+		} else {
+			item["result"] = json.RawMessage(jsoncdc.MustEncode(e.value))
+		}
+		result = append(result, item)
+	}
+
+	return result
+}
+
+func (r *batchResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Row\tArguments\tResult\n")
+	for _, e := range r.executions {
+		_, _ = fmt.Fprintf(writer, "%d\t%s\t%s\n", e.Row, strings.Join(e.Arguments, ", "), e.result())
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *batchResult) Oneliner() string {
+	results := make([]string, 0, len(r.executions))
+	for _, e := range r.executions {
+		results = append(results, e.result())
+	}
+	return strings.Join(results, ", ")
+}
+
+func (r *batchResult) CSV() ([]byte, error) {
+	var b bytes.Buffer
+	writer := csv.NewWriter(&b)
+
+	header := append([]string{"row"}, r.params...)
+	_ = writer.Write(append(header, "result", "error"))
+	for _, e := range r.executions {
+		record := append([]string{strconv.Itoa(e.Row)}, e.Arguments...)
+		if e.err != nil {
+			record = append(record, "", e.err.Error())
+		} else {
+			record = append(record, e.value.String(), "")
+		}
+		_ = writer.Write(record)
+	}
+
+	writer.Flush()
+	return b.Bytes(), writer.Error()
+}
+
+// result returns the script result or the error of a failed execution.
+func (e batchExecution) result() string {
+	if e.err != nil {
+		return fmt.Sprintf("error: %s", e.err)
+	}
+	return e.value.String()
+}
@@ -20,7 +20,6 @@ package scripts
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/onflow/cadence"
 	flowsdk "github.com/onflow/flow-go-sdk"
@@ -30,6 +29,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit/arguments"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/i18n"
 )
 
 type flagsScripts struct {
@@ -42,10 +42,11 @@ var scriptFlags = flagsScripts{}
 
 var executeCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "execute <filename> [<argument> <argument> ...]",
-		Short:   "Execute a script",
-		Example: `flow scripts execute script.cdc "Meow" "Woof"`,
-		Args:    cobra.MinimumNArgs(1),
+		Use:   "execute <filename> [<argument> <argument> ...]",
+		Short: "Execute a script",
+		Example: `flow scripts execute script.cdc "Meow" "Woof"
+flow scripts execute script.cdc "Meow" --block-height 54000000 --network mainnet`,
+		Args: cobra.MinimumNArgs(1),
 	},
 	Flags: &scriptFlags,
 	Run:   execute,
@@ -58,34 +59,54 @@ func execute(
 	readerWriter flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
+	value, err := executeScript(args, scriptFlags, readerWriter, flow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scriptResult{value}, nil
+}
+
+// executeScript executes the script file in the first argument with the rest of the arguments
+// as script arguments, unless the arguments are provided in JSON-Cadence format with the flags.
+func executeScript(
+	args []string,
+	flags flagsScripts,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (cadence.Value, error) {
+	if flags.BlockHeight != 0 && flags.BlockID != "" {
+		return nil, i18n.Errorf("provide either the --block-height or the --block-id flag, not both")
+	}
+
 	filename := args[0]
 
 	code, err := readerWriter.ReadFile(filename)
 	if err != nil {
-		return nil, fmt.Errorf("error loading script file: %w", err)
+		return nil, i18n.Errorf("error loading script file: %w", err)
 	}
 
 	var scriptArgs []cadence.Value
-	if scriptFlags.ArgsJSON != "" {
-		scriptArgs, err = arguments.ParseJSON(scriptFlags.ArgsJSON)
+	if flags.ArgsJSON != "" {
+		scriptArgs, err = arguments.ParseJSON(flags.ArgsJSON)
 	} else {
 		scriptArgs, err = arguments.ParseWithoutType(args[1:], code, filename)
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("error parsing script arguments: %w", err)
+		return nil, i18n.Errorf("error parsing script arguments: %w", err)
 	}
 
 	query := flowkit.ScriptQuery{}
-	if scriptFlags.BlockHeight != 0 {
-		query.Height = scriptFlags.BlockHeight
-	} else if scriptFlags.BlockID != "" {
-		query.ID = flowsdk.HexToID(scriptFlags.BlockID)
+	if flags.BlockHeight != 0 {
+		query.Height = flags.BlockHeight
+	} else if flags.BlockID != "" {
+		query.ID = flowsdk.HexToID(flags.BlockID)
 	} else {
 		query.Latest = true
 	}
 
-	value, err := flow.ExecuteScript(
+	return flow.ExecuteScript(
 		context.Background(),
 		flowkit.Script{
 			Code:     code,
@@ -94,9 +115,4 @@ func execute(
 		},
 		query,
 	)
-	if err != nil {
-		return nil, err
-	}
-
-	return &scriptResult{value}, nil
 }
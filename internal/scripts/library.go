@@ -0,0 +1,150 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+//go:embed library/*.cdc
+var libraryFiles embed.FS
+
+type libraryScript struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Arguments   string `json:"arguments,omitempty"`
+}
+
+// library contains the vetted scripts shipped with the CLI, the code is stored in the library folder
+// under the same name.
+var library = []libraryScript{{
+	Name:        "get-account-keys",
+	Description: "Get all the keys on the account",
+	Arguments:   "<address>",
+}, {
+	Name:        "get-flow-balance",
+	Description: "Get the FLOW balance of the account",
+	Arguments:   "<address>",
+}, {
+	Name:        "get-storage-stats",
+	Description: "Get the storage used, capacity and available storage of the account",
+	Arguments:   "<address>",
+}, {
+	Name:        "get-total-supply",
+	Description: "Get the total supply of FLOW",
+}, {
+	Name:        "get-current-epoch",
+	Description: "Get the counter, phase and views of the current epoch",
+}}
+
+// libraryCode returns the code of the library script with the core contract imports replaced
+// by the addresses on the provided network.
+func libraryCode(name string, network string) ([]byte, error) {
+	code, err := libraryFiles.ReadFile(fmt.Sprintf("library/%s.cdc", name))
+	if err != nil {
+		return nil, fmt.Errorf("script %s is not part of the library, run 'flow run' to list available scripts", name)
+	}
+
+	code, err = util.ReplaceCoreImports(code, network)
+	if err != nil {
+		return nil, fmt.Errorf("library scripts are not available on network %s, supported networks are emulator, testnet and mainnet", network)
+	}
+
+	return code, nil
+}
+
+// ExecuteLibrary executes the library script by the name provided as the first argument, using the rest
+// of the arguments or the JSON arguments as script arguments.
+func ExecuteLibrary(args []string, argsJSON string, flow flowkit.Services) (command.Result, error) {
+	name := args[0]
+	code, err := libraryCode(name, flow.Network().Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var scriptArgs []cadence.Value
+	if argsJSON != "" {
+		scriptArgs, err = arguments.ParseJSON(argsJSON)
+	} else {
+		scriptArgs, err = arguments.ParseWithoutType(args[1:], code, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing script arguments: %w", err)
+	}
+
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code:     code,
+			Args:     scriptArgs,
+			Location: name,
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scriptResult{value}, nil
+}
+
+// Library returns the list of available library scripts.
+func Library() command.Result {
+	return libraryResult(library)
+}
+
+type libraryResult []libraryScript
+
+func (r libraryResult) JSON() any {
+	return []libraryScript(r)
+}
+
+func (r libraryResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Available scripts:\n")
+	for _, script := range r {
+		_, _ = fmt.Fprintf(writer, "  %s %s\t%s\n", script.Name, script.Arguments, script.Description)
+	}
+
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (r libraryResult) Oneliner() string {
+	names := make([]string, len(r))
+	for i, script := range r {
+		names[i] = script.Name
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -39,6 +39,8 @@ var Cmd = &cobra.Command{
 
 func init() {
 	executeCommand.AddToParent(Cmd)
+	assertCommand.AddToParent(Cmd)
+	executeBatchCommand.AddToParent(Cmd)
 }
 
 type scriptResult struct {
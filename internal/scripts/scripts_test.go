@@ -20,11 +20,13 @@ package scripts
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/onflow/cadence"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/tests"
@@ -65,4 +67,191 @@ func Test_Execute(t *testing.T) {
 		assert.EqualError(t, err, "error parsing script arguments: invalid character 'i' looking for beginning of value")
 	})
 
+	t.Run("Success at block height", func(t *testing.T) {
+		inArgs := []string{tests.ScriptArgString.Filename, "foo"}
+		scriptFlags = flagsScripts{BlockHeight: 100}
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			query := args.Get(2).(flowkit.ScriptQuery)
+			assert.False(t, query.Latest)
+			assert.Equal(t, uint64(100), query.Height)
+		}).Return(cadence.NewInt(1), nil)
+
+		result, err := execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NotNil(t, result)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success at block ID", func(t *testing.T) {
+		inArgs := []string{tests.ScriptArgString.Filename, "foo"}
+		scriptFlags = flagsScripts{BlockID: "a310685082f0b09f2a148b2e8905f08ea458ed873596b53b200699e8e1f6536f"}
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			query := args.Get(2).(flowkit.ScriptQuery)
+			assert.False(t, query.Latest)
+			assert.Equal(t, scriptFlags.BlockID, query.ID.String())
+		}).Return(cadence.NewInt(1), nil)
+
+		result, err := execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NotNil(t, result)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail both block height and ID", func(t *testing.T) {
+		inArgs := []string{tests.ScriptArgString.Filename, "foo"}
+		scriptFlags = flagsScripts{BlockHeight: 100, BlockID: "a310685082f0b09f2a148b2e8905f08ea458ed873596b53b200699e8e1f6536f"}
+
+		result, err := execute(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "provide either the --block-height or the --block-id flag, not both")
+	})
+
+	scriptFlags = flagsScripts{}
+}
+
+func Test_Library(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+
+	t.Run("Success", func(t *testing.T) {
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			assert.Equal(t, "get-flow-balance", script.Location)
+			assert.Contains(t, string(script.Code), "import FlowToken from 0x0ae53cb6e3f42a79")
+			assert.Contains(t, string(script.Code), "import FungibleToken from 0xee82856bf20e2aa6")
+			assert.Equal(t, "0x01cf0e2f2f715450", script.Args[0].String())
+		}).Return(cadence.UFix64(100), nil)
+
+		result, err := ExecuteLibrary([]string{"get-flow-balance", "0x01cf0e2f2f715450"}, "", srv.Mock)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Code for all networks", func(t *testing.T) {
+		for _, script := range library {
+			for network := range util.CoreContracts {
+				code, err := libraryCode(script.Name, network)
+				assert.NoError(t, err)
+				assert.NotContains(t, string(code), "from 0xF")
+			}
+		}
+	})
+
+	t.Run("Fail unknown script", func(t *testing.T) {
+		result, err := ExecuteLibrary([]string{"foo"}, "", srv.Mock)
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "script foo is not part of the library, run 'flow run' to list available scripts")
+	})
+
+	t.Run("Fail unsupported network", func(t *testing.T) {
+		_, err := libraryCode("get-total-supply", "previewnet")
+		assert.EqualError(t, err, "library scripts are not available on network previewnet, supported networks are emulator, testnet and mainnet")
+	})
+}
+
+func Test_Assert(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	inArgs := []string{tests.TestScriptSimple.Filename}
+
+	srv.ExecuteScript.Run(func(args mock.Arguments) {
+		srv.ExecuteScript.Return(cadence.NewArray([]cadence.Value{
+			cadence.String("foo"),
+			cadence.String("bar"),
+		}), nil)
+	})
+
+	t.Run("Success expect", func(t *testing.T) {
+		assertFlags = flagsAssert{Expect: `["foo", "bar"]`}
+		result, err := assertScript(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, `["foo", "bar"]`, result.Oneliner())
+	})
+
+	t.Run("Success expect JSON", func(t *testing.T) {
+		_ = rw.WriteFile("expected.json", []byte(`{"type": "Array", "value": [
+			{"type": "String", "value": "foo"},
+			{"type": "String", "value": "bar"}
+		]}`), 0644)
+		assertFlags = flagsAssert{ExpectJSON: "expected.json"}
+		_, err := assertScript(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail expect", func(t *testing.T) {
+		assertFlags = flagsAssert{Expect: `["foo"]`}
+		_, err := assertScript(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "script result doesn't match the expected value:\n- [\"foo\"]\n+ [\"foo\", \"bar\"]\n")
+	})
+
+	t.Run("Fail expect JSON", func(t *testing.T) {
+		_ = rw.WriteFile("expected.json", []byte(`{"type": "Array", "value": [
+			{"type": "String", "value": "foo"},
+			{"type": "String", "value": "baz"}
+		]}`), 0644)
+		assertFlags = flagsAssert{ExpectJSON: "expected.json"}
+		_, err := assertScript(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "script result doesn't match the expected value:\n")
+		assert.ErrorContains(t, err, "-       \"value\": \"baz\",\n")
+		assert.ErrorContains(t, err, "+       \"value\": \"bar\",\n")
+		assert.ErrorContains(t, err, "        \"value\": \"foo\",\n")
+	})
+
+	t.Run("Fail missing expectation", func(t *testing.T) {
+		assertFlags = flagsAssert{}
+		_, err := assertScript(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "provide exactly one of the --expect or --expect-json flags")
+	})
+}
+
+func Test_ExecuteBatch(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	_ = rw.WriteFile("names.csv", []byte("name,age\nfoo,1\nbar,2\n"), 0644)
+
+	srv.GetBlock.Return(tests.NewBlock(), nil)
+
+	t.Run("Success", func(t *testing.T) {
+		batchFlags = flagsBatch{Input: "names.csv", Header: true, Map: []string{"name=$1-$2"}, Concurrency: 2}
+
+		var mu sync.Mutex
+		names := make([]string, 0)
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			query := args.Get(2).(flowkit.ScriptQuery)
+			assert.Equal(t, tests.NewBlock().Height, query.Height)
+
+			mu.Lock()
+			names = append(names, script.Args[0].String())
+			mu.Unlock()
+		}).Return(cadence.String("Hello"), nil)
+
+		result, err := executeBatch([]string{tests.ScriptArgString.Filename}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{`"foo-1"`, `"bar-2"`}, names)
+
+		csv, err := result.(command.CSVResult).CSV()
+		require.NoError(t, err)
+		assert.Equal(t, "row,name,result,error\n1,foo-1,\"\"\"Hello\"\"\",\n2,bar-2,\"\"\"Hello\"\"\",\n", string(csv))
+	})
+
+	t.Run("Fail unmapped parameter", func(t *testing.T) {
+		batchFlags = flagsBatch{Input: "names.csv", Map: []string{"age=$2"}, Concurrency: 1}
+
+		_, err := executeBatch([]string{tests.ScriptArgString.Filename}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "parameter name is not mapped")
+	})
+
+	t.Run("Fail missing column", func(t *testing.T) {
+		batchFlags = flagsBatch{Input: "names.csv", Map: []string{"name=$3"}, Concurrency: 1}
+
+		_, err := executeBatch([]string{tests.ScriptArgString.Filename}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "row 1: column $3 does not exist, the row has 2 columns")
+	})
+
+	t.Run("Fail missing input", func(t *testing.T) {
+		batchFlags = flagsBatch{Concurrency: 1}
+
+		_, err := executeBatch([]string{tests.ScriptArgString.Filename}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "the --input flag is required")
+	})
+
+	batchFlags = flagsBatch{}
 }
@@ -0,0 +1,115 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package settings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var aliasSettings = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage user-defined command aliases",
+	Example: `flow settings alias set dep "project deploy --network testnet --update"
+flow settings alias list
+flow settings alias remove dep`,
+	TraverseChildren: true,
+}
+
+var aliasSet = &cobra.Command{
+	Use:     "set <name> <command>",
+	Short:   "Add or replace a command alias",
+	Example: `flow settings alias set dep "project deploy --network testnet --update"`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    handleAliasSet,
+}
+
+var aliasRemove = &cobra.Command{
+	Use:     "remove <name>",
+	Short:   "Remove a command alias",
+	Example: "flow settings alias remove dep",
+	Args:    cobra.ExactArgs(1),
+	RunE:    handleAliasRemove,
+}
+
+var aliasList = &cobra.Command{
+	Use:   "list",
+	Short: "List all command aliases",
+	Args:  cobra.NoArgs,
+	RunE:  handleAliasList,
+}
+
+func init() {
+	aliasSettings.AddCommand(aliasSet)
+	aliasSettings.AddCommand(aliasRemove)
+	aliasSettings.AddCommand(aliasList)
+}
+
+// handleAliasSet saves the alias to the global settings, aliases can't replace existing commands.
+func handleAliasSet(cmd *cobra.Command, args []string) error {
+	name, command := args[0], strings.TrimSpace(args[1])
+	if name == "" || strings.ContainsAny(name, " \t") || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid alias name %s, names can't contain spaces or start with a dash", name)
+	}
+	if found, _, err := cmd.Root().Find([]string{name}); err == nil && found != cmd.Root() {
+		return fmt.Errorf("alias %s conflicts with the existing command flow %s", name, name)
+	}
+	if command == "" {
+		return fmt.Errorf("alias %s must define a command", name)
+	}
+
+	if err := SetAlias(name, command); err != nil {
+		return errors.Wrap(err, "failed to update alias settings")
+	}
+
+	fmt.Printf("Alias %s was set to '%s'. Settings were updated in %s \n", name, command, FileName())
+	return nil
+}
+
+func handleAliasRemove(_ *cobra.Command, args []string) error {
+	if err := RemoveAlias(args[0]); err != nil {
+		return errors.Wrap(err, "failed to update alias settings")
+	}
+
+	fmt.Printf("Alias %s was removed. Settings were updated in %s \n", args[0], FileName())
+	return nil
+}
+
+func handleAliasList(_ *cobra.Command, _ []string) error {
+	all := Aliases()
+	if len(all) == 0 {
+		fmt.Println("No aliases defined, add one using: flow settings alias set <name> <command>")
+		return nil
+	}
+
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("%s = %s\n", name, all[name])
+	}
+	return nil
+}
@@ -30,4 +30,7 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(metricsSettings)
+	Cmd.AddCommand(strictSettings)
+	Cmd.AddCommand(hooksSettings)
+	Cmd.AddCommand(aliasSettings)
 }
@@ -27,12 +27,18 @@ import (
 const (
 	metricsEnabled = "MetricsEnabled"
 	flowserPath    = "FlowserPath"
+	strictMode     = "StrictMode"
+	trustedHooks   = "TrustedHooks"
+	aliases        = "Aliases"
 )
 
 // defaults holds the default values for global settings
 var defaults = map[string]any{
 	metricsEnabled: true,
 	flowserPath:    getDefaultInstallDir(),
+	strictMode:     false,
+	trustedHooks:   []string{},
+	aliases:        map[string]string{},
 }
 
 const (
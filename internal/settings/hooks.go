@@ -0,0 +1,51 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package settings
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const reset = "reset"
+
+var hooksSettings = &cobra.Command{
+	Use:       "hooks",
+	Short:     "Forget the projects whose hooks were trusted to run without the --allow-hooks flag",
+	Example:   "flow settings hooks reset",
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{reset},
+	RunE:      handleHooksSettings,
+}
+
+// handleHooksSettings removes the trusted project hooks from the global settings
+func handleHooksSettings(
+	_ *cobra.Command,
+	_ []string,
+) error {
+	if err := Set(trustedHooks, []string{}); err != nil {
+		return errors.Wrap(err, "failed to update hooks settings")
+	}
+
+	fmt.Printf("Trusted project hooks were reset. Settings were updated in %s \n", FileName())
+
+	return nil
+}
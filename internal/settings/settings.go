@@ -22,7 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -51,7 +52,7 @@ func FileDir() string {
 	if err != nil {
 		dir = "."
 	}
-	return path.Join(dir, settingsDir)
+	return filepath.Join(dir, settingsDir)
 }
 
 // Set updates settings file with new value for provided key
@@ -130,3 +131,81 @@ func MetricsEnabled() bool {
 	}
 	return viper.GetBool(metricsEnabled)
 }
+
+// StrictModeEnabled checks whether strict mode is enabled by default for all commands.
+func StrictModeEnabled() bool {
+	if err := loadViper(); err != nil {
+		return false
+	}
+	return viper.GetBool(strictMode)
+}
+
+// HooksTrusted checks whether the user trusted the hooks with the hash defined in the project configuration.
+func HooksTrusted(project string, hash string) bool {
+	if err := loadViper(); err != nil {
+		return false
+	}
+
+	for _, trusted := range viper.GetStringSlice(trustedHooks) {
+		if trusted == trustedHooksEntry(project, hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// TrustHooks records the hooks with the hash defined in the project configuration as trusted, replacing the hooks
+// previously trusted for the project.
+func TrustHooks(project string, hash string) error {
+	if err := loadViper(); err != nil {
+		return err
+	}
+
+	all := []string{trustedHooksEntry(project, hash)}
+	for _, trusted := range viper.GetStringSlice(trustedHooks) {
+		if _, path, _ := strings.Cut(trusted, " "); path != project {
+			all = append(all, trusted)
+		}
+	}
+	return Set(trustedHooks, all)
+}
+
+// trustedHooksEntry returns the trusted hooks setting entry of the project, the hash goes first since the
+// project path may contain spaces.
+func trustedHooksEntry(project string, hash string) string {
+	return fmt.Sprintf("%s %s", hash, project)
+}
+
+// Aliases gets the user-defined command aliases by name.
+func Aliases() map[string]string {
+	if err := loadViper(); err != nil {
+		return nil
+	}
+	return viper.GetStringMapString(aliases)
+}
+
+// SetAlias adds the alias for the command or replaces the existing alias with the same name.
+func SetAlias(name string, command string) error {
+	if err := loadViper(); err != nil {
+		return err
+	}
+
+	all := viper.GetStringMapString(aliases)
+	all[strings.ToLower(name)] = command
+	return Set(aliases, all)
+}
+
+// RemoveAlias removes the alias with the name.
+func RemoveAlias(name string) error {
+	if err := loadViper(); err != nil {
+		return err
+	}
+
+	all := viper.GetStringMapString(aliases)
+	if _, ok := all[strings.ToLower(name)]; !ok {
+		return fmt.Errorf("alias %s doesn't exist", name)
+	}
+
+	delete(all, strings.ToLower(name))
+	return Set(aliases, all)
+}
@@ -19,6 +19,7 @@
 package snapshot
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 
@@ -54,7 +55,7 @@ func save(
 		logger.Info(fmt.Sprintf("%s warning: using insecure client connection to download snapshot, you should use a secure network configuration...", output.WarningEmoji()))
 	}
 
-	snapshotBytes, err := flow.Gateway().GetLatestProtocolStateSnapshot()
+	snapshotBytes, err := flow.Gateway().GetLatestProtocolStateSnapshot(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest finalized protocol snapshot from gateway: %w", err)
 	}
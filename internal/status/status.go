@@ -20,11 +20,15 @@ package status
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
+	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
@@ -51,19 +55,51 @@ func status(
 	flow flowkit.Services,
 	_ *flowkit.State,
 ) (command.Result, error) {
-	err := flow.Ping()
-
-	return &result{
+	r := &result{
 		network:    flow.Network().Name,
 		accessNode: flow.Network().Host,
-		err:        err,
-	}, nil
+		err:        flow.Ping(),
+	}
+	if r.err != nil {
+		return r, nil
+	}
+
+	// the details are optional, older access nodes don't support all of them
+	ctx := context.Background()
+	gw := flow.Gateway()
+	if chainID, err := gw.GetNetworkParameters(ctx); err == nil {
+		r.chainID = chainID
+	}
+	if block, err := gw.GetLatestBlock(ctx); err == nil {
+		r.sealedHeight = block.Height
+	}
+	if version, err := gw.GetNodeVersion(ctx); err == nil {
+		r.nodeVersion = version
+	}
+
+	return r, nil
+}
+
+// expectedChains are the chains the default networks are expected to be connected to.
+var expectedChains = map[string][]flowsdk.ChainID{
+	config.EmulatorNetwork.Name: {flowsdk.Emulator, flowsdk.MonotonicEmulator},
+	config.TestnetNetwork.Name:  {flowsdk.Testnet},
+	config.MainnetNetwork.Name:  {flowsdk.Mainnet},
 }
 
 type result struct {
-	network    string
-	accessNode string
-	err        error
+	network      string
+	accessNode   string
+	chainID      flowsdk.ChainID
+	sealedHeight uint64
+	nodeVersion  string
+	err          error
+}
+
+// chainMismatch returns true if the access node is connected to a chain not expected for the network.
+func (r *result) chainMismatch() bool {
+	expected, ok := expectedChains[r.network]
+	return ok && r.chainID != "" && !slices.Contains(expected, r.chainID)
 }
 
 // getStatus returns string representation for Flow network status.
@@ -101,18 +137,38 @@ func (r *result) String() string {
 	_, _ = fmt.Fprintf(writer, "Status:\t %s %s\n", r.getIcon(), r.getColoredStatus())
 	_, _ = fmt.Fprintf(writer, "Network:\t %s\n", r.network)
 	_, _ = fmt.Fprintf(writer, "Access Node:\t %s\n", r.accessNode)
+	if r.err == nil {
+		_, _ = fmt.Fprintf(writer, "Chain ID:\t %s\n", valueOrUnknown(string(r.chainID)))
+		_, _ = fmt.Fprintf(writer, "Sealed Height:\t %d\n", r.sealedHeight)
+		_, _ = fmt.Fprintf(writer, "Node Version:\t %s\n", valueOrUnknown(r.nodeVersion))
+	}
 
 	_ = writer.Flush()
+
+	if r.chainMismatch() {
+		_, _ = fmt.Fprintf(
+			&b,
+			"\n%s The access node is connected to chain %s, which is not the %s chain\n",
+			output.WarningEmoji(),
+			r.chainID,
+			r.network,
+		)
+	}
 	return b.String()
 }
 
 // JSON converts result to a JSON.
 func (r *result) JSON() any {
-	result := make(map[string]string)
+	result := make(map[string]any)
 
 	result["network"] = r.network
 	result["accessNode"] = r.accessNode
 	result["status"] = r.getStatus()
+	if r.err == nil {
+		result["chainId"] = string(r.chainID)
+		result["sealedHeight"] = r.sealedHeight
+		result["nodeVersion"] = r.nodeVersion
+	}
 
 	return result
 }
@@ -121,3 +177,10 @@ func (r *result) JSON() any {
 func (r *result) Oneliner() string {
 	return r.getStatus()
 }
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
@@ -0,0 +1,94 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Status(t *testing.T) {
+	newGateway := func(chainID flow.ChainID) *gateway.Mock {
+		gw := gateway.NewMock()
+		gw.GetNetworkParametersFunc = func(ctx context.Context) (flow.ChainID, error) {
+			return chainID, nil
+		}
+		gw.GetLatestBlockFunc = func(ctx context.Context) (*flow.Block, error) {
+			block := tests.NewBlock()
+			block.Height = 42
+			return block, nil
+		}
+		gw.GetNodeVersionFunc = func(ctx context.Context) (string, error) {
+			return "", errors.New("unimplemented")
+		}
+		return gw
+	}
+
+	t.Run("Online", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Ping.Return(nil)
+		srv.Gateway.Return(newGateway(flow.Emulator))
+
+		res, err := status([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		r := res.(*result)
+		assert.Equal(t, flow.Emulator, r.chainID)
+		assert.Equal(t, uint64(42), r.sealedHeight)
+		assert.Empty(t, r.nodeVersion)
+		assert.False(t, r.chainMismatch())
+		assert.Contains(t, r.String(), "Node Version:")
+		assert.Contains(t, r.String(), "unknown")
+		assert.Equal(t, "flow-emulator", r.JSON().(map[string]any)["chainId"])
+	})
+
+	t.Run("Chain Mismatch", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Ping.Return(nil)
+		srv.Network.Return(config.MainnetNetwork)
+		srv.Gateway.Return(newGateway(flow.Testnet))
+
+		res, err := status([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.True(t, res.(*result).chainMismatch())
+		assert.Contains(t, res.String(), "not the mainnet chain")
+	})
+
+	t.Run("Offline", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Ping.Return(errors.New("unavailable"))
+
+		res, err := status([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "OFFLINE", res.Oneliner())
+		assert.NotContains(t, res.String(), "Chain ID")
+		srv.Mock.AssertNotCalled(t, "Gateway")
+	})
+}
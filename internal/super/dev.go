@@ -19,20 +19,32 @@
 package super
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/onflow/cadence/runtime/parser"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
 )
 
-type flagsDev struct{}
+type flagsDev struct {
+	Persist   string `default:"" flag:"persist" info:"Run an in-process emulator that keeps the chain state in this directory across restarts, instead of connecting to a running emulator"`
+	Reset     bool   `default:"false" flag:"reset" info:"Wipe the chain state persisted by the in-process emulator before starting"`
+	GRPCPort  int    `default:"3569" flag:"grpc-port" info:"Port the in-process emulator gRPC API is exposed on, 0 disables it"`
+	RestPort  int    `default:"8888" flag:"rest-port" info:"Port the in-process emulator REST API is exposed on, 0 disables it"`
+	AdminPort int    `default:"8080" flag:"admin-port" info:"Port the in-process emulator admin API reporting the stats is exposed on, 0 disables it"`
+	BlockTime string `default:"" flag:"block-time" info:"Time between blocks committed by the in-process emulator, e.g. 5s, by default a block is committed for each transaction"`
+	Retention uint64 `default:"0" flag:"retention" info:"Run an in-process emulator keeping only this number of latest blocks in memory, older blocks are pruned with their transactions and events"`
+}
 
 var devFlags = flagsDev{}
 
@@ -41,7 +53,7 @@ var DevCommand = &command.Command{
 		Use:     "dev",
 		Short:   "Build your Flow project",
 		Args:    cobra.ExactArgs(0),
-		Example: "flow dev",
+		Example: "flow dev\nflow dev --persist .flow/emulator --reset\nflow dev --retention 1000",
 		GroupID: "super",
 	},
 	Flags: &devFlags,
@@ -60,6 +72,24 @@ func dev(
 		return nil, err
 	}
 
+	// the path is never taken from the 'dbPath' of the emulator configuration, so the database of the
+	// standalone emulator is not shared with the in-process emulator or wiped by '--reset'
+	if devFlags.Reset && devFlags.Persist == "" {
+		return nil, fmt.Errorf("the '--reset' flag requires the '--persist' flag")
+	}
+	if devFlags.Retention > 0 && devFlags.Persist != "" {
+		return nil, fmt.Errorf("the '--retention' flag only applies to the in-memory emulator and can't be used with the '--persist' flag")
+	}
+	if devFlags.BlockTime != "" && devFlags.Persist == "" && devFlags.Retention == 0 {
+		return nil, fmt.Errorf("the '--block-time' flag requires the '--persist' or '--retention' flag")
+	}
+	if devFlags.Persist != "" || devFlags.Retention > 0 {
+		flow, err = inProcessEmulator(state, logger, devFlags)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = flow.Ping()
 	if err != nil {
 		logger.Error("Error connecting to emulator. Make sure you started an emulator using 'flow emulator' command.")
@@ -74,11 +104,12 @@ func dev(
 
 	flow.SetLogger(output.NewStdoutLogger(output.NoneLog))
 
+	files := newProjectFiles(dir)
 	project, err := newProject(
 		*service,
 		flow,
 		state,
-		newProjectFiles(dir),
+		files,
 	)
 	if err != nil {
 		fmt.Printf("%s Failed to run the command, please make sure you ran 'flow setup' command first and that you are running this command inside the project ROOT folder.\n\n", output.TryEmoji())
@@ -100,10 +131,83 @@ func dev(
 		}
 	}
 
-	err = project.watch()
+	// the configuration is saved after each change, so watching stops without saving when dev is interrupted
+	command.OnShutdown("file watcher", files.close)
+
+	err = project.watch(command.Interrupted())
 	if err != nil {
 		return nil, err
 	}
 
 	return nil, nil
 }
+
+// inProcessEmulator creates services backed by an emulator running in this process with the chain
+// state persisted at the path, optionally wiping any previously persisted state first. Without a path
+// the chain state is kept in memory, limited to the retained number of latest blocks.
+//
+// The emulator is also exposed on the access API ports, so external tools like the dev wallet
+// share the chain state with the deployed project.
+func inProcessEmulator(
+	state *flowkit.State,
+	logger output.Logger,
+	flags flagsDev,
+) (flowkit.Services, error) {
+	if flags.Reset {
+		err := gateway.ResetPersistentStore(flags.Persist)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reset emulator state: %w", err)
+		}
+	}
+
+	opts := []func(*gateway.EmulatorGateway){gateway.WithRetention(flags.Retention)}
+	if flags.Persist != "" {
+		store, err := gateway.WithPersistentStore(flags.Persist)
+		if err != nil {
+			return nil, err
+		}
+		opts = []func(*gateway.EmulatorGateway){store}
+	}
+	if flags.BlockTime != "" {
+		blockTime, err := time.ParseDuration(flags.BlockTime)
+		if err != nil || blockTime <= 0 {
+			return nil, fmt.Errorf("invalid --block-time %s, provide a positive duration such as 5s", flags.BlockTime)
+		}
+		opts = append(opts, gateway.WithBlockTime(blockTime))
+	}
+
+	service, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := service.Key.PrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("the in-process emulator requires a service account with a private key: %w", err)
+	}
+
+	gw := gateway.NewEmulatorGatewayWithOpts(&gateway.EmulatorKey{
+		PublicKey: (*privateKey).PublicKey(),
+		SigAlgo:   service.Key.SigAlgo(),
+		HashAlgo:  service.Key.HashAlgo(),
+	}, opts...)
+	command.OnShutdown("emulator store", gw.Close)
+
+	if flags.GRPCPort != 0 || flags.RestPort != 0 || flags.AdminPort != 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			err := gw.Serve(ctx, flags.GRPCPort, flags.RestPort, flags.AdminPort)
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to expose the emulator: %s", err))
+			}
+		}()
+		command.OnShutdown("emulator server", func() error {
+			cancel()
+			<-done
+			return nil
+		})
+	}
+
+	return flowkit.NewFlowkit(state, config.EmulatorNetwork, gw, logger), nil
+}
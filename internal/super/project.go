@@ -20,6 +20,8 @@ package super
 
 import (
 	"context"
+	"path/filepath"
+	"strings"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -146,8 +148,8 @@ func (p *project) cleanState() {
 	}
 }
 
-// watch project files and update the state accordingly.
-func (p *project) watch() error {
+// watch project files and update the state accordingly until the context is cancelled.
+func (p *project) watch(ctx context.Context) error {
 	accountChanges, contractChanges, err := p.projectFiles.watch()
 	if err != nil {
 		return errors.Wrap(err, "error watching files")
@@ -155,6 +157,8 @@ func (p *project) watch() error {
 
 	for {
 		select {
+		case <-ctx.Done():
+			return nil
 		case account := <-accountChanges:
 			if account.status == created {
 				err = p.addAccount(account.name)
@@ -282,7 +286,7 @@ func (p *project) addContract(
 
 	contract := config.Contract{
 		Name:     name,
-		Location: path,
+		Location: p.configLocation(path),
 	}
 
 	existing, _ := p.state.Contracts().ByName(name)
@@ -338,10 +342,26 @@ func (p *project) removeContract(
 
 // renameContract and update the location in the state
 func (p *project) renameContract(oldLocation string, newLocation string) {
+	oldLocation = p.configLocation(oldLocation)
 	for _, c := range *p.state.Contracts() {
 		if c.Location == oldLocation {
-			c.Location = newLocation
+			c.Location = p.configLocation(newLocation)
 			p.state.Contracts().AddOrUpdate(c)
 		}
 	}
 }
+
+// configLocation returns the location of the file as defined in the configuration, which is relative to
+// the source root if the configuration defines one and the file is inside it.
+func (p *project) configLocation(path string) string {
+	root := p.state.Config().SourceRoot
+	if root == "" {
+		return path
+	}
+
+	relative, err := filepath.Rel(root, path)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(relative)
+}
@@ -25,12 +25,12 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"path"
 	"path/filepath"
 	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
@@ -112,6 +112,11 @@ func create(
 	if err != nil {
 		return nil, fmt.Errorf("failed creating scaffold %w", err)
 	}
+
+	err = lockScaffold(&afero.Afero{Fs: afero.NewOsFs()}, targetDir, pickedScaffold)
+	if err != nil {
+		return nil, fmt.Errorf("failed locking scaffold version: %w", err)
+	}
 	logger.StopProgress()
 
 	return &setupResult{targetDir: targetDir}, nil
@@ -123,7 +128,7 @@ func getTargetDirectory(directory string) (string, error) {
 		return "", err
 	}
 
-	target := path.Join(pwd, directory)
+	target := filepath.Join(pwd, directory)
 	info, err := os.Stat(target)
 	if !os.IsNotExist(err) {
 		if !info.IsDir() {
@@ -222,6 +227,25 @@ func cloneScaffold(targetDir string, conf scaffold) error {
 	return os.RemoveAll(filepath.Join(targetDir, ".git"))
 }
 
+// lockScaffold records the scaffold repository and commit the project was created from in the lockfile.
+func lockScaffold(readerWriter flowkit.ReaderWriter, targetDir string, conf scaffold) error {
+	lockPath := filepath.Join(targetDir, flowkit.LockfileName)
+	lock, err := flowkit.LoadLockfile(readerWriter, lockPath)
+	if err != nil {
+		return err
+	}
+
+	if lock.Templates == nil {
+		lock.Templates = make(map[string]flowkit.LockedTemplate)
+	}
+	lock.Templates[conf.Name] = flowkit.LockedTemplate{
+		Repo:   conf.Repo,
+		Commit: conf.Commit,
+	}
+
+	return lock.Save(readerWriter, lockPath)
+}
+
 type setupResult struct {
 	targetDir string
 }
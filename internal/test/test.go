@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	cdcTests "github.com/onflow/cadence-tools/test"
@@ -34,6 +36,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -42,6 +45,9 @@ import (
 // are considered to be helper/utility scripts for test files.
 const helperScriptSubstr = "_helper"
 
+// progressTest is the operation of the progress events reported while running the test files.
+const progressTest = "test"
+
 type flagsTests struct {
 	Cover        bool   `default:"false" flag:"cover" info:"Use the cover flag to calculate coverage report"`
 	CoverProfile string `default:"coverage.json" flag:"coverprofile" info:"Filename to write the calculated coverage report"`
@@ -67,7 +73,7 @@ var TestCommand = &command.Command{
 func run(
 	args []string,
 	_ command.GlobalFlags,
-	logger output.Logger,
+	_ output.Logger,
 	_ flowkit.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
@@ -95,7 +101,7 @@ func run(
 		var file []byte
 		var err error
 
-		ext := path.Ext(testFlags.CoverProfile)
+		ext := filepath.Ext(testFlags.CoverProfile)
 		if ext == ".json" {
 			file, err = json.MarshalIndent(coverageReport, "", "  ")
 		} else if ext == ".lcov" {
@@ -120,6 +126,10 @@ func run(
 	}, nil
 }
 
+// testCode runs the tests of each test file and returns the results by file.
+//
+// Test files are isolated from each other without snapshots, since the test runner creates a new emulator backend
+// for every test file it runs, so the state changed by a test file is never visible to the next one.
 func testCode(
 	testFiles map[string][]byte,
 	state *flowkit.State,
@@ -132,23 +142,70 @@ func testCode(
 		runner = runner.WithCoverageReport(coverageReport)
 	}
 
+	// test files are run in order, so the progress is reported in the same order on each run
+	scriptPaths := make([]string, 0, len(testFiles))
+	for scriptPath := range testFiles {
+		scriptPaths = append(scriptPaths, scriptPath)
+	}
+	sort.Strings(scriptPaths)
+
+	output.ReportProgress(output.ProgressEvent{
+		Operation: progressTest,
+		Status:    output.ProgressStarted,
+		Total:     uint64(len(scriptPaths)),
+	})
+
 	testResults := make(map[string]cdcTests.Results, 0)
-	for scriptPath, code := range testFiles {
+	failed := 0
+	for i, scriptPath := range scriptPaths {
 		runner := runner.
 			WithImportResolver(importResolver(scriptPath, state)).
 			WithFileResolver(fileResolver(scriptPath, state))
-		results, err := runner.RunTests(string(code))
+		results, err := runner.RunTests(string(testFiles[scriptPath]))
 		if err != nil {
+			output.ReportProgress(output.ProgressEvent{
+				Operation: progressTest,
+				Status:    output.ProgressFailed,
+				Step:      scriptPath,
+				Current:   uint64(i),
+				Total:     uint64(len(scriptPaths)),
+				Message:   err.Error(),
+			})
 			return nil, nil, err
 		}
 		testResults[scriptPath] = results
+
+		fileFailed := 0
 		for _, result := range results {
 			if result.Error != nil {
 				status = 1
-				break
+				fileFailed++
 			}
 		}
+		failed += fileFailed
+
+		output.ReportProgress(output.ProgressEvent{
+			Operation: progressTest,
+			Status:    output.ProgressUpdated,
+			Step:      scriptPath,
+			Current:   uint64(i + 1),
+			Total:     uint64(len(scriptPaths)),
+			Message:   fmt.Sprintf("%d passed, %d failed", len(results)-fileFailed, fileFailed),
+		})
+	}
+
+	finished := output.ProgressEvent{
+		Operation: progressTest,
+		Status:    output.ProgressCompleted,
+		Current:   uint64(len(scriptPaths)),
+		Total:     uint64(len(scriptPaths)),
 	}
+	if failed > 0 {
+		finished.Status = output.ProgressFailed
+		finished.Message = fmt.Sprintf("%d tests failed", failed)
+	}
+	output.ReportProgress(finished)
+
 	return testResults, coverageReport, nil
 }
 
@@ -183,7 +240,7 @@ func importResolver(scriptPath string, state *flowkit.State) cdcTests.ImportReso
 			)
 		}
 
-		contractCode, err := state.ReadFile(contract.Location)
+		contractCode, err := state.ReadFile(state.Config().SourceLocation(contract.Location))
 		if err != nil {
 			return "", err
 		}
@@ -206,11 +263,11 @@ func fileResolver(scriptPath string, state *flowkit.State) cdcTests.FileResolver
 }
 
 func absolutePath(basePath, filePath string) string {
-	if path.IsAbs(filePath) {
+	if filepath.IsAbs(filePath) {
 		return filePath
 	}
 
-	return path.Join(path.Dir(basePath), filePath)
+	return path.Join(path.Dir(project.CleanLocation(basePath)), project.CleanLocation(filePath))
 }
 
 type result struct {
@@ -171,6 +171,41 @@ func TestExecutingTests(t *testing.T) {
 		)
 	})
 
+	t.Run("isolated test files", func(t *testing.T) {
+		t.Parallel()
+		_, state, _ := util.TestMocks(t)
+
+		// the first file changes the state and the second file checks it starts from a new state
+		testFiles := map[string][]byte{
+			"a_test.cdc": []byte(`
+				import Test
+
+				pub fun testCreateAccounts() {
+					let blockchain = Test.newEmulatorBlockchain()
+					let first = blockchain.createAccount()
+					let second = blockchain.createAccount()
+					assert(first.address == 0x01cf0e2f2f715450)
+					assert(second.address != first.address)
+				}
+			`),
+			"b_test.cdc": []byte(`
+				import Test
+
+				pub fun testNewState() {
+					let blockchain = Test.newEmulatorBlockchain()
+					let account = blockchain.createAccount()
+					assert(account.address == 0x01cf0e2f2f715450)
+				}
+			`),
+		}
+		results, _, err := testCode(testFiles, state, false)
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.NoError(t, results["a_test.cdc"][0].Error)
+		assert.NoError(t, results["b_test.cdc"][0].Error)
+	})
+
 	t.Run("with file read", func(t *testing.T) {
 		t.Parallel()
 		_, state, rw := util.TestMocks(t)
@@ -0,0 +1,418 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsLoadTest struct {
+	Tx       string   `default:"" flag:"tx" info:"Transaction code filename sent by the load test"`
+	ArgsJSON string   `default:"" flag:"args-json" info:"Transaction arguments in JSON-Cadence format"`
+	TPS      int      `default:"10" flag:"tps" info:"Number of transactions sent per second"`
+	Duration string   `default:"60s" flag:"duration" info:"Duration of the load test, e.g. 30s or 5m"`
+	Signers  []string `default:"" flag:"signers" info:"Comma-separated account names from configuration signing the transactions, defaults to the emulator service account"`
+	GasLimit uint64   `default:"1000" flag:"gas-limit" info:"Transaction gas limit"`
+}
+
+var loadTestFlags = flagsLoadTest{}
+
+var loadTestCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "load-test",
+		Short: "Send a sustained load of transactions and report the latency and failures",
+		Long: `Send the transaction at the provided rate for the duration of the load test and report the latency
+percentiles and failure causes, to size the gas limits and find the hot paths of the contracts.
+
+Each signer is the proposer, payer and authorizer of the transactions it signs. A proposal key can only
+be used by one transaction at a time, so all the account keys matching the configured key of a signer
+are pooled, and the transactions that can't get a free key are reported as skipped. Add keys to the
+signer accounts to reach higher rates.
+
+Sending the load test to a network other than the emulator must be confirmed.`,
+		Example: "flow util load-test --tx tx.cdc --tps 20 --duration 60s --signers alice,bob",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &loadTestFlags,
+	RunS:  loadTest,
+}
+
+func loadTest(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if loadTestFlags.Tx == "" {
+		return nil, fmt.Errorf("the --tx flag is required")
+	}
+	if loadTestFlags.TPS <= 0 {
+		return nil, fmt.Errorf("invalid --tps %d, provide a positive number of transactions per second", loadTestFlags.TPS)
+	}
+
+	duration, err := time.ParseDuration(loadTestFlags.Duration)
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("invalid --duration %s, provide a positive duration such as 60s", loadTestFlags.Duration)
+	}
+
+	code, err := state.ReadFile(loadTestFlags.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	var txArgs []cadence.Value
+	if loadTestFlags.ArgsJSON != "" {
+		txArgs, err = arguments.ParseJSON(loadTestFlags.ArgsJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+		}
+	}
+
+	signers := loadTestFlags.Signers
+	if len(signers) == 0 {
+		signers = []string{state.Config().Emulators.Default().ServiceAccount}
+	}
+
+	keys, err := proposalKeys(context.Background(), flow, state, signers)
+	if err != nil {
+		return nil, err
+	}
+
+	count := int(duration.Seconds() * float64(loadTestFlags.TPS))
+	network := flow.Network().Name
+	if network != config.EmulatorNetwork.Name && !globalFlags.Yes && !util.ConfirmLoadTestPrompt(count, network) {
+		return nil, fmt.Errorf("load test canceled")
+	}
+
+	logger.Info(fmt.Sprintf(
+		"Sending %d transactions per second to %s for %s using %d proposal keys...",
+		loadTestFlags.TPS, network, duration, len(keys),
+	))
+
+	// the progress of each transaction would flood the output
+	flow.SetLogger(output.NewStdoutLogger(output.NoneLog))
+	defer flow.SetLogger(logger)
+
+	script := flowkit.Script{Code: code, Args: txArgs, Location: loadTestFlags.Tx}
+	run := runLoad(flow, script, keys, loadTestFlags.TPS, duration, loadTestFlags.GasLimit)
+	run.network = network
+
+	return run, nil
+}
+
+// proposalKeys returns an account for each key of the signers that can be used as a proposal key.
+//
+// The keys on the network matching the configured key of the signer are pooled, so the signer
+// can propose a transaction with each of them at the same time.
+func proposalKeys(
+	ctx context.Context,
+	flow flowkit.Services,
+	state *flowkit.State,
+	signers []string,
+) ([]*accounts.Account, error) {
+	keys := make([]*accounts.Account, 0)
+	for _, name := range signers {
+		signer, err := state.Accounts().ByName(strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", name)
+		}
+
+		// keys without a private key, such as KMS keys, can only use the configured key index
+		privateKey, err := signer.Key.PrivateKey()
+		if err != nil {
+			keys = append(keys, signer)
+			continue
+		}
+
+		account, err := flow.GetAccount(ctx, signer.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get signer account %s: %w", signer.Name, err)
+		}
+
+		found := 0
+		for _, key := range account.Keys {
+			if key.Revoked || key.Weight < flowsdk.AccountKeyWeightThreshold || !key.PublicKey.Equals((*privateKey).PublicKey()) {
+				continue
+			}
+
+			keyConf := signer.Key.ToConfig()
+			keyConf.Index = key.Index
+			pooled, err := accounts.KeyFromConfig(keyConf)
+			if err != nil {
+				return nil, err
+			}
+
+			keys = append(keys, &accounts.Account{Name: signer.Name, Address: signer.Address, Key: pooled})
+			found++
+		}
+
+		if found == 0 {
+			return nil, fmt.Errorf("signer account %s has no key on the network matching the configured key", signer.Name)
+		}
+	}
+
+	return keys, nil
+}
+
+// runLoad sends the transaction at the rate until the duration passes, and waits for the sent transactions to complete.
+func runLoad(
+	flow flowkit.Services,
+	script flowkit.Script,
+	keys []*accounts.Account,
+	tps int,
+	duration time.Duration,
+	gasLimit uint64,
+) *loadTestResult {
+	pool := make(chan *accounts.Account, len(keys))
+	for _, key := range keys {
+		pool <- key
+	}
+
+	result := &loadTestResult{
+		tps:    tps,
+		keys:   len(keys),
+		causes: make(map[string]int),
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	send := func(key *accounts.Account) {
+		defer wg.Done()
+		defer func() { pool <- key }()
+
+		roles := transactions.AccountRoles{
+			Proposer:    *key,
+			Authorizers: []accounts.Account{*key},
+			Payer:       *key,
+		}
+
+		start := time.Now()
+		_, txResult, err := flow.SendTransaction(context.Background(), roles, script, gasLimit)
+		latency := time.Since(start)
+
+		if err == nil && txResult != nil && txResult.Error != nil {
+			err = txResult.Error
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		result.sent++
+		result.latencies = append(result.latencies, latency)
+		if err != nil {
+			result.causes[failureCause(err)]++
+		}
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(time.Second / time.Duration(tps))
+	defer ticker.Stop()
+	deadline := time.After(duration)
+
+load:
+	for {
+		select {
+		case <-deadline:
+			break load
+		case <-ticker.C:
+			select {
+			case key := <-pool:
+				wg.Add(1)
+				go send(key)
+			default:
+				mu.Lock()
+				result.skipped++
+				mu.Unlock()
+			}
+		}
+	}
+
+	wg.Wait()
+	result.elapsed = time.Since(start)
+
+	return result
+}
+
+// failureCause returns the first line of the error, which describes the cause without the details of the failure.
+func failureCause(err error) string {
+	cause := strings.TrimSpace(strings.SplitN(err.Error(), "\n", 2)[0])
+	const maxLength = 120
+	if len(cause) > maxLength {
+		cause = cause[:maxLength] + "..."
+	}
+	return cause
+}
+
+// percentile returns the latency below which the percent of the sorted latencies fall, using the nearest rank.
+func percentile(sorted []time.Duration, percent float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(percent / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+type loadTestResult struct {
+	network   string
+	tps       int
+	keys      int
+	elapsed   time.Duration
+	sent      int
+	skipped   int
+	latencies []time.Duration
+	causes    map[string]int
+}
+
+type failure struct {
+	cause string
+	count int
+}
+
+func (r *loadTestResult) failed() int {
+	failed := 0
+	for _, count := range r.causes {
+		failed += count
+	}
+	return failed
+}
+
+// failures are sorted by the number of failed transactions.
+func (r *loadTestResult) failures() []failure {
+	failures := make([]failure, 0, len(r.causes))
+	for cause, count := range r.causes {
+		failures = append(failures, failure{cause: cause, count: count})
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].count != failures[j].count {
+			return failures[i].count > failures[j].count
+		}
+		return failures[i].cause < failures[j].cause
+	})
+	return failures
+}
+
+func (r *loadTestResult) sortedLatencies() []time.Duration {
+	sorted := append([]time.Duration{}, r.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+func (r *loadTestResult) achievedTPS() float64 {
+	if r.elapsed <= 0 {
+		return 0
+	}
+	return float64(r.sent-r.failed()) / r.elapsed.Seconds()
+}
+
+func (r *loadTestResult) JSON() any {
+	sorted := r.sortedLatencies()
+
+	failures := make([]map[string]any, 0)
+	for _, f := range r.failures() {
+		failures = append(failures, map[string]any{"cause": f.cause, "count": f.count})
+	}
+
+	return map[string]any{
+		"network":     r.network,
+		"tps":         r.tps,
+		"achievedTps": r.achievedTPS(),
+		"keys":        r.keys,
+		"elapsedMs":   r.elapsed.Milliseconds(),
+		"sent":        r.sent,
+		"succeeded":   r.sent - r.failed(),
+		"failed":      r.failed(),
+		"skipped":     r.skipped,
+		"latencyMs": map[string]int64{
+			"p50": percentile(sorted, 50).Milliseconds(),
+			"p90": percentile(sorted, 90).Milliseconds(),
+			"p99": percentile(sorted, 99).Milliseconds(),
+			"max": percentile(sorted, 100).Milliseconds(),
+		},
+		"failures": failures,
+	}
+}
+
+func (r *loadTestResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+	sorted := r.sortedLatencies()
+
+	_, _ = fmt.Fprintf(writer, "Network\t%s\n", r.network)
+	_, _ = fmt.Fprintf(writer, "Duration\t%s\n", r.elapsed.Round(time.Millisecond))
+	_, _ = fmt.Fprintf(writer, "Proposal Keys\t%d\n", r.keys)
+	_, _ = fmt.Fprintf(writer, "Target TPS\t%d\n", r.tps)
+	_, _ = fmt.Fprintf(writer, "Achieved TPS\t%.2f\n", r.achievedTPS())
+	_, _ = fmt.Fprintf(writer, "Sent\t%d\n", r.sent)
+	_, _ = fmt.Fprintf(writer, "Succeeded\t%d\n", r.sent-r.failed())
+	_, _ = fmt.Fprintf(writer, "Failed\t%d\n", r.failed())
+	_, _ = fmt.Fprintf(writer, "Skipped\t%d\n", r.skipped)
+	_, _ = fmt.Fprintf(writer, "Latency p50\t%s\n", percentile(sorted, 50).Round(time.Millisecond))
+	_, _ = fmt.Fprintf(writer, "Latency p90\t%s\n", percentile(sorted, 90).Round(time.Millisecond))
+	_, _ = fmt.Fprintf(writer, "Latency p99\t%s\n", percentile(sorted, 99).Round(time.Millisecond))
+	_, _ = fmt.Fprintf(writer, "Latency max\t%s\n", percentile(sorted, 100).Round(time.Millisecond))
+
+	if failures := r.failures(); len(failures) > 0 {
+		_, _ = fmt.Fprintf(writer, "\nFailures\n")
+		for _, f := range failures {
+			_, _ = fmt.Fprintf(writer, "  %d\t%s\n", f.count, f.cause)
+		}
+	}
+
+	if r.skipped > 0 {
+		_, _ = fmt.Fprintf(writer, "\n%d transactions were skipped because no proposal key was free, add keys to the signers to reach the target rate.\n", r.skipped)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *loadTestResult) Oneliner() string {
+	sorted := r.sortedLatencies()
+	return fmt.Sprintf(
+		"sent %d, failed %d, skipped %d, p50 %s, p99 %s",
+		r.sent,
+		r.failed(),
+		r.skipped,
+		percentile(sorted, 50).Round(time.Millisecond),
+		percentile(sorted, 99).Round(time.Millisecond),
+	)
+}
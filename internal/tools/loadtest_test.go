@@ -0,0 +1,127 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tools
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_LoadTest(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	require.NoError(t, rw.WriteFile("tx.cdc", []byte("transaction {}"), 0644))
+	srv.Network.Return(config.EmulatorNetwork)
+	srv.Mock.On("SetLogger", mock.Anything).Return()
+
+	signer, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	privateKey, err := signer.Key.PrivateKey()
+	require.NoError(t, err)
+
+	// the signer key is added twice, the third key is a different key that can't be used
+	otherKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte("otherseedotherseedotherseedotherseedother"))
+	require.NoError(t, err)
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(signer.Address.String())
+		account.Keys = []*flow.AccountKey{
+			{Index: 0, PublicKey: (*privateKey).PublicKey(), Weight: flow.AccountKeyWeightThreshold},
+			{Index: 1, PublicKey: (*privateKey).PublicKey(), Weight: flow.AccountKeyWeightThreshold},
+			{Index: 2, PublicKey: otherKey.PublicKey(), Weight: flow.AccountKeyWeightThreshold},
+		}
+		srv.GetAccount.Return(account, nil)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		loadTestFlags = flagsLoadTest{Tx: "tx.cdc", TPS: 100, Duration: "200ms", GasLimit: 1000}
+
+		var mu sync.Mutex
+		indexes := make(map[int]bool)
+		sent := 0
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			mu.Lock()
+			indexes[roles.Proposer.Key.Index()] = true
+			sent++
+			failed := sent%2 == 0
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+			txResult := tests.NewTransactionResult(nil)
+			if failed {
+				txResult.Error = fmt.Errorf("[Error Code: 1110] computation exceeds limit (1000)\nmore details")
+			}
+			srv.SendTransaction.Return(tests.NewTransaction(), txResult, nil)
+		})
+
+		result, err := loadTest(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		res := result.(*loadTestResult)
+		assert.Equal(t, 2, res.keys)
+		assert.Greater(t, res.sent, 0)
+		assert.Equal(t, res.sent, len(res.latencies))
+		assert.Equal(t, map[int]bool{0: true, 1: true}, indexes)
+		assert.Equal(t, res.sent/2, res.causes["[Error Code: 1110] computation exceeds limit (1000)"])
+		assert.Contains(t, res.String(), "Latency p99")
+	})
+
+	t.Run("Fail no matching key", func(t *testing.T) {
+		loadTestFlags = flagsLoadTest{Tx: "tx.cdc", TPS: 10, Duration: "1s", Signers: []string{"emulator-account"}}
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			account := tests.NewAccountWithAddress(signer.Address.String())
+			account.Keys = []*flow.AccountKey{{Index: 0, PublicKey: otherKey.PublicKey(), Weight: flow.AccountKeyWeightThreshold}}
+			srv.GetAccount.Return(account, nil)
+		})
+
+		_, err := loadTest(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "signer account emulator-account has no key on the network matching the configured key")
+	})
+
+	t.Run("Fail invalid flags", func(t *testing.T) {
+		loadTestFlags = flagsLoadTest{Tx: "tx.cdc", TPS: 0, Duration: "1s"}
+		_, err := loadTest(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid --tps 0, provide a positive number of transactions per second")
+
+		loadTestFlags = flagsLoadTest{Tx: "tx.cdc", TPS: 10, Duration: "soon"}
+		_, err = loadTest(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid --duration soon, provide a positive duration such as 60s")
+	})
+}
+
+func Test_Percentile(t *testing.T) {
+	sorted := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, time.Duration(5), percentile(sorted, 50))
+	assert.Equal(t, time.Duration(9), percentile(sorted, 90))
+	assert.Equal(t, time.Duration(10), percentile(sorted, 99))
+	assert.Equal(t, time.Duration(0), percentile(nil, 99))
+}
@@ -41,6 +41,7 @@ type flagsBuild struct {
 	Payer            string   `default:"emulator-account" flag:"payer" info:"transaction payer"`
 	Authorizer       []string `default:"emulator-account" flag:"authorizer" info:"transaction authorizer"`
 	GasLimit         uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	SecretArgs       []string `default:"" flag:"secret-arg" info:"Name or index of a transaction argument that should be redacted from the output"`
 }
 
 var buildFlags = flagsBuild{}
@@ -99,6 +100,11 @@ func build(
 		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
 	}
 
+	secrets, err := parseSecrets(buildFlags.SecretArgs, code, state)
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := flow.BuildTransaction(
 		context.Background(),
 		transactions.AddressesRoles{
@@ -118,13 +124,14 @@ func build(
 		return nil, err
 	}
 
-	if !globalFlags.Yes && !util.ApproveTransactionForBuildingPrompt(tx.FlowTransaction()) {
+	if !globalFlags.Yes && !util.ApproveTransactionForBuildingPrompt(tx.FlowTransaction(), secrets) {
 		return nil, fmt.Errorf("transaction was not approved")
 	}
 
 	return &transactionResult{
 		tx:      tx.FlowTransaction(),
 		include: []string{"code", "payload", "signatures"},
+		secrets: secrets,
 	}, nil
 }
 
@@ -0,0 +1,113 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"context"
+	"sort"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+)
+
+// feeSummary of the transaction costs inferred from the transaction events.
+type feeSummary struct {
+	fee          *cadence.UFix64
+	deltas       map[flow.Address]cadence.Fix64
+	balanceAfter *cadence.UFix64
+}
+
+// fees computes the fee summary from the transaction result events, if the payer balance
+// after the transaction is known, the balance before is inferred using the payer delta.
+func (r *transactionResult) fees() *feeSummary {
+	if r.result == nil {
+		return nil
+	}
+
+	events := flowkit.EventsFromTransaction(r.result)
+	summary := &feeSummary{
+		deltas: events.GetFlowBalanceDeltas(),
+	}
+	if fee, ok := events.GetFeesDeducted(); ok {
+		summary.fee = &fee
+	}
+	if r.payerBalance != nil {
+		after := cadence.UFix64(*r.payerBalance)
+		summary.balanceAfter = &after
+	}
+
+	return summary
+}
+
+// balanceBefore returns the payer balance before the transaction was executed.
+func (f *feeSummary) balanceBefore(payer flow.Address) cadence.Fix64 {
+	return cadence.Fix64(*f.balanceAfter) - f.deltas[payer]
+}
+
+// sortedAddresses returns addresses with balance changes in deterministic order.
+func (f *feeSummary) sortedAddresses() []flow.Address {
+	addresses := make([]flow.Address, 0, len(f.deltas))
+	for address := range f.deltas {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+	return addresses
+}
+
+// JSON converts the fee summary to a JSON friendly map.
+func (f *feeSummary) JSON(payer flow.Address) map[string]any {
+	result := make(map[string]any)
+	if f.fee != nil {
+		result["fee"] = f.fee.String()
+	}
+	if f.balanceAfter != nil {
+		result["payer_balance_before"] = f.balanceBefore(payer).String()
+		result["payer_balance_after"] = f.balanceAfter.String()
+	}
+
+	deltas := make(map[string]string, len(f.deltas))
+	for address, delta := range f.deltas {
+		deltas[address.Hex()] = delta.String()
+	}
+	result["balance_changes"] = deltas
+
+	return result
+}
+
+// empty returns true if the transaction didn't produce any fee or balance related events.
+func (f *feeSummary) empty() bool {
+	return f.fee == nil && len(f.deltas) == 0
+}
+
+// payerBalance fetches the current payer balance, used to show the balance change caused by the transaction.
+func payerBalance(services flowkit.Services, tx *flow.Transaction) *uint64 {
+	if tx == nil {
+		return nil
+	}
+
+	account, err := services.GetAccount(context.Background(), tx.Payer)
+	if err != nil || account == nil {
+		return nil
+	}
+	return &account.Balance
+}
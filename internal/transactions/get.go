@@ -28,6 +28,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/events"
 )
 
 type flagsGet struct {
@@ -69,5 +70,6 @@ func get(
 		tx:      tx,
 		include: getFlags.Include,
 		exclude: getFlags.Exclude,
+		chainID: events.ChainID(flow),
 	}, nil
 }
@@ -0,0 +1,234 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsRecent struct {
+	Blocks uint64 `default:"50" flag:"blocks" info:"Number of latest blocks to scan"`
+	Top    int    `default:"5" flag:"top" info:"Number of top payers and contracts to show"`
+}
+
+var recentFlags = flagsRecent{}
+
+var recentCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "recent",
+		Short:   "Summarize transactions in the latest blocks",
+		Example: "flow transactions recent --blocks 50",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &recentFlags,
+	Run:   recent,
+}
+
+func recent(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if recentFlags.Blocks == 0 {
+		return nil, fmt.Errorf("number of blocks must be greater than zero")
+	}
+
+	ctx := context.Background()
+	latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	start := uint64(0)
+	if latest.Height+1 > recentFlags.Blocks {
+		start = latest.Height + 1 - recentFlags.Blocks
+	}
+
+	logger.StartProgress(fmt.Sprintf("Scanning blocks %d - %d...", start, latest.Height))
+	defer logger.StopProgress()
+
+	summary := newRecentSummary(start, latest.Height, recentFlags.Top)
+	iterator := flowkit.BlockRangeIterator(flow.Gateway(), start, latest.Height, flowkit.BlockRangeOptions{BatchSize: 1})
+	err = iterator.ForEach(ctx, func(ctx context.Context, _ gateway.Gateway, batch flowkit.BlockRange) error {
+		block := latest
+		if batch.Start != latest.Height {
+			var err error
+			block, err = flow.GetBlock(ctx, flowkit.BlockQuery{Height: batch.Start})
+			if err != nil {
+				return err
+			}
+		}
+
+		txs, results, err := flow.GetTransactionsByBlockID(ctx, block.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get transactions for block %d: %w", batch.Start, err)
+		}
+		summary.add(txs, results)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// recentSummary aggregates transactions from a range of blocks.
+type recentSummary struct {
+	startHeight uint64
+	endHeight   uint64
+	top         int
+	count       int
+	failed      int
+	statuses    map[string]int
+	payers      map[string]int
+	contracts   map[string]int
+}
+
+func newRecentSummary(start uint64, end uint64, top int) *recentSummary {
+	return &recentSummary{
+		startHeight: start,
+		endHeight:   end,
+		top:         top,
+		statuses:    make(map[string]int),
+		payers:      make(map[string]int),
+		contracts:   make(map[string]int),
+	}
+}
+
+// add includes the transactions of a block in the summary, results are matched to transactions by position.
+func (r *recentSummary) add(txs []*flow.Transaction, results []*flow.TransactionResult) {
+	for i, tx := range txs {
+		r.count++
+		r.payers[tx.Payer.Hex()]++
+
+		if i >= len(results) || results[i] == nil {
+			r.statuses[flow.TransactionStatusUnknown.String()]++
+			continue
+		}
+
+		result := results[i]
+		r.statuses[result.Status.String()]++
+		if result.Error != nil {
+			r.failed++
+		}
+
+		// count each contract once per transaction
+		touched := make(map[string]bool)
+		for _, event := range result.Events {
+			if contract, ok := eventContract(event.Type); ok && !touched[contract] {
+				touched[contract] = true
+				r.contracts[contract]++
+			}
+		}
+	}
+}
+
+// eventContract returns the contract identifier (A.address.Name) that emitted the event type.
+func eventContract(eventType string) (string, bool) {
+	parts := strings.Split(eventType, ".")
+	if len(parts) != 4 || parts[0] != "A" {
+		return "", false
+	}
+	return strings.Join(parts[:3], "."), true
+}
+
+type rankedCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// ranked sorts the counts descending, ties are ordered by name, and returns at most limit entries.
+func ranked(counts map[string]int, limit int) []rankedCount {
+	ranks := make([]rankedCount, 0, len(counts))
+	for name, count := range counts {
+		ranks = append(ranks, rankedCount{Name: name, Count: count})
+	}
+	sort.Slice(ranks, func(i, j int) bool {
+		if ranks[i].Count != ranks[j].Count {
+			return ranks[i].Count > ranks[j].Count
+		}
+		return ranks[i].Name < ranks[j].Name
+	})
+	if limit >= 0 && len(ranks) > limit {
+		ranks = ranks[:limit]
+	}
+	return ranks
+}
+
+func (r *recentSummary) JSON() any {
+	return map[string]any{
+		"startHeight":  r.startHeight,
+		"endHeight":    r.endHeight,
+		"transactions": r.count,
+		"failed":       r.failed,
+		"statuses":     r.statuses,
+		"topPayers":    ranked(r.payers, r.top),
+		"topContracts": ranked(r.contracts, r.top),
+	}
+}
+
+func (r *recentSummary) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Blocks\t%d - %d\n", r.startHeight, r.endHeight)
+	_, _ = fmt.Fprintf(writer, "Transactions\t%d\n", r.count)
+	_, _ = fmt.Fprintf(writer, "Failed\t%d\n", r.failed)
+
+	_, _ = fmt.Fprintf(writer, "\nStatus\tCount\n")
+	for _, status := range ranked(r.statuses, -1) {
+		_, _ = fmt.Fprintf(writer, "%s\t%d\n", status.Name, status.Count)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nTop Payers\tTransactions\n")
+	for _, payer := range ranked(r.payers, r.top) {
+		_, _ = fmt.Fprintf(writer, "0x%s\t%d\n", payer.Name, payer.Count)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nTop Contracts\tTransactions\n")
+	for _, contract := range ranked(r.contracts, r.top) {
+		_, _ = fmt.Fprintf(writer, "%s\t%d\n", contract.Name, contract.Count)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *recentSummary) Oneliner() string {
+	return fmt.Sprintf(
+		"Blocks: %d - %d, Transactions: %d, Failed: %d",
+		r.startHeight, r.endHeight, r.count, r.failed,
+	)
+}
@@ -0,0 +1,237 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-emulator/emulator"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsReplay struct{}
+
+var replayFlags = flagsReplay{}
+
+var replayCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "replay <tx_id>",
+		Short: "Execute a past transaction on a local fork of the network and compare the results",
+		Long: `Execute a past transaction on a local emulator forking the network at the block before the transaction,
+and compare the replayed status, error and events with the original execution.
+
+The fork reads the network state from the archive node, so only mainnet and testnet transactions can be replayed.
+The state is forked at the start of the block, so changes made by the transactions before it in the same block
+are not included.`,
+		Example: "flow transactions replay 07a8...b433 --network mainnet",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &replayFlags,
+	Run:   replay,
+}
+
+// forkGateway creates a local emulator forking the network with the chain ID at the block height.
+var forkGateway = func(chainID flowsdk.ChainID, height uint64) (gateway.Gateway, error) {
+	if chainID != flowsdk.Mainnet && chainID != flowsdk.Testnet {
+		return nil, fmt.Errorf("only mainnet and testnet transactions can be replayed, the network chain is %s", chainID)
+	}
+
+	fork, err := gateway.WithForkedStore(chainID, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// the signatures and sequence numbers were already validated by the network
+	return gateway.NewEmulatorGatewayWithOpts(
+		nil,
+		fork,
+		gateway.WithEmulatorOptions(emulator.WithTransactionValidationEnabled(false)),
+	), nil
+}
+
+func replay(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	id := flowsdk.HexToID(strings.TrimPrefix(args[0], "0x"))
+	ctx := context.Background()
+
+	tx, original, err := flow.GetTransactionByID(ctx, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if original.Status != flowsdk.TransactionStatusExecuted && original.Status != flowsdk.TransactionStatusSealed {
+		return nil, fmt.Errorf("transaction %s is not executed yet, the status is %s", id, original.Status)
+	}
+	if original.BlockHeight == 0 {
+		return nil, fmt.Errorf("transaction %s in the root block can't be replayed", id)
+	}
+
+	chainID, err := flow.Gateway().GetNetworkParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the network chain: %w", err)
+	}
+
+	forkHeight := original.BlockHeight - 1
+	logger.StartProgress(fmt.Sprintf("Replaying transaction on %s forked at block %d...", chainID, forkHeight))
+	defer logger.StopProgress()
+
+	fork, err := forkGateway(chainID, forkHeight)
+	if err != nil {
+		return nil, err
+	}
+	if closer, ok := fork.(interface{ Close() error }); ok {
+		defer func() { _ = closer.Close() }()
+	}
+
+	sent, err := fork.SendSignedTransaction(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay transaction: %w", err)
+	}
+	replayed, err := fork.GetTransactionResult(ctx, sent.ID(), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the replayed transaction result: %w", err)
+	}
+
+	return &replayResult{
+		id:          id,
+		forkHeight:  forkHeight,
+		original:    original,
+		replayed:    replayed,
+		differences: compareResults(original, replayed),
+	}, nil
+}
+
+// compareResults describes the differences between the original and replayed transaction results.
+func compareResults(original *flowsdk.TransactionResult, replayed *flowsdk.TransactionResult) []string {
+	differences := make([]string, 0)
+
+	switch {
+	case original.Error == nil && replayed.Error != nil:
+		differences = append(differences, fmt.Sprintf("the original execution succeeded, but the replay failed: %s", replayed.Error))
+	case original.Error != nil && replayed.Error == nil:
+		differences = append(differences, fmt.Sprintf("the original execution failed, but the replay succeeded: %s", original.Error))
+	case original.Error != nil && original.Error.Error() != replayed.Error.Error():
+		differences = append(differences, fmt.Sprintf("the error differs: %s", replayed.Error))
+	}
+
+	if len(original.Events) != len(replayed.Events) {
+		differences = append(differences, fmt.Sprintf(
+			"%d events were emitted originally, but %d by the replay",
+			len(original.Events),
+			len(replayed.Events),
+		))
+	}
+	for i := 0; i < len(original.Events) && i < len(replayed.Events); i++ {
+		o, r := original.Events[i], replayed.Events[i]
+		if o.Type != r.Type {
+			differences = append(differences, fmt.Sprintf("event %d type differs: %s was replayed as %s", i, o.Type, r.Type))
+			continue
+		}
+		if o.Value.String() != r.Value.String() {
+			differences = append(differences, fmt.Sprintf("event %d %s values differ: %s was replayed as %s", i, o.Type, o.Value, r.Value))
+		}
+	}
+
+	return differences
+}
+
+type replayResult struct {
+	id          flowsdk.Identifier
+	forkHeight  uint64
+	original    *flowsdk.TransactionResult
+	replayed    *flowsdk.TransactionResult
+	differences []string
+}
+
+var _ command.Result = &replayResult{}
+
+func executionJSON(result *flowsdk.TransactionResult) map[string]any {
+	events := make([]string, 0, len(result.Events))
+	for _, event := range result.Events {
+		events = append(events, event.Type)
+	}
+
+	execution := map[string]any{
+		"events": events,
+	}
+	if result.Error != nil {
+		execution["error"] = result.Error.Error()
+	}
+	return execution
+}
+
+func (r *replayResult) JSON() any {
+	return map[string]any{
+		"id":          r.id.String(),
+		"forkHeight":  r.forkHeight,
+		"original":    executionJSON(r.original),
+		"replayed":    executionJSON(r.replayed),
+		"differences": r.differences,
+	}
+}
+
+func (r *replayResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	execution := func(result *flowsdk.TransactionResult) string {
+		if result.Error != nil {
+			return fmt.Sprintf("%s, %d events", output.Red("FAILED"), len(result.Events))
+		}
+		return fmt.Sprintf("%s, %d events", output.Green("SUCCESS"), len(result.Events))
+	}
+
+	_, _ = fmt.Fprintf(writer, "Transaction\t%s\n", r.id)
+	_, _ = fmt.Fprintf(writer, "Forked At Block\t%d\n", r.forkHeight)
+	_, _ = fmt.Fprintf(writer, "Original\t%s\n", execution(r.original))
+	_, _ = fmt.Fprintf(writer, "Replayed\t%s\n", execution(r.replayed))
+
+	if len(r.differences) == 0 {
+		_, _ = fmt.Fprintf(writer, "\n%s The replay matches the original execution\n", output.OkEmoji())
+	} else {
+		_, _ = fmt.Fprintf(writer, "\n%s The replay differs from the original execution:\n", output.WarningEmoji())
+		for _, difference := range r.differences {
+			_, _ = fmt.Fprintf(writer, "  - %s\n", difference)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *replayResult) Oneliner() string {
+	if len(r.differences) == 0 {
+		return fmt.Sprintf("Transaction %s replay matches", r.id)
+	}
+	return fmt.Sprintf("Transaction %s replay differs: %s", r.id, strings.Join(r.differences, "; "))
+}
@@ -0,0 +1,145 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+)
+
+// rolesFile defines the accounts of each transaction role, used with the --roles flag.
+//
+// Example:
+//
+//	{
+//	  "proposer": "alice",
+//	  "payer": "env:PAYER",
+//	  "authorizers": [
+//	    "alice",
+//	    { "address": "0x179b6b1cb6755e31", "keyIndex": 2, "signer": "bob" }
+//	  ]
+//	}
+type rolesFile struct {
+	Proposer    *role  `json:"proposer"`
+	Payer       *role  `json:"payer"`
+	Authorizers []role `json:"authorizers"`
+}
+
+// role is either the name of an account in the configuration, or the address and key index of an account signed
+// using the key of the signer account in the configuration.
+type role struct {
+	Name     string `json:"-"`
+	Address  string `json:"address"`
+	KeyIndex *int   `json:"keyIndex"`
+	Signer   string `json:"signer"`
+}
+
+func (r *role) UnmarshalJSON(b []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte(`"`)) {
+		return json.Unmarshal(b, &r.Name)
+	}
+
+	type definition role // avoids recursion when decoding the object
+	return json.Unmarshal(b, (*definition)(r))
+}
+
+// account resolves the role to an account from the configuration.
+func (r role) account(state *flowkit.State) (*accounts.Account, error) {
+	if r.Name != "" {
+		return state.Accounts().ByName(r.Name)
+	}
+	if r.Signer == "" {
+		return nil, fmt.Errorf("either an account name or a signer must be provided")
+	}
+
+	signer, err := state.Accounts().ByName(r.Signer)
+	if err != nil {
+		return nil, err
+	}
+	account := *signer
+
+	if r.Address != "" {
+		account.Address = flowsdk.HexToAddress(r.Address)
+		if account.Address == flowsdk.EmptyAddress {
+			return nil, fmt.Errorf("invalid address %s", r.Address)
+		}
+	}
+
+	if r.KeyIndex != nil {
+		keyConf := signer.Key.ToConfig()
+		keyConf.Index = *r.KeyIndex
+		account.Key, err = accounts.KeyFromConfig(keyConf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &account, nil
+}
+
+// loadRoles reads the roles file and resolves the accounts of the transaction roles.
+func loadRoles(state *flowkit.State, filename string) (*transactions.AccountRoles, error) {
+	data, err := state.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading roles file: %w", err)
+	}
+
+	var file rolesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid roles file %s: %w", filename, err)
+	}
+	if file.Proposer == nil {
+		return nil, fmt.Errorf("roles file %s is missing the proposer", filename)
+	}
+	if file.Payer == nil {
+		return nil, fmt.Errorf("roles file %s is missing the payer", filename)
+	}
+
+	proposer, err := file.Proposer.account(state)
+	if err != nil {
+		return nil, fmt.Errorf("proposer role: %w", err)
+	}
+
+	payer, err := file.Payer.account(state)
+	if err != nil {
+		return nil, fmt.Errorf("payer role: %w", err)
+	}
+
+	authorizers := make([]accounts.Account, 0, len(file.Authorizers))
+	for i, r := range file.Authorizers {
+		authorizer, err := r.account(state)
+		if err != nil {
+			return nil, fmt.Errorf("authorizer role %d: %w", i, err)
+		}
+		authorizers = append(authorizers, *authorizer)
+	}
+
+	return &transactions.AccountRoles{
+		Proposer:    *proposer,
+		Authorizers: authorizers,
+		Payer:       *payer,
+	}, nil
+}
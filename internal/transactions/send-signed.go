@@ -29,12 +29,15 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/history"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsSendSigned struct {
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
-	Exclude []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	Include    []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
+	Exclude    []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events, fees)"`
+	SecretArgs []string `default:"" flag:"secret-arg" info:"Name or index of a transaction argument that should be redacted from the output"`
 }
 
 var sendSignedFlags = flagsSendSigned{}
@@ -69,7 +72,12 @@ func sendSigned(
 		return nil, err
 	}
 
-	if !globalFlags.Yes && !util.ApproveTransactionForSendingPrompt(tx.FlowTransaction()) {
+	secrets, err := parseSecrets(sendSignedFlags.SecretArgs, tx.FlowTransaction().Script, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if !globalFlags.Yes && !util.ApproveTransactionForSendingPrompt(tx.FlowTransaction(), secrets) {
 		return nil, fmt.Errorf("transaction was not approved for sending")
 	}
 
@@ -77,14 +85,28 @@ func sendSigned(
 	defer logger.StopProgress()
 
 	sentTx, result, err := flow.SendSignedTransaction(context.Background(), tx)
+
+	_ = history.RecordTransaction(
+		reader,
+		"transactions send-signed",
+		flow.Network(),
+		filename,
+		tx.FlowTransaction().ID(),
+		result,
+		err,
+	)
+
 	if err != nil {
 		return nil, err
 	}
 
 	return &transactionResult{
-		result:  result,
-		tx:      sentTx,
-		include: sendSignedFlags.Include,
-		exclude: sendSignedFlags.Exclude,
+		result:       result,
+		tx:           sentTx,
+		include:      sendSignedFlags.Include,
+		exclude:      sendSignedFlags.Exclude,
+		secrets:      secrets,
+		payerBalance: payerBalance(flow, sentTx),
+		chainID:      events.ChainID(flow),
 	}, nil
 }
@@ -20,7 +20,6 @@ package transactions
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/onflow/cadence"
 	"github.com/spf13/cobra"
@@ -31,17 +30,22 @@ import (
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/flowkit/transactions"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/history"
+	"github.com/onflow/flow-cli/internal/i18n"
 )
 
 type flagsSend struct {
 	ArgsJSON    string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	Signer      string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and suthorizer"`
+	Signer      string   `default:"" flag:"signer" info:"Account name from configuration, or env:NAME to read the account from the FLOW_NAME_ADDRESS and FLOW_NAME_PRIVATE_KEY environment variables, used to sign the transaction as proposer, payer and suthorizer"`
 	Proposer    string   `default:"" flag:"proposer" info:"Account name from configuration used as proposer"`
 	Payer       string   `default:"" flag:"payer" info:"Account name from configuration used as payer"`
 	Authorizers []string `default:"" flag:"authorizer" info:"Name of a single or multiple comma-separated accounts used as authorizers from configuration"`
 	Include     []string `default:"" flag:"include" info:"Fields to include in the output"`
-	Exclude     []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	Exclude     []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events, fees)"`
 	GasLimit    uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	SecretArgs  []string `default:"" flag:"secret-arg" info:"Name or index of a transaction argument that should be redacted from the output"`
+	Roles       string   `default:"" flag:"roles" info:"JSON file defining the proposer, payer and authorizers, either as account names or as an address, key index and signer account"`
 }
 
 var sendFlags = flagsSend{}
@@ -66,12 +70,81 @@ func send(
 ) (result command.Result, err error) {
 	codeFilename := args[0]
 
+	var roles *transactions.AccountRoles
+	if sendFlags.Roles != "" {
+		if sendFlags.Signer != "" || sendFlags.Proposer != "" || sendFlags.Payer != "" || len(sendFlags.Authorizers) > 0 {
+			return nil, i18n.Errorf("roles flag cannot be combined with signer/payer/proposer/authorizer flags")
+		}
+		roles, err = loadRoles(state, sendFlags.Roles)
+	} else {
+		roles, err = flagRoles(state)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := state.ReadFile(codeFilename)
+	if err != nil {
+		return nil, i18n.Errorf("error loading transaction file: %w", err)
+	}
+
+	var transactionArgs []cadence.Value
+	if sendFlags.ArgsJSON != "" {
+		transactionArgs, err = arguments.ParseJSON(sendFlags.ArgsJSON)
+	} else {
+		transactionArgs, err = arguments.ParseWithoutType(args[1:], code, codeFilename)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("error parsing transaction arguments: %w", err)
+	}
+
+	secrets, err := parseSecrets(sendFlags.SecretArgs, code, state)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, txResult, err := flow.SendTransaction(
+		context.Background(),
+		*roles,
+		flowkit.Script{Code: code, Args: transactionArgs, Location: codeFilename},
+		sendFlags.GasLimit,
+	)
+
+	_ = history.RecordTransaction(
+		state.ReaderWriter(),
+		"transactions send",
+		flow.Network(),
+		historyDetails(codeFilename, transactionArgs, secrets),
+		txID(tx),
+		txResult,
+		err,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionResult{
+		result:       txResult,
+		tx:           tx,
+		include:      sendFlags.Include,
+		exclude:      sendFlags.Exclude,
+		secrets:      secrets,
+		payerBalance: payerBalance(flow, tx),
+		chainID:      events.ChainID(flow),
+	}, nil
+}
+
+// flagRoles resolves the accounts of the transaction roles from the signer, proposer, payer and authorizer flags.
+func flagRoles(state *flowkit.State) (*transactions.AccountRoles, error) {
+	var err error
+
 	proposerName := sendFlags.Proposer
 	var proposer *accounts.Account
 	if proposerName != "" {
 		proposer, err = state.Accounts().ByName(proposerName)
 		if err != nil {
-			return nil, fmt.Errorf("proposer account: [%s] doesn't exists in configuration", proposerName)
+			return nil, i18n.Errorf("proposer account: [%s] doesn't exists in configuration", proposerName)
 		}
 	}
 
@@ -80,7 +153,7 @@ func send(
 	if payerName != "" {
 		payer, err = state.Accounts().ByName(payerName)
 		if err != nil {
-			return nil, fmt.Errorf("payer account: [%s] doesn't exists in configuration", payerName)
+			return nil, i18n.Errorf("payer account: [%s] doesn't exists in configuration", payerName)
 		}
 	}
 
@@ -88,7 +161,7 @@ func send(
 	for _, authorizerName := range sendFlags.Authorizers {
 		authorizer, err := state.Accounts().ByName(authorizerName)
 		if err != nil {
-			return nil, fmt.Errorf("authorizer account: [%s] doesn't exists in configuration", authorizerName)
+			return nil, i18n.Errorf("authorizer account: [%s] doesn't exists in configuration", authorizerName)
 		}
 		authorizers = append(authorizers, *authorizer)
 	}
@@ -101,51 +174,24 @@ func send(
 
 	if signerName != "" {
 		if proposer != nil || payer != nil || len(authorizers) > 0 {
-			return nil, fmt.Errorf("signer flag cannot be combined with payer/proposer/authorizer flags")
+			return nil, i18n.Errorf("signer flag cannot be combined with payer/proposer/authorizer flags")
 		}
 		signer, err := state.Accounts().ByName(signerName)
 		if err != nil {
-			return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
+			return nil, i18n.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
 		}
 		proposer = signer
 		payer = signer
 		authorizers = append(authorizers, *signer)
 	}
 
-	code, err := state.ReadFile(codeFilename)
-	if err != nil {
-		return nil, fmt.Errorf("error loading transaction file: %w", err)
+	if proposer == nil || payer == nil {
+		return nil, i18n.Errorf("both proposer and payer flags must be provided when the signer flag is not used")
 	}
 
-	var transactionArgs []cadence.Value
-	if sendFlags.ArgsJSON != "" {
-		transactionArgs, err = arguments.ParseJSON(sendFlags.ArgsJSON)
-	} else {
-		transactionArgs, err = arguments.ParseWithoutType(args[1:], code, codeFilename)
-	}
-	if err != nil {
-		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
-	}
-
-	tx, txResult, err := flow.SendTransaction(
-		context.Background(),
-		transactions.AccountRoles{
-			Proposer:    *proposer,
-			Authorizers: authorizers,
-			Payer:       *payer,
-		},
-		flowkit.Script{Code: code, Args: transactionArgs, Location: codeFilename},
-		sendFlags.GasLimit,
-	)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return &transactionResult{
-		result:  txResult,
-		tx:      tx,
-		include: sendFlags.Include,
-		exclude: sendFlags.Exclude,
+	return &transactions.AccountRoles{
+		Proposer:    *proposer,
+		Authorizers: authorizers,
+		Payer:       *payer,
 	}, nil
 }
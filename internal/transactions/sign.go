@@ -44,6 +44,7 @@ type flagsSign struct {
 	Signer        []string `default:"emulator-account" flag:"signer" info:"name of a single or multiple comma-separated accounts used to sign"`
 	Include       []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
 	FromRemoteUrl string   `default:"" flag:"from-remote-url" info:"server URL where RLP can be fetched, signed RLP will be posted back to remote URL."`
+	SecretArgs    []string `default:"" flag:"secret-arg" info:"Name or index of a transaction argument that should be redacted from the output"`
 }
 
 var signFlags = flagsSign{}
@@ -99,6 +100,11 @@ func sign(
 		return nil, err
 	}
 
+	secrets, err := parseSecrets(signFlags.SecretArgs, tx.FlowTransaction().Script, state)
+	if err != nil {
+		return nil, err
+	}
+
 	// validate all signers
 	for _, signerName := range signFlags.Signer {
 		signer, err := state.Accounts().ByName(signerName)
@@ -114,7 +120,7 @@ func sign(
 	})
 
 	for _, signer := range signers {
-		if !globalFlags.Yes && !util.ApproveTransactionForSigningPrompt(tx.FlowTransaction()) {
+		if !globalFlags.Yes && !util.ApproveTransactionForSigningPrompt(tx.FlowTransaction(), secrets) {
 			return nil, fmt.Errorf("transaction was not approved for signing")
 		}
 
@@ -139,6 +145,7 @@ func sign(
 	return &transactionResult{
 		tx:      signed.FlowTransaction(),
 		include: signFlags.Include,
+		secrets: secrets,
 	}, nil
 }
 
@@ -22,10 +22,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/arguments"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/events"
@@ -46,19 +50,29 @@ func init() {
 	buildCommand.AddToParent(Cmd)
 	sendSignedCommand.AddToParent(Cmd)
 	decodeCommand.AddToParent(Cmd)
+	recentCommand.AddToParent(Cmd)
+	replayCommand.AddToParent(Cmd)
 }
 
 type transactionResult struct {
-	result  *flow.TransactionResult
-	tx      *flow.Transaction
-	include []string
-	exclude []string
+	result       *flow.TransactionResult
+	tx           *flow.Transaction
+	include      []string
+	exclude      []string
+	secrets      arguments.Secrets
+	payerBalance *uint64
+	chainID      flow.ChainID
 }
 
 func (r *transactionResult) JSON() any {
 	result := make(map[string]any)
 	result["id"] = r.tx.ID().String()
-	result["payload"] = fmt.Sprintf("%x", r.tx.Encode())
+	// the payload contains the arguments, so it is left out when any of them is secret
+	if len(r.secrets) == 0 {
+		result["payload"] = fmt.Sprintf("%x", r.tx.Encode())
+	} else {
+		result["payload"] = arguments.RedactedValue
+	}
 	result["authorizers"] = fmt.Sprintf("%s", r.tx.Authorizers)
 	result["payer"] = r.tx.Payer.String()
 
@@ -82,6 +96,10 @@ func (r *transactionResult) JSON() any {
 		if r.result.Error != nil {
 			result["error"] = r.result.Error.Error()
 		}
+
+		if fees := r.fees(); !fees.empty() {
+			result["fees"] = fees.JSON(r.tx.Payer)
+		}
 	}
 
 	return result
@@ -150,7 +168,8 @@ func (r *transactionResult) String() string {
 
 	if r.result != nil && !command.ContainsFlag(r.exclude, "events") {
 		e := events.EventResult{
-			Events: r.result.Events,
+			Events:  r.result.Events,
+			ChainID: r.chainID,
 		}
 
 		eventsOutput := e.String()
@@ -161,6 +180,24 @@ func (r *transactionResult) String() string {
 		_, _ = fmt.Fprintf(writer, "\n\nEvents:\t %s\n", eventsOutput)
 	}
 
+	if fees := r.fees(); fees != nil && !fees.empty() && !command.ContainsFlag(r.exclude, "fees") {
+		_, _ = fmt.Fprintf(writer, "\n\nFees:\n")
+		if fees.fee != nil {
+			_, _ = fmt.Fprintf(writer, "    Fee Paid\t%s FLOW\n", fees.fee)
+		} else {
+			_, _ = fmt.Fprintf(writer, "    Fee Paid\tNone\n")
+		}
+		if fees.balanceAfter != nil {
+			_, _ = fmt.Fprintf(writer, "    Payer Balance\t%s -> %s FLOW\n", fees.balanceBefore(r.tx.Payer), fees.balanceAfter)
+		}
+		if len(fees.deltas) > 0 {
+			_, _ = fmt.Fprintf(writer, "    Balance Changes\n")
+			for _, address := range fees.sortedAddresses() {
+				_, _ = fmt.Fprintf(writer, "\t\t- 0x%s: %s FLOW\n", address, fees.deltas[address])
+			}
+		}
+	}
+
 	if r.tx.Script != nil {
 		if command.ContainsFlag(r.include, "code") {
 			if len(r.tx.Arguments) == 0 {
@@ -168,7 +205,7 @@ func (r *transactionResult) String() string {
 			} else {
 				_, _ = fmt.Fprintf(writer, "\n\nArguments (%d):\n", len(r.tx.Arguments))
 				for i, argument := range r.tx.Arguments {
-					_, _ = fmt.Fprintf(writer, "    - Argument %d: %s\n", i, argument)
+					_, _ = fmt.Fprintf(writer, "    - Argument %d: %s\n", i, r.secrets.Redact(i, string(argument)))
 				}
 			}
 
@@ -178,7 +215,9 @@ func (r *transactionResult) String() string {
 		}
 	}
 
-	if command.ContainsFlag(r.include, "payload") {
+	if command.ContainsFlag(r.include, "payload") && len(r.secrets) > 0 {
+		_, _ = fmt.Fprint(writer, "\n\nPayload (redacted, contains secret arguments)")
+	} else if command.ContainsFlag(r.include, "payload") {
 		_, _ = fmt.Fprintf(writer, "\n\nPayload:\n%x", r.tx.Encode())
 	} else {
 		_, _ = fmt.Fprint(writer, "\n\nPayload (hidden, use --include payload)")
@@ -188,6 +227,41 @@ func (r *transactionResult) String() string {
 	return b.String()
 }
 
+// parseSecrets returns the secret arguments of the transaction code, referenced by the flag values
+// or by the secret argument names defined in the configuration.
+func parseSecrets(refs []string, code []byte, state *flowkit.State) (arguments.Secrets, error) {
+	secrets, err := arguments.ParseSecrets(refs, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if state != nil {
+		if err := secrets.AddParameters(state.Config().SecretArguments, code); err != nil {
+			return nil, err
+		}
+	}
+
+	return secrets, nil
+}
+
+// historyDetails describes the sent transaction for the history, with the secret arguments redacted.
+func historyDetails(filename string, args []cadence.Value, secrets arguments.Secrets) string {
+	values := make([]string, len(args))
+	for i, arg := range args {
+		values[i] = arg.String()
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s %s", filename, strings.Join(secrets.RedactAll(values), " ")))
+}
+
+// txID returns the transaction ID or an empty ID if the transaction wasn't sent.
+func txID(tx *flow.Transaction) flow.Identifier {
+	if tx == nil {
+		return flow.EmptyID
+	}
+	return tx.ID()
+}
+
 func (r *transactionResult) Oneliner() string {
 	result := fmt.Sprintf(
 		"ID: %s, Payer: %s, Authorizer: %s",
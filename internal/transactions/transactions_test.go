@@ -19,7 +19,10 @@
 package transactions
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -27,10 +30,13 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/arguments"
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/flowkit/transactions"
 	"github.com/onflow/flow-cli/internal/command"
@@ -159,6 +165,22 @@ func Test_Send(t *testing.T) {
 		assert.NotNil(t, result)
 	})
 
+	t.Run("Success signer from environment", func(t *testing.T) {
+		t.Setenv("FLOW_DEPLOYER_ADDRESS", "01cf0e2f2f715450")
+		t.Setenv("FLOW_DEPLOYER_PRIVATE_KEY", "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+		sendFlags.Signer = "env:DEPLOYER"
+		defer func() { sendFlags.Signer = "" }()
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			assert.Equal(t, "env:DEPLOYER", roles.Payer.Name)
+			assert.Equal(t, "01cf0e2f2f715450", roles.Proposer.Address.String())
+		}).Return(nil, nil, nil)
+
+		_, err := send([]string{tests.TransactionArgString.Filename, "foo"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+	})
+
 	t.Run("Fail non-existing account", func(t *testing.T) {
 		sendFlags.Proposer = "invalid"
 		_, err := send([]string{""}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
@@ -186,6 +208,60 @@ func Test_Send(t *testing.T) {
 		sendFlags.Signer = config.DefaultEmulator.ServiceAccount
 		_, err := send([]string{""}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
 		assert.EqualError(t, err, "signer flag cannot be combined with payer/proposer/authorizer flags")
+		sendFlags.Signer = ""   // reset
+		sendFlags.Proposer = "" // reset
+	})
+
+	t.Run("Fail proposer without payer", func(t *testing.T) {
+		sendFlags.Proposer = config.DefaultEmulator.ServiceAccount
+		_, err := send([]string{""}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "both proposer and payer flags must be provided when the signer flag is not used")
+		sendFlags.Proposer = "" // reset
+	})
+
+	t.Run("Success roles file", func(t *testing.T) {
+		rolesFile := []byte(`{
+			"proposer": "emulator-account",
+			"payer": "emulator-account",
+			"authorizers": [
+				"emulator-account",
+				{ "address": "0x179b6b1cb6755e31", "keyIndex": 2, "signer": "emulator-account" }
+			]
+		}`)
+		require.NoError(t, state.ReaderWriter().WriteFile("roles.json", rolesFile, 0644))
+		sendFlags.Roles = "roles.json"
+		defer func() { sendFlags.Roles = "" }()
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			acc := config.DefaultEmulator.ServiceAccount
+			assert.Equal(t, acc, roles.Proposer.Name)
+			assert.Equal(t, acc, roles.Payer.Name)
+			require.Len(t, roles.Authorizers, 2)
+			assert.Equal(t, acc, roles.Authorizers[0].Name)
+			assert.Equal(t, "179b6b1cb6755e31", roles.Authorizers[1].Address.String())
+			assert.Equal(t, 2, roles.Authorizers[1].Key.Index())
+		}).Return(nil, nil, nil)
+
+		_, err := send([]string{tests.TransactionArgString.Filename, "foo"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail roles file", func(t *testing.T) {
+		sendFlags.Roles = "roles.json"
+		defer func() { sendFlags.Roles = "" }()
+
+		require.NoError(t, state.ReaderWriter().WriteFile("roles.json", []byte(`{"proposer": "emulator-account"}`), 0644))
+		_, err := send([]string{""}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "roles file roles.json is missing the payer")
+
+		require.NoError(t, state.ReaderWriter().WriteFile("roles.json", []byte(`{"proposer": "emulator-account", "payer": {"address": "01"}}`), 0644))
+		_, err = send([]string{""}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "payer role: either an account name or a signer must be provided")
+
+		sendFlags.Signer = config.DefaultEmulator.ServiceAccount
+		_, err = send([]string{""}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "roles flag cannot be combined with signer/payer/proposer/authorizer flags")
 		sendFlags.Signer = "" // reset
 	})
 
@@ -391,4 +467,204 @@ Payload (hidden, use --include payload)`, "\n"), result.String())
 			"status":  "SEALED",
 		}, result.JSON())
 	})
+	t.Run("Success redacting payload with secrets", func(t *testing.T) {
+		result := transactionResult{
+			tx:      tx,
+			include: []string{"payload"},
+			secrets: arguments.Secrets{0: true},
+		}
+
+		assert.Equal(t, arguments.RedactedValue, result.JSON().(map[string]any)["payload"])
+		assert.Contains(t, result.String(), "Payload (redacted, contains secret arguments)")
+		assert.NotContains(t, result.String(), fmt.Sprintf("%x", tx.Encode()))
+	})
+}
+
+func Test_TransactionResultFees(t *testing.T) {
+	payer := flow.HexToAddress("0x02")
+	feesAddress := flow.HexToAddress("0x03")
+
+	tokenFields := func(addressField string) []cadence.Field {
+		return []cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: addressField, Type: &cadence.OptionalType{Type: cadence.AddressType{}}},
+		}
+	}
+	withdrawn := tests.NewEvent(0,
+		"A.0ae53cb6e3f42a79.FlowToken.TokensWithdrawn",
+		tokenFields("from"),
+		[]cadence.Value{cadence.UFix64(1000), cadence.NewOptional(cadence.NewAddress(payer))},
+	)
+	deposited := tests.NewEvent(1,
+		"A.0ae53cb6e3f42a79.FlowToken.TokensDeposited",
+		tokenFields("to"),
+		[]cadence.Value{cadence.UFix64(1000), cadence.NewOptional(cadence.NewAddress(feesAddress))},
+	)
+	feesDeducted := tests.NewEvent(2,
+		"A.e5a8b7f23e8b548f.FlowFees.FeesDeducted",
+		[]cadence.Field{{Identifier: "amount", Type: cadence.UFix64Type{}}},
+		[]cadence.Value{cadence.UFix64(1000)},
+	)
+
+	balance := uint64(100000000)
+	result := transactionResult{
+		tx: &flow.Transaction{Payer: payer},
+		result: &flow.TransactionResult{
+			Status: flow.TransactionStatusSealed,
+			Events: []flow.Event{*withdrawn, *deposited, *feesDeducted},
+		},
+		payerBalance: &balance,
+	}
+
+	output := result.String()
+	assert.Contains(t, output, "Fee Paid")
+	assert.Contains(t, output, "0.00001000 FLOW")
+	assert.Contains(t, output, "1.00001000 -> 1.00000000 FLOW")
+	assert.Contains(t, output, "- 0x0000000000000002: -0.00001000 FLOW")
+	assert.Contains(t, output, "- 0x0000000000000003: 0.00001000 FLOW")
+
+	fees := result.JSON().(map[string]any)["fees"].(map[string]any)
+	assert.Equal(t, "0.00001000", fees["fee"])
+	assert.Equal(t, "1.00001000", fees["payer_balance_before"])
+	assert.Equal(t, "1.00000000", fees["payer_balance_after"])
+	assert.Equal(t, map[string]string{
+		"0000000000000002": "-0.00001000",
+		"0000000000000003": "0.00001000",
+	}, fees["balance_changes"])
+}
+
+func Test_Recent(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	payer := flow.HexToAddress("0x02")
+	other := flow.HexToAddress("0x03")
+	withdrawn := tests.NewEvent(0, "A.0ae53cb6e3f42a79.FlowToken.TokensWithdrawn", nil, nil)
+	deposited := tests.NewEvent(1, "A.0ae53cb6e3f42a79.FlowToken.TokensDeposited", nil, nil)
+	created := tests.NewEvent(0, "flow.AccountCreated", nil, nil)
+
+	srv.GetBlock.Run(func(args mock.Arguments) {
+		query := args.Get(1).(flowkit.BlockQuery)
+		block := tests.NewBlock()
+		block.Height = query.Height
+		if query.Latest {
+			block.Height = 1
+		}
+		block.ID = flow.Identifier{byte(block.Height)}
+		srv.GetBlock.Return(block, nil)
+	})
+
+	srv.GetTransactionsByBlockID.Run(func(args mock.Arguments) {
+		id := args.Get(1).(flow.Identifier)
+		txs := []*flow.Transaction{{Payer: payer}}
+		results := []*flow.TransactionResult{{
+			Status: flow.TransactionStatusSealed,
+			Events: []flow.Event{*withdrawn, *deposited},
+		}}
+		if id[0] == 1 {
+			txs = append(txs, &flow.Transaction{Payer: other})
+			results = append(results, &flow.TransactionResult{
+				Status: flow.TransactionStatusSealed,
+				Error:  fmt.Errorf("failed"),
+				Events: []flow.Event{*created},
+			})
+		}
+		srv.GetTransactionsByBlockID.Return(txs, results, nil)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		recentFlags.Blocks = 50
+		recentFlags.Top = 5
+		result, err := recent([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		summary := result.(*recentSummary)
+		assert.Equal(t, uint64(0), summary.startHeight)
+		assert.Equal(t, uint64(1), summary.endHeight)
+		assert.Equal(t, 3, summary.count)
+		assert.Equal(t, 1, summary.failed)
+		assert.Equal(t, map[string]int{"SEALED": 3}, summary.statuses)
+		assert.Equal(t, []rankedCount{
+			{Name: payer.Hex(), Count: 2},
+			{Name: other.Hex(), Count: 1},
+		}, ranked(summary.payers, 5))
+		assert.Equal(t, []rankedCount{
+			{Name: "A.0ae53cb6e3f42a79.FlowToken", Count: 2},
+		}, ranked(summary.contracts, 5))
+		assert.Contains(t, result.String(), "A.0ae53cb6e3f42a79.FlowToken")
+	})
+
+	t.Run("Fail zero blocks", func(t *testing.T) {
+		recentFlags.Blocks = 0
+		_, err := recent([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "number of blocks must be greater than zero")
+		recentFlags.Blocks = 50
+	})
+}
+
+func Test_Replay(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	defer func(original func(flow.ChainID, uint64) (gateway.Gateway, error)) { forkGateway = original }(forkGateway)
+
+	event := *tests.NewEvent(0, "A.01.Token.Deposited", []cadence.Field{{Identifier: "amount", Type: cadence.IntType{}}}, []cadence.Value{cadence.NewInt(10)})
+	original := tests.NewTransactionResult([]flow.Event{event})
+	original.Status = flow.TransactionStatusSealed
+	original.BlockHeight = 100
+	tx := tests.NewTransaction()
+	srv.GetTransactionByID.Return(tx, original, nil)
+
+	gw := &gateway.Mock{
+		GetNetworkParametersFunc: func(ctx context.Context) (flow.ChainID, error) {
+			return flow.Mainnet, nil
+		},
+	}
+	srv.Gateway.Return(gw)
+
+	replayWith := func(t *testing.T, replayed *flow.TransactionResult) *replayResult {
+		fork := &gateway.Mock{
+			SendSignedTransactionFunc: func(ctx context.Context, sent *flow.Transaction) (*flow.Transaction, error) {
+				assert.Equal(t, tx.ID(), sent.ID())
+				return sent, nil
+			},
+			GetTransactionResultFunc: func(ctx context.Context, ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+				return replayed, nil
+			},
+		}
+		forkGateway = func(chainID flow.ChainID, height uint64) (gateway.Gateway, error) {
+			assert.Equal(t, flow.Mainnet, chainID)
+			assert.Equal(t, uint64(99), height)
+			return fork, nil
+		}
+
+		result, err := replay([]string{tx.ID().String()}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		return result.(*replayResult)
+	}
+
+	t.Run("Matching", func(t *testing.T) {
+		result := replayWith(t, tests.NewTransactionResult([]flow.Event{event}))
+		assert.Empty(t, result.differences)
+		assert.Contains(t, result.String(), "The replay matches the original execution")
+	})
+
+	t.Run("Differing", func(t *testing.T) {
+		differentEvent := *tests.NewEvent(0, "A.01.Token.Deposited", []cadence.Field{{Identifier: "amount", Type: cadence.IntType{}}}, []cadence.Value{cadence.NewInt(5)})
+		replayed := tests.NewTransactionResult([]flow.Event{differentEvent, event})
+		replayed.Error = errors.New("panic: insufficient balance")
+
+		result := replayWith(t, replayed)
+		assert.Equal(t, []string{
+			"the original execution succeeded, but the replay failed: panic: insufficient balance",
+			"1 events were emitted originally, but 2 by the replay",
+			"event 0 A.01.Token.Deposited values differ: A.01.Token.Deposited(amount: 10) was replayed as A.01.Token.Deposited(amount: 5)",
+		}, result.differences)
+	})
+
+	t.Run("Fail pending transaction", func(t *testing.T) {
+		pending := tests.NewTransactionResult(nil)
+		pending.Status = flow.TransactionStatusPending
+		srv.GetTransactionByID.Return(tx, pending, nil)
+
+		_, err := replay([]string{tx.ID().String()}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, fmt.Sprintf("transaction %s is not executed yet, the status is PENDING", tx.ID()))
+	})
 }
@@ -0,0 +1,95 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/onflow/flow-cli/flowkit"
+)
+
+// AllowlistFile is the project file listing the code allowed to be sent to mainnet in operations mode.
+//
+// Operations mode is enabled when the file exists, it should be committed so changes to it are reviewed.
+const AllowlistFile = "flow-ops.json"
+
+// AllowlistEntry is a transaction or script allowed to be sent to mainnet.
+type AllowlistEntry struct {
+	// File the code was read from.
+	File string `json:"file"`
+	// Hash of the code with the imports resolved for mainnet.
+	Hash string `json:"hash"`
+}
+
+// Allowlist contains the code allowed in operations mode, sorted by file.
+type Allowlist []AllowlistEntry
+
+// AllowlistPath returns the path of the allowlist file next to the configuration, or in the working
+// directory if no configuration was loaded or it was loaded from multiple locations.
+func AllowlistPath(state *flowkit.State) string {
+	return configRelativePath(state, AllowlistFile)
+}
+
+// LoadAllowlist reads the allowlist file at the path, nil is returned if the file doesn't exist.
+//
+// An error is returned if the file exists but can't be read, so operations mode can't be turned off
+// by an unreadable allowlist.
+func LoadAllowlist(reader flowkit.ReaderWriter, path string) (Allowlist, error) {
+	data, err := reader.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allowlist %s: %w", path, err)
+	}
+
+	allowlist := make(Allowlist, 0)
+	if err := json.Unmarshal(data, &allowlist); err != nil {
+		return nil, fmt.Errorf("failed to parse allowlist file %s: %w", path, err)
+	}
+
+	return allowlist, nil
+}
+
+// Save the allowlist to the allowlist file at the path.
+func (a Allowlist) Save(writer flowkit.ReaderWriter, path string) error {
+	sort.Slice(a, func(i, j int) bool {
+		return a[i].File < a[j].File
+	})
+
+	data, err := json.MarshalIndent(a, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return writer.WriteFile(path, data, 0644)
+}
+
+// Hashes returns the hashes of the allowed code.
+func (a Allowlist) Hashes() []string {
+	hashes := make([]string, 0, len(a))
+	for _, entry := range a {
+		hashes = append(hashes, entry.Hash)
+	}
+	return hashes
+}
@@ -0,0 +1,117 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+const relativeHeightPrefix = "latest-"
+
+// BlockQuery creates a block query from the value, extending flowkit.NewBlockQuery with heights relative
+// to the latest block (e.g. "latest-100") and RFC 3339 timestamps (e.g. "2023-06-01T15:00:00Z"),
+// which are resolved to the first block produced at or after the time.
+func BlockQuery(ctx context.Context, flow flowkit.Services, value string) (flowkit.BlockQuery, error) {
+	if strings.HasPrefix(value, relativeHeightPrefix) {
+		offset, err := strconv.ParseUint(strings.TrimPrefix(value, relativeHeightPrefix), 10, 64)
+		if err != nil {
+			return flowkit.BlockQuery{}, fmt.Errorf("invalid relative height %s, use latest-N where N is the number of blocks", value)
+		}
+
+		latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+		if err != nil {
+			return flowkit.BlockQuery{}, err
+		}
+		if offset > latest.Height {
+			return flowkit.BlockQuery{}, fmt.Errorf("relative height %s is before the first block", value)
+		}
+
+		return flowkit.BlockQuery{Height: latest.Height - offset}, nil
+	}
+
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		height, err := heightAtTime(ctx, flow, timestamp)
+		if err != nil {
+			return flowkit.BlockQuery{}, err
+		}
+
+		return flowkit.BlockQuery{Height: height}, nil
+	}
+
+	return flowkit.NewBlockQuery(value)
+}
+
+// BlockHeight resolves the value to a block height, all the values supported by BlockQuery can be used.
+func BlockHeight(ctx context.Context, flow flowkit.Services, value string) (uint64, error) {
+	query, err := BlockQuery(ctx, flow, value)
+	if err != nil {
+		return 0, err
+	}
+
+	if !query.Latest && query.ID == nil {
+		return query.Height, nil
+	}
+
+	block, err := flow.GetBlock(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return block.Height, nil
+}
+
+// heightAtTime finds the height of the first block with timestamp at or after the time using binary search.
+//
+// Blocks which are not found, like blocks from previous sporks, are treated as produced before the time,
+// any other error fetching a block is returned.
+func heightAtTime(ctx context.Context, flow flowkit.Services, timestamp time.Time) (uint64, error) {
+	latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+	if err != nil {
+		return 0, err
+	}
+	if latest.Timestamp.Before(timestamp) {
+		return 0, fmt.Errorf("time %s is after the latest block", timestamp.Format(time.RFC3339))
+	}
+
+	low, high := uint64(0), latest.Height
+	for low < high {
+		mid := low + (high-low)/2
+
+		block, err := flow.GetBlock(ctx, flowkit.BlockQuery{Height: mid})
+		if err != nil && !errors.Is(err, gateway.ErrNotFound) {
+			return 0, fmt.Errorf("failed to get block at height %d: %w", mid, err)
+		}
+
+		if err != nil || block.Timestamp.Before(timestamp) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+
+	return low, nil
+}
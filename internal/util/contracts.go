@@ -0,0 +1,71 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// CoreContracts contains the addresses of the core and common contracts on each network, used by the code
+// shipped with the CLI which imports the contracts by the placeholder address, e.g. "from 0xFungibleToken".
+//
+// The common contracts on the emulator are only deployed when it's started with the --contracts flag.
+var CoreContracts = map[string]map[string]string{
+	"emulator": {
+		"FungibleToken":    "0xee82856bf20e2aa6",
+		"FlowToken":        "0x0ae53cb6e3f42a79",
+		"FlowEpoch":        "0xf8d6e0586b0a20c7",
+		"NonFungibleToken": "0xf8d6e0586b0a20c7",
+		"MetadataViews":    "0xf8d6e0586b0a20c7",
+		"ExampleNFT":       "0xf8d6e0586b0a20c7",
+		"FUSD":             "0xf8d6e0586b0a20c7",
+	},
+	"testnet": {
+		"FungibleToken":    "0x9a0766d93b6608b7",
+		"FlowToken":        "0x7e60df042a9c0868",
+		"FlowEpoch":        "0x9eca2b38b18b5dfe",
+		"NonFungibleToken": "0x631e88ae7f1d7c20",
+		"MetadataViews":    "0x631e88ae7f1d7c20",
+		"FUSD":             "0xe223d8a629e49c68",
+	},
+	"mainnet": {
+		"FungibleToken":    "0xf233dcee88fe0abe",
+		"FlowToken":        "0x1654653399040a61",
+		"FlowEpoch":        "0x8624b52f9ddcd04a",
+		"NonFungibleToken": "0x1d7e57aa55817448",
+		"MetadataViews":    "0x1d7e57aa55817448",
+		"FUSD":             "0x3c5959b568896393",
+	},
+}
+
+// ReplaceCoreImports replaces the placeholder addresses of the core contract imports in the code with
+// the addresses on the network.
+func ReplaceCoreImports(code []byte, network string) ([]byte, error) {
+	contracts, ok := CoreContracts[network]
+	if !ok {
+		return nil, fmt.Errorf("core contracts are not known on network %s", network)
+	}
+
+	for contract, address := range contracts {
+		code = bytes.ReplaceAll(code, []byte(fmt.Sprintf("from 0x%s\n", contract)), []byte(fmt.Sprintf("from %s\n", address)))
+	}
+
+	return code, nil
+}
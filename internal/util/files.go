@@ -0,0 +1,114 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// privateFilePermissions allow only the owner to read and write the file.
+const privateFilePermissions = os.FileMode(0600)
+
+// WritePrivateFile writes sensitive data like private keys to a file only accessible by the current user.
+//
+// The mode of an existing file is changed before it's overwritten, since the file mode is only applied when the file
+// is created. On Windows file mode bits are ignored, so the file access control list is replaced to only grant access
+// to the current user.
+func WritePrivateFile(filename string, data []byte, writer flowkit.ReaderWriter) error {
+	if chmod, ok := writer.(interface {
+		Chmod(name string, mode os.FileMode) error
+	}); ok {
+		if err := chmod.Chmod(filename, privateFilePermissions); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	if err := writer.WriteFile(filename, data, privateFilePermissions); err != nil {
+		return err
+	}
+
+	return restrictFileAccess(filename)
+}
+
+// SecretFiles returns the project files containing private keys.
+//
+// Configuration files are included if any of the accounts, other than the emulator service account,
+// has the private key defined inline, and the private configuration is always included. The key files
+// are resolved relative to the configuration.
+func SecretFiles(state *flowkit.State) []string {
+	files := make([]string, 0)
+	inlineKeys := false
+
+	for _, account := range state.Config().Accounts {
+		if account.Key.Type == config.KeyTypeFile {
+			files = append(files, configRelativePath(state, account.Key.Location))
+		} else if account.HasSecretKey() {
+			inlineKeys = true
+		}
+	}
+
+	if inlineKeys {
+		files = append(files, state.ConfigPaths()...)
+	}
+	if private := state.PrivateConfigPath(); private != "" {
+		files = append(files, private)
+	}
+
+	return files
+}
+
+// configRelativePath returns the relative path resolved against the directory of the configuration if it was
+// loaded from a single location, otherwise the path is relative to the working directory.
+func configRelativePath(state *flowkit.State, path string) string {
+	if state == nil || filepath.IsAbs(path) {
+		return path
+	}
+	if paths := state.ConfigPaths(); len(paths) == 1 {
+		return filepath.Join(filepath.Dir(paths[0]), path)
+	}
+	return path
+}
+
+// InsecureFiles returns the existing files that can be accessed by users other than the owner.
+func InsecureFiles(files []string) []string {
+	insecure := make([]string, 0)
+	// access on Windows is controlled by access control lists and not by the file mode
+	if runtime.GOOS == "windows" {
+		return insecure
+	}
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+
+		if info.Mode().Perm()&0077 != 0 {
+			insecure = append(insecure, file)
+		}
+	}
+
+	return insecure
+}
@@ -0,0 +1,77 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+)
+
+func Test_PrivateFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file access on Windows is not controlled by the file mode")
+	}
+
+	dir := t.TempDir()
+	rw := afero.Afero{Fs: afero.NewOsFs()}
+
+	t.Run("Restrict existing file", func(t *testing.T) {
+		file := filepath.Join(dir, "existing.pkey")
+		require.NoError(t, os.WriteFile(file, []byte("old"), 0644))
+		require.NoError(t, os.Chmod(file, 0644))
+
+		require.NoError(t, WritePrivateFile(file, []byte("new"), rw))
+
+		info, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+	})
+
+	t.Run("Insecure key file next to configuration", func(t *testing.T) {
+		project := filepath.Join(dir, "project")
+		require.NoError(t, os.MkdirAll(project, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(project, "flow.json"), []byte(`{
+			"accounts": {
+				"alice": {
+					"address": "f8d6e0586b0a20c7",
+					"key": { "type": "file", "location": "alice.pkey" }
+				}
+			}
+		}`), 0600))
+		keyFile := filepath.Join(project, "alice.pkey")
+		require.NoError(t, os.WriteFile(keyFile, []byte("key"), 0644))
+		require.NoError(t, os.Chmod(keyFile, 0644))
+
+		state, err := flowkit.Load([]string{filepath.Join(project, "flow.json")}, rw)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{keyFile}, InsecureFiles(SecretFiles(state)))
+	})
+}
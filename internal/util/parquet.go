@@ -0,0 +1,119 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// Parquet physical types supported by the writer.
+const (
+	ParquetInt64     = parquet.Type_INT64
+	ParquetByteArray = parquet.Type_BYTE_ARRAY
+)
+
+// ParquetColumn contains the values of a single column, values must be int64 for ParquetInt64 columns
+// and string for ParquetByteArray columns, nil values are only allowed in optional columns.
+type ParquetColumn struct {
+	Name     string
+	Type     parquet.Type
+	Optional bool
+	Values   []any
+}
+
+// metadata returns the schema definition of the column used by the Parquet writer.
+func (c ParquetColumn) metadata() string {
+	repetition := "REQUIRED"
+	if c.Optional {
+		repetition = "OPTIONAL"
+	}
+
+	if c.Type == ParquetByteArray {
+		return fmt.Sprintf("name=%s, type=%s, convertedtype=UTF8, repetitiontype=%s", c.Name, c.Type, repetition)
+	}
+	return fmt.Sprintf("name=%s, type=%s, repetitiontype=%s", c.Name, c.Type, repetition)
+}
+
+// check returns an error if a value doesn't match the column type.
+func (c ParquetColumn) check(value any) error {
+	switch value.(type) {
+	case nil:
+		if !c.Optional {
+			return fmt.Errorf("column %s is required but contains an empty value", c.Name)
+		}
+	case int64:
+		if c.Type != ParquetInt64 {
+			return fmt.Errorf("column %s contains an invalid value %v", c.Name, value)
+		}
+	case string:
+		if c.Type != ParquetByteArray {
+			return fmt.Errorf("column %s contains an invalid value %v", c.Name, value)
+		}
+	default:
+		return fmt.Errorf("column %s contains an unsupported value %v", c.Name, value)
+	}
+	return nil
+}
+
+// WriteParquet encodes the columns into a Parquet file.
+func WriteParquet(columns []ParquetColumn) ([]byte, error) {
+	rows := 0
+	if len(columns) > 0 {
+		rows = len(columns[0].Values)
+	}
+
+	metadata := make([]string, len(columns))
+	for i, column := range columns {
+		if len(column.Values) != rows {
+			return nil, fmt.Errorf("column %s has %d values, expected %d", column.Name, len(column.Values), rows)
+		}
+		for _, value := range column.Values {
+			if err := column.check(value); err != nil {
+				return nil, err
+			}
+		}
+		metadata[i] = column.metadata()
+	}
+
+	var file bytes.Buffer
+	w, err := writer.NewCSVWriterFromWriter(metadata, &file, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Parquet writer: %w", err)
+	}
+
+	for row := 0; row < rows; row++ {
+		record := make([]any, len(columns))
+		for i, column := range columns {
+			record[i] = column.Values[row]
+		}
+		if err := w.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write Parquet row: %w", err)
+		}
+	}
+
+	if err := w.WriteStop(); err != nil {
+		return nil, fmt.Errorf("failed to write Parquet file: %w", err)
+	}
+
+	return file.Bytes(), nil
+}
@@ -0,0 +1,117 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func Test_WriteParquet(t *testing.T) {
+	t.Run("Read written columns", func(t *testing.T) {
+		columns := []ParquetColumn{
+			{Name: "height", Type: ParquetInt64, Values: []any{int64(1), int64(-2), int64(3)}},
+			{Name: "id", Type: ParquetByteArray, Values: []any{"a", "", "ccc"}},
+			{Name: "index", Type: ParquetInt64, Optional: true, Values: []any{nil, int64(5), nil}},
+			{Name: "field", Type: ParquetByteArray, Optional: true, Values: []any{"x", nil, "z"}},
+		}
+
+		data, err := WriteParquet(columns)
+		require.NoError(t, err)
+
+		rows, read := readParquet(t, data)
+		assert.Equal(t, 3, rows)
+		assert.Equal(t, columns, read)
+	})
+
+	t.Run("Read many columns", func(t *testing.T) {
+		columns := make([]ParquetColumn, 0)
+		for i := 0; i < 20; i++ {
+			columns = append(columns, ParquetColumn{
+				Name:     fmt.Sprintf("column_%d", i),
+				Type:     ParquetByteArray,
+				Optional: true,
+				Values:   []any{fmt.Sprintf("value_%d", i)},
+			})
+		}
+
+		data, err := WriteParquet(columns)
+		require.NoError(t, err)
+
+		rows, read := readParquet(t, data)
+		assert.Equal(t, 1, rows)
+		assert.Equal(t, columns, read)
+	})
+
+	t.Run("Read empty columns", func(t *testing.T) {
+		columns := []ParquetColumn{{Name: "a", Type: ParquetInt64, Values: []any{}}}
+
+		data, err := WriteParquet(columns)
+		require.NoError(t, err)
+
+		rows, read := readParquet(t, data)
+		assert.Equal(t, 0, rows)
+		assert.Equal(t, columns, read)
+	})
+
+	t.Run("Fail invalid values", func(t *testing.T) {
+		_, err := WriteParquet([]ParquetColumn{{Name: "a", Type: ParquetInt64, Values: []any{nil}}})
+		assert.EqualError(t, err, "column a is required but contains an empty value")
+
+		_, err = WriteParquet([]ParquetColumn{{Name: "a", Type: ParquetInt64, Values: []any{"1"}}})
+		assert.EqualError(t, err, "column a contains an invalid value 1")
+
+		_, err = WriteParquet([]ParquetColumn{
+			{Name: "a", Type: ParquetInt64, Values: []any{int64(1)}},
+			{Name: "b", Type: ParquetInt64, Values: []any{}},
+		})
+		assert.EqualError(t, err, "column b has 0 values, expected 1")
+	})
+}
+
+// readParquet reads the columns with the Parquet reader, so the written files are checked to be readable by a reader.
+func readParquet(t *testing.T, data []byte) (int, []ParquetColumn) {
+	file, err := buffer.NewBufferFile(data)
+	require.NoError(t, err)
+	pr, err := reader.NewParquetReader(file, nil, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+
+	rows := int(pr.GetNumRows())
+	columns := make([]ParquetColumn, 0)
+	for i, element := range pr.Footer.Schema[1:] {
+		values, _, _, err := pr.ReadColumnByIndex(int64(i), int64(rows))
+		require.NoError(t, err)
+
+		columns = append(columns, ParquetColumn{
+			Name:     pr.SchemaHandler.GetExName(i + 1),
+			Type:     element.GetType(),
+			Optional: element.GetRepetitionType() == parquet.FieldRepetitionType_OPTIONAL,
+			Values:   values,
+		})
+	}
+
+	return rows, columns
+}
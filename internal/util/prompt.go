@@ -21,7 +21,7 @@ package util
 import (
 	"fmt"
 	"os"
-	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -33,23 +33,24 @@ import (
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
+	"github.com/onflow/flow-cli/flowkit/arguments"
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/output"
 )
 
-func ApproveTransactionForSigningPrompt(transaction *flow.Transaction) bool {
-	return ApproveTransactionPrompt(transaction, "⚠️  Do you want to SIGN this transaction?")
+func ApproveTransactionForSigningPrompt(transaction *flow.Transaction, secrets arguments.Secrets) bool {
+	return ApproveTransactionPrompt(transaction, secrets, "⚠️  Do you want to SIGN this transaction?")
 }
 
-func ApproveTransactionForBuildingPrompt(transaction *flow.Transaction) bool {
-	return ApproveTransactionPrompt(transaction, "⚠️  Do you want to BUILD this transaction?")
+func ApproveTransactionForBuildingPrompt(transaction *flow.Transaction, secrets arguments.Secrets) bool {
+	return ApproveTransactionPrompt(transaction, secrets, "⚠️  Do you want to BUILD this transaction?")
 }
 
-func ApproveTransactionForSendingPrompt(transaction *flow.Transaction) bool {
-	return ApproveTransactionPrompt(transaction, "⚠️  Do you want to SEND this transaction?")
+func ApproveTransactionForSendingPrompt(transaction *flow.Transaction, secrets arguments.Secrets) bool {
+	return ApproveTransactionPrompt(transaction, secrets, "⚠️  Do you want to SEND this transaction?")
 }
 
-func ApproveTransactionPrompt(tx *flow.Transaction, promptMsg string) bool {
+func ApproveTransactionPrompt(tx *flow.Transaction, secrets arguments.Secrets, promptMsg string) bool {
 	writer := uilive.New()
 
 	_, _ = fmt.Fprintf(writer, "\n")
@@ -90,7 +91,7 @@ func ApproveTransactionPrompt(tx *flow.Transaction, promptMsg string) bool {
 		} else {
 			_, _ = fmt.Fprintf(writer, "\n\nArguments (%d):\n", len(tx.Arguments))
 			for i, argument := range tx.Arguments {
-				_, _ = fmt.Fprintf(writer, "    - Argument %d: %s\n", i, argument)
+				_, _ = fmt.Fprintf(writer, "    - Argument %d: %s\n", i, secrets.Redact(i, string(argument)))
 			}
 		}
 
@@ -271,6 +272,123 @@ func ShowContractDiffPrompt(logger output.Logger) func([]byte, []byte) bool {
 	}
 }
 
+// DeployNetworkPrompt asks the user to choose the network to deploy to.
+func DeployNetworkPrompt(networks []string) string {
+	networkPrompt := promptui.Select{
+		Label: "Choose network for deployment",
+		Items: networks,
+	}
+
+	_, network, err := networkPrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return network
+}
+
+// ToggleContractsPrompt lets the user toggle contracts on or off for the deployment
+// and returns the selection once the user chooses to continue.
+func ToggleContractsPrompt(names []string, selected []bool) []bool {
+	const done = "Continue"
+	cursor := 0
+
+	for {
+		items := make([]string, 0, len(names)+1)
+		for i, name := range names {
+			mark := " "
+			if selected[i] {
+				mark = "x"
+			}
+			items = append(items, fmt.Sprintf("[%s] %s", mark, name))
+		}
+		items = append(items, done)
+
+		togglePrompt := promptui.Select{
+			Label:     "Toggle contracts to deploy",
+			Items:     items,
+			Size:      len(items),
+			CursorPos: cursor,
+		}
+
+		index, _, err := togglePrompt.Run()
+		if err == promptui.ErrInterrupt {
+			os.Exit(-1)
+		}
+		if err != nil || index == len(names) {
+			return selected
+		}
+
+		selected[index] = !selected[index]
+		cursor = index
+	}
+}
+
+// ConfirmDeploymentPrompt asks the user to confirm the deployment of the selected contracts.
+func ConfirmDeploymentPrompt(count int, network string) bool {
+	confirmPrompt := promptui.Select{
+		Label: fmt.Sprintf("Do you wish to deploy %d contracts to %s?", count, network),
+		Items: []string{"No", "Yes"},
+	}
+
+	_, result, err := confirmPrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return result == "Yes"
+}
+
+// ConfirmLoadTestPrompt asks the user to confirm sending the load test transactions to the network.
+func ConfirmLoadTestPrompt(count int, network string) bool {
+	confirmPrompt := promptui.Select{
+		Label: fmt.Sprintf("Do you wish to send up to %d transactions to %s?", count, network),
+		Items: []string{"No", "Yes"},
+	}
+
+	_, result, err := confirmPrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return result == "Yes"
+}
+
+// ConfirmRollbackPrompt asks the user to confirm removing the emulator blocks after the block height.
+func ConfirmRollbackPrompt(height uint64) bool {
+	confirmPrompt := promptui.Select{
+		Label: fmt.Sprintf("Do you wish to remove all the emulator blocks after block height %d?", height),
+		Items: []string{"No", "Yes"},
+	}
+
+	_, result, err := confirmPrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return result == "Yes"
+}
+
+// ConfirmHooksPrompt asks the user to trust the hooks of the project to run their commands.
+func ConfirmHooksPrompt(hooks config.Hooks) bool {
+	commands := make([]string, 0, len(hooks))
+	for _, hook := range hooks {
+		commands = append(commands, fmt.Sprintf("%s: %s", hook.Name, hook.Command))
+	}
+
+	confirmPrompt := promptui.Select{
+		Label: fmt.Sprintf("Do you trust the hooks of this project to run these commands? %s", strings.Join(commands, "; ")),
+		Items: []string{"No", "Yes"},
+	}
+
+	_, result, err := confirmPrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return result == "Yes"
+}
+
 type AccountData struct {
 	Name     string
 	Address  string
@@ -685,7 +803,7 @@ func InstallPathPrompt(defaultPath string) string {
 		os.Exit(-1)
 	}
 
-	return path.Clean(install)
+	return filepath.Clean(install)
 }
 
 type ScaffoldItem struct {
@@ -23,7 +23,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
-	"path"
+	"path/filepath"
 	"strings"
 	"text/tabwriter"
 
@@ -41,12 +41,14 @@ func Exit(code int, msg string) {
 }
 
 // AddToGitIgnore adds a new line to the .gitignore if one doesn't exist it creates it.
+//
+// The .gitignore is left unchanged if the filename is already listed.
 func AddToGitIgnore(filename string, loader flowkit.ReaderWriter) error {
 	currentWd, err := os.Getwd()
 	if err != nil {
 		return err
 	}
-	gitIgnorePath := path.Join(currentWd, ".gitignore")
+	gitIgnorePath := filepath.Join(currentWd, ".gitignore")
 	gitIgnoreFiles := ""
 	filePermissions := os.FileMode(0644)
 
@@ -58,6 +60,12 @@ func AddToGitIgnore(filename string, loader flowkit.ReaderWriter) error {
 		}
 		gitIgnoreFiles = string(gitIgnoreFilesRaw)
 		filePermissions = fileStat.Mode().Perm()
+
+		for _, line := range strings.Split(gitIgnoreFiles, "\n") {
+			if strings.TrimSpace(line) == filename {
+				return nil
+			}
+		}
 	}
 	return loader.WriteFile(
 		gitIgnorePath,
@@ -0,0 +1,270 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsWait struct {
+	Tx          string `default:"" flag:"tx" info:"Wait for the transaction with the ID to reach the status"`
+	Status      string `default:"sealed" flag:"status" info:"Transaction status to wait for: pending, finalized, executed or sealed"`
+	Height      uint64 `default:"0" flag:"height" info:"Wait for the latest sealed block to reach the height"`
+	Script      string `default:"" flag:"script" info:"Wait for the script to return the value provided with --until"`
+	ArgsJSON    string `default:"" flag:"args-json" info:"Script arguments in JSON-Cadence format"`
+	Until       string `default:"" flag:"until" info:"Expected script result in the Cadence value format, e.g. '\"true\"' for a string"`
+	Interval    string `default:"5s" flag:"interval" info:"Polling interval, e.g. 500ms, 5s or 1m"`
+	WaitTimeout string `default:"10m" flag:"wait-timeout" info:"Maximum time to wait for the condition before failing"`
+}
+
+var waitFlags = flagsWait{}
+
+var Command = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for a transaction, block height or script result condition to be met",
+		Example: `flow wait --tx 07a8...b433 --status sealed
+flow wait --height 1000
+flow wait --script is_deployed.cdc --until true --interval 5s --wait-timeout 10m`,
+		Args: cobra.NoArgs,
+	},
+	Flags: &waitFlags,
+	Run:   wait,
+}
+
+// transactionStatuses are the statuses that can be waited for, a status is reached once the
+// transaction has that status or any later one.
+var transactionStatuses = map[string]flowsdk.TransactionStatus{
+	"pending":   flowsdk.TransactionStatusPending,
+	"finalized": flowsdk.TransactionStatusFinalized,
+	"executed":  flowsdk.TransactionStatusExecuted,
+	"sealed":    flowsdk.TransactionStatusSealed,
+}
+
+// condition checks whether the awaited state was reached and describes the current state.
+type condition func() (bool, string, error)
+
+func wait(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	interval, err := time.ParseDuration(waitFlags.Interval)
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("invalid --interval %s, provide a positive duration such as 5s", waitFlags.Interval)
+	}
+
+	timeout, err := time.ParseDuration(waitFlags.WaitTimeout)
+	if err != nil || timeout <= 0 {
+		return nil, fmt.Errorf("invalid --wait-timeout %s, provide a positive duration such as 10m", waitFlags.WaitTimeout)
+	}
+
+	description, check, err := waitCondition(waitFlags, readerWriter, flow)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("Waiting for %s...", description))
+
+	start := time.Now()
+	deadline := start.Add(timeout)
+	for {
+		done, state, err := check()
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			return &result{
+				condition: description,
+				state:     state,
+				elapsed:   time.Since(start),
+			}, nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for %s, last state: %s", timeout, description, state)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// waitCondition creates the condition selected by the flags, exactly one condition must be provided.
+func waitCondition(
+	flags flagsWait,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (string, condition, error) {
+	selected := 0
+	for _, set := range []bool{flags.Tx != "", flags.Height != 0, flags.Script != ""} {
+		if set {
+			selected++
+		}
+	}
+	if selected != 1 {
+		return "", nil, fmt.Errorf("provide exactly one of the --tx, --height or --script flags")
+	}
+
+	switch {
+	case flags.Tx != "":
+		status, ok := transactionStatuses[strings.ToLower(flags.Status)]
+		if !ok {
+			return "", nil, fmt.Errorf("invalid --status %s, use one of pending, finalized, executed or sealed", flags.Status)
+		}
+
+		ID := flowsdk.HexToID(strings.TrimPrefix(flags.Tx, "0x"))
+		return fmt.Sprintf("transaction %s to be %s", ID, strings.ToLower(flags.Status)),
+			transactionCondition(flow, ID, status),
+			nil
+
+	case flags.Height != 0:
+		return fmt.Sprintf("block height %d", flags.Height), heightCondition(flow, flags.Height), nil
+
+	default:
+		if flags.Until == "" {
+			return "", nil, fmt.Errorf("the --until flag is required when waiting for a script")
+		}
+
+		code, err := readerWriter.ReadFile(flags.Script)
+		if err != nil {
+			return "", nil, fmt.Errorf("error loading script file: %w", err)
+		}
+
+		var scriptArgs []cadence.Value
+		if flags.ArgsJSON != "" {
+			scriptArgs, err = arguments.ParseJSON(flags.ArgsJSON)
+			if err != nil {
+				return "", nil, fmt.Errorf("error parsing script arguments: %w", err)
+			}
+		}
+
+		script := flowkit.Script{
+			Code:     code,
+			Args:     scriptArgs,
+			Location: flags.Script,
+		}
+		return fmt.Sprintf("script %s to return %s", flags.Script, flags.Until),
+			scriptCondition(flow, script, flags.Until),
+			nil
+	}
+}
+
+// transactionCondition is met once the transaction reaches the status, failed or expired transactions are reported as errors.
+//
+// A transaction which is not found yet, because it was just sent and didn't reach the access node, is waited for.
+func transactionCondition(flow flowkit.Services, ID flowsdk.Identifier, status flowsdk.TransactionStatus) condition {
+	return func() (bool, string, error) {
+		_, txResult, err := flow.GetTransactionByID(context.Background(), ID, false)
+		if errors.Is(err, gateway.ErrNotFound) {
+			return false, "not found", nil
+		}
+		if err != nil {
+			return false, "", err
+		}
+
+		if txResult.Status == flowsdk.TransactionStatusExpired {
+			return false, "", fmt.Errorf("transaction %s expired", ID)
+		}
+		if txResult.Status < status {
+			return false, txResult.Status.String(), nil
+		}
+		if txResult.Error != nil {
+			return false, "", fmt.Errorf("transaction %s failed: %w", ID, txResult.Error)
+		}
+
+		return true, txResult.Status.String(), nil
+	}
+}
+
+// heightCondition is met once the latest sealed block reaches the height.
+func heightCondition(flow flowkit.Services, height uint64) condition {
+	return func() (bool, string, error) {
+		block, err := flow.GetBlock(context.Background(), flowkit.LatestBlockQuery)
+		if err != nil {
+			return false, "", err
+		}
+
+		return block.Height >= height, fmt.Sprintf("height %d", block.Height), nil
+	}
+}
+
+// scriptCondition is met once the script result matches the expected value in the Cadence value format.
+func scriptCondition(flow flowkit.Services, script flowkit.Script, until string) condition {
+	expected := strings.TrimSpace(until)
+
+	return func() (bool, string, error) {
+		value, err := flow.ExecuteScript(context.Background(), script, flowkit.ScriptQuery{Latest: true})
+		if err != nil {
+			return false, "", err
+		}
+
+		return value.String() == expected, value.String(), nil
+	}
+}
+
+type result struct {
+	condition string
+	state     string
+	elapsed   time.Duration
+}
+
+// String converts result to a string.
+func (r *result) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Condition met\n\n", output.SuccessEmoji())
+	_, _ = fmt.Fprintf(writer, "Condition\t %s\n", r.condition)
+	_, _ = fmt.Fprintf(writer, "State\t %s\n", r.state)
+	_, _ = fmt.Fprintf(writer, "Elapsed\t %s\n", r.elapsed.Round(time.Millisecond))
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+// JSON converts result to a JSON.
+func (r *result) JSON() any {
+	return map[string]any{
+		"condition": r.condition,
+		"state":     r.state,
+		"elapsed":   r.elapsed.Seconds(),
+	}
+}
+
+// Oneliner returns result as one liner grep friendly.
+func (r *result) Oneliner() string {
+	return r.state
+}
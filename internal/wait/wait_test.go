@@ -0,0 +1,142 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wait
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Wait(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	t.Run("Transaction Status", func(t *testing.T) {
+		waitFlags = flagsWait{Tx: "0x01", Status: "sealed", Interval: "1ms", WaitTimeout: "1m"}
+
+		statuses := []flow.TransactionStatus{
+			flow.TransactionStatusUnknown,
+			flow.TransactionStatusPending,
+			flow.TransactionStatusExecuted,
+			flow.TransactionStatusSealed,
+		}
+		calls := 0
+		srv.GetTransactionByID.Run(func(args mock.Arguments) {
+			assert.Equal(t, flow.HexToID("01"), args.Get(1).(flow.Identifier))
+			txResult := tests.NewTransactionResult(nil)
+			txResult.Status = statuses[calls]
+			calls++
+			// the transaction is not found until it reaches the access node
+			if txResult.Status == flow.TransactionStatusUnknown {
+				srv.GetTransactionByID.Return(nil, nil, fmt.Errorf("transaction: %w", gateway.ErrNotFound))
+				return
+			}
+			srv.GetTransactionByID.Return(tests.NewTransaction(), txResult, nil)
+		})
+
+		res, err := wait([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, 4, calls)
+		assert.Equal(t, "SEALED", res.Oneliner())
+	})
+
+	t.Run("Fail Transaction Error", func(t *testing.T) {
+		waitFlags = flagsWait{Tx: "0x01", Status: "executed", Interval: "1ms", WaitTimeout: "1m"}
+
+		srv.GetTransactionByID.Run(func(args mock.Arguments) {
+			txResult := tests.NewTransactionResult(nil)
+			txResult.Status = flow.TransactionStatusSealed
+			txResult.Error = fmt.Errorf("panic")
+			srv.GetTransactionByID.Return(tests.NewTransaction(), txResult, nil)
+		})
+
+		_, err := wait([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "failed: panic")
+	})
+
+	t.Run("Block Height", func(t *testing.T) {
+		waitFlags = flagsWait{Height: 10, Interval: "1ms", WaitTimeout: "1m"}
+
+		height := uint64(8)
+		srv.GetBlock.Run(func(args mock.Arguments) {
+			block := tests.NewBlock()
+			block.Height = height
+			height++
+			srv.GetBlock.Return(block, nil)
+		})
+
+		res, err := wait([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "height 10", res.Oneliner())
+	})
+
+	t.Run("Script Result", func(t *testing.T) {
+		waitFlags = flagsWait{Script: tests.ScriptArgString.Filename, Until: `"done"`, Interval: "1ms", WaitTimeout: "1m"}
+
+		values := []string{"pending", "done"}
+		calls := 0
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			assert.Equal(t, tests.ScriptArgString.Source, script.Code)
+			srv.ExecuteScript.Return(cadence.String(values[calls]), nil)
+			calls++
+		})
+
+		res, err := wait([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, `"done"`, res.Oneliner())
+	})
+
+	t.Run("Fail Timeout", func(t *testing.T) {
+		waitFlags = flagsWait{Height: 100, Interval: "1ms", WaitTimeout: "5ms"}
+
+		srv.GetBlock.Run(func(args mock.Arguments) {
+			srv.GetBlock.Return(tests.NewBlock(), nil)
+		})
+
+		_, err := wait([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "timed out after 5ms waiting for block height 100")
+	})
+
+	t.Run("Fail Multiple Conditions", func(t *testing.T) {
+		waitFlags = flagsWait{Tx: "0x01", Height: 10, Interval: "5s", WaitTimeout: "10m"}
+
+		_, err := wait([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "provide exactly one of the --tx, --height or --script flags")
+	})
+
+	t.Run("Fail Invalid Status", func(t *testing.T) {
+		waitFlags = flagsWait{Tx: "0x01", Status: "done", Interval: "5s", WaitTimeout: "10m"}
+
+		_, err := wait([]string{}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "invalid --status done")
+	})
+}